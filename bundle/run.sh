@@ -13,6 +13,7 @@ export NGINX_PATH="/data/nginx"
 export JETSTREAM_PATH="/data/jetstream"
 
 export CERTIFICATE_AUTHORITY_ADDRESS="localhost:${CERTIFICATE_AUTHORITY_PORT}"
+export CERTIFICATE_AUTHORITY_HTTP_ADDRESS="localhost:${CERTIFICATE_AUTHORITY_HTTP_PORT}"
 export MOCK_OAUTH_SERVER_ADDRESS="localhost:${MOCK_OAUTH_SERVER_PORT}"
 export RESOURCE_AGGREGATE_ADDRESS="localhost:${RESOURCE_AGGREGATE_PORT}"
 export RESOURCE_DIRECTORY_ADDRESS="localhost:${RESOURCE_DIRECTORY_PORT}"
@@ -379,7 +380,7 @@ fi
 echo "starting mongod"
 cat $DIAL_FILE_CERT_DIR_PATH/$DIAL_FILE_CERT_NAME > $DIAL_FILE_CERT_DIR_PATH/mongo.key
 cat $DIAL_FILE_CERT_DIR_PATH/$DIAL_FILE_CERT_KEY_NAME >> $DIAL_FILE_CERT_DIR_PATH/mongo.key
-mongod --setParameter maxNumActiveUserIndexBuilds=64 --port $MONGO_PORT --dbpath $MONGO_PATH --sslMode requireSSL --sslCAFile $CA_POOL_CERT_PATH --sslPEMKeyFile $DIAL_FILE_CERT_DIR_PATH/mongo.key >$LOGS_PATH/mongod.log 2>&1 &
+mongod --setParameter maxNumActiveUserIndexBuilds=64 --replSet rs0 --port $MONGO_PORT --dbpath $MONGO_PATH --sslMode requireSSL --sslCAFile $CA_POOL_CERT_PATH --sslPEMKeyFile $DIAL_FILE_CERT_DIR_PATH/mongo.key >$LOGS_PATH/mongod.log 2>&1 &
 status=$?
 mongo_pid=$!
 if [ $status -ne 0 ]; then
@@ -400,6 +401,10 @@ while true; do
   sleep 1
 done
 
+# the identity-store stores its events atomically with its data by transactions of a replica set; a standalone mongod works, but without the atomicity.
+# Existing data of a standalone mongod are kept, the replica set is initialized once (next starts only report that it is already initialized).
+mongo --quiet --port $MONGO_PORT --tls --tlsCAFile $CA_POOL_CERT_PATH --tlsCertificateKeyFile $DIAL_FILE_CERT_DIR_PATH/mongo.key --eval "rs.initiate({_id: 'rs0', members: [{_id: 0, host: '${MONGODB_HOST}'}]})" >>$LOGS_PATH/mongod.log 2>&1
+
 # starting nginx
 echo "starting nginx"
 nginx -c $NGINX_PATH/nginx.conf >$LOGS_PATH/nginx.log 2>&1
@@ -723,6 +728,9 @@ cat /configs/certificate-authority.yaml | yq e "\
   .apis.grpc.authorization.http.tls.useSystemCAPool = true |
   .apis.grpc.authorization.authority = \"https://${OAUTH_ENDPOINT}\" |
   .apis.grpc.authorization.ownerClaim = \"${OWNER_CLAIM}\" |
+  .apis.http.address = \"${CERTIFICATE_AUTHORITY_HTTP_ADDRESS}\" |
+  .clients.storage.mongoDB.uri = \"${MONGODB_URI}\" |
+  .clients.identityStore.grpc.address = \"${IDENTITY_STORE_ADDRESS}\" |
   .signer.keyFile = \"${CA_POOL_CERT_KEY_PATH}\" |
   .signer.certFile = \"${CA_POOL_CERT_PATH}\"
 " - > /data/certificate-authority.yaml
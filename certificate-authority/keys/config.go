@@ -0,0 +1,57 @@
+package keys
+
+import (
+	"fmt"
+)
+
+type Backend string
+
+const (
+	FileBackend   Backend = "file"
+	PKCS11Backend Backend = "pkcs11"
+)
+
+type Config struct {
+	Backend Backend      `yaml:"backend" json:"backend" description:"file or pkcs11, default file"`
+	KeyFile string       `yaml:"keyFile" json:"keyFile" description:"file name of CA private key in PEM format, used by the file backend"`
+	PKCS11  PKCS11Config `yaml:"pkcs11" json:"pkcs11"`
+}
+
+func (c *Config) Validate() error {
+	switch c.Backend {
+	case "", FileBackend:
+		if c.KeyFile == "" {
+			return fmt.Errorf("keyFile('%v')", c.KeyFile)
+		}
+	case PKCS11Backend:
+		if err := c.PKCS11.Validate(); err != nil {
+			return fmt.Errorf("pkcs11.%w", err)
+		}
+	default:
+		return fmt.Errorf("backend('%v')", c.Backend)
+	}
+	return nil
+}
+
+type PKCS11Config struct {
+	Module     string `yaml:"module" json:"module" description:"path to the PKCS#11 module library"`
+	TokenLabel string `yaml:"tokenLabel" json:"tokenLabel"`
+	PinFile    string `yaml:"pinFile" json:"pinFile" description:"file name with the user PIN of the token"`
+	KeyLabel   string `yaml:"keyLabel" json:"keyLabel" description:"label of the CA key pair stored in the token"`
+}
+
+func (c *PKCS11Config) Validate() error {
+	if c.Module == "" {
+		return fmt.Errorf("module('%v')", c.Module)
+	}
+	if c.TokenLabel == "" {
+		return fmt.Errorf("tokenLabel('%v')", c.TokenLabel)
+	}
+	if c.PinFile == "" {
+		return fmt.Errorf("pinFile('%v')", c.PinFile)
+	}
+	if c.KeyLabel == "" {
+		return fmt.Errorf("keyLabel('%v')", c.KeyLabel)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package keys
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/plgd-dev/kit/v2/security"
+)
+
+type fileSigner struct {
+	crypto.Signer
+}
+
+// NewFileSigner loads the private key in the PEM format from the file.
+func NewFileSigner(keyFile string) (Signer, error) {
+	privateKey, err := security.LoadX509PrivateKey(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load private key from file %v: %w", keyFile, err)
+	}
+	return &fileSigner{Signer: privateKey}, nil
+}
+
+func (s *fileSigner) Close() error {
+	return nil
+}
@@ -0,0 +1,24 @@
+package keys
+
+import (
+	"crypto"
+	"fmt"
+)
+
+// Signer signs by the CA private key. Depending on the backend, the private key
+// doesn't need to be present in the process memory.
+type Signer interface {
+	crypto.Signer
+	Close() error
+}
+
+// New creates the signer for the configured backend.
+func New(cfg Config) (Signer, error) {
+	switch cfg.Backend {
+	case "", FileBackend:
+		return NewFileSigner(cfg.KeyFile)
+	case PKCS11Backend:
+		return NewPKCS11Signer(cfg.PKCS11)
+	}
+	return nil, fmt.Errorf("unsupported backend '%v'", cfg.Backend)
+}
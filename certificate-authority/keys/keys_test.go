@@ -0,0 +1,102 @@
+package keys_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/certificate-authority/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func checkSigner(t *testing.T, signer keys.Signer) {
+	digest := sha256.Sum256([]byte("data"))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	publicKey, ok := signer.Public().(*ecdsa.PublicKey)
+	require.True(t, ok)
+	require.True(t, ecdsa.VerifyASN1(publicKey, digest[:], signature))
+}
+
+func TestNewFileSigner(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)
+	require.NoError(t, err)
+
+	signer, err := keys.New(keys.Config{KeyFile: keyFile})
+	require.NoError(t, err)
+	defer func() {
+		_ = signer.Close()
+	}()
+	require.True(t, privateKey.PublicKey.Equal(signer.Public()))
+	checkSigner(t, signer)
+
+	_, err = keys.New(keys.Config{KeyFile: filepath.Join(t.TempDir(), "notExist.pem")})
+	require.Error(t, err)
+}
+
+// TestNewPKCS11Signer requires an initialized token, e.g. by SoftHSM:
+// softhsm2-util --init-token --free --label test --pin 1234 --so-pin 1234
+func TestNewPKCS11Signer(t *testing.T) {
+	module := os.Getenv("TEST_PKCS11_MODULE")
+	if module == "" {
+		t.Skip("TEST_PKCS11_MODULE is not set")
+	}
+	tokenLabel := os.Getenv("TEST_PKCS11_TOKEN_LABEL")
+	pin := os.Getenv("TEST_PKCS11_PIN")
+	keyLabel := uuid.NewString()
+
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       module,
+		TokenLabel: tokenLabel,
+		Pin:        pin,
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = ctx.Close()
+	}()
+	keyPair, err := ctx.GenerateECDSAKeyPairWithLabel([]byte(keyLabel), []byte(keyLabel), elliptic.P256())
+	require.NoError(t, err)
+	defer func() {
+		_ = keyPair.Delete()
+	}()
+
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	err = ioutil.WriteFile(pinFile, []byte(pin), 0600)
+	require.NoError(t, err)
+	cfg := keys.Config{
+		Backend: keys.PKCS11Backend,
+		PKCS11: keys.PKCS11Config{
+			Module:     module,
+			TokenLabel: tokenLabel,
+			PinFile:    pinFile,
+			KeyLabel:   keyLabel,
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	signer, err := keys.New(cfg)
+	require.NoError(t, err)
+	defer func() {
+		_ = signer.Close()
+	}()
+	require.True(t, keyPair.Public().(*ecdsa.PublicKey).Equal(signer.Public()))
+	checkSigner(t, signer)
+
+	cfg.PKCS11.KeyLabel = uuid.NewString()
+	_, err = keys.New(cfg)
+	require.Error(t, err)
+}
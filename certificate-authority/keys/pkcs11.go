@@ -0,0 +1,47 @@
+// +build cgo
+
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ThalesIgnite/crypto11"
+)
+
+type pkcs11Signer struct {
+	crypto11.Signer
+	ctx *crypto11.Context
+}
+
+// NewPKCS11Signer finds the key pair in the token, the private key never leaves the token.
+func NewPKCS11Signer(cfg PKCS11Config) (Signer, error) {
+	pin, err := ioutil.ReadFile(cfg.PinFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read pin file %v: %w", cfg.PinFile, err)
+	}
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       cfg.Module,
+		TokenLabel: cfg.TokenLabel,
+		Pin:        strings.TrimSpace(string(pin)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open token %v: %w", cfg.TokenLabel, err)
+	}
+	signer, err := ctx.FindKeyPair(nil, []byte(cfg.KeyLabel))
+	if err == nil && signer == nil {
+		err = fmt.Errorf("not found")
+	}
+	if err != nil {
+		if errClose := ctx.Close(); errClose != nil {
+			err = fmt.Errorf("%w, cannot close token: %v", err, errClose)
+		}
+		return nil, fmt.Errorf("cannot find key pair %v: %w", cfg.KeyLabel, err)
+	}
+	return &pkcs11Signer{Signer: signer, ctx: ctx}, nil
+}
+
+func (s *pkcs11Signer) Close() error {
+	return s.ctx.Close()
+}
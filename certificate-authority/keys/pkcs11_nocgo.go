@@ -0,0 +1,12 @@
+// +build !cgo
+
+package keys
+
+import (
+	"fmt"
+)
+
+// NewPKCS11Signer is not supported without cgo.
+func NewPKCS11Signer(cfg PKCS11Config) (Signer, error) {
+	return nil, fmt.Errorf("pkcs11 backend requires cgo")
+}
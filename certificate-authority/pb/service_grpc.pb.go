@@ -24,6 +24,11 @@ type CertificateAuthorityClient interface {
 	// SignCertificate sends a Certificate Signing Request to the certificate authority
 	// and obtains a signed certificate. Both in the PEM format.
 	SignCertificate(ctx context.Context, in *SignCertificateRequest, opts ...grpc.CallOption) (*SignCertificateResponse, error)
+	// GetSigningRecords returns the certificates issued to the owner of the access token.
+	GetSigningRecords(ctx context.Context, in *GetSigningRecordsRequest, opts ...grpc.CallOption) (CertificateAuthority_GetSigningRecordsClient, error)
+	// RevokeCertificates revokes the certificates issued to the owner of the access token which
+	// match the filter. Revoked certificates are published by the CRL distribution endpoint.
+	RevokeCertificates(ctx context.Context, in *RevokeCertificatesRequest, opts ...grpc.CallOption) (*RevokeCertificatesResponse, error)
 }
 
 type certificateAuthorityClient struct {
@@ -52,6 +57,47 @@ func (c *certificateAuthorityClient) SignCertificate(ctx context.Context, in *Si
 	return out, nil
 }
 
+func (c *certificateAuthorityClient) GetSigningRecords(ctx context.Context, in *GetSigningRecordsRequest, opts ...grpc.CallOption) (CertificateAuthority_GetSigningRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CertificateAuthority_ServiceDesc.Streams[0], "/certificateauthority.pb.CertificateAuthority/GetSigningRecords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &certificateAuthorityGetSigningRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CertificateAuthority_GetSigningRecordsClient interface {
+	Recv() (*SigningRecord, error)
+	grpc.ClientStream
+}
+
+type certificateAuthorityGetSigningRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *certificateAuthorityGetSigningRecordsClient) Recv() (*SigningRecord, error) {
+	m := new(SigningRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *certificateAuthorityClient) RevokeCertificates(ctx context.Context, in *RevokeCertificatesRequest, opts ...grpc.CallOption) (*RevokeCertificatesResponse, error) {
+	out := new(RevokeCertificatesResponse)
+	err := c.cc.Invoke(ctx, "/certificateauthority.pb.CertificateAuthority/RevokeCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CertificateAuthorityServer is the server API for CertificateAuthority service.
 // All implementations must embed UnimplementedCertificateAuthorityServer
 // for forward compatibility
@@ -62,6 +108,11 @@ type CertificateAuthorityServer interface {
 	// SignCertificate sends a Certificate Signing Request to the certificate authority
 	// and obtains a signed certificate. Both in the PEM format.
 	SignCertificate(context.Context, *SignCertificateRequest) (*SignCertificateResponse, error)
+	// GetSigningRecords returns the certificates issued to the owner of the access token.
+	GetSigningRecords(*GetSigningRecordsRequest, CertificateAuthority_GetSigningRecordsServer) error
+	// RevokeCertificates revokes the certificates issued to the owner of the access token which
+	// match the filter. Revoked certificates are published by the CRL distribution endpoint.
+	RevokeCertificates(context.Context, *RevokeCertificatesRequest) (*RevokeCertificatesResponse, error)
 	mustEmbedUnimplementedCertificateAuthorityServer()
 }
 
@@ -75,6 +126,12 @@ func (UnimplementedCertificateAuthorityServer) SignIdentityCertificate(context.C
 func (UnimplementedCertificateAuthorityServer) SignCertificate(context.Context, *SignCertificateRequest) (*SignCertificateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignCertificate not implemented")
 }
+func (UnimplementedCertificateAuthorityServer) GetSigningRecords(*GetSigningRecordsRequest, CertificateAuthority_GetSigningRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetSigningRecords not implemented")
+}
+func (UnimplementedCertificateAuthorityServer) RevokeCertificates(context.Context, *RevokeCertificatesRequest) (*RevokeCertificatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificates not implemented")
+}
 func (UnimplementedCertificateAuthorityServer) mustEmbedUnimplementedCertificateAuthorityServer() {}
 
 // UnsafeCertificateAuthorityServer may be embedded to opt out of forward compatibility for this service.
@@ -124,6 +181,45 @@ func _CertificateAuthority_SignCertificate_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CertificateAuthority_GetSigningRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSigningRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CertificateAuthorityServer).GetSigningRecords(m, &certificateAuthorityGetSigningRecordsServer{stream})
+}
+
+type CertificateAuthority_GetSigningRecordsServer interface {
+	Send(*SigningRecord) error
+	grpc.ServerStream
+}
+
+type certificateAuthorityGetSigningRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *certificateAuthorityGetSigningRecordsServer) Send(m *SigningRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CertificateAuthority_RevokeCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificateAuthorityServer).RevokeCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certificateauthority.pb.CertificateAuthority/RevokeCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificateAuthorityServer).RevokeCertificates(ctx, req.(*RevokeCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CertificateAuthority_ServiceDesc is the grpc.ServiceDesc for CertificateAuthority service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -139,7 +235,17 @@ var CertificateAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SignCertificate",
 			Handler:    _CertificateAuthority_SignCertificate_Handler,
 		},
+		{
+			MethodName: "RevokeCertificates",
+			Handler:    _CertificateAuthority_RevokeCertificates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetSigningRecords",
+			Handler:       _CertificateAuthority_GetSigningRecords_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/plgd-dev/hub/certificate-authority/pb/service.proto",
 }
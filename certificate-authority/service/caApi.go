@@ -8,7 +8,11 @@ import (
 	"time"
 
 	"github.com/karrick/tparse/v2"
+	"github.com/plgd-dev/hub/certificate-authority/keys"
 	"github.com/plgd-dev/hub/certificate-authority/pb"
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
 	"github.com/plgd-dev/kit/v2/security"
 	"google.golang.org/grpc"
@@ -26,15 +30,30 @@ type RequestHandler struct {
 	ValidFor    time.Duration
 	Certificate []*x509.Certificate
 	PrivateKey  crypto.PrivateKey
+	store       store.Store
+	isClient    pbIS.IdentityStoreClient
+	ownerClaim  string
 }
 
-func AddHandler(svr *server.Server, cfg SignerConfig) error {
-	handler, err := NewRequestHandlerFromConfig(cfg)
+func AddHandler(svr *server.Server, cfg SignerConfig, store store.Store, isClient pbIS.IdentityStoreClient, ownerClaim string) (*RequestHandler, error) {
+	key, err := keys.New(cfg.Config)
 	if err != nil {
-		return fmt.Errorf("could not create plgd-dev/certificate-authority: %w", err)
+		return nil, fmt.Errorf("could not create plgd-dev/certificate-authority: cannot create signer: %w", err)
 	}
+	handler, err := NewRequestHandlerFromConfig(cfg, key, store, isClient, ownerClaim)
+	if err != nil {
+		if errClose := key.Close(); errClose != nil {
+			log.Errorf("cannot close signer: %w", errClose)
+		}
+		return nil, fmt.Errorf("could not create plgd-dev/certificate-authority: %w", err)
+	}
+	svr.AddCloseFunc(func() {
+		if err := key.Close(); err != nil {
+			log.Errorf("cannot close signer: %w", err)
+		}
+	})
 	pb.RegisterCertificateAuthorityServer(svr.Server, handler)
-	return nil
+	return handler, nil
 }
 
 // Register registers the handler instance with a gRPC server.
@@ -42,20 +61,25 @@ func Register(server *grpc.Server, handler *RequestHandler) {
 	pb.RegisterCertificateAuthorityServer(server, handler)
 }
 
-func NewRequestHandlerFromConfig(cfg SignerConfig) (*RequestHandler, error) {
+// NewRequestHandlerFromConfig creates the handler which signs certificates by the key,
+// the key must belong to the CA certificate.
+func NewRequestHandlerFromConfig(cfg SignerConfig, key crypto.Signer, store store.Store, isClient pbIS.IdentityStoreClient, ownerClaim string) (*RequestHandler, error) {
 	chainCerts, err := security.LoadX509(cfg.CertFile)
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := security.LoadX509PrivateKey(cfg.KeyFile)
-	if err != nil {
-		return nil, err
+	if len(chainCerts) == 0 {
+		return nil, fmt.Errorf("certificate not found in %v", cfg.CertFile)
+	}
+	publicKey, ok := chainCerts[0].PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(key.Public()) {
+		return nil, fmt.Errorf("key doesn't match the certificate %v", cfg.CertFile)
 	}
 
 	return NewRequestHandler(func() time.Time {
 		t, _ := tparse.ParseNow(time.RFC3339, cfg.ValidFrom)
 		return t
-	}, cfg.ExpiresIn, chainCerts, privateKey), nil
+	}, cfg.ExpiresIn, chainCerts, key, store, isClient, ownerClaim), nil
 }
 
 // NewRequestHandler factory for new RequestHandler.
@@ -63,11 +87,17 @@ func NewRequestHandler(
 	ValidFrom func() time.Time,
 	ValidFor time.Duration,
 	Certificate []*x509.Certificate,
-	PrivateKey crypto.PrivateKey) *RequestHandler {
+	PrivateKey crypto.PrivateKey,
+	store store.Store,
+	isClient pbIS.IdentityStoreClient,
+	ownerClaim string) *RequestHandler {
 	return &RequestHandler{
 		ValidFrom:   ValidFrom,
 		ValidFor:    ValidFor,
 		Certificate: Certificate,
 		PrivateKey:  PrivateKey,
+		store:       store,
+		isClient:    isClient,
+		ownerClaim:  ownerClaim,
 	}
 }
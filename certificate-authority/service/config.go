@@ -5,55 +5,129 @@ import (
 	"time"
 
 	"github.com/karrick/tparse/v2"
+	"github.com/plgd-dev/hub/certificate-authority/keys"
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
+	"github.com/plgd-dev/hub/pkg/mongodb"
+	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 )
 
 type Config struct {
-	Log    log.Config   `yaml:"log" json:"log"`
-	APIs   APIsConfig   `yaml:"apis" json:"apis"`
-	Signer SignerConfig `yaml:"signer" json:"signer"`
+	Log     log.Config    `yaml:"log" json:"log"`
+	APIs    APIsConfig    `yaml:"apis" json:"apis"`
+	Clients ClientsConfig `yaml:"clients" json:"clients"`
+	Signer  SignerConfig  `yaml:"signer" json:"signer"`
 }
 
 func (c *Config) Validate() error {
 	if err := c.APIs.Validate(); err != nil {
 		return fmt.Errorf("apis.%w", err)
 	}
-	if err := c.Signer.Validate(); err != nil {
+	if err := c.Clients.Validate(); err != nil {
 		return fmt.Errorf("clients.%w", err)
 	}
+	if err := c.Signer.Validate(); err != nil {
+		return fmt.Errorf("signer.%w", err)
+	}
 	return nil
 }
 
 // Config represent application configuration
 type APIsConfig struct {
-	GRPC server.Config `yaml:"grpc" json:"grpc"`
+	GRPC    server.Config  `yaml:"grpc" json:"grpc"`
+	HTTP    HTTPConfig     `yaml:"http" json:"http"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.GRPC.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if err := c.HTTP.Validate(); err != nil {
+		return fmt.Errorf("http.%w", err)
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
+	return nil
+}
+
+type HTTPConfig struct {
+	Connection   listener.Config `yaml:",inline" json:",inline"`
+	CRLExpiresIn time.Duration   `yaml:"crlExpiresIn" json:"crlExpiresIn" description:"validity of the certificate revocation list published by the CRL distribution endpoint"`
+}
+
+func (c *HTTPConfig) Validate() error {
+	if err := c.Connection.Validate(); err != nil {
+		return err
+	}
+	if c.CRLExpiresIn <= 0 {
+		return fmt.Errorf("crlExpiresIn('%v')", c.CRLExpiresIn)
+	}
+	return nil
+}
+
+type ClientsConfig struct {
+	Storage                StorageConfig        `yaml:"storage" json:"storage"`
+	IdentityStore          IdentityStoreConfig  `yaml:"identityStore" json:"identityStore"`
+	OpenTelemetryCollector opentelemetry.Config `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
+}
+
+func (c *ClientsConfig) Validate() error {
+	if err := c.Storage.Validate(); err != nil {
+		return fmt.Errorf("storage.%w", err)
+	}
+	if err := c.IdentityStore.Validate(); err != nil {
+		return fmt.Errorf("identityStore.%w", err)
+	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
+	return nil
+}
+
+type IdentityStoreConfig struct {
+	Connection client.Config `yaml:"grpc" json:"grpc"`
+}
+
+func (c *IdentityStoreConfig) Validate() error {
+	if err := c.Connection.Validate(); err != nil {
+		return fmt.Errorf("grpc.%w", err)
+	}
+	return nil
+}
+
+type StorageConfig struct {
+	MongoDB mongodb.Config `yaml:"mongoDB" json:"mongoDB"`
+}
+
+func (c *StorageConfig) Validate() error {
+	if err := c.MongoDB.Validate(); err != nil {
+		return fmt.Errorf("mongoDB.%w", err)
+	}
 	return nil
 }
 
 type SignerConfig struct {
-	KeyFile   string        `yaml:"keyFile" json:"keyFile" description:"file name of CA private key in PEM format"`
-	CertFile  string        `yaml:"certFile" json:"certFile" description:"file name of CA certificate in PEM format"`
-	ValidFrom string        `yaml:"validFrom" json:"validFrom" description:"format https://github.com/karrick/tparse"`
-	ExpiresIn time.Duration `yaml:"expiresIn" json:"expiresIn"`
+	keys.Config `yaml:",inline" json:",inline"`
+	CertFile    string        `yaml:"certFile" json:"certFile" description:"file name of CA certificate in PEM format"`
+	ValidFrom   string        `yaml:"validFrom" json:"validFrom" description:"format https://github.com/karrick/tparse"`
+	ExpiresIn   time.Duration `yaml:"expiresIn" json:"expiresIn"`
 }
 
 func (c *SignerConfig) Validate() error {
 	if c.CertFile == "" {
 		return fmt.Errorf("certFile('%v')", c.CertFile)
 	}
-	if c.KeyFile == "" {
-		return fmt.Errorf("keyFile('%v')", c.KeyFile)
+	if err := c.Config.Validate(); err != nil {
+		return err
 	}
 	if c.ExpiresIn <= 0 {
-		return fmt.Errorf("expiresIn('%v')", c.KeyFile)
+		return fmt.Errorf("expiresIn('%v')", c.ExpiresIn)
 	}
 	_, err := tparse.ParseNow(time.RFC3339, c.ValidFrom)
 	if err != nil {
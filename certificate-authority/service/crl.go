@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	"github.com/plgd-dev/hub/certificate-authority/uri"
+	"github.com/plgd-dev/hub/pkg/log"
+)
+
+const contentTypePkixCRL = "application/pkix-crl"
+
+type revokedCertificatesHandler struct {
+	now     time.Time
+	revoked []pkix.RevokedCertificate
+}
+
+func (h *revokedCertificatesHandler) Handle(ctx context.Context, iter store.SigningRecordIter) error {
+	var record store.SigningRecord
+	for iter.Next(ctx, &record) {
+		if record.ValidUntil < h.now.UnixNano() {
+			// expired certificates don't need to be published
+			continue
+		}
+		serialNumber, ok := new(big.Int).SetString(record.SerialNumber, 10)
+		if !ok {
+			return fmt.Errorf("invalid serial number '%v'", record.SerialNumber)
+		}
+		h.revoked = append(h.revoked, pkix.RevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: time.Unix(0, record.RevocationDate),
+		})
+	}
+	return iter.Err()
+}
+
+// CreateCRL creates the certificate revocation list signed by the CA in the DER format.
+func (r *RequestHandler) CreateCRL(ctx context.Context, expiresIn time.Duration) ([]byte, error) {
+	h := revokedCertificatesHandler{
+		now: time.Now(),
+	}
+	if err := r.store.LoadSigningRecords(ctx, store.SigningRecordsQuery{RevokedOnly: true}, &h); err != nil {
+		return nil, fmt.Errorf("cannot load revoked certificates: %w", err)
+	}
+	crl, err := r.Certificate[0].CreateCRL(rand.Reader, r.PrivateKey, h.revoked, h.now, h.now.Add(expiresIn))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CRL: %w", err)
+	}
+	return crl, nil
+}
+
+func writeCRLError(w http.ResponseWriter, err error) {
+	log.Errorf("%v", err)
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusInternalServerError)
+	if _, err2 := w.Write([]byte(err.Error())); err2 != nil {
+		log.Errorf("failed to write error response body: %w", err2)
+	}
+}
+
+// NewHTTP creates the HTTP server with the CRL distribution endpoint.
+func NewHTTP(requestHandler *RequestHandler, crlExpiresIn time.Duration) *http.Server {
+	r := mux.NewRouter()
+	r.HandleFunc(uri.CRL, func(w http.ResponseWriter, req *http.Request) {
+		crl, err := requestHandler.CreateCRL(req.Context(), crlExpiresIn)
+		if err != nil {
+			writeCRLError(w, fmt.Errorf("cannot get CRL: %w", err))
+			return
+		}
+		w.Header().Set("Content-Type", contentTypePkixCRL)
+		if _, err := w.Write(crl); err != nil {
+			log.Errorf("failed to write CRL: %w", err)
+		}
+	}).Methods(http.MethodGet)
+	return &http.Server{Handler: r}
+}
@@ -2,16 +2,58 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 
+	"github.com/plgd-dev/hub/certificate-authority/store/mongodb"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
-
+	"github.com/plgd-dev/hub/pkg/metrics"
+	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
+	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 )
 
 type Service struct {
-	*server.Server
+	grpcServer *server.Server
+	httpServer *http.Server
+	listener   *listener.Server
+}
+
+func newStore(ctx context.Context, config StorageConfig, logger log.Logger) (*mongodb.Store, func(), error) {
+	certManager, err := cmClient.New(config.MongoDB.TLS, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create cert manager: %w", err)
+	}
+	store, err := mongodb.NewStore(ctx, config.MongoDB, certManager.GetTLSConfig())
+	if err != nil {
+		certManager.Close()
+		return nil, nil, fmt.Errorf("cannot create mongodb store: %w", err)
+	}
+	return store, func() {
+		if err := store.Close(ctx); err != nil {
+			log.Errorf("failed to close mongodb store: %w", err)
+		}
+		certManager.Close()
+	}, nil
+}
+
+func newIdentityStoreClient(config IdentityStoreConfig, logger log.Logger) (pbIS.IdentityStoreClient, func(), error) {
+	isConn, err := client.New(config.Connection, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot connect to identity-store: %w", err)
+	}
+	closeIsConn := func() {
+		if err := isConn.Close(); err != nil {
+			logger.Errorf("error occurs during close connection to identity-store: %w", err)
+		}
+	}
+	return pbIS.NewIdentityStoreClient(isConn.GRPC()), closeIsConn, nil
 }
 
 func New(ctx context.Context, config Config, logger log.Logger) (*Service, error) {
@@ -19,7 +61,7 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 	if err != nil {
 		return nil, fmt.Errorf("cannot create validator: %w", err)
 	}
-	opts, err := server.MakeDefaultOptions(server.NewAuth(validator), logger)
+	opts, err := server.MakeDefaultOptions(server.NewAuth(validator, server.WithRequiredScopes(config.APIs.GRPC.Authorization.RequiredScopes)), logger)
 	if err != nil {
 		validator.Close()
 		return nil, fmt.Errorf("cannot create grpc server options: %w", err)
@@ -32,13 +74,84 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 	}
 	server.AddCloseFunc(validator.Close)
 
-	err = AddHandler(server, config.Signer)
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	server.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "certificate-authority", logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	server.AddCloseFunc(otelProvider.Close)
+
+	store, closeStore, err := newStore(ctx, config.Clients.Storage, logger)
 	if err != nil {
 		server.Close()
 		return nil, err
 	}
+	server.AddCloseFunc(closeStore)
+
+	isClient, closeIsClient, err := newIdentityStoreClient(config.Clients.IdentityStore, logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create identity-store client: %w", err)
+	}
+	server.AddCloseFunc(closeIsClient)
+
+	handler, err := AddHandler(server, config.Signer, store, isClient, config.APIs.GRPC.Authorization.OwnerClaim)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	listener, err := listener.New(config.APIs.HTTP.Connection, logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create http server: %w", err)
+	}
 
 	return &Service{
-		Server: server,
+		grpcServer: server,
+		httpServer: NewHTTP(handler, config.APIs.HTTP.CRLExpiresIn),
+		listener:   listener,
 	}, nil
 }
+
+// Serve starts the service's GRPC and HTTP server and blocks.
+func (s *Service) Serve() error {
+	var wg sync.WaitGroup
+	var httpErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		httpErr = s.httpServer.Serve(s.listener)
+		if !errors.Is(httpErr, http.ErrServerClosed) {
+			// the grpc server cannot outlive the failed http server
+			s.grpcServer.Stop()
+		}
+	}()
+	err := s.grpcServer.Serve()
+	if errHttp := s.httpServer.Close(); errHttp != nil {
+		log.Errorf("cannot close http server: %w", errHttp)
+	}
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	if !errors.Is(httpErr, http.ErrServerClosed) {
+		return fmt.Errorf("serving http failed: %w", httpErr)
+	}
+	return nil
+}
+
+// Close stops the GRPC and HTTP server.
+func (s *Service) Close() {
+	if err := s.httpServer.Close(); err != nil {
+		log.Errorf("cannot close http server: %w", err)
+	}
+	s.grpcServer.Close()
+}
@@ -11,7 +11,10 @@ import (
 )
 
 func (r *RequestHandler) SignCertificate(ctx context.Context, req *pb.SignCertificateRequest) (*pb.SignCertificateResponse, error) {
-
+	owner, err := r.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
 	notBefore := r.ValidFrom()
 	notAfter := notBefore.Add(r.ValidFor)
 	signer := signer.NewBasicCertificateSigner(r.Certificate, r.PrivateKey, notBefore, notAfter)
@@ -19,6 +22,9 @@ func (r *RequestHandler) SignCertificate(ctx context.Context, req *pb.SignCertif
 	if err != nil {
 		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot sign certificate: %v", err))
 	}
+	if err := r.storeSigningRecord(ctx, owner, cert, false); err != nil {
+		return nil, err
+	}
 	log.Debugf("RequestHandler.SignCertificate csr=%v crt=%v", string(req.CertificateSigningRequest), string(cert))
 
 	return &pb.SignCertificateResponse{
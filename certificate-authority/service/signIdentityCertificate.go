@@ -2,22 +2,114 @@ package service
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/plgd-dev/hub/certificate-authority/pb"
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/kit/v2/security/signer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// getDeviceIDFromCSR returns the device ID from the common name ("uuid:<deviceID>") of the CSR.
+func getDeviceIDFromCSR(csr []byte) (string, error) {
+	block, _ := pem.Decode(csr)
+	if block == nil {
+		return "", fmt.Errorf("invalid PEM block")
+	}
+	certificateRequest, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	cn := strings.Split(certificateRequest.Subject.CommonName, ":")
+	if len(cn) != 2 || strings.ToLower(cn[0]) != "uuid" {
+		return "", fmt.Errorf("invalid subject common name: %v", certificateRequest.Subject.CommonName)
+	}
+	deviceID, err := uuid.Parse(cn[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid subject common name %v: %w", certificateRequest.Subject.CommonName, err)
+	}
+	return deviceID.String(), nil
+}
+
+// checkDeviceOwner verifies that the device belongs to the owner. The identity-store checks without registering the device
+// that the device doesn't belong to another user, and the device is bound to the owner until validUntil in the CA's records,
+// which fails when another owner holds a not revoked and not expired identity certificate of the device. It returns true
+// when the device is already registered to the owner in the identity-store.
+func (r *RequestHandler) checkDeviceOwner(ctx context.Context, owner, deviceID string, validUntil time.Time) (bool, error) {
+	resp, err := r.isClient.CheckDeviceOwner(ctx, &pbIS.CheckDeviceOwnerRequest{DeviceId: deviceID})
+	if err != nil {
+		if isOwnedByAnotherUser(err) {
+			return false, status.Errorf(codes.PermissionDenied, "cannot sign identity certificate: device %v is owned by another user: %v", deviceID, err)
+		}
+		return false, kitNetGrpc.ForwardErrorf(codes.Internal, "cannot sign identity certificate: cannot check owner of device %v: %v", deviceID, err)
+	}
+	err = r.store.BindDevice(ctx, deviceID, owner, validUntil.UnixNano(), time.Now().UnixNano())
+	if errors.Is(err, store.ErrDeviceBoundToAnotherOwner) {
+		return false, status.Errorf(codes.PermissionDenied, "cannot sign identity certificate: device %v is owned by another user", deviceID)
+	}
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "cannot sign identity certificate: %v", err)
+	}
+	return resp.GetOwned(), nil
+}
+
+func isOwnedByAnotherUser(err error) bool {
+	switch status.Convert(err).Code() {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return true
+	}
+	return false
+}
+
+// registerDevice registers the device to the owner in the identity-store after the identity certificate has been issued.
+func (r *RequestHandler) registerDevice(ctx context.Context, deviceID string) error {
+	if _, err := r.isClient.AddDevice(ctx, &pbIS.AddDeviceRequest{DeviceId: deviceID}); err != nil {
+		if isOwnedByAnotherUser(err) {
+			return status.Errorf(codes.PermissionDenied, "cannot sign identity certificate: device %v is owned by another user: %v", deviceID, err)
+		}
+		return kitNetGrpc.ForwardErrorf(codes.Internal, "cannot sign identity certificate: cannot register device %v to the owner: %v", deviceID, err)
+	}
+	return nil
+}
+
 func (r *RequestHandler) SignIdentityCertificate(ctx context.Context, req *pb.SignCertificateRequest) (*pb.SignCertificateResponse, error) {
+	owner, err := r.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deviceID, err := getDeviceIDFromCSR(req.CertificateSigningRequest)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot sign identity certificate: %v", err))
+	}
 	notBefore := r.ValidFrom()
 	notAfter := notBefore.Add(r.ValidFor)
+	owned, err := r.checkDeviceOwner(ctx, owner, deviceID, notAfter)
+	if err != nil {
+		return nil, log.LogAndReturnError(err)
+	}
 	signer := signer.NewIdentityCertificateSigner(r.Certificate, r.PrivateKey, notBefore, notAfter)
 	cert, err := signer.Sign(ctx, req.CertificateSigningRequest)
 	if err != nil {
 		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot sign identity certificate: %v", err))
 	}
+	if !owned {
+		if err := r.registerDevice(ctx, deviceID); err != nil {
+			return nil, log.LogAndReturnError(err)
+		}
+	}
+	if err := r.storeSigningRecord(ctx, owner, cert, true); err != nil {
+		return nil, err
+	}
 	log.Debugf("RequestHandler.SignIdentityCertificate csr=%v crt=%v", string(req.CertificateSigningRequest), string(cert))
 
 	return &pb.SignCertificateResponse{
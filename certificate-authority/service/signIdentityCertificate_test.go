@@ -2,9 +2,32 @@ package service_test
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/certificate-authority/keys"
 	"github.com/plgd-dev/hub/certificate-authority/pb"
+	"github.com/plgd-dev/hub/certificate-authority/service"
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	"github.com/plgd-dev/hub/certificate-authority/store/mongodb"
+	caTest "github.com/plgd-dev/hub/certificate-authority/test"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	hubTestService "github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 func TestRequestHandlerSignIdentityCertificate(t *testing.T) {
@@ -12,3 +35,262 @@ func TestRequestHandlerSignIdentityCertificate(t *testing.T) {
 		return c.SignIdentityCertificate(ctx, req)
 	})
 }
+
+func newTestStore(ctx context.Context, t *testing.T, cfg service.Config) (*mongodb.Store, func()) {
+	logger, err := log.NewLogger(cfg.Log)
+	require.NoError(t, err)
+	certManager, err := client.New(cfg.Clients.Storage.MongoDB.TLS, logger)
+	require.NoError(t, err)
+	s, err := mongodb.NewStore(ctx, cfg.Clients.Storage.MongoDB, certManager.GetTLSConfig())
+	require.NoError(t, err)
+	return s, func() {
+		_ = s.Clear(ctx)
+		_ = s.Close(ctx)
+		certManager.Close()
+	}
+}
+
+func TestRequestHandlerSignIdentityCertificateOwnedByAnotherUser(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tearDown := hubTestService.SetUp(ctx, t)
+	defer tearDown()
+
+	s, closeStore := newTestStore(ctx, t, caTest.MakeConfig(t))
+	defer closeStore()
+	const deviceID = "00000000-0000-0000-0000-000000000001"
+	now := time.Now()
+	err := s.BindDevice(ctx, deviceID, "anotherOwner", now.Add(time.Hour).UnixNano(), now.UnixNano())
+	require.NoError(t, err)
+
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+	conn, err := grpc.Dial(testCfg.CERTIFICATE_AUTHORITY_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := pb.NewCertificateAuthorityClient(conn)
+
+	_, err = c.SignIdentityCertificate(ctx, &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+
+	// the expired binding doesn't bind the device to the owner anymore
+	err = s.UnbindDevice(ctx, deviceID, "anotherOwner", time.Now().UnixNano())
+	require.NoError(t, err)
+	err = s.BindDevice(ctx, deviceID, "anotherOwner", now.Add(-time.Minute).UnixNano(), now.Add(-time.Hour).UnixNano())
+	require.NoError(t, err)
+	_, err = c.SignIdentityCertificate(ctx, &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.NoError(t, err)
+}
+
+// testIdentityStore registers devices to the owner of the token like the identity-store.
+type testIdentityStore struct {
+	pbIS.IdentityStoreClient
+	mutex   sync.Mutex
+	devices map[string]string
+	// allowAll accepts every owner, e.g. when the device has been removed from the identity-store meanwhile
+	allowAll bool
+}
+
+func (s *testIdentityStore) CheckDeviceOwner(ctx context.Context, in *pbIS.CheckDeviceOwnerRequest, opts ...grpc.CallOption) (*pbIS.CheckDeviceOwnerResponse, error) {
+	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, "sub")
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.allowAll {
+		return &pbIS.CheckDeviceOwnerResponse{}, nil
+	}
+	o, ok := s.devices[in.GetDeviceId()]
+	if ok && o != owner {
+		return nil, status.Errorf(codes.PermissionDenied, "device %v belongs to another owner", in.GetDeviceId())
+	}
+	return &pbIS.CheckDeviceOwnerResponse{Owned: ok}, nil
+}
+
+func (s *testIdentityStore) AddDevice(ctx context.Context, in *pbIS.AddDeviceRequest, opts ...grpc.CallOption) (*pbIS.AddDeviceResponse, error) {
+	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, "sub")
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.allowAll {
+		return &pbIS.AddDeviceResponse{}, nil
+	}
+	if o, ok := s.devices[in.GetDeviceId()]; ok && o != owner {
+		return nil, status.Errorf(codes.PermissionDenied, "device %v belongs to another owner", in.GetDeviceId())
+	}
+	s.devices[in.GetDeviceId()] = owner
+	return &pbIS.AddDeviceResponse{}, nil
+}
+
+func (s *testIdentityStore) getOwner(deviceID string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	owner, ok := s.devices[deviceID]
+	return owner, ok
+}
+
+func (s *testIdentityStore) setAllowAll(allowAll bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.allowAll = allowAll
+}
+
+func newTestRequestHandler(ctx context.Context, t *testing.T) (*service.RequestHandler, *testIdentityStore, *mongodb.Store, func()) {
+	cfg := caTest.MakeConfig(t)
+	s, closeStore := newTestStore(ctx, t, cfg)
+	key, err := keys.New(cfg.Signer.Config)
+	require.NoError(t, err)
+	isClient := &testIdentityStore{
+		devices: make(map[string]string),
+	}
+	h, err := service.NewRequestHandlerFromConfig(cfg.Signer, key, s, isClient, "sub")
+	require.NoError(t, err)
+	return h, isClient, s, func() {
+		closeStore()
+		_ = key.Close()
+	}
+}
+
+func ctxWithOwner(ctx context.Context, t *testing.T, owner string) context.Context {
+	return kitNetGrpc.CtxWithIncomingToken(ctx, testCfg.CreateJwtToken(t, jwt.MapClaims{
+		"sub": owner,
+	}))
+}
+
+func TestRequestHandlerSignIdentityCertificateSecondOwner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	h, isClient, _, tearDown := newTestRequestHandler(ctx, t)
+	defer tearDown()
+
+	req := &pb.SignCertificateRequest{CertificateSigningRequest: testCSR}
+	_, err := h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner1"), req)
+	require.NoError(t, err)
+	// renewal by the same owner
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner1"), req)
+	require.NoError(t, err)
+
+	// the identity-store rejects the second owner
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner2"), req)
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+
+	// the binding rejects the second owner even when the identity-store doesn't know the device
+	isClient.setAllowAll(true)
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner2"), req)
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+
+	// revoking the certificates of the device releases the binding
+	_, err = h.RevokeCertificates(ctxWithOwner(ctx, t, "owner1"), &pb.RevokeCertificatesRequest{
+		DeviceIdFilter: []string{"00000000-0000-0000-0000-000000000001"},
+	})
+	require.NoError(t, err)
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner2"), req)
+	require.NoError(t, err)
+}
+
+func TestRequestHandlerSignIdentityCertificateExpiredBinding(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	h, _, s, tearDown := newTestRequestHandler(ctx, t)
+	defer tearDown()
+
+	now := time.Now()
+	err := s.BindDevice(ctx, "00000000-0000-0000-0000-000000000001", "anotherOwner", now.Add(-time.Minute).UnixNano(), now.Add(-time.Hour).UnixNano())
+	require.NoError(t, err)
+
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner1"), &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.NoError(t, err)
+
+	// the binding of owner1 is active
+	err = s.BindDevice(ctx, "00000000-0000-0000-0000-000000000001", "anotherOwner", now.Add(time.Hour).UnixNano(), time.Now().UnixNano())
+	require.ErrorIs(t, err, store.ErrDeviceBoundToAnotherOwner)
+}
+
+func TestRequestHandlerSignIdentityCertificateRegistersDeviceAfterSigning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	h, isClient, s, tearDown := newTestRequestHandler(ctx, t)
+	defer tearDown()
+
+	const deviceID = "00000000-0000-0000-0000-000000000001"
+	now := time.Now()
+	err := s.BindDevice(ctx, deviceID, "anotherOwner", now.Add(time.Hour).UnixNano(), now.UnixNano())
+	require.NoError(t, err)
+
+	// the denied request doesn't register the device to the identity-store
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner1"), &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+	_, ok := isClient.getOwner(deviceID)
+	require.False(t, ok)
+
+	err = s.UnbindDevice(ctx, deviceID, "anotherOwner", time.Now().UnixNano())
+	require.NoError(t, err)
+	_, err = h.SignIdentityCertificate(ctxWithOwner(ctx, t, "owner1"), &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.NoError(t, err)
+	owner, ok := isClient.getOwner(deviceID)
+	require.True(t, ok)
+	require.Equal(t, "owner1", owner)
+}
+
+func TestRequestHandlerSignIdentityCertificateConcurrently(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	defer cancel()
+
+	h, isClient, _, tearDown := newTestRequestHandler(ctx, t)
+	defer tearDown()
+	// only the CA binding decides between the owners
+	isClient.setAllowAll(true)
+
+	const numRequests = 10
+	signConcurrently := func(getOwner func(i int) string) (succeeded []string, denied int) {
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		for i := 0; i < numRequests; i++ {
+			wg.Add(1)
+			go func(owner string) {
+				defer wg.Done()
+				_, err := h.SignIdentityCertificate(ctxWithOwner(ctx, t, owner), &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err == nil {
+					succeeded = append(succeeded, owner)
+					return
+				}
+				if status.Convert(err).Code() == codes.PermissionDenied {
+					denied++
+				}
+			}(getOwner(i))
+		}
+		wg.Wait()
+		return succeeded, denied
+	}
+
+	// exactly one of the owners binds the device
+	succeeded, denied := signConcurrently(func(i int) string {
+		return fmt.Sprintf("owner%v", i)
+	})
+	require.Len(t, succeeded, 1)
+	require.Equal(t, numRequests-1, denied)
+
+	// concurrent renewals of the owner succeed
+	owner := succeeded[0]
+	succeeded, denied = signConcurrently(func(int) string {
+		return owner
+	})
+	require.Len(t, succeeded, numRequests)
+	require.Equal(t, 0, denied)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/device/pkg/net/coap"
+	"github.com/plgd-dev/hub/certificate-authority/pb"
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (r *RequestHandler) owner(ctx context.Context) (string, error) {
+	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, r.ownerClaim)
+	if err != nil {
+		return "", log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "invalid owner: %v", err))
+	}
+	return owner, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM block")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func newSigningRecord(owner string, certPEM []byte, identity bool) (store.SigningRecord, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return store.SigningRecord{}, fmt.Errorf("cannot parse signed certificate: %w", err)
+	}
+	var deviceID string
+	if identity {
+		deviceID, err = coap.GetDeviceIDFromIndetityCertificate(cert)
+		if err != nil {
+			return store.SigningRecord{}, fmt.Errorf("cannot get device id from signed certificate: %w", err)
+		}
+	}
+	return store.SigningRecord{
+		SerialNumber: cert.SerialNumber.String(),
+		Owner:        owner,
+		CommonName:   cert.Subject.CommonName,
+		DeviceID:     deviceID,
+		Certificate:  certPEM,
+		ValidFrom:    cert.NotBefore.UnixNano(),
+		ValidUntil:   cert.NotAfter.UnixNano(),
+		CreationDate: time.Now().UnixNano(),
+	}, nil
+}
+
+// storeSigningRecord records the issued certificate so it can be listed and revoked later.
+func (r *RequestHandler) storeSigningRecord(ctx context.Context, owner string, certPEM []byte, identity bool) error {
+	record, err := newSigningRecord(owner, certPEM, identity)
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.Internal, "cannot store signing record: %v", err))
+	}
+	if err := r.store.CreateSigningRecord(ctx, record); err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.Internal, "cannot store signing record: %v", err))
+	}
+	return nil
+}
+
+func toSigningRecord(r store.SigningRecord) *pb.SigningRecord {
+	return &pb.SigningRecord{
+		SerialNumber:   r.SerialNumber,
+		Owner:          r.Owner,
+		CommonName:     r.CommonName,
+		DeviceId:       r.DeviceID,
+		Certificate:    r.Certificate,
+		ValidFrom:      r.ValidFrom,
+		ValidUntil:     r.ValidUntil,
+		CreationDate:   r.CreationDate,
+		RevocationDate: r.RevocationDate,
+	}
+}
+
+type sendSigningRecordsHandler struct {
+	srv pb.CertificateAuthority_GetSigningRecordsServer
+}
+
+func (h *sendSigningRecordsHandler) Handle(ctx context.Context, iter store.SigningRecordIter) error {
+	var record store.SigningRecord
+	for iter.Next(ctx, &record) {
+		if err := h.srv.Send(toSigningRecord(record)); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func (r *RequestHandler) GetSigningRecords(req *pb.GetSigningRecordsRequest, srv pb.CertificateAuthority_GetSigningRecordsServer) error {
+	owner, err := r.owner(srv.Context())
+	if err != nil {
+		return err
+	}
+	err = r.store.LoadSigningRecords(srv.Context(), store.SigningRecordsQuery{
+		Owner:         owner,
+		SerialNumbers: req.GetSerialNumberFilter(),
+		DeviceIDs:     req.GetDeviceIdFilter(),
+	}, &sendSigningRecordsHandler{srv: srv})
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.Internal, "cannot get signing records: %v", err))
+	}
+	return nil
+}
+
+type activeDevicesHandler struct {
+	now     int64
+	devices map[string]bool
+}
+
+func (h *activeDevicesHandler) Handle(ctx context.Context, iter store.SigningRecordIter) error {
+	var record store.SigningRecord
+	for iter.Next(ctx, &record) {
+		if record.DeviceID == "" || record.IsRevoked() || record.ValidUntil <= h.now {
+			continue
+		}
+		h.devices[record.DeviceID] = true
+	}
+	return iter.Err()
+}
+
+// getActiveDevices returns devices of the not revoked and not expired identity certificates matched by the query.
+func (r *RequestHandler) getActiveDevices(ctx context.Context, query store.SigningRecordsQuery, now int64) (map[string]bool, error) {
+	h := activeDevicesHandler{
+		now:     now,
+		devices: make(map[string]bool),
+	}
+	if err := r.store.LoadSigningRecords(ctx, query, &h); err != nil {
+		return nil, err
+	}
+	return h.devices, nil
+}
+
+// unbindRevokedDevices releases the binding of the devices to the owner when the owner has no valid identity
+// certificate of the device anymore. Bindings updated by a concurrent signing after revokedAt are kept.
+func (r *RequestHandler) unbindRevokedDevices(ctx context.Context, owner string, devices map[string]bool, revokedAt int64) error {
+	if len(devices) == 0 {
+		return nil
+	}
+	deviceIDs := make([]string, 0, len(devices))
+	for deviceID := range devices {
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+	activeDevices, err := r.getActiveDevices(ctx, store.SigningRecordsQuery{
+		Owner:     owner,
+		DeviceIDs: deviceIDs,
+	}, revokedAt)
+	if err != nil {
+		return err
+	}
+	for _, deviceID := range deviceIDs {
+		if activeDevices[deviceID] {
+			continue
+		}
+		if err := r.store.UnbindDevice(ctx, deviceID, owner, revokedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RequestHandler) RevokeCertificates(ctx context.Context, req *pb.RevokeCertificatesRequest) (*pb.RevokeCertificatesResponse, error) {
+	owner, err := r.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetSerialNumberFilter()) == 0 && len(req.GetDeviceIdFilter()) == 0 {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot revoke certificates: serialNumberFilter or deviceIdFilter is required"))
+	}
+	query := store.SigningRecordsQuery{
+		Owner:         owner,
+		SerialNumbers: req.GetSerialNumberFilter(),
+		DeviceIDs:     req.GetDeviceIdFilter(),
+	}
+	revokedAt := time.Now().UnixNano()
+	devices, err := r.getActiveDevices(ctx, query, revokedAt)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot revoke certificates: %v", err))
+	}
+	for _, deviceID := range req.GetDeviceIdFilter() {
+		// the binding can exist without the signing record when the signing failed
+		devices[deviceID] = true
+	}
+	count, err := r.store.RevokeSigningRecords(ctx, query, revokedAt)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot revoke certificates: %v", err))
+	}
+	if err := r.unbindRevokedDevices(ctx, owner, devices, revokedAt); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot revoke certificates: cannot unbind devices: %v", err))
+	}
+	return &pb.RevokeCertificatesResponse{
+		Count: count,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/hub/certificate-authority/pb"
+	"github.com/plgd-dev/hub/certificate-authority/uri"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+func getSigningRecords(ctx context.Context, t *testing.T, c pb.CertificateAuthorityClient, req *pb.GetSigningRecordsRequest) []*pb.SigningRecord {
+	client, err := c.GetSigningRecords(ctx, req)
+	require.NoError(t, err)
+	var records []*pb.SigningRecord
+	for {
+		record, err := client.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+	return records
+}
+
+func getCRL(t *testing.T) *pkix.CertificateList {
+	c := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: test.GetRootCertificatePool(t),
+			},
+		},
+	}
+	resp, err := c.Get("https://" + testCfg.CERTIFICATE_AUTHORITY_HTTP_HOST + uri.CRL)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	crl, err := x509.ParseDERCRL(data)
+	require.NoError(t, err)
+	return crl
+}
+
+func TestRequestHandlerRevokeCertificates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(testCfg.CERTIFICATE_AUTHORITY_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := pb.NewCertificateAuthorityClient(conn)
+
+	_, err = c.SignIdentityCertificate(ctx, &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.NoError(t, err)
+	_, err = c.SignCertificate(ctx, &pb.SignCertificateRequest{CertificateSigningRequest: testCSR})
+	require.NoError(t, err)
+
+	records := getSigningRecords(ctx, t, c, &pb.GetSigningRecordsRequest{})
+	require.Len(t, records, 2)
+	deviceID := "00000000-0000-0000-0000-000000000001"
+	records = getSigningRecords(ctx, t, c, &pb.GetSigningRecordsRequest{DeviceIdFilter: []string{deviceID}})
+	require.Len(t, records, 1)
+	require.Equal(t, deviceID, records[0].GetDeviceId())
+	require.Equal(t, int64(0), records[0].GetRevocationDate())
+	require.Empty(t, getCRL(t).TBSCertList.RevokedCertificates)
+
+	_, err = c.RevokeCertificates(ctx, &pb.RevokeCertificatesRequest{})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	resp, err := c.RevokeCertificates(ctx, &pb.RevokeCertificatesRequest{DeviceIdFilter: []string{deviceID}})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.GetCount())
+	// revoking twice doesn't change the revocation
+	resp, err = c.RevokeCertificates(ctx, &pb.RevokeCertificatesRequest{SerialNumberFilter: []string{records[0].GetSerialNumber()}})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), resp.GetCount())
+
+	revoked := getSigningRecords(ctx, t, c, &pb.GetSigningRecordsRequest{SerialNumberFilter: []string{records[0].GetSerialNumber()}})
+	require.Len(t, revoked, 1)
+	require.NotEqual(t, int64(0), revoked[0].GetRevocationDate())
+
+	crl := getCRL(t)
+	require.Len(t, crl.TBSCertList.RevokedCertificates, 1)
+	require.Equal(t, records[0].GetSerialNumber(), crl.TBSCertList.RevokedCertificates[0].SerialNumber.String())
+}
@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// deviceBindingsCName stores one document per device, the _id of the document is the device ID. The unique _id
+// makes the binding of the device to the owner atomic.
+const deviceBindingsCName = "deviceBindings"
+const validUntilKey = "validuntil"
+const updatedAtKey = "updatedat"
+
+func (s *Store) BindDevice(ctx context.Context, deviceID, owner string, validUntil, now int64) error {
+	if deviceID == "" {
+		return fmt.Errorf("cannot bind device: invalid DeviceID")
+	}
+	if owner == "" {
+		return fmt.Errorf("cannot bind device: invalid Owner")
+	}
+	// the document is updated only when it belongs to the owner or the binding of another owner has expired,
+	// otherwise the upsert tries to insert a new document with the same _id and fails
+	filter := bson.M{
+		"_id": deviceID,
+		"$or": bson.A{
+			bson.M{ownerKey: owner},
+			bson.M{validUntilKey: bson.M{"$lte": now}},
+		},
+	}
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			validUntilKey: bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$" + ownerKey, owner}},
+				bson.M{"$max": bson.A{"$" + validUntilKey, validUntil}},
+				validUntil,
+			}},
+			ownerKey:     owner,
+			updatedAtKey: now,
+		}},
+	}
+	_, err := s.Collection(deviceBindingsCName).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("cannot bind device %v: %w", deviceID, store.ErrDeviceBoundToAnotherOwner)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot bind device %v: %w", deviceID, err)
+	}
+	return nil
+}
+
+func (s *Store) UnbindDevice(ctx context.Context, deviceID, owner string, updatedBefore int64) error {
+	_, err := s.Collection(deviceBindingsCName).DeleteOne(ctx, bson.M{
+		"_id":        deviceID,
+		ownerKey:     owner,
+		updatedAtKey: bson.M{"$lt": updatedBefore},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot unbind device %v: %w", deviceID, err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/certificate-authority/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const signingRecordsCName = "signingRecords"
+const ownerKey = "owner"
+const deviceIDKey = "deviceid"
+const revocationDateKey = "revocationdate"
+
+var ownerQueryIndex = bson.D{
+	{Key: ownerKey, Value: 1},
+}
+
+var ownerDeviceIDQueryIndex = bson.D{
+	{Key: ownerKey, Value: 1},
+	{Key: deviceIDKey, Value: 1},
+}
+
+var revocationDateQueryIndex = bson.D{
+	{Key: revocationDateKey, Value: 1},
+}
+
+type DBSigningRecord struct {
+	SerialNumber   string `bson:"_id"`
+	Owner          string `bson:"owner"`
+	CommonName     string `bson:"commonname"`
+	DeviceID       string `bson:"deviceid"`
+	Certificate    []byte `bson:"certificate"`
+	ValidFrom      int64  `bson:"validfrom"`
+	ValidUntil     int64  `bson:"validuntil"`
+	CreationDate   int64  `bson:"creationdate"`
+	RevocationDate int64  `bson:"revocationdate"`
+}
+
+func makeDBSigningRecord(r store.SigningRecord) DBSigningRecord {
+	return DBSigningRecord{
+		SerialNumber:   r.SerialNumber,
+		Owner:          r.Owner,
+		CommonName:     r.CommonName,
+		DeviceID:       r.DeviceID,
+		Certificate:    r.Certificate,
+		ValidFrom:      r.ValidFrom,
+		ValidUntil:     r.ValidUntil,
+		CreationDate:   r.CreationDate,
+		RevocationDate: r.RevocationDate,
+	}
+}
+
+func convertToSigningRecord(r DBSigningRecord) store.SigningRecord {
+	return store.SigningRecord{
+		SerialNumber:   r.SerialNumber,
+		Owner:          r.Owner,
+		CommonName:     r.CommonName,
+		DeviceID:       r.DeviceID,
+		Certificate:    r.Certificate,
+		ValidFrom:      r.ValidFrom,
+		ValidUntil:     r.ValidUntil,
+		CreationDate:   r.CreationDate,
+		RevocationDate: r.RevocationDate,
+	}
+}
+
+func validateSigningRecord(r store.SigningRecord) error {
+	if r.SerialNumber == "" {
+		return fmt.Errorf("invalid SerialNumber")
+	}
+	if r.Owner == "" {
+		return fmt.Errorf("invalid Owner")
+	}
+	if len(r.Certificate) == 0 {
+		return fmt.Errorf("invalid Certificate")
+	}
+	return nil
+}
+
+func (s *Store) CreateSigningRecord(ctx context.Context, record store.SigningRecord) error {
+	if err := validateSigningRecord(record); err != nil {
+		return fmt.Errorf("cannot create signing record: %w", err)
+	}
+	_, err := s.Collection(signingRecordsCName).InsertOne(ctx, makeDBSigningRecord(record))
+	if err != nil {
+		return fmt.Errorf("cannot create signing record %v: %w", record.SerialNumber, err)
+	}
+	return nil
+}
+
+func toSigningRecordsFilter(query store.SigningRecordsQuery) bson.M {
+	filter := bson.M{}
+	if query.Owner != "" {
+		filter[ownerKey] = query.Owner
+	}
+	if len(query.SerialNumbers) > 0 {
+		filter["_id"] = bson.M{"$in": query.SerialNumbers}
+	}
+	if len(query.DeviceIDs) > 0 {
+		filter[deviceIDKey] = bson.M{"$in": query.DeviceIDs}
+	}
+	if query.RevokedOnly {
+		filter[revocationDateKey] = bson.M{"$gt": 0}
+	}
+	return filter
+}
+
+func (s *Store) LoadSigningRecords(ctx context.Context, query store.SigningRecordsQuery, h store.SigningRecordHandler) error {
+	iter, err := s.Collection(signingRecordsCName).Find(ctx, toSigningRecordsFilter(query))
+	if err == mongo.ErrNilDocument {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	i := signingRecordIterator{
+		iter: iter,
+	}
+	err = h.Handle(ctx, &i)
+
+	errClose := iter.Close(ctx)
+	if err == nil {
+		return errClose
+	}
+	return err
+}
+
+func (s *Store) RevokeSigningRecords(ctx context.Context, query store.SigningRecordsQuery, revocationDate int64) (int64, error) {
+	if revocationDate <= 0 {
+		return 0, fmt.Errorf("cannot revoke signing records: invalid revocationDate")
+	}
+	filter := toSigningRecordsFilter(query)
+	// already revoked records keep the original revocation date
+	filter[revocationDateKey] = 0
+	res, err := s.Collection(signingRecordsCName).UpdateMany(ctx, filter, bson.M{"$set": bson.M{revocationDateKey: revocationDate}})
+	if err != nil {
+		return 0, fmt.Errorf("cannot revoke signing records: %w", err)
+	}
+	return res.ModifiedCount, nil
+}
+
+type signingRecordIterator struct {
+	iter *mongo.Cursor
+}
+
+func (i *signingRecordIterator) Next(ctx context.Context, r *store.SigningRecord) bool {
+	var record DBSigningRecord
+
+	if !i.iter.Next(ctx) {
+		return false
+	}
+
+	err := i.iter.Decode(&record)
+	if err != nil {
+		return false
+	}
+	*r = convertToSigningRecord(record)
+	return true
+}
+
+func (i *signingRecordIterator) Err() error {
+	return i.iter.Err()
+}
@@ -0,0 +1,27 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+
+	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
+)
+
+type Store struct {
+	*pkgMongo.Store
+}
+
+func NewStore(ctx context.Context, cfg pkgMongo.Config, tls *tls.Config) (*Store, error) {
+	s, err := pkgMongo.NewStoreWithCollection(ctx, cfg, tls, signingRecordsCName, ownerQueryIndex, ownerDeviceIDQueryIndex,
+		revocationDateQueryIndex)
+	if err != nil {
+		return nil, err
+	}
+	s.SetOnClear(func(c context.Context) error {
+		if err := s.DropCollection(c, deviceBindingsCName); err != nil {
+			return err
+		}
+		return s.DropCollection(c, signingRecordsCName)
+	})
+	return &Store{s}, nil
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeviceBoundToAnotherOwner is returned by BindDevice when the device is bound to another owner.
+var ErrDeviceBoundToAnotherOwner = errors.New("device is bound to another owner")
+
+type SigningRecord struct {
+	SerialNumber   string
+	Owner          string
+	CommonName     string
+	DeviceID       string
+	Certificate    []byte
+	ValidFrom      int64
+	ValidUntil     int64
+	CreationDate   int64
+	RevocationDate int64
+}
+
+// IsRevoked returns true when the certificate was revoked.
+func (r SigningRecord) IsRevoked() bool {
+	return r.RevocationDate > 0
+}
+
+type SigningRecordsQuery struct {
+	Owner         string
+	SerialNumbers []string
+	DeviceIDs     []string
+	// RevokedOnly selects only revoked records.
+	RevokedOnly bool
+}
+
+type SigningRecordIter interface {
+	Next(ctx context.Context, record *SigningRecord) bool
+	Err() error
+}
+
+type SigningRecordHandler interface {
+	Handle(ctx context.Context, iter SigningRecordIter) (err error)
+}
+
+type Store interface {
+	CreateSigningRecord(ctx context.Context, record SigningRecord) error
+	LoadSigningRecords(ctx context.Context, query SigningRecordsQuery, h SigningRecordHandler) error
+	// RevokeSigningRecords marks the matched records as revoked and returns the number of newly revoked records.
+	RevokeSigningRecords(ctx context.Context, query SigningRecordsQuery, revocationDate int64) (int64, error)
+	// BindDevice atomically binds the device to the owner until validUntil. It returns ErrDeviceBoundToAnotherOwner
+	// when the device is bound to another owner and the binding hasn't expired at now.
+	BindDevice(ctx context.Context, deviceID, owner string, validUntil, now int64) error
+	// UnbindDevice removes the binding of the device to the owner when it wasn't updated since updatedBefore.
+	UnbindDevice(ctx context.Context, deviceID, owner string, updatedBefore int64) error
+	Clear(ctx context.Context) error
+	Close(ctx context.Context) error
+}
@@ -17,6 +17,13 @@ func MakeConfig(t *testing.T) service.Config {
 	var cfg service.Config
 	cfg.APIs.GRPC = config.MakeGrpcServerConfig(config.CERTIFICATE_AUTHORITY_HOST)
 	cfg.APIs.GRPC.TLS.ClientCertificateRequired = false
+	cfg.APIs.HTTP.Connection = config.MakeListenerConfig(config.CERTIFICATE_AUTHORITY_HTTP_HOST)
+	cfg.APIs.HTTP.Connection.TLS.ClientCertificateRequired = false
+	cfg.APIs.HTTP.CRLExpiresIn = time.Hour
+	cfg.Clients.Storage.MongoDB.URI = config.MONGODB_URI
+	cfg.Clients.Storage.MongoDB.Database = config.CERTIFICATE_AUTHORITY_DB
+	cfg.Clients.Storage.MongoDB.TLS = config.MakeTLSClientConfig()
+	cfg.Clients.IdentityStore.Connection = config.MakeGrpcClientConfig(config.IDENTITY_STORE_HOST)
 	cfg.Signer.KeyFile = os.Getenv("TEST_ROOT_CA_KEY")
 	cfg.Signer.CertFile = os.Getenv("TEST_ROOT_CA_CERT")
 	cfg.Signer.ValidFrom = "now-1h"
@@ -0,0 +1,8 @@
+package uri
+
+const (
+	API = "/api/v1"
+
+	// CRL distribution endpoint, returns the certificate revocation list in the DER format
+	CRL = API + "/crl"
+)
@@ -102,6 +102,10 @@ If release name contains chart name it will be used as a full name.
   {{- $authoriztion := index . 1 }}
   {{- $prefix := index . 2 }}
   ownerClaim:{{ printf " " }}{{ required (printf "%s.apis.grpc.authorization.ownerClaim or global.ownerClaim is required " $prefix) ( $authoriztion.ownerClaim | default $.Values.global.ownerClaim ) | quote }}
+  {{- with $authoriztion.requiredScopes }}
+  requiredScopes:
+  {{- toYaml . | nindent 2 }}
+  {{- end }}
   {{- if not $.Values.mockoauthserver.enabled }}
   authority:{{ printf " " }}{{ required (printf "%s.apis.grpc.authorization.authority or global.authority is required " $prefix) ( $authoriztion.authority | default $.Values.global.authority ) | quote }}
   audience:{{ printf " " }}{{ ( $authoriztion.audience | default $.Values.global.audience ) | quote }}
@@ -109,6 +113,10 @@ If release name contains chart name it will be used as a full name.
   authority:{{ printf " " }}{{ include "plgd-hub.mockoauthserver.uri" $ }}
   audience:{{ printf " " }}{{ printf "" | quote }}
   {{- end }}
+  {{- with ( $authoriztion.authorities | default $.Values.global.authorities ) }}
+  authorities:
+  {{- toYaml . | nindent 2 }}
+  {{- end }}
 {{- end }}
 
 {{- define "plgd-hub.baseAthorizationConfig" }}
@@ -122,6 +130,10 @@ If release name contains chart name it will be used as a full name.
   authority:{{ printf " " }}{{ include "plgd-hub.mockoauthserver.uri" $ }}
   audience:{{ printf " " }}{{ printf "" | quote }}
   {{- end }}
+  {{- with ( $authoriztion.authorities | default $.Values.global.authorities ) }}
+  authorities:
+  {{- toYaml . | nindent 2 }}
+  {{- end }}
 {{- end }}
 
 
@@ -6,3 +6,20 @@ import (
 
 type ResourcesPublished []schema.ResourceLink
 type ResourcesUnpublished []schema.ResourceLink
+
+// DeviceMetadataUpdatePending is the body of the extended device event device_metadataupdatepending.
+type DeviceMetadataUpdatePending struct {
+	DeviceID              string `json:"di"`
+	CorrelationID         string `json:"correlationId"`
+	ShadowSynchronization string `json:"shadowSynchronization"`
+	ValidUntil            int64  `json:"validUntil,omitempty"` // unix timestamp in nanoseconds, 0 means forever
+}
+
+// DeviceMetadataUpdated is the body of the extended device event device_metadataupdated.
+type DeviceMetadataUpdated struct {
+	DeviceID              string `json:"di"`
+	CorrelationID         string `json:"correlationId,omitempty"`
+	Online                bool   `json:"online"`
+	ShadowSynchronization string `json:"shadowSynchronization"`
+	Canceled              bool   `json:"canceled,omitempty"`
+}
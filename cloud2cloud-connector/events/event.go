@@ -66,7 +66,12 @@ func ParseEventHeader(r *http.Request) (h EventHeader, _ error) {
 	eventType := EventType(r.Header.Get(EventTypeKey))
 	switch eventType {
 	case EventType_ResourceChanged,
+		EventType_ResourceCreatePending, EventType_ResourceCreated,
+		EventType_ResourceRetrievePending, EventType_ResourceRetrieved,
+		EventType_ResourceUpdatePending, EventType_ResourceUpdated,
+		EventType_ResourceDeletePending, EventType_ResourceDeleted,
 		EventType_ResourcesPublished, EventType_ResourcesUnpublished,
+		EventType_DeviceMetadataUpdatePending, EventType_DeviceMetadataUpdated,
 		EventType_DevicesOnline, EventType_DevicesOffline, EventType_DevicesRegistered, EventType_DevicesUnregistered,
 		EventType_SubscriptionCanceled:
 	default:
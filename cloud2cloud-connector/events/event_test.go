@@ -1,6 +1,8 @@
 package events
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -42,3 +44,41 @@ func TestCalculateEventSignature(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEventHeader(t *testing.T) {
+	makeRequest := func(eventType EventType) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/events", nil)
+		r.Header.Set(SubscriptionIDKey, "subscriptionID")
+		r.Header.Set(EventTypeKey, string(eventType))
+		r.Header.Set(ContentTypeKey, ContentType_JSON)
+		r.Header.Set(SequenceNumberKey, "1")
+		r.Header.Set(EventTimestampKey, "2")
+		r.Header.Set(EventSignatureKey, "signature")
+		return r
+	}
+	eventTypes := append([]EventType{EventType_SubscriptionCanceled}, AllDevicesEvents...)
+	eventTypes = append(eventTypes, AllDeviceEvents...)
+	eventTypes = append(eventTypes, ExtendedDeviceEvents...)
+	eventTypes = append(eventTypes, AllResourceEvents...)
+	eventTypes = append(eventTypes, ExtendedResourceEvents...)
+	for _, eventType := range eventTypes {
+		t.Run(string(eventType), func(t *testing.T) {
+			h, err := ParseEventHeader(makeRequest(eventType))
+			if err != nil {
+				t.Fatalf("ParseEventHeader() error = %v", err)
+			}
+			if h.EventType != eventType {
+				t.Errorf("ParseEventHeader() EventType = %v, want %v", h.EventType, eventType)
+			}
+			if h.SequenceNumber != 1 {
+				t.Errorf("ParseEventHeader() SequenceNumber = %v, want 1", h.SequenceNumber)
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := ParseEventHeader(makeRequest("unknown")); err == nil {
+			t.Errorf("ParseEventHeader() expected error for unknown event type")
+		}
+	})
+}
@@ -1,3 +1,20 @@
 package events
 
 type ResourceChanged []byte
+
+// ResourceCommandPending is the body of the extended resource events: resource_createpending, resource_retrievepending, resource_updatepending, resource_deletepending.
+type ResourceCommandPending struct {
+	Href              string      `json:"href"`
+	CorrelationID     string      `json:"correlationId"`
+	ResourceInterface string      `json:"resourceInterface,omitempty"`
+	Content           interface{} `json:"content,omitempty"`
+	ValidUntil        int64       `json:"validUntil,omitempty"` // unix timestamp in nanoseconds, 0 means forever
+}
+
+// ResourceCommandProcessed is the body of the extended resource events: resource_created, resource_retrieved, resource_updated, resource_deleted.
+type ResourceCommandProcessed struct {
+	Href          string      `json:"href"`
+	CorrelationID string      `json:"correlationId"`
+	Status        string      `json:"status"`
+	Content       interface{} `json:"content,omitempty"`
+}
@@ -6,10 +6,24 @@ const (
 	// resource
 	EventType_ResourceChanged EventType = "resource_contentchanged"
 
+	// resource - extended, the events are not defined by the C2C specification
+	EventType_ResourceCreatePending   EventType = "resource_createpending"
+	EventType_ResourceCreated         EventType = "resource_created"
+	EventType_ResourceRetrievePending EventType = "resource_retrievepending"
+	EventType_ResourceRetrieved       EventType = "resource_retrieved"
+	EventType_ResourceUpdatePending   EventType = "resource_updatepending"
+	EventType_ResourceUpdated         EventType = "resource_updated"
+	EventType_ResourceDeletePending   EventType = "resource_deletepending"
+	EventType_ResourceDeleted         EventType = "resource_deleted"
+
 	// device
 	EventType_ResourcesPublished   EventType = "resources_published"
 	EventType_ResourcesUnpublished EventType = "resources_unpublished"
 
+	// device - extended, the events are not defined by the C2C specification
+	EventType_DeviceMetadataUpdatePending EventType = "device_metadataupdatepending"
+	EventType_DeviceMetadataUpdated       EventType = "device_metadataupdated"
+
 	// devices
 	EventType_DevicesOnline       EventType = "devices_online"
 	EventType_DevicesOffline      EventType = "devices_offline"
@@ -24,6 +38,17 @@ var AllDevicesEvents = []EventType{EventType_DevicesOnline, EventType_DevicesOff
 var AllDeviceEvents = []EventType{EventType_ResourcesPublished, EventType_ResourcesUnpublished}
 var AllResourceEvents = []EventType{EventType_ResourceChanged}
 
+// ExtendedDeviceEvents are device events of the hub which are emitted only when a subscriber asks for them.
+var ExtendedDeviceEvents = []EventType{EventType_DeviceMetadataUpdatePending, EventType_DeviceMetadataUpdated}
+
+// ExtendedResourceEvents are resource events of the hub which are emitted only when a subscriber asks for them.
+var ExtendedResourceEvents = []EventType{
+	EventType_ResourceCreatePending, EventType_ResourceCreated,
+	EventType_ResourceRetrievePending, EventType_ResourceRetrieved,
+	EventType_ResourceUpdatePending, EventType_ResourceUpdated,
+	EventType_ResourceDeletePending, EventType_ResourceDeleted,
+}
+
 type EventTypes []EventType
 
 func (e EventTypes) Has(ev EventType) bool {
@@ -6,9 +6,11 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/mongodb"
 	grpcClient "github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/pkg/security/oauth2"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 )
@@ -35,13 +37,17 @@ func (c *Config) Validate() error {
 }
 
 type APIsConfig struct {
-	HTTP HTTPConfig `yaml:"http" json:"http"`
+	HTTP    HTTPConfig     `yaml:"http" json:"http"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.HTTP.Validate(); err != nil {
 		return fmt.Errorf("http.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
@@ -93,12 +99,13 @@ func (c *HTTPConfig) Validate() error {
 }
 
 type ClientsConfig struct {
-	IdentityStore     IdentityStoreConfig     `yaml:"identityStore" json:"identityStore"`
-	Eventbus          EventBusConfig          `yaml:"eventBus" json:"eventBus"`
-	GrpcGateway       GrpcGatewayConfig       `yaml:"grpcGateway" json:"grpcGateway"`
-	ResourceAggregate ResourceAggregateConfig `yaml:"resourceAggregate" json:"resourceAggregate"`
-	Storage           StorageConfig           `yaml:"storage" json:"storage"`
-	Subscription      SubscriptionConfig      `yaml:"subscription" json:"subscription"`
+	IdentityStore          IdentityStoreConfig     `yaml:"identityStore" json:"identityStore"`
+	Eventbus               EventBusConfig          `yaml:"eventBus" json:"eventBus"`
+	GrpcGateway            GrpcGatewayConfig       `yaml:"grpcGateway" json:"grpcGateway"`
+	ResourceAggregate      ResourceAggregateConfig `yaml:"resourceAggregate" json:"resourceAggregate"`
+	Storage                StorageConfig           `yaml:"storage" json:"storage"`
+	Subscription           SubscriptionConfig      `yaml:"subscription" json:"subscription"`
+	OpenTelemetryCollector opentelemetry.Config    `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 func (c *ClientsConfig) Validate() error {
@@ -120,6 +127,9 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.Subscription.Validate(); err != nil {
 		return fmt.Errorf("subscription.%w", err)
 	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
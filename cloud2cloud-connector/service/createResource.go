@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/plgd-dev/hub/cloud2cloud-connector/store"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	raEvents "github.com/plgd-dev/hub/resource-aggregate/events"
+	raService "github.com/plgd-dev/hub/resource-aggregate/service"
+	"github.com/plgd-dev/kit/v2/log"
+)
+
+func createResource(ctx context.Context, raClient raService.ResourceAggregateClient, e *raEvents.ResourceCreatePending, linkedAccount store.LinkedAccount, linkedCloud store.LinkedCloud) error {
+	deviceID := e.GetResourceId().GetDeviceId()
+	href := e.GetResourceId().GetHref()
+	contentType, content, status, err := sendDeviceResourceRequest(ctx, http.MethodPut, deviceID, href, e.GetContent().GetContentType(), e.GetContent().GetData(), linkedAccount, linkedCloud)
+	if err != nil {
+		log.Errorf("cannot create resource %v/%v: %w", deviceID, href, err)
+	}
+	coapContentFormat := stringToSupportedMediaType(contentType)
+	ctx = kitNetGrpc.CtxWithToken(ctx, linkedAccount.Data.Origin().AccessToken.String())
+	_, err = raClient.ConfirmResourceCreate(ctx, &commands.ConfirmResourceCreateRequest{
+		ResourceId:    commands.NewResourceID(deviceID, href),
+		CorrelationId: e.GetAuditContext().GetCorrelationId(),
+		CommandMetadata: &commands.CommandMetadata{
+			ConnectionId: linkedAccount.ID,
+			Sequence:     uint64(time.Now().UnixNano()),
+		},
+		Content: &commands.Content{
+			Data:              content,
+			ContentType:       contentType,
+			CoapContentFormat: coapContentFormat,
+		},
+		Status: status,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create resource /%v%v: %w", deviceID, href, err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	"github.com/plgd-dev/device/schema/device"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/store"
+	c2cConnectorTest "github.com/plgd-dev/hub/cloud2cloud-connector/test"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func testRequestHandlerDeleteResource(t *testing.T, events store.Events) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	tests := []struct {
+		name    string
+		href    string
+		wantErr bool
+	}{
+		{
+			name: "delete /switches/1",
+			href: test.TestResourceSwitchesInstanceHref("1"),
+		},
+		{
+			name:    "/oic/d - PermissionDenied",
+			href:    device.ResourceURI,
+			wantErr: true,
+		},
+		{
+			name:    "invalid Href",
+			href:    "/unknown",
+			wantErr: true,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := c2cConnectorTest.SetUpClouds(ctx, t, deviceID, events)
+	defer tearDown()
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(c2cConnectorTest.GRPC_GATEWAY_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+	defer func() {
+		_ = conn.Close()
+	}()
+	test.AddDeviceSwitchResources(ctx, t, deviceID, c, "1")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.DeleteResource(ctx, &pb.DeleteResourceRequest{
+				ResourceId: commands.NewResourceID(deviceID, tt.href),
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, commands.Status_OK, got.GetData().GetStatus())
+			require.Equal(t, commands.NewResourceID(deviceID, tt.href).ToString(), got.GetData().GetResourceId().ToString())
+		})
+	}
+}
+
+func TestRequestHandlerDeleteResource(t *testing.T) {
+	type args struct {
+		events store.Events
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "full pulling",
+		},
+		{
+			name: "full events",
+			args: args{
+				events: store.Events{
+					Devices:  events.AllDevicesEvents,
+					Device:   events.AllDeviceEvents,
+					Resource: events.AllResourceEvents,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testRequestHandlerDeleteResource(t, tt.args.events)
+		})
+	}
+}
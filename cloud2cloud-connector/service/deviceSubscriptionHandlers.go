@@ -23,6 +23,8 @@ const NOT_SUPPORTED_ERR = "not supported"
 type deviceSubscriptionHandlers struct {
 	onResourceUpdatePending   func(ctx context.Context, val *raEvents.ResourceUpdatePending) error
 	onResourceRetrievePending func(ctx context.Context, val *raEvents.ResourceRetrievePending) error
+	onResourceDeletePending   func(ctx context.Context, val *raEvents.ResourceDeletePending) error
+	onResourceCreatePending   func(ctx context.Context, val *raEvents.ResourceCreatePending) error
 	onError                   func(err error)
 	getContext                func() (context.Context, context.CancelFunc)
 }
@@ -40,11 +42,11 @@ func (h deviceSubscriptionHandlers) RetrieveResource(ctx context.Context, event
 }
 
 func (h deviceSubscriptionHandlers) DeleteResource(ctx context.Context, event *raEvents.ResourceDeletePending) error {
-	return fmt.Errorf(NOT_SUPPORTED_ERR)
+	return h.onResourceDeletePending(ctx, event)
 }
 
 func (h deviceSubscriptionHandlers) CreateResource(ctx context.Context, event *raEvents.ResourceCreatePending) error {
-	return fmt.Errorf(NOT_SUPPORTED_ERR)
+	return h.onResourceCreatePending(ctx, event)
 }
 
 func (h deviceSubscriptionHandlers) UpdateDeviceMetadata(ctx context.Context, event *raEvents.DeviceMetadataUpdatePending) error {
@@ -115,8 +117,14 @@ func (c *DevicesSubscription) Add(deviceID string, linkedAccount store.LinkedAcc
 		onResourceRetrievePending: func(ctx context.Context, val *raEvents.ResourceRetrievePending) error {
 			return retrieveResource(ctx, c.raClient, val, linkedAccount, linkedCloud)
 		},
+		onResourceDeletePending: func(ctx context.Context, val *raEvents.ResourceDeletePending) error {
+			return deleteResource(ctx, c.raClient, val, linkedAccount, linkedCloud)
+		},
+		onResourceCreatePending: func(ctx context.Context, val *raEvents.ResourceCreatePending) error {
+			return createResource(ctx, c.raClient, val, linkedAccount, linkedCloud)
+		},
 		onError: func(err error) {
-			log.Errorf("device %v subscription(ResourceUpdatePending, ResourceRetrievePending) was closed", deviceID)
+			log.Errorf("device %v subscription(ResourceUpdatePending, ResourceRetrievePending, ResourceDeletePending, ResourceCreatePending) was closed", deviceID)
 			c.data.Delete(getKey(linkedAccount.UserID, deviceID))
 		},
 	})
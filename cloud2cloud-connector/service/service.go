@@ -15,11 +15,13 @@ import (
 	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/fn"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	grpcClient "github.com/plgd-dev/hub/pkg/net/grpc/client"
 	kitNetHttp "github.com/plgd-dev/hub/pkg/net/http"
 	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	"github.com/plgd-dev/hub/pkg/security/oauth2"
@@ -227,6 +229,20 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Server, error)
 		}
 	})
 
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		cleanUp.Execute()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	listener.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "cloud2cloud-connector", logger)
+	if err != nil {
+		cleanUp.Execute()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	listener.AddCloseFunc(otelProvider.Close)
+
 	raClient, closeRaClient, err := newResourceAggregateClient(config.Clients.ResourceAggregate, logger)
 	if err != nil {
 		cleanUp.Execute()
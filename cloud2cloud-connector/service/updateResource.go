@@ -22,58 +22,58 @@ func makeHTTPEndpoint(url, deviceID, href string) string {
 	return url + kitHttp.CanonicalHref("devices/"+deviceID+"/"+href)
 }
 
-func updateDeviceResource(ctx context.Context, deviceID, href, contentType string, content []byte, linkedAccount store.LinkedAccount, linkedCloud store.LinkedCloud) (string, []byte, commands.Status, error) {
+// sendDeviceResourceRequest sends the request to the resource of the linked cloud. Any 2xx status code of
+// the response is accepted.
+func sendDeviceResourceRequest(ctx context.Context, method, deviceID, href, contentType string, content []byte, linkedAccount store.LinkedAccount, linkedCloud store.LinkedCloud) (string, []byte, commands.Status, error) {
 	client := linkedCloud.GetHTTPClient()
 	defer client.CloseIdleConnections()
-	r, w := io.Pipe()
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, makeHTTPEndpoint(linkedCloud.Endpoint.URL, deviceID, href), r)
+	var body io.Reader
+	if len(content) > 0 {
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, makeHTTPEndpoint(linkedCloud.Endpoint.URL, deviceID, href), body)
 	if err != nil {
-		return "", nil, commands.Status_BAD_REQUEST, fmt.Errorf("cannot create post request: %w", err)
+		return "", nil, commands.Status_BAD_REQUEST, fmt.Errorf("cannot create %v request: %w", method, err)
 	}
 	req.Header.Set(AcceptHeader, events.ContentType_JSON+","+events.ContentType_VNDOCFCBOR)
-	req.Header.Set(events.ContentTypeKey, contentType)
+	if body != nil {
+		req.Header.Set(events.ContentTypeKey, contentType)
+	}
 	req.Header.Set(AuthorizationHeader, "Bearer "+string(linkedAccount.Data.Target().AccessToken))
 	req.Header.Set("Connection", "close")
 	req.Close = true
 
-	go func() {
-		defer func() {
-			if err := w.Close(); err != nil {
-				log.Errorf("failed to close write pipe: %v", err)
-			}
-		}()
-		_, err := w.Write(content)
-		if err != nil {
-			log.Errorf("cannot update content of device %v resource %v: %w", deviceID, href, err)
-		}
-	}()
 	httpResp, err := client.Do(req)
 	if err != nil {
-		return "", nil, commands.Status_UNAVAILABLE, fmt.Errorf("cannot post: %w", err)
+		return "", nil, commands.Status_UNAVAILABLE, fmt.Errorf("cannot %v: %w", method, err)
 	}
 	defer func() {
 		if err := httpResp.Body.Close(); err != nil {
-			log.Errorf("failed to close response body stream: %v")
+			log.Errorf("failed to close response body stream: %v", err)
 		}
 	}()
-	if httpResp.StatusCode != http.StatusOK {
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
 		status := commands.HTTPStatus2Status(httpResp.StatusCode)
 		return "", nil, status, fmt.Errorf("unexpected statusCode %v", httpResp.StatusCode)
 	}
+	status := commands.HTTPStatus2Status(httpResp.StatusCode)
+	if status == commands.Status_UNKNOWN {
+		status = commands.Status_OK
+	}
 	respContentType := httpResp.Header.Get(events.ContentTypeKey)
 	respContent := bytes.NewBuffer(make([]byte, 0, 1024))
 	_, err = respContent.ReadFrom(httpResp.Body)
 	if err != nil {
-		return "", nil, commands.Status_UNAVAILABLE, fmt.Errorf("cannot read update response: %w", err)
+		return "", nil, commands.Status_UNAVAILABLE, fmt.Errorf("cannot read %v response: %w", method, err)
 	}
 
-	return respContentType, respContent.Bytes(), commands.Status_OK, nil
+	return respContentType, respContent.Bytes(), status, nil
 }
 
 func updateResource(ctx context.Context, raClient raService.ResourceAggregateClient, e *raEvents.ResourceUpdatePending, linkedAccount store.LinkedAccount, linkedCloud store.LinkedCloud) error {
 	deviceID := e.GetResourceId().GetDeviceId()
 	href := e.GetResourceId().GetHref()
-	contentType, content, status, err := updateDeviceResource(ctx, deviceID, href, e.GetContent().GetContentType(), e.GetContent().GetData(), linkedAccount, linkedCloud)
+	contentType, content, status, err := sendDeviceResourceRequest(ctx, http.MethodPost, deviceID, href, e.GetContent().GetContentType(), e.GetContent().GetData(), linkedAccount, linkedCloud)
 	if err != nil {
 		log.Errorf("cannot update resource %v/%v: %w", deviceID, href, err)
 	}
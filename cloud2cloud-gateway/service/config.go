@@ -6,13 +6,15 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/mongodb"
 	grpcClient "github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	"github.com/plgd-dev/hub/pkg/sync/task/queue"
-	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
+	eventbusConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/config"
 )
 
 // Config represents application configuration
@@ -37,13 +39,17 @@ func (c *Config) Validate() error {
 }
 
 type APIsConfig struct {
-	HTTP HTTPConfig `yaml:"http" json:"http"`
+	HTTP    HTTPConfig     `yaml:"http" json:"http"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.HTTP.Validate(); err != nil {
 		return fmt.Errorf("http.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
@@ -63,11 +69,12 @@ func (c *HTTPConfig) Validate() error {
 }
 
 type ClientsConfig struct {
-	Eventbus          EventBusConfig          `yaml:"eventBus" json:"eventBus"`
-	GrpcGateway       GrpcGatewayConfig       `yaml:"grpcGateway" json:"grpcGateway"`
-	ResourceAggregate ResourceAggregateConfig `yaml:"resourceAggregate" json:"resourceAggregate"`
-	Storage           StorageConfig           `yaml:"storage" json:"storage"`
-	Subscription      SubscriptionConfig      `yaml:"subscription" json:"subscription"`
+	Eventbus               EventBusConfig          `yaml:"eventBus" json:"eventBus"`
+	GrpcGateway            GrpcGatewayConfig       `yaml:"grpcGateway" json:"grpcGateway"`
+	ResourceAggregate      ResourceAggregateConfig `yaml:"resourceAggregate" json:"resourceAggregate"`
+	Storage                StorageConfig           `yaml:"storage" json:"storage"`
+	Subscription           SubscriptionConfig      `yaml:"subscription" json:"subscription"`
+	OpenTelemetryCollector opentelemetry.Config    `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 func (c *ClientsConfig) Validate() error {
@@ -86,18 +93,18 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.Subscription.Validate(); err != nil {
 		return fmt.Errorf("subscription.%w", err)
 	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
 type EventBusConfig struct {
-	NATS natsClient.Config `yaml:"nats" json:"nats"`
+	eventbusConfig.SubscriberConfig `yaml:",inline" json:",inline"`
 }
 
 func (c *EventBusConfig) Validate() error {
-	if err := c.NATS.Validate(); err != nil {
-		return fmt.Errorf("nats.%w", err)
-	}
-	return nil
+	return c.SubscriberConfig.Validate()
 }
 
 type GrpcGatewayConfig struct {
@@ -147,6 +154,7 @@ func (c *SubscriptionConfig) Validate() error {
 type HTTPSubscriptionConfig struct {
 	ReconnectInterval time.Duration   `yaml:"reconnectInterval" json:"reconnectInterval"`
 	EmitEventTimeout  time.Duration   `yaml:"emitEventTimeout" json:"emitEventTimeout"`
+	Delivery          DeliveryConfig  `yaml:"delivery" json:"delivery"`
 	TLS               cmClient.Config `yaml:"tls" json:"tls"`
 }
 
@@ -157,12 +165,37 @@ func (c *HTTPSubscriptionConfig) Validate() error {
 	if c.EmitEventTimeout <= 0 {
 		return fmt.Errorf("emitEventTimeout('%v')", c.EmitEventTimeout)
 	}
+	if err := c.Delivery.Validate(); err != nil {
+		return fmt.Errorf("delivery.%w", err)
+	}
 	if err := c.TLS.Validate(); err != nil {
 		return fmt.Errorf("tls.%w", err)
 	}
 	return nil
 }
 
+// DeliveryConfig configures retries of failed deliveries of events to the subscribers.
+type DeliveryConfig struct {
+	// InitialBackoff is the delay of the first retry, it is doubled by each next retry. Failed deliveries are checked with this period.
+	InitialBackoff time.Duration `yaml:"initialBackoff" json:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff" json:"maxBackoff"`
+	// MaxAge is the time after which the undelivered event is moved to the dead-letter.
+	MaxAge time.Duration `yaml:"maxAge" json:"maxAge"`
+}
+
+func (c *DeliveryConfig) Validate() error {
+	if c.InitialBackoff <= 0 {
+		return fmt.Errorf("initialBackoff('%v')", c.InitialBackoff)
+	}
+	if c.MaxBackoff < c.InitialBackoff {
+		return fmt.Errorf("maxBackoff('%v')", c.MaxBackoff)
+	}
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("maxAge('%v')", c.MaxAge)
+	}
+	return nil
+}
+
 // Return string representation of Config
 func (c Config) String() string {
 	return config.ToString(c)
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+)
+
+func (rh *RequestHandler) createResource(w http.ResponseWriter, r *http.Request) (int, error) {
+	correlationUUID, err := uuid.NewRandom()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("cannot create correlationID: %w", err)
+	}
+
+	contentType := r.Header.Get(events.ContentTypeKey)
+
+	routeVars := mux.Vars(r)
+	deviceID := routeVars[deviceIDKey]
+	href := routeVars[HrefKey]
+
+	buffer := bytes.NewBuffer(make([]byte, 0, 1024))
+	_, err = buffer.ReadFrom(r.Body)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("cannot read body: %w", err)
+	}
+
+	createCommand := &commands.CreateResourceRequest{
+		ResourceId:    commands.NewResourceID(deviceID, href),
+		CorrelationId: correlationUUID.String(),
+		Content: &commands.Content{
+			Data:              buffer.Bytes(),
+			ContentType:       contentType,
+			CoapContentFormat: -1,
+		},
+		CommandMetadata: &commands.CommandMetadata{
+			ConnectionId: r.RemoteAddr,
+		},
+	}
+
+	createdEvent, err := rh.raClient.SyncCreateResource(r.Context(), "*", createCommand)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("cannot create resource: %w", err)
+	}
+	return sendResponse(w, createdEvent)
+}
+
+// CreateResource creates a resource in the collection, e.g. a link of a scene or of a rule.
+func (rh *RequestHandler) CreateResource(w http.ResponseWriter, r *http.Request) {
+	statusCode, err := rh.createResource(w, r)
+	if err != nil {
+		logAndWriteErrorResponse(fmt.Errorf("cannot create resource: %w", err), statusCode, w)
+	}
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/plgd-dev/device/schema/device"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/uri"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	testHttp "github.com/plgd-dev/hub/test/http"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestRequestHandlerCreateResource(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	type args struct {
+		href string
+		data map[string]interface{}
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantCode int
+		wantHref string
+	}{
+		{
+			name: "invalid Href",
+			args: args{
+				href: "/unknown",
+				data: test.MakeSwitchResourceDefaultData(),
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "/oic/d - PermissionDenied",
+			args: args{
+				href: device.ResourceURI,
+				data: test.MakeSwitchResourceDefaultData(),
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "create /switches/1",
+			args: args{
+				href: test.TestResourceSwitchesHref,
+				data: test.MakeSwitchResourceDefaultData(),
+			},
+			wantCode: http.StatusCreated,
+			wantHref: test.TestResourceSwitchesInstanceHref("1"),
+		},
+		{
+			name: "create /switches/2",
+			args: args{
+				href: test.TestResourceSwitchesHref,
+				data: test.MakeSwitchResourceDefaultData(),
+			},
+			wantCode: http.StatusCreated,
+			wantHref: test.TestResourceSwitchesInstanceHref("2"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := bytes.NewReader(test.EncodeToCbor(t, tt.args.data))
+			req := testHttp.NewHTTPRequest(http.MethodPut, testHttp.HTTPS_SCHEME+testCfg.C2C_GW_HOST+uri.Devices+"/"+deviceID+tt.args.href, body).Accept(message.AppJSON.String()).Build(ctx, t)
+			req.Header.Set(events.ContentTypeKey, message.AppOcfCbor.String())
+			resp := testHttp.DoHTTPRequest(t, req)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			require.Equal(t, tt.wantCode, resp.StatusCode)
+			if tt.wantHref == "" {
+				return
+			}
+			require.Equal(t, message.AppJSON.String(), resp.Header.Get(events.ContentTypeKey))
+			got := testHttp.ReadHTTPResponse(t, resp.Body, message.AppJSON.String())
+			created, ok := got.(map[interface{}]interface{})
+			require.True(t, ok)
+			require.Equal(t, tt.wantHref, created["href"])
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+)
+
+func (rh *RequestHandler) deleteResource(w http.ResponseWriter, r *http.Request) (int, error) {
+	correlationUUID, err := uuid.NewRandom()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("cannot create correlationID: %w", err)
+	}
+
+	routeVars := mux.Vars(r)
+	deviceID := routeVars[deviceIDKey]
+	href := routeVars[HrefKey]
+
+	deleteCommand := &commands.DeleteResourceRequest{
+		ResourceId:    commands.NewResourceID(deviceID, href),
+		CorrelationId: correlationUUID.String(),
+		CommandMetadata: &commands.CommandMetadata{
+			ConnectionId: r.RemoteAddr,
+		},
+	}
+
+	deletedEvent, err := rh.raClient.SyncDeleteResource(r.Context(), "*", deleteCommand)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("cannot delete resource: %w", err)
+	}
+	return sendResponse(w, deletedEvent)
+}
+
+// DeleteResource deletes the resource, e.g. a link of a scene or of a rule in the collection.
+func (rh *RequestHandler) DeleteResource(w http.ResponseWriter, r *http.Request) {
+	statusCode, err := rh.deleteResource(w, r)
+	if err != nil {
+		logAndWriteErrorResponse(fmt.Errorf("cannot delete resource: %w", err), statusCode, w)
+	}
+}
@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/plgd-dev/device/schema/device"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/uri"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	testHttp "github.com/plgd-dev/hub/test/http"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestRequestHandlerDeleteResource(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	tests := []struct {
+		name     string
+		href     string
+		wantCode int
+	}{
+		{
+			name:     "/light/1 - MethodNotAllowed",
+			href:     test.TestResourceLightInstanceHref("1"),
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "invalid Href",
+			href:     "/unknown",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "/oic/d - PermissionDenied",
+			href:     device.ResourceURI,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "delete /switches/1",
+			href:     test.TestResourceSwitchesInstanceHref("1"),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "delete /switches/1 again",
+			href:     test.TestResourceSwitchesInstanceHref("1"),
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+	test.AddDeviceSwitchResources(ctx, t, deviceID, c, "1")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testHttp.NewHTTPRequest(http.MethodDelete, testHttp.HTTPS_SCHEME+testCfg.C2C_GW_HOST+uri.Devices+"/"+deviceID+tt.href, nil).Build(ctx, t)
+			resp := testHttp.DoHTTPRequest(t, req)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			require.Equal(t, tt.wantCode, resp.StatusCode)
+		})
+	}
+}
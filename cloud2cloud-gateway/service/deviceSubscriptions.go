@@ -74,3 +74,46 @@ type resourceUnpublishedHandler struct {
 func (h *resourceUnpublishedHandler) HandleResourceUnpublished(ctx context.Context, val *raEvents.ResourceLinksUnpublished) error {
 	return h.h.HandleResourceUnpublished(ctx, val)
 }
+
+// extendedDeviceSubscriptionHandler handles the resource published/unpublished and the device metadata events,
+// it emits only the events requested by the subscription.
+type extendedDeviceSubscriptionHandler struct {
+	deviceSubscriptionHandler
+}
+
+func (h *extendedDeviceSubscriptionHandler) HandleResourcePublished(ctx context.Context, val *raEvents.ResourceLinksPublished) error {
+	if !h.subData.Data().EventTypes.Has(events.EventType_ResourcesPublished) {
+		return nil
+	}
+	return h.deviceSubscriptionHandler.HandleResourcePublished(ctx, val)
+}
+
+func (h *extendedDeviceSubscriptionHandler) HandleResourceUnpublished(ctx context.Context, val *raEvents.ResourceLinksUnpublished) error {
+	if !h.subData.Data().EventTypes.Has(events.EventType_ResourcesUnpublished) {
+		return nil
+	}
+	return h.deviceSubscriptionHandler.HandleResourceUnpublished(ctx, val)
+}
+
+func (h *extendedDeviceSubscriptionHandler) HandleDeviceMetadataUpdatePending(ctx context.Context, val *raEvents.DeviceMetadataUpdatePending) error {
+	return h.subData.emitRequestedEvent(ctx, h.emitEvent, events.EventType_DeviceMetadataUpdatePending, func() (interface{}, error) {
+		return events.DeviceMetadataUpdatePending{
+			DeviceID:              val.GetDeviceId(),
+			CorrelationID:         val.GetAuditContext().GetCorrelationId(),
+			ShadowSynchronization: val.GetShadowSynchronization().String(),
+			ValidUntil:            val.GetValidUntil(),
+		}, nil
+	})
+}
+
+func (h *extendedDeviceSubscriptionHandler) HandleDeviceMetadataUpdated(ctx context.Context, val *raEvents.DeviceMetadataUpdated) error {
+	return h.subData.emitRequestedEvent(ctx, h.emitEvent, events.EventType_DeviceMetadataUpdated, func() (interface{}, error) {
+		return events.DeviceMetadataUpdated{
+			DeviceID:              val.GetDeviceId(),
+			CorrelationID:         val.GetAuditContext().GetCorrelationId(),
+			Online:                val.GetStatus().IsOnline(),
+			ShadowSynchronization: val.GetShadowSynchronization().String(),
+			Canceled:              val.GetCanceled(),
+		}, nil
+	})
+}
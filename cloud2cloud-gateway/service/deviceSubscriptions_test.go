@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/device/schema"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	raEvents "github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendedDeviceSubscriptionHandler(t *testing.T) {
+	const deviceID = "deviceID"
+	auditContext := commands.NewAuditContext("userID", "correlationID")
+
+	var e testEmitter
+	h := extendedDeviceSubscriptionHandler{
+		deviceSubscriptionHandler: deviceSubscriptionHandler{
+			subData:   newTestSubscriptionData(events.EventType_ResourcesPublished, events.EventType_DeviceMetadataUpdatePending, events.EventType_DeviceMetadataUpdated),
+			emitEvent: e.emitEvent,
+		},
+	}
+	ctx := context.Background()
+	// resources unpublished is not requested by the subscription
+	err := h.HandleResourceUnpublished(ctx, &raEvents.ResourceLinksUnpublished{DeviceId: deviceID, Hrefs: []string{"/light/1"}})
+	require.NoError(t, err)
+	require.Empty(t, e.emitted)
+
+	err = h.HandleResourcePublished(ctx, &raEvents.ResourceLinksPublished{DeviceId: deviceID, Resources: []*commands.Resource{{DeviceId: deviceID, Href: "/light/1"}}})
+	require.NoError(t, err)
+	err = h.HandleDeviceMetadataUpdatePending(ctx, &raEvents.DeviceMetadataUpdatePending{
+		DeviceId:     deviceID,
+		AuditContext: auditContext,
+		ValidUntil:   1,
+		UpdatePending: &raEvents.DeviceMetadataUpdatePending_ShadowSynchronization{
+			ShadowSynchronization: commands.ShadowSynchronization_DISABLED,
+		},
+	})
+	require.NoError(t, err)
+	err = h.HandleDeviceMetadataUpdated(ctx, &raEvents.DeviceMetadataUpdated{
+		DeviceId:              deviceID,
+		AuditContext:          auditContext,
+		Status:                &commands.ConnectionStatus{Value: commands.ConnectionStatus_ONLINE},
+		ShadowSynchronization: commands.ShadowSynchronization_DISABLED,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, e.emitted, 3)
+	require.Equal(t, events.EventType_ResourcesPublished, e.emitted[0].eventType)
+	published, ok := e.emitted[0].rep.([]schema.ResourceLink)
+	require.True(t, ok)
+	require.Equal(t, []schema.ResourceLink{{DeviceID: deviceID, Href: "/" + deviceID + "/light/1"}}, published)
+	require.Equal(t, testEmittedEvent{
+		eventType: events.EventType_DeviceMetadataUpdatePending,
+		seqNum:    1,
+		rep: events.DeviceMetadataUpdatePending{
+			DeviceID:              deviceID,
+			CorrelationID:         "correlationID",
+			ShadowSynchronization: commands.ShadowSynchronization_DISABLED.String(),
+			ValidUntil:            1,
+		},
+	}, e.emitted[1])
+	require.Equal(t, testEmittedEvent{
+		eventType: events.EventType_DeviceMetadataUpdated,
+		seqNum:    2,
+		rep: events.DeviceMetadataUpdated{
+			DeviceID:              deviceID,
+			CorrelationID:         "correlationID",
+			Online:                true,
+			ShadowSynchronization: commands.ShadowSynchronization_DISABLED.String(),
+		},
+	}, e.emitted[2])
+}
@@ -7,8 +7,10 @@ import (
 	"io/ioutil"
 	netHttp "net/http"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
 	"github.com/plgd-dev/hub/cloud2cloud-gateway/store"
 	"github.com/plgd-dev/hub/pkg/log"
@@ -30,29 +32,47 @@ func makeEmitEventRequestBody(accept []string, rep interface{}) ([]byte, string,
 	return body, contentType, nil
 }
 
-func makeEmitEventRequest(ctx context.Context, eventType events.EventType, s store.Subscription, seqNum uint64, rep interface{}) (*netHttp.Request, error) {
+func makeDelivery(eventType events.EventType, s store.Subscription, seqNum uint64, rep interface{}) (store.Delivery, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return store.Delivery{}, fmt.Errorf("cannot generate delivery id: %w", err)
+	}
+	now := time.Now()
+	d := store.Delivery{
+		ID:             id.String(),
+		SubscriptionID: s.ID,
+		SequenceNumber: seqNum,
+		EventType:      eventType,
+		Timestamp:      now,
+		QueuedAt:       now,
+		Status:         store.DeliveryStatus_Pending,
+		NextAttempt:    now,
+	}
+	if rep != nil {
+		d.Body, d.ContentType, err = makeEmitEventRequestBody(s.Accept, rep)
+		if err != nil {
+			return store.Delivery{}, fmt.Errorf("cannot create post request body: %w", err)
+		}
+	}
+	return d, nil
+}
+
+func makeEmitEventRequest(ctx context.Context, s store.Subscription, d store.Delivery) (*netHttp.Request, error) {
 	r, w := io.Pipe()
 	req, err := netHttp.NewRequestWithContext(ctx, netHttp.MethodPost, s.URL, r)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create post request: %w", err)
 	}
-	timestamp := time.Now()
-	req.Header.Set(events.EventTypeKey, string(eventType))
+	req.Header.Set(events.EventTypeKey, string(d.EventType))
 	req.Header.Set(events.SubscriptionIDKey, s.ID)
-	req.Header.Set(events.SequenceNumberKey, strconv.FormatUint(seqNum, 10))
+	req.Header.Set(events.SequenceNumberKey, strconv.FormatUint(d.SequenceNumber, 10))
 	req.Header.Set(events.CorrelationIDKey, s.CorrelationID)
-	req.Header.Set(events.EventTimestampKey, strconv.FormatInt(timestamp.Unix(), 10))
-
-	var body []byte
-	if rep != nil {
-		var contentType string
-		body, contentType, err = makeEmitEventRequestBody(s.Accept, rep)
-		if err != nil {
-			return nil, fmt.Errorf("cannot create post request body: %w", err)
-		}
-		req.Header.Set(events.ContentTypeKey, contentType)
+	req.Header.Set(events.EventTimestampKey, strconv.FormatInt(d.Timestamp.Unix(), 10))
+	if d.ContentType != "" {
+		req.Header.Set(events.ContentTypeKey, d.ContentType)
 	}
 
+	body := d.Body
 	if len(body) > 0 {
 		go func() {
 			defer func() {
@@ -74,10 +94,10 @@ func makeEmitEventRequest(ctx context.Context, eventType events.EventType, s sto
 	req.Header.Set(events.EventSignatureKey, events.CalculateEventSignature(
 		s.SigningSecret,
 		req.Header.Get(events.ContentTypeKey),
-		eventType,
+		d.EventType,
 		req.Header.Get(events.SubscriptionIDKey),
-		seqNum,
-		timestamp,
+		d.SequenceNumber,
+		d.Timestamp,
 		body,
 	))
 	req.Header.Set("Connection", "close")
@@ -86,8 +106,27 @@ func makeEmitEventRequest(ctx context.Context, eventType events.EventType, s sto
 	return req, nil
 }
 
-func createEmitEventFunc(cfg cmClient.Config, timeout time.Duration, logger log.Logger) (emitEventFunc, func(), error) {
-	certManager, err := cmClient.New(cfg, logger)
+// deliveryQueue stores emitted events per subscription and delivers them in the order of the sequence numbers.
+// Failed deliveries are retried with an exponential backoff, deliveries which are not delivered within the max age
+// are moved to the dead-letter and they can be replayed on demand.
+type deliveryQueue struct {
+	store   store.Store
+	client  netHttp.Client
+	timeout time.Duration
+	cfg     DeliveryConfig
+
+	locksMutex sync.Mutex
+	locks      map[string]*subscriptionLock
+}
+
+// subscriptionLock is removed from the locks of the queue when nobody holds or waits for it.
+type subscriptionLock struct {
+	sync.Mutex
+	refs int
+}
+
+func createDeliveryQueue(cfg HTTPSubscriptionConfig, store store.Store, logger log.Logger) (*deliveryQueue, func(), error) {
+	certManager, err := cmClient.New(cfg.TLS, logger)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cannot create cert manager: %w", err)
 	}
@@ -96,39 +135,259 @@ func createEmitEventFunc(cfg cmClient.Config, timeout time.Duration, logger log.
 	}
 	trans := netHttp.DefaultTransport.(*netHttp.Transport).Clone()
 	trans.TLSClientConfig = certManager.GetTLSConfig()
-	client := netHttp.Client{
-		Transport: trans,
+	return &deliveryQueue{
+		store: store,
+		client: netHttp.Client{
+			Transport: trans,
+		},
+		timeout: cfg.EmitEventTimeout,
+		cfg:     cfg.Delivery,
+		locks:   make(map[string]*subscriptionLock),
+	}, closeFunc, nil
+}
+
+// lock serializes the deliveries of the subscription.
+func (q *deliveryQueue) lock(subscriptionID string) func() {
+	q.locksMutex.Lock()
+	l, ok := q.locks[subscriptionID]
+	if !ok {
+		l = &subscriptionLock{}
+		q.locks[subscriptionID] = l
+	}
+	l.refs++
+	q.locksMutex.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		q.locksMutex.Lock()
+		defer q.locksMutex.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(q.locks, subscriptionID)
+		}
+	}
+}
+
+func (q *deliveryQueue) backoff(attempts int) time.Duration {
+	backoff := q.cfg.InitialBackoff
+	for i := 1; i < attempts && backoff < q.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > q.cfg.MaxBackoff {
+		return q.cfg.MaxBackoff
+	}
+	return backoff
+}
+
+// send posts the event to the subscriber, gone is set when the subscriber doesn't want to receive events anymore.
+func (q *deliveryQueue) send(ctx context.Context, s store.Subscription, d store.Delivery) (gone bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+
+	req, err := makeEmitEventRequest(ctx, s, d)
+	if err != nil {
+		return false, fmt.Errorf("cannot create request: %w", err)
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("cannot post: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Errorf("failed to close response body stream: %w", err)
+		}
+	}()
+	if resp.StatusCode != netHttp.StatusOK {
+		errBody, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode == netHttp.StatusGone, fmt.Errorf("%v: unexpected statusCode %v: body: '%v'", s.URL, resp.StatusCode, string(errBody))
+	}
+	return false, nil
+}
+
+type deliveryLoader struct {
+	deliveries []store.Delivery
+}
+
+func (l *deliveryLoader) Handle(ctx context.Context, iter store.DeliveryIter) error {
+	for {
+		var d store.Delivery
+		if !iter.Next(ctx, &d) {
+			break
+		}
+		l.deliveries = append(l.deliveries, d)
+	}
+	return iter.Err()
+}
+
+func (q *deliveryQueue) loadDeliveries(ctx context.Context, query store.DeliveryQuery) ([]store.Delivery, error) {
+	var h deliveryLoader
+	if err := q.store.LoadDeliveries(ctx, query, &h); err != nil {
+		return nil, fmt.Errorf("cannot load deliveries: %w", err)
+	}
+	return h.deliveries, nil
+}
+
+// deliver sends pending events of the subscription in the order of the sequence numbers. It stops on the first
+// failure, so the following events wait for the retry of the failed one. The caller must hold the lock of the subscription.
+func (q *deliveryQueue) deliver(ctx context.Context, s store.Subscription) (remove bool, err error) {
+	deliveries, err := q.loadDeliveries(ctx, store.DeliveryQuery{
+		SubscriptionID: s.ID,
+		Status:         store.DeliveryStatus_Pending,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, d := range deliveries {
+		now := time.Now()
+		if now.Sub(d.QueuedAt) > q.cfg.MaxAge {
+			log.Errorf("cannot deliver event %v with sequence number %v to subscription %v: max age exceeded: %v", d.EventType, d.SequenceNumber, s.ID, d.LastError)
+			d.Status = store.DeliveryStatus_Failed
+			if err := q.store.SaveDelivery(ctx, d); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if d.NextAttempt.After(now) {
+			// the event is delivered by the next retry
+			return false, nil
+		}
+		gone, err := q.send(ctx, s, d)
+		if gone {
+			return true, err
+		}
+		if err != nil {
+			d.Attempts++
+			d.NextAttempt = now.Add(q.backoff(d.Attempts))
+			d.LastError = err.Error()
+			if errSave := q.store.SaveDelivery(ctx, d); errSave != nil {
+				log.Errorf("cannot schedule retry of event %v with sequence number %v to subscription %v: %w", d.EventType, d.SequenceNumber, s.ID, errSave)
+			}
+			return false, err
+		}
+		if err := q.store.RemoveDelivery(ctx, d.ID); err != nil {
+			return false, err
+		}
 	}
-	emitFunc := func(ctx context.Context, eventType events.EventType, s store.Subscription, incrementSubscriptionSequenceNumber incrementSubscriptionSequenceNumberFunc, rep interface{}) (remove bool, err error) {
-		log.Debugf("emitEvent: %v: %+v", eventType, s)
-		ctx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
+	return false, nil
+}
 
+// EmitEvent queues the event and delivers the pending events of the subscription.
+func (q *deliveryQueue) EmitEvent(ctx context.Context, eventType events.EventType, s store.Subscription, incrementSubscriptionSequenceNumber incrementSubscriptionSequenceNumberFunc, rep interface{}) (remove bool, err error) {
+	log.Debugf("emitEvent: %v: %+v", eventType, s)
+	if eventType == events.EventType_SubscriptionCanceled {
+		// the subscription was removed with its queue, so the event is sent just once
 		seqNum, err := incrementSubscriptionSequenceNumber(ctx)
 		if err != nil {
 			return false, fmt.Errorf("cannot increment sequence number: %w", err)
 		}
-
-		req, err := makeEmitEventRequest(ctx, eventType, s, seqNum, rep)
+		d, err := makeDelivery(eventType, s, seqNum, rep)
 		if err != nil {
-			return false, fmt.Errorf("cannot create request: %w", err)
+			return false, err
+		}
+		if _, err := q.send(ctx, s, d); err != nil {
+			return true, err
+		}
+		return true, nil
+	}
+
+	unlock := q.lock(s.ID)
+	defer unlock()
+
+	queueCtx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+	seqNum, err := incrementSubscriptionSequenceNumber(queueCtx)
+	if err != nil {
+		return false, fmt.Errorf("cannot increment sequence number: %w", err)
+	}
+	d, err := makeDelivery(eventType, s, seqNum, rep)
+	if err != nil {
+		return false, err
+	}
+	if err := q.store.SaveDelivery(queueCtx, d); err != nil {
+		return false, fmt.Errorf("cannot queue event: %w", err)
+	}
+	return q.deliver(ctx, s)
+}
+
+type subscriptionsHandler struct {
+	subs []store.Subscription
+}
+
+func (h *subscriptionsHandler) Handle(ctx context.Context, iter store.SubscriptionIter) error {
+	for {
+		var s store.Subscription
+		if !iter.Next(ctx, &s) {
+			break
 		}
+		h.subs = append(h.subs, s)
+	}
+	return iter.Err()
+}
+
+func (q *deliveryQueue) loadSubscription(ctx context.Context, subscriptionID string) (store.Subscription, bool, error) {
+	var h subscriptionsHandler
+	if err := q.store.LoadSubscriptions(ctx, store.SubscriptionQuery{SubscriptionID: subscriptionID}, &h); err != nil {
+		return store.Subscription{}, false, err
+	}
+	if len(h.subs) == 0 {
+		return store.Subscription{}, false, nil
+	}
+	return h.subs[0], true, nil
+}
 
-		resp, err := client.Do(req)
+func (q *deliveryQueue) retry(ctx context.Context, removeSubscription func(ctx context.Context, subscriptionID string)) error {
+	subscriptionIDs, err := q.store.LoadPendingDeliverySubscriptionIDs(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, subscriptionID := range subscriptionIDs {
+		s, ok, err := q.loadSubscription(ctx, subscriptionID)
 		if err != nil {
-			return false, fmt.Errorf("cannot post: %w", err)
+			log.Errorf("cannot load subscription %v: %w", subscriptionID, err)
+			continue
 		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				log.Errorf("failed to close response body stream: %w", err)
-			}
-		}()
-		if resp.StatusCode != netHttp.StatusOK {
-			errBody, _ := ioutil.ReadAll(resp.Body)
-			return resp.StatusCode == netHttp.StatusGone, fmt.Errorf("%v: unexpected statusCode %v: body: '%v'", s.URL, resp.StatusCode, string(errBody))
+		if !ok {
+			continue
+		}
+		unlock := q.lock(subscriptionID)
+		remove, err := q.deliver(ctx, s)
+		unlock()
+		if err != nil {
+			log.Errorf("cannot deliver events to subscription %v: %w", subscriptionID, err)
+		}
+		if remove {
+			removeSubscription(ctx, subscriptionID)
+		}
+	}
+	return nil
+}
+
+// Run retries failed deliveries until the context is canceled.
+func (q *deliveryQueue) Run(ctx context.Context, removeSubscription func(ctx context.Context, subscriptionID string)) {
+	ticker := time.NewTicker(q.cfg.InitialBackoff)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := q.retry(ctx, removeSubscription); err != nil {
+			log.Errorf("cannot retry deliveries: %w", err)
 		}
-		return eventType == events.EventType_SubscriptionCanceled, nil
 	}
+}
+
+// Deliveries returns pending and failed deliveries of the subscription.
+func (q *deliveryQueue) Deliveries(ctx context.Context, subscriptionID string) ([]store.Delivery, error) {
+	return q.loadDeliveries(ctx, store.DeliveryQuery{
+		SubscriptionID: subscriptionID,
+	})
+}
 
-	return emitFunc, closeFunc, nil
+// Replay moves failed deliveries of the subscription back to the queue and returns the number of them.
+func (q *deliveryQueue) Replay(ctx context.Context, subscriptionID string) (int64, error) {
+	return q.store.ReplayDeliveries(ctx, subscriptionID)
 }
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/store"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/store/mongodb"
+	"github.com/plgd-dev/hub/pkg/log"
+	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
+	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubscriptionID = "b8b7d3a6-8ed2-4a4b-9b0b-5f2f7b8f5c7d"
+
+func newTestStore(t *testing.T) (*mongodb.Store, func()) {
+	logger, err := log.NewLogger(log.Config{Debug: true})
+	require.NoError(t, err)
+	cfg := pkgMongo.Config{
+		URI:      config.MONGODB_URI,
+		Database: config.C2C_GW_DB,
+		TLS:      config.MakeTLSClientConfig(),
+	}
+	certManager, err := cmClient.New(cfg.TLS, logger)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	s, err := mongodb.NewStore(ctx, cfg, certManager.GetTLSConfig())
+	require.NoError(t, err)
+	return s, func() {
+		err := s.Clear(ctx)
+		require.NoError(t, err)
+		_ = s.Close(ctx)
+		certManager.Close()
+	}
+}
+
+// testEventReceiver records sequence numbers of received events, it rejects events while it fails.
+type testEventReceiver struct {
+	lock     sync.Mutex
+	fail     bool
+	received []uint64
+	attempts int
+}
+
+func (r *testEventReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.attempts++
+	if r.fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	seqNum, err := strconv.ParseUint(req.Header.Get(events.SequenceNumberKey), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.received = append(r.received, seqNum)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *testEventReceiver) setFail(fail bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fail = fail
+}
+
+func (r *testEventReceiver) get() (received []uint64, attempts int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]uint64(nil), r.received...), r.attempts
+}
+
+func newTestDeliveryQueue(t *testing.T, s store.Store, cfg DeliveryConfig) (*deliveryQueue, *testEventReceiver, store.Subscription, func()) {
+	receiver := &testEventReceiver{}
+	srv := httptest.NewServer(receiver)
+	sub := store.Subscription{
+		ID:            testSubscriptionID,
+		URL:           srv.URL,
+		CorrelationID: "correlationID",
+		Accept:        []string{events.ContentType_JSON},
+		EventTypes:    []events.EventType{events.EventType_DevicesOnline},
+		SigningSecret: "signingSecret",
+		Type:          store.Type_Devices,
+		AccessToken:   oauthTest.GetDefaultServiceToken(t),
+	}
+	err := s.SaveSubscription(context.Background(), sub)
+	require.NoError(t, err)
+	return &deliveryQueue{
+		store:   s,
+		timeout: time.Second * 5,
+		cfg:     cfg,
+		locks:   make(map[string]*subscriptionLock),
+	}, receiver, sub, srv.Close
+}
+
+func emitTestEvent(ctx context.Context, t *testing.T, q *deliveryQueue, sub store.Subscription) error {
+	remove, err := q.EmitEvent(ctx, events.EventType_DevicesOnline, sub, func(ctx context.Context) (uint64, error) {
+		return q.store.IncrementSubscriptionSequenceNumber(ctx, sub.ID)
+	}, events.DevicesOnline{})
+	require.False(t, remove)
+	return err
+}
+
+func noRemoveSubscription(t *testing.T) func(ctx context.Context, subscriptionID string) {
+	return func(ctx context.Context, subscriptionID string) {
+		require.FailNowf(t, "unexpected removal of subscription", "subscription %v", subscriptionID)
+	}
+}
+
+func TestDeliveryQueueBackoff(t *testing.T) {
+	q := deliveryQueue{
+		cfg: DeliveryConfig{
+			InitialBackoff: time.Second,
+			MaxBackoff:     time.Second * 10,
+		},
+	}
+	require.Equal(t, time.Second, q.backoff(1))
+	require.Equal(t, time.Second*2, q.backoff(2))
+	require.Equal(t, time.Second*4, q.backoff(3))
+	require.Equal(t, time.Second*8, q.backoff(4))
+	require.Equal(t, time.Second*10, q.backoff(5))
+	require.Equal(t, time.Second*10, q.backoff(100))
+}
+
+func TestDeliveryQueueOrdering(t *testing.T) {
+	s, cleanUpStore := newTestStore(t)
+	defer cleanUpStore()
+	shutdown := oauthTest.SetUp(t)
+	defer shutdown()
+
+	q, receiver, sub, closeReceiver := newTestDeliveryQueue(t, s, DeliveryConfig{
+		InitialBackoff: time.Millisecond * 500,
+		MaxBackoff:     time.Second,
+		MaxAge:         time.Hour,
+	})
+	defer closeReceiver()
+	ctx := context.Background()
+
+	receiver.setFail(true)
+	err := emitTestEvent(ctx, t, q, sub)
+	require.Error(t, err)
+	receiver.setFail(false)
+
+	// the following events wait for the retry of the failed one
+	for i := 0; i < 2; i++ {
+		err = emitTestEvent(ctx, t, q, sub)
+		require.NoError(t, err)
+	}
+	received, attempts := receiver.get()
+	require.Empty(t, received)
+	require.Equal(t, 1, attempts)
+
+	deliveries, err := q.Deliveries(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	require.Equal(t, 1, deliveries[0].Attempts)
+	require.NotEmpty(t, deliveries[0].LastError)
+
+	// the retry is scheduled by the backoff
+	err = q.retry(ctx, noRemoveSubscription(t))
+	require.NoError(t, err)
+	received, _ = receiver.get()
+	require.Empty(t, received)
+
+	time.Sleep(q.cfg.InitialBackoff)
+	err = q.retry(ctx, noRemoveSubscription(t))
+	require.NoError(t, err)
+	received, _ = receiver.get()
+	require.Equal(t, []uint64{0, 1, 2}, received)
+
+	deliveries, err = q.Deliveries(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+	require.Empty(t, q.locks)
+}
+
+func TestDeliveryQueueMaxAge(t *testing.T) {
+	s, cleanUpStore := newTestStore(t)
+	defer cleanUpStore()
+	shutdown := oauthTest.SetUp(t)
+	defer shutdown()
+
+	q, receiver, sub, closeReceiver := newTestDeliveryQueue(t, s, DeliveryConfig{
+		InitialBackoff: time.Millisecond * 100,
+		MaxBackoff:     time.Millisecond * 100,
+		MaxAge:         time.Millisecond * 500,
+	})
+	defer closeReceiver()
+	ctx := context.Background()
+
+	receiver.setFail(true)
+	err := emitTestEvent(ctx, t, q, sub)
+	require.Error(t, err)
+
+	// the delivery is moved to the dead-letter when the max age is exceeded
+	time.Sleep(q.cfg.MaxAge + q.cfg.InitialBackoff)
+	err = q.retry(ctx, noRemoveSubscription(t))
+	require.NoError(t, err)
+	deliveries, err := q.Deliveries(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, store.DeliveryStatus_Failed, deliveries[0].Status)
+
+	// failed deliveries are not retried
+	_, attempts := receiver.get()
+	time.Sleep(q.cfg.MaxBackoff)
+	err = q.retry(ctx, noRemoveSubscription(t))
+	require.NoError(t, err)
+	_, attemptsAfterRetry := receiver.get()
+	require.Equal(t, attempts, attemptsAfterRetry)
+
+	receiver.setFail(false)
+	replayed, err := q.Replay(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), replayed)
+	err = q.retry(ctx, noRemoveSubscription(t))
+	require.NoError(t, err)
+	received, _ := receiver.get()
+	require.Equal(t, []uint64{0}, received)
+	deliveries, err = q.Deliveries(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+}
+
+func TestRequestHandlerSubscriptionDeliveries(t *testing.T) {
+	s, cleanUpStore := newTestStore(t)
+	defer cleanUpStore()
+	shutdown := oauthTest.SetUp(t)
+	defer shutdown()
+
+	q, receiver, sub, closeReceiver := newTestDeliveryQueue(t, s, DeliveryConfig{
+		InitialBackoff: time.Millisecond * 100,
+		MaxBackoff:     time.Millisecond * 100,
+		MaxAge:         time.Millisecond * 100,
+	})
+	defer closeReceiver()
+	ctx := context.Background()
+	subMgr := NewSubscriptionManager(ctx, s, nil, time.Second, q.EmitEvent)
+	subMgr.storeToSubs(sub)
+	rh := NewRequestHandler(nil, nil, subMgr, q)
+
+	receiver.setFail(true)
+	err := emitTestEvent(ctx, t, q, sub)
+	require.Error(t, err)
+	time.Sleep(q.cfg.MaxAge + q.cfg.InitialBackoff)
+	err = q.retry(ctx, noRemoveSubscription(t))
+	require.NoError(t, err)
+
+	retrieve := func(subscriptionID string) (int, []DeliveryResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/subscriptions/"+subscriptionID+"/deliveries", nil)
+		req = mux.SetURLVars(req, map[string]string{subscriptionIDKey: subscriptionID})
+		w := httptest.NewRecorder()
+		rh.RetrieveSubscriptionDeliveries(w, req)
+		var resp []DeliveryResponse
+		if w.Code == http.StatusOK {
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			require.NoError(t, err)
+		}
+		return w.Code, resp
+	}
+	replay := func(subscriptionID string) (int, ReplayDeliveriesResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/subscriptions/"+subscriptionID+"/deliveries/replay", nil)
+		req = mux.SetURLVars(req, map[string]string{subscriptionIDKey: subscriptionID})
+		w := httptest.NewRecorder()
+		rh.ReplaySubscriptionDeliveries(w, req)
+		var resp ReplayDeliveriesResponse
+		if w.Code == http.StatusAccepted {
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			require.NoError(t, err)
+		}
+		return w.Code, resp
+	}
+
+	code, deliveries := retrieve(sub.ID)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, uint64(0), deliveries[0].SequenceNumber)
+	require.Equal(t, string(events.EventType_DevicesOnline), deliveries[0].EventType)
+	require.Equal(t, string(store.DeliveryStatus_Failed), deliveries[0].Status)
+	require.NotEmpty(t, deliveries[0].LastError)
+
+	code, _ = retrieve("notFound")
+	require.Equal(t, http.StatusNotFound, code)
+
+	code, replayed := replay(sub.ID)
+	require.Equal(t, http.StatusAccepted, code)
+	require.Equal(t, int64(1), replayed.Replayed)
+
+	code, deliveries = retrieve(sub.ID)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, string(store.DeliveryStatus_Pending), deliveries[0].Status)
+	require.Equal(t, 0, deliveries[0].Attempts)
+
+	code, _ = replay("notFound")
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -35,10 +35,11 @@ type ListDevicesOfUserFunc func(ctx context.Context, correlationID, userID, acce
 
 //RequestHandler for handling incoming request
 type RequestHandler struct {
-	gwClient  pbGRPC.GrpcGatewayClient
-	raClient  *raClient.Client
-	subMgr    *SubscriptionManager
-	emitEvent emitEventFunc
+	gwClient      pbGRPC.GrpcGatewayClient
+	raClient      *raClient.Client
+	subMgr        *SubscriptionManager
+	deliveryQueue *deliveryQueue
+	emitEvent     emitEventFunc
 }
 
 func logAndWriteErrorResponse(err error, statusCode int, w http.ResponseWriter) {
@@ -55,13 +56,14 @@ func NewRequestHandler(
 	gwClient pbGRPC.GrpcGatewayClient,
 	raClient *raClient.Client,
 	subMgr *SubscriptionManager,
-	emitEvent emitEventFunc,
+	deliveryQueue *deliveryQueue,
 ) *RequestHandler {
 	return &RequestHandler{
-		gwClient:  gwClient,
-		raClient:  raClient,
-		subMgr:    subMgr,
-		emitEvent: emitEvent,
+		gwClient:      gwClient,
+		raClient:      raClient,
+		subMgr:        subMgr,
+		deliveryQueue: deliveryQueue,
+		emitEvent:     deliveryQueue.EmitEvent,
 	}
 }
 
@@ -200,6 +202,10 @@ func NewHTTP(requestHandler *RequestHandler, authInterceptor kitNetHttp.Intercep
 	r.HandleFunc(uri.DeviceSubscription, requestHandler.RetrieveDeviceSubscription).Methods("GET")
 	r.HandleFunc(uri.DeviceSubscription, requestHandler.UnsubscribeFromDevice).Methods("DELETE")
 
+	// deliveries of subscription
+	r.HandleFunc(uri.SubscriptionDeliveries, requestHandler.RetrieveSubscriptionDeliveries).Methods("GET")
+	r.HandleFunc(uri.SubscriptionDeliveriesReplay, requestHandler.ReplaySubscriptionDeliveries).Methods("POST")
+
 	s1 := r.PathPrefix(uri.Device).Subrouter()
 	// resource subscription
 	s1.MatcherFunc(func(r *http.Request, rm *router.RouteMatch) bool {
@@ -220,5 +226,7 @@ func NewHTTP(requestHandler *RequestHandler, authInterceptor kitNetHttp.Intercep
 	// resource
 	s1.MatcherFunc(resourceMatcher).Methods("POST").HandlerFunc(requestHandler.UpdateResource)
 	s1.MatcherFunc(resourceMatcher).Methods("GET").HandlerFunc(requestHandler.RetrieveResource)
+	s1.MatcherFunc(resourceMatcher).Methods("PUT").HandlerFunc(requestHandler.CreateResource)
+	s1.MatcherFunc(resourceMatcher).Methods("DELETE").HandlerFunc(requestHandler.DeleteResource)
 	return &http.Server{Handler: r}
 }
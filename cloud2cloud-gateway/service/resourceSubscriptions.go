@@ -32,3 +32,99 @@ func (h *resourceSubscriptionHandler) HandleResourceContentChanged(ctx context.C
 	}
 	return nil
 }
+
+// extendedResourceSubscriptionHandler handles the resource content changed and the events of resource commands,
+// it emits only the events requested by the subscription.
+type extendedResourceSubscriptionHandler struct {
+	resourceSubscriptionHandler
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceContentChanged(ctx context.Context, val *raEvents.ResourceChanged) error {
+	if !h.subData.Data().EventTypes.Has(events.EventType_ResourceChanged) {
+		return nil
+	}
+	return h.resourceSubscriptionHandler.HandleResourceContentChanged(ctx, val)
+}
+
+func (h *extendedResourceSubscriptionHandler) emitExtendedEvent(ctx context.Context, eventType events.EventType, makeRep func() (interface{}, error)) error {
+	return h.subData.emitRequestedEvent(ctx, h.emitEvent, eventType, makeRep)
+}
+
+func makeResourceCommandPending(resourceID *commands.ResourceId, auditContext *commands.AuditContext, resourceInterface string, content *commands.Content, validUntil int64) (interface{}, error) {
+	var c interface{}
+	if content != nil {
+		var err error
+		c, err = unmarshalContent(content)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal content: %w", err)
+		}
+	}
+	return events.ResourceCommandPending{
+		Href:              getHref(resourceID.GetDeviceId(), resourceID.GetHref()),
+		CorrelationID:     auditContext.GetCorrelationId(),
+		ResourceInterface: resourceInterface,
+		Content:           c,
+		ValidUntil:        validUntil,
+	}, nil
+}
+
+func makeResourceCommandProcessed(resourceID *commands.ResourceId, auditContext *commands.AuditContext, status commands.Status, content *commands.Content) (interface{}, error) {
+	c, err := unmarshalContent(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal content: %w", err)
+	}
+	return events.ResourceCommandProcessed{
+		Href:          getHref(resourceID.GetDeviceId(), resourceID.GetHref()),
+		CorrelationID: auditContext.GetCorrelationId(),
+		Status:        status.String(),
+		Content:       c,
+	}, nil
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceCreatePending(ctx context.Context, val *raEvents.ResourceCreatePending) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceCreatePending, func() (interface{}, error) {
+		return makeResourceCommandPending(val.GetResourceId(), val.GetAuditContext(), "", val.GetContent(), val.GetValidUntil())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceCreated(ctx context.Context, val *raEvents.ResourceCreated) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceCreated, func() (interface{}, error) {
+		return makeResourceCommandProcessed(val.GetResourceId(), val.GetAuditContext(), val.GetStatus(), val.GetContent())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceRetrievePending(ctx context.Context, val *raEvents.ResourceRetrievePending) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceRetrievePending, func() (interface{}, error) {
+		return makeResourceCommandPending(val.GetResourceId(), val.GetAuditContext(), val.GetResourceInterface(), nil, val.GetValidUntil())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceRetrieved(ctx context.Context, val *raEvents.ResourceRetrieved) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceRetrieved, func() (interface{}, error) {
+		return makeResourceCommandProcessed(val.GetResourceId(), val.GetAuditContext(), val.GetStatus(), val.GetContent())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceUpdatePending(ctx context.Context, val *raEvents.ResourceUpdatePending) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceUpdatePending, func() (interface{}, error) {
+		return makeResourceCommandPending(val.GetResourceId(), val.GetAuditContext(), val.GetResourceInterface(), val.GetContent(), val.GetValidUntil())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceUpdated(ctx context.Context, val *raEvents.ResourceUpdated) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceUpdated, func() (interface{}, error) {
+		return makeResourceCommandProcessed(val.GetResourceId(), val.GetAuditContext(), val.GetStatus(), val.GetContent())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceDeletePending(ctx context.Context, val *raEvents.ResourceDeletePending) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceDeletePending, func() (interface{}, error) {
+		return makeResourceCommandPending(val.GetResourceId(), val.GetAuditContext(), "", nil, val.GetValidUntil())
+	})
+}
+
+func (h *extendedResourceSubscriptionHandler) HandleResourceDeleted(ctx context.Context, val *raEvents.ResourceDeleted) error {
+	return h.emitExtendedEvent(ctx, events.EventType_ResourceDeleted, func() (interface{}, error) {
+		return makeResourceCommandProcessed(val.GetResourceId(), val.GetAuditContext(), val.GetStatus(), val.GetContent())
+	})
+}
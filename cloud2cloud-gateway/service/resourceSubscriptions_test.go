@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/store"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	raEvents "github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+type testEmittedEvent struct {
+	eventType events.EventType
+	seqNum    uint64
+	rep       interface{}
+}
+
+// testEmitter records emitted events, the subscription is removed when remove is set.
+type testEmitter struct {
+	emitted []testEmittedEvent
+	remove  bool
+}
+
+func (e *testEmitter) emitEvent(ctx context.Context, eventType events.EventType, s store.Subscription, incrementSubscriptionSequenceNumber incrementSubscriptionSequenceNumberFunc, rep interface{}) (bool, error) {
+	seqNum, err := incrementSubscriptionSequenceNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	e.emitted = append(e.emitted, testEmittedEvent{
+		eventType: eventType,
+		seqNum:    seqNum,
+		rep:       rep,
+	})
+	if e.remove {
+		return true, errors.New("subscription is gone")
+	}
+	return false, nil
+}
+
+func newTestSubscriptionData(eventTypes ...events.EventType) *SubscriptionData {
+	var seqNum uint64
+	return &SubscriptionData{
+		incrementSubscriptionSequenceNumber: func(ctx context.Context, subscriptionID string) (uint64, error) {
+			v := seqNum
+			seqNum++
+			return v, nil
+		},
+		data: store.Subscription{
+			ID:         "subscriptionID",
+			EventTypes: eventTypes,
+		},
+	}
+}
+
+func TestExtendedResourceSubscriptionHandler(t *testing.T) {
+	const deviceID = "deviceID"
+	const href = "/light/1"
+	resourceID := commands.NewResourceID(deviceID, href)
+	data, err := cbor.Encode(map[string]interface{}{"power": 1})
+	require.NoError(t, err)
+	content := &commands.Content{
+		ContentType: message.AppOcfCbor.String(),
+		Data:        data,
+	}
+	auditContext := commands.NewAuditContext("userID", "correlationID")
+
+	var e testEmitter
+	h := extendedResourceSubscriptionHandler{
+		resourceSubscriptionHandler: resourceSubscriptionHandler{
+			subData:   newTestSubscriptionData(events.ExtendedResourceEvents...),
+			emitEvent: e.emitEvent,
+		},
+	}
+	ctx := context.Background()
+	// resource content changed is not requested by the subscription
+	err = h.HandleResourceContentChanged(ctx, &raEvents.ResourceChanged{ResourceId: resourceID, Content: content})
+	require.NoError(t, err)
+	require.Empty(t, e.emitted)
+
+	err = h.HandleResourceCreatePending(ctx, &raEvents.ResourceCreatePending{ResourceId: resourceID, Content: content, AuditContext: auditContext, ValidUntil: 1})
+	require.NoError(t, err)
+	err = h.HandleResourceCreated(ctx, &raEvents.ResourceCreated{ResourceId: resourceID, Content: content, AuditContext: auditContext, Status: commands.Status_CREATED})
+	require.NoError(t, err)
+	err = h.HandleResourceRetrievePending(ctx, &raEvents.ResourceRetrievePending{ResourceId: resourceID, AuditContext: auditContext, ResourceInterface: "oic.if.baseline"})
+	require.NoError(t, err)
+	err = h.HandleResourceRetrieved(ctx, &raEvents.ResourceRetrieved{ResourceId: resourceID, Content: content, AuditContext: auditContext, Status: commands.Status_OK})
+	require.NoError(t, err)
+	err = h.HandleResourceUpdatePending(ctx, &raEvents.ResourceUpdatePending{ResourceId: resourceID, Content: content, AuditContext: auditContext})
+	require.NoError(t, err)
+	err = h.HandleResourceUpdated(ctx, &raEvents.ResourceUpdated{ResourceId: resourceID, Content: content, AuditContext: auditContext, Status: commands.Status_OK})
+	require.NoError(t, err)
+	err = h.HandleResourceDeletePending(ctx, &raEvents.ResourceDeletePending{ResourceId: resourceID, AuditContext: auditContext})
+	require.NoError(t, err)
+	err = h.HandleResourceDeleted(ctx, &raEvents.ResourceDeleted{ResourceId: resourceID, Content: content, AuditContext: auditContext, Status: commands.Status_OK})
+	require.NoError(t, err)
+
+	wantContent := map[interface{}]interface{}{"power": uint64(1)}
+	require.Equal(t, []testEmittedEvent{
+		{
+			eventType: events.EventType_ResourceCreatePending,
+			seqNum:    0,
+			rep:       events.ResourceCommandPending{Href: "/" + deviceID + href, CorrelationID: "correlationID", Content: wantContent, ValidUntil: 1},
+		},
+		{
+			eventType: events.EventType_ResourceCreated,
+			seqNum:    1,
+			rep:       events.ResourceCommandProcessed{Href: "/" + deviceID + href, CorrelationID: "correlationID", Status: commands.Status_CREATED.String(), Content: wantContent},
+		},
+		{
+			eventType: events.EventType_ResourceRetrievePending,
+			seqNum:    2,
+			rep:       events.ResourceCommandPending{Href: "/" + deviceID + href, CorrelationID: "correlationID", ResourceInterface: "oic.if.baseline"},
+		},
+		{
+			eventType: events.EventType_ResourceRetrieved,
+			seqNum:    3,
+			rep:       events.ResourceCommandProcessed{Href: "/" + deviceID + href, CorrelationID: "correlationID", Status: commands.Status_OK.String(), Content: wantContent},
+		},
+		{
+			eventType: events.EventType_ResourceUpdatePending,
+			seqNum:    4,
+			rep:       events.ResourceCommandPending{Href: "/" + deviceID + href, CorrelationID: "correlationID", Content: wantContent},
+		},
+		{
+			eventType: events.EventType_ResourceUpdated,
+			seqNum:    5,
+			rep:       events.ResourceCommandProcessed{Href: "/" + deviceID + href, CorrelationID: "correlationID", Status: commands.Status_OK.String(), Content: wantContent},
+		},
+		{
+			eventType: events.EventType_ResourceDeletePending,
+			seqNum:    6,
+			rep:       events.ResourceCommandPending{Href: "/" + deviceID + href, CorrelationID: "correlationID"},
+		},
+		{
+			eventType: events.EventType_ResourceDeleted,
+			seqNum:    7,
+			rep:       events.ResourceCommandProcessed{Href: "/" + deviceID + href, CorrelationID: "correlationID", Status: commands.Status_OK.String(), Content: wantContent},
+		},
+	}, e.emitted)
+}
+
+func TestExtendedResourceSubscriptionHandlerRequestedEvents(t *testing.T) {
+	resourceID := commands.NewResourceID("deviceID", "/light/1")
+	auditContext := commands.NewAuditContext("userID", "correlationID")
+
+	var e testEmitter
+	h := extendedResourceSubscriptionHandler{
+		resourceSubscriptionHandler: resourceSubscriptionHandler{
+			subData:   newTestSubscriptionData(events.EventType_ResourceUpdated),
+			emitEvent: e.emitEvent,
+		},
+	}
+	ctx := context.Background()
+	err := h.HandleResourceUpdatePending(ctx, &raEvents.ResourceUpdatePending{ResourceId: resourceID, AuditContext: auditContext})
+	require.NoError(t, err)
+	err = h.HandleResourceDeleted(ctx, &raEvents.ResourceDeleted{ResourceId: resourceID, AuditContext: auditContext, Status: commands.Status_OK})
+	require.NoError(t, err)
+	require.Empty(t, e.emitted)
+
+	err = h.HandleResourceUpdated(ctx, &raEvents.ResourceUpdated{ResourceId: resourceID, AuditContext: auditContext, Status: commands.Status_OK})
+	require.NoError(t, err)
+	require.Len(t, e.emitted, 1)
+	require.Equal(t, events.EventType_ResourceUpdated, e.emitted[0].eventType)
+
+	// the error is returned only when the subscription must be removed
+	e.remove = true
+	err = h.HandleResourceUpdated(ctx, &raEvents.ResourceUpdated{ResourceId: resourceID, AuditContext: auditContext, Status: commands.Status_OK})
+	require.Error(t, err)
+	require.Len(t, e.emitted, 2)
+}
@@ -7,20 +7,23 @@ import (
 	"regexp"
 	"sync"
 
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/store"
 	"github.com/plgd-dev/hub/cloud2cloud-gateway/store/mongodb"
 	pbGRPC "github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/pkg/fn"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	grpcClient "github.com/plgd-dev/hub/pkg/net/grpc/client"
 	kitNetHttp "github.com/plgd-dev/hub/pkg/net/http"
 	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	"github.com/plgd-dev/hub/pkg/sync/task/queue"
 	raClient "github.com/plgd-dev/hub/resource-aggregate/client"
-	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
-	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/subscriber"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	eventbusConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/config"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
 )
 
@@ -77,8 +80,20 @@ var authRules = map[string][]kitNetHttp.AuthArgs{
 				regexp.MustCompile(`r:.*`),
 			},
 		},
+		{
+			URI: regexp.MustCompile(`[\/]+api[\/]+v1[\/]+subscriptions[\/]+[^\/]+[\/]+deliveries[\/]*$`),
+			Scopes: []*regexp.Regexp{
+				regexp.MustCompile(`r:.*`),
+			},
+		},
 	},
 	http.MethodPost: {
+		{
+			URI: regexp.MustCompile(`[\/]+api[\/]+v1[\/]+subscriptions[\/]+[^\/]+[\/]+deliveries[\/]+replay[\/]*$`),
+			Scopes: []*regexp.Regexp{
+				regexp.MustCompile(`w:.*`),
+			},
+		},
 		{
 			URI: regexp.MustCompile(`[\/]+api[\/]+v1[\/]+devices[\/]+subscriptions[\/]*$`),
 			Scopes: []*regexp.Regexp{
@@ -123,6 +138,20 @@ var authRules = map[string][]kitNetHttp.AuthArgs{
 				regexp.MustCompile(`r:.*`),
 			},
 		},
+		{
+			URI: regexp.MustCompile(`[\/]+api[\/]+v1[\/]+devices[\/]+[0-9a-fA-F]{8}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{12}[\/]+.*[\/]*$`),
+			Scopes: []*regexp.Regexp{
+				regexp.MustCompile(`w:.*`),
+			},
+		},
+	},
+	http.MethodPut: {
+		{
+			URI: regexp.MustCompile(`[\/]+api[\/]+v1[\/]+devices[\/]+[0-9a-fA-F]{8}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{12}[\/]+.*[\/]*$`),
+			Scopes: []*regexp.Regexp{
+				regexp.MustCompile(`w:.*`),
+			},
+		},
 	},
 }
 
@@ -141,26 +170,15 @@ func newGrpcGatewayClient(config GrpcGatewayConfig, logger log.Logger) (pbGRPC.G
 	return client, fl.ToFunction(), nil
 }
 
-func newResourceSubscriber(config Config, logger log.Logger) (*subscriber.Subscriber, func(), error) {
+func newResourceSubscriber(config Config, logger log.Logger) (eventbus.Subscriber, func(), error) {
 	var fl fn.FuncList
-	nats, err := natsClient.New(config.Clients.Eventbus.NATS, logger)
-	if err != nil {
-		return nil, nil, fmt.Errorf("cannot create nats client: %w", err)
-	}
-	fl.AddFunc(nats.Close)
-
 	pool, err := queue.New(config.TaskQueue)
 	if err != nil {
-		fl.Execute()
 		return nil, nil, fmt.Errorf("cannot create job queue %w", err)
 	}
 	fl.AddFunc(pool.Release)
 
-	resourceSubscriber, err := subscriber.New(nats.GetConn(),
-		config.Clients.Eventbus.NATS.PendingLimits,
-		logger,
-		subscriber.WithGoPool(func(f func()) error { return pool.Submit(f) }),
-		subscriber.WithUnmarshaler(utils.Unmarshal))
+	resourceSubscriber, err := eventbusConfig.NewSubscriber(config.Clients.Eventbus.SubscriberConfig, logger, utils.Unmarshal, func(f func()) error { return pool.Submit(f) })
 	if err != nil {
 		fl.Execute()
 		return nil, nil, fmt.Errorf("cannot create eventbus subscriber: %w", err)
@@ -170,7 +188,7 @@ func newResourceSubscriber(config Config, logger log.Logger) (*subscriber.Subscr
 	return resourceSubscriber, fl.ToFunction(), nil
 }
 
-func newResourceAggregateClient(config ResourceAggregateConfig, subscriber *subscriber.Subscriber, logger log.Logger) (*raClient.Client, func(), error) {
+func newResourceAggregateClient(config ResourceAggregateConfig, subscriber eventbus.Subscriber, logger log.Logger) (*raClient.Client, func(), error) {
 	var fl fn.FuncList
 	conn, err := grpcClient.New(config.Connection, logger)
 	if err != nil {
@@ -185,7 +203,7 @@ func newResourceAggregateClient(config ResourceAggregateConfig, subscriber *subs
 	return client, fl.ToFunction(), nil
 }
 
-func newSubscriptionManager(ctx context.Context, cfg Config, gwClient pbGRPC.GrpcGatewayClient, emitEvent emitEventFunc, logger log.Logger) (*SubscriptionManager, func(), error) {
+func newStore(ctx context.Context, cfg Config, logger log.Logger) (*mongodb.Store, func(), error) {
 	var fl fn.FuncList
 	certManager, err := cmClient.New(cfg.Clients.Storage.MongoDB.TLS, logger)
 	if err != nil {
@@ -203,14 +221,15 @@ func newSubscriptionManager(ctx context.Context, cfg Config, gwClient pbGRPC.Grp
 			log.Errorf("failed to close subscription store: %w", err)
 		}
 	})
+	return store, fl.ToFunction(), nil
+}
 
+func newSubscriptionManager(ctx context.Context, cfg Config, store store.Store, gwClient pbGRPC.GrpcGatewayClient, emitEvent emitEventFunc) (*SubscriptionManager, error) {
 	subMgr := NewSubscriptionManager(ctx, store, gwClient, cfg.Clients.Subscription.HTTP.ReconnectInterval, emitEvent)
 	if err := subMgr.LoadSubscriptions(); err != nil {
-		fl.Execute()
-		return nil, nil, fmt.Errorf("cannot load subscriptions: %w", err)
+		return nil, fmt.Errorf("cannot load subscriptions: %w", err)
 	}
-
-	return subMgr, fl.ToFunction(), nil
+	return subMgr, nil
 }
 
 // New parses configuration and creates new Server with provided store and bus
@@ -225,6 +244,20 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Server, error)
 		}
 	}
 
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		closeListener()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	listener.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "cloud2cloud-gateway", logger)
+	if err != nil {
+		closeListener()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	listener.AddCloseFunc(otelProvider.Close)
+
 	validator, err := validator.New(ctx, config.APIs.HTTP.Authorization, logger)
 	if err != nil {
 		closeListener()
@@ -254,30 +287,48 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Server, error)
 	}
 	listener.AddCloseFunc(closeRaClient)
 
-	emitEvent, closeEmitEventFn, err := createEmitEventFunc(config.Clients.Subscription.HTTP.TLS, config.Clients.Subscription.HTTP.EmitEventTimeout, logger)
+	ctx, cancelSubMgrFunc := context.WithCancel(context.Background())
+	store, closeStoreFn, err := newStore(ctx, config, logger)
 	if err != nil {
+		cancelSubMgrFunc()
 		closeListener()
-		return nil, fmt.Errorf("cannot create emit event function: %w", err)
+		return nil, fmt.Errorf("cannot create store: %w", err)
 	}
-	listener.AddCloseFunc(closeEmitEventFn)
+	listener.AddCloseFunc(closeStoreFn)
 
-	ctx, cancelSubMgrFunc := context.WithCancel(context.Background())
-	subMgr, closeSubMgrFn, err := newSubscriptionManager(ctx, config, gwClient, emitEvent, logger)
+	deliveryQueue, closeDeliveryQueueFn, err := createDeliveryQueue(config.Clients.Subscription.HTTP, store, logger)
+	if err != nil {
+		cancelSubMgrFunc()
+		closeListener()
+		return nil, fmt.Errorf("cannot create delivery queue: %w", err)
+	}
+	listener.AddCloseFunc(closeDeliveryQueueFn)
+	emitEvent := deliveryQueue.EmitEvent
+
+	subMgr, err := newSubscriptionManager(ctx, config, store, gwClient, emitEvent)
 	if err != nil {
 		cancelSubMgrFunc()
 		closeListener()
 		return nil, fmt.Errorf("cannot create subscription manager: %w", err)
 	}
-	listener.AddCloseFunc(closeSubMgrFn)
 
 	var subMgrWg sync.WaitGroup
-	subMgrWg.Add(1)
+	subMgrWg.Add(2)
 	go func() {
 		defer subMgrWg.Done()
 		subMgr.Run()
 	}()
+	go func() {
+		defer subMgrWg.Done()
+		deliveryQueue.Run(ctx, func(ctx context.Context, subscriptionID string) {
+			// the subscriber is gone, so the subscription is removed without the cancel event
+			if _, err := subMgr.PullOut(ctx, subscriptionID); err != nil {
+				log.Errorf("cannot remove subscription %v: %w", subscriptionID, err)
+			}
+		})
+	}()
 
-	requestHandler := NewRequestHandler(gwClient, raClient, subMgr, emitEvent)
+	requestHandler := NewRequestHandler(gwClient, raClient, subMgr, deliveryQueue)
 
 	server := Server{
 		server:           NewHTTP(requestHandler, auth),
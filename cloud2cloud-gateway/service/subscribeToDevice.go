@@ -16,10 +16,10 @@ func (rh *RequestHandler) subscribeToDevice(w http.ResponseWriter, r *http.Reque
 	routeVars := mux.Vars(r)
 	deviceID := routeVars[deviceIDKey]
 
-	s, code, err := rh.makeSubscription(w, r, store.Type_Device, []events.EventType{
+	s, code, err := rh.makeSubscription(w, r, store.Type_Device, append([]events.EventType{
 		events.EventType_ResourcesPublished,
 		events.EventType_ResourcesUnpublished,
-	})
+	}, events.ExtendedDeviceEvents...))
 	if err != nil {
 		return code, err
 	}
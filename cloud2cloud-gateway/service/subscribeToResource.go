@@ -67,7 +67,7 @@ func (rh *RequestHandler) subscribeToResource(w http.ResponseWriter, r *http.Req
 	deviceID := routeVars[deviceIDKey]
 	href := routeVars[HrefKey]
 
-	s, code, err := rh.makeSubscription(w, r, store.Type_Resource, []events.EventType{events.EventType_ResourceChanged})
+	s, code, err := rh.makeSubscription(w, r, store.Type_Resource, append([]events.EventType{events.EventType_ResourceChanged}, events.ExtendedResourceEvents...))
 	if err != nil {
 		return code, err
 	}
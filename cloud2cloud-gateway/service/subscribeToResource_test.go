@@ -21,6 +21,7 @@ import (
 	"github.com/plgd-dev/hub/cloud2cloud-gateway/uri"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
 	"github.com/plgd-dev/hub/test"
 	testCfg "github.com/plgd-dev/hub/test/config"
 	testHttp "github.com/plgd-dev/hub/test/http"
@@ -234,3 +235,123 @@ func TestRequestHandlerSubscribeToResourceTokenTimeout(t *testing.T) {
 		c2cgwShutdown()
 	}()
 }
+
+func TestRequestHandlerSubscribeToResourceExtendedEvents(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	href := test.TestResourceLightInstanceHref("1")
+	uri := "https://" + testCfg.C2C_GW_HOST + uri.Devices + "/" + deviceID + href + "/subscriptions"
+
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+
+	token := oauthTest.GetDefaultServiceToken(t)
+	ctx = kitNetGrpc.CtxWithToken(ctx, token)
+
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	eventsServer, cleanUpEventsServer := c2cTest.NewTestListener(t)
+	defer cleanUpEventsServer()
+
+	type receivedEvent struct {
+		eventType events.EventType
+		body      map[interface{}]interface{}
+	}
+	received := make(chan receivedEvent, 16)
+	const eventsURI = "/events"
+	var wg sync.WaitGroup
+	wg.Add(1)
+	defer wg.Wait()
+	go func() {
+		defer wg.Done()
+		r := router.NewRouter()
+		r.StrictSlash(true)
+		r.HandleFunc(eventsURI, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h, err := events.ParseEventHeader(r)
+			assert.NoError(t, err)
+			defer func() {
+				_ = r.Body.Close()
+			}()
+			buf, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var v map[interface{}]interface{}
+			err = json.Decode(buf, &v)
+			assert.NoError(t, err)
+			received <- receivedEvent{eventType: h.EventType, body: v}
+			w.WriteHeader(http.StatusOK)
+		})).Methods("POST")
+		_ = http.Serve(eventsServer, r)
+	}()
+	defer func() {
+		_ = eventsServer.Close()
+	}()
+
+	_, port, err := net.SplitHostPort(eventsServer.Addr().String())
+	require.NoError(t, err)
+
+	sub := events.SubscriptionRequest{
+		URL:           "https://localhost:" + port + eventsURI,
+		EventTypes:    events.EventTypes{events.EventType_ResourceUpdatePending, events.EventType_ResourceUpdated},
+		SigningSecret: "a",
+	}
+	data, err := json.Encode(sub)
+	require.NoError(t, err)
+	req := testHttp.NewHTTPRequest(http.MethodPost, uri, bytes.NewBuffer(data)).AuthToken(token).Accept(message.AppJSON.String()).Build(ctx, t)
+	resp := testHttp.DoHTTPRequest(t, req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	_, err = c.UpdateResource(ctx, &pb.UpdateResourceRequest{
+		ResourceId: commands.NewResourceID(deviceID, href),
+		Content: &pb.Content{
+			ContentType: message.AppOcfCbor.String(),
+			Data: test.EncodeToCbor(t, map[string]interface{}{
+				"power": 1,
+			}),
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err = c.UpdateResource(ctx, &pb.UpdateResourceRequest{
+			ResourceId: commands.NewResourceID(deviceID, href),
+			Content: &pb.Content{
+				ContentType: message.AppOcfCbor.String(),
+				Data: test.EncodeToCbor(t, map[string]interface{}{
+					"power": 0,
+				}),
+			},
+		})
+		assert.NoError(t, err)
+	}()
+
+	waitForEvent := func() receivedEvent {
+		select {
+		case ev := <-received:
+			return ev
+		case <-ctx.Done():
+			require.FailNow(t, "event was not received")
+		}
+		return receivedEvent{}
+	}
+	// the resource content changed event is not requested, so just the events of the update are delivered
+	pending := waitForEvent()
+	require.Equal(t, events.EventType_ResourceUpdatePending, pending.eventType)
+	require.Equal(t, "/"+deviceID+href, pending.body["href"])
+	require.Equal(t, map[interface{}]interface{}{"power": uint64(1)}, pending.body["content"])
+	updated := waitForEvent()
+	require.Equal(t, events.EventType_ResourceUpdated, updated.eventType)
+	require.Equal(t, "/"+deviceID+href, updated.body["href"])
+	require.Equal(t, commands.Status_OK.String(), updated.body["status"])
+	require.Equal(t, pending.body["correlationId"], updated.body["correlationId"])
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type DeliveryResponse struct {
+	SequenceNumber uint64 `json:"sequenceNumber"`
+	EventType      string `json:"eventType"`
+	Status         string `json:"status"`
+	Timestamp      int64  `json:"timestamp"`
+	Attempts       int    `json:"attempts"`
+	NextAttempt    int64  `json:"nextAttempt,omitempty"`
+	LastError      string `json:"lastError,omitempty"`
+}
+
+type ReplayDeliveriesResponse struct {
+	Replayed int64 `json:"replayed"`
+}
+
+func (rh *RequestHandler) retrieveSubscriptionDeliveries(w http.ResponseWriter, r *http.Request) (int, error) {
+	routeVars := mux.Vars(r)
+	subscriptionID := routeVars[subscriptionIDKey]
+
+	if _, ok := rh.subMgr.Load(subscriptionID); !ok {
+		return http.StatusNotFound, fmt.Errorf("not found")
+	}
+	deliveries, err := rh.deliveryQueue.Deliveries(r.Context(), subscriptionID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	resp := make([]DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, DeliveryResponse{
+			SequenceNumber: d.SequenceNumber,
+			EventType:      string(d.EventType),
+			Status:         string(d.Status),
+			Timestamp:      d.Timestamp.Unix(),
+			Attempts:       d.Attempts,
+			NextAttempt:    d.NextAttempt.Unix(),
+			LastError:      d.LastError,
+		})
+	}
+	if err := jsonResponseWriterEncoder(w, resp, http.StatusOK); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("cannot write response: %w", err)
+	}
+	return http.StatusOK, nil
+}
+
+// RetrieveSubscriptionDeliveries returns events of the subscription which were not delivered yet.
+func (rh *RequestHandler) RetrieveSubscriptionDeliveries(w http.ResponseWriter, r *http.Request) {
+	statusCode, err := rh.retrieveSubscriptionDeliveries(w, r)
+	if err != nil {
+		logAndWriteErrorResponse(fmt.Errorf("cannot retrieve subscription deliveries: %w", err), statusCode, w)
+	}
+}
+
+func (rh *RequestHandler) replaySubscriptionDeliveries(w http.ResponseWriter, r *http.Request) (int, error) {
+	routeVars := mux.Vars(r)
+	subscriptionID := routeVars[subscriptionIDKey]
+
+	if _, ok := rh.subMgr.Load(subscriptionID); !ok {
+		return http.StatusNotFound, fmt.Errorf("not found")
+	}
+	replayed, err := rh.deliveryQueue.Replay(r.Context(), subscriptionID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := jsonResponseWriterEncoder(w, ReplayDeliveriesResponse{
+		Replayed: replayed,
+	}, http.StatusAccepted); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("cannot write response: %w", err)
+	}
+	return http.StatusAccepted, nil
+}
+
+// ReplaySubscriptionDeliveries moves failed deliveries of the subscription back to the queue, they are delivered by the next retry.
+func (rh *RequestHandler) ReplaySubscriptionDeliveries(w http.ResponseWriter, r *http.Request) {
+	statusCode, err := rh.replaySubscriptionDeliveries(w, r)
+	if err != nil {
+		logAndWriteErrorResponse(fmt.Errorf("cannot replay subscription deliveries: %w", err), statusCode, w)
+	}
+}
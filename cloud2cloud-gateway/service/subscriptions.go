@@ -238,6 +238,15 @@ func (s *SubscriptionData) createDevicesSubscription(ctx context.Context, emitEv
 	return client.NewDevicesSubscription(ctx, closeEventHandler, eventHandler, s.gwClient)
 }
 
+func (s *SubscriptionData) hasAnyEventType(eventTypes []events.EventType) bool {
+	for _, e := range eventTypes {
+		if s.data.EventTypes.Has(e) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *SubscriptionData) createResourceSubscription(ctx context.Context, emitEvent emitEventFunc, closeEventHandler *closeEventHandler) (Subscription, error) {
 	resHandler := resourceSubscriptionHandler{
 		subData:   s,
@@ -245,6 +254,10 @@ func (s *SubscriptionData) createResourceSubscription(ctx context.Context, emitE
 	}
 	var eventHandler interface{}
 	switch {
+	case s.hasAnyEventType(events.ExtendedResourceEvents):
+		eventHandler = &extendedResourceSubscriptionHandler{
+			resourceSubscriptionHandler: resHandler,
+		}
 	case s.data.EventTypes.Has(events.EventType_ResourceChanged):
 		eventHandler = &resHandler
 	default:
@@ -260,6 +273,10 @@ func (s *SubscriptionData) createDeviceSubscription(ctx context.Context, emitEve
 	}
 	var eventHandler interface{}
 	switch {
+	case s.hasAnyEventType(events.ExtendedDeviceEvents):
+		eventHandler = &extendedDeviceSubscriptionHandler{
+			deviceSubscriptionHandler: devHandler,
+		}
 	case s.data.EventTypes.Has(events.EventType_ResourcesPublished) && s.data.EventTypes.Has(events.EventType_ResourcesUnpublished):
 		eventHandler = &devHandler
 	case s.data.EventTypes.Has(events.EventType_ResourcesPublished):
@@ -327,6 +344,26 @@ func (s *SubscriptionData) IncrementSequenceNumber(ctx context.Context) (uint64,
 	return seqNum, nil
 }
 
+// emitRequestedEvent emits the event only when the subscription contains the event type.
+func (s *SubscriptionData) emitRequestedEvent(ctx context.Context, emitEvent emitEventFunc, eventType events.EventType, makeRep func() (interface{}, error)) error {
+	data := s.Data()
+	if !data.EventTypes.Has(eventType) {
+		return nil
+	}
+	rep, err := makeRep()
+	if err != nil {
+		return fmt.Errorf("cannot emit event %v: %w", eventType, err)
+	}
+	remove, err := emitEvent(ctx, eventType, data, s.IncrementSequenceNumber, rep)
+	if err != nil {
+		log.Errorf("cannot emit event %v: %v", eventType, err)
+	}
+	if remove {
+		return err
+	}
+	return nil
+}
+
 func (s *SubscriptionData) SetInitialized(ctx context.Context) error {
 	return s.setInitialized(ctx, s.data.ID)
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
-	raEvents "github.com/plgd-dev/hub/resource-aggregate/events"
 	"github.com/plgd-dev/kit/v2/log"
 )
 
@@ -43,9 +42,14 @@ func statusToHttpStatus(status commands.Status) int {
 	return http.StatusInternalServerError
 }
 
-func sendResponse(w http.ResponseWriter, processed *raEvents.ResourceUpdated) (int, error) {
+type processedResourceEvent interface {
+	GetStatus() commands.Status
+	GetContent() *commands.Content
+}
+
+func sendResponse(w http.ResponseWriter, processed processedResourceEvent) (int, error) {
 	statusCode := statusToHttpStatus(processed.GetStatus())
-	if processed.Content != nil {
+	if processed.GetContent() != nil {
 		content, err := unmarshalContent(processed.GetContent())
 		if err != nil {
 			logAndWriteErrorResponse(fmt.Errorf("cannot unmarshal content: %w", err), statusCode, w)
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+)
+
+type DeliveryStatus string
+
+const (
+	// DeliveryStatus_Pending marks the event waiting for the (next) attempt of the delivery.
+	DeliveryStatus_Pending DeliveryStatus = "pending"
+	// DeliveryStatus_Failed marks the event which was not delivered within the max age (dead-letter).
+	DeliveryStatus_Failed DeliveryStatus = "failed"
+)
+
+// Delivery is an event emitted to the subscriber.
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	SequenceNumber uint64
+	EventType      events.EventType
+	ContentType    string
+	Body           []byte
+	Timestamp      time.Time // time of the event
+	QueuedAt       time.Time // start of the delivery, the max age of the delivery is counted from it
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttempt    time.Time
+	LastError      string
+}
+
+type DeliveryQuery struct {
+	SubscriptionID    string
+	Status            DeliveryStatus
+	NextAttemptBefore time.Time // filled to get deliveries ready for the next attempt
+}
+
+type DeliveryIter interface {
+	Next(ctx context.Context, d *Delivery) bool
+	Err() error
+}
+
+type DeliveryHandler interface {
+	Handle(ctx context.Context, iter DeliveryIter) (err error)
+}
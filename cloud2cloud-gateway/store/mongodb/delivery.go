@@ -0,0 +1,227 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const deliveriesCName = "deliveries"
+const deliverySubscriptionIDKey = "subscriptionid"
+const deliverySequenceNumberKey = "sequencenumber"
+const deliveryStatusKey = "status"
+const deliveryNextAttemptKey = "nextattempt"
+const deliveryQueuedAtKey = "queuedat"
+const deliveryAttemptsKey = "attempts"
+
+var deliverySubscriptionQueryIndex = bson.D{
+	{Key: deliverySubscriptionIDKey, Value: 1},
+	{Key: deliveryStatusKey, Value: 1},
+	{Key: deliverySequenceNumberKey, Value: 1},
+}
+
+var deliveryStatusQueryIndex = bson.D{
+	{Key: deliveryStatusKey, Value: 1},
+	{Key: deliveryNextAttemptKey, Value: 1},
+}
+
+type DBDelivery struct {
+	ID             string               `bson:"_id"`
+	SubscriptionID string               `bson:"subscriptionid"`
+	SequenceNumber uint64               `bson:"sequencenumber"`
+	EventType      events.EventType     `bson:"eventtype"`
+	ContentType    string               `bson:"contenttype"`
+	Body           []byte               `bson:"body"`
+	Timestamp      time.Time            `bson:"timestamp"`
+	QueuedAt       time.Time            `bson:"queuedat"`
+	Status         store.DeliveryStatus `bson:"status"`
+	Attempts       int                  `bson:"attempts"`
+	NextAttempt    time.Time            `bson:"nextattempt"`
+	LastError      string               `bson:"lasterror"`
+}
+
+func makeDBDelivery(d store.Delivery) DBDelivery {
+	return DBDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		SequenceNumber: d.SequenceNumber,
+		EventType:      d.EventType,
+		ContentType:    d.ContentType,
+		Body:           d.Body,
+		Timestamp:      d.Timestamp,
+		QueuedAt:       d.QueuedAt,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttempt:    d.NextAttempt,
+		LastError:      d.LastError,
+	}
+}
+
+func convertToDelivery(d DBDelivery) store.Delivery {
+	return store.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		SequenceNumber: d.SequenceNumber,
+		EventType:      d.EventType,
+		ContentType:    d.ContentType,
+		Body:           d.Body,
+		Timestamp:      d.Timestamp,
+		QueuedAt:       d.QueuedAt,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttempt:    d.NextAttempt,
+		LastError:      d.LastError,
+	}
+}
+
+func validateDelivery(d store.Delivery) error {
+	if d.ID == "" {
+		return fmt.Errorf("invalid ID")
+	}
+	if d.SubscriptionID == "" {
+		return fmt.Errorf("invalid SubscriptionID")
+	}
+	if d.EventType == "" {
+		return fmt.Errorf("invalid EventType")
+	}
+	switch d.Status {
+	case store.DeliveryStatus_Pending, store.DeliveryStatus_Failed:
+	default:
+		return fmt.Errorf("not supported Status %v", d.Status)
+	}
+	return nil
+}
+
+func (s *Store) SaveDelivery(ctx context.Context, d store.Delivery) error {
+	if err := validateDelivery(d); err != nil {
+		return fmt.Errorf("cannot save delivery: %w", err)
+	}
+	col := s.Collection(deliveriesCName)
+	opts := options.Replace().SetUpsert(true)
+	if _, err := col.ReplaceOne(ctx, bson.M{"_id": d.ID}, makeDBDelivery(d), opts); err != nil {
+		return fmt.Errorf("cannot save delivery: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) LoadDeliveries(ctx context.Context, query store.DeliveryQuery, h store.DeliveryHandler) error {
+	q := bson.M{}
+	opts := options.Find().SetSort(bson.D{
+		{Key: deliverySubscriptionIDKey, Value: 1},
+		{Key: deliverySequenceNumberKey, Value: 1},
+	})
+	if query.SubscriptionID != "" {
+		q[deliverySubscriptionIDKey] = query.SubscriptionID
+	}
+	if query.Status != "" {
+		q[deliveryStatusKey] = query.Status
+	}
+	if !query.NextAttemptBefore.IsZero() {
+		q[deliveryNextAttemptKey] = bson.M{"$lte": query.NextAttemptBefore}
+	}
+	switch {
+	case query.SubscriptionID != "" && query.Status != "":
+		opts.SetHint(deliverySubscriptionQueryIndex)
+	case query.Status != "":
+		opts.SetHint(deliveryStatusQueryIndex)
+	}
+
+	col := s.Collection(deliveriesCName)
+	iter, err := col.Find(ctx, q, opts)
+	if err == mongo.ErrNilDocument {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	i := deliveryIterator{
+		iter: iter,
+	}
+	err = h.Handle(ctx, &i)
+
+	errClose := iter.Close(ctx)
+	if err == nil {
+		return errClose
+	}
+	return err
+}
+
+func (s *Store) LoadPendingDeliverySubscriptionIDs(ctx context.Context, nextAttemptBefore time.Time) ([]string, error) {
+	col := s.Collection(deliveriesCName)
+	ids, err := col.Distinct(ctx, deliverySubscriptionIDKey, bson.D{
+		{Key: deliveryStatusKey, Value: store.DeliveryStatus_Pending},
+		{Key: deliveryNextAttemptKey, Value: bson.M{"$lte": nextAttemptBefore}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot load subscriptions with pending deliveries: %w", err)
+	}
+	subscriptionIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if subscriptionID, ok := id.(string); ok {
+			subscriptionIDs = append(subscriptionIDs, subscriptionID)
+		}
+	}
+	return subscriptionIDs, nil
+}
+
+func (s *Store) RemoveDelivery(ctx context.Context, deliveryID string) error {
+	if deliveryID == "" {
+		return fmt.Errorf("cannot remove delivery: invalid deliveryID")
+	}
+	col := s.Collection(deliveriesCName)
+	if _, err := col.DeleteOne(ctx, bson.M{"_id": deliveryID}); err != nil {
+		return fmt.Errorf("cannot remove delivery %v: %w", deliveryID, err)
+	}
+	return nil
+}
+
+func (s *Store) ReplayDeliveries(ctx context.Context, subscriptionID string) (int64, error) {
+	if subscriptionID == "" {
+		return 0, fmt.Errorf("cannot replay deliveries: invalid subscriptionId")
+	}
+	now := time.Now()
+	col := s.Collection(deliveriesCName)
+	res, err := col.UpdateMany(ctx, bson.D{
+		{Key: deliverySubscriptionIDKey, Value: subscriptionID},
+		{Key: deliveryStatusKey, Value: store.DeliveryStatus_Failed},
+	}, bson.M{"$set": bson.M{
+		deliveryStatusKey:      store.DeliveryStatus_Pending,
+		deliveryNextAttemptKey: now,
+		deliveryQueuedAtKey:    now,
+		deliveryAttemptsKey:    0,
+	}}, options.Update().SetHint(deliverySubscriptionQueryIndex))
+	if err != nil {
+		return 0, fmt.Errorf("cannot replay deliveries for %v: %w", subscriptionID, err)
+	}
+	return res.ModifiedCount, nil
+}
+
+type deliveryIterator struct {
+	iter *mongo.Cursor
+}
+
+func (i *deliveryIterator) Next(ctx context.Context, d *store.Delivery) bool {
+	var delivery DBDelivery
+
+	if !i.iter.Next(ctx) {
+		return false
+	}
+
+	err := i.iter.Decode(&delivery)
+	if err != nil {
+		return false
+	}
+	*d = convertToDelivery(delivery)
+	return true
+}
+
+func (i *deliveryIterator) Err() error {
+	return i.iter.Err()
+}
@@ -0,0 +1,151 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/hub/cloud2cloud-connector/events"
+	"github.com/plgd-dev/hub/cloud2cloud-gateway/store"
+	"github.com/stretchr/testify/require"
+)
+
+type testDeliveryHandler struct {
+	deliveries []store.Delivery
+}
+
+func (h *testDeliveryHandler) Handle(ctx context.Context, iter store.DeliveryIter) (err error) {
+	var d store.Delivery
+	for iter.Next(ctx, &d) {
+		h.deliveries = append(h.deliveries, d)
+	}
+	return iter.Err()
+}
+
+func makeTestDelivery(id string, seqNum uint64, status store.DeliveryStatus) store.Delivery {
+	now := time.Now().Truncate(time.Millisecond).UTC()
+	return store.Delivery{
+		ID:             id,
+		SubscriptionID: "subID",
+		SequenceNumber: seqNum,
+		EventType:      events.EventType_ResourceChanged,
+		ContentType:    events.ContentType_JSON,
+		Body:           []byte("{}"),
+		Timestamp:      now,
+		QueuedAt:       now,
+		Status:         status,
+		NextAttempt:    now,
+	}
+}
+
+func TestStore_SaveDelivery(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       store.Delivery
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			d:       makeTestDelivery("id1", 0, store.DeliveryStatus_Pending),
+			wantErr: false,
+		},
+		{
+			name:    "invalid ID",
+			d:       makeTestDelivery("", 0, store.DeliveryStatus_Pending),
+			wantErr: true,
+		},
+		{
+			name: "invalid SubscriptionID",
+			d: func() store.Delivery {
+				d := makeTestDelivery("id2", 0, store.DeliveryStatus_Pending)
+				d.SubscriptionID = ""
+				return d
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid Status",
+			d:       makeTestDelivery("id3", 0, "invalid"),
+			wantErr: true,
+		},
+	}
+
+	s, cleanUpStore := newTestStore(t)
+	defer cleanUpStore()
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.SaveDelivery(ctx, tt.d)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStore_LoadDeliveries(t *testing.T) {
+	s, cleanUpStore := newTestStore(t)
+	defer cleanUpStore()
+
+	ctx := context.Background()
+	d2 := makeTestDelivery("id2", 2, store.DeliveryStatus_Pending)
+	d1 := makeTestDelivery("id1", 1, store.DeliveryStatus_Pending)
+	d0 := makeTestDelivery("id0", 0, store.DeliveryStatus_Failed)
+	for _, d := range []store.Delivery{d2, d1, d0} {
+		err := s.SaveDelivery(ctx, d)
+		require.NoError(t, err)
+	}
+
+	var h testDeliveryHandler
+	err := s.LoadDeliveries(ctx, store.DeliveryQuery{SubscriptionID: "subID"}, &h)
+	require.NoError(t, err)
+	require.Equal(t, []store.Delivery{d0, d1, d2}, h.deliveries)
+
+	h = testDeliveryHandler{}
+	err = s.LoadDeliveries(ctx, store.DeliveryQuery{SubscriptionID: "subID", Status: store.DeliveryStatus_Pending}, &h)
+	require.NoError(t, err)
+	require.Equal(t, []store.Delivery{d1, d2}, h.deliveries)
+
+	err = s.RemoveDelivery(ctx, d1.ID)
+	require.NoError(t, err)
+
+	replayed, err := s.ReplayDeliveries(ctx, "subID")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), replayed)
+
+	h = testDeliveryHandler{}
+	err = s.LoadDeliveries(ctx, store.DeliveryQuery{SubscriptionID: "subID", Status: store.DeliveryStatus_Pending}, &h)
+	require.NoError(t, err)
+	require.Len(t, h.deliveries, 2)
+	require.Equal(t, d0.ID, h.deliveries[0].ID)
+	require.Equal(t, d2.ID, h.deliveries[1].ID)
+}
+
+func TestStore_LoadPendingDeliverySubscriptionIDs(t *testing.T) {
+	s, cleanUpStore := newTestStore(t)
+	defer cleanUpStore()
+
+	ctx := context.Background()
+	d0 := makeTestDelivery("id0", 0, store.DeliveryStatus_Pending)
+	d1 := makeTestDelivery("id1", 1, store.DeliveryStatus_Pending)
+	d2 := makeTestDelivery("id2", 0, store.DeliveryStatus_Pending)
+	d2.SubscriptionID = "subID2"
+	d2.NextAttempt = d2.NextAttempt.Add(time.Hour)
+	d3 := makeTestDelivery("id3", 0, store.DeliveryStatus_Failed)
+	d3.SubscriptionID = "subID3"
+	for _, d := range []store.Delivery{d0, d1, d2, d3} {
+		err := s.SaveDelivery(ctx, d)
+		require.NoError(t, err)
+	}
+
+	subscriptionIDs, err := s.LoadPendingDeliverySubscriptionIDs(ctx, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []string{"subID"}, subscriptionIDs)
+
+	subscriptionIDs, err = s.LoadPendingDeliverySubscriptionIDs(ctx, time.Now().Add(time.Hour*2))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"subID", "subID2"}, subscriptionIDs)
+}
@@ -21,8 +21,17 @@ func NewStore(ctx context.Context, cfg pkgMongo.Config, tls *tls.Config) (*Store
 	if err != nil {
 		return nil, err
 	}
+	if err := s.EnsureIndex(ctx, deliveriesCName, deliverySubscriptionQueryIndex, deliveryStatusQueryIndex); err != nil {
+		if errClose := s.Close(ctx); errClose != nil {
+			err = fmt.Errorf("%w, %v", err, errClose)
+		}
+		return nil, err
+	}
 	s.SetOnClear(func(c context.Context) error {
-		return s.DropCollection(ctx, subscriptionsCName)
+		if err := s.DropCollection(c, deliveriesCName); err != nil {
+			return err
+		}
+		return s.DropCollection(c, subscriptionsCName)
 	})
 	return &Store{s}, nil
 }
@@ -177,6 +177,10 @@ func (s *Store) PopSubscription(ctx context.Context, subscriptionID string) (sub
 	if err != nil {
 		return sub, err
 	}
+	// deliveries of the removed subscription are not needed anymore
+	if _, err := s.Collection(deliveriesCName).DeleteMany(ctx, bson.M{deliverySubscriptionIDKey: subscriptionID}); err != nil {
+		return sub, fmt.Errorf("cannot remove deliveries of subscription('%v'): %w", subscriptionID, err)
+	}
 	return convertToSubscription(DBSub), nil
 }
 
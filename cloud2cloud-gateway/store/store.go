@@ -32,4 +32,14 @@ type Store interface {
 	LoadSubscriptions(ctx context.Context, query SubscriptionQuery, h SubscriptionHandler) error
 	IncrementSubscriptionSequenceNumber(ctx context.Context, subscriptionID string) (uint64, error)
 	SetInitialized(ctx context.Context, subscriptionID string) error
+
+	// SaveDelivery creates or replaces the delivery.
+	SaveDelivery(ctx context.Context, d Delivery) error
+	// LoadDeliveries loads deliveries ordered by the subscription and the sequence number.
+	LoadDeliveries(ctx context.Context, query DeliveryQuery, h DeliveryHandler) error
+	// LoadPendingDeliverySubscriptionIDs loads IDs of subscriptions with pending deliveries ready for the next attempt.
+	LoadPendingDeliverySubscriptionIDs(ctx context.Context, nextAttemptBefore time.Time) ([]string, error)
+	RemoveDelivery(ctx context.Context, deliveryID string) error
+	// ReplayDeliveries moves failed deliveries of the subscription back to the queue.
+	ReplayDeliveries(ctx context.Context, subscriptionID string) (int64, error)
 }
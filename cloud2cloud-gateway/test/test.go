@@ -42,6 +42,9 @@ func MakeConfig(t *testing.T) service.Config {
 	cfg.Clients.Storage = MakeStorageConfig()
 	cfg.Clients.Subscription.HTTP.ReconnectInterval = time.Second * 10
 	cfg.Clients.Subscription.HTTP.EmitEventTimeout = time.Second * 5
+	cfg.Clients.Subscription.HTTP.Delivery.InitialBackoff = time.Second
+	cfg.Clients.Subscription.HTTP.Delivery.MaxBackoff = time.Minute
+	cfg.Clients.Subscription.HTTP.Delivery.MaxAge = time.Hour
 	cfg.Clients.Subscription.HTTP.TLS = config.MakeTLSClientConfig()
 
 	cfg.TaskQueue.GoPoolSize = 1600
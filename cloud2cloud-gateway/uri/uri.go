@@ -17,4 +17,8 @@ const (
 
 	ResourceSubscriptions string = Devices + "/{deviceID}/{{ .Href }}/subscriptions"
 	ResourceSubscription  string = Devices + "/{deviceID}/{{ .Href }}/subscriptions/{{ .SubscriptionID }}"
+
+	// deliveries of events to the subscriber of any type of subscription
+	SubscriptionDeliveries       string = Version + "/subscriptions/{subscriptionID}/deliveries"
+	SubscriptionDeliveriesReplay string = SubscriptionDeliveries + "/replay"
 )
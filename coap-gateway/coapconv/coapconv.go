@@ -12,9 +12,8 @@ import (
 	"github.com/plgd-dev/device/schema/interfaces"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/message/pool"
 	"github.com/plgd-dev/go-coap/v2/mux"
-	"github.com/plgd-dev/go-coap/v2/tcp"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
 	"github.com/plgd-dev/hub/resource-aggregate/events"
 )
@@ -97,7 +96,41 @@ func MakeMediaType(coapContentFormat int32, contentType string) (message.MediaTy
 	}
 }
 
-func NewCoapResourceUpdateRequest(ctx context.Context, event *events.ResourceUpdatePending) (*pool.Message, error) {
+// ToMessage converts the message to the form accepted by mux.Client, so it can be sent over any
+// transport (TCP, UDP or DTLS).
+func ToMessage(ctx context.Context, msg *pool.Message) *message.Message {
+	return &message.Message{
+		Context: ctx,
+		Token:   msg.Token(),
+		Code:    msg.Code(),
+		Options: msg.Options(),
+		Body:    msg.Body(),
+	}
+}
+
+func newCoapRequest(code codes.Code, href string) (*pool.Message, error) {
+	token, err := message.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get token: %w", err)
+	}
+	req := pool.NewMessage()
+	req.SetCode(code)
+	req.SetToken(token)
+	req.SetPath(href)
+	return req, nil
+}
+
+func newCoapRequestWithPayload(code codes.Code, href string, contentFormat message.MediaType, payload io.ReadSeeker) (*pool.Message, error) {
+	req, err := newCoapRequest(code, href)
+	if err != nil {
+		return nil, err
+	}
+	req.SetContentFormat(contentFormat)
+	req.SetBody(payload)
+	return req, nil
+}
+
+func NewCoapResourceUpdateRequest(ctx context.Context, event *events.ResourceUpdatePending) (*message.Message, error) {
 	mediaType, err := MakeMediaType(-1, event.GetContent().GetContentType())
 	if err != nil {
 		return nil, fmt.Errorf("invalid content type for update content: %w", err)
@@ -106,7 +139,7 @@ func NewCoapResourceUpdateRequest(ctx context.Context, event *events.ResourceUpd
 		return nil, fmt.Errorf("invalid content for update content")
 	}
 
-	req, err := tcp.NewPostRequest(ctx, event.GetResourceId().GetHref(), mediaType, bytes.NewReader(event.GetContent().GetData()))
+	req, err := newCoapRequestWithPayload(codes.POST, event.GetResourceId().GetHref(), mediaType, bytes.NewReader(event.GetContent().GetData()))
 	if err != nil {
 		return nil, err
 	}
@@ -114,11 +147,11 @@ func NewCoapResourceUpdateRequest(ctx context.Context, event *events.ResourceUpd
 		req.AddOptionString(message.URIQuery, "if="+event.GetResourceInterface())
 	}
 
-	return req, nil
+	return ToMessage(ctx, req), nil
 }
 
-func NewCoapResourceRetrieveRequest(ctx context.Context, event *events.ResourceRetrievePending) (*pool.Message, error) {
-	req, err := tcp.NewGetRequest(ctx, event.GetResourceId().GetHref())
+func NewCoapResourceRetrieveRequest(ctx context.Context, event *events.ResourceRetrievePending) (*message.Message, error) {
+	req, err := newCoapRequest(codes.GET, event.GetResourceId().GetHref())
 	if err != nil {
 		return nil, err
 	}
@@ -126,16 +159,16 @@ func NewCoapResourceRetrieveRequest(ctx context.Context, event *events.ResourceR
 		req.AddOptionString(message.URIQuery, "if="+event.GetResourceInterface())
 	}
 
-	return req, nil
+	return ToMessage(ctx, req), nil
 }
 
-func NewCoapResourceDeleteRequest(ctx context.Context, event *events.ResourceDeletePending) (*pool.Message, error) {
-	req, err := tcp.NewDeleteRequest(ctx, event.GetResourceId().GetHref())
+func NewCoapResourceDeleteRequest(ctx context.Context, event *events.ResourceDeletePending) (*message.Message, error) {
+	req, err := newCoapRequest(codes.DELETE, event.GetResourceId().GetHref())
 	if err != nil {
 		return nil, err
 	}
 
-	return req, nil
+	return ToMessage(ctx, req), nil
 }
 
 func NewContent(opts message.Options, body io.Reader) *commands.Content {
@@ -157,6 +190,15 @@ func NewContent(opts message.Options, body io.Reader) *commands.Content {
 	}
 }
 
+// NewETag returns the ETag of the content or nil when it is not set.
+func NewETag(opts message.Options) []byte {
+	etag, err := opts.GetBytes(message.ETag)
+	if err != nil {
+		return nil
+	}
+	return etag
+}
+
 func NewCommandMetadata(sequenceNumber uint64, connectionID string) *commands.CommandMetadata {
 	return &commands.CommandMetadata{
 		Sequence:     sequenceNumber,
@@ -164,27 +206,27 @@ func NewCommandMetadata(sequenceNumber uint64, connectionID string) *commands.Co
 	}
 }
 
-func NewConfirmResourceRetrieveRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *pool.Message) *commands.ConfirmResourceRetrieveRequest {
-	content := NewContent(req.Options(), req.Body())
-	metadata := NewCommandMetadata(req.Sequence(), connectionID)
+func NewConfirmResourceRetrieveRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *mux.Message) *commands.ConfirmResourceRetrieveRequest {
+	content := NewContent(req.Options, req.Body)
+	metadata := NewCommandMetadata(req.SequenceNumber, connectionID)
 
 	return &commands.ConfirmResourceRetrieveRequest{
 		ResourceId:      resourceID,
 		CorrelationId:   correlationId,
-		Status:          CoapCodeToStatus(req.Code()),
+		Status:          CoapCodeToStatus(req.Code),
 		Content:         content,
 		CommandMetadata: metadata,
 	}
 }
 
-func NewConfirmResourceUpdateRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *pool.Message) *commands.ConfirmResourceUpdateRequest {
-	content := NewContent(req.Options(), req.Body())
-	metadata := NewCommandMetadata(req.Sequence(), connectionID)
+func NewConfirmResourceUpdateRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *mux.Message) *commands.ConfirmResourceUpdateRequest {
+	content := NewContent(req.Options, req.Body)
+	metadata := NewCommandMetadata(req.SequenceNumber, connectionID)
 
 	return &commands.ConfirmResourceUpdateRequest{
 		ResourceId:      resourceID,
 		CorrelationId:   correlationId,
-		Status:          CoapCodeToStatus(req.Code()),
+		Status:          CoapCodeToStatus(req.Code),
 		Content:         content,
 		CommandMetadata: metadata,
 	}
@@ -203,28 +245,29 @@ func NewDeleteResourceRequest(resourceID *commands.ResourceId, req *mux.Message,
 	}, nil
 }
 
-func NewConfirmResourceDeleteRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *pool.Message) *commands.ConfirmResourceDeleteRequest {
-	content := NewContent(req.Options(), req.Body())
-	metadata := NewCommandMetadata(req.Sequence(), connectionID)
+func NewConfirmResourceDeleteRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *mux.Message) *commands.ConfirmResourceDeleteRequest {
+	content := NewContent(req.Options, req.Body)
+	metadata := NewCommandMetadata(req.SequenceNumber, connectionID)
 
 	return &commands.ConfirmResourceDeleteRequest{
 		ResourceId:      resourceID,
 		CorrelationId:   correlationId,
-		Status:          CoapCodeToStatus(req.Code()),
+		Status:          CoapCodeToStatus(req.Code),
 		Content:         content,
 		CommandMetadata: metadata,
 	}
 }
 
-func NewNotifyResourceChangedRequest(resourceID *commands.ResourceId, connectionID string, req *pool.Message) *commands.NotifyResourceChangedRequest {
-	content := NewContent(req.Options(), req.Body())
-	metadata := NewCommandMetadata(req.Sequence(), connectionID)
+func NewNotifyResourceChangedRequest(resourceID *commands.ResourceId, connectionID string, req *mux.Message) *commands.NotifyResourceChangedRequest {
+	content := NewContent(req.Options, req.Body)
+	metadata := NewCommandMetadata(req.SequenceNumber, connectionID)
 
 	return &commands.NotifyResourceChangedRequest{
 		ResourceId:      resourceID,
 		Content:         content,
 		CommandMetadata: metadata,
-		Status:          CoapCodeToStatus(req.Code()),
+		Status:          CoapCodeToStatus(req.Code),
+		Etag:            NewETag(req.Options),
 	}
 }
 
@@ -300,20 +343,20 @@ func NewCreateResourceRequest(resourceID *commands.ResourceId, req *mux.Message,
 	}, nil
 }
 
-func NewConfirmResourceCreateRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *pool.Message) *commands.ConfirmResourceCreateRequest {
-	content := NewContent(req.Options(), req.Body())
-	metadata := NewCommandMetadata(req.Sequence(), connectionID)
+func NewConfirmResourceCreateRequest(resourceID *commands.ResourceId, correlationId string, connectionID string, req *mux.Message) *commands.ConfirmResourceCreateRequest {
+	content := NewContent(req.Options, req.Body)
+	metadata := NewCommandMetadata(req.SequenceNumber, connectionID)
 
 	return &commands.ConfirmResourceCreateRequest{
 		ResourceId:      resourceID,
 		CorrelationId:   correlationId,
-		Status:          CoapCodeToStatus(req.Code()),
+		Status:          CoapCodeToStatus(req.Code),
 		Content:         content,
 		CommandMetadata: metadata,
 	}
 }
 
-func NewCoapResourceCreateRequest(ctx context.Context, event *events.ResourceCreatePending) (*pool.Message, error) {
+func NewCoapResourceCreateRequest(ctx context.Context, event *events.ResourceCreatePending) (*message.Message, error) {
 	mediaType, err := MakeMediaType(-1, event.GetContent().GetContentType())
 	if err != nil {
 		return nil, fmt.Errorf("invalid content type for create content: %w", err)
@@ -322,11 +365,11 @@ func NewCoapResourceCreateRequest(ctx context.Context, event *events.ResourceCre
 		return nil, fmt.Errorf("invalid content for create content")
 	}
 
-	req, err := tcp.NewPostRequest(ctx, event.GetResourceId().GetHref(), mediaType, bytes.NewReader(event.GetContent().GetData()))
+	req, err := newCoapRequestWithPayload(codes.POST, event.GetResourceId().GetHref(), mediaType, bytes.NewReader(event.GetContent().GetData()))
 	if err != nil {
 		return nil, err
 	}
 	req.AddOptionString(message.URIQuery, "if="+interfaces.OC_IF_CREATE)
 
-	return req, nil
+	return ToMessage(ctx, req), nil
 }
@@ -2,6 +2,7 @@ package coapconv
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/kit/v2/codec/cbor"
@@ -30,3 +31,17 @@ func GetEncoder(accept message.MediaType) (EncodeFunc, error) {
 		return nil, fmt.Errorf("unsupported type (%v)", accept)
 	}
 }
+
+type ReadFromFunc = func(r io.Reader, v interface{}) error
+
+// GetReadFrom returns decoder of the content by its content format
+func GetReadFrom(contentFormat message.MediaType) (ReadFromFunc, error) {
+	switch contentFormat {
+	case message.AppJSON:
+		return json.ReadFrom, nil
+	case message.AppCBOR, message.AppOcfCbor:
+		return cbor.ReadFrom, nil
+	default:
+		return nil, fmt.Errorf("unsupported type (%v)", contentFormat)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	cache "github.com/patrickmn/go-cache"
+	piondtls "github.com/pion/dtls/v2"
 	"github.com/plgd-dev/hub/coap-gateway/uri"
+	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/hub/pkg/net/grpc"
 	pkgJwt "github.com/plgd-dev/hub/pkg/security/jwt"
 	"github.com/plgd-dev/device/pkg/net/coap"
@@ -46,6 +48,16 @@ func (s *Service) ValidateToken(ctx context.Context, token string) (pkgJwt.Claim
 	return pkgJwt.Claims(m), nil
 }
 
+// Owner returns the owner of the validated token, the owner claim might be overridden by the provider of the token.
+func (s *Service) Owner(claims pkgJwt.Claims) (string, error) {
+	return s.jwtValidator.Owner(claims, s.config.APIs.COAP.Authorization.OwnerClaim)
+}
+
+// ValidateOwner validates that the owner of the validated token is the userID.
+func (s *Service) ValidateOwner(claims pkgJwt.Claims, userID string) error {
+	return s.jwtValidator.ValidateOwner(claims, s.config.APIs.COAP.Authorization.OwnerClaim, userID)
+}
+
 func (s *Service) VerifyDeviceID(tlsDeviceID string, claim pkgJwt.Claims) error {
 	jwtDeviceID := claim.DeviceID(s.config.APIs.COAP.Authorization.DeviceIDClaim)
 	if s.config.APIs.COAP.Authorization.DeviceIDClaim != "" && jwtDeviceID == "" {
@@ -132,3 +144,47 @@ func MakeGetConfigForClient(tlsCfg *tls.Config, deviceIdCache *cache.Cache) func
 		}, nil
 	}
 }
+
+// MakeDTLSConfig converts the tls configuration of the cert manager to the dtls configuration. The device ID
+// is taken from the peer certificate of the dtls connection, because the dtls handshake doesn't provide ClientHelloInfo.
+func MakeDTLSConfig(tlsCfg *tls.Config, handshakeTimeout time.Duration) (*piondtls.Config, error) {
+	cert, err := tlsCfg.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get certificate: %w", err)
+	}
+	dtlsCfg := piondtls.Config{
+		Certificates:         []tls.Certificate{*cert},
+		ClientCAs:            tlsCfg.ClientCAs,
+		ClientAuth:           piondtls.ClientAuthType(tlsCfg.ClientAuth),
+		ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+	}
+	// The dtls config doesn't provide a GetCertificate callback, so the certificate of the cert manager is
+	// reloaded before each handshake to follow the rotation of the certificate. The listener accepts connections
+	// sequentially and the handshake copies the certificates, so replacing the slice doesn't affect running handshakes.
+	dtlsCfg.ConnectContextMaker = func() (context.Context, func()) {
+		cert, err := tlsCfg.GetCertificate(&tls.ClientHelloInfo{})
+		if err != nil {
+			log.Errorf("cannot get certificate for dtls handshake: %w", err)
+		} else {
+			dtlsCfg.Certificates = []tls.Certificate{*cert}
+		}
+		return context.WithTimeout(context.Background(), handshakeTimeout)
+	}
+	if tlsCfg.ClientAuth >= tls.VerifyClientCertIfGiven {
+		dtlsCfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			var errors []error
+			for _, chain := range verifiedChains {
+				_, err := verifyChain(chain, tlsCfg.ClientCAs)
+				if err == nil {
+					return nil
+				}
+				errors = append(errors, err)
+			}
+			if len(errors) > 0 {
+				return fmt.Errorf("%v", errors)
+			}
+			return fmt.Errorf("empty chains")
+		}
+	}
+	return &dtlsCfg, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/plgd-dev/hub/pkg/security/oauth2"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJwtValidator(t *testing.T) {
+	shutdown := oauthTest.SetUp(t)
+	defer shutdown()
+
+	logger, err := log.NewLogger(log.Config{Debug: true})
+	require.NoError(t, err)
+	cfg := AuthorizationConfig{
+		OwnerClaim: jwt.ClaimSubject,
+		Providers: []ProvidersConfig{
+			{Name: "first", Config: config.MakeDeviceAuthorization()},
+			{Name: "sameAuthority", Config: config.MakeDeviceAuthorization()},
+			{Name: "other", OwnerClaim: "oid", Config: config.MakeDeviceAuthorization()},
+		},
+	}
+	cfg.Providers[2].Config.Authority = "https://other.authority"
+	require.NoError(t, cfg.Validate())
+
+	ctx := context.Background()
+	providers, firstProvider, closeProviders, err := newProviders(ctx, AuthorizationConfig{Providers: cfg.Providers[:2]}, logger)
+	require.NoError(t, err)
+	defer closeProviders()
+	// the openid configuration of the other authority is not served by the test oauth server
+	providers["other"] = &oauth2.PlgdProvider{
+		Config:     cfg.Providers[2].Config,
+		HTTPClient: firstProvider.HTTPClient,
+		OpenID:     firstProvider.OpenID,
+	}
+	providers["other"].OpenID.Issuer = "https://other.authority/"
+
+	v := newJwtValidator(cfg, providers, firstProvider)
+	s := Service{
+		config:       Config{APIs: APIsConfig{COAP: COAPConfig{Authorization: cfg}}},
+		jwtValidator: v,
+	}
+
+	// tokens of the first provider are validated and their owners are not prefixed
+	token := oauthTest.GetDefaultServiceToken(t)
+	claims, err := v.ParseWithContext(ctx, token)
+	require.NoError(t, err)
+	owner, err := s.Owner(jwt.Claims(claims))
+	require.NoError(t, err)
+	require.Equal(t, jwt.Claims(claims).Subject(), owner)
+	require.NoError(t, s.ValidateOwner(jwt.Claims(claims), owner))
+
+	// owners of the other authority are identified by its owner claim and prefixed by its issuer
+	otherClaims := jwt.Claims{
+		jwt.ClaimIssuer:  "https://other.authority/",
+		jwt.ClaimSubject: owner,
+		"oid":            "otherOwner",
+	}
+	owner, err = s.Owner(otherClaims)
+	require.NoError(t, err)
+	require.Equal(t, "https://other.authority/"+jwt.OwnerNamespaceSeparator+"otherOwner", owner)
+	require.Error(t, s.ValidateOwner(otherClaims, "otherOwner"))
+
+	// tokens of unknown issuers are rejected
+	_, err = v.ParseWithContext(ctx, config.CreateJwtToken(t, map[string]interface{}{
+		jwt.ClaimIssuer:  "https://unknown/",
+		jwt.ClaimSubject: "owner",
+	}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "untrusted issuer")
+	_, err = s.Owner(jwt.Claims{jwt.ClaimIssuer: "https://unknown/", jwt.ClaimSubject: "owner"})
+	require.Error(t, err)
+
+	// owners of the forwarded tokens are resolved by the validator
+	resolverCtx := kitNetGrpc.CtxWithIncomingToken(kitNetGrpc.CtxWithOwnerResolver(ctx, v), config.CreateJwtToken(t, map[string]interface{}{
+		jwt.ClaimIssuer:  "https://other.authority/",
+		jwt.ClaimSubject: "subject",
+		"oid":            "otherOwner",
+	}))
+	owner, err = kitNetGrpc.OwnerFromTokenMD(resolverCtx, jwt.ClaimSubject)
+	require.NoError(t, err)
+	require.Equal(t, "https://other.authority/"+jwt.OwnerNamespaceSeparator+"otherOwner", owner)
+}
+
+func TestAuthorizationConfigOwnerClaimOfAuthority(t *testing.T) {
+	cfg := AuthorizationConfig{
+		OwnerClaim: jwt.ClaimSubject,
+		Providers: []ProvidersConfig{
+			{Name: "first", Config: config.MakeDeviceAuthorization()},
+			{Name: "second", OwnerClaim: "oid", Config: config.MakeDeviceAuthorization()},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "providers[1].ownerClaim")
+}
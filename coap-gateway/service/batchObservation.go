@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/plgd-dev/device/schema/interfaces"
+	"github.com/plgd-dev/device/schema/resources"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/mux"
+	"github.com/plgd-dev/hub/coap-gateway/coapconv"
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+)
+
+// batchRepresentation is an item of the /oic/res?if=oic.if.b response.
+type batchRepresentation struct {
+	Href           string      `json:"href"`
+	Representation interface{} `json:"rep"`
+	ETag           []byte      `json:"etag,omitempty"`
+}
+
+// findBatchObservableResource returns /oic/res when the device observes it and supports the batch interface.
+func findBatchObservableResource(published []*commands.Resource) *commands.Resource {
+	for _, r := range published {
+		if r.GetHref() != resources.ResourceURI || !r.IsObservable() {
+			continue
+		}
+		for _, i := range r.GetInterfaces() {
+			if i == interfaces.OC_IF_B {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// makeBatchObservationOptions creates options of the batch observation with the known ETags of the resources, so the device
+// can respond 2.03 Valid or notify only the changed resources.
+func makeBatchObservationOptions(etags map[string][]byte) message.Options {
+	opts := message.Options{message.Option{
+		ID:    message.URIQuery,
+		Value: []byte("if=" + interfaces.OC_IF_B),
+	}}
+	for _, etag := range etags {
+		if len(etag) > 0 {
+			opts = append(opts, message.Option{
+				ID:    message.ETag,
+				Value: etag,
+			})
+		}
+	}
+	return opts
+}
+
+// observeResourcesBatchLocked tracks published resources of the device which content is delivered by the batch observation.
+// It returns the resources which must be observed one by one, because the device doesn't support the batch observation.
+// Etags contains the known ETags of the resources content by href.
+func (client *Client) observeResourcesBatchLocked(ctx context.Context, published []*commands.Resource, etags map[string][]byte) []*commands.Resource {
+	if len(published) == 0 {
+		return published
+	}
+	deviceID := published[0].GetDeviceId()
+	batchObsRes, ok := client.observedResources[deviceID][getInstanceID(resources.ResourceURI)]
+	if ok && batchObsRes.batch {
+		// batch notifications contain only changed resources, so the current content of newly published resources is retrieved
+		for _, r := range published {
+			if obsRes, ok := client.trackResourceLocked(deviceID, r.GetHref()); ok {
+				if obsRes.href != commands.StatusHref {
+					client.getResourceContent(ctx, deviceID, obsRes.href, etags[obsRes.href])
+				}
+			}
+		}
+		return nil
+	}
+	batchRes := findBatchObservableResource(published)
+	if batchRes == nil {
+		return published
+	}
+	obs, err := client.coapConn.Observe(ctx, batchRes.GetHref(), func(req *message.Message) {
+		notification := client.newMuxMessage(req)
+		err2 := client.server.taskQueue.Submit(func() {
+			if err := client.notifyBatchContentChanged(deviceID, notification); err != nil {
+				// cloud is unsynchronized against device. To recover cloud state, client need to reconnect to cloud.
+				log.Errorf("cannot observe batch resource /%v%v: %w", deviceID, batchRes.GetHref(), err)
+				if err := client.Close(); err != nil {
+					log.Errorf("failed to close client connection on observe batch resource /%v%v: %w", deviceID, batchRes.GetHref(), err)
+				}
+			}
+		})
+		if err2 != nil {
+			log.Errorf("cannot observe batch resource /%v%v: %w", deviceID, batchRes.GetHref(), err2)
+		}
+	}, makeBatchObservationOptions(etags)...)
+	if err != nil {
+		log.Errorf("cannot observe batch resource /%v%v, fallback to the observation of each resource: %w", deviceID, batchRes.GetHref(), err)
+		return published
+	}
+	for _, r := range published {
+		client.trackResourceLocked(deviceID, r.GetHref())
+	}
+	batchObsRes = client.observedResources[deviceID][getInstanceID(batchRes.GetHref())]
+	batchObsRes.batch = true
+	batchObsRes.SetObservation(&batchObservation{
+		batch:    obs,
+		resource: client.observeBatchResource(ctx, deviceID, batchObsRes.href, etags[batchObsRes.href]),
+	})
+	return nil
+}
+
+// batchObservation cancels the batch observation of /oic/res together with the observation of the /oic/res content.
+type batchObservation struct {
+	batch    mux.Observation
+	resource mux.Observation
+}
+
+func (o *batchObservation) Cancel(ctx context.Context) error {
+	var errors []error
+	for _, obs := range []mux.Observation{o.batch, o.resource} {
+		if obs == nil {
+			continue
+		}
+		if err := obs.Cancel(ctx); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%v", errors)
+	}
+	return nil
+}
+
+// observeBatchResource observes the content of /oic/res itself by the default interface, because the batch notifications
+// contain only the other resources of the device. It returns nil when the observation fails and the content is retrieved once.
+func (client *Client) observeBatchResource(ctx context.Context, deviceID, href string, etag []byte) mux.Observation {
+	obs, err := client.coapConn.Observe(ctx, href, func(req *message.Message) {
+		if req.Code == codes.Valid {
+			log.Debugf("resource /%v%v content is up to date", deviceID, href)
+			return
+		}
+		notification := client.newMuxMessage(req)
+		err2 := client.server.taskQueue.Submit(func() {
+			if err := client.notifyContentChanged(deviceID, href, notification); err != nil {
+				// cloud is unsynchronized against device. To recover cloud state, client need to reconnect to cloud.
+				log.Errorf("cannot observe resource /%v%v: %w", deviceID, href, err)
+				if err := client.Close(); err != nil {
+					log.Errorf("failed to close client connection on observe resource /%v%v: %w", deviceID, href, err)
+				}
+			}
+		})
+		if err2 != nil {
+			log.Errorf("cannot observe resource /%v%v: %w", deviceID, href, err2)
+		}
+	}, makeObservationOptions(etag)...)
+	if err != nil {
+		log.Errorf("cannot observe resource /%v%v: %w", deviceID, href, err)
+		client.getResourceContent(ctx, deviceID, href, etag)
+		return nil
+	}
+	return obs
+}
+
+// trackResourceLocked adds the resource to observed resources without creating the observation.
+func (client *Client) trackResourceLocked(deviceID, href string) (*observedResource, bool) {
+	instanceID := getInstanceID(href)
+	if _, ok := client.observedResources[deviceID]; !ok {
+		client.observedResources[deviceID] = make(map[int64]*observedResource)
+	}
+	if _, ok := client.observedResources[deviceID][instanceID]; ok {
+		return nil, false
+	}
+	obsRes := observedResource{href: href}
+	client.observedResources[deviceID][instanceID] = &obsRes
+	return &obsRes, true
+}
+
+func (client *Client) isTrackedResource(deviceID, href string) bool {
+	client.observedResourcesLock.Lock()
+	defer client.observedResourcesLock.Unlock()
+	_, ok := client.observedResources[deviceID][getInstanceID(href)]
+	return ok
+}
+
+// batchItemHref converts href of the batch item ocf://{deviceID}/{href} to the href of the resource.
+func batchItemHref(deviceID, href string) string {
+	return fixHref(strings.TrimPrefix(href, "ocf://"+deviceID))
+}
+
+// notifyBatchContentChanged demultiplexes the batch notification to NotifyResourceChanged commands of the contained resources.
+func (client *Client) notifyBatchContentChanged(deviceID string, notification *mux.Message) error {
+	if notification.Code == codes.Valid {
+		return nil
+	}
+	if notification.Code != codes.Content {
+		return fmt.Errorf("unexpected batch notification code %v", notification.Code)
+	}
+	authCtx, err := client.GetAuthorizationContext()
+	if err != nil {
+		return fmt.Errorf("cannot notify batch content changed: %w", err)
+	}
+	decodeMsgToDebug(client, notification.Message, "RECEIVED-BATCH-NOTIFICATION")
+
+	contentFormat, err := notification.Options.ContentFormat()
+	if err != nil {
+		contentFormat = message.AppOcfCbor
+	}
+	var items []batchRepresentation
+	if notification.Body != nil {
+		readFrom, err := coapconv.GetReadFrom(contentFormat)
+		if err != nil {
+			return fmt.Errorf("cannot decode batch notification: %w", err)
+		}
+		if err := readFrom(notification.Body, &items); err != nil {
+			return fmt.Errorf("cannot decode batch notification: %w", err)
+		}
+	}
+	encode, err := coapconv.GetEncoder(contentFormat)
+	if err != nil {
+		return fmt.Errorf("cannot encode batch notification: %w", err)
+	}
+	ctx := kitNetGrpc.CtxWithToken(client.Context(), authCtx.GetAccessToken())
+	metadata := coapconv.NewCommandMetadata(notification.SequenceNumber, client.remoteAddrString())
+	for _, item := range items {
+		href := batchItemHref(deviceID, item.Href)
+		// the content of /oic/res is delivered by its own observation
+		if href == resources.ResourceURI || !client.isTrackedResource(deviceID, href) {
+			continue
+		}
+		data, err := encode(item.Representation)
+		if err != nil {
+			return fmt.Errorf("cannot encode resource /%v%v content of batch notification: %w", deviceID, href, err)
+		}
+		_, err = client.server.raClient.NotifyResourceChanged(ctx, &commands.NotifyResourceChangedRequest{
+			ResourceId: commands.NewResourceID(deviceID, href),
+			Content: &commands.Content{
+				ContentType:       contentFormat.String(),
+				CoapContentFormat: int32(contentFormat),
+				Data:              data,
+			},
+			CommandMetadata: metadata,
+			Status:          commands.Status_OK,
+			Etag:            item.ETag,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot notify resource /%v%v content changed: %w", deviceID, href, err)
+		}
+	}
+	return nil
+}
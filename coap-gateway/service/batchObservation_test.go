@@ -0,0 +1,325 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/plgd-dev/device/schema"
+	"github.com/plgd-dev/device/schema/interfaces"
+	"github.com/plgd-dev/device/schema/resources"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/mux"
+	raClient "github.com/plgd-dev/hub/resource-aggregate/client"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/plgd-dev/kit/v2/codec/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestFindBatchObservableResource(t *testing.T) {
+	observable := &commands.Policy{BitFlags: int32(schema.Observable)}
+	oicRes := &commands.Resource{
+		Href:       resources.ResourceURI,
+		Interfaces: []string{interfaces.OC_IF_LL, interfaces.OC_IF_B, interfaces.OC_IF_BASELINE},
+		Policy:     observable,
+	}
+	light := &commands.Resource{
+		Href:   "/light/1",
+		Policy: observable,
+	}
+	tests := []struct {
+		name      string
+		published []*commands.Resource
+		want      *commands.Resource
+	}{
+		{
+			name:      "batch supported",
+			published: []*commands.Resource{light, oicRes},
+			want:      oicRes,
+		},
+		{
+			name: "without batch interface",
+			published: []*commands.Resource{light, {
+				Href:       resources.ResourceURI,
+				Interfaces: []string{interfaces.OC_IF_LL, interfaces.OC_IF_BASELINE},
+				Policy:     observable,
+			}},
+		},
+		{
+			name: "not observable",
+			published: []*commands.Resource{light, {
+				Href:       resources.ResourceURI,
+				Interfaces: []string{interfaces.OC_IF_B},
+			}},
+		},
+		{
+			name:      "without /oic/res",
+			published: []*commands.Resource{light},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findBatchObservableResource(tt.published))
+		})
+	}
+}
+
+func TestBatchItemHref(t *testing.T) {
+	assert.Equal(t, "/light/1", batchItemHref("dev1", "ocf://dev1/light/1"))
+	assert.Equal(t, "/light/1", batchItemHref("dev1", "/light/1"))
+}
+
+// testBatchCoapConn records the requests of the coap-gateway to the device.
+type testBatchCoapConn struct {
+	mux.Client
+	observeBatchErr error
+
+	observed map[string][]message.Options
+	got      map[string]message.Options
+}
+
+// sortOptions sorts the options by ID like the request of the connection.
+func sortOptions(opts []message.Option) message.Options {
+	sorted := make(message.Options, 0, len(opts))
+	for _, o := range opts {
+		sorted = sorted.Add(o)
+	}
+	return sorted
+}
+
+func (c *testBatchCoapConn) Observe(ctx context.Context, path string, observeFunc func(notification *message.Message), opts ...message.Option) (mux.Observation, error) {
+	sorted := sortOptions(opts)
+	c.observed[path] = append(c.observed[path], sorted)
+	queries, err := sorted.Queries()
+	if err != nil {
+		return nil, err
+	}
+	if len(queries) > 0 && queries[0] == "if="+interfaces.OC_IF_B && c.observeBatchErr != nil {
+		return nil, c.observeBatchErr
+	}
+	return nil, nil
+}
+
+func (c *testBatchCoapConn) Get(ctx context.Context, path string, opts ...message.Option) (*message.Message, error) {
+	c.got[path] = sortOptions(opts)
+	return &message.Message{
+		Code: codes.Valid,
+	}, nil
+}
+
+func (c *testBatchCoapConn) Context() context.Context {
+	return context.Background()
+}
+
+func (c *testBatchCoapConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{}
+}
+
+func (c *testBatchCoapConn) Sequence() uint64 {
+	return 1
+}
+
+// testResourceAggregateConn records the commands sent to the resource-aggregate.
+type testResourceAggregateConn struct {
+	grpc.ClientConnInterface
+
+	mutex         sync.Mutex
+	notifications []*commands.NotifyResourceChangedRequest
+}
+
+func (c *testResourceAggregateConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if req, ok := args.(*commands.NotifyResourceChangedRequest); ok {
+		c.notifications = append(c.notifications, req)
+	}
+	return nil
+}
+
+func newTestBatchClient(coapConn *testBatchCoapConn, raConn *testResourceAggregateConn) *Client {
+	var s Service
+	s.config.APIs.COAP.BatchObservation.Enabled = true
+	s.raClient = raClient.New(raConn, nil)
+	client := newClient(&s, coapConn, "")
+	client.authCtx = &authorizationContext{
+		DeviceID:    "dev1",
+		AccessToken: "token",
+	}
+	return client
+}
+
+func getOptionETags(opts message.Options) [][]byte {
+	var etags [][]byte
+	for _, o := range opts {
+		if o.ID == message.ETag {
+			etags = append(etags, o.Value)
+		}
+	}
+	return etags
+}
+
+func TestObserveResourcesBatch(t *testing.T) {
+	observable := &commands.Policy{BitFlags: int32(schema.Observable)}
+	oicRes := &commands.Resource{
+		DeviceId:   "dev1",
+		Href:       resources.ResourceURI,
+		Interfaces: []string{interfaces.OC_IF_LL, interfaces.OC_IF_B, interfaces.OC_IF_BASELINE},
+		Policy:     observable,
+	}
+	light1 := &commands.Resource{
+		DeviceId: "dev1",
+		Href:     "/light/1",
+		Policy:   observable,
+	}
+	light2 := &commands.Resource{
+		DeviceId: "dev1",
+		Href:     "/light/2",
+		Policy:   observable,
+	}
+	etags := map[string][]byte{
+		resources.ResourceURI: []byte("etagRes"),
+		light1.GetHref():      []byte("etag1"),
+		light2.GetHref():      []byte("etag2"),
+	}
+
+	coapConn := &testBatchCoapConn{
+		observed: make(map[string][]message.Options),
+		got:      make(map[string]message.Options),
+	}
+	client := newTestBatchClient(coapConn, &testResourceAggregateConn{})
+	client.observedResourcesLock.Lock()
+	defer client.observedResourcesLock.Unlock()
+
+	// resources are delivered by the batch observation, which is requested with the known ETags, and the content
+	// of /oic/res itself by the observation of the baseline interface
+	client.observeResourcesLocked(context.Background(), []*commands.Resource{oicRes, light1}, etags)
+	require.Len(t, coapConn.observed, 1)
+	observations := coapConn.observed[resources.ResourceURI]
+	require.Len(t, observations, 2)
+	queries, err := observations[0].Queries()
+	require.NoError(t, err)
+	require.Equal(t, []string{"if=" + interfaces.OC_IF_B}, queries)
+	require.ElementsMatch(t, [][]byte{[]byte("etagRes"), []byte("etag1"), []byte("etag2")}, getOptionETags(observations[0]))
+	queries, err = observations[1].Queries()
+	require.NoError(t, err)
+	require.Equal(t, []string{"if=" + interfaces.OC_IF_BASELINE}, queries)
+	require.Equal(t, [][]byte{[]byte("etagRes")}, getOptionETags(observations[1]))
+	require.True(t, client.observedResources["dev1"][getInstanceID(resources.ResourceURI)].batch)
+	require.Contains(t, client.observedResources["dev1"], getInstanceID(light1.GetHref()))
+
+	// content of the newly published resource is retrieved with its ETag, because batch notifications contain only changes
+	client.observeResourcesLocked(context.Background(), []*commands.Resource{light2}, etags)
+	require.Len(t, coapConn.observed, 1)
+	opts, ok := coapConn.got[light2.GetHref()]
+	require.True(t, ok)
+	require.Equal(t, [][]byte{[]byte("etag2")}, getOptionETags(opts))
+	require.Contains(t, client.observedResources["dev1"], getInstanceID(light2.GetHref()))
+}
+
+func TestObserveResourcesBatchFallback(t *testing.T) {
+	observable := &commands.Policy{BitFlags: int32(schema.Observable)}
+	oicRes := &commands.Resource{
+		DeviceId:   "dev1",
+		Href:       resources.ResourceURI,
+		Interfaces: []string{interfaces.OC_IF_LL, interfaces.OC_IF_B, interfaces.OC_IF_BASELINE},
+		Policy:     observable,
+	}
+	light1 := &commands.Resource{
+		DeviceId: "dev1",
+		Href:     "/light/1",
+		Policy:   observable,
+	}
+
+	coapConn := &testBatchCoapConn{
+		observeBatchErr: errors.New("batch interface is not supported"),
+		observed:        make(map[string][]message.Options),
+		got:             make(map[string]message.Options),
+	}
+	client := newTestBatchClient(coapConn, &testResourceAggregateConn{})
+	client.observedResourcesLock.Lock()
+	defer client.observedResourcesLock.Unlock()
+
+	// resources are observed one by one with their ETags, when the batch observation fails
+	client.observeResourcesLocked(context.Background(), []*commands.Resource{oicRes, light1}, map[string][]byte{
+		light1.GetHref(): []byte("etag1"),
+	})
+	require.Len(t, coapConn.observed, 2)
+	require.Len(t, coapConn.observed[resources.ResourceURI], 2)
+	observations, ok := coapConn.observed[light1.GetHref()]
+	require.True(t, ok)
+	require.Len(t, observations, 1)
+	require.Equal(t, [][]byte{[]byte("etag1")}, getOptionETags(observations[0]))
+	require.False(t, client.observedResources["dev1"][getInstanceID(resources.ResourceURI)].batch)
+}
+
+func TestNotifyBatchContentChanged(t *testing.T) {
+	raConn := &testResourceAggregateConn{}
+	client := newTestBatchClient(&testBatchCoapConn{}, raConn)
+	client.observedResourcesLock.Lock()
+	client.trackResourceLocked("dev1", resources.ResourceURI)
+	client.trackResourceLocked("dev1", "/light/1")
+	client.observedResourcesLock.Unlock()
+
+	body, err := cbor.Encode([]batchRepresentation{
+		{Href: "ocf://dev1" + resources.ResourceURI, Representation: map[string]interface{}{"rt": "oic.wk.res"}},
+		{Href: "ocf://dev1/light/1", Representation: map[string]interface{}{"name": "light1"}, ETag: []byte("etag1")},
+		{Href: "ocf://dev1/light/2", Representation: map[string]interface{}{"name": "light2"}},
+	})
+	require.NoError(t, err)
+
+	// 2.03 Valid means that the content of all resources is up to date
+	err = client.notifyBatchContentChanged("dev1", &mux.Message{
+		Message: &message.Message{Code: codes.Valid},
+	})
+	require.NoError(t, err)
+	require.Empty(t, raConn.notifications)
+
+	err = client.notifyBatchContentChanged("dev1", &mux.Message{
+		Message: &message.Message{Code: codes.InternalServerError},
+	})
+	require.Error(t, err)
+
+	// only the tracked resources of the batch are notified, /oic/res itself is delivered by its own observation
+	err = client.notifyBatchContentChanged("dev1", &mux.Message{
+		Message:        &message.Message{Code: codes.Content, Body: bytes.NewReader(body)},
+		SequenceNumber: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, raConn.notifications, 1)
+	n := raConn.notifications[0]
+	require.Equal(t, commands.NewResourceID("dev1", "/light/1"), n.GetResourceId())
+	require.Equal(t, []byte("etag1"), n.GetEtag())
+	require.Equal(t, uint64(5), n.GetCommandMetadata().GetSequence())
+	require.Equal(t, int32(message.AppOcfCbor), n.GetContent().GetCoapContentFormat())
+	var rep map[string]interface{}
+	require.NoError(t, cbor.Decode(n.GetContent().GetData(), &rep))
+	require.Equal(t, map[string]interface{}{"name": "light1"}, rep)
+
+	// the content format of the notification is kept
+	body, err = json.Encode([]batchRepresentation{
+		{Href: "ocf://dev1/light/1", Representation: map[string]interface{}{"name": "light1"}},
+	})
+	require.NoError(t, err)
+	err = client.notifyBatchContentChanged("dev1", &mux.Message{
+		Message: &message.Message{
+			Code:    codes.Content,
+			Options: message.Options{{ID: message.ContentFormat, Value: []byte{byte(message.AppJSON)}}},
+			Body:    bytes.NewReader(body),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, raConn.notifications, 2)
+	n = raConn.notifications[1]
+	require.Equal(t, message.AppJSON.String(), n.GetContent().GetContentType())
+	require.Equal(t, int32(message.AppJSON), n.GetContent().GetCoapContentFormat())
+	rep = nil
+	require.NoError(t, json.Decode(n.GetContent().GetData(), &rep))
+	require.Equal(t, map[string]interface{}{"name": "light1"}, rep)
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/plgd-dev/device/schema/interfaces"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
-	"github.com/plgd-dev/go-coap/v2/tcp"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
+	"github.com/plgd-dev/go-coap/v2/message/pool"
+	"github.com/plgd-dev/go-coap/v2/mux"
 	"github.com/plgd-dev/hub/coap-gateway/coapconv"
 	grpcClient "github.com/plgd-dev/hub/grpc-gateway/client"
 	idEvents "github.com/plgd-dev/hub/identity-store/events"
@@ -27,18 +27,21 @@ import (
 
 type observedResource struct {
 	href string
+	etag []byte // ETag of the content stored in the hub, the device responds 2.03 Valid when it is still up to date
+	// batch is set for /oic/res when its observation delivers the content of all resources of the device
+	batch bool
 
 	mutex       sync.Mutex
-	observation *tcp.Observation
+	observation mux.Observation
 }
 
-func (r *observedResource) SetObservation(o *tcp.Observation) {
+func (r *observedResource) SetObservation(o mux.Observation) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	r.observation = o
 }
 
-func (r *observedResource) PopObservation() *tcp.Observation {
+func (r *observedResource) PopObservation() mux.Observation {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	o := r.observation
@@ -94,7 +97,7 @@ func (a *authorizationContext) ToContext(ctx context.Context) context.Context {
 //Client a setup of connection
 type Client struct {
 	server      *Service
-	coapConn    *tcp.ClientConn
+	coapConn    mux.Client
 	tlsDeviceID string
 
 	observedResources     map[string]map[int64]*observedResource // [deviceID][instanceID]
@@ -113,7 +116,7 @@ type Client struct {
 }
 
 //newClient create and initialize client
-func newClient(server *Service, client *tcp.ClientConn, tlsDeviceID string) *Client {
+func newClient(server *Service, client mux.Client, tlsDeviceID string) *Client {
 	return &Client{
 		server:                server,
 		coapConn:              client,
@@ -144,6 +147,29 @@ func (client *Client) Context() context.Context {
 	return client.coapConn.Context()
 }
 
+// newMuxMessage attaches the sequence number of the connection to the message received from the device.
+func (client *Client) newMuxMessage(msg *message.Message) *mux.Message {
+	return &mux.Message{
+		Message:        msg,
+		SequenceNumber: client.coapConn.Sequence(),
+	}
+}
+
+func (client *Client) newConfirmMessage(ctx context.Context, code codes.Code) *mux.Message {
+	return client.newMuxMessage(&message.Message{
+		Context: ctx,
+		Code:    code,
+	})
+}
+
+func (client *Client) newErrorConfirmMessage(ctx context.Context, code codes.Code, errToSend error) *mux.Message {
+	msg := pool.NewMessage()
+	msg.SetCode(code)
+	msg.SetContentFormat(message.TextPlain)
+	msg.SetBody(bytes.NewReader([]byte(errToSend.Error())))
+	return client.newMuxMessage(coapconv.ToMessage(ctx, msg))
+}
+
 func (client *Client) cancelResourceSubscription(token string) (bool, error) {
 	s, ok := client.resourceSubscriptions.PullOut(token)
 	if !ok {
@@ -157,9 +183,13 @@ func (client *Client) cancelResourceSubscription(token string) (bool, error) {
 	}
 	return true, nil
 }
-func (client *Client) observeResourcesLocked(ctx context.Context, resources []*commands.Resource) {
+// observeResourcesLocked observes the resources, etags contains the known ETags of the resources content by href.
+func (client *Client) observeResourcesLocked(ctx context.Context, resources []*commands.Resource, etags map[string][]byte) {
+	if client.server.config.APIs.COAP.BatchObservation.Enabled {
+		resources = client.observeResourcesBatchLocked(ctx, resources, etags)
+	}
 	for _, resource := range resources {
-		client.observeResource(ctx, resource.GetResourceID(), resource.IsObservable())
+		client.observeResource(ctx, resource.GetResourceID(), resource.IsObservable(), etags[resource.GetHref()])
 	}
 }
 
@@ -167,10 +197,10 @@ func (client *Client) observeResources(ctx context.Context, resources []*command
 	client.observedResourcesLock.Lock()
 	defer client.observedResourcesLock.Unlock()
 
-	client.observeResourcesLocked(ctx, resources)
+	client.observeResourcesLocked(ctx, resources, nil)
 }
 
-func (client *Client) observeResource(ctx context.Context, resourceID *commands.ResourceId, observable bool) {
+func (client *Client) observeResource(ctx context.Context, resourceID *commands.ResourceId, observable bool, etag []byte) {
 	log.Debugf("observation of resource %v requested", resourceID)
 	instanceID := getInstanceID(resourceID.GetHref())
 	deviceID := resourceID.GetDeviceId()
@@ -181,23 +211,40 @@ func (client *Client) observeResource(ctx context.Context, resourceID *commands.
 	if _, ok := client.observedResources[deviceID][instanceID]; ok {
 		return
 	}
-	obsRes := observedResource{href: href}
+	obsRes := observedResource{href: href, etag: etag}
 	client.observedResources[deviceID][instanceID] = &obsRes
 	client.addObservedResourceLocked(ctx, deviceID, observable, &obsRes)
 }
 
-func (client *Client) getResourceContent(ctx context.Context, deviceID, href string) {
-	resp, err := client.coapConn.Get(ctx, href, message.Option{
+// makeObservationOptions creates options of the GET/observe request, with the ETag the device can respond 2.03 Valid
+// instead of the whole content (RFC 7252 5.10.6).
+func makeObservationOptions(etag []byte) message.Options {
+	opts := message.Options{message.Option{
 		ID:    message.URIQuery,
 		Value: []byte("if=" + interfaces.OC_IF_BASELINE),
-	})
+	}}
+	if len(etag) > 0 {
+		opts = append(opts, message.Option{
+			ID:    message.ETag,
+			Value: etag,
+		})
+	}
+	return opts
+}
+
+func (client *Client) getResourceContent(ctx context.Context, deviceID, href string, etag []byte) {
+	resp, err := client.coapConn.Get(ctx, href, makeObservationOptions(etag)...)
 	if err != nil {
 		log.Errorf("cannot get resource /%v%v content: %w", deviceID, href, err)
 		return
 	}
+	if resp.Code == codes.Valid {
+		log.Debugf("resource /%v%v content is up to date", deviceID, href)
+		return
+	}
+	notification := client.newMuxMessage(resp)
 	err = client.server.taskQueue.Submit(func() {
-		defer pool.ReleaseMessage(resp)
-		err := client.notifyContentChanged(deviceID, href, resp)
+		err := client.notifyContentChanged(deviceID, href, notification)
 		if err != nil {
 			// cloud is unsynchronized against device. To recover cloud state, client need to reconnect to cloud.
 			log.Errorf("cannot get resource /%v%v content: %w", deviceID, href, err)
@@ -205,7 +252,7 @@ func (client *Client) getResourceContent(ctx context.Context, deviceID, href str
 				log.Errorf("failed to close client connection on get resource /%v%v: %w", deviceID, href, err)
 			}
 		}
-		if resp.Code() == codes.NotFound {
+		if resp.Code == codes.NotFound {
 			client.unpublishResourceLinks(client.getUserAuthorizedContext(ctx), []string{href})
 		}
 	})
@@ -220,11 +267,14 @@ func (client *Client) addObservedResourceLocked(ctx context.Context, deviceID st
 		return
 	}
 	if isObservable {
-		obs, err := client.coapConn.Observe(ctx, obsRes.href, func(req *pool.Message) {
-			req.Hijack()
+		obs, err := client.coapConn.Observe(ctx, obsRes.href, func(req *message.Message) {
+			if req.Code == codes.Valid {
+				log.Debugf("resource /%v%v content is up to date", deviceID, obsRes.href)
+				return
+			}
+			notification := client.newMuxMessage(req)
 			err2 := client.server.taskQueue.Submit(func() {
-				err := client.notifyContentChanged(deviceID, obsRes.href, req)
-				defer pool.ReleaseMessage(req)
+				err := client.notifyContentChanged(deviceID, obsRes.href, notification)
 				if err != nil {
 					// cloud is unsynchronized against device. To recover cloud state, client need to reconnect to cloud.
 					log.Errorf("cannot observe resource /%v%v: %w", deviceID, obsRes.href, err)
@@ -232,24 +282,21 @@ func (client *Client) addObservedResourceLocked(ctx context.Context, deviceID st
 						log.Errorf("failed to close client connection on observe resource /%v%v: %w", deviceID, obsRes.href, err)
 					}
 				}
-				if req.Code() == codes.NotFound {
-					client.unpublishResourceLinks(client.getUserAuthorizedContext(req.Context()), []string{obsRes.href})
+				if req.Code == codes.NotFound {
+					client.unpublishResourceLinks(client.getUserAuthorizedContext(req.Context), []string{obsRes.href})
 				}
 			})
 			if err2 != nil {
 				log.Errorf("cannot observe resource /%v%v: %w", deviceID, obsRes.href, err2)
 			}
-		}, message.Option{
-			ID:    message.URIQuery,
-			Value: []byte("if=" + interfaces.OC_IF_BASELINE),
-		})
+		}, makeObservationOptions(obsRes.etag)...)
 		if err != nil {
 			log.Errorf("cannot observe resource /%v%v: %w", deviceID, obsRes.href, err)
 		} else {
 			obsRes.SetObservation(obs)
 		}
 	} else {
-		client.getResourceContent(ctx, deviceID, obsRes.href)
+		client.getResourceContent(ctx, deviceID, obsRes.href, obsRes.etag)
 	}
 }
 
@@ -292,7 +339,7 @@ func (client *Client) removeResourceLocked(deviceID string, instanceID int64) {
 	}
 }
 
-func (client *Client) popObservation(deviceID string, instanceID int64) *tcp.Observation {
+func (client *Client) popObservation(deviceID string, instanceID int64) mux.Observation {
 	log.Debugf("remove published resource ocf://%v/%v", deviceID, instanceID)
 
 	if device, ok := client.observedResources[deviceID]; ok {
@@ -322,8 +369,8 @@ func (client *Client) Close() error {
 	return nil
 }
 
-func (client *Client) popTrackedObservation(hrefs []string) []*tcp.Observation {
-	observartions := make([]*tcp.Observation, 0, 32)
+func (client *Client) popTrackedObservation(hrefs []string) []mux.Observation {
+	observartions := make([]mux.Observation, 0, 32)
 
 	client.observedResourcesLock.Lock()
 	defer client.observedResourcesLock.Unlock()
@@ -436,6 +483,7 @@ func (client *Client) OnClose() {
 	oldAuthCtx := client.CleanUp(false)
 
 	if oldAuthCtx.GetDeviceID() != "" {
+		connectedDevices.Dec()
 		client.server.expirationClientCache.Set(oldAuthCtx.GetDeviceID(), nil, time.Millisecond)
 		ctx, cancel := context.WithTimeout(context.Background(), client.server.config.APIs.COAP.KeepAlive.Timeout)
 		defer cancel()
@@ -464,6 +512,12 @@ func (client *Client) SetAuthorizationContext(authCtx *authorizationContext) (ol
 	defer client.mutex.Unlock()
 	oldAuthContext := client.authCtx
 	client.authCtx = authCtx
+	switch {
+	case oldAuthContext.GetDeviceID() == "" && authCtx.GetDeviceID() != "":
+		connectedDevices.Inc()
+	case oldAuthContext.GetDeviceID() != "" && authCtx.GetDeviceID() == "":
+		connectedDevices.Dec()
+	}
 	return oldAuthContext
 }
 
@@ -473,12 +527,12 @@ func (client *Client) GetAuthorizationContext() (*authorizationContext, error) {
 	return client.authCtx, client.authCtx.IsValid()
 }
 
-func (client *Client) notifyContentChanged(deviceID string, href string, notification *pool.Message) error {
+func (client *Client) notifyContentChanged(deviceID string, href string, notification *mux.Message) error {
 	authCtx, err := client.GetAuthorizationContext()
 	if err != nil {
 		return fmt.Errorf("cannot notify resource /%v%v content changed: %w", deviceID, href, err)
 	}
-	decodeMsgToDebug(client, notification, "RECEIVED-NOTIFICATION")
+	decodeMsgToDebug(client, notification.Message, "RECEIVED-NOTIFICATION")
 
 	ctx := kitNetGrpc.CtxWithToken(client.Context(), authCtx.GetAccessToken())
 	request := coapconv.NewNotifyResourceChangedRequest(commands.NewResourceID(deviceID, href), client.remoteAddrString(), notification)
@@ -490,11 +544,7 @@ func (client *Client) notifyContentChanged(deviceID string, href string, notific
 }
 
 func (client *Client) sendErrorConfirmResourceUpdate(ctx context.Context, deviceID, href, userID, correlationID string, code codes.Code, errToSend error) {
-	resp := pool.AcquireMessage(ctx)
-	defer pool.ReleaseMessage(resp)
-	resp.SetContentFormat(message.TextPlain)
-	resp.SetBody(bytes.NewReader([]byte(errToSend.Error())))
-	resp.SetCode(code)
+	resp := client.newErrorConfirmMessage(ctx, code, errToSend)
 	request := coapconv.NewConfirmResourceUpdateRequest(commands.NewResourceID(deviceID, href), correlationID, client.remoteAddrString(), resp)
 	_, err := client.server.raClient.ConfirmResourceUpdate(ctx, request)
 	if err != nil {
@@ -514,10 +564,7 @@ func (client *Client) UpdateResource(ctx context.Context, event *events.Resource
 	sendConfirmCtx := authCtx.ToContext(ctx)
 
 	if event.GetResourceId().GetHref() == commands.StatusHref {
-		msg := pool.AcquireMessage(ctx)
-		msg.SetCode(codes.MethodNotAllowed)
-		msg.SetSequence(client.coapConn.Sequence())
-		defer pool.ReleaseMessage(msg)
+		msg := client.newConfirmMessage(ctx, codes.MethodNotAllowed)
 		request := coapconv.NewConfirmResourceUpdateRequest(event.GetResourceId(), event.GetAuditContext().GetCorrelationId(), client.remoteAddrString(), msg)
 		_, err = client.server.raClient.ConfirmResourceUpdate(sendConfirmCtx, request)
 		if err != nil {
@@ -533,20 +580,19 @@ func (client *Client) UpdateResource(ctx context.Context, event *events.Resource
 		client.sendErrorConfirmResourceUpdate(sendConfirmCtx, event.GetResourceId().GetDeviceId(), event.GetResourceId().GetHref(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.BadRequest, err)
 		return err
 	}
-	defer pool.ReleaseMessage(req)
 
 	decodeMsgToDebug(client, req, "RESOURCE-UPDATE-REQUEST")
 
-	resp, err := client.coapConn.Do(req)
+	coapResp, err := client.coapConn.Do(req)
 	if err != nil {
 		client.sendErrorConfirmResourceUpdate(sendConfirmCtx, event.GetResourceId().GetDeviceId(), event.GetResourceId().GetHref(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.ServiceUnavailable, err)
 		return err
 	}
-	defer pool.ReleaseMessage(resp)
+	resp := client.newMuxMessage(coapResp)
 
-	decodeMsgToDebug(client, resp, "RESOURCE-UPDATE-RESPONSE")
+	decodeMsgToDebug(client, resp.Message, "RESOURCE-UPDATE-RESPONSE")
 
-	if resp.Code() == codes.NotFound {
+	if resp.Code == codes.NotFound {
 		client.unpublishResourceLinks(client.getUserAuthorizedContext(ctx), []string{event.GetResourceId().GetHref()})
 	}
 
@@ -560,11 +606,7 @@ func (client *Client) UpdateResource(ctx context.Context, event *events.Resource
 }
 
 func (client *Client) sendErrorConfirmResourceRetrieve(ctx context.Context, deviceID, href, userID, correlationID string, code codes.Code, errToSend error) {
-	resp := pool.AcquireMessage(ctx)
-	defer pool.ReleaseMessage(resp)
-	resp.SetContentFormat(message.TextPlain)
-	resp.SetBody(bytes.NewReader([]byte(errToSend.Error())))
-	resp.SetCode(code)
+	resp := client.newErrorConfirmMessage(ctx, code, errToSend)
 	request := coapconv.NewConfirmResourceRetrieveRequest(commands.NewResourceID(deviceID, href), correlationID, client.remoteAddrString(), resp)
 	_, err := client.server.raClient.ConfirmResourceRetrieve(ctx, request)
 	if err != nil {
@@ -584,10 +626,7 @@ func (client *Client) RetrieveResource(ctx context.Context, event *events.Resour
 	sendConfirmCtx := authCtx.ToContext(ctx)
 
 	if event.GetResourceId().GetHref() == commands.StatusHref {
-		msg := pool.AcquireMessage(ctx)
-		msg.SetCode(codes.Content)
-		msg.SetSequence(client.coapConn.Sequence())
-		defer pool.ReleaseMessage(msg)
+		msg := client.newConfirmMessage(ctx, codes.Content)
 
 		request := coapconv.NewConfirmResourceRetrieveRequest(event.GetResourceId(), event.GetAuditContext().GetCorrelationId(), client.remoteAddrString(), msg)
 		_, err = client.server.raClient.ConfirmResourceRetrieve(sendConfirmCtx, request)
@@ -604,20 +643,19 @@ func (client *Client) RetrieveResource(ctx context.Context, event *events.Resour
 		client.sendErrorConfirmResourceRetrieve(sendConfirmCtx, event.GetResourceId().GetDeviceId(), event.GetResourceId().GetHref(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.BadRequest, err)
 		return err
 	}
-	defer pool.ReleaseMessage(req)
 
 	decodeMsgToDebug(client, req, "RESOURCE-RETRIEVE-REQUEST")
 
-	resp, err := client.coapConn.Do(req)
+	coapResp, err := client.coapConn.Do(req)
 	if err != nil {
 		client.sendErrorConfirmResourceRetrieve(sendConfirmCtx, event.GetResourceId().GetDeviceId(), event.GetResourceId().GetHref(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.ServiceUnavailable, err)
 		return err
 	}
-	defer pool.ReleaseMessage(resp)
+	resp := client.newMuxMessage(coapResp)
 
-	decodeMsgToDebug(client, resp, "RESOURCE-RETRIEVE-RESPONSE")
+	decodeMsgToDebug(client, resp.Message, "RESOURCE-RETRIEVE-RESPONSE")
 
-	if resp.Code() == codes.NotFound {
+	if resp.Code == codes.NotFound {
 		client.unpublishResourceLinks(client.getUserAuthorizedContext(ctx), []string{event.GetResourceId().GetHref()})
 	}
 
@@ -631,11 +669,7 @@ func (client *Client) RetrieveResource(ctx context.Context, event *events.Resour
 }
 
 func (client *Client) sendErrorConfirmResourceDelete(ctx context.Context, deviceID, href, userID, correlationID string, code codes.Code, errToSend error) {
-	resp := pool.AcquireMessage(ctx)
-	defer pool.ReleaseMessage(resp)
-	resp.SetContentFormat(message.TextPlain)
-	resp.SetBody(bytes.NewReader([]byte(errToSend.Error())))
-	resp.SetCode(code)
+	resp := client.newErrorConfirmMessage(ctx, code, errToSend)
 	request := coapconv.NewConfirmResourceDeleteRequest(commands.NewResourceID(deviceID, href), correlationID, client.remoteAddrString(), resp)
 	_, err := client.server.raClient.ConfirmResourceDelete(ctx, request)
 	if err != nil {
@@ -655,10 +689,7 @@ func (client *Client) DeleteResource(ctx context.Context, event *events.Resource
 	sendConfirmCtx := authCtx.ToContext(ctx)
 
 	if event.GetResourceId().GetHref() == commands.StatusHref {
-		msg := pool.AcquireMessage(ctx)
-		msg.SetCode(codes.Forbidden)
-		msg.SetSequence(client.coapConn.Sequence())
-		defer pool.ReleaseMessage(msg)
+		msg := client.newConfirmMessage(ctx, codes.Forbidden)
 
 		request := coapconv.NewConfirmResourceDeleteRequest(event.GetResourceId(), event.GetAuditContext().GetCorrelationId(), client.remoteAddrString(), msg)
 		_, err = client.server.raClient.ConfirmResourceDelete(sendConfirmCtx, request)
@@ -675,20 +706,19 @@ func (client *Client) DeleteResource(ctx context.Context, event *events.Resource
 		client.sendErrorConfirmResourceDelete(sendConfirmCtx, event.GetResourceId().GetDeviceId(), event.GetResourceId().GetHref(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.BadRequest, err)
 		return err
 	}
-	defer pool.ReleaseMessage(req)
 
 	decodeMsgToDebug(client, req, "RESOURCE-DELETE-REQUEST")
 
-	resp, err := client.coapConn.Do(req)
+	coapResp, err := client.coapConn.Do(req)
 	if err != nil {
 		client.sendErrorConfirmResourceDelete(sendConfirmCtx, event.GetResourceId().GetDeviceId(), event.GetResourceId().GetHref(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.ServiceUnavailable, err)
 		return err
 	}
-	defer pool.ReleaseMessage(resp)
+	resp := client.newMuxMessage(coapResp)
 
-	decodeMsgToDebug(client, resp, "RESOURCE-DELETE-RESPONSE")
+	decodeMsgToDebug(client, resp.Message, "RESOURCE-DELETE-RESPONSE")
 
-	if resp.Code() == codes.NotFound {
+	if resp.Code == codes.NotFound {
 		client.unpublishResourceLinks(client.getUserAuthorizedContext(ctx), []string{event.GetResourceId().GetHref()})
 	}
 
@@ -761,11 +791,7 @@ func (client *Client) unpublishResourceLinks(ctx context.Context, hrefs []string
 }
 
 func (client *Client) sendErrorConfirmResourceCreate(ctx context.Context, resourceID *commands.ResourceId, userID, correlationID string, code codes.Code, errToSend error) {
-	resp := pool.AcquireMessage(ctx)
-	defer pool.ReleaseMessage(resp)
-	resp.SetContentFormat(message.TextPlain)
-	resp.SetBody(bytes.NewReader([]byte(errToSend.Error())))
-	resp.SetCode(code)
+	resp := client.newErrorConfirmMessage(ctx, code, errToSend)
 	request := coapconv.NewConfirmResourceCreateRequest(resourceID, correlationID, client.remoteAddrString(), resp)
 	_, err := client.server.raClient.ConfirmResourceCreate(ctx, request)
 	if err != nil {
@@ -784,10 +810,7 @@ func (client *Client) CreateResource(ctx context.Context, event *events.Resource
 	}
 	sendConfirmCtx := authCtx.ToContext(ctx)
 	if event.GetResourceId().GetHref() == commands.StatusHref {
-		msg := pool.AcquireMessage(ctx)
-		msg.SetCode(codes.Forbidden)
-		msg.SetSequence(client.coapConn.Sequence())
-		defer pool.ReleaseMessage(msg)
+		msg := client.newConfirmMessage(ctx, codes.Forbidden)
 		request := coapconv.NewConfirmResourceCreateRequest(event.GetResourceId(), event.GetAuditContext().GetCorrelationId(), client.remoteAddrString(), msg)
 		_, err = client.server.raClient.ConfirmResourceCreate(sendConfirmCtx, request)
 		if err != nil {
@@ -803,20 +826,19 @@ func (client *Client) CreateResource(ctx context.Context, event *events.Resource
 		client.sendErrorConfirmResourceCreate(sendConfirmCtx, event.GetResourceId(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.BadRequest, err)
 		return err
 	}
-	defer pool.ReleaseMessage(req)
 
 	decodeMsgToDebug(client, req, "RESOURCE-CREATE-REQUEST")
 
-	resp, err := client.coapConn.Do(req)
+	coapResp, err := client.coapConn.Do(req)
 	if err != nil {
 		client.sendErrorConfirmResourceCreate(sendConfirmCtx, event.GetResourceId(), authCtx.GetUserID(), event.GetAuditContext().GetCorrelationId(), codes.ServiceUnavailable, err)
 		return err
 	}
-	defer pool.ReleaseMessage(resp)
+	resp := client.newMuxMessage(coapResp)
 
-	decodeMsgToDebug(client, resp, "RESOURCE-CREATE-RESPONSE")
+	decodeMsgToDebug(client, resp.Message, "RESOURCE-CREATE-RESPONSE")
 
-	if resp.Code() == codes.NotFound {
+	if resp.Code == codes.NotFound {
 		client.unpublishResourceLinks(client.getUserAuthorizedContext(ctx), []string{event.GetResourceId().GetHref()})
 	}
 
@@ -846,19 +868,24 @@ func (client *Client) GetContext() (context.Context, context.CancelFunc) {
 	return authCtx.ToContext(client.Context()), func() {}
 }
 
-func (client *Client) setShadowSynchronization(ctx context.Context, deviceID string, shadowSynchronization commands.ShadowSynchronization) commands.ShadowSynchronization {
+func (client *Client) replaceShadowSynchronization(shadowSynchronization commands.ShadowSynchronization) commands.ShadowSynchronization {
 	client.observedResourcesLock.Lock()
 	defer client.observedResourcesLock.Unlock()
-	if client.shadowSynchronization == shadowSynchronization {
-		return client.shadowSynchronization
-	}
 	previous := client.shadowSynchronization
-
+	if previous == shadowSynchronization {
+		return previous
+	}
 	client.shadowSynchronization = shadowSynchronization
 	if shadowSynchronization == commands.ShadowSynchronization_DISABLED {
 		client.cleanObservedResourcesLocked()
-	} else {
-		client.registerObservationsForPublishedResourcesLocked(ctx, deviceID)
+	}
+	return previous
+}
+
+func (client *Client) setShadowSynchronization(ctx context.Context, deviceID string, shadowSynchronization commands.ShadowSynchronization) commands.ShadowSynchronization {
+	previous := client.replaceShadowSynchronization(shadowSynchronization)
+	if previous != shadowSynchronization && shadowSynchronization != commands.ShadowSynchronization_DISABLED {
+		client.registerObservationsForPublishedResources(ctx, deviceID)
 	}
 	return previous
 }
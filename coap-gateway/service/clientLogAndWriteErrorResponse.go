@@ -8,7 +8,8 @@ import (
 
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
+	"github.com/plgd-dev/go-coap/v2/message/pool"
+	"github.com/plgd-dev/hub/coap-gateway/coapconv"
 	"github.com/plgd-dev/hub/pkg/log"
 )
 
@@ -35,15 +36,15 @@ func (client *Client) logAndWriteErrorResponse(err error, code codes.Code, token
 	if err != nil {
 		log.Errorf("%w", err)
 	}
-	msg := pool.AcquireMessage(client.coapConn.Context())
-	defer pool.ReleaseMessage(msg)
+	msg := pool.NewMessage()
 	msg.SetCode(code)
 	msg.SetToken(token)
 	// Don't set content format for diagnostic message: https://tools.ietf.org/html/rfc7252#section-5.5.2
 	msg.SetBody(bytes.NewReader([]byte(err.Error())))
-	err = client.coapConn.WriteMessage(msg)
+	resp := coapconv.ToMessage(client.coapConn.Context(), msg)
+	err = client.coapConn.WriteMessage(resp)
 	if err != nil {
 		log.Errorf("cannot send error to %v: %w", getDeviceID(client), err)
 	}
-	decodeMsgToDebug(client, msg, "SEND-ERROR")
+	decodeMsgToDebug(client, resp, "SEND-ERROR")
 }
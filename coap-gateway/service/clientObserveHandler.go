@@ -11,8 +11,8 @@ import (
 
 	"github.com/plgd-dev/go-coap/v2/message"
 	coapCodes "github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/message/pool"
 	"github.com/plgd-dev/go-coap/v2/mux"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
 	"github.com/plgd-dev/hub/coap-gateway/coapconv"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/grpc-gateway/subscription"
@@ -53,7 +53,7 @@ func clientObserveHandler(req *mux.Message, client *Client, observe uint32) {
 
 func SendResourceContentToObserver(client *Client, resourceChanged *events.ResourceChanged, observe uint32, token message.Token) {
 
-	msg := pool.AcquireMessage(client.coapConn.Context())
+	msg := pool.NewMessage()
 	msg.SetCode(coapCodes.Content)
 	msg.SetObserve(observe)
 	msg.SetToken(token)
@@ -66,11 +66,12 @@ func SendResourceContentToObserver(client *Client, resourceChanged *events.Resou
 		msg.SetContentFormat(mediaType)
 		msg.SetBody(bytes.NewReader(resourceChanged.GetContent().GetData()))
 	}
-	err := client.coapConn.WriteMessage(msg)
+	notification := coapconv.ToMessage(client.coapConn.Context(), msg)
+	err := client.coapConn.WriteMessage(notification)
 	if err != nil {
 		log.Errorf("cannot send observe notification to %v: %w", client.remoteAddrString(), err)
 	}
-	decodeMsgToDebug(client, msg, "SEND-NOTIFICATION")
+	decodeMsgToDebug(client, notification, "SEND-NOTIFICATION")
 }
 
 type resourceSubscription struct {
@@ -201,7 +202,7 @@ func newResourceSubscription(req *mux.Message, client *Client, authCtx *authoriz
 	}
 
 	res := &commands.ResourceId{DeviceId: deviceID, Href: href}
-	sub := subscription.New(r.eventHandler, req.Token.String(), &pb.SubscribeToEvents_CreateSubscription{
+	sub := subscription.New(r.eventHandler, req.Token.String(), 0, &pb.SubscribeToEvents_CreateSubscription{
 		ResourceIdFilter: []string{res.ToString()},
 		EventFilter:      []pb.SubscribeToEvents_CreateSubscription_Event{pb.SubscribeToEvents_CreateSubscription_RESOURCE_CHANGED, pb.SubscribeToEvents_CreateSubscription_UNREGISTERED, pb.SubscribeToEvents_CreateSubscription_RESOURCE_UNPUBLISHED},
 	})
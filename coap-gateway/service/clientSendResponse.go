@@ -3,27 +3,28 @@ package service
 import (
 	"bytes"
 
+	"github.com/plgd-dev/hub/coap-gateway/coapconv"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/go-coap/v2/message"
 	coapCodes "github.com/plgd-dev/go-coap/v2/message/codes"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
+	"github.com/plgd-dev/go-coap/v2/message/pool"
 )
 
 func (client *Client) sendResponse(code coapCodes.Code, token message.Token, contentFormat message.MediaType, payload []byte) {
-	msg := pool.AcquireMessage(client.coapConn.Context())
-	defer pool.ReleaseMessage(msg)
+	msg := pool.NewMessage()
 	msg.SetCode(code)
 	msg.SetToken(token)
 	if len(payload) > 0 {
 		msg.SetContentFormat(contentFormat)
 		msg.SetBody(bytes.NewReader(payload))
 	}
-	err := client.coapConn.WriteMessage(msg)
+	resp := coapconv.ToMessage(client.coapConn.Context(), msg)
+	err := client.coapConn.WriteMessage(resp)
 	if err != nil {
 		if !kitNetGrpc.IsContextCanceled(err) {
 			log.Errorf("cannot send reply to %v: %w", getDeviceID(client), err)
 		}
 	}
-	decodeMsgToDebug(client, msg, "SEND-RESPONSE")
+	decodeMsgToDebug(client, resp, "SEND-RESPONSE")
 }
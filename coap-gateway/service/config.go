@@ -7,7 +7,9 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	certManagerServer "github.com/plgd-dev/hub/pkg/security/certManager/server"
 	"github.com/plgd-dev/hub/pkg/security/oauth2"
 	"github.com/plgd-dev/hub/pkg/sync/task/queue"
@@ -42,25 +44,28 @@ type LogConfig struct {
 }
 
 type APIsConfig struct {
-	COAP COAPConfig `yaml:"coap" json:"coap"`
+	COAP    COAPConfig     `yaml:"coap" json:"coap"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.COAP.Validate(); err != nil {
 		return fmt.Errorf("coap.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
 type ProvidersConfig struct {
-	Name          string `yaml:"name" json:"name"`
+	Name string `yaml:"name" json:"name"`
+	// OwnerClaim, when set, overrides authorization.ownerClaim for tokens of the provider's authority.
+	OwnerClaim    string `yaml:"ownerClaim" json:"ownerClaim"`
 	oauth2.Config `yaml:",inline"`
 }
 
-func (c *ProvidersConfig) Validate(firstAuthority string, providerNames map[string]bool) error {
-	if c.Authority != firstAuthority {
-		return fmt.Errorf("authority('%v' != authorization[0].authority('%v'))", c.Authority, firstAuthority)
-	}
+func (c *ProvidersConfig) Validate(providerNames map[string]bool) error {
 	if _, ok := providerNames[c.Name]; ok {
 		return fmt.Errorf("name('%v' is duplicit)", c.Name)
 	}
@@ -82,16 +87,31 @@ func (c *AuthorizationConfig) Validate() error {
 		return fmt.Errorf("providers('%v')", c.Providers)
 	}
 	duplicitProviderNames := make(map[string]bool)
+	authorityOwnerClaims := make(map[string]string)
 	for i := 0; i < len(c.Providers); i++ {
-		if err := c.Providers[i].Validate(c.Providers[0].Authority, duplicitProviderNames); err != nil {
+		if err := c.Providers[i].Validate(duplicitProviderNames); err != nil {
 			return fmt.Errorf("providers[%v].%w", i, err)
 		}
+		if ownerClaim, ok := authorityOwnerClaims[c.Providers[i].Authority]; ok && ownerClaim != c.Providers[i].OwnerClaim {
+			return fmt.Errorf("providers[%v].ownerClaim('%v' differs from the providers of the same authority)", i, c.Providers[i].OwnerClaim)
+		}
+		authorityOwnerClaims[c.Providers[i].Authority] = c.Providers[i].OwnerClaim
 	}
 	return nil
 }
 
+type Protocol string
+
+const (
+	// TCP enables CoAP over TCP (RFC 8323), secured by TLS when tls.enabled is set.
+	TCP Protocol = "tcp"
+	// UDP enables CoAP over UDP (RFC 7252), secured by DTLS (RFC 6347) when tls.enabled is set.
+	UDP Protocol = "udp"
+)
+
 type COAPConfig struct {
 	Addr                     string                  `yaml:"address" json:"address"`
+	Protocols                []Protocol              `yaml:"protocols" json:"protocols"`
 	ExternalAddress          string                  `yaml:"externalAddress" json:"externalAddress"`
 	MaxMessageSize           int                     `yaml:"maxMessageSize" json:"maxMessageSize"`
 	OwnerCacheExpiration     time.Duration           `yaml:"ownerCacheExpiration" json:"ownerCacheExpiration"`
@@ -99,7 +119,9 @@ type COAPConfig struct {
 	GoroutineSocketHeartbeat time.Duration           `yaml:"goroutineSocketHeartbeat" json:"goroutineSocketHeartbeat"`
 	KeepAlive                KeepAlive               `yaml:"keepAlive" json:"keepAlive"`
 	BlockwiseTransfer        BlockwiseTransferConfig `yaml:"blockwiseTransfer" json:"blockwiseTransfer"`
+	BatchObservation         BatchObservationConfig  `yaml:"batchObservation" json:"batchObservation"`
 	TLS                      TLSConfig               `yaml:"tls" json:"tls"`
+	DTLS                     DTLSConfig              `yaml:"dtls" json:"dtls"`
 	Authorization            AuthorizationConfig     `yaml:"authorization" json:"authorization"`
 }
 
@@ -107,6 +129,21 @@ func (c *COAPConfig) Validate() error {
 	if c.Addr == "" {
 		return fmt.Errorf("address('%v')", c.Addr)
 	}
+	if len(c.Protocols) == 0 {
+		return fmt.Errorf("protocols('%v')", c.Protocols)
+	}
+	duplicitProtocols := make(map[Protocol]bool)
+	for i, p := range c.Protocols {
+		switch p {
+		case TCP, UDP:
+		default:
+			return fmt.Errorf("protocols[%v]('%v')", i, p)
+		}
+		if duplicitProtocols[p] {
+			return fmt.Errorf("protocols[%v]('%v' is duplicit)", i, p)
+		}
+		duplicitProtocols[p] = true
+	}
 	if c.ExternalAddress == "" {
 		return fmt.Errorf("externalAddress('%v')", c.ExternalAddress)
 	}
@@ -131,6 +168,11 @@ func (c *COAPConfig) Validate() error {
 	if err := c.TLS.Validate(); err != nil {
 		return fmt.Errorf("tls.%w", err)
 	}
+	if c.TLS.Enabled && duplicitProtocols[UDP] {
+		if err := c.DTLS.Validate(); err != nil {
+			return fmt.Errorf("dtls.%w", err)
+		}
+	}
 	if err := c.Authorization.Validate(); err != nil {
 		return fmt.Errorf("authorization.%w", err)
 	}
@@ -142,6 +184,18 @@ type TLSConfig struct {
 	Embedded certManagerServer.Config `yaml:",inline" json:",inline"`
 }
 
+// DTLSConfig configures the DTLS listener which is used for the udp protocol when tls.enabled is set.
+type DTLSConfig struct {
+	HandshakeTimeout time.Duration `yaml:"handshakeTimeout" json:"handshakeTimeout"`
+}
+
+func (c *DTLSConfig) Validate() error {
+	if c.HandshakeTimeout <= 0 {
+		return fmt.Errorf("handshakeTimeout('%v')", c.HandshakeTimeout)
+	}
+	return nil
+}
+
 type KeepAlive struct {
 	Timeout time.Duration `yaml:"timeout" json:"timeout"`
 }
@@ -153,6 +207,14 @@ func (c *KeepAlive) Validate() error {
 	return nil
 }
 
+// BatchObservationConfig enables the observation of all resources of the device by one batch observation of /oic/res?if=oic.if.b,
+// when the device supports it. Otherwise each resource is observed separately. The content of the resources received by the batch
+// observation is the representation of the default interface of the resource instead of oic.if.baseline. /oic/res itself is
+// observed by an additional observation of oic.if.baseline.
+type BatchObservationConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+}
+
 type BlockwiseTransferConfig struct {
 	Enabled bool   `yaml:"enabled" json:"enabled"`
 	SZX     string `yaml:"blockSize" json:"blockSize"`
@@ -203,10 +265,11 @@ func (c *IdentityStoreConfig) Validate() error {
 }
 
 type ClientsConfig struct {
-	Eventbus          EventBusConfig          `yaml:"eventBus" json:"eventBus"`
-	IdentityStore     IdentityStoreConfig     `yaml:"identityStore" json:"identityStore"`
-	ResourceAggregate ResourceAggregateConfig `yaml:"resourceAggregate" json:"resourceAggregate"`
-	ResourceDirectory GrpcServerConfig        `yaml:"resourceDirectory" json:"resourceDirectory"`
+	Eventbus               EventBusConfig          `yaml:"eventBus" json:"eventBus"`
+	IdentityStore          IdentityStoreConfig     `yaml:"identityStore" json:"identityStore"`
+	ResourceAggregate      ResourceAggregateConfig `yaml:"resourceAggregate" json:"resourceAggregate"`
+	ResourceDirectory      GrpcServerConfig        `yaml:"resourceDirectory" json:"resourceDirectory"`
+	OpenTelemetryCollector opentelemetry.Config    `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 func (c *ClientsConfig) Validate() error {
@@ -222,6 +285,9 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.ResourceDirectory.Validate(); err != nil {
 		return fmt.Errorf("resourceDirectory.%w", err)
 	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
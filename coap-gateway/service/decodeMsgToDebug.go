@@ -8,7 +8,6 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/go-coap/v2/message"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
 	"github.com/plgd-dev/kit/v2/codec/cbor"
 )
 
@@ -35,26 +34,26 @@ func readBody(r io.ReadSeeker) []byte {
 	return body
 }
 
-func decodeMsgToDebug(client *Client, resp *pool.Message, tag string) {
+func decodeMsgToDebug(client *Client, resp *message.Message, tag string) {
 	if !client.server.config.Log.DumpCoapMessages {
 		return
 	}
 	buf := bytes.NewBuffer(make([]byte, 0, 2048))
-	path, _ := resp.Options().Path()
-	queries, _ := resp.Options().Queries()
+	path, _ := resp.Options.Path()
+	queries, _ := resp.Options.Queries()
 
 	fmt.Fprintf(buf, "\n-------------------%v------------------\n", tag)
 	fmt.Fprintf(buf, "DeviceId: %v\n", getDeviceID(client))
-	fmt.Fprintf(buf, "Token: %v\n", resp.Token())
+	fmt.Fprintf(buf, "Token: %v\n", resp.Token)
 	fmt.Fprintf(buf, "Path: %v\n", path)
-	fmt.Fprintf(buf, "Code: %v\n", resp.Code())
+	fmt.Fprintf(buf, "Code: %v\n", resp.Code)
 	fmt.Fprintf(buf, "Query: %v\n", queries)
 
-	if observe, err := resp.Options().Observe(); err == nil {
+	if observe, err := resp.Options.Observe(); err == nil {
 		fmt.Fprintf(buf, "Observe: %v\n", observe)
 	}
-	body := readBody(resp.Body())
-	if mt, err := resp.Options().ContentFormat(); err == nil {
+	body := readBody(resp.Body)
+	if mt, err := resp.Options.ContentFormat(); err == nil {
 		fmt.Fprintf(buf, "ContentFormat: %v\n", mt)
 		if body != nil {
 			switch mt {
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"testing"
+	"time"
+
+	piondtls "github.com/pion/dtls/v2"
+	"github.com/plgd-dev/go-coap/v2/dtls"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/udp"
+	udpClient "github.com/plgd-dev/go-coap/v2/udp/client"
+	"github.com/plgd-dev/go-coap/v2/udp/message/pool"
+	"github.com/plgd-dev/hub/coap-gateway/service"
+	coapgwTest "github.com/plgd-dev/hub/coap-gateway/test"
+	"github.com/plgd-dev/hub/coap-gateway/uri"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/plgd-dev/kit/v2/security"
+	"github.com/plgd-dev/kit/v2/security/generateCertificate"
+	"github.com/stretchr/testify/require"
+)
+
+// testDeviceHandlerUDP responds to the requests of the coap-gateway like the tcp test device and reports the paths of the received GET requests.
+func testDeviceHandlerUDP(t *testing.T, getRequests chan<- string) udp.HandlerFunc {
+	return func(w *udpClient.ResponseWriter, r *pool.Message) {
+		var err error
+		switch r.Code() {
+		case codes.POST:
+			err = w.SetResponse(codes.Changed, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		case codes.GET:
+			if path, errP := r.Path(); errP == nil {
+				select {
+				case getRequests <- path:
+				default:
+				}
+			}
+			err = w.SetResponse(codes.Content, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		case codes.PUT:
+			err = w.SetResponse(codes.Created, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		case codes.DELETE:
+			err = w.SetResponse(codes.Deleted, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		}
+		require.NoError(t, err)
+	}
+}
+
+func testCoapDialUDP(t *testing.T, host string, getRequests chan<- string) *udpClient.ClientConn {
+	conn, err := udp.Dial(host, udp.WithHandlerFunc(testDeviceHandlerUDP(t, getRequests)))
+	require.NoError(t, err)
+	return conn
+}
+
+func testCoapDialDTLS(t *testing.T, host, deviceID string, getRequests chan<- string) *udpClient.ClientConn {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signerCert, err := security.LoadX509(os.Getenv("TEST_ROOT_CA_CERT"))
+	require.NoError(t, err)
+	signerKey, err := security.LoadX509PrivateKey(os.Getenv("TEST_ROOT_CA_KEY"))
+	require.NoError(t, err)
+
+	certData, err := generateCertificate.GenerateIdentityCert(generateCertificate.Configuration{
+		ValidFrom: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		ValidFor:  2 * time.Hour,
+	}, deviceID, priv, signerCert, signerKey)
+	require.NoError(t, err)
+	b, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+	crt, err := tls.X509KeyPair(certData, key)
+	require.NoError(t, err)
+
+	conn, err := dtls.Dial(host, &piondtls.Config{
+		Certificates:         []tls.Certificate{crt},
+		InsecureSkipVerify:   true,
+		ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+		CipherSuites:         []piondtls.CipherSuiteID{piondtls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	}, dtls.WithHandlerFunc(testDeviceHandlerUDP(t, getRequests)))
+	require.NoError(t, err)
+	return conn
+}
+
+func testPostUDP(t *testing.T, co *udpClient.ClientConn, path string, req interface{}, resp interface{}) codes.Code {
+	inputCbor, err := cbor.Encode(req)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(co.Context(), TestExchangeTimeout)
+	defer cancel()
+	msg, err := co.Post(ctx, path, message.AppOcfCbor, bytes.NewReader(inputCbor))
+	require.NoError(t, err)
+	if msg.Code() == codes.Changed {
+		err = cbor.ReadFrom(msg.Body(), resp)
+		require.NoError(t, err)
+	}
+	return msg.Code()
+}
+
+func testSignUpInUDP(t *testing.T, co *udpClient.ClientConn, deviceID string) {
+	var signUpResp service.CoapSignUpResponse
+	code := testPostUDP(t, co, uri.SignUp, service.CoapSignUpRequest{
+		DeviceID:              deviceID,
+		AuthorizationCode:     oauthTest.GetDefaultDeviceAuthorizationCode(t, deviceID),
+		AuthorizationProvider: testCfg.DEVICE_PROVIDER,
+	}, &signUpResp)
+	require.Equal(t, codes.Changed, code)
+	require.NotEmpty(t, signUpResp.AccessToken)
+
+	var signInResp service.CoapSignInResp
+	code = testPostUDP(t, co, uri.SignIn, service.CoapSignInReq{
+		DeviceID:    deviceID,
+		UserID:      signUpResp.UserID,
+		AccessToken: signUpResp.AccessToken,
+		Login:       true,
+	}, &signInResp)
+	require.Equal(t, codes.Changed, code)
+	require.NotEqual(t, int64(0), signInResp.ExpiresIn)
+}
+
+// testPublishObserveUDP publishes an observable resource of the signed in device, waits until the coap-gateway
+// requests its content and observes the resource through the coap-gateway.
+func testPublishObserveUDP(t *testing.T, co *udpClient.ClientConn, getRequests <-chan string) {
+	links, err := json2cbor(`{ "di":"` + CertIdentity + `", "links":[ { "di":"` + CertIdentity + `", "href":"` + TestAResourceHref + `", "rt":["` + TestAResourceType + `"], "type":["` + message.TextPlain.String() + `"], "p":{"bm":3} } ], "ttl":12345}`)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(co.Context(), TestExchangeTimeout)
+	defer cancel()
+	resp, err := co.Post(ctx, uri.ResourceDirectory, message.AppOcfCbor, bytes.NewReader(links))
+	require.NoError(t, err)
+	require.Equal(t, codes.Changed, resp.Code())
+
+	select {
+	case path := <-getRequests:
+		require.Equal(t, TestAResourceHref, path)
+	case <-ctx.Done():
+		require.NoError(t, ctx.Err(), "coap-gateway hasn't requested the content of the published resource")
+	}
+
+	notifications := make(chan codes.Code, 1)
+	obs, err := co.Observe(ctx, uri.ResourceRoute+"/"+CertIdentity+TestAResourceHref, func(req *pool.Message) {
+		select {
+		case notifications <- req.Code():
+		default:
+		}
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = obs.Cancel(ctx)
+	}()
+	select {
+	case code := <-notifications:
+		require.Equal(t, codes.Content, code)
+	case <-ctx.Done():
+		require.NoError(t, ctx.Err(), "observation of the resource hasn't been notified")
+	}
+}
+
+func TestSignUpInDTLS(t *testing.T) {
+	coapgwCfg := coapgwTest.MakeConfig(t)
+	coapgwCfg.APIs.COAP.Protocols = []service.Protocol{service.TCP, service.UDP}
+	shutdown := setUp(t, coapgwCfg)
+	defer shutdown()
+
+	co := testCoapDialDTLS(t, testCfg.GW_HOST, CertIdentity, make(chan string, 1))
+	defer func() {
+		_ = co.Close()
+	}()
+
+	testSignUpInUDP(t, co, CertIdentity)
+}
+
+func TestPublishObserveDTLS(t *testing.T) {
+	coapgwCfg := coapgwTest.MakeConfig(t)
+	coapgwCfg.APIs.COAP.Protocols = []service.Protocol{service.UDP}
+	shutdown := setUp(t, coapgwCfg)
+	defer shutdown()
+
+	getRequests := make(chan string, 1)
+	co := testCoapDialDTLS(t, testCfg.GW_HOST, CertIdentity, getRequests)
+	defer func() {
+		_ = co.Close()
+	}()
+
+	testSignUpInUDP(t, co, CertIdentity)
+	testPublishObserveUDP(t, co, getRequests)
+}
+
+func TestPublishObserveUDP(t *testing.T) {
+	coapgwCfg := coapgwTest.MakeConfig(t)
+	coapgwCfg.APIs.COAP.Protocols = []service.Protocol{service.UDP}
+	coapgwCfg.APIs.COAP.TLS.Enabled = false
+	shutdown := setUp(t, coapgwCfg)
+	defer shutdown()
+
+	getRequests := make(chan string, 1)
+	co := testCoapDialUDP(t, testCfg.GW_HOST, getRequests)
+	defer func() {
+		_ = co.Close()
+	}()
+
+	testSignUpInUDP(t, co, CertIdentity)
+	testPublishObserveUDP(t, co, getRequests)
+}
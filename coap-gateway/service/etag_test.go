@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/tcp"
+	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
+	"github.com/plgd-dev/hub/coap-gateway/uri"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var testETag = []byte("etag-a")
+
+// testETagDeviceHandler responds to the GET requests of the resource A by the content with the ETag, or by 2.03 Valid
+// when the request contains the ETag. ETags of the received requests are reported.
+func testETagDeviceHandler(t *testing.T, requestedETags chan<- []byte) tcp.HandlerFunc {
+	return func(w *tcp.ResponseWriter, r *pool.Message) {
+		path, _ := r.Path()
+		if r.Code() != codes.GET || path != TestAResourceHref {
+			require.NoError(t, w.SetResponse(codes.Changed, message.TextPlain, bytes.NewReader([]byte("hello world"))))
+			return
+		}
+		etag, _ := r.ETag()
+		select {
+		case requestedETags <- etag:
+		default:
+		}
+		opts := []message.Option{{ID: message.ETag, Value: testETag}}
+		if _, err := r.Observe(); err == nil {
+			opts = append(opts, message.Option{ID: message.Observe, Value: []byte{2}})
+		}
+		if bytes.Equal(etag, testETag) {
+			require.NoError(t, w.SetResponse(codes.Valid, message.TextPlain, nil, opts...))
+			return
+		}
+		require.NoError(t, w.SetResponse(codes.Content, message.TextPlain, bytes.NewReader([]byte("hello world")), opts...))
+	}
+}
+
+func waitForRequestedETag(ctx context.Context, t *testing.T, requestedETags <-chan []byte) []byte {
+	select {
+	case etag := <-requestedETags:
+		return etag
+	case <-ctx.Done():
+		require.NoError(t, ctx.Err(), "coap-gateway hasn't requested the content of the resource")
+	}
+	return nil
+}
+
+func getResourceA(ctx context.Context, t *testing.T, c pb.GrpcGatewayClient, excludeContent bool) *pb.Resource {
+	client, err := c.GetResources(ctx, &pb.GetResourcesRequest{
+		ResourceIdFilter: []string{commands.NewResourceID(CertIdentity, TestAResourceHref).ToString()},
+		ExcludeContent:   excludeContent,
+	})
+	require.NoError(t, err)
+	var resource *pb.Resource
+	for {
+		r, err := client.Recv()
+		if errors.Is(err, io.EOF) {
+			return resource
+		}
+		require.NoError(t, err)
+		resource = r
+	}
+}
+
+// waitForStoredETag waits until the content of the resource A with the ETag is stored in the hub.
+func waitForStoredETag(ctx context.Context, t *testing.T, c pb.GrpcGatewayClient) *pb.Resource {
+	for {
+		resource := getResourceA(ctx, t, c, true)
+		if bytes.Equal(testETag, resource.GetData().GetEtag()) {
+			return resource
+		}
+		select {
+		case <-time.After(time.Millisecond * 100):
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err(), "ETag of the resource hasn't been stored")
+		}
+	}
+}
+
+func TestETagOfObservedResourceOnReconnect(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{
+			name:   "get",
+			policy: `{"bm":1}`,
+		},
+		{
+			name:   "observe",
+			policy: `{"bm":3}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown := setUp(t)
+			defer shutdown()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+			defer cancel()
+			ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+			conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				RootCAs: test.GetRootCertificatePool(t),
+			})))
+			require.NoError(t, err)
+			defer func() {
+				_ = conn.Close()
+			}()
+			c := pb.NewGrpcGatewayClient(conn)
+
+			requestedETags := make(chan []byte, 8)
+			co := testCoapDialWithHandler(t, testCfg.GW_HOST, "", testETagDeviceHandler(t, requestedETags))
+			signUpResp := testSignUp(t, CertIdentity, co)
+			testSignIn(t, CertIdentity, signUpResp, co)
+			links, err := json2cbor(`{ "di":"` + CertIdentity + `", "links":[ { "di":"` + CertIdentity + `", "href":"` + TestAResourceHref + `", "rt":["` + TestAResourceType + `"], "type":["` + message.TextPlain.String() + `"], "p":` + tt.policy + ` } ], "ttl":12345}`)
+			require.NoError(t, err)
+			resp, err := co.Post(ctx, uri.ResourceDirectory, message.AppOcfCbor, bytes.NewReader(links))
+			require.NoError(t, err)
+			require.Equal(t, codes.Changed, resp.Code())
+
+			// the content of the newly published resource is requested without the ETag
+			require.Empty(t, waitForRequestedETag(ctx, t, requestedETags))
+			stored := waitForStoredETag(ctx, t, c)
+			require.Empty(t, stored.GetData().GetContent())
+			_ = co.Close()
+
+			// the reconnected device is requested with the stored ETag
+			co = testCoapDialWithHandler(t, testCfg.GW_HOST, "", testETagDeviceHandler(t, requestedETags))
+			defer func() {
+				_ = co.Close()
+			}()
+			testSignIn(t, CertIdentity, signUpResp, co)
+			require.Equal(t, testETag, waitForRequestedETag(ctx, t, requestedETags))
+
+			// 2.03 Valid response of the device doesn't change the stored content
+			time.Sleep(time.Second)
+			resource := getResourceA(ctx, t, c, false)
+			require.Equal(t, testETag, resource.GetData().GetEtag())
+			require.Equal(t, stored.GetData().GetEventMetadata().GetVersion(), resource.GetData().GetEventMetadata().GetVersion())
+			require.Equal(t, []byte("hello world"), resource.GetData().GetContent().GetData())
+		})
+	}
+}
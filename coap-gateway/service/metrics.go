@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	connectedDevices = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "plgd",
+		Subsystem: "coap_gateway",
+		Name:      "connected_devices",
+		Help:      "Number of connections with a signed in device.",
+	})
+	signInFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "plgd",
+		Subsystem: "coap_gateway",
+		Name:      "sign_in_failures_total",
+		Help:      "Number of rejected sign in requests.",
+	})
+)
@@ -120,8 +120,8 @@ func refreshTokenPostHandler(req *mux.Message, client *Client) {
 		return
 	}
 
-	owner := claim.Owner(client.server.config.APIs.COAP.Authorization.OwnerClaim)
-	if owner == "" {
+	owner, err := client.server.Owner(claim)
+	if err != nil {
 		owner = refreshToken.UserID
 	}
 	if owner == "" {
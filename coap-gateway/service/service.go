@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,13 +13,17 @@ import (
 	"time"
 
 	cache "github.com/patrickmn/go-cache"
+	piondtls "github.com/pion/dtls/v2"
+	"github.com/plgd-dev/device/pkg/net/coap"
+	"github.com/plgd-dev/go-coap/v2/dtls"
 	coapCodes "github.com/plgd-dev/go-coap/v2/message/codes"
 	"github.com/plgd-dev/go-coap/v2/mux"
 	"github.com/plgd-dev/go-coap/v2/net"
 	"github.com/plgd-dev/go-coap/v2/net/blockwise"
 	"github.com/plgd-dev/go-coap/v2/net/monitor/inactivity"
 	"github.com/plgd-dev/go-coap/v2/tcp"
-	"github.com/plgd-dev/go-coap/v2/tcp/message/pool"
+	"github.com/plgd-dev/go-coap/v2/udp"
+	udpClient "github.com/plgd-dev/go-coap/v2/udp/client"
 	"github.com/plgd-dev/hub/coap-gateway/uri"
 	pbGRPC "github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/grpc-gateway/subscription"
@@ -26,8 +31,10 @@ import (
 	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/fn"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	grpcClient "github.com/plgd-dev/hub/pkg/net/grpc/client"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	certManagerServer "github.com/plgd-dev/hub/pkg/security/certManager/server"
 	"github.com/plgd-dev/hub/pkg/security/jwt"
 	"github.com/plgd-dev/hub/pkg/security/oauth2"
@@ -37,6 +44,9 @@ import (
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/subscriber"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var authCtxKey = "AuthCtx"
@@ -53,8 +63,8 @@ type Service struct {
 	rdClient              pbGRPC.GrpcGatewayClient
 	expirationClientCache *cache.Cache
 	tlsDeviceIDCache      *cache.Cache
-	coapServer            *tcp.Server
-	listener              tcp.Listener
+	listeners             listeners
+	coapServers           []coapServer
 	authInterceptor       Interceptor
 	ctx                   context.Context
 	cancel                context.CancelFunc
@@ -62,7 +72,7 @@ type Service struct {
 	devicesStatusUpdater  *devicesStatusUpdater
 	resourceSubscriber    *subscriber.Subscriber
 	providers             map[string]*oauth2.PlgdProvider
-	jwtValidator          *jwt.Validator
+	jwtValidator          *jwt.IssuerValidator
 	sigs                  chan os.Signal
 	ownerCache            *idClient.OwnerCache
 	subscriptionsCache    *subscription.SubscriptionsCache
@@ -137,6 +147,19 @@ func newResourceDirectoryClient(config GrpcServerConfig, logger log.Logger) (pbG
 	return rdClient, closeRdConn, nil
 }
 
+// listeners holds a listener for each enabled protocol, the listener of a disabled protocol is nil.
+type listeners struct {
+	tcp  tcp.Listener
+	udp  *net.UDPConn
+	dtls dtls.Listener
+}
+
+// coapServer serves devices connected over one protocol.
+type coapServer struct {
+	serve func() error
+	stop  func()
+}
+
 func newTCPListener(config COAPConfig, tlsDeviceIDCache *cache.Cache, logger log.Logger) (tcp.Listener, func(), error) {
 	if !config.TLS.Enabled {
 		listener, err := net.NewTCPListener("tcp", config.Addr)
@@ -174,6 +197,83 @@ func newTCPListener(config COAPConfig, tlsDeviceIDCache *cache.Cache, logger log
 	return listener, closeListener.ToFunction(), nil
 }
 
+func newUDPListener(config COAPConfig) (*net.UDPConn, func(), error) {
+	listener, err := net.NewListenUDP("udp", config.Addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create udp listener: %w", err)
+	}
+	closeListener := func() {
+		if err := listener.Close(); err != nil {
+			log.Errorf("failed to close udp listener: %w", err)
+		}
+	}
+	return listener, closeListener, nil
+}
+
+func newDTLSListener(config COAPConfig, logger log.Logger) (dtls.Listener, func(), error) {
+	var closeListener fn.FuncList
+	coapsTLS, err := certManagerServer.New(config.TLS.Embedded, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create tls cert manager: %w", err)
+	}
+	closeListener.AddFunc(coapsTLS.Close)
+	dtlsCfg, err := MakeDTLSConfig(coapsTLS.GetTLSConfig(), config.DTLS.HandshakeTimeout)
+	if err != nil {
+		closeListener.Execute()
+		return nil, nil, fmt.Errorf("cannot create dtls config: %w", err)
+	}
+	listener, err := net.NewDTLSListener("udp", config.Addr, dtlsCfg)
+	if err != nil {
+		closeListener.Execute()
+		return nil, nil, fmt.Errorf("cannot create udp-dtls listener: %w", err)
+	}
+	closeListener.AddFunc(func() {
+		if err := listener.Close(); err != nil {
+			log.Errorf("failed to close udp-dtls listener: %w", err)
+		}
+	})
+	return listener, closeListener.ToFunction(), nil
+}
+
+func newListeners(config COAPConfig, tlsDeviceIDCache *cache.Cache, logger log.Logger) (listeners, func(), error) {
+	var l listeners
+	var closeListeners fn.FuncList
+	for _, protocol := range config.Protocols {
+		switch protocol {
+		case TCP:
+			listener, closeListener, err := newTCPListener(config, tlsDeviceIDCache, logger)
+			if err != nil {
+				closeListeners.Execute()
+				return listeners{}, nil, err
+			}
+			l.tcp = listener
+			closeListeners.AddFunc(closeListener)
+		case UDP:
+			if config.TLS.Enabled {
+				listener, closeListener, err := newDTLSListener(config, logger)
+				if err != nil {
+					closeListeners.Execute()
+					return listeners{}, nil, err
+				}
+				l.dtls = listener
+				closeListeners.AddFunc(closeListener)
+				continue
+			}
+			listener, closeListener, err := newUDPListener(config)
+			if err != nil {
+				closeListeners.Execute()
+				return listeners{}, nil, err
+			}
+			l.udp = listener
+			closeListeners.AddFunc(closeListener)
+		default:
+			closeListeners.Execute()
+			return listeners{}, nil, fmt.Errorf("unsupported protocol %v", protocol)
+		}
+	}
+	return l, closeListeners.ToFunction(), nil
+}
+
 func blockWiseTransferSZXFromString(s string) (blockwise.SZX, error) {
 	switch strings.ToLower(s) {
 	case "16":
@@ -230,6 +330,25 @@ func newProviders(ctx context.Context, config AuthorizationConfig, logger log.Lo
 	return providers, firstProvider, closeProviders.ToFunction(), nil
 }
 
+// newJwtValidator creates validator which selects the key cache of the provider's authority by the iss claim of the token,
+// tokens of unknown issuers are rejected. Owners of the authorities other than the authority of the first provider are prefixed by the issuer.
+func newJwtValidator(config AuthorizationConfig, providers map[string]*oauth2.PlgdProvider, firstProvider *oauth2.PlgdProvider) *jwt.IssuerValidator {
+	validator := jwt.NewIssuerValidator()
+	for _, p := range config.Providers {
+		provider := providers[p.Name]
+		if _, ok := validator.Issuer(provider.OpenID.Issuer); ok {
+			continue
+		}
+		keyCache := jwt.NewKeyCacheWithHttp(provider.OpenID.JWKSURL, provider.HTTPClient.HTTP())
+		validator.AddIssuer(provider.OpenID.Issuer, jwt.Issuer{
+			Validator:      jwt.NewValidatorWithKeyCache(keyCache),
+			OwnerClaim:     p.OwnerClaim,
+			NamespaceOwner: provider.Config.Authority != firstProvider.Config.Authority,
+		})
+	}
+	return validator
+}
+
 // New creates server.
 func New(ctx context.Context, config Config, logger log.Logger) (*Service, error) {
 	queue, err := queue.New(config.TaskQueue)
@@ -277,12 +396,26 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 	nats.AddCloseFunc(closeRdClient)
 
 	tlsDeviceIDCache := cache.New(config.APIs.COAP.KeepAlive.Timeout, config.APIs.COAP.KeepAlive.Timeout/2)
-	listener, closeListener, err := newTCPListener(config.APIs.COAP, tlsDeviceIDCache, logger)
+	listeners, closeListeners, err := newListeners(config.APIs.COAP, tlsDeviceIDCache, logger)
 	if err != nil {
 		nats.Close()
 		return nil, fmt.Errorf("cannot create listener: %w", err)
 	}
-	nats.AddCloseFunc(closeListener)
+	nats.AddCloseFunc(closeListeners)
+
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		nats.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	nats.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "coap-gateway", logger)
+	if err != nil {
+		nats.Close()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	nats.AddCloseFunc(otelProvider.Close)
 
 	blockWiseTransferSZX := blockwise.SZX1024
 	if config.APIs.COAP.BlockwiseTransfer.Enabled {
@@ -305,9 +438,7 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 		return nil, fmt.Errorf("device providers are empty")
 	}
 
-	keyCache := jwt.NewKeyCacheWithHttp(firstProvider.OpenID.JWKSURL, firstProvider.HTTPClient.HTTP())
-
-	jwtValidator := jwt.NewValidatorWithKeyCache(keyCache)
+	jwtValidator := newJwtValidator(config.APIs.COAP.Authorization, providers, firstProvider)
 
 	ownerCache := idClient.NewOwnerCache(config.APIs.COAP.Authorization.OwnerClaim, config.APIs.COAP.OwnerCacheExpiration, nats.GetConn(), isClient, func(err error) {
 		log.Errorf("ownerCache error: %w", err)
@@ -318,7 +449,8 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 		log.Errorf("subscriptionsCache error: %w", err)
 	})
 
-	ctx, cancel := context.WithCancel(ctx)
+	// owners from the tokens forwarded to the grpc services are resolved by the validator
+	ctx, cancel := context.WithCancel(kitNetGrpc.CtxWithOwnerResolver(ctx, jwtValidator))
 
 	s := Service{
 		config:                config,
@@ -331,7 +463,7 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 		rdClient:              rdClient,
 		expirationClientCache: newExpirationClientCache(),
 		tlsDeviceIDCache:      tlsDeviceIDCache,
-		listener:              listener,
+		listeners:             listeners,
 		authInterceptor:       newAuthInterceptor(),
 		devicesStatusUpdater:  newDevicesStatusUpdater(ctx, config.Clients.ResourceAggregate.DeviceStatusExpiration),
 
@@ -371,7 +503,7 @@ func getDeviceID(client *Client) string {
 func validateCommand(s mux.ResponseWriter, req *mux.Message, server *Service, fnc func(req *mux.Message, client *Client)) {
 	client, ok := s.Client().Context().Value(clientKey).(*Client)
 	if !ok || client == nil {
-		client = newClient(server, s.Client().ClientConn().(*tcp.ClientConn), "")
+		client = newClient(server, s.Client(), "")
 	}
 	err := server.taskQueue.Submit(func() {
 		switch req.Code {
@@ -388,13 +520,7 @@ func validateCommand(s mux.ResponseWriter, req *mux.Message, server *Service, fn
 			clientResetHandler(req, client)
 		case coapCodes.Content:
 			// Unregistered observer at a peer send us a notification
-			deviceID := getDeviceID(client)
-			tmp, err := pool.ConvertFrom(req.Message)
-			if err != nil {
-				log.Errorf("DeviceId: %v: cannot convert dropped notification: %w", deviceID, err)
-			} else {
-				decodeMsgToDebug(client, tmp, "DROPPED-NOTIFICATION")
-			}
+			decodeMsgToDebug(client, req.Message, "DROPPED-NOTIFICATION")
 		default:
 			deviceID := getDeviceID(client)
 			log.Errorf("DeviceId: %v: received invalid code: CoapCode(%v)", deviceID, req.Code)
@@ -423,31 +549,80 @@ func defaultHandler(req *mux.Message, client *Client) {
 
 const clientKey = "client"
 
-func (server *Service) coapConnOnNew(coapConn *tcp.ClientConn, tlscon *tls.Conn) {
+func (server *Service) coapConnOnNew(coapConn mux.Client, tlsDeviceID string) *Client {
+	client := newClient(server, coapConn, tlsDeviceID)
+	coapConn.SetContextValue(clientKey, client)
+	return client
+}
+
+func (server *Service) tcpConnOnNew(coapConn *tcp.ClientConn, tlscon *tls.Conn) {
 	var tlsDeviceID string
 	v, ok := server.tlsDeviceIDCache.Get(coapConn.RemoteAddr().String())
 	if ok {
 		tlsDeviceID = v.(string)
 	}
-	client := newClient(server, coapConn, tlsDeviceID)
-	coapConn.SetContextValue(clientKey, client)
+	client := server.coapConnOnNew(coapConn.Client(), tlsDeviceID)
+	coapConn.AddOnClose(func() {
+		client.OnClose()
+	})
+}
+
+func (server *Service) udpConnOnNew(coapConn *udpClient.ClientConn) {
+	client := server.coapConnOnNew(coapConn.Client(), "")
+	coapConn.AddOnClose(func() {
+		client.OnClose()
+	})
+}
+
+func (server *Service) dtlsConnOnNew(coapConn *udpClient.ClientConn, dtlsConn *piondtls.Conn) {
+	var tlsDeviceID string
+	peerCertificates := dtlsConn.ConnectionState().PeerCertificates
+	if len(peerCertificates) > 0 {
+		deviceID, err := getDeviceIDFromRawCertificate(peerCertificates[0])
+		if err != nil {
+			log.Errorf("cannot get device id from certificate of %v: %w", coapConn.RemoteAddr(), err)
+		} else {
+			tlsDeviceID = deviceID
+		}
+	}
+	client := server.coapConnOnNew(coapConn.Client(), tlsDeviceID)
 	coapConn.AddOnClose(func() {
 		client.OnClose()
 	})
 }
 
+func getDeviceIDFromRawCertificate(rawCertificate []byte) (string, error) {
+	certificate, err := x509.ParseCertificate(rawCertificate)
+	if err != nil {
+		return "", err
+	}
+	return coap.GetDeviceIDFromIndetityCertificate(certificate)
+}
+
+func (server *Service) tracingMiddleware(next mux.Handler) mux.Handler {
+	return mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
+		path, _ := r.Options.Path()
+		ctx, span := opentelemetry.Tracer().Start(r.Context, "COAP "+r.Code.String()+" /"+path, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+			semconv.NetPeerIPKey.String(w.Client().RemoteAddr().String()),
+		))
+		defer span.End()
+		if client, ok := w.Client().Context().Value(clientKey).(*Client); ok {
+			if authCtx, _ := client.GetAuthorizationContext(); authCtx.GetDeviceID() != "" {
+				span.SetAttributes(attribute.String("plgd.device_id", authCtx.GetDeviceID()))
+			}
+		}
+		r.Context = ctx
+		next.ServeCOAP(w, r)
+	})
+}
+
 func (server *Service) loggingMiddleware(next mux.Handler) mux.Handler {
 	return mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
 		client, ok := w.Client().Context().Value(clientKey).(*Client)
 		if !ok {
-			client = newClient(server, w.Client().ClientConn().(*tcp.ClientConn), "")
+			client = newClient(server, w.Client(), "")
 		}
-		tmp, err := pool.ConvertFrom(r.Message)
-		if err != nil {
-			client.logAndWriteErrorResponse(fmt.Errorf("cannot convert from mux.Message: %w", err), coapCodes.InternalServerError, r.Token)
-			return
-		}
-		decodeMsgToDebug(client, tmp, "RECEIVED-COMMAND")
+		decodeMsgToDebug(client, r.Message, "RECEIVED-COMMAND")
 		next.ServeCOAP(w, r)
 	})
 }
@@ -456,7 +631,7 @@ func (server *Service) authMiddleware(next mux.Handler) mux.Handler {
 	return mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
 		client, ok := w.Client().Context().Value(clientKey).(*Client)
 		if !ok {
-			client = newClient(server, w.Client().ClientConn().(*tcp.ClientConn), "")
+			client = newClient(server, w.Client(), "")
 		}
 		authCtx, _ := client.GetAuthorizationContext()
 		ctx := context.WithValue(r.Context, &authCtxKey, authCtx)
@@ -480,7 +655,7 @@ func (server *Service) authMiddleware(next mux.Handler) mux.Handler {
 //setupCoapServer setup coap server
 func (server *Service) setupCoapServer() error {
 	m := mux.NewRouter()
-	m.Use(server.loggingMiddleware, server.authMiddleware)
+	m.Use(server.tracingMiddleware, server.loggingMiddleware, server.authMiddleware)
 	m.DefaultHandle(mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
 		validateCommand(w, r, server, defaultHandler)
 	}))
@@ -510,28 +685,83 @@ func (server *Service) setupCoapServer() error {
 		return fmt.Errorf("failed to set %v handler: %w", uri.RefreshToken, err)
 	}
 
-	opts := make([]tcp.ServerOption, 0, 8)
+	if server.listeners.tcp != nil {
+		server.coapServers = append(server.coapServers, server.newTCPServer(m))
+	}
+	if server.listeners.udp != nil {
+		server.coapServers = append(server.coapServers, server.newUDPServer(m))
+	}
+	if server.listeners.dtls != nil {
+		server.coapServers = append(server.coapServers, server.newDTLSServer(m))
+	}
+	return nil
+}
+
+// goPool calls directly function in connection-goroutine because
+// pairing request/response cannot be done in taskQueue for a observe resource.
+// - the observe resource creates task which wait for the response and this wait can be infinite
+// if all task goroutines are processing observations and they are waiting for the responses, which
+// will be stored in task queue.  it happens when we use task queue here.
+func goPool(f func()) error {
+	f()
+	return nil
+}
+
+func logCoapError(err error) {
+	log.Errorf("plgd/go-coap: %w", err)
+}
+
+func (server *Service) newTCPServer(m *mux.Router) coapServer {
+	opts := make([]tcp.ServerOption, 0, 9)
 	opts = append(opts, tcp.WithKeepAlive(1, server.config.APIs.COAP.KeepAlive.Timeout, server.keepaliveOnInactivity))
-	opts = append(opts, tcp.WithOnNewClientConn(server.coapConnOnNew))
+	opts = append(opts, tcp.WithOnNewClientConn(server.tcpConnOnNew))
 	opts = append(opts, tcp.WithBlockwise(server.config.APIs.COAP.BlockwiseTransfer.Enabled, server.blockWiseTransferSZX, server.config.APIs.COAP.KeepAlive.Timeout))
 	opts = append(opts, tcp.WithMux(m))
 	opts = append(opts, tcp.WithContext(server.ctx))
 	opts = append(opts, tcp.WithHeartBeat(server.config.APIs.COAP.GoroutineSocketHeartbeat))
 	opts = append(opts, tcp.WithMaxMessageSize(server.config.APIs.COAP.MaxMessageSize))
-	opts = append(opts, tcp.WithErrors(func(e error) {
-		log.Errorf("plgd/go-coap: %w", e)
-	}))
-	opts = append(opts, tcp.WithGoPool(func(f func()) error {
-		// we call directly function in connection-goroutine because
-		// pairing request/response cannot be done in taskQueue for a observe resource.
-		// - the observe resource creates task which wait for the response and this wait can be infinite
-		// if all task goroutines are processing observations and they are waiting for the responses, which
-		// will be stored in task queue.  it happens when we use task queue here.
-		f()
-		return nil
-	}))
-	server.coapServer = tcp.NewServer(opts...)
-	return nil
+	opts = append(opts, tcp.WithErrors(logCoapError))
+	opts = append(opts, tcp.WithGoPool(goPool))
+	s := tcp.NewServer(opts...)
+	return coapServer{
+		serve: func() error { return s.Serve(server.listeners.tcp) },
+		stop:  s.Stop,
+	}
+}
+
+func (server *Service) newUDPServer(m *mux.Router) coapServer {
+	opts := make([]udp.ServerOption, 0, 8)
+	opts = append(opts, udp.WithKeepAlive(1, server.config.APIs.COAP.KeepAlive.Timeout, server.keepaliveOnInactivity))
+	opts = append(opts, udp.WithOnNewClientConn(server.udpConnOnNew))
+	opts = append(opts, udp.WithBlockwise(server.config.APIs.COAP.BlockwiseTransfer.Enabled, server.blockWiseTransferSZX, server.config.APIs.COAP.KeepAlive.Timeout))
+	opts = append(opts, udp.WithMux(m))
+	opts = append(opts, udp.WithContext(server.ctx))
+	opts = append(opts, udp.WithMaxMessageSize(server.config.APIs.COAP.MaxMessageSize))
+	opts = append(opts, udp.WithErrors(logCoapError))
+	opts = append(opts, udp.WithGoPool(goPool))
+	s := udp.NewServer(opts...)
+	return coapServer{
+		serve: func() error { return s.Serve(server.listeners.udp) },
+		stop:  s.Stop,
+	}
+}
+
+func (server *Service) newDTLSServer(m *mux.Router) coapServer {
+	opts := make([]dtls.ServerOption, 0, 9)
+	opts = append(opts, dtls.WithKeepAlive(1, server.config.APIs.COAP.KeepAlive.Timeout, server.keepaliveOnInactivity))
+	opts = append(opts, dtls.WithOnNewClientConn(server.dtlsConnOnNew))
+	opts = append(opts, dtls.WithBlockwise(server.config.APIs.COAP.BlockwiseTransfer.Enabled, server.blockWiseTransferSZX, server.config.APIs.COAP.KeepAlive.Timeout))
+	opts = append(opts, dtls.WithMux(m))
+	opts = append(opts, dtls.WithContext(server.ctx))
+	opts = append(opts, dtls.WithHeartBeat(server.config.APIs.COAP.GoroutineSocketHeartbeat))
+	opts = append(opts, dtls.WithMaxMessageSize(server.config.APIs.COAP.MaxMessageSize))
+	opts = append(opts, dtls.WithErrors(logCoapError))
+	opts = append(opts, dtls.WithGoPool(goPool))
+	s := dtls.NewServer(opts...)
+	return coapServer{
+		serve: func() error { return s.Serve(server.listeners.dtls) },
+		stop:  s.Stop,
+	}
 }
 
 func (server *Service) tlsEnabled() bool {
@@ -545,14 +775,16 @@ func (server *Service) Serve() error {
 
 func (server *Service) serveWithHandlingSignal() error {
 	var wg sync.WaitGroup
-	var err error
-	wg.Add(1)
-	go func(server *Service) {
-		defer wg.Done()
-		err = server.coapServer.Serve(server.listener)
-		server.cancel()
-		server.natsClient.Close()
-	}(server)
+	errors := make([]error, len(server.coapServers))
+	for i := range server.coapServers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errors[i] = server.coapServers[i].serve()
+			// a failed listener shuts down the whole service
+			_ = server.Close()
+		}(i)
+	}
 
 	signal.Notify(server.sigs,
 		syscall.SIGHUP,
@@ -561,12 +793,21 @@ func (server *Service) serveWithHandlingSignal() error {
 		syscall.SIGQUIT)
 	<-server.sigs
 
-	server.coapServer.Stop()
+	for _, s := range server.coapServers {
+		s.stop()
+	}
 	wg.Wait()
+	server.cancel()
+	server.natsClient.Close()
 
 	server.tlsDeviceIDCache.Flush()
 
-	return err
+	for _, err := range errors {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Shutdown turn off server.
@@ -62,16 +62,17 @@ func (request CoapSignInReq) updateOAUthRequestIfEmpty(deviceID, userID, accessT
 	return request
 }
 
-func (client *Client) registerObservationsForPublishedResourcesLocked(ctx context.Context, deviceID string) {
+// getPublishedResources returns resources published by the device to the hub.
+func (client *Client) getPublishedResources(ctx context.Context, deviceID string) []*commands.Resource {
 	getResourceLinksClient, err := client.server.rdClient.GetResourceLinks(ctx, &pb.GetResourceLinksRequest{
 		DeviceIdFilter: []string{deviceID},
 	})
 	if err != nil {
 		if status.Convert(err).Code() == codes.NotFound {
-			return
+			return nil
 		}
 		log.Errorf("signIn: cannot get resource links for the device %v: %w", deviceID, err)
-		return
+		return nil
 	}
 	resources := make([]*commands.Resource, 0, 8)
 	for {
@@ -80,16 +81,72 @@ func (client *Client) registerObservationsForPublishedResourcesLocked(ctx contex
 			break
 		}
 		if status.Convert(err).Code() == codes.NotFound {
-			return
+			return nil
 		}
 		if err != nil {
 			log.Errorf("signIn: cannot receive link for the device %v: %w", deviceID, err)
-			return
+			return nil
 		}
 		resources = append(resources, m.GetResources()...)
 
 	}
-	client.observeResourcesLocked(ctx, resources)
+	return resources
+}
+
+func (client *Client) isShadowSynchronizationDisabled() bool {
+	client.observedResourcesLock.Lock()
+	defer client.observedResourcesLock.Unlock()
+	return client.shadowSynchronization == commands.ShadowSynchronization_DISABLED
+}
+
+// registerObservationsForPublishedResources observes resources published by the device. The resources and their ETags
+// are retrieved from the hub before the observed resources are locked, so notifications of the device are not blocked.
+func (client *Client) registerObservationsForPublishedResources(ctx context.Context, deviceID string) {
+	if client.isShadowSynchronizationDisabled() {
+		return
+	}
+	resources := client.getPublishedResources(ctx, deviceID)
+	if len(resources) == 0 {
+		return
+	}
+	etags := client.getResourcesETags(ctx, deviceID)
+	client.observedResourcesLock.Lock()
+	defer client.observedResourcesLock.Unlock()
+	if client.shadowSynchronization == commands.ShadowSynchronization_DISABLED {
+		return
+	}
+	client.observeResourcesLocked(ctx, resources, etags)
+}
+
+// getResourcesETags returns ETags of the resources content stored in the hub by href.
+func (client *Client) getResourcesETags(ctx context.Context, deviceID string) map[string][]byte {
+	getResourcesClient, err := client.server.rdClient.GetResources(ctx, &pb.GetResourcesRequest{
+		DeviceIdFilter: []string{deviceID},
+		ExcludeContent: true,
+	})
+	if err != nil {
+		if status.Convert(err).Code() != codes.NotFound {
+			log.Errorf("signIn: cannot get resources for the device %v: %w", deviceID, err)
+		}
+		return nil
+	}
+	etags := make(map[string][]byte)
+	for {
+		m, err := getResourcesClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if status.Convert(err).Code() != codes.NotFound {
+				log.Errorf("signIn: cannot receive resource for the device %v: %w", deviceID, err)
+			}
+			return nil
+		}
+		if etag := m.GetData().GetEtag(); len(etag) > 0 {
+			etags[m.GetData().GetResourceId().GetHref()] = etag
+		}
+	}
+	return etags
 }
 
 func (client *Client) loadShadowSynchronization(ctx context.Context, deviceID string) error {
@@ -251,6 +308,7 @@ func subscribeAndValidateDeviceAccess(ctx context.Context, client *Client, owner
 // https://github.com/openconnectivityfoundation/security/blob/master/swagger2.0/oic.sec.session.swagger.json
 func signInPostHandler(req *mux.Message, client *Client, signIn CoapSignInReq) {
 	logErrorAndCloseClient := func(err error, code coapCodes.Code) {
+		signInFailures.Inc()
 		client.logAndWriteErrorResponse(err, code, req.Token)
 		if err := client.Close(); err != nil {
 			log.Errorf("sign in error: %w", err)
@@ -274,7 +332,7 @@ func signInPostHandler(req *mux.Message, client *Client, signIn CoapSignInReq) {
 		return
 	}
 
-	if err := jwtClaims.ValidateOwnerClaim(client.server.config.APIs.COAP.Authorization.OwnerClaim, signIn.UserID); err != nil {
+	if err := client.server.ValidateOwner(jwtClaims, signIn.UserID); err != nil {
 		logErrorAndCloseClient(fmt.Errorf("cannot handle sign in: %w", err), coapCodes.InternalServerError)
 		return
 	}
@@ -324,12 +382,7 @@ func signInPostHandler(req *mux.Message, client *Client, signIn CoapSignInReq) {
 
 	// try to register observations to the device for published resources at the cloud.
 	if err := client.server.taskQueue.Submit(func() {
-		client.observedResourcesLock.Lock()
-		defer client.observedResourcesLock.Unlock()
-		if client.shadowSynchronization == commands.ShadowSynchronization_DISABLED {
-			return
-		}
-		client.registerObservationsForPublishedResourcesLocked(ctx, deviceID)
+		client.registerObservationsForPublishedResources(ctx, deviceID)
 	}); err != nil {
 		log.Errorf("sign in error: failed to register resource observations for device %v: %w", deviceID, err)
 	}
@@ -395,7 +448,7 @@ func signOutPostHandler(req *mux.Message, client *Client, signOut CoapSignInReq)
 		return
 	}
 
-	if err := jwtClaims.ValidateOwnerClaim(client.server.config.APIs.COAP.Authorization.OwnerClaim, signOut.UserID); err != nil {
+	if err := client.server.ValidateOwner(jwtClaims, signOut.UserID); err != nil {
 		logErrorAndCloseClient(fmt.Errorf("cannot handle sign out: %w", err), coapCodes.InternalServerError)
 		return
 	}
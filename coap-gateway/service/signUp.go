@@ -116,9 +116,9 @@ func signUpPostHandler(r *mux.Message, client *Client) {
 		return
 	}
 
-	owner := claim.Owner(client.server.config.APIs.COAP.Authorization.OwnerClaim)
-	if owner == "" {
-		logErrorAndCloseClient(fmt.Errorf("cannot sign up: cannot determine owner"), coapCodes.Unauthorized)
+	owner, err := client.server.Owner(claim)
+	if err != nil {
+		logErrorAndCloseClient(fmt.Errorf("cannot sign up: cannot determine owner: %w", err), coapCodes.Unauthorized)
 		return
 	}
 
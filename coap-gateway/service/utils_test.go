@@ -254,6 +254,24 @@ func testPrepareDevice(t *testing.T, co *tcp.ClientConn) {
 }
 
 func testCoapDial(t *testing.T, host, deviceID string, withoutTLS ...bool) *tcp.ClientConn {
+	return testCoapDialWithHandler(t, host, deviceID, func(w *tcp.ResponseWriter, r *pool.Message) {
+		var err error
+		switch r.Code() {
+		case codes.POST:
+			err = w.SetResponse(codes.Changed, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		case codes.GET:
+			err = w.SetResponse(codes.Content, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		case codes.PUT:
+			err = w.SetResponse(codes.Created, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		case codes.DELETE:
+			err = w.SetResponse(codes.Deleted, message.TextPlain, bytes.NewReader([]byte("hello world")))
+		}
+		require.NoError(t, err)
+	}, withoutTLS...)
+}
+
+// testCoapDialWithHandler connects the device which responds to the requests of the coap-gateway by the handler.
+func testCoapDialWithHandler(t *testing.T, host, deviceID string, handler tcp.HandlerFunc, withoutTLS ...bool) *tcp.ClientConn {
 	var tlsConfig *tls.Config
 
 	if len(withoutTLS) == 0 {
@@ -318,20 +336,7 @@ func testCoapDial(t *testing.T, host, deviceID string, withoutTLS ...bool) *tcp.
 			},
 		}
 	}
-	conn, err := tcp.Dial(host, tcp.WithTLS(tlsConfig), tcp.WithHandlerFunc(func(w *tcp.ResponseWriter, r *pool.Message) {
-		var err error
-		switch r.Code() {
-		case codes.POST:
-			err = w.SetResponse(codes.Changed, message.TextPlain, bytes.NewReader([]byte("hello world")))
-		case codes.GET:
-			err = w.SetResponse(codes.Content, message.TextPlain, bytes.NewReader([]byte("hello world")))
-		case codes.PUT:
-			err = w.SetResponse(codes.Created, message.TextPlain, bytes.NewReader([]byte("hello world")))
-		case codes.DELETE:
-			err = w.SetResponse(codes.Deleted, message.TextPlain, bytes.NewReader([]byte("hello world")))
-		}
-		require.NoError(t, err)
-	}))
+	conn, err := tcp.Dial(host, tcp.WithTLS(tlsConfig), tcp.WithHandlerFunc(handler))
 	require.NoError(t, err)
 	return conn
 }
@@ -19,12 +19,14 @@ func MakeConfig(t *testing.T) service.Config {
 	cfg.TaskQueue.GoPoolSize = 1600
 	cfg.TaskQueue.Size = 2 * 1024 * 1024
 	cfg.APIs.COAP.Addr = config.GW_HOST
+	cfg.APIs.COAP.Protocols = []service.Protocol{service.TCP}
 	cfg.APIs.COAP.ExternalAddress = config.GW_HOST
 	cfg.APIs.COAP.MaxMessageSize = 256 * 1024
 	cfg.APIs.COAP.OwnerCacheExpiration = time.Minute
 	cfg.APIs.COAP.SubscriptionBufferSize = 1000
 	cfg.APIs.COAP.GoroutineSocketHeartbeat = time.Millisecond * 300
 	cfg.APIs.COAP.KeepAlive.Timeout = time.Second * 20
+	cfg.APIs.COAP.DTLS.HandshakeTimeout = time.Second * 10
 	cfg.APIs.COAP.BlockwiseTransfer.Enabled = false
 	cfg.APIs.COAP.BlockwiseTransfer.SZX = "1024"
 	cfg.APIs.COAP.TLS.Embedded = config.MakeTLSServerConfig()
@@ -3,7 +3,9 @@ module github.com/plgd-dev/hub
 go 1.16
 
 require (
+	github.com/ThalesIgnite/crypto11 v1.2.5
 	github.com/fsnotify/fsnotify v1.5.1
+	github.com/gocql/gocql v1.0.0
 	github.com/golang-jwt/jwt/v4 v4.1.0
 	github.com/golang/snappy v0.0.4
 	github.com/google/go-querystring v1.1.0
@@ -11,6 +13,7 @@ require (
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/websocket v1.4.2
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
+	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.6.0
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/json-iterator/go v1.1.12
@@ -21,12 +24,21 @@ require (
 	github.com/nats-io/nats.go v1.12.3
 	github.com/panjf2000/ants/v2 v2.4.6
 	github.com/patrickmn/go-cache v2.1.0+incompatible
+	github.com/pion/dtls/v2 v2.0.10-0.20210502094952-3dc563b9aede
 	github.com/plgd-dev/device v0.0.0-20211016113451-5fefd434551f
 	github.com/plgd-dev/go-coap/v2 v2.4.1-0.20211006194403-5081d8da41f9
 	github.com/plgd-dev/kit/v2 v2.0.0-20211006190727-057b33161b90
+	github.com/prometheus/client_golang v1.11.0
+	github.com/segmentio/kafka-go v0.4.23
 	github.com/sirupsen/logrus v1.8.1
 	github.com/stretchr/testify v1.7.0
 	go.mongodb.org/mongo-driver v1.7.3
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.25.0
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.25.0
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
 	go.uber.org/atomic v1.9.0
 	go.uber.org/zap v1.19.1
 	golang.org/x/net v0.0.0-20211006190231-62292e806868
@@ -12,13 +12,15 @@ type DeviceSubscription struct {
 	sub *DeviceSubscriptions
 }
 
-// NewDeviceSubscription creates new devices subscriptions to listen events: resource published, resource unpublished.
+// NewDeviceSubscription creates new devices subscriptions to listen events: resource published, resource unpublished, device metadata updated
+// and the events of resource commands according to the implemented handlers.
 // JWT token must be stored in context for grpc call.
 func (c *Client) NewDeviceSubscription(ctx context.Context, deviceID string, handle SubscriptionHandler) (*DeviceSubscription, error) {
 	return NewDeviceSubscription(ctx, deviceID, handle, handle, c.gateway)
 }
 
-// NewDeviceSubscription creates new devices subscriptions to listen events: resource published, resource unpublished.
+// NewDeviceSubscription creates new devices subscriptions to listen events: resource published, resource unpublished, device metadata updated
+// and the events of resource commands according to the implemented handlers.
 // JWT token must be stored in context for grpc call.
 func NewDeviceSubscription(ctx context.Context, deviceID string, closeErrorHandler SubscriptionHandler, handle interface{}, gwClient pb.GrpcGatewayClient) (*DeviceSubscription, error) {
 	sub, err := NewDeviceSubscriptions(ctx, gwClient, closeErrorHandler.Error)
@@ -142,6 +142,8 @@ type deviceSub struct {
 	ResourceDeletedHandler
 	ResourceCreatePendingHandler
 	ResourceCreatedHandler
+	DeviceMetadataUpdatePendingHandler
+	DeviceMetadataUpdatedHandler
 }
 
 func (s *deviceSub) HandleResourcePublished(ctx context.Context, val *events.ResourceLinksPublished) error {
@@ -214,6 +216,20 @@ func (s *deviceSub) HandleResourceCreated(ctx context.Context, val *events.Resou
 	return s.ResourceCreatedHandler.HandleResourceCreated(ctx, val)
 }
 
+func (s *deviceSub) HandleDeviceMetadataUpdatePending(ctx context.Context, val *events.DeviceMetadataUpdatePending) error {
+	if s.DeviceMetadataUpdatePendingHandler == nil {
+		return fmt.Errorf("DeviceMetadataUpdatePendingHandler in not supported")
+	}
+	return s.DeviceMetadataUpdatePendingHandler.HandleDeviceMetadataUpdatePending(ctx, val)
+}
+
+func (s *deviceSub) HandleDeviceMetadataUpdated(ctx context.Context, val *events.DeviceMetadataUpdated) error {
+	if s.DeviceMetadataUpdatedHandler == nil {
+		return fmt.Errorf("DeviceMetadataUpdatedHandler in not supported")
+	}
+	return s.DeviceMetadataUpdatedHandler.HandleDeviceMetadataUpdated(ctx, val)
+}
+
 type Subcription struct {
 	id     string
 	cancel func(context.Context) error
@@ -241,6 +257,8 @@ func getSubscribeTypeAndHandler(closeErrorHandler SubscriptionHandler, handle in
 	var resourceDeletedHandler ResourceDeletedHandler
 	var resourceCreatePendingHandler ResourceCreatePendingHandler
 	var resourceCreatedHandler ResourceCreatedHandler
+	var deviceMetadataUpdatePendingHandler DeviceMetadataUpdatePendingHandler
+	var deviceMetadataUpdatedHandler DeviceMetadataUpdatedHandler
 
 	filterEvents := make([]pb.SubscribeToEvents_CreateSubscription_Event, 0, 2)
 	if v, ok := handle.(ResourcePublishedHandler); ok {
@@ -283,23 +301,33 @@ func getSubscribeTypeAndHandler(closeErrorHandler SubscriptionHandler, handle in
 		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_CREATED)
 		resourceCreatedHandler = v
 	}
+	if v, ok := handle.(DeviceMetadataUpdatePendingHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_DEVICE_METADATA_UPDATE_PENDING)
+		deviceMetadataUpdatePendingHandler = v
+	}
+	if v, ok := handle.(DeviceMetadataUpdatedHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_DEVICE_METADATA_UPDATED)
+		deviceMetadataUpdatedHandler = v
+	}
 
 	if len(filterEvents) == 0 {
-		return nil, nil, fmt.Errorf("invalid handler - supported handlers: ResourcePublishedHandler, ResourceUnpublishedHandler, ResourceUpdatePendingHandler, ResourceUpdatedHandler, ResourceRetrievePendingHandler, ResourceRetrievedHandler, ResourceDeletePendingHandler, ResourceDeletedHandler, ResourceCreatePendingHandler, ResourceCreatedHandler")
+		return nil, nil, fmt.Errorf("invalid handler - supported handlers: ResourcePublishedHandler, ResourceUnpublishedHandler, ResourceUpdatePendingHandler, ResourceUpdatedHandler, ResourceRetrievePendingHandler, ResourceRetrievedHandler, ResourceDeletePendingHandler, ResourceDeletedHandler, ResourceCreatePendingHandler, ResourceCreatedHandler, DeviceMetadataUpdatePendingHandler, DeviceMetadataUpdatedHandler")
 	}
 
 	return filterEvents, &deviceSub{
-		SubscriptionHandler:            closeErrorHandler,
-		ResourcePublishedHandler:       resourcePublishedHandler,
-		ResourceUnpublishedHandler:     resourceUnpublishedHandler,
-		ResourceUpdatePendingHandler:   resourceUpdatePendingHandler,
-		ResourceUpdatedHandler:         resourceUpdatedHandler,
-		ResourceRetrievePendingHandler: resourceRetrievePendingHandler,
-		ResourceRetrievedHandler:       resourceRetrievedHandler,
-		ResourceDeletePendingHandler:   resourceDeletePendingHandler,
-		ResourceDeletedHandler:         resourceDeletedHandler,
-		ResourceCreatePendingHandler:   resourceCreatePendingHandler,
-		ResourceCreatedHandler:         resourceCreatedHandler,
+		SubscriptionHandler:                closeErrorHandler,
+		ResourcePublishedHandler:           resourcePublishedHandler,
+		ResourceUnpublishedHandler:         resourceUnpublishedHandler,
+		ResourceUpdatePendingHandler:       resourceUpdatePendingHandler,
+		ResourceUpdatedHandler:             resourceUpdatedHandler,
+		ResourceRetrievePendingHandler:     resourceRetrievePendingHandler,
+		ResourceRetrievedHandler:           resourceRetrievedHandler,
+		ResourceDeletePendingHandler:       resourceDeletePendingHandler,
+		ResourceDeletedHandler:             resourceDeletedHandler,
+		ResourceCreatePendingHandler:       resourceCreatePendingHandler,
+		ResourceCreatedHandler:             resourceCreatedHandler,
+		DeviceMetadataUpdatePendingHandler: deviceMetadataUpdatePendingHandler,
+		DeviceMetadataUpdatedHandler:       deviceMetadataUpdatedHandler,
 	}, nil
 }
 
@@ -502,6 +530,14 @@ func (s *DeviceSubscriptions) handleEventByType(e *pb.Event, h *deviceSub) (ok b
 		err := h.HandleResourceCreated(s.client.Context(), ct)
 		return err == nil
 	}
+	if ct := e.GetDeviceMetadataUpdatePending(); ct != nil {
+		err := h.HandleDeviceMetadataUpdatePending(s.client.Context(), ct)
+		return err == nil
+	}
+	if ct := e.GetDeviceMetadataUpdated(); ct != nil {
+		err := h.HandleDeviceMetadataUpdated(s.client.Context(), ct)
+		return err == nil
+	}
 
 	handler, ok := s.handlers.PullOut(e.GetCorrelationId())
 	if !ok {
@@ -17,6 +17,11 @@ type DeviceMetadataUpdatedHandler = interface {
 	HandleDeviceMetadataUpdated(ctx context.Context, val *events.DeviceMetadataUpdated) error
 }
 
+// DeviceMetadataUpdatePendingHandler handler of events.
+type DeviceMetadataUpdatePendingHandler = interface {
+	HandleDeviceMetadataUpdatePending(ctx context.Context, val *events.DeviceMetadataUpdatePending) error
+}
+
 // DeviceRegisteredHandler handler of events.
 type DeviceRegisteredHandler = interface {
 	HandleDeviceRegistered(ctx context.Context, val *pb.Event_DeviceRegistered) error
@@ -44,12 +44,24 @@ type ResourceContentChangedHandler = interface {
 	HandleResourceContentChanged(ctx context.Context, val *events.ResourceChanged) error
 }
 
+type resourceSub struct {
+	ResourceContentChangedHandler
+	ResourceUpdatePendingHandler
+	ResourceUpdatedHandler
+	ResourceRetrievePendingHandler
+	ResourceRetrievedHandler
+	ResourceDeletePendingHandler
+	ResourceDeletedHandler
+	ResourceCreatePendingHandler
+	ResourceCreatedHandler
+}
+
 // ResourceSubscription subscription.
 type ResourceSubscription struct {
-	client                        pb.GrpcGateway_SubscribeToEventsClient
-	subscriptionID                string
-	closeErrorHandler             SubscriptionHandler
-	resourceContentChangedHandler ResourceContentChangedHandler
+	client            pb.GrpcGateway_SubscribeToEventsClient
+	subscriptionID    string
+	closeErrorHandler SubscriptionHandler
+	handler           resourceSub
 
 	wait     func()
 	canceled uint32
@@ -61,18 +73,59 @@ func (c *Client) NewResourceSubscription(ctx context.Context, resourceID *comman
 	return NewResourceSubscription(ctx, resourceID, handle, handle, c.gateway)
 }
 
-// NewResourceSubscription creates new resource content changed subscription.
-// JWT token must be stored in context for grpc call.
-func NewResourceSubscription(ctx context.Context, resourceID *commands.ResourceId, closeErrorHandler SubscriptionHandler, handle interface{}, gwClient pb.GrpcGatewayClient) (*ResourceSubscription, error) {
-	var resourceContentChangedHandler ResourceContentChangedHandler
+func getResourceSubscribeTypeAndHandler(handle interface{}) ([]pb.SubscribeToEvents_CreateSubscription_Event, resourceSub, error) {
+	var h resourceSub
 	filterEvents := make([]pb.SubscribeToEvents_CreateSubscription_Event, 0, 1)
 	if v, ok := handle.(ResourceContentChangedHandler); ok {
 		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_CHANGED)
-		resourceContentChangedHandler = v
+		h.ResourceContentChangedHandler = v
+	}
+	if v, ok := handle.(ResourceUpdatePendingHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_UPDATE_PENDING)
+		h.ResourceUpdatePendingHandler = v
+	}
+	if v, ok := handle.(ResourceUpdatedHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_UPDATED)
+		h.ResourceUpdatedHandler = v
+	}
+	if v, ok := handle.(ResourceRetrievePendingHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_RETRIEVE_PENDING)
+		h.ResourceRetrievePendingHandler = v
+	}
+	if v, ok := handle.(ResourceRetrievedHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_RETRIEVED)
+		h.ResourceRetrievedHandler = v
+	}
+	if v, ok := handle.(ResourceDeletePendingHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_DELETE_PENDING)
+		h.ResourceDeletePendingHandler = v
+	}
+	if v, ok := handle.(ResourceDeletedHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_DELETED)
+		h.ResourceDeletedHandler = v
+	}
+	if v, ok := handle.(ResourceCreatePendingHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_CREATE_PENDING)
+		h.ResourceCreatePendingHandler = v
+	}
+	if v, ok := handle.(ResourceCreatedHandler); ok {
+		filterEvents = append(filterEvents, pb.SubscribeToEvents_CreateSubscription_RESOURCE_CREATED)
+		h.ResourceCreatedHandler = v
 	}
 
-	if resourceContentChangedHandler == nil {
-		return nil, fmt.Errorf("invalid handler - it's supports: ResourceContentChangedHandler")
+	if len(filterEvents) == 0 {
+		return nil, h, fmt.Errorf("invalid handler - supported handlers: ResourceContentChangedHandler, ResourceUpdatePendingHandler, ResourceUpdatedHandler, ResourceRetrievePendingHandler, ResourceRetrievedHandler, ResourceDeletePendingHandler, ResourceDeletedHandler, ResourceCreatePendingHandler, ResourceCreatedHandler")
+	}
+	return filterEvents, h, nil
+}
+
+// NewResourceSubscription creates new resource subscription to listen events: resource content changed
+// and the events of resource commands(create, retrieve, update, delete) according to the implemented handlers.
+// JWT token must be stored in context for grpc call.
+func NewResourceSubscription(ctx context.Context, resourceID *commands.ResourceId, closeErrorHandler SubscriptionHandler, handle interface{}, gwClient pb.GrpcGatewayClient) (*ResourceSubscription, error) {
+	filterEvents, handler, err := getResourceSubscribeTypeAndHandler(handle)
+	if err != nil {
+		return nil, err
 	}
 	client, err := New(gwClient).SubscribeToEventsWithCurrentState(ctx, time.Minute)
 	if err != nil {
@@ -104,11 +157,11 @@ func NewResourceSubscription(ctx context.Context, resourceID *commands.ResourceI
 
 	var wg sync.WaitGroup
 	sub := &ResourceSubscription{
-		client:                        client,
-		closeErrorHandler:             closeErrorHandler,
-		subscriptionID:                ev.GetSubscriptionId(),
-		resourceContentChangedHandler: resourceContentChangedHandler,
-		wait:                          wg.Wait,
+		client:            client,
+		closeErrorHandler: closeErrorHandler,
+		subscriptionID:    ev.GetSubscriptionId(),
+		handler:           handler,
+		wait:              wg.Wait,
 	}
 	wg.Add(1)
 	go func() {
@@ -173,19 +226,39 @@ func (s *ResourceSubscription) runRecv() {
 			return
 		}
 
-		if ct := ev.GetResourceChanged(); ct != nil {
-			err = s.resourceContentChangedHandler.HandleResourceContentChanged(s.client.Context(), ct)
-			if err != nil {
-				cancelAndHandleError(err)
-				return
-			}
-		} else {
-			cancelAndHandleError(fmt.Errorf("unknown event occurs %T on recv resource events: %+v", ev, ev))
+		err = s.handleEvent(s.client.Context(), ev)
+		if err != nil {
+			cancelAndHandleError(err)
 			return
 		}
 	}
 }
 
+func (s *ResourceSubscription) handleEvent(ctx context.Context, ev *pb.Event) error {
+	h := s.handler
+	switch {
+	case ev.GetResourceChanged() != nil && h.ResourceContentChangedHandler != nil:
+		return h.HandleResourceContentChanged(ctx, ev.GetResourceChanged())
+	case ev.GetResourceUpdatePending() != nil && h.ResourceUpdatePendingHandler != nil:
+		return h.HandleResourceUpdatePending(ctx, ev.GetResourceUpdatePending())
+	case ev.GetResourceUpdated() != nil && h.ResourceUpdatedHandler != nil:
+		return h.HandleResourceUpdated(ctx, ev.GetResourceUpdated())
+	case ev.GetResourceRetrievePending() != nil && h.ResourceRetrievePendingHandler != nil:
+		return h.HandleResourceRetrievePending(ctx, ev.GetResourceRetrievePending())
+	case ev.GetResourceRetrieved() != nil && h.ResourceRetrievedHandler != nil:
+		return h.HandleResourceRetrieved(ctx, ev.GetResourceRetrieved())
+	case ev.GetResourceDeletePending() != nil && h.ResourceDeletePendingHandler != nil:
+		return h.HandleResourceDeletePending(ctx, ev.GetResourceDeletePending())
+	case ev.GetResourceDeleted() != nil && h.ResourceDeletedHandler != nil:
+		return h.HandleResourceDeleted(ctx, ev.GetResourceDeleted())
+	case ev.GetResourceCreatePending() != nil && h.ResourceCreatePendingHandler != nil:
+		return h.HandleResourceCreatePending(ctx, ev.GetResourceCreatePending())
+	case ev.GetResourceCreated() != nil && h.ResourceCreatedHandler != nil:
+		return h.HandleResourceCreated(ctx, ev.GetResourceCreated())
+	}
+	return fmt.Errorf("unknown event occurs %T on recv resource events: %+v", ev, ev)
+}
+
 func ToResourceSubscription(v interface{}, ok bool) (*ResourceSubscription, bool) {
 	if !ok {
 		return nil, false
@@ -0,0 +1,92 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/schema/configuration"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResourceUpdateHandler handles only the events of the update command, so the subscription doesn't contain
+// the resource content changed events.
+type testResourceUpdateHandler struct {
+	pending chan *events.ResourceUpdatePending
+	updated chan *events.ResourceUpdated
+}
+
+func (h *testResourceUpdateHandler) HandleResourceUpdatePending(ctx context.Context, val *events.ResourceUpdatePending) error {
+	h.pending <- val
+	return nil
+}
+
+func (h *testResourceUpdateHandler) HandleResourceUpdated(ctx context.Context, val *events.ResourceUpdated) error {
+	h.updated <- val
+	return nil
+}
+
+func (h *testResourceUpdateHandler) Error(err error) { fmt.Println(err) }
+
+func (h *testResourceUpdateHandler) OnClose() { fmt.Println("Resource subscription was closed") }
+
+func TestResourceSubscriptionUpdateEvents(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	ctx, cancel := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancel()
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	c := NewTestClient(t)
+	defer func() {
+		err := c.Close(context.Background())
+		assert.NoError(t, err)
+	}()
+	deviceID, shutdownDevSim := test.OnboardDevSim(ctx, t, c.GrpcGatewayClient(), deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	h := &testResourceUpdateHandler{
+		pending: make(chan *events.ResourceUpdatePending, 10),
+		updated: make(chan *events.ResourceUpdated, 10),
+	}
+	sub, err := c.NewResourceSubscription(ctx, commands.NewResourceID(deviceID, configuration.ResourceURI), h)
+	require.NoError(t, err)
+	defer func() {
+		wait, err := sub.Cancel()
+		require.NoError(t, err)
+		wait()
+	}()
+
+	err = c.UpdateResource(ctx, deviceID, configuration.ResourceURI, map[string]interface{}{"n": "resource subscription"}, nil)
+	require.NoError(t, err)
+	defer func() {
+		err := c.UpdateResource(ctx, deviceID, configuration.ResourceURI, map[string]interface{}{"n": test.TestDeviceName}, nil)
+		assert.NoError(t, err)
+	}()
+
+	var pending *events.ResourceUpdatePending
+	select {
+	case pending = <-h.pending:
+	case <-time.After(time.Second * 5):
+		require.FailNow(t, "resource update pending event was not received")
+	}
+	require.Equal(t, configuration.ResourceURI, pending.GetResourceId().GetHref())
+	select {
+	case updated := <-h.updated:
+		require.Equal(t, configuration.ResourceURI, updated.GetResourceId().GetHref())
+		require.Equal(t, commands.Status_OK, updated.GetStatus())
+		require.Equal(t, pending.GetAuditContext().GetCorrelationId(), updated.GetAuditContext().GetCorrelationId())
+	case <-time.After(time.Second * 5):
+		require.FailNow(t, "resource updated event was not received")
+	}
+}
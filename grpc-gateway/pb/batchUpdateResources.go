@@ -0,0 +1,54 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"google.golang.org/grpc/peer"
+)
+
+// MakeBatchCorrelationID creates the correlation id of the idx-th update of the batch.
+func MakeBatchCorrelationID(batchID string, idx int) string {
+	return fmt.Sprintf("%v.%v", batchID, idx)
+}
+
+func (req *BatchUpdateResourcesRequest) Validate() error {
+	if len(req.GetResourceIdFilter()) == 0 && len(req.GetTypeFilter()) == 0 {
+		return fmt.Errorf("invalid filter: resourceIdFilter or typeFilter must be set")
+	}
+	if req.GetContent() == nil {
+		return fmt.Errorf("invalid content")
+	}
+	return nil
+}
+
+func (req *BatchUpdateResourcesRequest) ToGetResourcesRequest() *GetResourcesRequest {
+	return &GetResourcesRequest{
+		ResourceIdFilter: req.GetResourceIdFilter(),
+		DeviceIdFilter:   req.GetDeviceIdFilter(),
+		TypeFilter:       req.GetTypeFilter(),
+	}
+}
+
+func (req *BatchUpdateResourcesRequest) ToRACommand(ctx context.Context, resourceID *commands.ResourceId, correlationID string) *commands.UpdateResourceRequest {
+	connectionID := ""
+	peer, ok := peer.FromContext(ctx)
+	if ok {
+		connectionID = peer.Addr.String()
+	}
+	return &commands.UpdateResourceRequest{
+		ResourceId:        resourceID,
+		CorrelationId:     correlationID,
+		ResourceInterface: req.GetResourceInterface(),
+		TimeToLive:        req.GetTimeToLive(),
+		Content: &commands.Content{
+			Data:              req.GetContent().GetData(),
+			ContentType:       req.GetContent().GetContentType(),
+			CoapContentFormat: -1,
+		},
+		CommandMetadata: &commands.CommandMetadata{
+			ConnectionId: connectionID,
+		},
+	}
+}
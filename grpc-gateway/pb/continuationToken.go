@@ -0,0 +1,53 @@
+package pb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+)
+
+// continuationToken is serialized eventstore.GetEventsCursor
+type continuationToken struct {
+	Timestamp   int64  `json:"t"`
+	AggregateID string `json:"a"`
+	Version     uint64 `json:"v"`
+}
+
+// MakeContinuationToken creates the opaque token of the event, it is returned by GetEvents and SubscribeToEvents.
+func MakeContinuationToken(event eventstore.Event) (string, error) {
+	cursor := eventstore.NewGetEventsCursor(event)
+	data, err := json.Marshal(continuationToken{
+		Timestamp:   cursor.Timestamp,
+		AggregateID: cursor.AggregateID,
+		Version:     cursor.Version,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// ParseContinuationToken parses the token created by MakeContinuationToken, for the empty token it returns nil.
+func ParseContinuationToken(token string) (*eventstore.GetEventsCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continuationToken: %w", err)
+	}
+	var t continuationToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("invalid continuationToken: %w", err)
+	}
+	if t.AggregateID == "" {
+		return nil, fmt.Errorf("invalid continuationToken: missing aggregateID")
+	}
+	return &eventstore.GetEventsCursor{
+		Timestamp:   t.Timestamp,
+		AggregateID: t.AggregateID,
+		Version:     t.Version,
+	}, nil
+}
@@ -150,7 +150,7 @@ func (x SubscribeToEvents_CreateSubscription_Event) Number() protoreflect.EnumNu
 
 // Deprecated: Use SubscribeToEvents_CreateSubscription_Event.Descriptor instead.
 func (SubscribeToEvents_CreateSubscription_Event) EnumDescriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{10, 0, 0}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{12, 0, 0}
 }
 
 type Event_OperationProcessed_ErrorStatus_Code int32
@@ -199,7 +199,7 @@ func (x Event_OperationProcessed_ErrorStatus_Code) Number() protoreflect.EnumNum
 
 // Deprecated: Use Event_OperationProcessed_ErrorStatus_Code.Descriptor instead.
 func (Event_OperationProcessed_ErrorStatus_Code) EnumDescriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11, 2, 0, 0}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 2, 0, 0}
 }
 
 type GetDevicesRequest struct {
@@ -359,6 +359,108 @@ func (x *DeleteDevicesResponse) GetDeviceIds() []string {
 	return nil
 }
 
+type TransferDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceIdFilter []string `protobuf:"bytes,1,rep,name=device_id_filter,json=deviceIdFilter,proto3" json:"device_id_filter,omitempty"`
+	NewOwner       string   `protobuf:"bytes,2,opt,name=new_owner,json=newOwner,proto3" json:"new_owner,omitempty"`
+}
+
+func (x *TransferDevicesRequest) Reset() {
+	*x = TransferDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferDevicesRequest) ProtoMessage() {}
+
+func (x *TransferDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferDevicesRequest.ProtoReflect.Descriptor instead.
+func (*TransferDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransferDevicesRequest) GetDeviceIdFilter() []string {
+	if x != nil {
+		return x.DeviceIdFilter
+	}
+	return nil
+}
+
+func (x *TransferDevicesRequest) GetNewOwner() string {
+	if x != nil {
+		return x.NewOwner
+	}
+	return ""
+}
+
+type TransferDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceIds []string `protobuf:"bytes,1,rep,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+}
+
+func (x *TransferDevicesResponse) Reset() {
+	*x = TransferDevicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferDevicesResponse) ProtoMessage() {}
+
+func (x *TransferDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferDevicesResponse.ProtoReflect.Descriptor instead.
+func (*TransferDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferDevicesResponse) GetDeviceIds() []string {
+	if x != nil {
+		return x.DeviceIds
+	}
+	return nil
+}
+
 type GetResourceLinksRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -371,7 +473,7 @@ type GetResourceLinksRequest struct {
 func (x *GetResourceLinksRequest) Reset() {
 	*x = GetResourceLinksRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[3]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -384,7 +486,7 @@ func (x *GetResourceLinksRequest) String() string {
 func (*GetResourceLinksRequest) ProtoMessage() {}
 
 func (x *GetResourceLinksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[3]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +499,7 @@ func (x *GetResourceLinksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourceLinksRequest.ProtoReflect.Descriptor instead.
 func (*GetResourceLinksRequest) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{3}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetResourceLinksRequest) GetTypeFilter() []string {
@@ -427,7 +529,7 @@ type GetResourceFromDeviceRequest struct {
 func (x *GetResourceFromDeviceRequest) Reset() {
 	*x = GetResourceFromDeviceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[4]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -440,7 +542,7 @@ func (x *GetResourceFromDeviceRequest) String() string {
 func (*GetResourceFromDeviceRequest) ProtoMessage() {}
 
 func (x *GetResourceFromDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[4]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -453,7 +555,7 @@ func (x *GetResourceFromDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourceFromDeviceRequest.ProtoReflect.Descriptor instead.
 func (*GetResourceFromDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{4}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetResourceFromDeviceRequest) GetResourceId() *commands.ResourceId {
@@ -488,7 +590,7 @@ type GetResourceFromDeviceResponse struct {
 func (x *GetResourceFromDeviceResponse) Reset() {
 	*x = GetResourceFromDeviceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[5]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -501,7 +603,7 @@ func (x *GetResourceFromDeviceResponse) String() string {
 func (*GetResourceFromDeviceResponse) ProtoMessage() {}
 
 func (x *GetResourceFromDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[5]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +616,7 @@ func (x *GetResourceFromDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourceFromDeviceResponse.ProtoReflect.Descriptor instead.
 func (*GetResourceFromDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{5}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetResourceFromDeviceResponse) GetData() *events.ResourceRetrieved {
@@ -532,12 +634,21 @@ type GetResourcesRequest struct {
 	ResourceIdFilter []string `protobuf:"bytes,1,rep,name=resource_id_filter,json=resourceIdFilter,proto3" json:"resource_id_filter,omitempty"` // format {deviceID}{href}. eg "ae424c58-e517-4494-6de7-583536c48213/oic/d"
 	DeviceIdFilter   []string `protobuf:"bytes,2,rep,name=device_id_filter,json=deviceIdFilter,proto3" json:"device_id_filter,omitempty"`
 	TypeFilter       []string `protobuf:"bytes,3,rep,name=type_filter,json=typeFilter,proto3" json:"type_filter,omitempty"`
+	// Expression evaluated against the content of the resource, eg "temperature > 30 && units == \"C\"".
+	// Conditions joined by && have format {path} {operator} {value}, where path addresses the property
+	// of the decoded content (eg "$.a.b[0]"), operator is one of ==, !=, <, <=, >, >= and value is a JSON literal.
+	// A condition with a property missing in the content is not satisfied by any operator, including !=.
+	// The content isn't indexed, it is decoded and evaluated by the resource-directory for each resource selected
+	// by the other filters, so combine it with the device, resource or type filter to limit the evaluated resources.
+	ContentFilter string `protobuf:"bytes,4,opt,name=content_filter,json=contentFilter,proto3" json:"content_filter,omitempty"`
+	// Resources are sent without the content, eg to compare ETags of the stored content.
+	ExcludeContent bool `protobuf:"varint,5,opt,name=exclude_content,json=excludeContent,proto3" json:"exclude_content,omitempty"`
 }
 
 func (x *GetResourcesRequest) Reset() {
 	*x = GetResourcesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[6]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -550,7 +661,7 @@ func (x *GetResourcesRequest) String() string {
 func (*GetResourcesRequest) ProtoMessage() {}
 
 func (x *GetResourcesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[6]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +674,7 @@ func (x *GetResourcesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResourcesRequest.ProtoReflect.Descriptor instead.
 func (*GetResourcesRequest) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{6}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetResourcesRequest) GetResourceIdFilter() []string {
@@ -587,6 +698,20 @@ func (x *GetResourcesRequest) GetTypeFilter() []string {
 	return nil
 }
 
+func (x *GetResourcesRequest) GetContentFilter() string {
+	if x != nil {
+		return x.ContentFilter
+	}
+	return ""
+}
+
+func (x *GetResourcesRequest) GetExcludeContent() bool {
+	if x != nil {
+		return x.ExcludeContent
+	}
+	return false
+}
+
 type Resource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -599,7 +724,7 @@ type Resource struct {
 func (x *Resource) Reset() {
 	*x = Resource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[7]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -612,7 +737,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[7]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,7 +750,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{7}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Resource) GetTypes() []string {
@@ -656,7 +781,7 @@ type UpdateResourceRequest struct {
 func (x *UpdateResourceRequest) Reset() {
 	*x = UpdateResourceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[8]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -669,7 +794,7 @@ func (x *UpdateResourceRequest) String() string {
 func (*UpdateResourceRequest) ProtoMessage() {}
 
 func (x *UpdateResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[8]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,7 +807,7 @@ func (x *UpdateResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateResourceRequest.ProtoReflect.Descriptor instead.
 func (*UpdateResourceRequest) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{8}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateResourceRequest) GetResourceId() *commands.ResourceId {
@@ -724,7 +849,7 @@ type UpdateResourceResponse struct {
 func (x *UpdateResourceResponse) Reset() {
 	*x = UpdateResourceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[9]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -737,7 +862,7 @@ func (x *UpdateResourceResponse) String() string {
 func (*UpdateResourceResponse) ProtoMessage() {}
 
 func (x *UpdateResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[9]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +875,7 @@ func (x *UpdateResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateResourceResponse.ProtoReflect.Descriptor instead.
 func (*UpdateResourceResponse) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{9}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateResourceResponse) GetData() *events.ResourceUpdated {
@@ -775,7 +900,7 @@ type SubscribeToEvents struct {
 func (x *SubscribeToEvents) Reset() {
 	*x = SubscribeToEvents{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[10]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -788,7 +913,7 @@ func (x *SubscribeToEvents) String() string {
 func (*SubscribeToEvents) ProtoMessage() {}
 
 func (x *SubscribeToEvents) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[10]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,7 +926,7 @@ func (x *SubscribeToEvents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeToEvents.ProtoReflect.Descriptor instead.
 func (*SubscribeToEvents) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{10}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{12}
 }
 
 func (m *SubscribeToEvents) GetAction() isSubscribeToEvents_Action {
@@ -874,12 +999,14 @@ type Event struct {
 	//	*Event_DeviceMetadataUpdatePending
 	//	*Event_DeviceMetadataUpdated
 	Type isEvent_Type `protobuf_oneof:"type"`
+	// opaque cursor of the event, use it in CreateSubscription.resume_cursor to continue after the event. It is empty for the events not stored in the eventstore.
+	ResumeCursor string `protobuf:"bytes,22,opt,name=resume_cursor,json=resumeCursor,proto3" json:"resume_cursor,omitempty"`
 }
 
 func (x *Event) Reset() {
 	*x = Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[11]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -892,7 +1019,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[11]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -905,7 +1032,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Event) GetSubscriptionId() string {
@@ -1048,6 +1175,13 @@ func (x *Event) GetDeviceMetadataUpdated() *events.DeviceMetadataUpdated {
 	return nil
 }
 
+func (x *Event) GetResumeCursor() string {
+	if x != nil {
+		return x.ResumeCursor
+	}
+	return ""
+}
+
 type isEvent_Type interface {
 	isEvent_Type()
 }
@@ -1166,7 +1300,7 @@ type LocalizedString struct {
 func (x *LocalizedString) Reset() {
 	*x = LocalizedString{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[12]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1179,7 +1313,7 @@ func (x *LocalizedString) String() string {
 func (*LocalizedString) ProtoMessage() {}
 
 func (x *LocalizedString) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[12]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1192,7 +1326,7 @@ func (x *LocalizedString) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalizedString.ProtoReflect.Descriptor instead.
 func (*LocalizedString) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{12}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *LocalizedString) GetLanguage() string {
@@ -1227,7 +1361,7 @@ type Device struct {
 func (x *Device) Reset() {
 	*x = Device{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[13]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1240,7 +1374,7 @@ func (x *Device) String() string {
 func (*Device) ProtoMessage() {}
 
 func (x *Device) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[13]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1253,7 +1387,7 @@ func (x *Device) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Device.ProtoReflect.Descriptor instead.
 func (*Device) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Device) GetId() string {
@@ -1324,7 +1458,7 @@ type Content struct {
 func (x *Content) Reset() {
 	*x = Content{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[14]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1337,7 +1471,7 @@ func (x *Content) String() string {
 func (*Content) ProtoMessage() {}
 
 func (x *Content) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[14]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1350,7 +1484,7 @@ func (x *Content) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Content.ProtoReflect.Descriptor instead.
 func (*Content) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{14}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Content) GetContentType() string {
@@ -1379,7 +1513,7 @@ type DeleteResourceRequest struct {
 func (x *DeleteResourceRequest) Reset() {
 	*x = DeleteResourceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[15]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1392,7 +1526,7 @@ func (x *DeleteResourceRequest) String() string {
 func (*DeleteResourceRequest) ProtoMessage() {}
 
 func (x *DeleteResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[15]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1405,7 +1539,7 @@ func (x *DeleteResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResourceRequest.ProtoReflect.Descriptor instead.
 func (*DeleteResourceRequest) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{15}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DeleteResourceRequest) GetResourceId() *commands.ResourceId {
@@ -1433,7 +1567,7 @@ type DeleteResourceResponse struct {
 func (x *DeleteResourceResponse) Reset() {
 	*x = DeleteResourceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[16]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1446,7 +1580,7 @@ func (x *DeleteResourceResponse) String() string {
 func (*DeleteResourceResponse) ProtoMessage() {}
 
 func (x *DeleteResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[16]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1459,7 +1593,7 @@ func (x *DeleteResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResourceResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResourceResponse) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{16}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *DeleteResourceResponse) GetData() *events.ResourceDeleted {
@@ -1482,7 +1616,7 @@ type CreateResourceRequest struct {
 func (x *CreateResourceRequest) Reset() {
 	*x = CreateResourceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[17]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1495,7 +1629,7 @@ func (x *CreateResourceRequest) String() string {
 func (*CreateResourceRequest) ProtoMessage() {}
 
 func (x *CreateResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[17]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1508,7 +1642,7 @@ func (x *CreateResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateResourceRequest.ProtoReflect.Descriptor instead.
 func (*CreateResourceRequest) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{17}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CreateResourceRequest) GetResourceId() *commands.ResourceId {
@@ -1543,7 +1677,7 @@ type CreateResourceResponse struct {
 func (x *CreateResourceResponse) Reset() {
 	*x = CreateResourceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[18]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1556,7 +1690,7 @@ func (x *CreateResourceResponse) String() string {
 func (*CreateResourceResponse) ProtoMessage() {}
 
 func (x *CreateResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[18]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1569,7 +1703,7 @@ func (x *CreateResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateResourceResponse.ProtoReflect.Descriptor instead.
 func (*CreateResourceResponse) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{18}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CreateResourceResponse) GetData() *events.ResourceCreated {
@@ -1587,12 +1721,16 @@ type SubscribeToEvents_CreateSubscription struct {
 	EventFilter      []SubscribeToEvents_CreateSubscription_Event `protobuf:"varint,1,rep,packed,name=event_filter,json=eventFilter,proto3,enum=grpcgateway.pb.SubscribeToEvents_CreateSubscription_Event" json:"event_filter,omitempty"`
 	DeviceIdFilter   []string                                     `protobuf:"bytes,2,rep,name=device_id_filter,json=deviceIdFilter,proto3" json:"device_id_filter,omitempty"`
 	ResourceIdFilter []string                                     `protobuf:"bytes,3,rep,name=resource_id_filter,json=resourceIdFilter,proto3" json:"resource_id_filter,omitempty"` // format {deviceID}{href}. eg "ae424c58-e517-4494-6de7-583536c48213/oic/d"
+	// resume the subscription after the event with the cursor (Event.resume_cursor), missed events are replayed from the eventstore before the live events.
+	ResumeCursor string `protobuf:"bytes,4,opt,name=resume_cursor,json=resumeCursor,proto3" json:"resume_cursor,omitempty"`
+	// replay events with timestamp > than given value (unix nanoseconds) before the live events, it is ignored when resume_cursor is set
+	ResumeTimestamp int64 `protobuf:"varint,5,opt,name=resume_timestamp,json=resumeTimestamp,proto3" json:"resume_timestamp,omitempty"`
 }
 
 func (x *SubscribeToEvents_CreateSubscription) Reset() {
 	*x = SubscribeToEvents_CreateSubscription{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[19]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1605,7 +1743,7 @@ func (x *SubscribeToEvents_CreateSubscription) String() string {
 func (*SubscribeToEvents_CreateSubscription) ProtoMessage() {}
 
 func (x *SubscribeToEvents_CreateSubscription) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[19]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1618,7 +1756,7 @@ func (x *SubscribeToEvents_CreateSubscription) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SubscribeToEvents_CreateSubscription.ProtoReflect.Descriptor instead.
 func (*SubscribeToEvents_CreateSubscription) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{10, 0}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{12, 0}
 }
 
 func (x *SubscribeToEvents_CreateSubscription) GetEventFilter() []SubscribeToEvents_CreateSubscription_Event {
@@ -1642,6 +1780,20 @@ func (x *SubscribeToEvents_CreateSubscription) GetResourceIdFilter() []string {
 	return nil
 }
 
+func (x *SubscribeToEvents_CreateSubscription) GetResumeCursor() string {
+	if x != nil {
+		return x.ResumeCursor
+	}
+	return ""
+}
+
+func (x *SubscribeToEvents_CreateSubscription) GetResumeTimestamp() int64 {
+	if x != nil {
+		return x.ResumeTimestamp
+	}
+	return 0
+}
+
 type SubscribeToEvents_CancelSubscription struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1653,7 +1805,7 @@ type SubscribeToEvents_CancelSubscription struct {
 func (x *SubscribeToEvents_CancelSubscription) Reset() {
 	*x = SubscribeToEvents_CancelSubscription{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[20]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1666,7 +1818,7 @@ func (x *SubscribeToEvents_CancelSubscription) String() string {
 func (*SubscribeToEvents_CancelSubscription) ProtoMessage() {}
 
 func (x *SubscribeToEvents_CancelSubscription) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[20]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1679,7 +1831,7 @@ func (x *SubscribeToEvents_CancelSubscription) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SubscribeToEvents_CancelSubscription.ProtoReflect.Descriptor instead.
 func (*SubscribeToEvents_CancelSubscription) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{10, 1}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{12, 1}
 }
 
 func (x *SubscribeToEvents_CancelSubscription) GetSubscriptionId() string {
@@ -1700,7 +1852,7 @@ type Event_DeviceRegistered struct {
 func (x *Event_DeviceRegistered) Reset() {
 	*x = Event_DeviceRegistered{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[21]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1713,7 +1865,7 @@ func (x *Event_DeviceRegistered) String() string {
 func (*Event_DeviceRegistered) ProtoMessage() {}
 
 func (x *Event_DeviceRegistered) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[21]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1726,7 +1878,7 @@ func (x *Event_DeviceRegistered) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event_DeviceRegistered.ProtoReflect.Descriptor instead.
 func (*Event_DeviceRegistered) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11, 0}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 0}
 }
 
 func (x *Event_DeviceRegistered) GetDeviceIds() []string {
@@ -1747,7 +1899,7 @@ type Event_DeviceUnregistered struct {
 func (x *Event_DeviceUnregistered) Reset() {
 	*x = Event_DeviceUnregistered{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[22]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1760,7 +1912,7 @@ func (x *Event_DeviceUnregistered) String() string {
 func (*Event_DeviceUnregistered) ProtoMessage() {}
 
 func (x *Event_DeviceUnregistered) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[22]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1773,7 +1925,7 @@ func (x *Event_DeviceUnregistered) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event_DeviceUnregistered.ProtoReflect.Descriptor instead.
 func (*Event_DeviceUnregistered) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11, 1}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 1}
 }
 
 func (x *Event_DeviceUnregistered) GetDeviceIds() []string {
@@ -1794,7 +1946,7 @@ type Event_OperationProcessed struct {
 func (x *Event_OperationProcessed) Reset() {
 	*x = Event_OperationProcessed{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[23]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1807,7 +1959,7 @@ func (x *Event_OperationProcessed) String() string {
 func (*Event_OperationProcessed) ProtoMessage() {}
 
 func (x *Event_OperationProcessed) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[23]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1820,7 +1972,7 @@ func (x *Event_OperationProcessed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event_OperationProcessed.ProtoReflect.Descriptor instead.
 func (*Event_OperationProcessed) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11, 2}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 2}
 }
 
 func (x *Event_OperationProcessed) GetErrorStatus() *Event_OperationProcessed_ErrorStatus {
@@ -1841,7 +1993,7 @@ type Event_SubscriptionCanceled struct {
 func (x *Event_SubscriptionCanceled) Reset() {
 	*x = Event_SubscriptionCanceled{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[24]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1854,7 +2006,7 @@ func (x *Event_SubscriptionCanceled) String() string {
 func (*Event_SubscriptionCanceled) ProtoMessage() {}
 
 func (x *Event_SubscriptionCanceled) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[24]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1867,7 +2019,7 @@ func (x *Event_SubscriptionCanceled) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event_SubscriptionCanceled.ProtoReflect.Descriptor instead.
 func (*Event_SubscriptionCanceled) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11, 3}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 3}
 }
 
 func (x *Event_SubscriptionCanceled) GetReason() string {
@@ -1889,7 +2041,7 @@ type Event_OperationProcessed_ErrorStatus struct {
 func (x *Event_OperationProcessed_ErrorStatus) Reset() {
 	*x = Event_OperationProcessed_ErrorStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[25]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1902,7 +2054,7 @@ func (x *Event_OperationProcessed_ErrorStatus) String() string {
 func (*Event_OperationProcessed_ErrorStatus) ProtoMessage() {}
 
 func (x *Event_OperationProcessed_ErrorStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[25]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +2067,7 @@ func (x *Event_OperationProcessed_ErrorStatus) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use Event_OperationProcessed_ErrorStatus.ProtoReflect.Descriptor instead.
 func (*Event_OperationProcessed_ErrorStatus) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{11, 2, 0}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 2, 0}
 }
 
 func (x *Event_OperationProcessed_ErrorStatus) GetCode() Event_OperationProcessed_ErrorStatus_Code {
@@ -1944,7 +2096,7 @@ type Device_Metadata struct {
 func (x *Device_Metadata) Reset() {
 	*x = Device_Metadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[26]
+		mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1957,7 +2109,7 @@ func (x *Device_Metadata) String() string {
 func (*Device_Metadata) ProtoMessage() {}
 
 func (x *Device_Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[26]
+	mi := &file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1970,7 +2122,7 @@ func (x *Device_Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Device_Metadata.ProtoReflect.Descriptor instead.
 func (*Device_Metadata) Descriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{13, 0}
+	return file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *Device_Metadata) GetStatus() *commands.ConnectionStatus {
@@ -2022,232 +2174,254 @@ var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDesc = []byte{
 	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x36, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
 	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x64,
-	0x0a, 0x17, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6e,
-	0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70,
-	0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
-	0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x22, 0xb0, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70,
-	0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x52, 0x0a, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
-	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x54,
-	0x6f, 0x4c, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x69, 0x6d,
-	0x65, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65, 0x22, 0x5c, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8e, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a,
-	0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x64,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x5b, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0xdc, 0x01, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a,
-	0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67,
-	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x49, 0x64, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
-	0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65, 0x12,
-	0x31, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70,
-	0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x22, 0x53, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70,
-	0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xa8, 0x07, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x67, 0x0a,
-	0x13, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x72, 0x70,
-	0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x48, 0x00, 0x52, 0x12, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x67, 0x0a, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
-	0x5f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x63, 0x61, 0x6e,
-	0x63, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
-	0x64, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x1a, 0xd0, 0x04, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5d, 0x0a,
-	0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0e, 0x32, 0x3a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
-	0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x22, 0x82, 0x03, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e,
-	0x0a, 0x0a, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10,
-	0x0a, 0x0c, 0x55, 0x4e, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x01,
-	0x12, 0x1b, 0x0a, 0x17, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x44,
-	0x41, 0x54, 0x41, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x22, 0x0a,
-	0x1e, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x44, 0x41, 0x54, 0x41,
-	0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
-	0x05, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x50, 0x55,
-	0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x45, 0x53,
-	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x4e, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45,
-	0x44, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
-	0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x08,
-	0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x50, 0x44,
-	0x41, 0x54, 0x45, 0x44, 0x10, 0x09, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52,
-	0x43, 0x45, 0x5f, 0x52, 0x45, 0x54, 0x52, 0x49, 0x45, 0x56, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44,
-	0x49, 0x4e, 0x47, 0x10, 0x0a, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43,
-	0x45, 0x5f, 0x52, 0x45, 0x54, 0x52, 0x49, 0x45, 0x56, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x1b, 0x0a,
-	0x17, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
-	0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x0c, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45,
-	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x0d,
-	0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x52, 0x45,
-	0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x0e, 0x12, 0x14, 0x0a,
-	0x10, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
-	0x44, 0x10, 0x0f, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
-	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x10, 0x1a, 0x3d, 0x0a, 0x12, 0x43, 0x61, 0x6e,
-	0x63, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0xef, 0x10, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f,
-	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
-	0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x55, 0x0a, 0x11,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61,
-	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x48,
-	0x00, 0x52, 0x10, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x65, 0x64, 0x12, 0x5b, 0x0a, 0x13, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x6e,
-	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x28, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70,
-	0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x55, 0x6e,
-	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x12, 0x64, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64,
-	0x12, 0x5d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
-	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6e, 0x6b,
-	0x73, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12,
-	0x63, 0x0a, 0x14, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x6e, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x5f,
+	0x0a, 0x16, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x22,
+	0x38, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x64, 0x0a, 0x17, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22,
+	0xb0, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46,
+	0x72, 0x6f, 0x6d, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x41, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4c, 0x69,
+	0x76, 0x65, 0x22, 0x5c, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0xde, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x5b, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xdc,
+	0x01, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
 	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6e,
-	0x6b, 0x73, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x48, 0x00, 0x52,
-	0x13, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x73, 0x68, 0x65, 0x64, 0x12, 0x52, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x5b, 0x0a, 0x13, 0x6f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x48,
-	0x00, 0x52, 0x12, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x61, 0x0a, 0x15, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64,
-	0x48, 0x00, 0x52, 0x14, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x12, 0x65, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64,
-	0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
-	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12,
-	0x52, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f,
+	0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x52,
+	0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69,
+	0x6d, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x74, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x53, 0x0a,
+	0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xf8, 0x07, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x54, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x67, 0x0a, 0x13, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x54, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x67, 0x0a, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f,
+	0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x65, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x1a, 0xa0, 0x05, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5d, 0x0a, 0x0c, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x3a,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
+	0x82, 0x03, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x45, 0x47,
+	0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x52,
+	0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x44,
+	0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x55,
+	0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x22, 0x0a, 0x1e, 0x44, 0x45, 0x56, 0x49,
+	0x43, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x55, 0x50, 0x44, 0x41,
+	0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12,
+	0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48,
+	0x45, 0x44, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
+	0x5f, 0x55, 0x4e, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x07, 0x12, 0x1b,
+	0x0a, 0x17, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x08, 0x12, 0x14, 0x0a, 0x10, 0x52,
+	0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x09, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x52, 0x45,
+	0x54, 0x52, 0x49, 0x45, 0x56, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x0a,
+	0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x52, 0x45, 0x54,
+	0x52, 0x49, 0x45, 0x56, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x10, 0x0c, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x0d, 0x12, 0x1b, 0x0a, 0x17, 0x52,
+	0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x50,
+	0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x0e, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x0f, 0x12, 0x14,
+	0x0a, 0x10, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47,
+	0x45, 0x44, 0x10, 0x10, 0x1a, 0x3d, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x94, 0x11,
+	0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x55, 0x0a, 0x11, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x10, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x5b,
+	0x0a, 0x13, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x12, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x55,
+	0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x5d, 0x0a, 0x12, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x63, 0x0a, 0x14, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x55, 0x6e, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12,
+	0x52, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f,
 	0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
-	0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x64, 0x12, 0x6b, 0x0a, 0x19, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x50, 0x65,
-	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x12, 0x5b, 0x0a, 0x13, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70,
+	0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x48, 0x00, 0x52, 0x12, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x12, 0x61, 0x0a, 0x15, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x14, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x65, 0x64, 0x12, 0x65, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x52, 0x0a, 0x10, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x6b,
+	0x0a, 0x19, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
 	0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x12, 0x58, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
-	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72,
-	0x69, 0x65, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x12, 0x65, 0x0a, 0x17, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x70, 0x65,
-	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
-	0x67, 0x12, 0x52, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x65, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x48, 0x00, 0x52, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x58, 0x0a, 0x12, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x64, 0x12, 0x65, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
 	0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64,
-	0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x52, 0x0a, 0x10,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x52, 0x0a, 0x10,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
 	0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52,
-	0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x12, 0x78, 0x0a, 0x1e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69,
-	0x6e, 0x67, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x1b, 0x64,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x65, 0x0a, 0x17, 0x64, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e,
-	0x70, 0x62, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x15, 0x64, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x1a, 0x31, 0x0a, 0x10, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52,
+	0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x12, 0x65, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00,
+	0x52, 0x15, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x52, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x78, 0x0a, 0x1e, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x1b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x65, 0x0a, 0x17, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x15, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x1a, 0x31, 0x0a, 0x10, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73,
 	0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f,
 	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63,
 	0x65, 0x49, 0x64, 0x73, 0x1a, 0x33, 0x0a, 0x12, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x55, 0x6e,
@@ -2363,7 +2537,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDescGZIP() []
 }
 
 var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_goTypes = []interface{}{
 	(GetDevicesRequest_Status)(0),                   // 0: grpcgateway.pb.GetDevicesRequest.Status
 	(SubscribeToEvents_CreateSubscription_Event)(0), // 1: grpcgateway.pb.SubscribeToEvents.CreateSubscription.Event
@@ -2371,86 +2545,88 @@ var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_goTypes = []inter
 	(*GetDevicesRequest)(nil),                       // 3: grpcgateway.pb.GetDevicesRequest
 	(*DeleteDevicesRequest)(nil),                    // 4: grpcgateway.pb.DeleteDevicesRequest
 	(*DeleteDevicesResponse)(nil),                   // 5: grpcgateway.pb.DeleteDevicesResponse
-	(*GetResourceLinksRequest)(nil),                 // 6: grpcgateway.pb.GetResourceLinksRequest
-	(*GetResourceFromDeviceRequest)(nil),            // 7: grpcgateway.pb.GetResourceFromDeviceRequest
-	(*GetResourceFromDeviceResponse)(nil),           // 8: grpcgateway.pb.GetResourceFromDeviceResponse
-	(*GetResourcesRequest)(nil),                     // 9: grpcgateway.pb.GetResourcesRequest
-	(*Resource)(nil),                                // 10: grpcgateway.pb.Resource
-	(*UpdateResourceRequest)(nil),                   // 11: grpcgateway.pb.UpdateResourceRequest
-	(*UpdateResourceResponse)(nil),                  // 12: grpcgateway.pb.UpdateResourceResponse
-	(*SubscribeToEvents)(nil),                       // 13: grpcgateway.pb.SubscribeToEvents
-	(*Event)(nil),                                   // 14: grpcgateway.pb.Event
-	(*LocalizedString)(nil),                         // 15: grpcgateway.pb.LocalizedString
-	(*Device)(nil),                                  // 16: grpcgateway.pb.Device
-	(*Content)(nil),                                 // 17: grpcgateway.pb.Content
-	(*DeleteResourceRequest)(nil),                   // 18: grpcgateway.pb.DeleteResourceRequest
-	(*DeleteResourceResponse)(nil),                  // 19: grpcgateway.pb.DeleteResourceResponse
-	(*CreateResourceRequest)(nil),                   // 20: grpcgateway.pb.CreateResourceRequest
-	(*CreateResourceResponse)(nil),                  // 21: grpcgateway.pb.CreateResourceResponse
-	(*SubscribeToEvents_CreateSubscription)(nil),    // 22: grpcgateway.pb.SubscribeToEvents.CreateSubscription
-	(*SubscribeToEvents_CancelSubscription)(nil),    // 23: grpcgateway.pb.SubscribeToEvents.CancelSubscription
-	(*Event_DeviceRegistered)(nil),                  // 24: grpcgateway.pb.Event.DeviceRegistered
-	(*Event_DeviceUnregistered)(nil),                // 25: grpcgateway.pb.Event.DeviceUnregistered
-	(*Event_OperationProcessed)(nil),                // 26: grpcgateway.pb.Event.OperationProcessed
-	(*Event_SubscriptionCanceled)(nil),              // 27: grpcgateway.pb.Event.SubscriptionCanceled
-	(*Event_OperationProcessed_ErrorStatus)(nil),    // 28: grpcgateway.pb.Event.OperationProcessed.ErrorStatus
-	(*Device_Metadata)(nil),                         // 29: grpcgateway.pb.Device.Metadata
-	(*commands.ResourceId)(nil),                     // 30: resourceaggregate.pb.ResourceId
-	(*events.ResourceRetrieved)(nil),                // 31: resourceaggregate.pb.ResourceRetrieved
-	(*events.ResourceChanged)(nil),                  // 32: resourceaggregate.pb.ResourceChanged
-	(*events.ResourceUpdated)(nil),                  // 33: resourceaggregate.pb.ResourceUpdated
-	(*events.ResourceLinksPublished)(nil),           // 34: resourceaggregate.pb.ResourceLinksPublished
-	(*events.ResourceLinksUnpublished)(nil),         // 35: resourceaggregate.pb.ResourceLinksUnpublished
-	(*events.ResourceUpdatePending)(nil),            // 36: resourceaggregate.pb.ResourceUpdatePending
-	(*events.ResourceRetrievePending)(nil),          // 37: resourceaggregate.pb.ResourceRetrievePending
-	(*events.ResourceDeletePending)(nil),            // 38: resourceaggregate.pb.ResourceDeletePending
-	(*events.ResourceDeleted)(nil),                  // 39: resourceaggregate.pb.ResourceDeleted
-	(*events.ResourceCreatePending)(nil),            // 40: resourceaggregate.pb.ResourceCreatePending
-	(*events.ResourceCreated)(nil),                  // 41: resourceaggregate.pb.ResourceCreated
-	(*events.DeviceMetadataUpdatePending)(nil),      // 42: resourceaggregate.pb.DeviceMetadataUpdatePending
-	(*events.DeviceMetadataUpdated)(nil),            // 43: resourceaggregate.pb.DeviceMetadataUpdated
-	(*commands.ConnectionStatus)(nil),               // 44: resourceaggregate.pb.ConnectionStatus
-	(commands.ShadowSynchronization)(0),             // 45: resourceaggregate.pb.ShadowSynchronization
+	(*TransferDevicesRequest)(nil),                  // 6: grpcgateway.pb.TransferDevicesRequest
+	(*TransferDevicesResponse)(nil),                 // 7: grpcgateway.pb.TransferDevicesResponse
+	(*GetResourceLinksRequest)(nil),                 // 8: grpcgateway.pb.GetResourceLinksRequest
+	(*GetResourceFromDeviceRequest)(nil),            // 9: grpcgateway.pb.GetResourceFromDeviceRequest
+	(*GetResourceFromDeviceResponse)(nil),           // 10: grpcgateway.pb.GetResourceFromDeviceResponse
+	(*GetResourcesRequest)(nil),                     // 11: grpcgateway.pb.GetResourcesRequest
+	(*Resource)(nil),                                // 12: grpcgateway.pb.Resource
+	(*UpdateResourceRequest)(nil),                   // 13: grpcgateway.pb.UpdateResourceRequest
+	(*UpdateResourceResponse)(nil),                  // 14: grpcgateway.pb.UpdateResourceResponse
+	(*SubscribeToEvents)(nil),                       // 15: grpcgateway.pb.SubscribeToEvents
+	(*Event)(nil),                                   // 16: grpcgateway.pb.Event
+	(*LocalizedString)(nil),                         // 17: grpcgateway.pb.LocalizedString
+	(*Device)(nil),                                  // 18: grpcgateway.pb.Device
+	(*Content)(nil),                                 // 19: grpcgateway.pb.Content
+	(*DeleteResourceRequest)(nil),                   // 20: grpcgateway.pb.DeleteResourceRequest
+	(*DeleteResourceResponse)(nil),                  // 21: grpcgateway.pb.DeleteResourceResponse
+	(*CreateResourceRequest)(nil),                   // 22: grpcgateway.pb.CreateResourceRequest
+	(*CreateResourceResponse)(nil),                  // 23: grpcgateway.pb.CreateResourceResponse
+	(*SubscribeToEvents_CreateSubscription)(nil),    // 24: grpcgateway.pb.SubscribeToEvents.CreateSubscription
+	(*SubscribeToEvents_CancelSubscription)(nil),    // 25: grpcgateway.pb.SubscribeToEvents.CancelSubscription
+	(*Event_DeviceRegistered)(nil),                  // 26: grpcgateway.pb.Event.DeviceRegistered
+	(*Event_DeviceUnregistered)(nil),                // 27: grpcgateway.pb.Event.DeviceUnregistered
+	(*Event_OperationProcessed)(nil),                // 28: grpcgateway.pb.Event.OperationProcessed
+	(*Event_SubscriptionCanceled)(nil),              // 29: grpcgateway.pb.Event.SubscriptionCanceled
+	(*Event_OperationProcessed_ErrorStatus)(nil),    // 30: grpcgateway.pb.Event.OperationProcessed.ErrorStatus
+	(*Device_Metadata)(nil),                         // 31: grpcgateway.pb.Device.Metadata
+	(*commands.ResourceId)(nil),                     // 32: resourceaggregate.pb.ResourceId
+	(*events.ResourceRetrieved)(nil),                // 33: resourceaggregate.pb.ResourceRetrieved
+	(*events.ResourceChanged)(nil),                  // 34: resourceaggregate.pb.ResourceChanged
+	(*events.ResourceUpdated)(nil),                  // 35: resourceaggregate.pb.ResourceUpdated
+	(*events.ResourceLinksPublished)(nil),           // 36: resourceaggregate.pb.ResourceLinksPublished
+	(*events.ResourceLinksUnpublished)(nil),         // 37: resourceaggregate.pb.ResourceLinksUnpublished
+	(*events.ResourceUpdatePending)(nil),            // 38: resourceaggregate.pb.ResourceUpdatePending
+	(*events.ResourceRetrievePending)(nil),          // 39: resourceaggregate.pb.ResourceRetrievePending
+	(*events.ResourceDeletePending)(nil),            // 40: resourceaggregate.pb.ResourceDeletePending
+	(*events.ResourceDeleted)(nil),                  // 41: resourceaggregate.pb.ResourceDeleted
+	(*events.ResourceCreatePending)(nil),            // 42: resourceaggregate.pb.ResourceCreatePending
+	(*events.ResourceCreated)(nil),                  // 43: resourceaggregate.pb.ResourceCreated
+	(*events.DeviceMetadataUpdatePending)(nil),      // 44: resourceaggregate.pb.DeviceMetadataUpdatePending
+	(*events.DeviceMetadataUpdated)(nil),            // 45: resourceaggregate.pb.DeviceMetadataUpdated
+	(*commands.ConnectionStatus)(nil),               // 46: resourceaggregate.pb.ConnectionStatus
+	(commands.ShadowSynchronization)(0),             // 47: resourceaggregate.pb.ShadowSynchronization
 }
 var file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_depIdxs = []int32{
 	0,  // 0: grpcgateway.pb.GetDevicesRequest.status_filter:type_name -> grpcgateway.pb.GetDevicesRequest.Status
-	30, // 1: grpcgateway.pb.GetResourceFromDeviceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
-	31, // 2: grpcgateway.pb.GetResourceFromDeviceResponse.data:type_name -> resourceaggregate.pb.ResourceRetrieved
-	32, // 3: grpcgateway.pb.Resource.data:type_name -> resourceaggregate.pb.ResourceChanged
-	30, // 4: grpcgateway.pb.UpdateResourceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
-	17, // 5: grpcgateway.pb.UpdateResourceRequest.content:type_name -> grpcgateway.pb.Content
-	33, // 6: grpcgateway.pb.UpdateResourceResponse.data:type_name -> resourceaggregate.pb.ResourceUpdated
-	22, // 7: grpcgateway.pb.SubscribeToEvents.create_subscription:type_name -> grpcgateway.pb.SubscribeToEvents.CreateSubscription
-	23, // 8: grpcgateway.pb.SubscribeToEvents.cancel_subscription:type_name -> grpcgateway.pb.SubscribeToEvents.CancelSubscription
-	24, // 9: grpcgateway.pb.Event.device_registered:type_name -> grpcgateway.pb.Event.DeviceRegistered
-	25, // 10: grpcgateway.pb.Event.device_unregistered:type_name -> grpcgateway.pb.Event.DeviceUnregistered
-	34, // 11: grpcgateway.pb.Event.resource_published:type_name -> resourceaggregate.pb.ResourceLinksPublished
-	35, // 12: grpcgateway.pb.Event.resource_unpublished:type_name -> resourceaggregate.pb.ResourceLinksUnpublished
-	32, // 13: grpcgateway.pb.Event.resource_changed:type_name -> resourceaggregate.pb.ResourceChanged
-	26, // 14: grpcgateway.pb.Event.operation_processed:type_name -> grpcgateway.pb.Event.OperationProcessed
-	27, // 15: grpcgateway.pb.Event.subscription_canceled:type_name -> grpcgateway.pb.Event.SubscriptionCanceled
-	36, // 16: grpcgateway.pb.Event.resource_update_pending:type_name -> resourceaggregate.pb.ResourceUpdatePending
-	33, // 17: grpcgateway.pb.Event.resource_updated:type_name -> resourceaggregate.pb.ResourceUpdated
-	37, // 18: grpcgateway.pb.Event.resource_retrieve_pending:type_name -> resourceaggregate.pb.ResourceRetrievePending
-	31, // 19: grpcgateway.pb.Event.resource_retrieved:type_name -> resourceaggregate.pb.ResourceRetrieved
-	38, // 20: grpcgateway.pb.Event.resource_delete_pending:type_name -> resourceaggregate.pb.ResourceDeletePending
-	39, // 21: grpcgateway.pb.Event.resource_deleted:type_name -> resourceaggregate.pb.ResourceDeleted
-	40, // 22: grpcgateway.pb.Event.resource_create_pending:type_name -> resourceaggregate.pb.ResourceCreatePending
-	41, // 23: grpcgateway.pb.Event.resource_created:type_name -> resourceaggregate.pb.ResourceCreated
-	42, // 24: grpcgateway.pb.Event.device_metadata_update_pending:type_name -> resourceaggregate.pb.DeviceMetadataUpdatePending
-	43, // 25: grpcgateway.pb.Event.device_metadata_updated:type_name -> resourceaggregate.pb.DeviceMetadataUpdated
-	29, // 26: grpcgateway.pb.Device.metadata:type_name -> grpcgateway.pb.Device.Metadata
-	15, // 27: grpcgateway.pb.Device.manufacturer_name:type_name -> grpcgateway.pb.LocalizedString
-	30, // 28: grpcgateway.pb.DeleteResourceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
-	39, // 29: grpcgateway.pb.DeleteResourceResponse.data:type_name -> resourceaggregate.pb.ResourceDeleted
-	30, // 30: grpcgateway.pb.CreateResourceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
-	17, // 31: grpcgateway.pb.CreateResourceRequest.content:type_name -> grpcgateway.pb.Content
-	41, // 32: grpcgateway.pb.CreateResourceResponse.data:type_name -> resourceaggregate.pb.ResourceCreated
+	32, // 1: grpcgateway.pb.GetResourceFromDeviceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
+	33, // 2: grpcgateway.pb.GetResourceFromDeviceResponse.data:type_name -> resourceaggregate.pb.ResourceRetrieved
+	34, // 3: grpcgateway.pb.Resource.data:type_name -> resourceaggregate.pb.ResourceChanged
+	32, // 4: grpcgateway.pb.UpdateResourceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
+	19, // 5: grpcgateway.pb.UpdateResourceRequest.content:type_name -> grpcgateway.pb.Content
+	35, // 6: grpcgateway.pb.UpdateResourceResponse.data:type_name -> resourceaggregate.pb.ResourceUpdated
+	24, // 7: grpcgateway.pb.SubscribeToEvents.create_subscription:type_name -> grpcgateway.pb.SubscribeToEvents.CreateSubscription
+	25, // 8: grpcgateway.pb.SubscribeToEvents.cancel_subscription:type_name -> grpcgateway.pb.SubscribeToEvents.CancelSubscription
+	26, // 9: grpcgateway.pb.Event.device_registered:type_name -> grpcgateway.pb.Event.DeviceRegistered
+	27, // 10: grpcgateway.pb.Event.device_unregistered:type_name -> grpcgateway.pb.Event.DeviceUnregistered
+	36, // 11: grpcgateway.pb.Event.resource_published:type_name -> resourceaggregate.pb.ResourceLinksPublished
+	37, // 12: grpcgateway.pb.Event.resource_unpublished:type_name -> resourceaggregate.pb.ResourceLinksUnpublished
+	34, // 13: grpcgateway.pb.Event.resource_changed:type_name -> resourceaggregate.pb.ResourceChanged
+	28, // 14: grpcgateway.pb.Event.operation_processed:type_name -> grpcgateway.pb.Event.OperationProcessed
+	29, // 15: grpcgateway.pb.Event.subscription_canceled:type_name -> grpcgateway.pb.Event.SubscriptionCanceled
+	38, // 16: grpcgateway.pb.Event.resource_update_pending:type_name -> resourceaggregate.pb.ResourceUpdatePending
+	35, // 17: grpcgateway.pb.Event.resource_updated:type_name -> resourceaggregate.pb.ResourceUpdated
+	39, // 18: grpcgateway.pb.Event.resource_retrieve_pending:type_name -> resourceaggregate.pb.ResourceRetrievePending
+	33, // 19: grpcgateway.pb.Event.resource_retrieved:type_name -> resourceaggregate.pb.ResourceRetrieved
+	40, // 20: grpcgateway.pb.Event.resource_delete_pending:type_name -> resourceaggregate.pb.ResourceDeletePending
+	41, // 21: grpcgateway.pb.Event.resource_deleted:type_name -> resourceaggregate.pb.ResourceDeleted
+	42, // 22: grpcgateway.pb.Event.resource_create_pending:type_name -> resourceaggregate.pb.ResourceCreatePending
+	43, // 23: grpcgateway.pb.Event.resource_created:type_name -> resourceaggregate.pb.ResourceCreated
+	44, // 24: grpcgateway.pb.Event.device_metadata_update_pending:type_name -> resourceaggregate.pb.DeviceMetadataUpdatePending
+	45, // 25: grpcgateway.pb.Event.device_metadata_updated:type_name -> resourceaggregate.pb.DeviceMetadataUpdated
+	31, // 26: grpcgateway.pb.Device.metadata:type_name -> grpcgateway.pb.Device.Metadata
+	17, // 27: grpcgateway.pb.Device.manufacturer_name:type_name -> grpcgateway.pb.LocalizedString
+	32, // 28: grpcgateway.pb.DeleteResourceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
+	41, // 29: grpcgateway.pb.DeleteResourceResponse.data:type_name -> resourceaggregate.pb.ResourceDeleted
+	32, // 30: grpcgateway.pb.CreateResourceRequest.resource_id:type_name -> resourceaggregate.pb.ResourceId
+	19, // 31: grpcgateway.pb.CreateResourceRequest.content:type_name -> grpcgateway.pb.Content
+	43, // 32: grpcgateway.pb.CreateResourceResponse.data:type_name -> resourceaggregate.pb.ResourceCreated
 	1,  // 33: grpcgateway.pb.SubscribeToEvents.CreateSubscription.event_filter:type_name -> grpcgateway.pb.SubscribeToEvents.CreateSubscription.Event
-	28, // 34: grpcgateway.pb.Event.OperationProcessed.error_status:type_name -> grpcgateway.pb.Event.OperationProcessed.ErrorStatus
+	30, // 34: grpcgateway.pb.Event.OperationProcessed.error_status:type_name -> grpcgateway.pb.Event.OperationProcessed.ErrorStatus
 	2,  // 35: grpcgateway.pb.Event.OperationProcessed.ErrorStatus.code:type_name -> grpcgateway.pb.Event.OperationProcessed.ErrorStatus.Code
-	44, // 36: grpcgateway.pb.Device.Metadata.status:type_name -> resourceaggregate.pb.ConnectionStatus
-	45, // 37: grpcgateway.pb.Device.Metadata.shadow_synchronization:type_name -> resourceaggregate.pb.ShadowSynchronization
+	46, // 36: grpcgateway.pb.Device.Metadata.status:type_name -> resourceaggregate.pb.ConnectionStatus
+	47, // 37: grpcgateway.pb.Device.Metadata.shadow_synchronization:type_name -> resourceaggregate.pb.ShadowSynchronization
 	38, // [38:38] is the sub-list for method output_type
 	38, // [38:38] is the sub-list for method input_type
 	38, // [38:38] is the sub-list for extension type_name
@@ -2501,7 +2677,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResourceLinksRequest); i {
+			switch v := v.(*TransferDevicesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2513,7 +2689,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResourceFromDeviceRequest); i {
+			switch v := v.(*TransferDevicesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2525,7 +2701,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResourceFromDeviceResponse); i {
+			switch v := v.(*GetResourceLinksRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2537,7 +2713,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResourcesRequest); i {
+			switch v := v.(*GetResourceFromDeviceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2549,7 +2725,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Resource); i {
+			switch v := v.(*GetResourceFromDeviceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2561,7 +2737,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateResourceRequest); i {
+			switch v := v.(*GetResourcesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2573,7 +2749,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateResourceResponse); i {
+			switch v := v.(*Resource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2585,7 +2761,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeToEvents); i {
+			switch v := v.(*UpdateResourceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2597,7 +2773,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event); i {
+			switch v := v.(*UpdateResourceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2609,7 +2785,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LocalizedString); i {
+			switch v := v.(*SubscribeToEvents); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2621,7 +2797,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Device); i {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2633,7 +2809,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Content); i {
+			switch v := v.(*LocalizedString); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2645,7 +2821,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteResourceRequest); i {
+			switch v := v.(*Device); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2657,7 +2833,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteResourceResponse); i {
+			switch v := v.(*Content); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2669,7 +2845,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateResourceRequest); i {
+			switch v := v.(*DeleteResourceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2681,7 +2857,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateResourceResponse); i {
+			switch v := v.(*DeleteResourceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2693,7 +2869,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeToEvents_CreateSubscription); i {
+			switch v := v.(*CreateResourceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2705,7 +2881,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeToEvents_CancelSubscription); i {
+			switch v := v.(*CreateResourceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2717,7 +2893,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_DeviceRegistered); i {
+			switch v := v.(*SubscribeToEvents_CreateSubscription); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2729,7 +2905,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_DeviceUnregistered); i {
+			switch v := v.(*SubscribeToEvents_CancelSubscription); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2741,7 +2917,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_OperationProcessed); i {
+			switch v := v.(*Event_DeviceRegistered); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2753,7 +2929,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_SubscriptionCanceled); i {
+			switch v := v.(*Event_DeviceUnregistered); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2765,7 +2941,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_OperationProcessed_ErrorStatus); i {
+			switch v := v.(*Event_OperationProcessed); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2777,6 +2953,30 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event_SubscriptionCanceled); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event_OperationProcessed_ErrorStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Device_Metadata); i {
 			case 0:
 				return &v.state
@@ -2789,11 +2989,11 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			}
 		}
 	}
-	file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[12].OneofWrappers = []interface{}{
 		(*SubscribeToEvents_CreateSubscription_)(nil),
 		(*SubscribeToEvents_CancelSubscription_)(nil),
 	}
-	file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[11].OneofWrappers = []interface{}{
+	file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*Event_DeviceRegistered_)(nil),
 		(*Event_DeviceUnregistered_)(nil),
 		(*Event_ResourcePublished)(nil),
@@ -2818,7 +3018,7 @@ func file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_plgd_dev_hub_grpc_gateway_pb_devices_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   27,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,63 @@
+package pb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// ResumeCursor is the position of the subscription. It contains the versions of the aggregates delivered up to the resume skew
+// before the timestamp, because events stored in that interval are replayed again.
+type ResumeCursor struct {
+	Timestamp int64             // timestamp of the delivered events in unix nanoseconds
+	Versions  map[string]uint64 // [aggregateID]version of the last delivered event
+}
+
+// resumeCursor is serialized ResumeCursor, the continuation token of GetEvents is accepted too.
+type resumeCursor struct {
+	Timestamp   int64             `json:"t"`
+	AggregateID string            `json:"a,omitempty"`
+	Version     uint64            `json:"v,omitempty"`
+	Versions    map[string]uint64 `json:"vs,omitempty"`
+}
+
+// MakeResumeCursor creates the opaque cursor of the subscription, it is returned by SubscribeToEvents.
+func MakeResumeCursor(cursor ResumeCursor) (string, error) {
+	data, err := json.Marshal(resumeCursor{
+		Timestamp: cursor.Timestamp,
+		Versions:  cursor.Versions,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// ParseResumeCursor parses the cursor created by MakeResumeCursor or MakeContinuationToken, for the empty cursor it returns nil.
+func ParseResumeCursor(cursor string) (*ResumeCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resumeCursor: %w", err)
+	}
+	var c resumeCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid resumeCursor: %w", err)
+	}
+	versions := make(map[string]uint64, len(c.Versions)+1)
+	for aggregateID, version := range c.Versions {
+		versions[aggregateID] = version
+	}
+	if c.AggregateID != "" {
+		versions[c.AggregateID] = c.Version
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("invalid resumeCursor: missing versions")
+	}
+	return &ResumeCursor{
+		Timestamp: c.Timestamp,
+		Versions:  versions,
+	}, nil
+}
@@ -23,6 +23,9 @@ type GrpcGatewayClient interface {
 	GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (GrpcGateway_GetDevicesClient, error)
 	// Delete selected devices.
 	DeleteDevices(ctx context.Context, in *DeleteDevicesRequest, opts ...grpc.CallOption) (*DeleteDevicesResponse, error)
+	// Transfer selected devices to another owner.
+	// The new owner doesn't accept the devices, so the token of the user must contain the scope configured by deviceTransfer.requiredScope of the identity-store.
+	TransferDevices(ctx context.Context, in *TransferDevicesRequest, opts ...grpc.CallOption) (*TransferDevicesResponse, error)
 	// Get resource links of devices.
 	GetResourceLinks(ctx context.Context, in *GetResourceLinksRequest, opts ...grpc.CallOption) (GrpcGateway_GetResourceLinksClient, error)
 	// Get resource from the device.
@@ -31,6 +34,8 @@ type GrpcGatewayClient interface {
 	GetResources(ctx context.Context, in *GetResourcesRequest, opts ...grpc.CallOption) (GrpcGateway_GetResourcesClient, error)
 	// Update resource at the device.
 	UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UpdateResourceResponse, error)
+	// Update the resources matching the filters at the devices. The result of each update is streamed back as soon as it is available.
+	BatchUpdateResources(ctx context.Context, in *BatchUpdateResourcesRequest, opts ...grpc.CallOption) (GrpcGateway_BatchUpdateResourcesClient, error)
 	// When the client creates a subscription.
 	// Subscription doesn't guarantee that all events will be sent to the client. The client is responsible for synchronize events.
 	SubscribeToEvents(ctx context.Context, opts ...grpc.CallOption) (GrpcGateway_SubscribeToEventsClient, error)
@@ -103,6 +108,15 @@ func (c *grpcGatewayClient) DeleteDevices(ctx context.Context, in *DeleteDevices
 	return out, nil
 }
 
+func (c *grpcGatewayClient) TransferDevices(ctx context.Context, in *TransferDevicesRequest, opts ...grpc.CallOption) (*TransferDevicesResponse, error) {
+	out := new(TransferDevicesResponse)
+	err := c.cc.Invoke(ctx, "/grpcgateway.pb.GrpcGateway/TransferDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *grpcGatewayClient) GetResourceLinks(ctx context.Context, in *GetResourceLinksRequest, opts ...grpc.CallOption) (GrpcGateway_GetResourceLinksClient, error) {
 	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[1], "/grpcgateway.pb.GrpcGateway/GetResourceLinks", opts...)
 	if err != nil {
@@ -185,8 +199,40 @@ func (c *grpcGatewayClient) UpdateResource(ctx context.Context, in *UpdateResour
 	return out, nil
 }
 
+func (c *grpcGatewayClient) BatchUpdateResources(ctx context.Context, in *BatchUpdateResourcesRequest, opts ...grpc.CallOption) (GrpcGateway_BatchUpdateResourcesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[3], "/grpcgateway.pb.GrpcGateway/BatchUpdateResources", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpcGatewayBatchUpdateResourcesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GrpcGateway_BatchUpdateResourcesClient interface {
+	Recv() (*BatchUpdateResourcesResponse, error)
+	grpc.ClientStream
+}
+
+type grpcGatewayBatchUpdateResourcesClient struct {
+	grpc.ClientStream
+}
+
+func (x *grpcGatewayBatchUpdateResourcesClient) Recv() (*BatchUpdateResourcesResponse, error) {
+	m := new(BatchUpdateResourcesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *grpcGatewayClient) SubscribeToEvents(ctx context.Context, opts ...grpc.CallOption) (GrpcGateway_SubscribeToEventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[3], "/grpcgateway.pb.GrpcGateway/SubscribeToEvents", opts...)
+	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[4], "/grpcgateway.pb.GrpcGateway/SubscribeToEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -253,7 +299,7 @@ func (c *grpcGatewayClient) UpdateDeviceMetadata(ctx context.Context, in *Update
 }
 
 func (c *grpcGatewayClient) GetPendingCommands(ctx context.Context, in *GetPendingCommandsRequest, opts ...grpc.CallOption) (GrpcGateway_GetPendingCommandsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[4], "/grpcgateway.pb.GrpcGateway/GetPendingCommands", opts...)
+	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[5], "/grpcgateway.pb.GrpcGateway/GetPendingCommands", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -303,7 +349,7 @@ func (c *grpcGatewayClient) CancelPendingMetadataUpdates(ctx context.Context, in
 }
 
 func (c *grpcGatewayClient) GetDevicesMetadata(ctx context.Context, in *GetDevicesMetadataRequest, opts ...grpc.CallOption) (GrpcGateway_GetDevicesMetadataClient, error) {
-	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[5], "/grpcgateway.pb.GrpcGateway/GetDevicesMetadata", opts...)
+	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[6], "/grpcgateway.pb.GrpcGateway/GetDevicesMetadata", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +381,7 @@ func (x *grpcGatewayGetDevicesMetadataClient) Recv() (*events.DeviceMetadataUpda
 }
 
 func (c *grpcGatewayClient) GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (GrpcGateway_GetEventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[6], "/grpcgateway.pb.GrpcGateway/GetEvents", opts...)
+	stream, err := c.cc.NewStream(ctx, &GrpcGateway_ServiceDesc.Streams[7], "/grpcgateway.pb.GrpcGateway/GetEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -374,6 +420,9 @@ type GrpcGatewayServer interface {
 	GetDevices(*GetDevicesRequest, GrpcGateway_GetDevicesServer) error
 	// Delete selected devices.
 	DeleteDevices(context.Context, *DeleteDevicesRequest) (*DeleteDevicesResponse, error)
+	// Transfer selected devices to another owner.
+	// The new owner doesn't accept the devices, so the token of the user must contain the scope configured by deviceTransfer.requiredScope of the identity-store.
+	TransferDevices(context.Context, *TransferDevicesRequest) (*TransferDevicesResponse, error)
 	// Get resource links of devices.
 	GetResourceLinks(*GetResourceLinksRequest, GrpcGateway_GetResourceLinksServer) error
 	// Get resource from the device.
@@ -382,6 +431,8 @@ type GrpcGatewayServer interface {
 	GetResources(*GetResourcesRequest, GrpcGateway_GetResourcesServer) error
 	// Update resource at the device.
 	UpdateResource(context.Context, *UpdateResourceRequest) (*UpdateResourceResponse, error)
+	// Update the resources matching the filters at the devices. The result of each update is streamed back as soon as it is available.
+	BatchUpdateResources(*BatchUpdateResourcesRequest, GrpcGateway_BatchUpdateResourcesServer) error
 	// When the client creates a subscription.
 	// Subscription doesn't guarantee that all events will be sent to the client. The client is responsible for synchronize events.
 	SubscribeToEvents(GrpcGateway_SubscribeToEventsServer) error
@@ -416,6 +467,9 @@ func (UnimplementedGrpcGatewayServer) GetDevices(*GetDevicesRequest, GrpcGateway
 func (UnimplementedGrpcGatewayServer) DeleteDevices(context.Context, *DeleteDevicesRequest) (*DeleteDevicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevices not implemented")
 }
+func (UnimplementedGrpcGatewayServer) TransferDevices(context.Context, *TransferDevicesRequest) (*TransferDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferDevices not implemented")
+}
 func (UnimplementedGrpcGatewayServer) GetResourceLinks(*GetResourceLinksRequest, GrpcGateway_GetResourceLinksServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetResourceLinks not implemented")
 }
@@ -428,6 +482,9 @@ func (UnimplementedGrpcGatewayServer) GetResources(*GetResourcesRequest, GrpcGat
 func (UnimplementedGrpcGatewayServer) UpdateResource(context.Context, *UpdateResourceRequest) (*UpdateResourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateResource not implemented")
 }
+func (UnimplementedGrpcGatewayServer) BatchUpdateResources(*BatchUpdateResourcesRequest, GrpcGateway_BatchUpdateResourcesServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchUpdateResources not implemented")
+}
 func (UnimplementedGrpcGatewayServer) SubscribeToEvents(GrpcGateway_SubscribeToEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeToEvents not implemented")
 }
@@ -510,6 +567,24 @@ func _GrpcGateway_DeleteDevices_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GrpcGateway_TransferDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrpcGatewayServer).TransferDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcgateway.pb.GrpcGateway/TransferDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrpcGatewayServer).TransferDevices(ctx, req.(*TransferDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GrpcGateway_GetResourceLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetResourceLinksRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -588,6 +663,27 @@ func _GrpcGateway_UpdateResource_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GrpcGateway_BatchUpdateResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchUpdateResourcesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GrpcGatewayServer).BatchUpdateResources(m, &grpcGatewayBatchUpdateResourcesServer{stream})
+}
+
+type GrpcGateway_BatchUpdateResourcesServer interface {
+	Send(*BatchUpdateResourcesResponse) error
+	grpc.ServerStream
+}
+
+type grpcGatewayBatchUpdateResourcesServer struct {
+	grpc.ServerStream
+}
+
+func (x *grpcGatewayBatchUpdateResourcesServer) Send(m *BatchUpdateResourcesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _GrpcGateway_SubscribeToEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(GrpcGatewayServer).SubscribeToEvents(&grpcGatewaySubscribeToEventsServer{stream})
 }
@@ -796,6 +892,10 @@ var GrpcGateway_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDevices",
 			Handler:    _GrpcGateway_DeleteDevices_Handler,
 		},
+		{
+			MethodName: "TransferDevices",
+			Handler:    _GrpcGateway_TransferDevices_Handler,
+		},
 		{
 			MethodName: "GetResourceFromDevice",
 			Handler:    _GrpcGateway_GetResourceFromDevice_Handler,
@@ -845,6 +945,11 @@ var GrpcGateway_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GrpcGateway_GetResources_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BatchUpdateResources",
+			Handler:       _GrpcGateway_BatchUpdateResources_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeToEvents",
 			Handler:       _GrpcGateway_SubscribeToEvents_Handler,
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maximal number of updates of the batch waiting for the device response at the same time
+const batchUpdateResourcesMaxInFlight = 64
+
+func (r *RequestHandler) getBatchUpdateResourceIDs(ctx context.Context, req *pb.BatchUpdateResourcesRequest) ([]*commands.ResourceId, error) {
+	rd, err := r.resourceDirectoryClient.GetResources(ctx, req.ToGetResourcesRequest())
+	if err != nil {
+		return nil, err
+	}
+	resourceIDs := make([]*commands.ResourceId, 0, 32)
+	for {
+		resp, err := rd.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		resourceIDs = append(resourceIDs, resp.GetData().GetResourceId())
+	}
+	return resourceIDs, nil
+}
+
+func (r *RequestHandler) batchUpdateResource(ctx context.Context, cmd *commands.UpdateResourceRequest) *pb.BatchUpdateResourcesResponse {
+	resp := pb.BatchUpdateResourcesResponse{
+		ResourceId:    cmd.GetResourceId(),
+		CorrelationId: cmd.GetCorrelationId(),
+	}
+	if cmd.GetTimeToLive() > 0 {
+		// the command of the offline device expires after timeToLive so don't wait for it longer
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cmd.GetTimeToLive()))
+		defer cancel()
+	}
+	updatedEvent, err := r.resourceAggregateClient.SyncUpdateResource(ctx, "*", cmd)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+			err = fmt.Errorf("timeToLive expired")
+		}
+		resp.Error = err.Error()
+		return &resp
+	}
+	resp.Data = updatedEvent
+	if err = commands.CheckEventContent(updatedEvent); err != nil {
+		resp.Error = err.Error()
+	}
+	return &resp
+}
+
+func (r *RequestHandler) BatchUpdateResources(req *pb.BatchUpdateResourcesRequest, srv pb.GrpcGateway_BatchUpdateResourcesServer) error {
+	ctx := srv.Context()
+	if err := req.Validate(); err != nil {
+		return log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.InvalidArgument, "cannot batch update resources: %v", err))
+	}
+	batchID, err := uuid.NewRandom()
+	if err != nil {
+		return log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot batch update resources: %v", err))
+	}
+	resourceIDs, err := r.getBatchUpdateResourceIDs(ctx, req)
+	if err != nil {
+		return log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot batch update resources: cannot retrieve resources: %v", err))
+	}
+
+	var wg sync.WaitGroup
+	var sendMutex sync.Mutex
+	var sendErr error
+	sem := make(chan struct{}, batchUpdateResourcesMaxInFlight)
+	for idx, resourceID := range resourceIDs {
+		cmd := req.ToRACommand(ctx, resourceID, pb.MakeBatchCorrelationID(batchID.String(), idx))
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resp := r.batchUpdateResource(ctx, cmd)
+			sendMutex.Lock()
+			defer sendMutex.Unlock()
+			if sendErr != nil {
+				return
+			}
+			sendErr = srv.Send(resp)
+		}()
+	}
+	wg.Wait()
+	if sendErr != nil {
+		return log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot batch update resources: cannot send result: %v", sendErr))
+	}
+	if ctx.Err() != nil {
+		return log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Canceled, "cannot batch update resources: %v", ctx.Err()))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/schema/interfaces"
+	"github.com/plgd-dev/device/test/resource/types"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	pbTest "github.com/plgd-dev/hub/test/pb"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestRequestHandler_BatchUpdateResources(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	makeContent := func(power int) *pb.Content {
+		return &pb.Content{
+			ContentType: message.AppOcfCbor.String(),
+			Data: test.EncodeToCbor(t, map[string]interface{}{
+				"power": power,
+			}),
+		}
+	}
+	type args struct {
+		req *pb.BatchUpdateResourcesRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*events.ResourceUpdated
+		wantErr bool
+	}{
+		{
+			name: "invalid filter",
+			args: args{
+				req: &pb.BatchUpdateResourcesRequest{
+					DeviceIdFilter: []string{deviceID},
+					Content:        makeContent(1),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no matching resource",
+			args: args{
+				req: &pb.BatchUpdateResourcesRequest{
+					TypeFilter: []string{"unknown"},
+					Content:    makeContent(1),
+				},
+			},
+		},
+		{
+			name: "valid",
+			args: args{
+				req: &pb.BatchUpdateResourcesRequest{
+					DeviceIdFilter: []string{deviceID},
+					TypeFilter:     []string{types.CORE_LIGHT},
+					TimeToLive:     int64(10 * time.Second),
+					Content:        makeContent(1),
+				},
+			},
+			want: []*events.ResourceUpdated{
+				pbTest.MakeResourceUpdated(deviceID, test.TestResourceLightInstanceHref("1")),
+			},
+		},
+		{
+			name: "revert update",
+			args: args{
+				req: &pb.BatchUpdateResourcesRequest{
+					ResourceInterface: interfaces.OC_IF_BASELINE,
+					ResourceIdFilter:  []string{commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")).ToString()},
+					Content:           makeContent(0),
+				},
+			},
+			want: []*events.ResourceUpdated{
+				pbTest.MakeResourceUpdated(deviceID, test.TestResourceLightInstanceHref("1")),
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := c.BatchUpdateResources(ctx, tt.args.req)
+			require.NoError(t, err)
+			var got []*events.ResourceUpdated
+			var batchID string
+			for {
+				resp, err := client.Recv()
+				if err == io.EOF {
+					break
+				}
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Empty(t, resp.GetError())
+				idx := strings.LastIndex(resp.GetCorrelationId(), ".")
+				require.Greater(t, idx, 0)
+				if batchID == "" {
+					batchID = resp.GetCorrelationId()[:idx]
+				}
+				require.Equal(t, batchID, resp.GetCorrelationId()[:idx])
+				require.Equal(t, resp.GetCorrelationId(), resp.GetData().GetAuditContext().GetCorrelationId())
+				got = append(got, resp.GetData())
+			}
+			require.False(t, tt.wantErr)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				pbTest.CmpResourceUpdated(t, tt.want[i], got[i])
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 )
 
@@ -29,12 +31,17 @@ func (c *Config) Validate() error {
 
 // Config represent application configuration
 type APIsConfig struct {
-	GRPC GRPCConfig `yaml:"grpc" json:"grpc"`
+	GRPC    GRPCConfig     `yaml:"grpc" json:"grpc"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 type GRPCConfig struct {
 	OwnerCacheExpiration   time.Duration `yaml:"ownerCacheExpiration" json:"ownerCacheExpiration"`
 	SubscriptionBufferSize int           `yaml:"subscriptionBufferSize" json:"subscriptionBufferSize"`
+	// SubscriptionResumeSkew is the maximal delay between the timestamp of the event and its delivery by the event bus, events stored
+	// up to the skew before the resume cursor of the subscription are replayed again. The cursor contains versions of the aggregates
+	// delivered within the skew, so they are not sent twice.
+	SubscriptionResumeSkew time.Duration `yaml:"subscriptionResumeSkew" json:"subscriptionResumeSkew"`
 	server.Config          `yaml:",inline" json:",inline"`
 }
 
@@ -45,6 +52,9 @@ func (c *GRPCConfig) Validate() error {
 	if c.SubscriptionBufferSize < 0 {
 		return fmt.Errorf("subscriptionBufferSize('%v')", c.SubscriptionBufferSize)
 	}
+	if c.SubscriptionResumeSkew < 0 {
+		return fmt.Errorf("subscriptionResumeSkew('%v')", c.SubscriptionResumeSkew)
+	}
 	return c.Config.Validate()
 }
 
@@ -52,25 +62,35 @@ func (c *APIsConfig) Validate() error {
 	if err := c.GRPC.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
 type IdentityStoreConfig struct {
 	Connection client.Config `yaml:"grpc" json:"grpc"`
+	// ServiceAccountVerificationInterval is the interval of the verification of the tokens of the service accounts by the identity-store,
+	// revoked service accounts and rotated keys are rejected at most after the interval. Zero value verifies each request.
+	ServiceAccountVerificationInterval time.Duration `yaml:"serviceAccountVerificationInterval" json:"serviceAccountVerificationInterval"`
 }
 
 func (c *IdentityStoreConfig) Validate() error {
 	if err := c.Connection.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if c.ServiceAccountVerificationInterval < 0 {
+		return fmt.Errorf("serviceAccountVerificationInterval('%v')", c.ServiceAccountVerificationInterval)
+	}
 	return nil
 }
 
 type ClientsConfig struct {
-	IdentityStore     IdentityStoreConfig `yaml:"identityStore" json:"identityStore"`
-	Eventbus          EventBusConfig      `yaml:"eventBus" json:"eventBus"`
-	ResourceAggregate GrpcServerConfig    `yaml:"resourceAggregate" json:"resourceAggregate"`
-	ResourceDirectory GrpcServerConfig    `yaml:"resourceDirectory" json:"resourceDirectory"`
+	IdentityStore          IdentityStoreConfig  `yaml:"identityStore" json:"identityStore"`
+	Eventbus               EventBusConfig       `yaml:"eventBus" json:"eventBus"`
+	ResourceAggregate      GrpcServerConfig     `yaml:"resourceAggregate" json:"resourceAggregate"`
+	ResourceDirectory      GrpcServerConfig     `yaml:"resourceDirectory" json:"resourceDirectory"`
+	OpenTelemetryCollector opentelemetry.Config `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 type EventBusConfig struct {
@@ -98,6 +118,9 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.ResourceDirectory.Validate(); err != nil {
 		return fmt.Errorf("resourceDirectory.%w", err)
 	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
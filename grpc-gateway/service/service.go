@@ -6,8 +6,11 @@ import (
 
 	"github.com/panjf2000/ants/v2"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	isClient "github.com/plgd-dev/hub/identity-store/client"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 )
 
@@ -20,20 +23,45 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 	if err != nil {
 		return nil, fmt.Errorf("cannot create validator: %w", err)
 	}
+	// keys of the service accounts are exchanged for their tokens and the tokens are verified by the identity-store
+	idClient, closeIdClient, err := newIdentityStoreClient(config.Clients.IdentityStore, logger)
+	if err != nil {
+		validator.Close()
+		return nil, fmt.Errorf("cannot create identity-store client: %w", err)
+	}
+	serviceAccountTokens := isClient.NewServiceAccountTokens(idClient, config.Clients.IdentityStore.ServiceAccountVerificationInterval)
 	method := "/" + pb.GrpcGateway_ServiceDesc.ServiceName + "/GetHubConfiguration"
-	interceptor := server.NewAuth(validator, server.WithWhiteListedMethods(method))
+	interceptor := server.NewAuth(validator, server.WithWhiteListedMethods(method), server.WithRequiredScopes(config.APIs.GRPC.Authorization.RequiredScopes),
+		server.WithTokenExchange(serviceAccountTokens.Exchange), server.WithTokenVerification(serviceAccountTokens.Verify), server.WithServiceAccountScopes())
 	opts, err := server.MakeDefaultOptions(interceptor, logger)
 	if err != nil {
+		closeIdClient()
 		validator.Close()
 		return nil, fmt.Errorf("cannot create grpc server options: %w", err)
 	}
 	server, err := server.New(config.APIs.GRPC.Config, logger, opts...)
 
 	if err != nil {
+		closeIdClient()
 		validator.Close()
 		return nil, err
 	}
 	server.AddCloseFunc(validator.Close)
+	server.AddCloseFunc(closeIdClient)
+
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	server.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "grpc-gateway", logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	server.AddCloseFunc(otelProvider.Close)
 
 	pool, err := ants.NewPool(config.Clients.Eventbus.GoPoolSize)
 	if err != nil {
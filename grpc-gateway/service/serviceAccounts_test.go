@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+func getDevicesCode(ctx context.Context, c pb.GrpcGatewayClient, token string) codes.Code {
+	client, err := c.GetDevices(kitNetGrpc.CtxWithToken(ctx, token), &pb.GetDevicesRequest{})
+	if err != nil {
+		return status.Code(err)
+	}
+	for {
+		_, err := client.Recv()
+		if errors.Is(err, io.EOF) {
+			return codes.OK
+		}
+		if err != nil {
+			return status.Code(err)
+		}
+	}
+}
+
+func TestServiceAccounts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUpServiceAccounts(ctx, t)
+	defer tearDown()
+
+	tlsCfg := &tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	}
+	isConn, err := grpc.Dial(testCfg.IDENTITY_STORE_HOST, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	require.NoError(t, err)
+	defer func() {
+		_ = isConn.Close()
+	}()
+	isClient := pbIS.NewIdentityStoreClient(isConn)
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := pb.NewGrpcGatewayClient(conn)
+
+	userCtx := kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+	created, err := isClient.CreateServiceAccount(userCtx, &pbIS.CreateServiceAccountRequest{
+		Name:   "reader",
+		Scopes: []string{"r:deviceinformation:*"},
+	})
+	require.NoError(t, err)
+	key := created.GetKey()
+	token, err := isClient.GetServiceAccountToken(ctx, &pbIS.GetServiceAccountTokenRequest{Key: key})
+	require.NoError(t, err)
+
+	// the key and the token of the service account are accepted for the read request
+	require.Equal(t, codes.OK, getDevicesCode(ctx, c, key))
+	require.Equal(t, codes.OK, getDevicesCode(ctx, c, token.GetAccessToken()))
+
+	// the service account without the write scope cannot modify the data
+	_, err = c.DeleteDevices(kitNetGrpc.CtxWithToken(ctx, key), &pb.DeleteDevicesRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the previous key and the token issued for it are rejected after the rotation
+	rotated, err := isClient.RotateServiceAccountKey(userCtx, &pbIS.RotateServiceAccountKeyRequest{
+		ServiceAccountId: created.GetServiceAccount().GetId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, codes.Unauthenticated, getDevicesCode(ctx, c, key))
+	require.Equal(t, codes.Unauthenticated, getDevicesCode(ctx, c, token.GetAccessToken()))
+	rotatedKey := rotated.GetKey()
+	require.Equal(t, codes.OK, getDevicesCode(ctx, c, rotatedKey))
+
+	// the key of the revoked service account is rejected
+	_, err = isClient.RevokeServiceAccounts(userCtx, &pbIS.RevokeServiceAccountsRequest{
+		ServiceAccountIds: []string{created.GetServiceAccount().GetId()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, codes.Unauthenticated, getDevicesCode(ctx, c, rotatedKey))
+}
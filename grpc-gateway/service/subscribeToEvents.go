@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/grpc-gateway/subscription"
@@ -16,24 +17,48 @@ import (
 type subscriptions struct {
 	owner              string
 	send               func(e *pb.Event) error
+	sendReplayed       func(ctx context.Context, e *pb.Event) error
 	subscriptionsCache *subscription.SubscriptionsCache
+	rdClient           pb.GrpcGatewayClient
+	resumeSkew         time.Duration
 
-	subs map[string]*subscription.Sub
+	ctx     context.Context
+	cancel  context.CancelFunc
+	replays sync.WaitGroup
+
+	mutex         sync.Mutex
+	subs          map[string]*subscription.Sub
+	cancelReplays map[string]context.CancelFunc
 }
 
 func newSubscriptions(
+	ctx context.Context,
 	owner string,
 	subscriptionsCache *subscription.SubscriptionsCache,
-	send func(e *pb.Event) error) *subscriptions {
+	rdClient pb.GrpcGatewayClient,
+	resumeSkew time.Duration,
+	send func(e *pb.Event) error,
+	sendReplayed func(ctx context.Context, e *pb.Event) error) *subscriptions {
+	ctx, cancel := context.WithCancel(ctx)
 	return &subscriptions{
 		owner:              owner,
 		subs:               make(map[string]*subscription.Sub),
+		cancelReplays:      make(map[string]context.CancelFunc),
 		send:               send,
+		sendReplayed:       sendReplayed,
 		subscriptionsCache: subscriptionsCache,
+		rdClient:           rdClient,
+		resumeSkew:         resumeSkew,
+		ctx:                ctx,
+		cancel:             cancel,
 	}
 }
 
 func (s *subscriptions) close() {
+	s.cancel()
+	s.replays.Wait()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	for _, sub := range s.subs {
 		err := sub.Close()
 		if err != nil {
@@ -42,8 +67,64 @@ func (s *subscriptions) close() {
 	}
 }
 
+// pullOut removes the subscription and stops its replay.
+func (s *subscriptions) pullOut(subscriptionID string) (*subscription.Sub, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sub, ok := s.subs[subscriptionID]
+	if !ok {
+		return nil, false
+	}
+	delete(s.subs, subscriptionID)
+	if cancelReplay, ok := s.cancelReplays[subscriptionID]; ok {
+		cancelReplay()
+		delete(s.cancelReplays, subscriptionID)
+	}
+	return sub, true
+}
+
+// replay sends the missed events of the subscription, it doesn't block processing of other requests of the stream.
+// The subscription is canceled when the replay fails.
+func (s *subscriptions) replay(sub *subscription.Sub, correlationID string) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.mutex.Lock()
+	s.cancelReplays[sub.Id()] = cancel
+	s.mutex.Unlock()
+	s.replays.Add(1)
+	go func() {
+		defer s.replays.Done()
+		defer cancel()
+		err := sub.Replay(ctx, s.rdClient, func(e *pb.Event) error {
+			return s.sendReplayed(ctx, e)
+		})
+		s.mutex.Lock()
+		delete(s.cancelReplays, sub.Id())
+		s.mutex.Unlock()
+		if err == nil {
+			return
+		}
+		if _, ok := s.pullOut(sub.Id()); !ok {
+			// subscription was canceled during the replay
+			return
+		}
+		log.Errorf("cannot replay events of subscription('%v'): %w", sub.Id(), err)
+		if errClose := sub.Close(); errClose != nil {
+			log.Errorf("cannot close subscription('%v'): %w", sub.Id(), errClose)
+		}
+		_ = s.send(&pb.Event{
+			SubscriptionId: sub.Id(),
+			CorrelationId:  correlationID,
+			Type: &pb.Event_SubscriptionCanceled_{
+				SubscriptionCanceled: &pb.Event_SubscriptionCanceled{
+					Reason: err.Error(),
+				},
+			},
+		})
+	}()
+}
+
 func (s *subscriptions) createSubscription(req *pb.SubscribeToEvents) error {
-	sub := subscription.New(s.send, req.GetCorrelationId(), req.GetCreateSubscription())
+	sub := subscription.New(s.send, req.GetCorrelationId(), s.resumeSkew, req.GetCreateSubscription())
 	err := s.send(&pb.Event{
 		SubscriptionId: sub.Id(),
 		CorrelationId:  req.GetCorrelationId(),
@@ -74,12 +155,15 @@ func (s *subscriptions) createSubscription(req *pb.SubscribeToEvents) error {
 		})
 		return err
 	}
+	s.mutex.Lock()
 	s.subs[sub.Id()] = sub
+	s.mutex.Unlock()
+	s.replay(sub, req.GetCorrelationId())
 	return nil
 }
 
-func (s *subscriptions) cancelSubscription(ctx context.Context, req *pb.SubscribeToEvents) error {
-	sub, ok := s.subs[req.GetCancelSubscription().GetSubscriptionId()]
+func (s *subscriptions) cancelSubscription(req *pb.SubscribeToEvents) error {
+	sub, ok := s.pullOut(req.GetCancelSubscription().GetSubscriptionId())
 	if !ok {
 		err := fmt.Errorf("cannot cancel subscription('%v'): not found", req.GetCancelSubscription().GetSubscriptionId())
 		err2 := s.send(&pb.Event{
@@ -99,7 +183,6 @@ func (s *subscriptions) cancelSubscription(ctx context.Context, req *pb.Subscrib
 		}
 		return err
 	}
-	delete(s.subs, req.GetCancelSubscription().GetSubscriptionId())
 	err := sub.Close()
 	err2 := s.send(&pb.Event{
 		SubscriptionId: sub.Id(),
@@ -128,7 +211,7 @@ func (s *subscriptions) cancelSubscription(ctx context.Context, req *pb.Subscrib
 	return err
 }
 
-func processNextRequest(ctx context.Context, srv pb.GrpcGateway_SubscribeToEventsServer, subs *subscriptions, send func(e *pb.Event) error) (bool, error) {
+func processNextRequest(srv pb.GrpcGateway_SubscribeToEventsServer, subs *subscriptions, send func(e *pb.Event) error) (bool, error) {
 	req, err := srv.Recv()
 	if err == io.EOF {
 		return false, nil
@@ -143,7 +226,7 @@ func processNextRequest(ctx context.Context, srv pb.GrpcGateway_SubscribeToEvent
 			log.Errorf("cannot create subscription: %w", err)
 		}
 	case (*pb.SubscribeToEvents_CancelSubscription_):
-		err := subs.cancelSubscription(ctx, req)
+		err := subs.cancelSubscription(req)
 		if err != nil {
 			log.Errorf("cannot cancel subscription: %w", err)
 		}
@@ -217,16 +300,26 @@ func (r *RequestHandler) SubscribeToEvents(srv pb.GrpcGateway_SubscribeToEventsS
 		return nil
 	}
 
+	// replayed events are not dropped, the replay waits for the grpc client
+	sendReplayed := func(replayCtx context.Context, e *pb.Event) error {
+		select {
+		case <-replayCtx.Done():
+			return replayCtx.Err()
+		case sendChan <- e:
+		}
+		return nil
+	}
+
 	owner, err := grpc.OwnerFromTokenMD(ctx, r.ownerCache.OwnerClaim())
 	if err != nil {
 		return err
 	}
 
-	subs := newSubscriptions(owner, r.subscriptionsCache, send)
+	subs := newSubscriptions(ctx, owner, r.subscriptionsCache, r.resourceDirectoryClient, r.config.APIs.GRPC.SubscriptionResumeSkew, send, sendReplayed)
 	defer subs.close()
 
 	for {
-		ok, err := processNextRequest(ctx, srv, subs, send)
+		ok, err := processNextRequest(srv, subs, send)
 		if err != nil {
 			return err
 		}
@@ -31,6 +31,7 @@ import (
 	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
 	pbTest "github.com/plgd-dev/hub/test/pb"
 	serviceTest "github.com/plgd-dev/hub/test/service"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -805,3 +806,123 @@ func TestRequestHandlerIssue270(t *testing.T) {
 		}
 	}
 }
+
+// subscribeToLightChanges creates the subscription of the changes of the light resource and waits for its confirmation.
+func subscribeToLightChanges(ctx context.Context, t *testing.T, c pb.GrpcGatewayClient, deviceID, resumeCursor string) pb.GrpcGateway_SubscribeToEventsClient {
+	client, err := c.SubscribeToEvents(ctx)
+	require.NoError(t, err)
+	err = client.Send(&pb.SubscribeToEvents{
+		CorrelationId: "resume",
+		Action: &pb.SubscribeToEvents_CreateSubscription_{
+			CreateSubscription: &pb.SubscribeToEvents_CreateSubscription{
+				ResourceIdFilter: []string{commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")).ToString()},
+				EventFilter: []pb.SubscribeToEvents_CreateSubscription_Event{
+					pb.SubscribeToEvents_CreateSubscription_RESOURCE_CHANGED,
+				},
+				ResumeCursor: resumeCursor,
+			},
+		},
+	})
+	require.NoError(t, err)
+	ev, err := client.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.Event_OperationProcessed_ErrorStatus_OK, ev.GetOperationProcessed().GetErrorStatus().GetCode())
+	return client
+}
+
+func updateLightPower(ctx context.Context, t *testing.T, c pb.GrpcGatewayClient, deviceID string, power uint64) {
+	_, err := c.UpdateResource(ctx, &pb.UpdateResourceRequest{
+		ResourceId: commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")),
+		Content: &pb.Content{
+			ContentType: message.AppOcfCbor.String(),
+			Data: test.EncodeToCbor(t, map[string]interface{}{
+				"power": power,
+			}),
+		},
+	})
+	require.NoError(t, err)
+}
+
+// waitForLightPower receives the changes of the light resource until the power is set to the value, it returns the received
+// events and the powers of the changes.
+func waitForLightPower(t *testing.T, client pb.GrpcGateway_SubscribeToEventsClient, power uint64) ([]*pb.Event, []uint64) {
+	var evs []*pb.Event
+	var powers []uint64
+	for {
+		ev, err := client.Recv()
+		require.NoError(t, err)
+		changed := ev.GetResourceChanged()
+		require.NotNil(t, changed, "unexpected event %v", ev)
+		require.NotEmpty(t, ev.GetResumeCursor())
+		evs = append(evs, ev)
+		var light map[string]interface{}
+		err = cbor.Decode(changed.GetContent().GetData(), &light)
+		require.NoError(t, err)
+		v, ok := light["power"].(uint64)
+		require.True(t, ok)
+		// the device can notify the same content repeatedly
+		if len(powers) == 0 || powers[len(powers)-1] != v {
+			powers = append(powers, v)
+		}
+		if v == power {
+			return evs, powers
+		}
+	}
+}
+
+// requireIncreasingVersions checks that the events of the aggregate are delivered once and in order.
+func requireIncreasingVersions(t *testing.T, version uint64, evs []*pb.Event) uint64 {
+	for _, ev := range evs {
+		require.Greater(t, ev.GetResourceChanged().GetEventMetadata().GetVersion(), version)
+		version = ev.GetResourceChanged().GetEventMetadata().GetVersion()
+	}
+	return version
+}
+
+func TestRequestHandlerSubscribeToEventsResume(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	ctx, cancel := context.WithTimeout(context.Background(), config.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := serviceTest.SetUp(ctx, t)
+	defer tearDown()
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(config.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := pb.NewGrpcGatewayClient(conn)
+
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, config.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	client := subscribeToLightChanges(subCtx, t, c, deviceID, "")
+	updateLightPower(ctx, t, c, deviceID, 1)
+	evs, _ := waitForLightPower(t, client, 1)
+	last := evs[len(evs)-1]
+	// the client disconnects
+	subCancel()
+
+	// changes made while the client is disconnected are replayed after the reconnection
+	updateLightPower(ctx, t, c, deviceID, 2)
+	updateLightPower(ctx, t, c, deviceID, 3)
+	client = subscribeToLightChanges(ctx, t, c, deviceID, last.GetResumeCursor())
+	defer func() {
+		_ = client.CloseSend()
+	}()
+	evs, powers := waitForLightPower(t, client, 3)
+	// changes of the resource up to the cursor are not delivered again and no change is missed
+	version := requireIncreasingVersions(t, last.GetResourceChanged().GetEventMetadata().GetVersion(), evs)
+	require.Equal(t, []uint64{2, 3}, powers)
+
+	// live events are delivered after the replay
+	updateLightPower(ctx, t, c, deviceID, 0)
+	evs, powers = waitForLightPower(t, client, 0)
+	requireIncreasingVersions(t, version, evs)
+	require.Equal(t, []uint64{0}, powers)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/strings"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"google.golang.org/grpc/codes"
+)
+
+func (r *RequestHandler) TransferDevices(ctx context.Context, req *pb.TransferDevicesRequest) (*pb.TransferDevicesResponse, error) {
+	// get unique non-empty ids
+	deviceIds, _ := strings.Split(strings.Unique(req.DeviceIdFilter), func(s string) bool {
+		return s != ""
+	})
+
+	// ResourceAggregate reassigns the owner in the IdentityStore and republishes the state of the devices
+	cmdRA := commands.TransferDevicesRequest{
+		DeviceIds: deviceIds,
+		NewOwner:  req.GetNewOwner(),
+	}
+	respRA, err := r.resourceAggregateClient.TransferDevices(ctx, &cmdRA)
+	if err != nil {
+		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot transfer devices: %v", err))
+	}
+	_, notTransferred := partitionDeletedDevices(deviceIds, respRA.GetDeviceIds())
+	for _, deviceId := range notTransferred {
+		log.Debugf("failed to transfer device('%v')", deviceId)
+	}
+
+	return &pb.TransferDevicesResponse{
+		DeviceIds: respRA.GetDeviceIds(),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package subscription
+
+import (
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+)
+
+var bitmaskToGetEventsFilter = map[FilterBitmask]pb.GetEventsRequest_Event{
+	FilterBitmaskResourceCreatePending:       pb.GetEventsRequest_RESOURCE_CREATE_PENDING,
+	FilterBitmaskResourceCreated:             pb.GetEventsRequest_RESOURCE_CREATED,
+	FilterBitmaskResourceRetrievePending:     pb.GetEventsRequest_RESOURCE_RETRIEVE_PENDING,
+	FilterBitmaskResourceRetrieved:           pb.GetEventsRequest_RESOURCE_RETRIEVED,
+	FilterBitmaskResourceUpdatePending:       pb.GetEventsRequest_RESOURCE_UPDATE_PENDING,
+	FilterBitmaskResourceUpdated:             pb.GetEventsRequest_RESOURCE_UPDATED,
+	FilterBitmaskResourceDeletePending:       pb.GetEventsRequest_RESOURCE_DELETE_PENDING,
+	FilterBitmaskResourceDeleted:             pb.GetEventsRequest_RESOURCE_DELETED,
+	FilterBitmaskDeviceMetadataUpdatePending: pb.GetEventsRequest_DEVICE_METADATA_UPDATE_PENDING,
+	FilterBitmaskDeviceMetadataUpdated:       pb.GetEventsRequest_DEVICE_METADATA_UPDATED,
+	FilterBitmaskResourceChanged:             pb.GetEventsRequest_RESOURCE_CHANGED,
+	FilterBitmaskResourcesPublished:          pb.GetEventsRequest_RESOURCE_LINKS_PUBLISHED,
+	FilterBitmaskResourcesUnpublished:        pb.GetEventsRequest_RESOURCE_LINKS_UNPUBLISHED,
+}
+
+// BitmaskToGetEventsFilter converts the bitmask to the event filter of GetEvents, registration events are not stored in the eventstore
+// so they are skipped.
+func BitmaskToGetEventsFilter(bitmask FilterBitmask) []pb.GetEventsRequest_Event {
+	res := make([]pb.GetEventsRequest_Event, 0, len(bitmaskToGetEventsFilter))
+	for bit, val := range bitmaskToGetEventsFilter {
+		if bitmask&bit == 0 {
+			continue
+		}
+		res = append(res, val)
+	}
+	return res
+}
+
+// GetEventsResponseToEvent converts the event loaded from the eventstore to the event of the subscription. Snapshots are not
+// delivered by subscriptions so nil is returned for them.
+func GetEventsResponseToEvent(resp *pb.GetEventsResponse) (*pb.Event, FilterBitmask) {
+	switch v := resp.GetType().(type) {
+	case *pb.GetEventsResponse_ResourceLinksPublished:
+		return &pb.Event{Type: &pb.Event_ResourcePublished{ResourcePublished: v.ResourceLinksPublished}}, FilterBitmaskResourcesPublished
+	case *pb.GetEventsResponse_ResourceLinksUnpublished:
+		return &pb.Event{Type: &pb.Event_ResourceUnpublished{ResourceUnpublished: v.ResourceLinksUnpublished}}, FilterBitmaskResourcesUnpublished
+	case *pb.GetEventsResponse_ResourceChanged:
+		return &pb.Event{Type: &pb.Event_ResourceChanged{ResourceChanged: v.ResourceChanged}}, FilterBitmaskResourceChanged
+	case *pb.GetEventsResponse_ResourceUpdatePending:
+		return &pb.Event{Type: &pb.Event_ResourceUpdatePending{ResourceUpdatePending: v.ResourceUpdatePending}}, FilterBitmaskResourceUpdatePending
+	case *pb.GetEventsResponse_ResourceUpdated:
+		return &pb.Event{Type: &pb.Event_ResourceUpdated{ResourceUpdated: v.ResourceUpdated}}, FilterBitmaskResourceUpdated
+	case *pb.GetEventsResponse_ResourceRetrievePending:
+		return &pb.Event{Type: &pb.Event_ResourceRetrievePending{ResourceRetrievePending: v.ResourceRetrievePending}}, FilterBitmaskResourceRetrievePending
+	case *pb.GetEventsResponse_ResourceRetrieved:
+		return &pb.Event{Type: &pb.Event_ResourceRetrieved{ResourceRetrieved: v.ResourceRetrieved}}, FilterBitmaskResourceRetrieved
+	case *pb.GetEventsResponse_ResourceDeletePending:
+		return &pb.Event{Type: &pb.Event_ResourceDeletePending{ResourceDeletePending: v.ResourceDeletePending}}, FilterBitmaskResourceDeletePending
+	case *pb.GetEventsResponse_ResourceDeleted:
+		return &pb.Event{Type: &pb.Event_ResourceDeleted{ResourceDeleted: v.ResourceDeleted}}, FilterBitmaskResourceDeleted
+	case *pb.GetEventsResponse_ResourceCreatePending:
+		return &pb.Event{Type: &pb.Event_ResourceCreatePending{ResourceCreatePending: v.ResourceCreatePending}}, FilterBitmaskResourceCreatePending
+	case *pb.GetEventsResponse_ResourceCreated:
+		return &pb.Event{Type: &pb.Event_ResourceCreated{ResourceCreated: v.ResourceCreated}}, FilterBitmaskResourceCreated
+	case *pb.GetEventsResponse_DeviceMetadataUpdatePending:
+		return &pb.Event{Type: &pb.Event_DeviceMetadataUpdatePending{DeviceMetadataUpdatePending: v.DeviceMetadataUpdatePending}}, FilterBitmaskDeviceMetadataUpdatePending
+	case *pb.GetEventsResponse_DeviceMetadataUpdated:
+		return &pb.Event{Type: &pb.Event_DeviceMetadataUpdated{DeviceMetadataUpdated: v.DeviceMetadataUpdated}}, FilterBitmaskDeviceMetadataUpdated
+	}
+	return nil, 0
+}
+
+// aggregateEvent returns the event of the aggregate carried by the subscription event, for registration events it returns nil.
+func aggregateEvent(e *pb.Event) eventstore.Event {
+	switch v := e.GetType().(type) {
+	case *pb.Event_ResourcePublished:
+		return v.ResourcePublished
+	case *pb.Event_ResourceUnpublished:
+		return v.ResourceUnpublished
+	case *pb.Event_ResourceChanged:
+		return v.ResourceChanged
+	case *pb.Event_ResourceUpdatePending:
+		return v.ResourceUpdatePending
+	case *pb.Event_ResourceUpdated:
+		return v.ResourceUpdated
+	case *pb.Event_ResourceRetrievePending:
+		return v.ResourceRetrievePending
+	case *pb.Event_ResourceRetrieved:
+		return v.ResourceRetrieved
+	case *pb.Event_ResourceDeletePending:
+		return v.ResourceDeletePending
+	case *pb.Event_ResourceDeleted:
+		return v.ResourceDeleted
+	case *pb.Event_ResourceCreatePending:
+		return v.ResourceCreatePending
+	case *pb.Event_ResourceCreated:
+		return v.ResourceCreated
+	case *pb.Event_DeviceMetadataUpdatePending:
+		return v.DeviceMetadataUpdatePending
+	case *pb.Event_DeviceMetadataUpdated:
+		return v.DeviceMetadataUpdated
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package subscription_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	subscription "github.com/plgd-dev/hub/grpc-gateway/subscription"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type testGetEventsClient struct {
+	grpc.ClientStream
+	events []*pb.GetEventsResponse
+	onRecv func()
+}
+
+func (c *testGetEventsClient) Recv() (*pb.GetEventsResponse, error) {
+	if c.onRecv != nil {
+		c.onRecv()
+		c.onRecv = nil
+	}
+	if len(c.events) == 0 {
+		return nil, io.EOF
+	}
+	ev := c.events[0]
+	c.events = c.events[1:]
+	return ev, nil
+}
+
+type testGrpcGatewayClient struct {
+	pb.GrpcGatewayClient
+	client *testGetEventsClient
+	req    *pb.GetEventsRequest
+}
+
+func (c *testGrpcGatewayClient) GetEvents(ctx context.Context, in *pb.GetEventsRequest, opts ...grpc.CallOption) (pb.GrpcGateway_GetEventsClient, error) {
+	c.req = in
+	return c.client, nil
+}
+
+func makeTestResourceChangedWithTimestamp(resourceID *commands.ResourceId, version uint64, timestamp int64) *events.ResourceChanged {
+	return &events.ResourceChanged{
+		ResourceId: resourceID,
+		Status:     commands.Status_OK,
+		EventMetadata: &events.EventMetadata{
+			Version:   version,
+			Timestamp: timestamp,
+		},
+	}
+}
+
+func makeTestResourceChanged(resourceID *commands.ResourceId, version uint64) *events.ResourceChanged {
+	return makeTestResourceChangedWithTimestamp(resourceID, version, int64(version))
+}
+
+func TestSubReplay(t *testing.T) {
+	resourceID := commands.NewResourceID("deviceID", "/light/1")
+
+	var lock sync.Mutex
+	var sent []*pb.Event
+	send := func(e *pb.Event) error {
+		lock.Lock()
+		defer lock.Unlock()
+		sent = append(sent, e)
+		return nil
+	}
+	sub := subscription.New(send, "correlationID", 0, &pb.SubscribeToEvents_CreateSubscription{
+		ResourceIdFilter: []string{resourceID.ToString()},
+		EventFilter:      []pb.SubscribeToEvents_CreateSubscription_Event{pb.SubscribeToEvents_CreateSubscription_RESOURCE_CHANGED},
+		ResumeTimestamp:  1,
+	})
+
+	rdClient := &testGrpcGatewayClient{
+		client: &testGetEventsClient{
+			events: []*pb.GetEventsResponse{
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChanged(resourceID, 1)}},
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChanged(resourceID, 2)}},
+			},
+		},
+	}
+	// live events received during the replay are buffered and the already replayed ones are skipped
+	rdClient.client.onRecv = func() {
+		for _, v := range []uint64{2, 3} {
+			err := sub.ProcessEvent(&pb.Event{Type: &pb.Event_ResourceChanged{ResourceChanged: makeTestResourceChanged(resourceID, v)}}, subscription.FilterBitmaskResourceChanged)
+			require.NoError(t, err)
+		}
+		require.Empty(t, sent)
+	}
+
+	err := sub.Replay(context.Background(), rdClient, send)
+	require.NoError(t, err)
+	require.Equal(t, []string{"deviceID"}, rdClient.req.GetDeviceIdFilter())
+	require.Equal(t, int64(1), rdClient.req.GetTimestampFilter())
+	require.Equal(t, []pb.GetEventsRequest_Event{pb.GetEventsRequest_RESOURCE_CHANGED}, rdClient.req.GetEventFilter())
+
+	require.Len(t, sent, 3)
+	for i, ev := range sent {
+		require.Equal(t, sub.Id(), ev.GetSubscriptionId())
+		require.Equal(t, uint64(i+1), ev.GetResourceChanged().GetEventMetadata().GetVersion())
+		cursor, err := pb.ParseResumeCursor(ev.GetResumeCursor())
+		require.NoError(t, err)
+		require.Equal(t, int64(i+1), cursor.Timestamp)
+		require.Equal(t, map[string]uint64{resourceID.ToUUID(): uint64(i + 1)}, cursor.Versions)
+	}
+
+	// after the replay the live events are sent directly
+	err = sub.ProcessEvent(&pb.Event{Type: &pb.Event_ResourceChanged{ResourceChanged: makeTestResourceChanged(resourceID, 4)}}, subscription.FilterBitmaskResourceChanged)
+	require.NoError(t, err)
+	require.Len(t, sent, 4)
+}
+
+func TestSubReplayInvalidCursor(t *testing.T) {
+	sub := subscription.New(func(e *pb.Event) error { return nil }, "correlationID", 0, &pb.SubscribeToEvents_CreateSubscription{
+		ResumeCursor: "invalid",
+	})
+	err := sub.Replay(context.Background(), &testGrpcGatewayClient{client: &testGetEventsClient{}}, func(e *pb.Event) error { return nil })
+	require.Error(t, err)
+}
+
+type aggregateVersion struct {
+	aggregateID string
+	version     uint64
+}
+
+func getSentVersions(sent []*pb.Event) []aggregateVersion {
+	got := make([]aggregateVersion, 0, len(sent))
+	for _, ev := range sent {
+		got = append(got, aggregateVersion{
+			aggregateID: ev.GetResourceChanged().GetResourceId().ToUUID(),
+			version:     ev.GetResourceChanged().GetEventMetadata().GetVersion(),
+		})
+	}
+	return got
+}
+
+func TestSubReplayFromCursor(t *testing.T) {
+	resourceID1 := commands.NewResourceID("deviceID", "/light/1")
+	resourceID2 := commands.NewResourceID("deviceID", "/light/2")
+	resourceID3 := commands.NewResourceID("deviceID", "/light/3")
+	cursor, err := pb.MakeResumeCursor(pb.ResumeCursor{
+		Timestamp: 100,
+		Versions: map[string]uint64{
+			resourceID1.ToUUID(): 5,
+			resourceID3.ToUUID(): 2,
+		},
+	})
+	require.NoError(t, err)
+
+	// live events are dropped, so the buffered events must be sent by the send function of the replay
+	sub := subscription.New(func(e *pb.Event) error {
+		return errors.New("event was dropped")
+	}, "correlationID", 50, &pb.SubscribeToEvents_CreateSubscription{
+		DeviceIdFilter: []string{"deviceID"},
+		ResumeCursor:   cursor,
+	})
+	var sent []*pb.Event
+	send := func(e *pb.Event) error {
+		sent = append(sent, e)
+		return nil
+	}
+
+	rdClient := &testGrpcGatewayClient{
+		client: &testGetEventsClient{
+			events: []*pb.GetEventsResponse{
+				// the event of the other aggregate with the lower timestamp was delivered by the event bus after the cursor
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID2, 1, 80)}},
+				// the event of the aggregate delivered within the skew before the cursor isn't sent again
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID3, 2, 90)}},
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID1, 5, 100)}},
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID1, 6, 110)}},
+			},
+		},
+	}
+	rdClient.client.onRecv = func() {
+		err := sub.ProcessEvent(&pb.Event{Type: &pb.Event_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID1, 7, 120)}}, subscription.FilterBitmaskResourceChanged)
+		require.NoError(t, err)
+	}
+
+	err = sub.Replay(context.Background(), rdClient, send)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), rdClient.req.GetTimestampFilter())
+	require.Empty(t, rdClient.req.GetContinuationToken())
+	require.Equal(t, []aggregateVersion{
+		{aggregateID: resourceID2.ToUUID(), version: 1},
+		{aggregateID: resourceID1.ToUUID(), version: 6},
+		{aggregateID: resourceID1.ToUUID(), version: 7},
+	}, getSentVersions(sent))
+
+	// the cursor continues the resume cursor and contains the aggregates delivered within the skew
+	last, err := pb.ParseResumeCursor(sent[len(sent)-1].GetResumeCursor())
+	require.NoError(t, err)
+	require.Equal(t, &pb.ResumeCursor{
+		Timestamp: 120,
+		Versions: map[string]uint64{
+			resourceID1.ToUUID(): 7,
+			resourceID2.ToUUID(): 1,
+			resourceID3.ToUUID(): 2,
+		},
+	}, last)
+}
+
+func TestSubReplayFromContinuationToken(t *testing.T) {
+	resourceID1 := commands.NewResourceID("deviceID", "/light/1")
+	resourceID2 := commands.NewResourceID("deviceID", "/light/2")
+	// the continuation token of GetEvents contains only the version of the aggregate of the event
+	cursor, err := pb.MakeContinuationToken(makeTestResourceChangedWithTimestamp(resourceID1, 5, 100))
+	require.NoError(t, err)
+
+	sub := subscription.New(func(e *pb.Event) error { return nil }, "correlationID", 50, &pb.SubscribeToEvents_CreateSubscription{
+		DeviceIdFilter: []string{"deviceID"},
+		ResumeCursor:   cursor,
+	})
+	var sent []*pb.Event
+	send := func(e *pb.Event) error {
+		sent = append(sent, e)
+		return nil
+	}
+	rdClient := &testGrpcGatewayClient{
+		client: &testGetEventsClient{
+			events: []*pb.GetEventsResponse{
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID2, 1, 80)}},
+				{Type: &pb.GetEventsResponse_ResourceChanged{ResourceChanged: makeTestResourceChangedWithTimestamp(resourceID1, 5, 100)}},
+			},
+		},
+	}
+	err = sub.Replay(context.Background(), rdClient, send)
+	require.NoError(t, err)
+	require.Equal(t, []aggregateVersion{
+		{aggregateID: resourceID2.ToUUID(), version: 1},
+	}, getSentVersions(sent))
+}
+
+func TestSubResumeCursorSkew(t *testing.T) {
+	resourceID1 := commands.NewResourceID("deviceID", "/light/1")
+	resourceID2 := commands.NewResourceID("deviceID", "/light/2")
+	resourceID3 := commands.NewResourceID("deviceID", "/light/3")
+	var sent []*pb.Event
+	sub := subscription.New(func(e *pb.Event) error {
+		sent = append(sent, e)
+		return nil
+	}, "correlationID", 10, &pb.SubscribeToEvents_CreateSubscription{})
+
+	for _, e := range []*events.ResourceChanged{
+		makeTestResourceChangedWithTimestamp(resourceID1, 1, 100),
+		makeTestResourceChangedWithTimestamp(resourceID2, 1, 105),
+		makeTestResourceChangedWithTimestamp(resourceID3, 1, 112),
+		// the event delivered out of the timestamp order
+		makeTestResourceChangedWithTimestamp(resourceID1, 2, 103),
+	} {
+		err := sub.ProcessEvent(&pb.Event{Type: &pb.Event_ResourceChanged{ResourceChanged: e}}, subscription.FilterBitmaskResourceChanged)
+		require.NoError(t, err)
+	}
+	require.Len(t, sent, 4)
+
+	// only the aggregates delivered within the skew before the latest timestamp are in the cursor
+	cursor, err := pb.ParseResumeCursor(sent[2].GetResumeCursor())
+	require.NoError(t, err)
+	require.Equal(t, &pb.ResumeCursor{
+		Timestamp: 112,
+		Versions: map[string]uint64{
+			resourceID2.ToUUID(): 1,
+			resourceID3.ToUUID(): 1,
+		},
+	}, cursor)
+	cursor, err = pb.ParseResumeCursor(sent[3].GetResumeCursor())
+	require.NoError(t, err)
+	require.Equal(t, &pb.ResumeCursor{
+		Timestamp: 112,
+		Versions: map[string]uint64{
+			resourceID1.ToUUID(): 2,
+			resourceID2.ToUUID(): 1,
+			resourceID3.ToUUID(): 1,
+		},
+	}, cursor)
+}
@@ -1,12 +1,17 @@
 package subscription
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/pkg/fn"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
 	"github.com/plgd-dev/kit/v2/strings"
 	"go.uber.org/atomic"
 )
@@ -24,6 +29,24 @@ type Sub struct {
 
 	closed      atomic.Bool
 	closeAtomic atomic.Value
+
+	replayMutex sync.Mutex
+	// live events are buffered until the events missed since the resume position are replayed
+	replaying        bool
+	replayBuffer     []*pb.Event
+	replayedVersions map[string]uint64 // [aggregateID]version of the last replayed event
+
+	// events stored up to resumeSkew before the resume cursor are replayed again, so the cursor contains versions of the aggregates
+	// delivered in that interval
+	resumeSkew         time.Duration
+	cursorMutex        sync.Mutex
+	deliveredTimestamp int64                       // maximal timestamp of the delivered events
+	deliveredVersions  map[string]deliveredVersion // [aggregateID]
+}
+
+type deliveredVersion struct {
+	version   uint64
+	timestamp int64
 }
 
 func isFilteredDevice(filteredDeviceIDs strings.Set, deviceID string) bool {
@@ -109,6 +132,26 @@ func (s *Sub) isFilteredEvent(e *pb.Event, eventType FilterBitmask) (bool, error
 	return false, fmt.Errorf("unknown event type('%T')", e.GetType())
 }
 
+func (s *Sub) sendEvent(e *pb.Event, send SendEventFunc) error {
+	ev := pb.Event{
+		SubscriptionId: s.id,
+		CorrelationId:  s.correlationID,
+		Type:           e.GetType(),
+	}
+	if ae := aggregateEvent(e); ae != nil {
+		cursor, err := s.makeResumeCursor(ae)
+		if err != nil {
+			return fmt.Errorf("correlationId: %v, subscriptionId: %v: cannot create resume cursor of event ('%v'): %w", s.correlationID, s.Id(), e, err)
+		}
+		ev.ResumeCursor = cursor
+	}
+	err := send(&ev)
+	if err != nil {
+		return fmt.Errorf("correlationId: %v, subscriptionId: %v: cannot send event ('%v'): %w", s.correlationID, s.Id(), e, err)
+	}
+	return nil
+}
+
 func (s *Sub) ProcessEvent(e *pb.Event, eventType FilterBitmask) error {
 	ok, err := s.isFilteredEvent(e, eventType)
 	if err != nil {
@@ -117,14 +160,218 @@ func (s *Sub) ProcessEvent(e *pb.Event, eventType FilterBitmask) error {
 	if !ok {
 		return nil
 	}
-	ev := pb.Event{
-		SubscriptionId: s.id,
-		CorrelationId:  s.correlationID,
-		Type:           e.GetType(),
+	s.replayMutex.Lock()
+	if s.replaying {
+		s.replayBuffer = append(s.replayBuffer, e)
+		s.replayMutex.Unlock()
+		return nil
 	}
-	err = s.send(&ev)
+	s.replayMutex.Unlock()
+	return s.sendEvent(e, s.send)
+}
+
+// isReplayedLocked checks whether the event was already delivered by the replay or before the resume cursor.
+func (s *Sub) isReplayedLocked(e *pb.Event) bool {
+	ae := aggregateEvent(e)
+	if ae == nil {
+		return false
+	}
+	version, ok := s.replayedVersions[ae.AggregateID()]
+	return ok && ae.Version() <= version
+}
+
+func (s *Sub) stopReplayLocked() {
+	s.replayBuffer = nil
+	s.replayedVersions = nil
+	s.replaying = false
+}
+
+// finishReplay sends buffered live events which were not replayed and switches the subscription to the live events. The events
+// are sent by the send function of the replay, so they are not dropped. Live events received during the sending are buffered
+// to keep the order of the events.
+func (s *Sub) finishReplay(send SendEventFunc) error {
+	for {
+		s.replayMutex.Lock()
+		buffer := make([]*pb.Event, 0, len(s.replayBuffer))
+		for _, e := range s.replayBuffer {
+			if !s.isReplayedLocked(e) {
+				buffer = append(buffer, e)
+			}
+		}
+		s.replayBuffer = nil
+		if len(buffer) == 0 {
+			s.stopReplayLocked()
+			s.replayMutex.Unlock()
+			return nil
+		}
+		s.replayMutex.Unlock()
+		for _, e := range buffer {
+			if err := s.sendEvent(e, send); err != nil {
+				s.replayMutex.Lock()
+				s.stopReplayLocked()
+				s.replayMutex.Unlock()
+				return err
+			}
+		}
+	}
+}
+
+// makeResumeCursor records the delivered event and creates the resume cursor with versions of the aggregates delivered up to
+// resumeSkew before the latest timestamp.
+func (s *Sub) makeResumeCursor(ae eventstore.Event) (string, error) {
+	s.cursorMutex.Lock()
+	defer s.cursorMutex.Unlock()
+	timestamp := ae.Timestamp().UnixNano()
+	if timestamp > s.deliveredTimestamp {
+		s.deliveredTimestamp = timestamp
+		for aggregateID, v := range s.deliveredVersions {
+			if v.timestamp < timestamp-s.resumeSkew.Nanoseconds() {
+				delete(s.deliveredVersions, aggregateID)
+			}
+		}
+	}
+	if timestamp >= s.deliveredTimestamp-s.resumeSkew.Nanoseconds() {
+		if v, ok := s.deliveredVersions[ae.AggregateID()]; !ok || ae.Version() > v.version {
+			s.deliveredVersions[ae.AggregateID()] = deliveredVersion{
+				version:   ae.Version(),
+				timestamp: timestamp,
+			}
+		}
+	}
+	versions := make(map[string]uint64, len(s.deliveredVersions))
+	for aggregateID, v := range s.deliveredVersions {
+		versions[aggregateID] = v.version
+	}
+	return pb.MakeResumeCursor(pb.ResumeCursor{
+		Timestamp: s.deliveredTimestamp,
+		Versions:  versions,
+	})
+}
+
+// resumeTimestamp returns the timestamp after which the events are replayed. Events are ordered by timestamps in the eventstore,
+// but they are delivered to the subscription in the order of the event bus, so the events published up to the skew before
+// the cursor are replayed too.
+func resumeTimestamp(cursor *pb.ResumeCursor, resumeSkew time.Duration) int64 {
+	timestamp := cursor.Timestamp - resumeSkew.Nanoseconds()
+	if timestamp < 0 {
+		return 0
+	}
+	return timestamp
+}
+
+func (s *Sub) makeReplayRequest(cursor *pb.ResumeCursor) *pb.GetEventsRequest {
+	// events of the resource filter are filtered by isFilteredEvent, because events of the device metadata and resource links
+	// are stored in different aggregates
+	deviceIDs := strings.MakeSet(s.req.GetDeviceIdFilter()...)
+	for _, r := range s.req.GetResourceIdFilter() {
+		if v := commands.ResourceIdFromString(r); v != nil {
+			deviceIDs.Add(v.GetDeviceId())
+		}
+	}
+	req := pb.GetEventsRequest{
+		DeviceIdFilter:  deviceIDs.ToSlice(),
+		TimestampFilter: s.req.GetResumeTimestamp(),
+	}
+	if cursor != nil {
+		req.TimestampFilter = resumeTimestamp(cursor, s.resumeSkew)
+	}
+	if len(s.req.GetEventFilter()) > 0 {
+		req.EventFilter = BitmaskToGetEventsFilter(s.filter)
+	}
+	return &req
+}
+
+// seedDeliveredVersions continues the resume cursor, so the events delivered before it are not delivered again after the next resume.
+func (s *Sub) seedDeliveredVersions(cursor *pb.ResumeCursor) {
+	s.cursorMutex.Lock()
+	defer s.cursorMutex.Unlock()
+	s.deliveredTimestamp = cursor.Timestamp
+	for aggregateID, version := range cursor.Versions {
+		s.deliveredVersions[aggregateID] = deliveredVersion{
+			version:   version,
+			timestamp: cursor.Timestamp,
+		}
+	}
+}
+
+func (s *Sub) replayEvents(ctx context.Context, rdClient pb.GrpcGatewayClient, send SendEventFunc) error {
+	cursor, err := pb.ParseResumeCursor(s.req.GetResumeCursor())
 	if err != nil {
-		return fmt.Errorf("correlationId: %v, subscriptionId: %v: cannot send event ('%v'): %w", s.correlationID, s.Id(), e, err)
+		return err
+	}
+	// events of the aggregates of the cursor up to their versions were already delivered
+	replayedVersions := make(map[string]uint64)
+	if cursor != nil {
+		for aggregateID, version := range cursor.Versions {
+			replayedVersions[aggregateID] = version
+		}
+		s.seedDeliveredVersions(cursor)
+	}
+	defer func() {
+		s.replayMutex.Lock()
+		defer s.replayMutex.Unlock()
+		s.replayedVersions = replayedVersions
+	}()
+
+	req := s.makeReplayRequest(cursor)
+	if len(s.req.GetEventFilter()) > 0 && len(req.GetEventFilter()) == 0 {
+		// only registration events are requested, they are not stored in the eventstore
+		return nil
+	}
+	client, err := rdClient.GetEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		e, bit := GetEventsResponseToEvent(resp)
+		if e == nil {
+			continue
+		}
+		ok, err := s.isFilteredEvent(e, bit)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		ae := aggregateEvent(e)
+		if version, ok := replayedVersions[ae.AggregateID()]; ok && ae.Version() <= version {
+			continue
+		}
+		if err := s.sendEvent(e, send); err != nil {
+			return err
+		}
+		replayedVersions[ae.AggregateID()] = ae.Version()
+	}
+}
+
+// Replay sends events missed since the resume position of the subscription, which are stored in the eventstore, and then
+// it sends live events received during the replay without duplicities. The subscription must be initialized before the replay
+// to not miss events at the boundary. The send function must not drop the events.
+func (s *Sub) Replay(ctx context.Context, rdClient pb.GrpcGatewayClient, send SendEventFunc) error {
+	s.replayMutex.Lock()
+	replaying := s.replaying
+	s.replayMutex.Unlock()
+	if !replaying {
+		return nil
+	}
+	err := s.replayEvents(ctx, rdClient, send)
+	if err == nil {
+		err = s.finishReplay(send)
+	} else {
+		s.replayMutex.Lock()
+		s.stopReplayLocked()
+		s.replayMutex.Unlock()
+	}
+	if err != nil {
+		return fmt.Errorf("correlationId: %v, subscriptionId: %v: cannot replay events: %w", s.correlationID, s.Id(), err)
 	}
 	return nil
 }
@@ -139,7 +386,9 @@ func (s *Sub) Close() error {
 	return nil
 }
 
-func New(send SendEventFunc, correlationID string, req *pb.SubscribeToEvents_CreateSubscription) *Sub {
+// New creates the subscription. Events stored up to resumeSkew before the resume cursor are replayed again, so the cursor contains
+// versions of aggregates delivered in that interval.
+func New(send SendEventFunc, correlationID string, resumeSkew time.Duration, req *pb.SubscribeToEvents_CreateSubscription) *Sub {
 	bitmask := EventsFilterToBitmask(req.GetEventFilter())
 	filteredResourceIDs := strings.MakeSet()
 	filteredDeviceIDs := strings.MakeSet(req.GetDeviceIdFilter()...)
@@ -173,5 +422,8 @@ func New(send SendEventFunc, correlationID string, req *pb.SubscribeToEvents_Cre
 		filteredResourceIDs: filteredResourceIDs,
 		correlationID:       correlationID,
 		closeAtomic:         closeAtomic,
+		replaying:           req.GetResumeCursor() != "" || req.GetResumeTimestamp() > 0,
+		resumeSkew:          resumeSkew,
+		deliveredVersions:   make(map[string]deliveredVersion),
 	}
 }
@@ -17,9 +17,11 @@ func MakeConfig(t *testing.T) service.Config {
 	cfg.APIs.GRPC.Config = config.MakeGrpcServerConfig(config.GRPC_HOST)
 	cfg.APIs.GRPC.OwnerCacheExpiration = time.Minute
 	cfg.APIs.GRPC.SubscriptionBufferSize = 1000
+	cfg.APIs.GRPC.SubscriptionResumeSkew = time.Second
 	cfg.APIs.GRPC.TLS.ClientCertificateRequired = false
 
 	cfg.Clients.IdentityStore.Connection = config.MakeGrpcClientConfig(config.IDENTITY_STORE_HOST)
+	cfg.Clients.IdentityStore.ServiceAccountVerificationInterval = time.Second * 10
 	cfg.Clients.Eventbus.NATS = config.MakeSubscriberConfig()
 	cfg.Clients.Eventbus.GoPoolSize = 16
 	cfg.Clients.ResourceAggregate.Connection = config.MakeGrpcClientConfig(config.RESOURCE_AGGREGATE_HOST)
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/plgd-dev/hub/http-gateway/uri"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func (requestHandler *RequestHandler) batchUpdateResources(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get(uri.ContentTypeHeaderKey)
+	if contentType == uri.ApplicationProtoJsonContentType {
+		requestHandler.mux.ServeHTTP(w, r)
+		return
+	}
+
+	newBody, err := createContentBody(r.Body)
+	if err != nil {
+		writeError(w, kitNetGrpc.ForwardErrorf(codes.InvalidArgument, "cannot batch update resources: %v", err))
+		return
+	}
+
+	r.Body = newBody
+	requestHandler.mux.ServeHTTP(w, r)
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/test/resource/types"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	httpgwTest "github.com/plgd-dev/hub/http-gateway/test"
+	"github.com/plgd-dev/hub/http-gateway/uri"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/test"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestRequestHandlerBatchUpdateResources(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	type args struct {
+		typeFilter       []string
+		resourceIdFilter []string
+		body             string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         []*commands.ResourceId
+		wantHTTPCode int
+	}{
+		{
+			name: "invalid filter",
+			args: args{
+				body: `{"power":1}`,
+			},
+			wantHTTPCode: http.StatusBadRequest,
+		},
+		{
+			name: "valid",
+			args: args{
+				typeFilter: []string{types.CORE_LIGHT},
+				body:       `{"power":1}`,
+			},
+			want:         []*commands.ResourceId{commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1"))},
+			wantHTTPCode: http.StatusOK,
+		},
+		{
+			name: "revert update",
+			args: args{
+				resourceIdFilter: []string{commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")).ToString()},
+				body:             `{"power":0}`,
+			},
+			want:         []*commands.ResourceId{commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1"))},
+			wantHTTPCode: http.StatusOK,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+
+	shutdownHttp := httpgwTest.SetUp(t)
+	defer shutdownHttp()
+
+	token := oauthTest.GetDefaultServiceToken(t)
+	ctx = kitNetGrpc.CtxWithToken(ctx, token)
+
+	conn, err := grpc.Dial(config.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, config.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+	time.Sleep(200 * time.Millisecond)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := httpgwTest.NewRequest(http.MethodPut, uri.Resources, bytes.NewReader([]byte(tt.args.body))).AuthToken(token).Accept(uri.ApplicationProtoJsonContentType)
+			rb.AddDeviceIdFilter([]string{deviceID}).AddTypeFilter(tt.args.typeFilter).AddResourceIdFilter(tt.args.resourceIdFilter).ContentType(message.AppJSON.String())
+			resp := httpgwTest.HTTPDo(t, rb.Build())
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			require.Equal(t, tt.wantHTTPCode, resp.StatusCode)
+			if tt.wantHTTPCode != http.StatusOK {
+				return
+			}
+
+			got := make([]*commands.ResourceId, 0, 1)
+			for {
+				var value pb.BatchUpdateResourcesResponse
+				err = Unmarshal(resp.StatusCode, resp.Body, &value)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				require.Empty(t, value.GetError())
+				require.Equal(t, commands.Status_OK, value.GetData().GetStatus())
+				got = append(got, value.GetResourceId())
+			}
+			test.CheckProtobufs(t, tt.want, got, test.RequireToCheckFunc(require.Equal))
+		})
+	}
+}
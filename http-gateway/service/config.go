@@ -6,8 +6,11 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
+	kitNetHttp "github.com/plgd-dev/hub/pkg/net/http"
 	"github.com/plgd-dev/hub/pkg/net/listener"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 )
@@ -34,13 +37,17 @@ func (c *Config) Validate() error {
 
 // Config represent application configuration
 type APIsConfig struct {
-	HTTP HTTPConfig `yaml:"http" json:"http"`
+	HTTP    HTTPConfig     `yaml:"http" json:"http"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.HTTP.Validate(); err != nil {
 		return fmt.Errorf("http.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
@@ -60,9 +67,24 @@ func (c *WebSocketConfig) Validate() error {
 }
 
 type HTTPConfig struct {
-	Connection    listener.Config  `yaml:",inline" json:",inline"`
-	WebSocket     WebSocketConfig  `yaml:"webSocket" json:"webSocket"`
-	Authorization validator.Config `yaml:"authorization" json:"authorization"`
+	Connection    listener.Config     `yaml:",inline" json:",inline"`
+	WebSocket     WebSocketConfig     `yaml:"webSocket" json:"webSocket"`
+	Authorization AuthorizationConfig `yaml:"authorization" json:"authorization"`
+}
+
+type AuthorizationConfig struct {
+	// RequiredScopes are evaluated in the order, the first rule matching the request is applied.
+	RequiredScopes   []kitNetHttp.RequestScopesConfig `yaml:"requiredScopes" json:"requiredScopes"`
+	validator.Config `yaml:",inline" json:",inline"`
+}
+
+func (c *AuthorizationConfig) Validate() error {
+	for i := range c.RequiredScopes {
+		if err := c.RequiredScopes[i].Validate(); err != nil {
+			return fmt.Errorf("requiredScopes[%v].%w", i, err)
+		}
+	}
+	return c.Config.Validate()
 }
 
 func (c *HTTPConfig) Validate() error {
@@ -76,7 +98,9 @@ func (c *HTTPConfig) Validate() error {
 }
 
 type ClientsConfig struct {
-	GrpcGateway GrpcServerConfig `yaml:"grpcGateway" json:"grpcGateway"`
+	GrpcGateway            GrpcServerConfig     `yaml:"grpcGateway" json:"grpcGateway"`
+	IdentityStore          GrpcServerConfig     `yaml:"identityStore" json:"identityStore"`
+	OpenTelemetryCollector opentelemetry.Config `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 type GrpcServerConfig struct {
@@ -110,7 +134,13 @@ func (c *ClientsConfig) Validate() error {
 	if err != nil {
 		return fmt.Errorf("grpcGateway.%w", err)
 	}
+	if err := c.IdentityStore.Validate(); err != nil {
+		return fmt.Errorf("identityStore.%w", err)
+	}
 
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
@@ -26,7 +26,7 @@ func (requestHandler *RequestHandler) getDeviceResources(w http.ResponseWriter,
 	}
 	for key, values := range r.URL.Query() {
 		switch key {
-		case uri.TypeFilterQueryKey:
+		case uri.TypeFilterQueryKey, uri.ContentFilterQueryKey:
 			for _, v := range values {
 				q.Add(key, v)
 			}
@@ -56,9 +56,12 @@ func makeCloudDeviceResourceChanged(t *testing.T, deviceID string) *events.Resou
 func TestRequestHandlerGetDeviceResources(t *testing.T) {
 	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
 	type args struct {
-		deviceID   string
-		typeFilter []string
-		accept     string
+		deviceID      string
+		typeFilter    []string
+		contentFilter string
+		// the content filter is set by the alias query key when it is not empty
+		contentFilterAlias string
+		accept             string
 	}
 	tests := []struct {
 		name    string
@@ -136,6 +139,62 @@ func TestRequestHandlerGetDeviceResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "filter by content of " + deviceID,
+			args: args{
+				deviceID:      deviceID,
+				contentFilter: `power == 0 && name == "Light"`,
+				accept:        uri.ApplicationProtoJsonContentType,
+			},
+			want: []*pb.Resource{
+				{
+					Types: []string{types.CORE_LIGHT},
+					Data: pbTest.MakeResourceChanged(t, deviceID, test.TestResourceLightInstanceHref("1"),
+						map[string]interface{}{
+							"state": false,
+							"power": uint64(0),
+							"name":  "Light",
+							"if":    []interface{}{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE},
+							"rt":    []interface{}{types.CORE_LIGHT},
+						},
+					),
+				},
+			},
+		},
+		{
+			name: "filter by type and content of " + deviceID,
+			args: args{
+				deviceID:           deviceID,
+				typeFilter:         []string{device.ResourceType, platform.ResourceType},
+				contentFilter:      `$.mnmn == "ocfcloud.com"`,
+				contentFilterAlias: uri.AliasContentFilterQueryKey,
+				accept:             uri.ApplicationProtoJsonContentType,
+			},
+			want: []*pb.Resource{
+				{
+					Types: []string{platform.ResourceType},
+					Data:  makePlatformResourceChanged(t, deviceID),
+				},
+			},
+		},
+		{
+			name: "filter by content without match",
+			args: args{
+				deviceID:      deviceID,
+				contentFilter: "power > 100",
+				accept:        uri.ApplicationProtoJsonContentType,
+			},
+			want: []*pb.Resource{},
+		},
+		{
+			name: "invalid content filter",
+			args: args{
+				deviceID:      deviceID,
+				contentFilter: "power 100",
+				accept:        uri.ApplicationProtoJsonContentType,
+			},
+			wantErr: true,
+		},
 		{
 			name: "not found",
 			args: args{
@@ -171,6 +230,11 @@ func TestRequestHandlerGetDeviceResources(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			rb := httpgwTest.NewRequest(http.MethodGet, uri.AliasDeviceResources, nil).Accept(tt.args.accept).AuthToken(token)
 			rb.DeviceId(tt.args.deviceID).AddTypeFilter(tt.args.typeFilter)
+			if tt.args.contentFilterAlias != "" {
+				rb.AddQuery(tt.args.contentFilterAlias, tt.args.contentFilter)
+			} else {
+				rb.ContentFilter(tt.args.contentFilter)
+			}
 			resp := httpgwTest.HTTPDo(t, rb.Build())
 			defer func() {
 				_ = resp.Body.Close()
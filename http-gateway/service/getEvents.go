@@ -12,16 +12,45 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+func parseTimestampQuery(r *http.Request, key string) (int64, error) {
+	t := r.URL.Query().Get(key)
+	if t == "" {
+		return 0, nil
+	}
+	timestamp, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "failed to parse %v %v: %v", key, t, err)
+	}
+	return timestamp, nil
+}
+
+func parseLimitQuery(r *http.Request) (uint32, error) {
+	l := r.URL.Query().Get(uri.LimitQueryKey)
+	if l == "" {
+		return 0, nil
+	}
+	limit, err := strconv.ParseUint(l, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "failed to parse %v %v: %v", uri.LimitQueryKey, l, err)
+	}
+	return uint32(limit), nil
+}
+
 func (requestHandler *RequestHandler) getEvents(w http.ResponseWriter, r *http.Request) {
-	var timestamp int64
-	var err error
-	t := r.URL.Query().Get(uri.TimestampFilterQueryKey)
-	if t != "" {
-		timestamp, err = strconv.ParseInt(t, 10, 64)
-		if err != nil {
-			writeError(w, status.Errorf(codes.InvalidArgument, "failed to parse timestamp %v: %v", t, err))
-			return
-		}
+	timestamp, err := parseTimestampQuery(r, uri.TimestampFilterQueryKey)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	timestampTo, err := parseTimestampQuery(r, uri.TimestampToFilterQueryKey)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	limit, err := parseLimitQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
 	vars := mux.Vars(r)
@@ -29,9 +58,13 @@ func (requestHandler *RequestHandler) getEvents(w http.ResponseWriter, r *http.R
 	href := vars[uri.ResourceHrefKey]
 	resourceID := commands.NewResourceID(deviceID, href).ToString()
 	type Options struct {
-		DeviceIdFilter   []string `url:"deviceIdFilter,omitempty"`
-		ResourceIdFilter []string `url:"resourceIdFilter,omitempty"`
-		TimestampFilter  int64    `url:"timestampFilter,omitempty"`
+		DeviceIdFilter    []string `url:"deviceIdFilter,omitempty"`
+		ResourceIdFilter  []string `url:"resourceIdFilter,omitempty"`
+		TimestampFilter   int64    `url:"timestampFilter,omitempty"`
+		EventFilter       []string `url:"eventFilter,omitempty"`
+		TimestampToFilter int64    `url:"timestampToFilter,omitempty"`
+		Limit             uint32   `url:"limit,omitempty"`
+		ContinuationToken string   `url:"continuationToken,omitempty"`
 	}
 	opt := Options{}
 	if resourceID != "" {
@@ -44,6 +77,10 @@ func (requestHandler *RequestHandler) getEvents(w http.ResponseWriter, r *http.R
 	if timestamp != 0 {
 		opt.TimestampFilter = timestamp
 	}
+	opt.EventFilter = r.URL.Query()[uri.EventFilterQueryKey]
+	opt.TimestampToFilter = timestampTo
+	opt.Limit = limit
+	opt.ContinuationToken = r.URL.Query().Get(uri.ContinuationTokenQueryKey)
 	q, err := query.Values(opt)
 	if err != nil {
 		writeError(w,
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"io"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -69,10 +70,12 @@ func TestRequestHandlerGetEvents(t *testing.T) {
 	require.NotNil(t, allEvents)
 
 	type args struct {
-		accept    string
-		deviceId  string
-		href      string
-		timestamp time.Time
+		accept      string
+		deviceId    string
+		href        string
+		timestamp   time.Time
+		eventFilter []string
+		limit       uint32
 	}
 	tests := []struct {
 		name         string
@@ -145,6 +148,36 @@ func TestRequestHandlerGetEvents(t *testing.T) {
 			wantLen:      1,
 			wantHTTPCode: http.StatusOK,
 		},
+		{
+			name: "Event filter (First resource changed)",
+			args: args{
+				accept:      uri.ApplicationProtoJsonContentType,
+				deviceId:    deviceID,
+				href:        test.GetAllBackendResourceLinks()[0].Href,
+				eventFilter: []string{pb.GetEventsRequest_RESOURCE_CHANGED.String()},
+			},
+			wantLen:      1,
+			wantHTTPCode: http.StatusOK,
+		},
+		{
+			name: "Limit (One event)",
+			args: args{
+				accept: uri.ApplicationProtoJsonContentType,
+				limit:  1,
+			},
+			wantLen:      1,
+			wantHTTPCode: http.StatusOK,
+		},
+		{
+			name: "Event filter (No events)",
+			args: args{
+				accept:      uri.ApplicationProtoJsonContentType,
+				deviceId:    deviceID,
+				href:        test.GetAllBackendResourceLinks()[0].Href,
+				eventFilter: []string{pb.GetEventsRequest_RESOURCE_UPDATED.String()},
+			},
+			wantHTTPCode: http.StatusOK,
+		},
 	}
 
 	getURL := func(deviceID, href string) string {
@@ -160,7 +193,10 @@ func TestRequestHandlerGetEvents(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rb := httpgwTest.NewRequest(http.MethodGet, getURL(tt.args.deviceId, tt.args.href), nil).AuthToken(token).Accept(tt.args.accept)
-			rb.DeviceId(tt.args.deviceId).ResourceHref(tt.args.href).Timestamp(tt.args.timestamp)
+			rb.DeviceId(tt.args.deviceId).ResourceHref(tt.args.href).Timestamp(tt.args.timestamp).AddEventFilter(tt.args.eventFilter)
+			if tt.args.limit > 0 {
+				rb.AddQuery(uri.LimitQueryKey, strconv.FormatUint(uint64(tt.args.limit), 10))
+			}
 			resp := httpgwTest.HTTPDo(t, rb.Build())
 			defer func() {
 				_ = resp.Body.Close()
@@ -15,6 +15,10 @@ import (
 	"github.com/plgd-dev/hub/http-gateway/uri"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitHttp "github.com/plgd-dev/hub/pkg/net/http"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	//	"github.com/tmc/grpc-websocket-proxy/wsproxy"
 	"github.com/plgd-dev/hub/http-gateway/grpc-websocket-proxy/wsproxy"
@@ -56,6 +60,12 @@ func loggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+func tracingMiddleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http-gateway", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + r.URL.Path
+	}))
+}
+
 func makeQueryCaseInsensitive(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		u, err := url.ParseRequestURI(r.RequestURI)
@@ -179,8 +189,12 @@ func resourceEventsMatcher(r *http.Request, rm *router.RouteMatch) bool {
 // NewHTTP returns HTTP server
 func NewHTTP(requestHandler *RequestHandler, authInterceptor kitHttp.Interceptor) (*http.Server, error) {
 	r0 := router.NewRouter()
+	r0.Use(tracingMiddleware)
 	r0.Use(loggingMiddleware)
 	r0.Use(kitHttp.CreateAuthMiddleware(authInterceptor, func(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+		if jwt.IsMissingScopes(err) {
+			err = status.Errorf(codes.PermissionDenied, "%v", err)
+		}
 		writeError(w, fmt.Errorf("cannot access to %v: %w", r.RequestURI, err))
 	}))
 	r0.Use(makeQueryCaseInsensitive)
@@ -198,6 +212,7 @@ func NewHTTP(requestHandler *RequestHandler, authInterceptor kitHttp.Interceptor
 	r.HandleFunc(uri.AliasDevicePendingMetadataUpdate, requestHandler.cancelPendingMetadataUpdate).Methods(http.MethodDelete)
 	r.HandleFunc(uri.AliasDeviceEvents, requestHandler.getEvents).Methods(http.MethodGet)
 	r.HandleFunc(uri.HubConfiguration, requestHandler.getHubConfiguration).Methods(http.MethodGet)
+	r.HandleFunc(uri.Resources, requestHandler.batchUpdateResources).Methods(http.MethodPut)
 
 	r.PathPrefix(uri.Devices).Methods(http.MethodPost).MatcherFunc(resourceLinksMatcher).HandlerFunc(requestHandler.createResource)
 	r.PathPrefix(uri.Devices).Methods(http.MethodGet).MatcherFunc(resourcePendingCommandsMatcher).HandlerFunc(requestHandler.getResourcePendingCommands)
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	httpgwTest "github.com/plgd-dev/hub/http-gateway/test"
+	"github.com/plgd-dev/hub/http-gateway/uri"
+	kitNetHttp "github.com/plgd-dev/hub/pkg/net/http"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestHandlerRequiredScopes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+
+	cfg := httpgwTest.MakeConfig(t)
+	cfg.APIs.HTTP.Authorization.RequiredScopes = []kitNetHttp.RequestScopesConfig{
+		{
+			Method: http.MethodDelete,
+			URI:    uri.Devices + ".*",
+			// the token of the test oauth server doesn't contain the scope
+			Scopes: []string{"w:devices:.*"},
+		},
+		{
+			Method: http.MethodGet,
+			URI:    uri.Devices,
+			Scopes: []string{"r:deviceinformation:.*"},
+		},
+		{
+			Method: http.MethodGet,
+			URI:    uri.HubConfiguration,
+			Scopes: []string{"admin:hub"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	shutdownHttp := httpgwTest.New(t, cfg)
+	defer shutdownHttp()
+
+	token := oauthTest.GetDefaultServiceToken(t)
+
+	tests := []struct {
+		name         string
+		method       string
+		uri          string
+		query        string
+		wantHTTPCode int
+	}{
+		{
+			name:         "missing scope",
+			method:       http.MethodDelete,
+			uri:          uri.Devices,
+			wantHTTPCode: http.StatusForbidden,
+		},
+		{
+			name:         "required scope",
+			method:       http.MethodGet,
+			uri:          uri.Devices,
+			wantHTTPCode: http.StatusOK,
+		},
+		{
+			name:         "required scope with query",
+			method:       http.MethodGet,
+			uri:          uri.Devices,
+			query:        "status=ONLINE",
+			wantHTTPCode: http.StatusOK,
+		},
+		{
+			// the uri is matched as a whole, so the request is handled by the default rules
+			name:         "not matched uri",
+			method:       http.MethodGet,
+			uri:          uri.Devices + "/notFound",
+			wantHTTPCode: http.StatusNotFound,
+		},
+		{
+			// the whitelisted requests are not authorized
+			name:         "whitelisted",
+			method:       http.MethodGet,
+			uri:          uri.HubConfiguration,
+			wantHTTPCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := httpgwTest.NewRequest(tt.method, tt.uri, nil).AuthToken(token)
+			if tt.query != "" {
+				rb = rb.SetQuery(tt.query)
+			}
+			resp := httpgwTest.HTTPDo(t, rb.Build())
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			require.Equal(t, tt.wantHTTPCode, resp.StatusCode)
+		})
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"regexp"
 
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 
 	"github.com/plgd-dev/hub/grpc-gateway/client"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/http-gateway/uri"
+	isClient "github.com/plgd-dev/hub/identity-store/client"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	grpcClient "github.com/plgd-dev/hub/pkg/net/grpc/client"
 	kitNetHttp "github.com/plgd-dev/hub/pkg/net/http"
 	"github.com/plgd-dev/hub/pkg/net/listener"
@@ -27,7 +31,7 @@ type Server struct {
 
 // New parses configuration and creates new Server with provided store and bus
 func New(ctx context.Context, config Config, logger log.Logger) (*Server, error) {
-	validator, err := validator.New(ctx, config.APIs.HTTP.Authorization, logger)
+	validator, err := validator.New(ctx, config.APIs.HTTP.Authorization.Config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create validator: %w", err)
 	}
@@ -39,6 +43,20 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Server, error)
 	}
 	listener.AddCloseFunc(validator.Close)
 
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	listener.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "http-gateway", logger)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	listener.AddCloseFunc(otelProvider.Close)
+
 	grpcConn, err := grpcClient.New(config.Clients.GrpcGateway.Connection, logger)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to resource directory: %w", err)
@@ -49,6 +67,18 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Server, error)
 			logger.Errorf("error occurs during close connection to resource-directory: %v", err)
 		}
 	})
+
+	isConn, err := grpcClient.New(config.Clients.IdentityStore.Connection, logger)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("cannot connect to identity-store: %w", err)
+	}
+	listener.AddCloseFunc(func() {
+		err := isConn.Close()
+		if err != nil {
+			logger.Errorf("error occurs during close connection to identity-store: %v", err)
+		}
+	})
 	grpcClient := pb.NewGrpcGatewayClient(grpcConn.GRPC())
 	client := client.New(grpcClient)
 
@@ -68,7 +98,11 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Server, error)
 			URI:    regexp.MustCompile(`(\/[^a]pi\/.*)|(\/a[^p]i\/.*)|(\/ap[^i]\/.*)||(\/api[^/].*)`),
 		})
 	}
-	auth := kitNetHttp.NewInterceptorWithValidator(validator, authRules, whiteList...)
+	auth := kitNetHttp.NewInterceptorWithValidator(validator, kitNetHttp.WithRequiredScopes(authRules, config.APIs.HTTP.Authorization.RequiredScopes), whiteList...)
+	// keys of the service accounts are exchanged for their tokens, which are validated and forwarded to the grpc-gateway,
+	// the grpc-gateway verifies the tokens of the service accounts
+	serviceAccountTokens := isClient.NewServiceAccountTokens(pbIS.NewIdentityStoreClient(isConn.GRPC()), 0)
+	auth = kitNetHttp.NewInterceptorWithTokenExchange(auth, serviceAccountTokens.Exchange)
 	requestHandler := NewRequestHandler(&config, client)
 
 	http, err := NewHTTP(requestHandler, auth)
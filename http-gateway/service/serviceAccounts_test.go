@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	httpgwTest "github.com/plgd-dev/hub/http-gateway/test"
+	"github.com/plgd-dev/hub/http-gateway/uri"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestRequestHandlerServiceAccounts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUpServiceAccounts(ctx, t)
+	defer tearDown()
+
+	cfg := httpgwTest.MakeConfig(t)
+	cfg.APIs.HTTP.Authorization.Authorities = append(cfg.APIs.HTTP.Authorization.Authorities, service.ServiceAccountsAuthority())
+	shutdownHttp := httpgwTest.New(t, cfg)
+	defer shutdownHttp()
+
+	isConn, err := grpc.Dial(config.IDENTITY_STORE_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = isConn.Close()
+	}()
+	isClient := pbIS.NewIdentityStoreClient(isConn)
+	created, err := isClient.CreateServiceAccount(kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t)), &pbIS.CreateServiceAccountRequest{
+		Name:   "reader",
+		Scopes: []string{"r:deviceinformation:*"},
+	})
+	require.NoError(t, err)
+
+	do := func(method, key string) int {
+		resp := httpgwTest.HTTPDo(t, httpgwTest.NewRequest(method, uri.Devices, nil).AuthToken(key).Build())
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		return resp.StatusCode
+	}
+
+	// the key is exchanged for the token, which is validated by the http-gateway
+	require.Equal(t, http.StatusOK, do(http.MethodGet, created.GetKey()))
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, created.GetKey()+"0"))
+	// the service account without the write scope cannot modify the data
+	require.Equal(t, http.StatusForbidden, do(http.MethodDelete, created.GetKey()))
+
+	// the exchanged token of the revoked service account is rejected by the grpc-gateway
+	_, err = isClient.RevokeServiceAccounts(kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t)), &pbIS.RevokeServiceAccountsRequest{
+		ServiceAccountIds: []string{created.GetServiceAccount().GetId()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, created.GetKey()))
+}
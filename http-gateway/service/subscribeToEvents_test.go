@@ -97,6 +97,7 @@ func (u *updateChecker) checkUpdateLightResource(t *testing.T, ctx context.Conte
 					},
 				},
 				CorrelationId: "updatePending + resourceUpdated",
+				ResumeCursor:  ev.GetResumeCursor(),
 			}
 			test.CheckProtobufs(t, expectedEvent, ev, test.RequireToCheckFunc(require.Equal))
 			updCorrelationID = ev.GetResourceUpdatePending().GetAuditContext().GetCorrelationId()
@@ -113,6 +114,7 @@ func (u *updateChecker) checkUpdateLightResource(t *testing.T, ctx context.Conte
 					},
 				},
 				CorrelationId: "updatePending + resourceUpdated",
+				ResumeCursor:  ev.GetResumeCursor(),
 			}
 			test.CheckProtobufs(t, expectedEvent, ev, test.RequireToCheckFunc(require.Equal))
 		case ev.GetResourceChanged() != nil:
@@ -137,6 +139,7 @@ func (u *updateChecker) checkUpdateLightResource(t *testing.T, ctx context.Conte
 					},
 				},
 				CorrelationId: "testToken",
+				ResumeCursor:  ev.GetResumeCursor(),
 			}
 			data := test.DecodeCbor(t, ev.GetResourceChanged().GetContent().GetData())
 			require.Equal(t, expData, data)
@@ -275,6 +278,7 @@ func TestRequestHandlerSubscribeToEvents(t *testing.T) {
 			},
 		},
 		CorrelationId: "testToken",
+		ResumeCursor:  ev.GetResumeCursor(),
 	}
 	test.CheckProtobufs(t, expectedEvent, ev, test.RequireToCheckFunc(require.Equal))
 
@@ -362,6 +366,7 @@ func TestRequestHandlerSubscribeToEvents(t *testing.T) {
 			},
 		},
 		CorrelationId: "receivePending + resourceReceived",
+		ResumeCursor:  ev.GetResumeCursor(),
 	}
 	test.CheckProtobufs(t, expectedEvent, ev, test.RequireToCheckFunc(require.Equal))
 	recvCorrelationID := ev.GetResourceRetrievePending().GetAuditContext().GetCorrelationId()
@@ -384,6 +389,7 @@ func TestRequestHandlerSubscribeToEvents(t *testing.T) {
 			},
 		},
 		CorrelationId: "receivePending + resourceReceived",
+		ResumeCursor:  ev.GetResumeCursor(),
 	}
 	test.CheckProtobufs(t, expectedEvent, ev, test.RequireToCheckFunc(require.Equal))
 
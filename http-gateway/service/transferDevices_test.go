@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	httpgwTest "github.com/plgd-dev/hub/http-gateway/test"
+	"github.com/plgd-dev/hub/http-gateway/uri"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRequestHandlerTransferDevices(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	ctx, cancel := context.WithTimeout(context.Background(), config.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+
+	shutdownHttp := httpgwTest.SetUp(t)
+	defer shutdownHttp()
+
+	token := oauthTest.GetDefaultServiceToken(t)
+	ctx = kitNetGrpc.CtxWithToken(ctx, token)
+
+	conn, err := grpc.Dial(config.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	c := pb.NewGrpcGatewayClient(conn)
+
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, config.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	type args struct {
+		req *pb.TransferDevicesRequest
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         *pb.TransferDevicesResponse
+		wantErr      bool
+		wantHTTPCode int
+	}{
+		{
+			name: "invalid deviceIds",
+			args: args{
+				req: &pb.TransferDevicesRequest{
+					NewOwner: "newOwner",
+				},
+			},
+			wantErr:      true,
+			wantHTTPCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid new owner",
+			args: args{
+				req: &pb.TransferDevicesRequest{
+					DeviceIdFilter: []string{deviceID},
+				},
+			},
+			wantErr:      true,
+			wantHTTPCode: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			args: args{
+				req: &pb.TransferDevicesRequest{
+					DeviceIdFilter: []string{"notFound"},
+					NewOwner:       "newOwner",
+				},
+			},
+			want:         &pb.TransferDevicesResponse{},
+			wantHTTPCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := protojson.Marshal(tt.args.req)
+			require.NoError(t, err)
+			req := httpgwTest.NewRequest(http.MethodPost, uri.DevicesTransfer, bytes.NewReader(data)).AuthToken(token).Build()
+			resp := httpgwTest.HTTPDo(t, req)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			assert.Equal(t, tt.wantHTTPCode, resp.StatusCode)
+
+			var got pb.TransferDevicesResponse
+			err = Unmarshal(resp.StatusCode, resp.Body, &got)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.DeviceIds, got.DeviceIds)
+		})
+	}
+}
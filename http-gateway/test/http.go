@@ -132,6 +132,14 @@ func (c *requestBuilder) AddTypeFilter(typeFilter []string) *requestBuilder {
 	return c
 }
 
+func (c *requestBuilder) ContentFilter(contentFilter string) *requestBuilder {
+	if contentFilter == "" {
+		return c
+	}
+	c.AddQuery(uri.ContentFilterQueryKey, contentFilter)
+	return c
+}
+
 func (c *requestBuilder) AddCorrelantionIdFilter(correlantionId []string) *requestBuilder {
 	if len(correlantionId) == 0 {
 		return c
@@ -148,6 +156,14 @@ func (c *requestBuilder) AddCommandsFilter(commandFilter []string) *requestBuild
 	return c
 }
 
+func (c *requestBuilder) AddEventFilter(eventFilter []string) *requestBuilder {
+	if len(eventFilter) == 0 {
+		return c
+	}
+	c.AddQuery(uri.EventFilterQueryKey, eventFilter...)
+	return c
+}
+
 func (c *requestBuilder) AddTimeToLive(ttl time.Duration) *requestBuilder {
 	if ttl == 0 {
 		return c
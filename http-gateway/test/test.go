@@ -19,13 +19,14 @@ import (
 
 func MakeConfig(t *testing.T) service.Config {
 	var cfg service.Config
-	cfg.APIs.HTTP.Authorization = config.MakeAuthorizationConfig()
+	cfg.APIs.HTTP.Authorization.Config = config.MakeAuthorizationConfig()
 	cfg.APIs.HTTP.Connection = config.MakeListenerConfig(config.HTTP_GW_HOST)
 	cfg.APIs.HTTP.Connection.TLS.ClientCertificateRequired = false
 	cfg.APIs.HTTP.WebSocket.StreamBodyLimit = 256 * 1024
 	cfg.APIs.HTTP.WebSocket.PingFrequency = 10 * time.Second
 
 	cfg.Clients.GrpcGateway.Connection = config.MakeGrpcClientConfig(config.GRPC_HOST)
+	cfg.Clients.IdentityStore.Connection = config.MakeGrpcClientConfig(config.IDENTITY_STORE_HOST)
 
 	err := cfg.Validate()
 	require.NoError(t, err)
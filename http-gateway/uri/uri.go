@@ -19,6 +19,11 @@ const (
 	CorrelationIDQueryKey       = "correlationId"
 	TimestampFilterQueryKey     = "timestampFilter"
 	CorrelationIdFilterQueryKey = "correlationIdFilter"
+	EventFilterQueryKey         = "eventFilter"
+	TimestampToFilterQueryKey   = "timestampToFilter"
+	LimitQueryKey               = "limit"
+	ContinuationTokenQueryKey   = "continuationToken"
+	ContentFilterQueryKey       = "contentFilter"
 
 	AliasInterfaceQueryKey        = "interface"
 	AliasCommandFilterQueryKey    = "command"
@@ -26,6 +31,8 @@ const (
 	AliasResourceIdFilterQueryKey = "resourceId"
 	AliasTypeFilterQueryKey       = "type"
 	AliasStatusFilterQueryKey     = "status"
+	AliasEventFilterQueryKey      = "event"
+	AliasContentFilterQueryKey    = "content"
 
 	CorrelationIDHeaderKey = "Correlation-Id"
 	ContentTypeHeaderKey   = "Content-Type"
@@ -54,12 +61,16 @@ const (
 	// (HTTP ALIAS) GET /api/v1/devices/{deviceId} -> rpc GetDevices + deviceIdFilter
 	// (HTTP ALIAS) DELETE /api/v1/devices/{deviceId} -> rpc DeleteDevices + deviceIdFilter
 	AliasDevice = Devices + "/{" + DeviceIDKey + "}"
+	// (GRPC + HTTP) POST /api/v1/devices/transfer -> rpc TransferDevices
+	DevicesTransfer = Devices + "/transfer"
 
 	// (GRPC + HTTP) GET /api/v1/resource-links -> rpc GetResourceLinks
 	ResourceLinks = API + "/" + ResourceLinksPathKey
 	// (HTTP ALIAS) GET /api/v1/devices/{deviceId}/resource-links
 	AliasDeviceResourceLinks = AliasDevice + "/" + ResourceLinksPathKey
 
+	// (GRPC + HTTP) GET /api/v1/resources -> rpc GetResources
+	// (GRPC + HTTP) PUT /api/v1/resources -> rpc BatchUpdateResources
 	Resources = API + "/" + ResourcesPathKey
 
 	// (GRPC + HTTP) GET /api/v1/devices/devices-metadata
@@ -98,6 +109,8 @@ const (
 
 	// (GRPC + HTTP) GET /api/v1/events -> rpc GetEvents
 	// (GRPC + HTTP) GET /api/v1/events?timestampFilter={timestamp} -> rpc GetEvents + timestampFilter
+	// (GRPC + HTTP) GET /api/v1/events?eventFilter={event} -> rpc GetEvents + eventFilter
+	// (GRPC + HTTP) GET /api/v1/events?timestampToFilter={timestamp}&limit={limit}&continuationToken={token} -> rpc GetEvents + timestampToFilter + limit + continuationToken
 	Events = API + "/" + EventsPathKey
 
 	// (HTTP ALIAS) GET /api/v1/devices/{deviceId}/events == rpc GetEvents + deviceIdFilter
@@ -128,4 +141,11 @@ var QueryCaseInsensitive = map[string]string{
 	strings.ToLower(TimestampFilterQueryKey):       TimestampFilterQueryKey,
 	strings.ToLower(TimeToLiveQueryKey):            TimeToLiveQueryKey,
 	strings.ToLower(CorrelationIdFilterQueryKey):   CorrelationIdFilterQueryKey,
+	strings.ToLower(EventFilterQueryKey):           EventFilterQueryKey,
+	strings.ToLower(AliasEventFilterQueryKey):      EventFilterQueryKey,
+	strings.ToLower(TimestampToFilterQueryKey):     TimestampToFilterQueryKey,
+	strings.ToLower(LimitQueryKey):                 LimitQueryKey,
+	strings.ToLower(ContinuationTokenQueryKey):     ContinuationTokenQueryKey,
+	strings.ToLower(ContentFilterQueryKey):         ContentFilterQueryKey,
+	strings.ToLower(AliasContentFilterQueryKey):    ContentFilterQueryKey,
 }
@@ -19,10 +19,17 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// sharedDevice is a device shared with the user by a group.
+type sharedDevice struct {
+	owner string
+	role  pbIS.Role
+}
+
 type ownerSubject struct {
 	handlers      map[uint64]func(e *events.Event)
 	subscription  *nats.Subscription
 	devices       strings.SortedSlice
+	sharedDevices map[string]sharedDevice
 	validUntil    time.Time
 	devicesSynced bool
 	sync.Mutex
@@ -30,9 +37,10 @@ type ownerSubject struct {
 
 func newOwnerSubject(validUntil time.Time) *ownerSubject {
 	return &ownerSubject{
-		handlers:   make(map[uint64]func(e *events.Event)),
-		devices:    make(strings.SortedSlice, 0, 16),
-		validUntil: validUntil,
+		handlers:      make(map[uint64]func(e *events.Event)),
+		devices:       make(strings.SortedSlice, 0, 16),
+		sharedDevices: make(map[string]sharedDevice),
+		validUntil:    validUntil,
 	}
 }
 
@@ -45,6 +53,10 @@ func (d *ownerSubject) Handle(msg *nats.Msg) error {
 	if d.devicesSynced {
 		d.devices = d.devices.Insert(e.GetDevicesRegistered().GetDeviceIds()...)
 		d.devices = d.devices.Remove(e.GetDevicesUnregistered().GetDeviceIds()...)
+		if e.GetDevicesShared() != nil || e.GetDevicesUnshared() != nil {
+			// the device can be shared by more groups, so the access is synchronized again
+			d.devicesSynced = false
+		}
 	}
 	handlers := make(map[uint64]func(e *events.Event))
 	for key, h := range d.handlers {
@@ -79,6 +91,18 @@ func (d *ownerSubject) updateDevicesLocked(devices []string) ([]string, []string
 	return added, removed
 }
 
+// getDevicesWithRoleLocked returns owned devices and shared devices with at least the role.
+func (d *ownerSubject) getDevicesWithRoleLocked(role pbIS.Role) strings.SortedSlice {
+	devices := make([]string, 0, len(d.devices)+len(d.sharedDevices))
+	devices = append(devices, d.devices...)
+	for deviceID, s := range d.sharedDevices {
+		if s.role >= role {
+			devices = append(devices, deviceID)
+		}
+	}
+	return strings.MakeSortedSlice(devices)
+}
+
 func (d *ownerSubject) subscribeLocked(owner string, subscribe func(subj string, cb nats.MsgHandler) (*nats.Subscription, error), handle func(msg *nats.Msg)) error {
 	if d.subscription == nil {
 		sub, err := subscribe(events.GetRegistrationSubject(owner), handle)
@@ -104,6 +128,11 @@ func (d *ownerSubject) syncDevicesLocked(ctx context.Context, owner string, cach
 	if err != nil {
 		return nil, nil, kitNetGrpc.ForwardFromError(codes.InvalidArgument, err)
 	}
+	sharedDevices, err := cache.getSharedDevices(ctx, owner, cache.isClient)
+	if err != nil {
+		return nil, nil, kitNetGrpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	d.sharedDevices = sharedDevices
 	d.validUntil = now.Add(cache.expiration)
 	added, removed = d.updateDevicesLocked(devices)
 	return added, removed, nil
@@ -190,6 +219,37 @@ func (c *OwnerCache) getOwnerDevices(ctx context.Context, isClient pbIS.Identity
 	return ownerDevices, nil
 }
 
+// getSharedDevices returns devices shared with the owner, the owned devices are skipped.
+func (c *OwnerCache) getSharedDevices(ctx context.Context, owner string, isClient pbIS.IdentityStoreClient) (map[string]sharedDevice, error) {
+	getDeviceAccessClient, err := isClient.GetDeviceAccess(ctx, &pbIS.GetDeviceAccessRequest{})
+	if err != nil {
+		return nil, status.Errorf(status.Convert(err).Code(), "cannot get shared devices: %v", err)
+	}
+	defer func() {
+		if err := getDeviceAccessClient.CloseSend(); err != nil {
+			c.errFunc(fmt.Errorf("cannot close send direction of get device access stream: %v", err))
+		}
+	}()
+	sharedDevices := make(map[string]sharedDevice)
+	for {
+		access, err := getDeviceAccessClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(status.Convert(err).Code(), "cannot receive shared devices: %v", err)
+		}
+		if access.GetOwner() == owner {
+			continue
+		}
+		sharedDevices[access.GetDeviceId()] = sharedDevice{
+			owner: access.GetOwner(),
+			role:  access.GetRole(),
+		}
+	}
+	return sharedDevices, nil
+}
+
 // Create or get owner subject, lock it, execute function and unlock it
 func (c *OwnerCache) executeOnLockedOwnerSubject(owner string, fn func(*ownerSubject) error) error {
 	val, _ := c.owners.LoadOrStoreWithFunc(owner, func(value interface{}) interface{} {
@@ -331,6 +391,64 @@ func (c *OwnerCache) OwnsDevices(ctx context.Context, devices []string) (bool, e
 	return equalDevices, nil
 }
 
+// GetAccessibleDevices provides devices owned by the user and devices shared with the user with at least the role.
+func (c *OwnerCache) GetAccessibleDevices(ctx context.Context, role pbIS.Role) (devices []string, err error) {
+	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, c.ownerClaim)
+	if err != nil {
+		return nil, kitNetGrpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	now := time.Now()
+	if err = c.executeOnLockedOwnerSubject(owner, func(s *ownerSubject) error {
+		if !s.devicesSynced {
+			if _, _, err := s.syncDevicesLocked(ctx, owner, c); err != nil {
+				return err
+			}
+		} else {
+			s.validUntil = now.Add(c.expiration)
+		}
+		devices = s.getDevicesWithRoleLocked(role)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// GetDevicesOwners provides owners of the selected devices, which are accessible by the user with at least the role.
+// An empty list of devices selects all accessible devices.
+func (c *OwnerCache) GetDevicesOwners(ctx context.Context, devices []string, role pbIS.Role) (map[string]string, error) {
+	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, c.ownerClaim)
+	if err != nil {
+		return nil, kitNetGrpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+
+	owners := make(map[string]string)
+	if err = c.executeOnLockedOwnerSubject(owner, func(s *ownerSubject) error {
+		if !s.devicesSynced {
+			if _, _, err := s.syncDevicesLocked(ctx, owner, c); err != nil {
+				return err
+			}
+		}
+		deviceIDs := s.getDevicesWithRoleLocked(role)
+		if len(devices) > 0 {
+			deviceIDs = deviceIDs.Intersection(strings.MakeSortedSlice(devices))
+		}
+		for _, deviceID := range deviceIDs {
+			if sd, ok := s.sharedDevices[deviceID]; ok && !s.devices.Contains(deviceID) {
+				owners[deviceID] = sd.owner
+				continue
+			}
+			owners[deviceID] = owner
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
 // Convenience method to check if given device is owned by the user
 func (c *OwnerCache) OwnsDevice(ctx context.Context, deviceID string) (bool, error) {
 	return c.OwnsDevices(ctx, []string{deviceID})
@@ -346,6 +464,7 @@ func (c *OwnerCache) getExpiredOwnerSubjects(t time.Time) []string {
 			if s.validUntil.Before(t) {
 				//expire devices in cache - user needs to call UpdateDevices to refresh them
 				s.devices = s.devices[:0]
+				s.sharedDevices = make(map[string]sharedDevice)
 				s.devicesSynced = false
 			}
 			return true
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokens are exchanged again before they expire, so the forwarded token is valid for the request
+const serviceAccountTokenRenewal = time.Second * 30
+
+type serviceAccountToken struct {
+	accessToken string
+	validUntil  time.Time
+}
+
+// ServiceAccountTokens exchanges keys of the service accounts for tokens issued by the identity-store and verifies
+// that the tokens of the service accounts were not revoked.
+//
+// Exchanged tokens are cached until they are close to the expiration, they are removed from the cache when their verification fails.
+// Successful verifications are cached for the verification interval, so revoked service accounts and rotated keys are rejected
+// at most after the interval. Zero interval verifies the token for each request.
+type ServiceAccountTokens struct {
+	client               pbIS.IdentityStoreClient
+	verificationInterval time.Duration
+
+	mutex    sync.Mutex
+	tokens   map[string]serviceAccountToken
+	verified map[string]time.Time
+}
+
+func NewServiceAccountTokens(client pbIS.IdentityStoreClient, verificationInterval time.Duration) *ServiceAccountTokens {
+	return &ServiceAccountTokens{
+		client:               client,
+		verificationInterval: verificationInterval,
+		tokens:               make(map[string]serviceAccountToken),
+		verified:             make(map[string]time.Time),
+	}
+}
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func (t *ServiceAccountTokens) load(id string, now time.Time) (string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	token, ok := t.tokens[id]
+	if !ok || now.After(token.validUntil) {
+		return "", false
+	}
+	return token.accessToken, true
+}
+
+func (t *ServiceAccountTokens) store(id string, token serviceAccountToken, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for k, v := range t.tokens {
+		if now.After(v.validUntil) {
+			delete(t.tokens, k)
+		}
+	}
+	t.tokens[id] = token
+}
+
+func (t *ServiceAccountTokens) isVerified(id string, now time.Time) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	validUntil, ok := t.verified[id]
+	return ok && now.Before(validUntil)
+}
+
+func (t *ServiceAccountTokens) storeVerified(id string, validUntil time.Time, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for k, v := range t.verified {
+		if !now.Before(v) {
+			delete(t.verified, k)
+		}
+	}
+	t.verified[id] = validUntil
+}
+
+// evict removes the token from the caches, so the key must be exchanged again.
+func (t *ServiceAccountTokens) evict(accessToken string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.verified, hashToken(accessToken))
+	for k, v := range t.tokens {
+		if v.accessToken == accessToken {
+			delete(t.tokens, k)
+		}
+	}
+}
+
+// Exchange returns the token of the service account for its key. Other tokens are returned unchanged.
+func (t *ServiceAccountTokens) Exchange(ctx context.Context, token string) (string, error) {
+	if !pbIS.IsServiceAccountKey(token) {
+		return token, nil
+	}
+	id := hashToken(token)
+	now := time.Now()
+	if accessToken, ok := t.load(id, now); ok {
+		return accessToken, nil
+	}
+	resp, err := t.client.GetServiceAccountToken(ctx, &pbIS.GetServiceAccountTokenRequest{
+		Key: token,
+	})
+	if err != nil {
+		return "", kitNetGrpc.ForwardErrorf(codes.Unauthenticated, "cannot exchange service account key: %v", err)
+	}
+	t.store(id, serviceAccountToken{
+		accessToken: resp.GetAccessToken(),
+		validUntil:  pkgTime.Unix(0, resp.GetExpiresAt()).Add(-serviceAccountTokenRenewal),
+	}, now)
+	return resp.GetAccessToken(), nil
+}
+
+func (t *ServiceAccountTokens) getServiceAccount(ctx context.Context, token, serviceAccountID string) (*pbIS.ServiceAccount, error) {
+	stream, err := t.client.GetServiceAccounts(kitNetGrpc.CtxWithToken(ctx, token), &pbIS.GetServiceAccountsRequest{
+		ServiceAccountIdsFilter: []string{serviceAccountID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var serviceAccount *pbIS.ServiceAccount
+	for {
+		a, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return serviceAccount, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if a.GetId() == serviceAccountID {
+			serviceAccount = a
+		}
+	}
+}
+
+// Verify rejects the validated token of the service account, when the service account was revoked or its key was rotated
+// after the token was issued. Tokens of users are accepted.
+func (t *ServiceAccountTokens) Verify(ctx context.Context, token string) error {
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "cannot verify token: %v", err)
+	}
+	serviceAccountID := claims.ServiceAccountID()
+	if serviceAccountID == "" {
+		return nil
+	}
+	id := hashToken(token)
+	now := time.Now()
+	if t.isVerified(id, now) {
+		return nil
+	}
+	a, err := t.getServiceAccount(ctx, token, serviceAccountID)
+	if err != nil {
+		return kitNetGrpc.ForwardErrorf(codes.Unauthenticated, "cannot verify service account('%v'): %v", serviceAccountID, err)
+	}
+	if a == nil {
+		t.evict(token)
+		return status.Errorf(codes.Unauthenticated, "cannot verify service account('%v'): service account was revoked", serviceAccountID)
+	}
+	if claims.ServiceAccountKeyRotatedAt() != strconv.FormatInt(a.GetKeyRotatedAt(), 10) {
+		t.evict(token)
+		return status.Errorf(codes.Unauthenticated, "cannot verify service account('%v'): key of the service account was rotated", serviceAccountID)
+	}
+	if t.verificationInterval <= 0 {
+		return nil
+	}
+	validUntil := now.Add(t.verificationInterval)
+	if exp, err := claims.ExpiresAt(); err == nil && !exp.IsZero() && exp.Before(validUntil) {
+		validUntil = exp
+	}
+	t.storeVerified(id, validUntil, now)
+	return nil
+}
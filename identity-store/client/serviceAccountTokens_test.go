@@ -0,0 +1,202 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	extJwt "github.com/golang-jwt/jwt/v4"
+	idClient "github.com/plgd-dev/hub/identity-store/client"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testServiceAccountsStream struct {
+	grpc.ClientStream
+	accounts []*pb.ServiceAccount
+}
+
+func (s *testServiceAccountsStream) Recv() (*pb.ServiceAccount, error) {
+	if len(s.accounts) == 0 {
+		return nil, io.EOF
+	}
+	a := s.accounts[0]
+	s.accounts = s.accounts[1:]
+	return a, nil
+}
+
+// testIdentityStoreClient issues unsigned tokens for the service accounts and counts the requests.
+type testIdentityStoreClient struct {
+	pb.IdentityStoreClient
+
+	mutex            sync.Mutex
+	accounts         map[string]*pb.ServiceAccount
+	exchanges        int
+	verifications    int
+	verifiedByTokens []string
+}
+
+func makeTestServiceAccountToken(a *pb.ServiceAccount) (string, error) {
+	return extJwt.NewWithClaims(extJwt.SigningMethodNone, extJwt.MapClaims{
+		jwt.ClaimSubject:                    a.GetOwner(),
+		jwt.ClaimExpiresAt:                  time.Now().Add(time.Hour).Unix(),
+		jwt.ClaimServiceAccountID:           a.GetId(),
+		jwt.ClaimServiceAccountKeyRotatedAt: strconv.FormatInt(a.GetKeyRotatedAt(), 10),
+	}).SignedString(extJwt.UnsafeAllowNoneSignatureType)
+}
+
+func (c *testIdentityStoreClient) GetServiceAccountToken(ctx context.Context, in *pb.GetServiceAccountTokenRequest, opts ...grpc.CallOption) (*pb.ServiceAccountToken, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.exchanges++
+	serviceAccountID, _, err := pb.ParseServiceAccountKey(in.GetKey())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	a, ok := c.accounts[serviceAccountID]
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid key")
+	}
+	accessToken, err := makeTestServiceAccountToken(a)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.ServiceAccountToken{
+		AccessToken: accessToken,
+		ExpiresAt:   pkgTime.UnixNano(time.Now().Add(time.Hour)),
+	}, nil
+}
+
+func (c *testIdentityStoreClient) GetServiceAccounts(ctx context.Context, in *pb.GetServiceAccountsRequest, opts ...grpc.CallOption) (pb.IdentityStore_GetServiceAccountsClient, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.verifications++
+	token, err := kitNetGrpc.TokenFromOutgoingMD(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	c.verifiedByTokens = append(c.verifiedByTokens, token)
+	var accounts []*pb.ServiceAccount
+	for _, id := range in.GetServiceAccountIdsFilter() {
+		if a, ok := c.accounts[id]; ok {
+			accounts = append(accounts, a)
+		}
+	}
+	return &testServiceAccountsStream{accounts: accounts}, nil
+}
+
+func (c *testIdentityStoreClient) counters() (int, int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.exchanges, c.verifications
+}
+
+func (c *testIdentityStoreClient) setAccount(a *pb.ServiceAccount) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.accounts[a.GetId()] = a
+}
+
+func (c *testIdentityStoreClient) deleteAccount(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.accounts, id)
+}
+
+func TestServiceAccountTokensExchange(t *testing.T) {
+	c := &testIdentityStoreClient{
+		accounts: map[string]*pb.ServiceAccount{
+			"sa0": {Id: "sa0", Owner: "owner", KeyRotatedAt: 1},
+		},
+	}
+	tokens := idClient.NewServiceAccountTokens(c, time.Hour)
+	ctx := context.Background()
+
+	// tokens of the users are not exchanged
+	token, err := tokens.Exchange(ctx, "token")
+	require.NoError(t, err)
+	require.Equal(t, "token", token)
+
+	key := pb.MakeServiceAccountKey("sa0", "secret")
+	token, err = tokens.Exchange(ctx, key)
+	require.NoError(t, err)
+	claims, err := jwt.ParseToken(token)
+	require.NoError(t, err)
+	require.Equal(t, "sa0", claims.ServiceAccountID())
+
+	// the exchanged token is cached
+	cachedToken, err := tokens.Exchange(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, token, cachedToken)
+	exchanges, _ := c.counters()
+	require.Equal(t, 1, exchanges)
+
+	_, err = tokens.Exchange(ctx, pb.MakeServiceAccountKey("unknown", "secret"))
+	require.Error(t, err)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServiceAccountTokensVerify(t *testing.T) {
+	c := &testIdentityStoreClient{
+		accounts: map[string]*pb.ServiceAccount{
+			"sa0": {Id: "sa0", Owner: "owner", KeyRotatedAt: 1},
+			"sa1": {Id: "sa1", Owner: "owner", KeyRotatedAt: 1},
+		},
+	}
+	tokens := idClient.NewServiceAccountTokens(c, time.Hour)
+	ctx := context.Background()
+
+	// tokens of the users are not verified by the identity-store
+	userToken, err := extJwt.NewWithClaims(extJwt.SigningMethodNone, extJwt.MapClaims{
+		jwt.ClaimSubject: "owner",
+	}).SignedString(extJwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	require.NoError(t, tokens.Verify(ctx, userToken))
+	_, verifications := c.counters()
+	require.Equal(t, 0, verifications)
+
+	// the service account is verified by its own token and the verification is cached
+	key := pb.MakeServiceAccountKey("sa0", "secret")
+	token, err := tokens.Exchange(ctx, key)
+	require.NoError(t, err)
+	require.NoError(t, tokens.Verify(ctx, token))
+	require.NoError(t, tokens.Verify(ctx, token))
+	_, verifications = c.counters()
+	require.Equal(t, 1, verifications)
+	require.Equal(t, []string{token}, c.verifiedByTokens)
+
+	// the token issued for the previous key is rejected after the rotation of the key
+	rotatedTokens := idClient.NewServiceAccountTokens(c, time.Hour)
+	rotatedToken, err := rotatedTokens.Exchange(ctx, key)
+	require.NoError(t, err)
+	c.setAccount(&pb.ServiceAccount{Id: "sa0", Owner: "owner", KeyRotatedAt: 2})
+	err = rotatedTokens.Verify(ctx, rotatedToken)
+	require.Error(t, err)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	// the rejected token is evicted from the cache, so the key is exchanged again
+	exchanges, _ := c.counters()
+	_, err = rotatedTokens.Exchange(ctx, key)
+	require.NoError(t, err)
+	exchangesAfterEviction, _ := c.counters()
+	require.Equal(t, exchanges+1, exchangesAfterEviction)
+
+	// the token of the revoked service account is rejected
+	sa1Tokens := idClient.NewServiceAccountTokens(c, 0)
+	sa1Token, err := sa1Tokens.Exchange(ctx, pb.MakeServiceAccountKey("sa1", "secret"))
+	require.NoError(t, err)
+	require.NoError(t, sa1Tokens.Verify(ctx, sa1Token))
+	c.deleteAccount("sa1")
+	// zero interval verifies each request
+	err = sa1Tokens.Verify(ctx, sa1Token)
+	require.Error(t, err)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -18,3 +18,14 @@ func GetDevicesRegisteredSubject(owner string) string {
 func GetDevicesUnregisteredSubject(owner string) string {
 	return ToSubject(PlgdOwnersOwnerRegistrationsEvent, WithOwner(owner), WithEventType(DevicesUnregisteredEvent))
 }
+
+const DevicesSharedEvent = "devicesshared"
+const DevicesUnsharedEvent = "devicesunshared"
+
+func GetDevicesSharedSubject(owner string) string {
+	return ToSubject(PlgdOwnersOwnerRegistrationsEvent, WithOwner(owner), WithEventType(DevicesSharedEvent))
+}
+
+func GetDevicesUnsharedSubject(owner string) string {
+	return ToSubject(PlgdOwnersOwnerRegistrationsEvent, WithOwner(owner), WithEventType(DevicesUnsharedEvent))
+}
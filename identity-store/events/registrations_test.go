@@ -71,3 +71,67 @@ func TestGetDevicesUnregisteredSubject(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDevicesSharedSubject(t *testing.T) {
+	type args struct {
+		owner string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "ok",
+			args: args{
+				owner: "a",
+			},
+			want: "plgd.owners.e1407479-3136-56c0-9908-bb02fb0339e2.registrations.devicesshared",
+		},
+		{
+			name: "*",
+			args: args{
+				owner: "*",
+			},
+			want: "plgd.owners.*.registrations.devicesshared",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetDevicesSharedSubject(tt.args.owner)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetDevicesUnsharedSubject(t *testing.T) {
+	type args struct {
+		owner string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "ok",
+			args: args{
+				owner: "a",
+			},
+			want: "plgd.owners.e1407479-3136-56c0-9908-bb02fb0339e2.registrations.devicesunshared",
+		},
+		{
+			name: "*",
+			args: args{
+				owner: "*",
+			},
+			want: "plgd.owners.*.registrations.devicesunshared",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetDevicesUnsharedSubject(tt.args.owner)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package pb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ServiceAccountKeyPrefix distinguishes keys of the service accounts from the tokens.
+const ServiceAccountKeyPrefix = "plgdsa_"
+
+// MakeServiceAccountKey creates the key of the service account from its ID and the secret.
+func MakeServiceAccountKey(serviceAccountID, secret string) string {
+	return ServiceAccountKeyPrefix + serviceAccountID + "_" + secret
+}
+
+// IsServiceAccountKey reports whether the bearer token is a key of the service account.
+func IsServiceAccountKey(token string) bool {
+	return strings.HasPrefix(token, ServiceAccountKeyPrefix)
+}
+
+// ParseServiceAccountKey returns the ID of the service account and the secret from the key.
+func ParseServiceAccountKey(key string) (serviceAccountID, secret string, err error) {
+	if !IsServiceAccountKey(key) {
+		return "", "", fmt.Errorf("invalid key prefix")
+	}
+	v := strings.SplitN(strings.TrimPrefix(key, ServiceAccountKeyPrefix), "_", 2)
+	if len(v) != 2 || v[0] == "" || v[1] == "" {
+		return "", "", fmt.Errorf("invalid key format")
+	}
+	return v[0], v[1], nil
+}
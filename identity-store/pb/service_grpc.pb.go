@@ -20,7 +20,23 @@ const _ = grpc.SupportPackageIsVersion7
 type IdentityStoreClient interface {
 	GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (IdentityStore_GetDevicesClient, error)
 	AddDevice(ctx context.Context, in *AddDeviceRequest, opts ...grpc.CallOption) (*AddDeviceResponse, error)
+	// Checks without registering the device that the device isn't owned by another user.
+	CheckDeviceOwner(ctx context.Context, in *CheckDeviceOwnerRequest, opts ...grpc.CallOption) (*CheckDeviceOwnerResponse, error)
 	DeleteDevices(ctx context.Context, in *DeleteDevicesRequest, opts ...grpc.CallOption) (*DeleteDevicesResponse, error)
+	TransferDevices(ctx context.Context, in *TransferDevicesRequest, opts ...grpc.CallOption) (*TransferDevicesResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*Group, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*Group, error)
+	DeleteGroups(ctx context.Context, in *DeleteGroupsRequest, opts ...grpc.CallOption) (*DeleteGroupsResponse, error)
+	GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (IdentityStore_GetGroupsClient, error)
+	ShareDevices(ctx context.Context, in *ShareDevicesRequest, opts ...grpc.CallOption) (*ShareDevicesResponse, error)
+	UnshareDevices(ctx context.Context, in *UnshareDevicesRequest, opts ...grpc.CallOption) (*UnshareDevicesResponse, error)
+	GetDeviceAccess(ctx context.Context, in *GetDeviceAccessRequest, opts ...grpc.CallOption) (IdentityStore_GetDeviceAccessClient, error)
+	CreateServiceAccount(ctx context.Context, in *CreateServiceAccountRequest, opts ...grpc.CallOption) (*CreateServiceAccountResponse, error)
+	GetServiceAccounts(ctx context.Context, in *GetServiceAccountsRequest, opts ...grpc.CallOption) (IdentityStore_GetServiceAccountsClient, error)
+	RotateServiceAccountKey(ctx context.Context, in *RotateServiceAccountKeyRequest, opts ...grpc.CallOption) (*RotateServiceAccountKeyResponse, error)
+	RevokeServiceAccounts(ctx context.Context, in *RevokeServiceAccountsRequest, opts ...grpc.CallOption) (*RevokeServiceAccountsResponse, error)
+	// Exchanges the key of the service account for a short-lived token. It is used by the gateways.
+	GetServiceAccountToken(ctx context.Context, in *GetServiceAccountTokenRequest, opts ...grpc.CallOption) (*ServiceAccountToken, error)
 }
 
 type identityStoreClient struct {
@@ -72,6 +88,15 @@ func (c *identityStoreClient) AddDevice(ctx context.Context, in *AddDeviceReques
 	return out, nil
 }
 
+func (c *identityStoreClient) CheckDeviceOwner(ctx context.Context, in *CheckDeviceOwnerRequest, opts ...grpc.CallOption) (*CheckDeviceOwnerResponse, error) {
+	out := new(CheckDeviceOwnerResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/CheckDeviceOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *identityStoreClient) DeleteDevices(ctx context.Context, in *DeleteDevicesRequest, opts ...grpc.CallOption) (*DeleteDevicesResponse, error) {
 	out := new(DeleteDevicesResponse)
 	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/DeleteDevices", in, out, opts...)
@@ -81,13 +106,215 @@ func (c *identityStoreClient) DeleteDevices(ctx context.Context, in *DeleteDevic
 	return out, nil
 }
 
+func (c *identityStoreClient) TransferDevices(ctx context.Context, in *TransferDevicesRequest, opts ...grpc.CallOption) (*TransferDevicesResponse, error) {
+	out := new(TransferDevicesResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/TransferDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*Group, error) {
+	out := new(Group)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*Group, error) {
+	out := new(Group)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) DeleteGroups(ctx context.Context, in *DeleteGroupsRequest, opts ...grpc.CallOption) (*DeleteGroupsResponse, error) {
+	out := new(DeleteGroupsResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/DeleteGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (IdentityStore_GetGroupsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IdentityStore_ServiceDesc.Streams[1], "/identitystore.pb.IdentityStore/GetGroups", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &identityStoreGetGroupsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IdentityStore_GetGroupsClient interface {
+	Recv() (*Group, error)
+	grpc.ClientStream
+}
+
+type identityStoreGetGroupsClient struct {
+	grpc.ClientStream
+}
+
+func (x *identityStoreGetGroupsClient) Recv() (*Group, error) {
+	m := new(Group)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *identityStoreClient) ShareDevices(ctx context.Context, in *ShareDevicesRequest, opts ...grpc.CallOption) (*ShareDevicesResponse, error) {
+	out := new(ShareDevicesResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/ShareDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) UnshareDevices(ctx context.Context, in *UnshareDevicesRequest, opts ...grpc.CallOption) (*UnshareDevicesResponse, error) {
+	out := new(UnshareDevicesResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/UnshareDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) GetDeviceAccess(ctx context.Context, in *GetDeviceAccessRequest, opts ...grpc.CallOption) (IdentityStore_GetDeviceAccessClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IdentityStore_ServiceDesc.Streams[2], "/identitystore.pb.IdentityStore/GetDeviceAccess", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &identityStoreGetDeviceAccessClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IdentityStore_GetDeviceAccessClient interface {
+	Recv() (*DeviceAccess, error)
+	grpc.ClientStream
+}
+
+type identityStoreGetDeviceAccessClient struct {
+	grpc.ClientStream
+}
+
+func (x *identityStoreGetDeviceAccessClient) Recv() (*DeviceAccess, error) {
+	m := new(DeviceAccess)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *identityStoreClient) CreateServiceAccount(ctx context.Context, in *CreateServiceAccountRequest, opts ...grpc.CallOption) (*CreateServiceAccountResponse, error) {
+	out := new(CreateServiceAccountResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/CreateServiceAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) GetServiceAccounts(ctx context.Context, in *GetServiceAccountsRequest, opts ...grpc.CallOption) (IdentityStore_GetServiceAccountsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IdentityStore_ServiceDesc.Streams[3], "/identitystore.pb.IdentityStore/GetServiceAccounts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &identityStoreGetServiceAccountsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IdentityStore_GetServiceAccountsClient interface {
+	Recv() (*ServiceAccount, error)
+	grpc.ClientStream
+}
+
+type identityStoreGetServiceAccountsClient struct {
+	grpc.ClientStream
+}
+
+func (x *identityStoreGetServiceAccountsClient) Recv() (*ServiceAccount, error) {
+	m := new(ServiceAccount)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *identityStoreClient) RotateServiceAccountKey(ctx context.Context, in *RotateServiceAccountKeyRequest, opts ...grpc.CallOption) (*RotateServiceAccountKeyResponse, error) {
+	out := new(RotateServiceAccountKeyResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/RotateServiceAccountKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) RevokeServiceAccounts(ctx context.Context, in *RevokeServiceAccountsRequest, opts ...grpc.CallOption) (*RevokeServiceAccountsResponse, error) {
+	out := new(RevokeServiceAccountsResponse)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/RevokeServiceAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityStoreClient) GetServiceAccountToken(ctx context.Context, in *GetServiceAccountTokenRequest, opts ...grpc.CallOption) (*ServiceAccountToken, error) {
+	out := new(ServiceAccountToken)
+	err := c.cc.Invoke(ctx, "/identitystore.pb.IdentityStore/GetServiceAccountToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IdentityStoreServer is the server API for IdentityStore service.
 // All implementations must embed UnimplementedIdentityStoreServer
 // for forward compatibility
 type IdentityStoreServer interface {
 	GetDevices(*GetDevicesRequest, IdentityStore_GetDevicesServer) error
 	AddDevice(context.Context, *AddDeviceRequest) (*AddDeviceResponse, error)
+	// Checks without registering the device that the device isn't owned by another user.
+	CheckDeviceOwner(context.Context, *CheckDeviceOwnerRequest) (*CheckDeviceOwnerResponse, error)
 	DeleteDevices(context.Context, *DeleteDevicesRequest) (*DeleteDevicesResponse, error)
+	TransferDevices(context.Context, *TransferDevicesRequest) (*TransferDevicesResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*Group, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*Group, error)
+	DeleteGroups(context.Context, *DeleteGroupsRequest) (*DeleteGroupsResponse, error)
+	GetGroups(*GetGroupsRequest, IdentityStore_GetGroupsServer) error
+	ShareDevices(context.Context, *ShareDevicesRequest) (*ShareDevicesResponse, error)
+	UnshareDevices(context.Context, *UnshareDevicesRequest) (*UnshareDevicesResponse, error)
+	GetDeviceAccess(*GetDeviceAccessRequest, IdentityStore_GetDeviceAccessServer) error
+	CreateServiceAccount(context.Context, *CreateServiceAccountRequest) (*CreateServiceAccountResponse, error)
+	GetServiceAccounts(*GetServiceAccountsRequest, IdentityStore_GetServiceAccountsServer) error
+	RotateServiceAccountKey(context.Context, *RotateServiceAccountKeyRequest) (*RotateServiceAccountKeyResponse, error)
+	RevokeServiceAccounts(context.Context, *RevokeServiceAccountsRequest) (*RevokeServiceAccountsResponse, error)
+	// Exchanges the key of the service account for a short-lived token. It is used by the gateways.
+	GetServiceAccountToken(context.Context, *GetServiceAccountTokenRequest) (*ServiceAccountToken, error)
 	mustEmbedUnimplementedIdentityStoreServer()
 }
 
@@ -101,9 +328,51 @@ func (UnimplementedIdentityStoreServer) GetDevices(*GetDevicesRequest, IdentityS
 func (UnimplementedIdentityStoreServer) AddDevice(context.Context, *AddDeviceRequest) (*AddDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddDevice not implemented")
 }
+func (UnimplementedIdentityStoreServer) CheckDeviceOwner(context.Context, *CheckDeviceOwnerRequest) (*CheckDeviceOwnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDeviceOwner not implemented")
+}
 func (UnimplementedIdentityStoreServer) DeleteDevices(context.Context, *DeleteDevicesRequest) (*DeleteDevicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevices not implemented")
 }
+func (UnimplementedIdentityStoreServer) TransferDevices(context.Context, *TransferDevicesRequest) (*TransferDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferDevices not implemented")
+}
+func (UnimplementedIdentityStoreServer) CreateGroup(context.Context, *CreateGroupRequest) (*Group, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedIdentityStoreServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*Group, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedIdentityStoreServer) DeleteGroups(context.Context, *DeleteGroupsRequest) (*DeleteGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroups not implemented")
+}
+func (UnimplementedIdentityStoreServer) GetGroups(*GetGroupsRequest, IdentityStore_GetGroupsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetGroups not implemented")
+}
+func (UnimplementedIdentityStoreServer) ShareDevices(context.Context, *ShareDevicesRequest) (*ShareDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareDevices not implemented")
+}
+func (UnimplementedIdentityStoreServer) UnshareDevices(context.Context, *UnshareDevicesRequest) (*UnshareDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareDevices not implemented")
+}
+func (UnimplementedIdentityStoreServer) GetDeviceAccess(*GetDeviceAccessRequest, IdentityStore_GetDeviceAccessServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetDeviceAccess not implemented")
+}
+func (UnimplementedIdentityStoreServer) CreateServiceAccount(context.Context, *CreateServiceAccountRequest) (*CreateServiceAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceAccount not implemented")
+}
+func (UnimplementedIdentityStoreServer) GetServiceAccounts(*GetServiceAccountsRequest, IdentityStore_GetServiceAccountsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetServiceAccounts not implemented")
+}
+func (UnimplementedIdentityStoreServer) RotateServiceAccountKey(context.Context, *RotateServiceAccountKeyRequest) (*RotateServiceAccountKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateServiceAccountKey not implemented")
+}
+func (UnimplementedIdentityStoreServer) RevokeServiceAccounts(context.Context, *RevokeServiceAccountsRequest) (*RevokeServiceAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeServiceAccounts not implemented")
+}
+func (UnimplementedIdentityStoreServer) GetServiceAccountToken(context.Context, *GetServiceAccountTokenRequest) (*ServiceAccountToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceAccountToken not implemented")
+}
 func (UnimplementedIdentityStoreServer) mustEmbedUnimplementedIdentityStoreServer() {}
 
 // UnsafeIdentityStoreServer may be embedded to opt out of forward compatibility for this service.
@@ -156,6 +425,24 @@ func _IdentityStore_AddDevice_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IdentityStore_CheckDeviceOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDeviceOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).CheckDeviceOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/CheckDeviceOwner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).CheckDeviceOwner(ctx, req.(*CheckDeviceOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IdentityStore_DeleteDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteDevicesRequest)
 	if err := dec(in); err != nil {
@@ -174,6 +461,249 @@ func _IdentityStore_DeleteDevices_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IdentityStore_TransferDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).TransferDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/TransferDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).TransferDevices(ctx, req.(*TransferDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_DeleteGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).DeleteGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/DeleteGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).DeleteGroups(ctx, req.(*DeleteGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_GetGroups_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetGroupsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IdentityStoreServer).GetGroups(m, &identityStoreGetGroupsServer{stream})
+}
+
+type IdentityStore_GetGroupsServer interface {
+	Send(*Group) error
+	grpc.ServerStream
+}
+
+type identityStoreGetGroupsServer struct {
+	grpc.ServerStream
+}
+
+func (x *identityStoreGetGroupsServer) Send(m *Group) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IdentityStore_ShareDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).ShareDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/ShareDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).ShareDevices(ctx, req.(*ShareDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_UnshareDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).UnshareDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/UnshareDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).UnshareDevices(ctx, req.(*UnshareDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_GetDeviceAccess_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetDeviceAccessRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IdentityStoreServer).GetDeviceAccess(m, &identityStoreGetDeviceAccessServer{stream})
+}
+
+type IdentityStore_GetDeviceAccessServer interface {
+	Send(*DeviceAccess) error
+	grpc.ServerStream
+}
+
+type identityStoreGetDeviceAccessServer struct {
+	grpc.ServerStream
+}
+
+func (x *identityStoreGetDeviceAccessServer) Send(m *DeviceAccess) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IdentityStore_CreateServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).CreateServiceAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/CreateServiceAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).CreateServiceAccount(ctx, req.(*CreateServiceAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_GetServiceAccounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetServiceAccountsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IdentityStoreServer).GetServiceAccounts(m, &identityStoreGetServiceAccountsServer{stream})
+}
+
+type IdentityStore_GetServiceAccountsServer interface {
+	Send(*ServiceAccount) error
+	grpc.ServerStream
+}
+
+type identityStoreGetServiceAccountsServer struct {
+	grpc.ServerStream
+}
+
+func (x *identityStoreGetServiceAccountsServer) Send(m *ServiceAccount) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IdentityStore_RotateServiceAccountKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateServiceAccountKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).RotateServiceAccountKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/RotateServiceAccountKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).RotateServiceAccountKey(ctx, req.(*RotateServiceAccountKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_RevokeServiceAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeServiceAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).RevokeServiceAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/RevokeServiceAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).RevokeServiceAccounts(ctx, req.(*RevokeServiceAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityStore_GetServiceAccountToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceAccountTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityStoreServer).GetServiceAccountToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/identitystore.pb.IdentityStore/GetServiceAccountToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityStoreServer).GetServiceAccountToken(ctx, req.(*GetServiceAccountTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IdentityStore_ServiceDesc is the grpc.ServiceDesc for IdentityStore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,10 +715,54 @@ var IdentityStore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddDevice",
 			Handler:    _IdentityStore_AddDevice_Handler,
 		},
+		{
+			MethodName: "CheckDeviceOwner",
+			Handler:    _IdentityStore_CheckDeviceOwner_Handler,
+		},
 		{
 			MethodName: "DeleteDevices",
 			Handler:    _IdentityStore_DeleteDevices_Handler,
 		},
+		{
+			MethodName: "TransferDevices",
+			Handler:    _IdentityStore_TransferDevices_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _IdentityStore_CreateGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _IdentityStore_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroups",
+			Handler:    _IdentityStore_DeleteGroups_Handler,
+		},
+		{
+			MethodName: "ShareDevices",
+			Handler:    _IdentityStore_ShareDevices_Handler,
+		},
+		{
+			MethodName: "UnshareDevices",
+			Handler:    _IdentityStore_UnshareDevices_Handler,
+		},
+		{
+			MethodName: "CreateServiceAccount",
+			Handler:    _IdentityStore_CreateServiceAccount_Handler,
+		},
+		{
+			MethodName: "RotateServiceAccountKey",
+			Handler:    _IdentityStore_RotateServiceAccountKey_Handler,
+		},
+		{
+			MethodName: "RevokeServiceAccounts",
+			Handler:    _IdentityStore_RevokeServiceAccounts_Handler,
+		},
+		{
+			MethodName: "GetServiceAccountToken",
+			Handler:    _IdentityStore_GetServiceAccountToken_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -196,6 +770,21 @@ var IdentityStore_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _IdentityStore_GetDevices_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetGroups",
+			Handler:       _IdentityStore_GetGroups_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetDeviceAccess",
+			Handler:       _IdentityStore_GetDeviceAccess_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetServiceAccounts",
+			Handler:       _IdentityStore_GetServiceAccounts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "github.com/plgd-dev/hub/identity-store/pb/service.proto",
 }
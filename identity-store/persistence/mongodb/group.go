@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	groupIDKey      = "_id"
+	groupOwnerKey   = "owner"
+	groupMembersKey = "members"
+	memberUserIDKey = "userid"
+)
+
+type dbGroupMember struct {
+	UserID string `bson:"userid"`
+	Role   int32  `bson:"role"`
+}
+
+type dbGroup struct {
+	ID      string          `bson:"_id"`
+	Name    string          `bson:"name"`
+	Owner   string          `bson:"owner"`
+	Members []dbGroupMember `bson:"members"`
+}
+
+func makeGroupRecord(g *persistence.Group) dbGroup {
+	members := make([]dbGroupMember, 0, len(g.Members))
+	for _, m := range g.Members {
+		members = append(members, dbGroupMember{
+			UserID: m.UserID,
+			Role:   int32(m.Role),
+		})
+	}
+	return dbGroup{
+		ID:      g.ID,
+		Name:    g.Name,
+		Owner:   g.Owner,
+		Members: members,
+	}
+}
+
+func (r dbGroup) toGroup(g *persistence.Group) {
+	g.ID = r.ID
+	g.Name = r.Name
+	g.Owner = r.Owner
+	g.Members = make([]persistence.GroupMember, 0, len(r.Members))
+	for _, m := range r.Members {
+		g.Members = append(g.Members, persistence.GroupMember{
+			UserID: m.UserID,
+			Role:   persistence.Role(m.Role),
+		})
+	}
+}
+
+// RetrieveGroup retrieves the group.
+func (p *PersistenceTx) RetrieveGroup(groupID string) (_ *persistence.Group, ok bool, err error) {
+	if p.err != nil {
+		err = p.err
+		return
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(groupsCName)
+	iter, err := col.Find(p.ctx, bson.M{groupIDKey: groupID})
+	if err == mongo.ErrNilDocument {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	it := groupIterator{
+		iter: iter,
+		ctx:  p.ctx,
+	}
+	defer it.Close()
+	var g persistence.Group
+	ok = it.Next(&g)
+	if it.Err() != nil {
+		err = it.Err()
+		return
+	}
+
+	return &g, ok, nil
+}
+
+// RetrieveGroupsByUser retrieves groups owned by the user or groups where the user is a member.
+func (p *PersistenceTx) RetrieveGroupsByUser(userID string) persistence.GroupIterator {
+	if p.err != nil {
+		return &groupIterator{err: p.err}
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(groupsCName)
+	iter, err := col.Find(p.ctx, bson.M{"$or": bson.A{
+		bson.M{groupOwnerKey: userID},
+		bson.M{groupMembersKey + "." + memberUserIDKey: userID},
+	}})
+	if err == mongo.ErrNilDocument {
+		return &groupIterator{}
+	}
+	if err != nil {
+		return &groupIterator{err: fmt.Errorf("cannot load groups of user: %w", err)}
+	}
+
+	return &groupIterator{
+		iter: iter,
+		ctx:  p.ctx,
+	}
+}
+
+// PersistGroup creates or replaces the group.
+func (p *PersistenceTx) PersistGroup(g *persistence.Group) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(groupsCName)
+	upsert := true
+	if _, err := col.ReplaceOne(p.ctx, bson.M{groupIDKey: g.ID}, makeGroupRecord(g), &options.ReplaceOptions{
+		Upsert: &upsert,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteGroup removes the group.
+func (p *PersistenceTx) DeleteGroup(groupID string) error {
+	if p.err != nil {
+		return p.err
+	}
+	col := p.tx.Client().Database(p.dbname).Collection(groupsCName)
+	res, err := col.DeleteOne(p.ctx, bson.M{groupIDKey: groupID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("not found")
+	}
+	return nil
+}
+
+type groupIterator struct {
+	err  error
+	iter *mongo.Cursor
+	ctx  context.Context
+}
+
+func (i *groupIterator) Next(g *persistence.Group) bool {
+	if i.err != nil {
+		return false
+	}
+
+	if !i.iter.Next(i.ctx) {
+		return false
+	}
+
+	var r dbGroup
+	err := i.iter.Decode(&r)
+	if err != nil {
+		return false
+	}
+	r.toGroup(g)
+
+	return true
+}
+
+func (i *groupIterator) Err() error {
+	if i.iter != nil {
+		return i.iter.Err()
+	}
+	return i.err
+}
+
+func (i *groupIterator) Close() {
+	if i.iter != nil {
+		i.err = i.iter.Close(i.ctx)
+	}
+}
@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	outboxIDKey        = "_id"
+	outboxTimestampKey = "timestamp"
+)
+
+type dbOutboxEvent struct {
+	ID        string `bson:"_id"`
+	Subject   string `bson:"subject"`
+	Data      []byte `bson:"data"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+// RetrieveOutboxEvents retrieves the oldest events which were not published yet.
+func (p *PersistenceTx) RetrieveOutboxEvents(limit int64) persistence.OutboxEventIterator {
+	if p.err != nil {
+		return &outboxEventIterator{err: p.err}
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(outboxCName)
+	iter, err := col.Find(p.ctx, bson.M{}, &options.FindOptions{
+		Hint:  outboxQueryIndex,
+		Sort:  outboxQueryIndex,
+		Limit: &limit,
+	})
+	if err == mongo.ErrNilDocument {
+		return &outboxEventIterator{}
+	}
+	if err != nil {
+		return &outboxEventIterator{err: fmt.Errorf("cannot load outbox events: %w", err)}
+	}
+
+	return &outboxEventIterator{
+		iter: iter,
+		ctx:  p.ctx,
+	}
+}
+
+// PersistOutboxEvent stores the event, which is published after the transaction is committed.
+func (p *PersistenceTx) PersistOutboxEvent(e *persistence.OutboxEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(outboxCName)
+	_, err := col.InsertOne(p.ctx, dbOutboxEvent{
+		ID:        e.ID,
+		Subject:   e.Subject,
+		Data:      e.Data,
+		Timestamp: e.Timestamp,
+	})
+	return err
+}
+
+// DeleteOutboxEvent removes the published event.
+func (p *PersistenceTx) DeleteOutboxEvent(id string) error {
+	if p.err != nil {
+		return p.err
+	}
+	col := p.tx.Client().Database(p.dbname).Collection(outboxCName)
+	_, err := col.DeleteOne(p.ctx, bson.M{outboxIDKey: id})
+	return err
+}
+
+type outboxEventIterator struct {
+	err  error
+	iter *mongo.Cursor
+	ctx  context.Context
+}
+
+func (i *outboxEventIterator) Next(e *persistence.OutboxEvent) bool {
+	if i.err != nil {
+		return false
+	}
+
+	if !i.iter.Next(i.ctx) {
+		return false
+	}
+
+	var r dbOutboxEvent
+	err := i.iter.Decode(&r)
+	if err != nil {
+		return false
+	}
+	e.ID = r.ID
+	e.Subject = r.Subject
+	e.Data = r.Data
+	e.Timestamp = r.Timestamp
+
+	return true
+}
+
+func (i *outboxEventIterator) Err() error {
+	if i.iter != nil {
+		return i.iter.Err()
+	}
+	return i.err
+}
+
+func (i *outboxEventIterator) Close() {
+	if i.iter != nil {
+		i.err = i.iter.Close(i.ctx)
+	}
+}
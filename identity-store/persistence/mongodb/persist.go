@@ -21,21 +21,46 @@ type PersistenceTx struct {
 	dbname string
 	err    error
 	ctx    context.Context
+	// transaction is false when the deployment doesn't support transactions, the writes are applied immediately
+	transaction bool
 }
 
 // NewTransaction creates a new transaction.
-// A transaction must always be closed:
+// A transaction must always be closed and the writes must be committed:
 //  tx := s.persistence.NewTransaction()
 //  defer tx.Close()
+//  ...
+//  err := tx.Commit()
+//
+// A standalone mongodb doesn't support transactions, so the writes are applied without a transaction.
 func (p *Store) NewTransaction(ctx context.Context) persistence.PersistenceTx {
 	tx, err := p.Client().StartSession()
-	if err == nil {
+	if err == nil && p.transactions {
 		err = tx.StartTransaction()
 		if err != nil {
 			tx.EndSession(ctx)
+			tx = nil
 		}
 	}
-	return &PersistenceTx{tx: tx, dbname: p.DBName(), err: err, ctx: ctx}
+	if err == nil {
+		// operations must use the session context to be a part of the transaction
+		ctx = mongo.NewSessionContext(ctx, tx)
+	}
+	return &PersistenceTx{tx: tx, dbname: p.DBName(), err: err, ctx: ctx, transaction: p.transactions}
+}
+
+// Commit applies all writes of the transaction. Writes of a transaction which is closed without commit are discarded.
+func (p *PersistenceTx) Commit() error {
+	if p.err != nil {
+		return p.err
+	}
+	if !p.transaction {
+		return nil
+	}
+	if err := p.tx.CommitTransaction(p.ctx); err != nil {
+		return fmt.Errorf("cannot commit transaction: %w", err)
+	}
+	return nil
 }
 
 // Retrieve device's authorization details.
@@ -214,9 +239,6 @@ func (p *PersistenceTx) Persist(d *persistence.AuthorizedDevice) error {
 		return err
 	}
 
-	if err := p.tx.CommitTransaction(p.ctx); err != nil {
-		return fmt.Errorf("cannot commit transaction: %w", err)
-	}
 	return nil
 }
 
@@ -236,10 +258,6 @@ func (p *PersistenceTx) Delete(deviceID, userID string) error {
 	if res.DeletedCount == 0 {
 		return fmt.Errorf("not found")
 	}
-	if err := p.tx.CommitTransaction(p.ctx); err != nil {
-		return fmt.Errorf("cannot commit transaction: %w", err)
-	}
-
 	return nil
 }
 
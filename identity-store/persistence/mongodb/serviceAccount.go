@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	serviceAccountIDKey    = "_id"
+	serviceAccountOwnerKey = "owner"
+)
+
+type dbServiceAccount struct {
+	ID           string   `bson:"_id"`
+	Name         string   `bson:"name"`
+	Owner        string   `bson:"owner"`
+	Scopes       []string `bson:"scopes"`
+	KeyHash      string   `bson:"keyhash"`
+	CreatedAt    int64    `bson:"createdat"`
+	KeyRotatedAt int64    `bson:"keyrotatedat"`
+}
+
+func makeServiceAccountRecord(a *persistence.ServiceAccount) dbServiceAccount {
+	return dbServiceAccount{
+		ID:           a.ID,
+		Name:         a.Name,
+		Owner:        a.Owner,
+		Scopes:       a.Scopes,
+		KeyHash:      a.KeyHash,
+		CreatedAt:    a.CreatedAt,
+		KeyRotatedAt: a.KeyRotatedAt,
+	}
+}
+
+func (r dbServiceAccount) toServiceAccount(a *persistence.ServiceAccount) {
+	a.ID = r.ID
+	a.Name = r.Name
+	a.Owner = r.Owner
+	a.Scopes = r.Scopes
+	a.KeyHash = r.KeyHash
+	a.CreatedAt = r.CreatedAt
+	a.KeyRotatedAt = r.KeyRotatedAt
+}
+
+// RetrieveServiceAccount retrieves the service account.
+func (p *PersistenceTx) RetrieveServiceAccount(serviceAccountID string) (_ *persistence.ServiceAccount, ok bool, err error) {
+	if p.err != nil {
+		err = p.err
+		return
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(serviceAccountsCName)
+	iter, err := col.Find(p.ctx, bson.M{serviceAccountIDKey: serviceAccountID})
+	if err == mongo.ErrNilDocument {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	it := serviceAccountIterator{
+		iter: iter,
+		ctx:  p.ctx,
+	}
+	defer it.Close()
+	var a persistence.ServiceAccount
+	ok = it.Next(&a)
+	if it.Err() != nil {
+		err = it.Err()
+		return
+	}
+
+	return &a, ok, nil
+}
+
+// RetrieveServiceAccountsByOwner retrieves service accounts created by the owner.
+func (p *PersistenceTx) RetrieveServiceAccountsByOwner(owner string) persistence.ServiceAccountIterator {
+	if p.err != nil {
+		return &serviceAccountIterator{err: p.err}
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(serviceAccountsCName)
+	iter, err := col.Find(p.ctx, bson.M{serviceAccountOwnerKey: owner})
+	if err == mongo.ErrNilDocument {
+		return &serviceAccountIterator{}
+	}
+	if err != nil {
+		return &serviceAccountIterator{err: fmt.Errorf("cannot load service accounts of owner: %w", err)}
+	}
+
+	return &serviceAccountIterator{
+		iter: iter,
+		ctx:  p.ctx,
+	}
+}
+
+// PersistServiceAccount creates or replaces the service account.
+func (p *PersistenceTx) PersistServiceAccount(a *persistence.ServiceAccount) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(serviceAccountsCName)
+	upsert := true
+	if _, err := col.ReplaceOne(p.ctx, bson.M{serviceAccountIDKey: a.ID}, makeServiceAccountRecord(a), &options.ReplaceOptions{
+		Upsert: &upsert,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteServiceAccount removes the service account.
+func (p *PersistenceTx) DeleteServiceAccount(serviceAccountID string) error {
+	if p.err != nil {
+		return p.err
+	}
+	col := p.tx.Client().Database(p.dbname).Collection(serviceAccountsCName)
+	res, err := col.DeleteOne(p.ctx, bson.M{serviceAccountIDKey: serviceAccountID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("not found")
+	}
+	return nil
+}
+
+type serviceAccountIterator struct {
+	err  error
+	iter *mongo.Cursor
+	ctx  context.Context
+}
+
+func (i *serviceAccountIterator) Next(a *persistence.ServiceAccount) bool {
+	if i.err != nil || i.iter == nil {
+		return false
+	}
+
+	if !i.iter.Next(i.ctx) {
+		return false
+	}
+
+	var r dbServiceAccount
+	err := i.iter.Decode(&r)
+	if err != nil {
+		return false
+	}
+	r.toServiceAccount(a)
+
+	return true
+}
+
+func (i *serviceAccountIterator) Err() error {
+	if i.iter != nil {
+		return i.iter.Err()
+	}
+	return i.err
+}
+
+func (i *serviceAccountIterator) Close() {
+	if i.iter != nil {
+		i.err = i.iter.Close(i.ctx)
+	}
+}
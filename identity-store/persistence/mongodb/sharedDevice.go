@@ -0,0 +1,137 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	sharedDeviceIDKey = "deviceid"
+	sharedOwnerKey    = "owner"
+	sharedGroupIDKey  = "groupid"
+)
+
+func makeSharedDeviceRecord(d *persistence.SharedDevice) bson.M {
+	return bson.M{
+		sharedDeviceIDKey: d.DeviceID,
+		sharedOwnerKey:    d.Owner,
+		sharedGroupIDKey:  d.GroupID,
+	}
+}
+
+func (p *PersistenceTx) retrieveSharedDevices(filter bson.M, opts ...*options.FindOptions) persistence.SharedDeviceIterator {
+	if p.err != nil {
+		return &sharedDeviceIterator{err: p.err}
+	}
+
+	col := p.tx.Client().Database(p.dbname).Collection(sharedDevicesCName)
+	iter, err := col.Find(p.ctx, filter, opts...)
+	if err == mongo.ErrNilDocument {
+		return &sharedDeviceIterator{}
+	}
+	if err != nil {
+		return &sharedDeviceIterator{err: fmt.Errorf("cannot load shared devices: %w", err)}
+	}
+
+	return &sharedDeviceIterator{
+		iter: iter,
+		ctx:  p.ctx,
+	}
+}
+
+// RetrieveSharedDevicesByGroups retrieves devices shared with the groups.
+func (p *PersistenceTx) RetrieveSharedDevicesByGroups(groupIDs []string) persistence.SharedDeviceIterator {
+	return p.retrieveSharedDevices(bson.M{sharedGroupIDKey: bson.M{"$in": groupIDs}}, &options.FindOptions{
+		Hint: sharedDevicesGroupQueryIndex,
+	})
+}
+
+// RetrieveSharedDevicesByDevice retrieves the groups, which the device is shared with.
+func (p *PersistenceTx) RetrieveSharedDevicesByDevice(deviceID string) persistence.SharedDeviceIterator {
+	return p.retrieveSharedDevices(bson.M{sharedDeviceIDKey: deviceID}, &options.FindOptions{
+		Hint: sharedDeviceQueryIndex,
+	})
+}
+
+// PersistSharedDevice shares the device with the group.
+func (p *PersistenceTx) PersistSharedDevice(d *persistence.SharedDevice) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	record := makeSharedDeviceRecord(d)
+	col := p.tx.Client().Database(p.dbname).Collection(sharedDevicesCName)
+	upsert := true
+	if _, err := col.UpdateOne(p.ctx, bson.M{sharedDeviceIDKey: d.DeviceID, sharedGroupIDKey: d.GroupID}, bson.M{"$set": record}, &options.UpdateOptions{
+		Upsert: &upsert,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSharedDevice unshares the device from the group.
+func (p *PersistenceTx) DeleteSharedDevice(deviceID, groupID string) error {
+	if p.err != nil {
+		return p.err
+	}
+	col := p.tx.Client().Database(p.dbname).Collection(sharedDevicesCName)
+	res, err := col.DeleteOne(p.ctx, bson.M{
+		sharedDeviceIDKey: deviceID,
+		sharedGroupIDKey:  groupID,
+	})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("not found")
+	}
+	return nil
+}
+
+type sharedDeviceIterator struct {
+	err  error
+	iter *mongo.Cursor
+	ctx  context.Context
+}
+
+func (i *sharedDeviceIterator) Next(d *persistence.SharedDevice) bool {
+	if i.err != nil {
+		return false
+	}
+
+	var sub bson.M
+
+	if !i.iter.Next(i.ctx) {
+		return false
+	}
+
+	err := i.iter.Decode(&sub)
+	if err != nil {
+		return false
+	}
+	d.DeviceID = sub[sharedDeviceIDKey].(string)
+	d.Owner = sub[sharedOwnerKey].(string)
+	d.GroupID = sub[sharedGroupIDKey].(string)
+
+	return true
+}
+
+func (i *sharedDeviceIterator) Err() error {
+	if i.iter != nil {
+		return i.iter.Err()
+	}
+	return i.err
+}
+
+func (i *sharedDeviceIterator) Close() {
+	if i.iter != nil {
+		i.err = i.iter.Close(i.ctx)
+	}
+}
@@ -3,12 +3,18 @@ package mongodb
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 
+	"github.com/plgd-dev/hub/pkg/log"
 	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 const userDevicesCName = "userdevices"
+const groupsCName = "groups"
+const sharedDevicesCName = "shareddevices"
+const outboxCName = "outbox"
+const serviceAccountsCName = "serviceaccounts"
 
 var userDeviceQueryIndex = bson.D{
 	{Key: ownerKey, Value: 1},
@@ -19,8 +25,48 @@ var userDevicesQueryIndex = bson.D{
 	{Key: ownerKey, Value: 1},
 }
 
+var groupOwnerQueryIndex = bson.D{
+	{Key: groupOwnerKey, Value: 1},
+}
+
+var groupMembersQueryIndex = bson.D{
+	{Key: groupMembersKey + "." + memberUserIDKey, Value: 1},
+}
+
+var sharedDeviceQueryIndex = bson.D{
+	{Key: sharedDeviceIDKey, Value: 1},
+	{Key: sharedGroupIDKey, Value: 1},
+}
+
+var sharedDevicesGroupQueryIndex = bson.D{
+	{Key: sharedGroupIDKey, Value: 1},
+}
+
+var outboxQueryIndex = bson.D{
+	{Key: outboxTimestampKey, Value: 1},
+	{Key: outboxIDKey, Value: 1},
+}
+
+var serviceAccountOwnerQueryIndex = bson.D{
+	{Key: serviceAccountOwnerKey, Value: 1},
+}
+
 type Store struct {
 	*pkgMongo.Store
+	// transactions are supported only by a replica set or a sharded cluster
+	transactions bool
+}
+
+// supportsTransactions checks whether the deployment is a replica set or a sharded cluster.
+func supportsTransactions(ctx context.Context, s *pkgMongo.Store) (bool, error) {
+	var resp struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := s.Client().Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&resp); err != nil {
+		return false, err
+	}
+	return resp.SetName != "" || resp.Msg == "isdbgrid", nil
 }
 
 func NewStore(ctx context.Context, cfg pkgMongo.Config, tls *tls.Config) (*Store, error) {
@@ -28,5 +74,29 @@ func NewStore(ctx context.Context, cfg pkgMongo.Config, tls *tls.Config) (*Store
 	if err != nil {
 		return nil, err
 	}
-	return &Store{s}, nil
+	if err := s.EnsureIndex(ctx, groupsCName, groupOwnerQueryIndex, groupMembersQueryIndex); err != nil {
+		_ = s.Close(ctx)
+		return nil, err
+	}
+	if err := s.EnsureIndex(ctx, sharedDevicesCName, sharedDeviceQueryIndex, sharedDevicesGroupQueryIndex); err != nil {
+		_ = s.Close(ctx)
+		return nil, err
+	}
+	if err := s.EnsureIndex(ctx, outboxCName, outboxQueryIndex); err != nil {
+		_ = s.Close(ctx)
+		return nil, err
+	}
+	if err := s.EnsureIndex(ctx, serviceAccountsCName, serviceAccountOwnerQueryIndex); err != nil {
+		_ = s.Close(ctx)
+		return nil, err
+	}
+	transactions, err := supportsTransactions(ctx, s)
+	if err != nil {
+		_ = s.Close(ctx)
+		return nil, fmt.Errorf("cannot detect support of transactions: %w", err)
+	}
+	if !transactions {
+		log.Warnf("mongodb is not a replica set, writes of the identity-store are not atomic: an event can be lost when the service fails during a write; configure a replica set to use transactions")
+	}
+	return &Store{Store: s, transactions: transactions}, nil
 }
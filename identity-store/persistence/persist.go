@@ -6,12 +6,112 @@ type AuthorizedDevice struct {
 	Owner    string `db:"owner"`
 }
 
+// Role of the group member.
+type Role int32
+
+const (
+	// RoleViewer reads the devices shared with the group.
+	RoleViewer Role = 0
+	// RoleOperator reads the devices and sends commands to them.
+	RoleOperator Role = 1
+	// RoleOwner is the operator, who manages the group and shares the devices with it.
+	RoleOwner Role = 2
+)
+
+// GroupMember comprises user's membership in the group.
+type GroupMember struct {
+	UserID string `db:"user_id"`
+	Role   Role   `db:"role"`
+}
+
+// Group comprises members, which access the devices shared with the group.
+type Group struct {
+	ID      string        `db:"id"`
+	Name    string        `db:"name"`
+	Owner   string        `db:"owner"`
+	Members []GroupMember `db:"members"`
+}
+
+// GetRole returns the role of the user in the group. The owner of the group has the RoleOwner.
+func (g *Group) GetRole(userID string) (Role, bool) {
+	if g.Owner == userID {
+		return RoleOwner, true
+	}
+	for _, m := range g.Members {
+		if m.UserID == userID {
+			return m.Role, true
+		}
+	}
+	return RoleViewer, false
+}
+
+// GetUserIDs returns the owner and the members of the group.
+func (g *Group) GetUserIDs() []string {
+	userIDs := make([]string, 0, len(g.Members)+1)
+	userIDs = append(userIDs, g.Owner)
+	for _, m := range g.Members {
+		userIDs = append(userIDs, m.UserID)
+	}
+	return userIDs
+}
+
+// SharedDevice comprises the device shared by its owner with the group.
+type SharedDevice struct {
+	DeviceID string `db:"device_id"`
+	Owner    string `db:"owner"`
+	GroupID  string `db:"group_id"`
+}
+
+// OutboxEvent is an event stored by the transaction, which is published to the eventbus after the commit.
+type OutboxEvent struct {
+	ID        string `db:"id"`
+	Subject   string `db:"subject"`
+	Data      []byte `db:"data"`
+	Timestamp int64  `db:"timestamp"`
+}
+
+// ServiceAccount comprises the credential of the machine client, which accesses the devices of the owner.
+// Only the hash of the key is stored.
+type ServiceAccount struct {
+	ID           string   `db:"id"`
+	Name         string   `db:"name"`
+	Owner        string   `db:"owner"`
+	Scopes       []string `db:"scopes"`
+	KeyHash      string   `db:"key_hash"`
+	CreatedAt    int64    `db:"created_at"`
+	KeyRotatedAt int64    `db:"key_rotated_at"`
+}
+
 type Iterator interface {
 	Err() error
 	Next(v *AuthorizedDevice) bool
 	Close()
 }
 
+type GroupIterator interface {
+	Err() error
+	Next(v *Group) bool
+	Close()
+}
+
+type SharedDeviceIterator interface {
+	Err() error
+	Next(v *SharedDevice) bool
+	Close()
+}
+
+type OutboxEventIterator interface {
+	Err() error
+	Next(v *OutboxEvent) bool
+	Close()
+}
+
+type ServiceAccountIterator interface {
+	Err() error
+	Next(v *ServiceAccount) bool
+	Close()
+}
+
 type PersistenceTx interface {
 	Retrieve(deviceID, owner string) (_ *AuthorizedDevice, ok bool, err error)
 	RetrieveByDevice(deviceID string) (_ *AuthorizedDevice, ok bool, err error)
@@ -19,5 +119,26 @@ type PersistenceTx interface {
 	RetrieveAll() Iterator
 	Persist(d *AuthorizedDevice) error
 	Delete(deviceID, owner string) error
+
+	RetrieveGroup(groupID string) (_ *Group, ok bool, err error)
+	RetrieveGroupsByUser(userID string) GroupIterator
+	PersistGroup(g *Group) error
+	DeleteGroup(groupID string) error
+
+	RetrieveSharedDevicesByGroups(groupIDs []string) SharedDeviceIterator
+	RetrieveSharedDevicesByDevice(deviceID string) SharedDeviceIterator
+	PersistSharedDevice(d *SharedDevice) error
+	DeleteSharedDevice(deviceID, groupID string) error
+
+	RetrieveOutboxEvents(limit int64) OutboxEventIterator
+	PersistOutboxEvent(e *OutboxEvent) error
+	DeleteOutboxEvent(id string) error
+
+	RetrieveServiceAccount(serviceAccountID string) (_ *ServiceAccount, ok bool, err error)
+	RetrieveServiceAccountsByOwner(owner string) ServiceAccountIterator
+	PersistServiceAccount(a *ServiceAccount) error
+	DeleteServiceAccount(serviceAccountID string) error
+
+	Commit() error
 	Close()
 }
@@ -12,13 +12,12 @@ import (
 	"github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/hub/pkg/security/jwt"
 	pkgTime "github.com/plgd-dev/hub/pkg/time"
-	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func (s *Service) publishDevicesRegistered(owner, userID string, deviceID []string) error {
-	v := events.Event{
+func publishDevicesRegistered(tx persistence.PersistenceTx, owner, userID string, deviceID []string) error {
+	return publishEvent(tx, events.GetDevicesRegisteredSubject(owner), &events.Event{
 		Type: &events.Event_DevicesRegistered{
 			DevicesRegistered: &events.DevicesRegistered{
 				Owner:     owner,
@@ -29,23 +28,7 @@ func (s *Service) publishDevicesRegistered(owner, userID string, deviceID []stri
 				Timestamp: pkgTime.UnixNano(time.Now()),
 			},
 		},
-	}
-	data, err := utils.Marshal(&v)
-	if err != nil {
-		return err
-	}
-
-	err = s.publisher.PublishData(events.GetDevicesRegisteredSubject(owner), data)
-	if err != nil {
-		return err
-	}
-
-	// timeout si driven by flusherTimeout.
-	err = s.publisher.Flush(context.Background())
-	if err != nil {
-		return err
-	}
-	return nil
+	})
 }
 
 func (s *Service) parseTokenMD(ctx context.Context) (owner, subject string, err error) {
@@ -57,14 +40,18 @@ func (s *Service) parseTokenMD(ctx context.Context) (owner, subject string, err
 	if err != nil {
 		return "", "", grpc.ForwardFromError(codes.InvalidArgument, err)
 	}
-	owner = claims.Owner(s.ownerClaim)
-	if owner == "" {
-		return "", "", status.Errorf(codes.InvalidArgument, "%v", fmt.Errorf("claim '%v' was not found", s.ownerClaim))
+	owner, err = grpc.OwnerFromClaims(ctx, s.ownerClaim, claims)
+	if err != nil {
+		return "", "", grpc.ForwardFromError(codes.InvalidArgument, err)
 	}
 	subject = claims.Subject()
 	if owner == "" {
 		return "", "", status.Errorf(codes.InvalidArgument, "%v", fmt.Errorf("claim '%v' was not found", "sub"))
 	}
+	if serviceAccountID := claims.ServiceAccountID(); serviceAccountID != "" {
+		// changes made by the service account are audited by its ID
+		subject = serviceAccountID
+	}
 	return
 }
 
@@ -101,10 +88,11 @@ func (s *Service) AddDevice(ctx context.Context, request *pb.AddDeviceRequest) (
 	if err := tx.Persist(&d); err != nil {
 		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot add device up: %v", err.Error()))
 	}
-
-	err = s.publishDevicesRegistered(owner, userID, []string{request.DeviceId})
-	if err != nil {
-		log.Errorf("cannot publish devices registered event with device('%v') and owner('%v'): %w", request.DeviceId, owner, err)
+	if err := publishDevicesRegistered(tx, owner, userID, []string{request.DeviceId}); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot add device up: cannot publish devices registered event: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot add device up: %v", err))
 	}
 
 	return &pb.AddDeviceResponse{}, nil
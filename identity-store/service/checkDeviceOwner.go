@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckDeviceOwner checks that the device isn't owned by another user. Unlike AddDevice, it doesn't register the device to the user.
+func (s *Service) CheckDeviceOwner(ctx context.Context, request *pb.CheckDeviceOwnerRequest) (*pb.CheckDeviceOwnerResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, err := grpc.OwnerFromTokenMD(ctx, s.ownerClaim)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot check device owner: %v", err))
+	}
+	if request.GetDeviceId() == "" {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot check device owner: invalid DeviceId"))
+	}
+
+	dev, ok, err := tx.RetrieveByDevice(request.GetDeviceId())
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot check device owner: %v", err))
+	}
+	if !ok {
+		return &pb.CheckDeviceOwnerResponse{}, nil
+	}
+	if dev.Owner != owner {
+		return nil, log.LogAndReturnError(status.Errorf(codes.PermissionDenied, "cannot check device owner: device %v is owned by another user", request.GetDeviceId()))
+	}
+	return &pb.CheckDeviceOwnerResponse{Owned: true}, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServiceCheckDeviceOwner(t *testing.T) {
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser2,
+	})
+	type args struct {
+		ctx     context.Context
+		request *pb.CheckDeviceOwnerRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     *pb.CheckDeviceOwnerResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "invalid userId",
+			args: args{
+				ctx:     context.Background(),
+				request: &pb.CheckDeviceOwnerRequest{DeviceId: testDeviceID},
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "invalid deviceId",
+			args: args{
+				ctx:     grpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.CheckDeviceOwnerRequest{},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "owned by another user",
+			args: args{
+				ctx:     grpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2),
+				request: &pb.CheckDeviceOwnerRequest{DeviceId: testDeviceID},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "owned",
+			args: args{
+				ctx:     grpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.CheckDeviceOwnerRequest{DeviceId: testDeviceID},
+			},
+			want: &pb.CheckDeviceOwnerResponse{Owned: true},
+		},
+		{
+			name: "not registered",
+			args: args{
+				ctx:     grpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2),
+				request: &pb.CheckDeviceOwnerRequest{DeviceId: "notRegisteredDeviceID"},
+			},
+			want: &pb.CheckDeviceOwnerResponse{},
+		},
+	}
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	persistDevice(t, s.service.persistence, newTestDevice())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.service.CheckDeviceOwner(tt.args.ctx, tt.args.request)
+			if tt.wantCode != codes.OK {
+				require.Error(t, err)
+				require.Equal(t, tt.wantCode, status.Convert(err).Code())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.GetOwned(), got.GetOwned())
+		})
+	}
+
+	// the check doesn't register the device
+	tx := s.service.persistence.NewTransaction(context.Background())
+	defer tx.Close()
+	_, ok, err := tx.RetrieveByDevice("notRegisteredDeviceID")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -2,19 +2,24 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/mongodb"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 	"github.com/plgd-dev/kit/v2/config"
 )
 
 // Config provides defaults and enables configuring via env variables.
 type Config struct {
-	Log     log.Config    `yaml:"log" json:"log"`
-	APIs    APIsConfig    `yaml:"apis" json:"apis"`
-	Clients ClientsConfig `yaml:"clients" json:"clients"`
+	Log             log.Config            `yaml:"log" json:"log"`
+	APIs            APIsConfig            `yaml:"apis" json:"apis"`
+	Clients         ClientsConfig         `yaml:"clients" json:"clients"`
+	ServiceAccounts ServiceAccountsConfig `yaml:"serviceAccounts" json:"serviceAccounts"`
+	DeviceTransfer  DeviceTransferConfig  `yaml:"deviceTransfer" json:"deviceTransfer"`
 }
 
 func (c *Config) Validate() error {
@@ -24,23 +29,71 @@ func (c *Config) Validate() error {
 	if err := c.APIs.Validate(); err != nil {
 		return fmt.Errorf("apis.%w", err)
 	}
+	if err := c.ServiceAccounts.Validate(); err != nil {
+		return fmt.Errorf("serviceAccounts.%w", err)
+	}
+	if err := c.DeviceTransfer.Validate(); err != nil {
+		return fmt.Errorf("deviceTransfer.%w", err)
+	}
+	return nil
+}
+
+// DeviceTransferConfig restricts the transfer of devices between owners. The new owner doesn't accept the devices,
+// so only privileged users, e.g. administrators, are allowed to transfer them.
+type DeviceTransferConfig struct {
+	// RequiredScope must be in the token of the user transferring the devices.
+	RequiredScope string `yaml:"requiredScope" json:"requiredScope"`
+}
+
+func (c *DeviceTransferConfig) Validate() error {
+	if c.RequiredScope == "" {
+		return fmt.Errorf("requiredScope('%v')", c.RequiredScope)
+	}
+	return nil
+}
+
+// ServiceAccountsConfig configures tokens issued for the keys of the service accounts.
+type ServiceAccountsConfig struct {
+	// Issuer of the tokens, the tokens are not issued when it is empty. Services trust the tokens by the authority
+	// with the issuer and the public key of KeyFile.
+	Issuer string `yaml:"issuer" json:"issuer"`
+	// KeyFile contains the RSA or ECDSA P-256 private key signing the tokens.
+	KeyFile         string        `yaml:"keyFile" json:"keyFile"`
+	TokenExpiration time.Duration `yaml:"tokenExpiration" json:"tokenExpiration"`
+}
+
+func (c *ServiceAccountsConfig) Validate() error {
+	if c.Issuer == "" {
+		return nil
+	}
+	if c.KeyFile == "" {
+		return fmt.Errorf("keyFile('%v')", c.KeyFile)
+	}
+	if c.TokenExpiration <= 0 {
+		return fmt.Errorf("tokenExpiration('%v')", c.TokenExpiration)
+	}
 	return nil
 }
 
 type APIsConfig struct {
-	GRPC server.Config `yaml:"grpc" json:"grpc"`
+	GRPC    server.Config  `yaml:"grpc" json:"grpc"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.GRPC.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
 type ClientsConfig struct {
-	Storage  StorageConfig  `yaml:"storage" json:"storage"`
-	Eventbus EventBusConfig `yaml:"eventBus" json:"eventBus"`
+	Storage                StorageConfig        `yaml:"storage" json:"storage"`
+	Eventbus               EventBusConfig       `yaml:"eventBus" json:"eventBus"`
+	OpenTelemetryCollector opentelemetry.Config `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 func (c *ClientsConfig) Validate() error {
@@ -50,17 +103,24 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.Eventbus.Validate(); err != nil {
 		return fmt.Errorf("eventBus.%w", err)
 	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
 type EventBusConfig struct {
-	NATS natsClient.ConfigPublisher `yaml:"nats" json:"nats"`
+	NATS                natsClient.ConfigPublisher `yaml:"nats" json:"nats"`
+	OutboxRetryInterval time.Duration              `yaml:"outboxRetryInterval" json:"outboxRetryInterval"`
 }
 
 func (c *EventBusConfig) Validate() error {
 	if err := c.NATS.Validate(); err != nil {
 		return fmt.Errorf("nats.%w", err)
 	}
+	if c.OutboxRetryInterval <= 0 {
+		return fmt.Errorf("outboxRetryInterval('%v')", c.OutboxRetryInterval)
+	}
 	return nil
 }
 
@@ -11,7 +11,6 @@ import (
 	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/hub/pkg/net/grpc"
 	pkgTime "github.com/plgd-dev/hub/pkg/time"
-	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
 	"github.com/plgd-dev/kit/v2/strings"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,8 +37,8 @@ func getOwnerDevices(tx persistence.PersistenceTx, owner string) ([]string, erro
 	return deviceIds, nil
 }
 
-func (s *Service) publishDevicesUnregistered(owner, userID string, deviceIDs []string) error {
-	v := events.Event{
+func publishDevicesUnregistered(tx persistence.PersistenceTx, owner, userID string, deviceIDs []string) error {
+	return publishEvent(tx, events.GetDevicesUnregisteredSubject(owner), &events.Event{
 		Type: &events.Event_DevicesUnregistered{
 			DevicesUnregistered: &events.DevicesUnregistered{
 				Owner:     owner,
@@ -50,23 +49,7 @@ func (s *Service) publishDevicesUnregistered(owner, userID string, deviceIDs []s
 				Timestamp: pkgTime.UnixNano(time.Now()),
 			},
 		},
-	}
-	data, err := utils.Marshal(&v)
-	if err != nil {
-		return err
-	}
-
-	err = s.publisher.PublishData(events.GetDevicesUnregisteredSubject(owner), data)
-	if err != nil {
-		return err
-	}
-
-	// timeout si driven by flusherTimeout.
-	err = s.publisher.Flush(context.Background())
-	if err != nil {
-		return err
-	}
-	return nil
+	})
 }
 
 func getDeviceIds(request *pb.DeleteDevicesRequest, tx persistence.PersistenceTx, owner string) ([]string, error) {
@@ -85,20 +68,62 @@ func getDeviceIds(request *pb.DeleteDevicesRequest, tx persistence.PersistenceTx
 	return deviceIds, nil
 }
 
-func deleteDevice(tx persistence.PersistenceTx, deviceId, owner string) (bool, error) {
+// unshareDevice removes the device from all groups and returns IDs of the groups.
+func unshareDevice(tx persistence.PersistenceTx, deviceId string) ([]string, error) {
+	it := tx.RetrieveSharedDevicesByDevice(deviceId)
+	var groupIDs []string
+	var d persistence.SharedDevice
+	for it.Next(&d) {
+		groupIDs = append(groupIDs, d.GroupID)
+	}
+	it.Close()
+	if it.Err() != nil {
+		return nil, fmt.Errorf("failed to obtain shared devices: %w", it.Err())
+	}
+	for _, groupID := range groupIDs {
+		if err := tx.DeleteSharedDevice(deviceId, groupID); err != nil {
+			return nil, err
+		}
+	}
+	return groupIDs, nil
+}
+
+func deleteDevice(tx persistence.PersistenceTx, deviceId, owner string) (bool, []string, error) {
 	_, ok, err := tx.Retrieve(deviceId, owner)
 	if err != nil {
-		return false, status.Errorf(codes.Internal, "cannot delete device('%v'): %v", deviceId, err.Error())
+		return false, nil, status.Errorf(codes.Internal, "cannot delete device('%v'): %v", deviceId, err.Error())
 	}
 	if !ok {
 		log.Debugf("cannot retrieve device by user('%v')", owner)
-		return false, nil
+		return false, nil, nil
+	}
+
+	groupIDs, err := unshareDevice(tx, deviceId)
+	if err != nil {
+		return false, nil, status.Errorf(codes.Internal, "cannot unshare device('%v'): %v", deviceId, err.Error())
 	}
 
 	if err = tx.Delete(deviceId, owner); err != nil {
-		return false, status.Errorf(codes.NotFound, "cannot delete device('%v'): not found", deviceId)
+		return false, nil, status.Errorf(codes.NotFound, "cannot delete device('%v'): not found", deviceId)
 	}
-	return true, nil
+	return true, groupIDs, nil
+}
+
+// publishGroupsDevicesUnshared notifies users of the groups about devices which were unshared by deletion.
+func publishGroupsDevicesUnshared(tx persistence.PersistenceTx, userID string, groupDevices map[string][]string) error {
+	for groupID, deviceIDs := range groupDevices {
+		g, ok, err := tx.RetrieveGroup(groupID)
+		if err != nil {
+			return fmt.Errorf("cannot retrieve group('%v'): %w", groupID, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := publishDevicesUnshared(tx, groupID, g.GetUserIDs(), userID, deviceIDs); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DeleteDevices removes a devices from user.
@@ -120,8 +145,9 @@ func (s *Service) DeleteDevices(ctx context.Context, request *pb.DeleteDevicesRe
 	}
 
 	var deletedDeviceIds []string
+	groupDevices := make(map[string][]string)
 	for _, deviceId := range deviceIds {
-		ok, err := deleteDevice(tx, deviceId, owner)
+		ok, groupIDs, err := deleteDevice(tx, deviceId, owner)
 		if err != nil {
 			return nil, log.LogAndReturnError(err)
 		}
@@ -129,10 +155,21 @@ func (s *Service) DeleteDevices(ctx context.Context, request *pb.DeleteDevicesRe
 			continue
 		}
 		deletedDeviceIds = append(deletedDeviceIds, deviceId)
+		for _, groupID := range groupIDs {
+			groupDevices[groupID] = append(groupDevices[groupID], deviceId)
+		}
 	}
 
-	if err := s.publishDevicesUnregistered(owner, userID, deletedDeviceIds); err != nil {
-		log.Errorf("cannot publish devices unregistered event with devices('%v') and owner('%v'): %w", deletedDeviceIds, owner, err)
+	if len(deletedDeviceIds) > 0 {
+		if err := publishDevicesUnregistered(tx, owner, userID, deletedDeviceIds); err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot delete devices: cannot publish devices unregistered event: %v", err))
+		}
+	}
+	if err := publishGroupsDevicesUnshared(tx, userID, groupDevices); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot delete devices: cannot publish devices unshared events: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot delete devices: %v", err))
 	}
 
 	return &pb.DeleteDevicesResponse{
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type deviceAccess struct {
+	owner string
+	role  persistence.Role
+}
+
+// getDeviceAccess returns devices accessible by the user. The owned devices have the owner role, the shared devices
+// have the highest role of the user in the groups which share them.
+func getDeviceAccess(tx persistence.PersistenceTx, userID string) (map[string]deviceAccess, error) {
+	ownedDevices, err := getOwnerDevices(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	access := make(map[string]deviceAccess, len(ownedDevices))
+	for _, deviceID := range ownedDevices {
+		access[deviceID] = deviceAccess{owner: userID, role: persistence.RoleOwner}
+	}
+
+	groups, err := getUserGroups(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return access, nil
+	}
+	groupIDs := make([]string, 0, len(groups))
+	roles := make(map[string]persistence.Role, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+		roles[g.ID], _ = g.GetRole(userID)
+	}
+
+	it := tx.RetrieveSharedDevicesByGroups(groupIDs)
+	defer it.Close()
+	var d persistence.SharedDevice
+	for it.Next(&d) {
+		role := roles[d.GroupID]
+		if a, ok := access[d.DeviceID]; ok && a.role >= role {
+			continue
+		}
+		access[d.DeviceID] = deviceAccess{owner: d.Owner, role: role}
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("failed to obtain shared devices: %w", it.Err())
+	}
+	return access, nil
+}
+
+// GetDeviceAccess returns owned devices and devices shared with the user with the role of the user.
+func (s *Service) GetDeviceAccess(request *pb.GetDeviceAccessRequest, srv pb.IdentityStore_GetDeviceAccessServer) error {
+	tx := s.persistence.NewTransaction(srv.Context())
+	defer tx.Close()
+
+	owner, err := grpc.OwnerFromTokenMD(srv.Context(), s.ownerClaim)
+	if err != nil {
+		return log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot get device access: %v", err))
+	}
+
+	access, err := getDeviceAccess(tx, owner)
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.Internal, "cannot get device access: %v", err))
+	}
+
+	deviceIdFilter := make(map[string]bool)
+	for _, deviceID := range request.GetDeviceIdsFilter() {
+		deviceIdFilter[deviceID] = true
+	}
+	ids := make([]string, 0, len(access))
+	for deviceID := range access {
+		if hasMatchDeviceID(deviceID, deviceIdFilter) {
+			ids = append(ids, deviceID)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, deviceID := range ids {
+		a := access[deviceID]
+		err := srv.Send(&pb.DeviceAccess{
+			DeviceId: deviceID,
+			Owner:    a.owner,
+			Role:     pb.Role(a.role),
+		})
+		if err != nil {
+			return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot get device access: %v", err))
+		}
+	}
+	return nil
+}
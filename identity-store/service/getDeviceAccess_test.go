@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestServiceGetDeviceAccess(t *testing.T) {
+	const testDevID1 = "testDeviceID1"
+	const testUser2DevID1 = "test2DeviceID1"
+	const testUser3 = "testUser3"
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser2,
+	})
+	jwtWithSubTestUser3 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser3,
+	})
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testDevID1, testUserID))
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testUser2DevID1, testUser2))
+
+	ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID)
+	viewers, err := s.service.CreateGroup(ctx, &pb.CreateGroupRequest{
+		Name:    "viewers",
+		Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_VIEWER}, {UserId: testUser3, Role: pb.Role_VIEWER}},
+	})
+	require.NoError(t, err)
+	operators, err := s.service.CreateGroup(ctx, &pb.CreateGroupRequest{
+		Name:    "operators",
+		Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OPERATOR}},
+	})
+	require.NoError(t, err)
+	_, err = s.service.ShareDevices(ctx, &pb.ShareDevicesRequest{GroupId: viewers.GetId(), DeviceIds: []string{testDevID1}})
+	require.NoError(t, err)
+	_, err = s.service.ShareDevices(ctx, &pb.ShareDevicesRequest{GroupId: operators.GetId(), DeviceIds: []string{testDevID1}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]*pb.DeviceAccess
+	}{
+		{
+			name: "owner",
+			ctx:  ctx,
+			want: map[string]*pb.DeviceAccess{
+				testDevID1: {DeviceId: testDevID1, Owner: testUserID, Role: pb.Role_OWNER},
+			},
+		},
+		{
+			name: "highest role",
+			ctx:  kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2),
+			want: map[string]*pb.DeviceAccess{
+				testDevID1:      {DeviceId: testDevID1, Owner: testUserID, Role: pb.Role_OPERATOR},
+				testUser2DevID1: {DeviceId: testUser2DevID1, Owner: testUser2, Role: pb.Role_OWNER},
+			},
+		},
+		{
+			name: "viewer",
+			ctx:  kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser3),
+			want: map[string]*pb.DeviceAccess{
+				testDevID1: {DeviceId: testDevID1, Owner: testUserID, Role: pb.Role_VIEWER},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newMockGetDeviceAccessServer(tt.ctx)
+			err := s.service.GetDeviceAccess(&pb.GetDeviceAccessRequest{}, srv)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, srv.access)
+		})
+	}
+}
+
+type mockGetDeviceAccessServer struct {
+	access map[string]*pb.DeviceAccess
+	ctx    context.Context
+	grpc.ServerStream
+}
+
+func newMockGetDeviceAccessServer(ctx context.Context) *mockGetDeviceAccessServer {
+	return &mockGetDeviceAccessServer{
+		ctx: ctx,
+	}
+}
+
+func (s *mockGetDeviceAccessServer) Send(a *pb.DeviceAccess) error {
+	if s.access == nil {
+		s.access = make(map[string]*pb.DeviceAccess)
+	}
+	s.access[a.GetDeviceId()] = a
+	return nil
+}
+
+func (s *mockGetDeviceAccessServer) Context() context.Context {
+	return s.ctx
+}
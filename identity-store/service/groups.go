@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/kit/v2/strings"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toGroupPb(g *persistence.Group) *pb.Group {
+	members := make([]*pb.GroupMember, 0, len(g.Members))
+	for _, m := range g.Members {
+		members = append(members, &pb.GroupMember{
+			UserId: m.UserID,
+			Role:   pb.Role(m.Role),
+		})
+	}
+	return &pb.Group{
+		Id:      g.ID,
+		Name:    g.Name,
+		Owner:   g.Owner,
+		Members: members,
+	}
+}
+
+func validateGroupMember(m *pb.GroupMember) error {
+	if m.GetUserId() == "" {
+		return fmt.Errorf("invalid UserId")
+	}
+	if _, ok := pb.Role_name[int32(m.GetRole())]; !ok {
+		return fmt.Errorf("invalid Role('%v') of user('%v')", m.GetRole(), m.GetUserId())
+	}
+	return nil
+}
+
+// setGroupMembers adds the members to the group or changes their roles. The owner of the group cannot be its member.
+// Users, whose role was changed, are returned.
+func setGroupMembers(g *persistence.Group, members []*pb.GroupMember) ([]string, error) {
+	var changed []string
+	for _, m := range members {
+		if err := validateGroupMember(m); err != nil {
+			return nil, err
+		}
+		if m.GetUserId() == g.Owner {
+			return nil, fmt.Errorf("user('%v') is the owner of the group", m.GetUserId())
+		}
+		role := persistence.Role(m.GetRole())
+		idx := -1
+		for i := range g.Members {
+			if g.Members[i].UserID == m.GetUserId() {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			g.Members = append(g.Members, persistence.GroupMember{UserID: m.GetUserId(), Role: role})
+			changed = append(changed, m.GetUserId())
+			continue
+		}
+		if g.Members[idx].Role != role {
+			g.Members[idx].Role = role
+			changed = append(changed, m.GetUserId())
+		}
+	}
+	return changed, nil
+}
+
+// removeGroupMembers removes the members from the group and returns the removed users.
+func removeGroupMembers(g *persistence.Group, userIDs []string) []string {
+	remove := make(strings.Set)
+	remove.Add(userIDs...)
+	var removed []string
+	members := make([]persistence.GroupMember, 0, len(g.Members))
+	for _, m := range g.Members {
+		if remove.HasOneOf(m.UserID) {
+			removed = append(removed, m.UserID)
+			continue
+		}
+		members = append(members, m)
+	}
+	g.Members = members
+	return removed
+}
+
+func getUserGroups(tx persistence.PersistenceTx, userID string) ([]persistence.Group, error) {
+	it := tx.RetrieveGroupsByUser(userID)
+	defer it.Close()
+	var groups []persistence.Group
+	var g persistence.Group
+	for it.Next(&g) {
+		groups = append(groups, g)
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("failed to obtain groups: %w", it.Err())
+	}
+	return groups, nil
+}
+
+// getManagedGroup returns the group, when the user has the owner role in it.
+func getManagedGroup(tx persistence.PersistenceTx, groupID, userID string) (*persistence.Group, error) {
+	if groupID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid GroupId")
+	}
+	g, ok, err := tx.RetrieveGroup(groupID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot retrieve group('%v'): %v", groupID, err)
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "group('%v') not found", groupID)
+	}
+	role, ok := g.GetRole(userID)
+	if !ok || role != persistence.RoleOwner {
+		return nil, status.Errorf(codes.PermissionDenied, "user('%v') cannot manage group('%v')", userID, groupID)
+	}
+	return g, nil
+}
+
+// CreateGroup creates a group owned by the user.
+func (s *Service) CreateGroup(ctx context.Context, request *pb.CreateGroupRequest) (*pb.Group, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, _, err := s.parseTokenMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.InvalidArgument, fmt.Errorf("cannot create group: %w", err)))
+	}
+	if request.GetName() == "" {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot create group: invalid Name"))
+	}
+
+	groupID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create group: %v", err))
+	}
+	g := persistence.Group{
+		ID:    groupID.String(),
+		Name:  request.GetName(),
+		Owner: owner,
+	}
+	if _, err := setGroupMembers(&g, request.GetMembers()); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot create group: %v", err))
+	}
+	if err := tx.PersistGroup(&g); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create group: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create group: %v", err))
+	}
+	return toGroupPb(&g), nil
+}
+
+// UpdateGroup changes the name or the members of the group. The devices of the group are shared with the new members
+// and unshared from the removed members.
+func (s *Service) UpdateGroup(ctx context.Context, request *pb.UpdateGroupRequest) (*pb.Group, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, userID, err := s.parseTokenMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.InvalidArgument, fmt.Errorf("cannot update group: %w", err)))
+	}
+	g, err := getManagedGroup(tx, request.GetGroupId(), owner)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot update group: %v", err))
+	}
+
+	if request.GetName() != "" {
+		g.Name = request.GetName()
+	}
+	removed := removeGroupMembers(g, request.GetRemoveUserIds())
+	changed, err := setGroupMembers(g, request.GetSetMembers())
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot update group: %v", err))
+	}
+	if err := tx.PersistGroup(g); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot update group: %v", err))
+	}
+
+	if len(changed) > 0 || len(removed) > 0 {
+		deviceIDs, err := getGroupDevices(tx, g.ID)
+		if err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot update group: %v", err))
+		}
+		if err := publishDevicesShared(tx, g, changed, userID, deviceIDs); err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot update group: %v", err))
+		}
+		if err := publishDevicesUnshared(tx, g.ID, removed, userID, deviceIDs); err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot update group: %v", err))
+		}
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot update group: %v", err))
+	}
+	return toGroupPb(g), nil
+}
+
+func deleteGroup(tx persistence.PersistenceTx, g *persistence.Group, userID string) error {
+	deviceIDs, err := getGroupDevices(tx, g.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot delete group('%v'): %v", g.ID, err)
+	}
+	for _, deviceID := range deviceIDs {
+		if err := tx.DeleteSharedDevice(deviceID, g.ID); err != nil {
+			return status.Errorf(codes.Internal, "cannot delete group('%v'): %v", g.ID, err)
+		}
+	}
+	if err := tx.DeleteGroup(g.ID); err != nil {
+		return status.Errorf(codes.Internal, "cannot delete group('%v'): %v", g.ID, err)
+	}
+	if err := publishDevicesUnshared(tx, g.ID, g.GetUserIDs(), userID, deviceIDs); err != nil {
+		return status.Errorf(codes.Internal, "cannot delete group('%v'): %v", g.ID, err)
+	}
+	return nil
+}
+
+// DeleteGroups removes groups owned by the user and unshares their devices.
+//
+// Using empty GroupIds in DeleteGroupsRequest is interpreting as requesting to delete all groups owned by the user.
+func (s *Service) DeleteGroups(ctx context.Context, request *pb.DeleteGroupsRequest) (*pb.DeleteGroupsResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, userID, err := s.parseTokenMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.InvalidArgument, fmt.Errorf("cannot delete groups: %w", err)))
+	}
+	groups, err := getUserGroups(tx, owner)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot delete groups: %v", err))
+	}
+	filter := make(strings.Set)
+	filter.Add(request.GetGroupIds()...)
+
+	var deletedGroupIDs []string
+	for i := range groups {
+		if groups[i].Owner != owner {
+			continue
+		}
+		if len(filter) > 0 && !filter.HasOneOf(groups[i].ID) {
+			continue
+		}
+		if err := deleteGroup(tx, &groups[i], userID); err != nil {
+			return nil, log.LogAndReturnError(err)
+		}
+		deletedGroupIDs = append(deletedGroupIDs, groups[i].ID)
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot delete groups: %v", err))
+	}
+
+	return &pb.DeleteGroupsResponse{
+		GroupIds: deletedGroupIDs,
+	}, nil
+}
+
+// GetGroups returns groups owned by the user and groups where the user is a member.
+func (s *Service) GetGroups(request *pb.GetGroupsRequest, srv pb.IdentityStore_GetGroupsServer) error {
+	tx := s.persistence.NewTransaction(srv.Context())
+	defer tx.Close()
+
+	owner, err := grpc.OwnerFromTokenMD(srv.Context(), s.ownerClaim)
+	if err != nil {
+		return log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot get groups: %v", err))
+	}
+	groups, err := getUserGroups(tx, owner)
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.Internal, "cannot get groups: %v", err))
+	}
+	filter := make(strings.Set)
+	filter.Add(request.GetGroupIdsFilter()...)
+
+	for i := range groups {
+		if len(filter) > 0 && !filter.HasOneOf(groups[i].ID) {
+			continue
+		}
+		if err := srv.Send(toGroupPb(&groups[i])); err != nil {
+			return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot get groups: %v", err))
+		}
+	}
+	return nil
+}
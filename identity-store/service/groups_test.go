@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestServiceCreateGroup(t *testing.T) {
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	type args struct {
+		ctx     context.Context
+		request *pb.CreateGroupRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *pb.Group
+		wantErr bool
+	}{
+		{
+			name: "invalid accesstoken",
+			args: args{
+				ctx:     context.Background(),
+				request: &pb.CreateGroupRequest{Name: "group"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid name",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.CreateGroupRequest{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "owner as member",
+			args: args{
+				ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.CreateGroupRequest{
+					Name:    "group",
+					Members: []*pb.GroupMember{{UserId: testUserID, Role: pb.Role_VIEWER}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid role",
+			args: args{
+				ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.CreateGroupRequest{
+					Name:    "group",
+					Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role(42)}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			args: args{
+				ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.CreateGroupRequest{
+					Name:    "group",
+					Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OPERATOR}},
+				},
+			},
+			want: &pb.Group{
+				Name:    "group",
+				Owner:   testUserID,
+				Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OPERATOR}},
+			},
+		},
+	}
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.service.CreateGroup(tt.args.ctx, tt.args.request)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, got.GetId())
+			tt.want.Id = got.GetId()
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServiceUpdateGroup(t *testing.T) {
+	const testUser3 = "testUser3"
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser2,
+	})
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID)
+	g, err := s.service.CreateGroup(ctx, &pb.CreateGroupRequest{
+		Name:    "group",
+		Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_VIEWER}},
+	})
+	require.NoError(t, err)
+
+	type args struct {
+		ctx     context.Context
+		request *pb.UpdateGroupRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *pb.Group
+		wantErr bool
+	}{
+		{
+			name: "not found",
+			args: args{
+				ctx:     ctx,
+				request: &pb.UpdateGroupRequest{GroupId: "notFound"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "viewer cannot manage group",
+			args: args{
+				ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2),
+				request: &pb.UpdateGroupRequest{
+					GroupId:    g.GetId(),
+					SetMembers: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OWNER}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "set members",
+			args: args{
+				ctx: ctx,
+				request: &pb.UpdateGroupRequest{
+					GroupId:    g.GetId(),
+					Name:       "renamed",
+					SetMembers: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OWNER}, {UserId: testUser3, Role: pb.Role_VIEWER}},
+				},
+			},
+			want: &pb.Group{
+				Id:      g.GetId(),
+				Name:    "renamed",
+				Owner:   testUserID,
+				Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OWNER}, {UserId: testUser3, Role: pb.Role_VIEWER}},
+			},
+		},
+		{
+			name: "remove members by member with owner role",
+			args: args{
+				ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2),
+				request: &pb.UpdateGroupRequest{
+					GroupId:       g.GetId(),
+					RemoveUserIds: []string{testUser3},
+				},
+			},
+			want: &pb.Group{
+				Id:      g.GetId(),
+				Name:    "renamed",
+				Owner:   testUserID,
+				Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OWNER}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.service.UpdateGroup(tt.args.ctx, tt.args.request)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServiceDeleteGroups(t *testing.T) {
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser2,
+	})
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID)
+	g, err := s.service.CreateGroup(ctx, &pb.CreateGroupRequest{
+		Name:    "group",
+		Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_OWNER}},
+	})
+	require.NoError(t, err)
+	persistDevice(t, s.service.persistence, newTestDevice())
+	_, err = s.service.ShareDevices(ctx, &pb.ShareDevicesRequest{GroupId: g.GetId(), DeviceIds: []string{testDeviceID}})
+	require.NoError(t, err)
+
+	// only the owner of the group can delete it
+	got, err := s.service.DeleteGroups(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2), &pb.DeleteGroupsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, got.GetGroupIds())
+
+	got, err = s.service.DeleteGroups(ctx, &pb.DeleteGroupsRequest{GroupIds: []string{g.GetId()}})
+	require.NoError(t, err)
+	require.Equal(t, []string{g.GetId()}, got.GetGroupIds())
+
+	srv := newMockGetDeviceAccessServer(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2))
+	err = s.service.GetDeviceAccess(&pb.GetDeviceAccessRequest{}, srv)
+	require.NoError(t, err)
+	require.Empty(t, srv.access)
+}
+
+type mockGetGroupsServer struct {
+	groups []*pb.Group
+	ctx    context.Context
+	grpc.ServerStream
+}
+
+func (s *mockGetGroupsServer) Send(g *pb.Group) error {
+	s.groups = append(s.groups, g)
+	return nil
+}
+
+func (s *mockGetGroupsServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestServiceGetGroups(t *testing.T) {
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser2,
+	})
+	jwtWithSubAaa := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": "aaa",
+	})
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	g, err := s.service.CreateGroup(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID), &pb.CreateGroupRequest{
+		Name:    "group",
+		Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_VIEWER}},
+	})
+	require.NoError(t, err)
+
+	srv := &mockGetGroupsServer{ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2)}
+	err = s.service.GetGroups(&pb.GetGroupsRequest{}, srv)
+	require.NoError(t, err)
+	require.Equal(t, []*pb.Group{g}, srv.groups)
+
+	srv = &mockGetGroupsServer{ctx: kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubAaa)}
+	err = s.service.GetGroups(&pb.GetGroupsRequest{}, srv)
+	require.NoError(t, err)
+	require.Empty(t, srv.groups)
+}
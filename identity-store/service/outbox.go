@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/identity-store/events"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+)
+
+const outboxRelayBatchSize = 128
+
+// publishEvent stores the event to the outbox of the transaction. The event is published by the outbox relay,
+// when the transaction is committed.
+func publishEvent(tx persistence.PersistenceTx, subject string, v *events.Event) error {
+	data, err := utils.Marshal(v)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	return tx.PersistOutboxEvent(&persistence.OutboxEvent{
+		ID:        id.String(),
+		Subject:   subject,
+		Data:      data,
+		Timestamp: time.Now().UnixNano(),
+	})
+}
+
+// outboxPublisher publishes events of the outbox to the eventbus.
+type outboxPublisher interface {
+	PublishData(subj string, data []byte) error
+	Flush(ctx context.Context) error
+}
+
+// outboxRelay publishes events stored in the outbox to the eventbus and removes them from the outbox.
+//
+// Events are published at least once, an event can be published again when its removal from the outbox fails.
+type outboxRelay struct {
+	persistence   Persistence
+	publisher     outboxPublisher
+	retryInterval time.Duration
+
+	notify chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newOutboxRelay(persistence Persistence, publisher outboxPublisher, retryInterval time.Duration) *outboxRelay {
+	r := &outboxRelay{
+		persistence:   persistence,
+		publisher:     publisher,
+		retryInterval: retryInterval,
+		notify:        make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run()
+	}()
+	return r
+}
+
+// Notify wakes up the relay to publish events of a committed transaction.
+func (r *outboxRelay) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (r *outboxRelay) run() {
+	ticker := time.NewTicker(r.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-r.notify:
+		case <-ticker.C:
+		}
+		if err := r.relay(); err != nil {
+			log.Errorf("cannot publish outbox events: %w", err)
+		}
+	}
+}
+
+// relay publishes stored events until the outbox is empty or the publishing fails.
+func (r *outboxRelay) relay() error {
+	for {
+		n, err := r.relayBatch()
+		if err != nil {
+			return err
+		}
+		if n < outboxRelayBatchSize {
+			return nil
+		}
+	}
+}
+
+func (r *outboxRelay) retrieveBatch() ([]persistence.OutboxEvent, error) {
+	tx := r.persistence.NewTransaction(context.Background())
+	defer tx.Close()
+	it := tx.RetrieveOutboxEvents(outboxRelayBatchSize)
+	defer it.Close()
+	var events []persistence.OutboxEvent
+	var e persistence.OutboxEvent
+	for it.Next(&e) {
+		events = append(events, e)
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("cannot retrieve outbox events: %w", it.Err())
+	}
+	return events, nil
+}
+
+func (r *outboxRelay) removeEvents(events []persistence.OutboxEvent) error {
+	tx := r.persistence.NewTransaction(context.Background())
+	defer tx.Close()
+	for _, e := range events {
+		if err := tx.DeleteOutboxEvent(e.ID); err != nil {
+			return fmt.Errorf("cannot remove outbox event('%v'): %w", e.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *outboxRelay) relayBatch() (int, error) {
+	events, err := r.retrieveBatch()
+	if err != nil {
+		return 0, err
+	}
+	var errPublish error
+	published := 0
+	// events are published in the order of creation, so the relay stops on the first failure and retries later
+	for _, e := range events {
+		if err := r.publisher.PublishData(e.Subject, e.Data); err != nil {
+			errPublish = fmt.Errorf("cannot publish outbox event('%v'): %w", e.ID, err)
+			break
+		}
+		published++
+	}
+	if published == 0 {
+		return 0, errPublish
+	}
+	// timeout is driven by flusherTimeout.
+	if err := r.publisher.Flush(context.Background()); err != nil {
+		return 0, fmt.Errorf("cannot flush outbox events: %w", err)
+	}
+	if err := r.removeEvents(events[:published]); err != nil {
+		return 0, err
+	}
+	if errPublish != nil {
+		return 0, errPublish
+	}
+	return published, nil
+}
+
+// Close stops the relay. Events remaining in the outbox are published by the next start of the service.
+func (r *outboxRelay) Close() {
+	close(r.done)
+	r.wg.Wait()
+}
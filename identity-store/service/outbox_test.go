@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nats-io/nats.go"
+	"github.com/plgd-dev/hub/identity-store/events"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/test"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+func countOutboxEvents(t *testing.T, p Persistence) int {
+	tx := p.NewTransaction(context.Background())
+	defer tx.Close()
+	it := tx.RetrieveOutboxEvents(outboxRelayBatchSize)
+	defer it.Close()
+	var n int
+	var e persistence.OutboxEvent
+	for it.Next(&e) {
+		n++
+	}
+	require.NoError(t, it.Err())
+	return n
+}
+
+func TestServiceOutboxRelay(t *testing.T) {
+	ctx := grpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	}))
+
+	s, shutdown := newTestService(t)
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	defer shutdown()
+
+	logger, err := log.NewLogger(makeConfig(t).Log)
+	require.NoError(t, err)
+	naClient, publisher, err := test.NewClientAndPublisher(config.MakePublisherConfig(), logger)
+	require.NoError(t, err)
+	defer func() {
+		publisher.Close()
+		naClient.Close()
+	}()
+	registered := make(chan *nats.Msg, 1)
+	sub, err := naClient.GetConn().ChanSubscribe(events.GetDevicesRegisteredSubject(testUserID), registered)
+	require.NoError(t, err)
+	defer func() {
+		_ = sub.Unsubscribe()
+	}()
+
+	_, err = s.service.AddDevice(ctx, &pb.AddDeviceRequest{
+		DeviceId: testDeviceID,
+	})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-registered:
+		var ev events.Event
+		err = utils.Unmarshal(msg.Data, &ev)
+		require.NoError(t, err)
+		require.Equal(t, []string{testDeviceID}, ev.GetDevicesRegistered().GetDeviceIds())
+	case <-time.After(time.Second * 5):
+		require.FailNow(t, "devices registered event was not received")
+	}
+
+	// published events are removed from the outbox
+	require.Eventually(t, func() bool {
+		return countOutboxEvents(t, s.service.persistence) == 0
+	}, time.Second*5, time.Millisecond*100)
+}
+
+// unavailablePublisher simulates an eventbus which is unavailable until it is enabled.
+type unavailablePublisher struct {
+	outboxPublisher
+	available uint32
+	attempts  uint32
+}
+
+func (p *unavailablePublisher) PublishData(subj string, data []byte) error {
+	atomic.AddUint32(&p.attempts, 1)
+	if atomic.LoadUint32(&p.available) == 0 {
+		return errors.New("nats: connection closed")
+	}
+	return p.outboxPublisher.PublishData(subj, data)
+}
+
+func TestServiceOutboxRelayRetry(t *testing.T) {
+	ctx := grpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	}))
+
+	s, shutdown := newTestService(t)
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	defer shutdown()
+
+	logger, err := log.NewLogger(makeConfig(t).Log)
+	require.NoError(t, err)
+	naClient, publisher, err := test.NewClientAndPublisher(config.MakePublisherConfig(), logger)
+	require.NoError(t, err)
+	defer func() {
+		publisher.Close()
+		naClient.Close()
+	}()
+	registered := make(chan *nats.Msg, 1)
+	sub, err := naClient.GetConn().ChanSubscribe(events.GetDevicesRegisteredSubject(testUserID), registered)
+	require.NoError(t, err)
+	defer func() {
+		_ = sub.Unsubscribe()
+	}()
+
+	// replace the relay of the service by a relay with the unavailable eventbus
+	unavailable := &unavailablePublisher{outboxPublisher: publisher}
+	s.service.outbox.Close()
+	s.service.outbox = newOutboxRelay(s.service.persistence, unavailable, time.Millisecond*100)
+
+	_, err = s.service.AddDevice(ctx, &pb.AddDeviceRequest{
+		DeviceId: testDeviceID,
+	})
+	require.NoError(t, err)
+
+	// the event stays in the outbox and the relay retries to publish it
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&unavailable.attempts) > 2
+	}, time.Second*5, time.Millisecond*50)
+	require.Equal(t, 1, countOutboxEvents(t, s.service.persistence))
+	select {
+	case <-registered:
+		require.FailNow(t, "devices registered event was published by unavailable eventbus")
+	default:
+	}
+
+	atomic.StoreUint32(&unavailable.available, 1)
+	select {
+	case msg := <-registered:
+		var ev events.Event
+		err = utils.Unmarshal(msg.Data, &ev)
+		require.NoError(t, err)
+		require.Equal(t, []string{testDeviceID}, ev.GetDevicesRegistered().GetDeviceIds())
+	case <-time.After(time.Second * 5):
+		require.FailNow(t, "devices registered event was not received")
+	}
+
+	require.Eventually(t, func() bool {
+		return countOutboxEvents(t, s.service.persistence) == 0
+	}, time.Second*5, time.Millisecond*100)
+}
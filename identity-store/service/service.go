@@ -3,12 +3,15 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/identity-store/persistence"
 	"github.com/plgd-dev/hub/identity-store/persistence/mongodb"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
@@ -27,8 +30,11 @@ type Persistence = interface {
 type Service struct {
 	pb.UnimplementedIdentityStoreServer
 	persistence Persistence
-	publisher   *publisher.Publisher
+	outbox      *outboxRelay
 	ownerClaim  string
+	tokenIssuer *serviceAccountTokenIssuer
+	// transferDevicesScope is required in the token to transfer devices
+	transferDevicesScope string
 }
 
 // Server is an HTTP server for the Service.
@@ -38,14 +44,28 @@ type Server struct {
 	cfg        Config
 }
 
-func NewService(persistence Persistence, publisher *publisher.Publisher, ownerClaim string) *Service {
+func NewService(persistence Persistence, publisher *publisher.Publisher, ownerClaim string, outboxRetryInterval time.Duration) *Service {
 	return &Service{
 		persistence: persistence,
 		ownerClaim:  ownerClaim,
-		publisher:   publisher,
+		outbox:      newOutboxRelay(persistence, publisher, outboxRetryInterval),
 	}
 }
 
+// commit applies the writes of the transaction and wakes up the outbox relay to publish events of the transaction.
+func (s *Service) commit(tx persistence.PersistenceTx) error {
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outbox.Notify()
+	return nil
+}
+
+// Close stops publishing of the events.
+func (s *Service) Close() {
+	s.outbox.Close()
+}
+
 func NewServer(ctx context.Context, cfg Config, logger log.Logger, publisher *publisher.Publisher, grpcOpts ...grpc.ServerOption) (*Server, error) {
 	grpcServer, err := server.New(cfg.APIs.GRPC, logger, grpcOpts...)
 	if err != nil {
@@ -62,14 +82,25 @@ func NewServer(ctx context.Context, cfg Config, logger log.Logger, publisher *pu
 	if err != nil {
 		return nil, fmt.Errorf("cannot create connector to mongo: %w", err)
 	}
+
+	tokenIssuer, err := newServiceAccountTokenIssuer(cfg.ServiceAccounts, cfg.APIs.GRPC.Authorization.OwnerClaim)
+	if err != nil {
+		if errClose := persistence.Close(ctx); errClose != nil {
+			log.Debugf("failed to close mongodb connector: %w", errClose)
+		}
+		return nil, fmt.Errorf("cannot create service account token issuer: %w", err)
+	}
+
+	service := NewService(persistence, publisher, cfg.APIs.GRPC.Authorization.OwnerClaim, cfg.Clients.Eventbus.OutboxRetryInterval)
+	service.tokenIssuer = tokenIssuer
+	service.transferDevicesScope = cfg.DeviceTransfer.RequiredScope
 	grpcServer.AddCloseFunc(func() {
+		service.Close()
 		if err := persistence.Close(ctx); err != nil {
 			log.Debugf("failed to close mongodb connector: %w", err)
 		}
 	})
 
-	service := NewService(persistence, publisher, cfg.APIs.GRPC.Authorization.OwnerClaim)
-
 	pb.RegisterIdentityStoreServer(grpcServer.Server, service)
 
 	return &Server{service: service, grpcServer: grpcServer, cfg: cfg}, nil
@@ -92,7 +123,8 @@ func New(ctx context.Context, cfg Config, logger log.Logger) (*Server, error) {
 		naClient.Close()
 		return nil, fmt.Errorf("cannot create validator: %w", err)
 	}
-	interceptor := server.NewAuth(validator, server.WithDisabledTokenForwarding())
+	method := "/" + pb.IdentityStore_ServiceDesc.ServiceName + "/GetServiceAccountToken"
+	interceptor := server.NewAuth(validator, server.WithDisabledTokenForwarding(), server.WithWhiteListedMethods(method), server.WithRequiredScopes(cfg.APIs.GRPC.Authorization.RequiredScopes))
 	opts, err := server.MakeDefaultOptions(interceptor, logger)
 	if err != nil {
 		validator.Close()
@@ -108,6 +140,20 @@ func New(ctx context.Context, cfg Config, logger log.Logger) (*Server, error) {
 	}
 	s.grpcServer.AddCloseFunc(validator.Close)
 	s.grpcServer.AddCloseFunc(naClient.Close)
+
+	metricsServer, err := metrics.New(cfg.APIs.Metrics, logger)
+	if err != nil {
+		s.grpcServer.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	s.grpcServer.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, cfg.Clients.OpenTelemetryCollector, "identity-store", logger)
+	if err != nil {
+		s.grpcServer.Close()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	s.grpcServer.AddCloseFunc(otelProvider.Close)
 	return s, nil
 }
 
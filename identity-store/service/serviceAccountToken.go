@@ -0,0 +1,85 @@
+package service
+
+import (
+	"crypto"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	pkgJwt "github.com/plgd-dev/hub/pkg/security/jwt"
+)
+
+// serviceAccountTokenIssuer signs short-lived tokens of the service accounts.
+type serviceAccountTokenIssuer struct {
+	issuer     string
+	ownerClaim string
+	expiration time.Duration
+	key        interface{}
+	method     jwt.SigningMethod
+	keyID      string
+}
+
+// newServiceAccountTokenIssuer creates the issuer, it returns nil when the tokens are not enabled.
+func newServiceAccountTokenIssuer(cfg ServiceAccountsConfig, ownerClaim string) (*serviceAccountTokenIssuer, error) {
+	if cfg.Issuer == "" {
+		return nil, nil
+	}
+	key, err := pkgJwt.LoadPrivateKey(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load private key %v: %w", cfg.KeyFile, err)
+	}
+	method, err := pkgJwt.SigningMethod(key)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	jwk, err := pkgJwt.NewJWK(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &serviceAccountTokenIssuer{
+		issuer:     cfg.Issuer,
+		ownerClaim: ownerClaim,
+		expiration: cfg.TokenExpiration,
+		key:        key,
+		method:     method,
+		keyID:      jwk.KeyID(),
+	}, nil
+}
+
+// issue creates the token of the service account. The owner claim contains the owner of the service account,
+// so the services authorize the token as the token of the owner.
+func (i *serviceAccountTokenIssuer) issue(a *persistence.ServiceAccount) (string, time.Time, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(i.expiration)
+	claims := jwt.MapClaims{
+		pkgJwt.ClaimSubject:          a.Owner,
+		pkgJwt.ClaimIssuer:           i.issuer,
+		pkgJwt.ClaimIssuedAt:         now.Unix(),
+		pkgJwt.ClaimExpiresAt:        expiresAt.Unix(),
+		pkgJwt.ClaimId:               id.String(),
+		pkgJwt.ClaimClientID:         a.ID,
+		pkgJwt.ClaimScope:            a.Scopes,
+		pkgJwt.ClaimServiceAccountID: a.ID,
+		// the number is stored as a string to keep the precision of nanoseconds
+		pkgJwt.ClaimServiceAccountKeyRotatedAt: strconv.FormatInt(a.KeyRotatedAt, 10),
+	}
+	claims[i.ownerClaim] = a.Owner
+	token := jwt.NewWithClaims(i.method, claims)
+	token.Header["kid"] = i.keyID
+	accessToken, err := token.SignedString(i.key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("cannot sign token: %w", err)
+	}
+	return accessToken, expiresAt, nil
+}
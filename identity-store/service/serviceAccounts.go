@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"github.com/plgd-dev/kit/v2/strings"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toServiceAccountPb(a *persistence.ServiceAccount) *pb.ServiceAccount {
+	return &pb.ServiceAccount{
+		Id:           a.ID,
+		Name:         a.Name,
+		Owner:        a.Owner,
+		Scopes:       a.Scopes,
+		CreatedAt:    a.CreatedAt,
+		KeyRotatedAt: a.KeyRotatedAt,
+	}
+}
+
+func hashServiceAccountSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// setServiceAccountKey generates a new key of the service account and returns it. Only the hash of the key is stored.
+func setServiceAccountKey(a *persistence.ServiceAccount) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("cannot generate key: %w", err)
+	}
+	s := hex.EncodeToString(secret)
+	a.KeyHash = hashServiceAccountSecret(s)
+	a.KeyRotatedAt = pkgTime.UnixNano(time.Now())
+	return pb.MakeServiceAccountKey(a.ID, s), nil
+}
+
+// parseUserClaimsMD returns the owner and the scopes from the token of the user. Service accounts cannot manage the service accounts.
+func (s *Service) parseUserClaimsMD(ctx context.Context) (string, []string, error) {
+	token, err := grpc.TokenFromMD(ctx)
+	if err != nil {
+		return "", nil, grpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return "", nil, grpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	if claims.ServiceAccountID() != "" {
+		return "", nil, status.Errorf(codes.PermissionDenied, "service account('%v') cannot manage service accounts", claims.ServiceAccountID())
+	}
+	owner, err := grpc.OwnerFromClaims(ctx, s.ownerClaim, claims)
+	if err != nil {
+		return "", nil, grpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	return owner, claims.Scope(), nil
+}
+
+func getOwnerServiceAccounts(tx persistence.PersistenceTx, owner string) ([]persistence.ServiceAccount, error) {
+	it := tx.RetrieveServiceAccountsByOwner(owner)
+	defer it.Close()
+	var accounts []persistence.ServiceAccount
+	var a persistence.ServiceAccount
+	for it.Next(&a) {
+		accounts = append(accounts, a)
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("failed to obtain service accounts: %w", it.Err())
+	}
+	return accounts, nil
+}
+
+// getOwnedServiceAccount returns the service account, when it was created by the owner.
+func getOwnedServiceAccount(tx persistence.PersistenceTx, serviceAccountID, owner string) (*persistence.ServiceAccount, error) {
+	if serviceAccountID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ServiceAccountId")
+	}
+	a, ok, err := tx.RetrieveServiceAccount(serviceAccountID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot retrieve service account('%v'): %v", serviceAccountID, err)
+	}
+	if !ok || a.Owner != owner {
+		return nil, status.Errorf(codes.NotFound, "service account('%v') not found", serviceAccountID)
+	}
+	return a, nil
+}
+
+// CreateServiceAccount creates a service account owned by the user. The scopes of the service account must be granted to the user.
+func (s *Service) CreateServiceAccount(ctx context.Context, request *pb.CreateServiceAccountRequest) (*pb.CreateServiceAccountResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, userScopes, err := s.parseUserClaimsMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot create service account: %v", err))
+	}
+	if request.GetName() == "" {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot create service account: invalid Name"))
+	}
+	granted := make(strings.Set)
+	granted.Add(userScopes...)
+	for _, scope := range request.GetScopes() {
+		if !granted.HasOneOf(scope) {
+			return nil, log.LogAndReturnError(status.Errorf(codes.PermissionDenied, "cannot create service account: scope('%v') is not granted to the user", scope))
+		}
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create service account: %v", err))
+	}
+	a := persistence.ServiceAccount{
+		ID:        id.String(),
+		Name:      request.GetName(),
+		Owner:     owner,
+		Scopes:    request.GetScopes(),
+		CreatedAt: pkgTime.UnixNano(time.Now()),
+	}
+	key, err := setServiceAccountKey(&a)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create service account: %v", err))
+	}
+	if err := tx.PersistServiceAccount(&a); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create service account: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot create service account: %v", err))
+	}
+	return &pb.CreateServiceAccountResponse{
+		ServiceAccount: toServiceAccountPb(&a),
+		Key:            key,
+	}, nil
+}
+
+// GetServiceAccounts returns service accounts owned by the user. Gateways use it with the tokens of the service accounts
+// to verify that the service accounts were not revoked.
+func (s *Service) GetServiceAccounts(request *pb.GetServiceAccountsRequest, srv pb.IdentityStore_GetServiceAccountsServer) error {
+	tx := s.persistence.NewTransaction(srv.Context())
+	defer tx.Close()
+
+	owner, err := grpc.OwnerFromTokenMD(srv.Context(), s.ownerClaim)
+	if err != nil {
+		return log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot get service accounts: %v", err))
+	}
+	accounts, err := getOwnerServiceAccounts(tx, owner)
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.Internal, "cannot get service accounts: %v", err))
+	}
+	filter := make(strings.Set)
+	filter.Add(request.GetServiceAccountIdsFilter()...)
+
+	for i := range accounts {
+		if len(filter) > 0 && !filter.HasOneOf(accounts[i].ID) {
+			continue
+		}
+		if err := srv.Send(toServiceAccountPb(&accounts[i])); err != nil {
+			return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot get service accounts: %v", err))
+		}
+	}
+	return nil
+}
+
+// RotateServiceAccountKey replaces the key of the service account. Tokens issued for the previous key are rejected by the gateways,
+// which verify the time of the rotation in the token against the service account.
+func (s *Service) RotateServiceAccountKey(ctx context.Context, request *pb.RotateServiceAccountKeyRequest) (*pb.RotateServiceAccountKeyResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, _, err := s.parseUserClaimsMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot rotate service account key: %v", err))
+	}
+	a, err := getOwnedServiceAccount(tx, request.GetServiceAccountId(), owner)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot rotate service account key: %v", err))
+	}
+	key, err := setServiceAccountKey(a)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot rotate service account key: %v", err))
+	}
+	if err := tx.PersistServiceAccount(a); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot rotate service account key: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot rotate service account key: %v", err))
+	}
+	return &pb.RotateServiceAccountKeyResponse{
+		ServiceAccount: toServiceAccountPb(a),
+		Key:            key,
+	}, nil
+}
+
+// RevokeServiceAccounts removes service accounts owned by the user. Tokens issued for the service accounts are rejected by the gateways,
+// which verify that the service account exists.
+//
+// Using empty ServiceAccountIds in RevokeServiceAccountsRequest is interpreting as requesting to revoke all service accounts of the user.
+func (s *Service) RevokeServiceAccounts(ctx context.Context, request *pb.RevokeServiceAccountsRequest) (*pb.RevokeServiceAccountsResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, _, err := s.parseUserClaimsMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot revoke service accounts: %v", err))
+	}
+	accounts, err := getOwnerServiceAccounts(tx, owner)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot revoke service accounts: %v", err))
+	}
+	filter := make(strings.Set)
+	filter.Add(request.GetServiceAccountIds()...)
+
+	var revoked []string
+	for i := range accounts {
+		if len(filter) > 0 && !filter.HasOneOf(accounts[i].ID) {
+			continue
+		}
+		if err := tx.DeleteServiceAccount(accounts[i].ID); err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot revoke service account('%v'): %v", accounts[i].ID, err))
+		}
+		revoked = append(revoked, accounts[i].ID)
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot revoke service accounts: %v", err))
+	}
+	return &pb.RevokeServiceAccountsResponse{
+		ServiceAccountIds: revoked,
+	}, nil
+}
+
+// GetServiceAccountToken exchanges the key of the service account for the token. The request is not authorized by the token,
+// the key is the credential.
+func (s *Service) GetServiceAccountToken(ctx context.Context, request *pb.GetServiceAccountTokenRequest) (*pb.ServiceAccountToken, error) {
+	if s.tokenIssuer == nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Unimplemented, "cannot get service account token: tokens of service accounts are not enabled"))
+	}
+	serviceAccountID, secret, err := pb.ParseServiceAccountKey(request.GetKey())
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Unauthenticated, "cannot get service account token: %v", err))
+	}
+
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	a, ok, err := tx.RetrieveServiceAccount(serviceAccountID)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot get service account token: %v", err))
+	}
+	if !ok || subtle.ConstantTimeCompare([]byte(a.KeyHash), []byte(hashServiceAccountSecret(secret))) != 1 {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Unauthenticated, "cannot get service account token: invalid key"))
+	}
+	accessToken, expiresAt, err := s.tokenIssuer.issue(a)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot get service account token: %v", err))
+	}
+	return &pb.ServiceAccountToken{
+		AccessToken: accessToken,
+		ExpiresAt:   pkgTime.UnixNano(expiresAt),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	pkgJwt "github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type mockGetServiceAccountsServer struct {
+	accounts []*pb.ServiceAccount
+	ctx      context.Context
+	grpc.ServerStream
+}
+
+func (s *mockGetServiceAccountsServer) Send(a *pb.ServiceAccount) error {
+	s.accounts = append(s.accounts, a)
+	return nil
+}
+
+func (s *mockGetServiceAccountsServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestServiceServiceAccounts(t *testing.T) {
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub":   testUserID,
+		"scope": []string{"r:*", "w:*"},
+	})
+	ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID)
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	tokenIssuer, err := newServiceAccountTokenIssuer(ServiceAccountsConfig{
+		Issuer:          "https://serviceaccounts",
+		KeyFile:         config.KEY_FILE,
+		TokenExpiration: time.Minute,
+	}, config.OWNER_CLAIM)
+	require.NoError(t, err)
+	s.service.tokenIssuer = tokenIssuer
+
+	_, err = s.service.CreateServiceAccount(ctx, &pb.CreateServiceAccountRequest{Scopes: []string{"r:*"}})
+	require.Error(t, err)
+	_, err = s.service.CreateServiceAccount(ctx, &pb.CreateServiceAccountRequest{Name: "job", Scopes: []string{"admin"}})
+	require.Error(t, err)
+	created, err := s.service.CreateServiceAccount(ctx, &pb.CreateServiceAccountRequest{Name: "job", Scopes: []string{"r:*"}})
+	require.NoError(t, err)
+	require.Equal(t, testUserID, created.GetServiceAccount().GetOwner())
+	require.Equal(t, []string{"r:*"}, created.GetServiceAccount().GetScopes())
+	require.True(t, pb.IsServiceAccountKey(created.GetKey()))
+
+	srv := &mockGetServiceAccountsServer{ctx: ctx}
+	err = s.service.GetServiceAccounts(&pb.GetServiceAccountsRequest{}, srv)
+	require.NoError(t, err)
+	require.Equal(t, []*pb.ServiceAccount{created.GetServiceAccount()}, srv.accounts)
+
+	_, err = s.service.GetServiceAccountToken(context.Background(), &pb.GetServiceAccountTokenRequest{Key: created.GetKey() + "0"})
+	require.Error(t, err)
+	token, err := s.service.GetServiceAccountToken(context.Background(), &pb.GetServiceAccountTokenRequest{Key: created.GetKey()})
+	require.NoError(t, err)
+	claims, err := pkgJwt.ParseToken(token.GetAccessToken())
+	require.NoError(t, err)
+	require.Equal(t, testUserID, claims.Owner(config.OWNER_CLAIM))
+	require.Equal(t, created.GetServiceAccount().GetId(), claims.ServiceAccountID())
+	require.Equal(t, []string{"r:*"}, claims.Scope())
+
+	// service account cannot create service accounts
+	_, err = s.service.CreateServiceAccount(kitNetGrpc.CtxWithIncomingToken(context.Background(), token.GetAccessToken()), &pb.CreateServiceAccountRequest{Name: "job2"})
+	require.Error(t, err)
+
+	rotated, err := s.service.RotateServiceAccountKey(ctx, &pb.RotateServiceAccountKeyRequest{ServiceAccountId: created.GetServiceAccount().GetId()})
+	require.NoError(t, err)
+	require.NotEqual(t, created.GetKey(), rotated.GetKey())
+	_, err = s.service.GetServiceAccountToken(context.Background(), &pb.GetServiceAccountTokenRequest{Key: created.GetKey()})
+	require.Error(t, err)
+	_, err = s.service.GetServiceAccountToken(context.Background(), &pb.GetServiceAccountTokenRequest{Key: rotated.GetKey()})
+	require.NoError(t, err)
+
+	revoked, err := s.service.RevokeServiceAccounts(ctx, &pb.RevokeServiceAccountsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{created.GetServiceAccount().GetId()}, revoked.GetServiceAccountIds())
+	_, err = s.service.GetServiceAccountToken(context.Background(), &pb.GetServiceAccountTokenRequest{Key: rotated.GetKey()})
+	require.Error(t, err)
+}
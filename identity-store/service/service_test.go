@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/plgd-dev/hub/identity-store/persistence"
 	"github.com/plgd-dev/hub/pkg/log"
@@ -37,6 +38,9 @@ func makeConfig(t *testing.T) Config {
 	cfg.Clients.Storage.MongoDB.TLS.KeyFile = config.KEY_FILE
 
 	cfg.Clients.Eventbus.NATS = config.MakePublisherConfig()
+	cfg.Clients.Eventbus.OutboxRetryInterval = time.Second
+
+	cfg.DeviceTransfer.RequiredScope = config.TRANSFER_DEVICES_SCOPE
 
 	err := cfg.Validate()
 	require.NoError(t, err)
@@ -100,4 +104,6 @@ func persistDevice(t *testing.T, p Persistence, d *persistence.AuthorizedDevice)
 	defer tx.Close()
 	err := tx.Persist(d)
 	assert.Nil(t, err)
+	err = tx.Commit()
+	assert.Nil(t, err)
 }
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/identity-store/events"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"github.com/plgd-dev/kit/v2/strings"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getGroupSharedDevices(tx persistence.PersistenceTx, groupID string) ([]persistence.SharedDevice, error) {
+	it := tx.RetrieveSharedDevicesByGroups([]string{groupID})
+	defer it.Close()
+	var devices []persistence.SharedDevice
+	var d persistence.SharedDevice
+	for it.Next(&d) {
+		devices = append(devices, d)
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("failed to obtain shared devices: %w", it.Err())
+	}
+	return devices, nil
+}
+
+func getGroupDevices(tx persistence.PersistenceTx, groupID string) ([]string, error) {
+	devices, err := getGroupSharedDevices(tx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	deviceIDs := make([]string, 0, len(devices))
+	for _, d := range devices {
+		deviceIDs = append(deviceIDs, d.DeviceID)
+	}
+	return deviceIDs, nil
+}
+
+// publishDevicesShared notifies the users about the devices shared with them by the group.
+func publishDevicesShared(tx persistence.PersistenceTx, g *persistence.Group, userIDs []string, auditUserID string, deviceIDs []string) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	for _, userID := range userIDs {
+		role, ok := g.GetRole(userID)
+		if !ok {
+			continue
+		}
+		err := publishEvent(tx, events.GetDevicesSharedSubject(userID), &events.Event{
+			Type: &events.Event_DevicesShared{
+				DevicesShared: &events.DevicesShared{
+					Owner:     userID,
+					DeviceIds: deviceIDs,
+					GroupId:   g.ID,
+					Role:      pb.Role(role),
+					AuditContext: &events.AuditContext{
+						UserId: auditUserID,
+					},
+					Timestamp: pkgTime.UnixNano(time.Now()),
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("cannot publish devices shared event for user('%v'): %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// publishDevicesUnshared notifies the users about the devices unshared from them by the group.
+func publishDevicesUnshared(tx persistence.PersistenceTx, groupID string, userIDs []string, auditUserID string, deviceIDs []string) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	for _, userID := range userIDs {
+		err := publishEvent(tx, events.GetDevicesUnsharedSubject(userID), &events.Event{
+			Type: &events.Event_DevicesUnshared{
+				DevicesUnshared: &events.DevicesUnshared{
+					Owner:     userID,
+					DeviceIds: deviceIDs,
+					GroupId:   groupID,
+					AuditContext: &events.AuditContext{
+						UserId: auditUserID,
+					},
+					Timestamp: pkgTime.UnixNano(time.Now()),
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("cannot publish devices unshared event for user('%v'): %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// ShareDevices shares devices owned by the user with the group. The user must have the owner role in the group.
+//
+// Function returns the list of shared devices, devices which are not owned by the user are skipped.
+func (s *Service) ShareDevices(ctx context.Context, request *pb.ShareDevicesRequest) (*pb.ShareDevicesResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, userID, err := s.parseTokenMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.InvalidArgument, fmt.Errorf("cannot share devices: %w", err)))
+	}
+	g, err := getManagedGroup(tx, request.GetGroupId(), owner)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardErrorf(codes.InvalidArgument, "cannot share devices: %v", err))
+	}
+	deviceIDs := getUniqueDeviceIds(request.GetDeviceIds())
+	if len(deviceIDs) == 0 {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot share devices: invalid DeviceIds"))
+	}
+
+	var sharedDeviceIDs []string
+	for _, deviceID := range deviceIDs {
+		_, ok, err := tx.Retrieve(deviceID, owner)
+		if err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot share device('%v'): %v", deviceID, err))
+		}
+		if !ok {
+			log.Debugf("cannot share device('%v') not owned by user('%v')", deviceID, owner)
+			continue
+		}
+		if err := tx.PersistSharedDevice(&persistence.SharedDevice{
+			DeviceID: deviceID,
+			Owner:    owner,
+			GroupID:  g.ID,
+		}); err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot share device('%v'): %v", deviceID, err))
+		}
+		sharedDeviceIDs = append(sharedDeviceIDs, deviceID)
+	}
+
+	if err := publishDevicesShared(tx, g, g.GetUserIDs(), userID, sharedDeviceIDs); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot share devices: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot share devices: %v", err))
+	}
+
+	return &pb.ShareDevicesResponse{
+		DeviceIds: sharedDeviceIDs,
+	}, nil
+}
+
+// UnshareDevices removes devices from the group. The user with the owner role in the group unshares any device,
+// other users unshare only their devices.
+//
+// Using empty DeviceIds in UnshareDevicesRequest is interpreting as requesting to unshare all devices
+// of the group, which can be unshared by the user.
+func (s *Service) UnshareDevices(ctx context.Context, request *pb.UnshareDevicesRequest) (*pb.UnshareDevicesResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, userID, err := s.parseTokenMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.InvalidArgument, fmt.Errorf("cannot unshare devices: %w", err)))
+	}
+	if request.GetGroupId() == "" {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot unshare devices: invalid GroupId"))
+	}
+	g, ok, err := tx.RetrieveGroup(request.GetGroupId())
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot unshare devices: %v", err))
+	}
+	if !ok {
+		return nil, log.LogAndReturnError(status.Errorf(codes.NotFound, "cannot unshare devices: group('%v') not found", request.GetGroupId()))
+	}
+	role, isMember := g.GetRole(owner)
+	manageGroup := isMember && role == persistence.RoleOwner
+
+	sharedDevices, err := getGroupSharedDevices(tx, g.ID)
+	if err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot unshare devices: %v", err))
+	}
+	filter := make(strings.Set)
+	filter.Add(request.GetDeviceIds()...)
+
+	var unsharedDeviceIDs []string
+	for _, d := range sharedDevices {
+		if len(filter) > 0 && !filter.HasOneOf(d.DeviceID) {
+			continue
+		}
+		if !manageGroup && d.Owner != owner {
+			continue
+		}
+		if err := tx.DeleteSharedDevice(d.DeviceID, g.ID); err != nil {
+			return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot unshare device('%v'): %v", d.DeviceID, err))
+		}
+		unsharedDeviceIDs = append(unsharedDeviceIDs, d.DeviceID)
+	}
+
+	if err := publishDevicesUnshared(tx, g.ID, g.GetUserIDs(), userID, unsharedDeviceIDs); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot unshare devices: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot unshare devices: %v", err))
+	}
+
+	return &pb.UnshareDevicesResponse{
+		DeviceIds: unsharedDeviceIDs,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceShareDevices(t *testing.T) {
+	const testDevID1 = "testDeviceID1"
+	const testDevID2 = "testDeviceID2"
+	const testUser2DevID1 = "test2DeviceID1"
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUserID,
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": testUser2,
+	})
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testDevID1, testUserID))
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testDevID2, testUserID))
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testUser2DevID1, testUser2))
+	g, err := s.service.CreateGroup(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID), &pb.CreateGroupRequest{
+		Name:    "group",
+		Members: []*pb.GroupMember{{UserId: testUser2, Role: pb.Role_VIEWER}},
+	})
+	require.NoError(t, err)
+
+	type args struct {
+		ctx     context.Context
+		request *pb.ShareDevicesRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *pb.ShareDevicesResponse
+		wantErr bool
+	}{
+		{
+			name: "invalid accesstoken",
+			args: args{
+				ctx:     context.Background(),
+				request: &pb.ShareDevicesRequest{GroupId: g.GetId(), DeviceIds: []string{testDevID1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid deviceIds",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.ShareDevicesRequest{GroupId: g.GetId()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "group not found",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.ShareDevicesRequest{GroupId: "notFound", DeviceIds: []string{testDevID1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "viewer cannot share",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2),
+				request: &pb.ShareDevicesRequest{GroupId: g.GetId(), DeviceIds: []string{testUser2DevID1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "owned and not owned",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.ShareDevicesRequest{GroupId: g.GetId(), DeviceIds: []string{testDevID1, testDevID2, testUser2DevID1}},
+			},
+			want: &pb.ShareDevicesResponse{DeviceIds: []string{testDevID1, testDevID2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.service.ShareDevices(tt.args.ctx, tt.args.request)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			sort.Strings(got.DeviceIds)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	// unshare by viewer is ignored, it doesn't own the devices
+	unshared, err := s.service.UnshareDevices(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2), &pb.UnshareDevicesRequest{GroupId: g.GetId()})
+	require.NoError(t, err)
+	require.Empty(t, unshared.GetDeviceIds())
+
+	unshared, err = s.service.UnshareDevices(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID), &pb.UnshareDevicesRequest{GroupId: g.GetId(), DeviceIds: []string{testDevID2}})
+	require.NoError(t, err)
+	require.Equal(t, []string{testDevID2}, unshared.GetDeviceIds())
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/identity-store/pb"
+	"github.com/plgd-dev/hub/identity-store/persistence"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func transferDevice(tx persistence.PersistenceTx, deviceId, owner, newOwner string) (bool, []string, error) {
+	_, ok, err := tx.Retrieve(deviceId, owner)
+	if err != nil {
+		return false, nil, status.Errorf(codes.Internal, "cannot transfer device('%v'): %v", deviceId, err.Error())
+	}
+	if !ok {
+		log.Debugf("cannot retrieve device by user('%v')", owner)
+		return false, nil, nil
+	}
+
+	// the groups belong to the previous owner, so the device cannot stay shared with them
+	groupIDs, err := unshareDevice(tx, deviceId)
+	if err != nil {
+		return false, nil, status.Errorf(codes.Internal, "cannot unshare device('%v'): %v", deviceId, err.Error())
+	}
+
+	if err := tx.Persist(&persistence.AuthorizedDevice{
+		DeviceID: deviceId,
+		Owner:    newOwner,
+	}); err != nil {
+		return false, nil, status.Errorf(codes.Internal, "cannot transfer device('%v'): %v", deviceId, err.Error())
+	}
+	return true, groupIDs, nil
+}
+
+// checkTransferDevicesScope verifies that the token of the user contains the scope required to transfer devices.
+func (s *Service) checkTransferDevicesScope(ctx context.Context) error {
+	token, err := grpc.TokenFromMD(ctx)
+	if err != nil {
+		return grpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return grpc.ForwardFromError(codes.InvalidArgument, err)
+	}
+	for _, scope := range claims.Scope() {
+		if scope == s.transferDevicesScope {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "scope('%v') is required", s.transferDevicesScope)
+}
+
+// TransferDevices reassigns devices owned by the user to the new owner.
+//
+// The devices are unregistered from the user and registered to the new owner, the devices shared
+// by the user with groups are unshared. The new owner doesn't accept the transfer, so the user must
+// have the scope configured by deviceTransfer.requiredScope. Function returns the list of transferred
+// devices, devices which are not owned by the user are skipped.
+func (s *Service) TransferDevices(ctx context.Context, request *pb.TransferDevicesRequest) (*pb.TransferDevicesResponse, error) {
+	tx := s.persistence.NewTransaction(ctx)
+	defer tx.Close()
+
+	owner, userID, err := s.parseTokenMD(ctx)
+	if err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.InvalidArgument, fmt.Errorf("cannot transfer devices: %w", err)))
+	}
+	if err := s.checkTransferDevicesScope(ctx); err != nil {
+		return nil, log.LogAndReturnError(grpc.ForwardFromError(codes.PermissionDenied, fmt.Errorf("cannot transfer devices: %w", err)))
+	}
+	if request.GetNewOwner() == "" {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot transfer devices: invalid NewOwner"))
+	}
+	if request.GetNewOwner() == owner {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot transfer devices: devices are already owned by user('%v')", owner))
+	}
+	deviceIds := getUniqueDeviceIds(request.GetDeviceIds())
+	if len(deviceIds) == 0 {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot transfer devices: invalid DeviceIds"))
+	}
+
+	var transferredDeviceIds []string
+	groupDevices := make(map[string][]string)
+	for _, deviceId := range deviceIds {
+		ok, groupIDs, err := transferDevice(tx, deviceId, owner, request.GetNewOwner())
+		if err != nil {
+			return nil, log.LogAndReturnError(err)
+		}
+		if !ok {
+			continue
+		}
+		transferredDeviceIds = append(transferredDeviceIds, deviceId)
+		for _, groupID := range groupIDs {
+			groupDevices[groupID] = append(groupDevices[groupID], deviceId)
+		}
+	}
+	if len(transferredDeviceIds) == 0 {
+		return &pb.TransferDevicesResponse{}, nil
+	}
+
+	if err := publishDevicesUnregistered(tx, owner, userID, transferredDeviceIds); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot transfer devices: cannot publish devices unregistered event: %v", err))
+	}
+	if err := publishDevicesRegistered(tx, request.GetNewOwner(), userID, transferredDeviceIds); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot transfer devices: cannot publish devices registered event: %v", err))
+	}
+	if err := publishGroupsDevicesUnshared(tx, userID, groupDevices); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot transfer devices: cannot publish devices unshared events: %v", err))
+	}
+	if err := s.commit(tx); err != nil {
+		return nil, log.LogAndReturnError(status.Errorf(codes.Internal, "cannot transfer devices: %v", err))
+	}
+
+	return &pb.TransferDevicesResponse{
+		DeviceIds: transferredDeviceIds,
+	}, nil
+}
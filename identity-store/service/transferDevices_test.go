@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/identity-store/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceTransferDevices(t *testing.T) {
+	const testDevID1 = "testDeviceID1"
+	const testDevID2 = "testDeviceID2"
+	const testDevID3 = "testDeviceID3"
+	const testUser2DevID1 = "test2DeviceID1"
+	jwtWithSubTestUserID := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub":   testUserID,
+		"scope": []string{config.TRANSFER_DEVICES_SCOPE},
+	})
+	jwtWithSubTestUser2 := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub":   testUser2,
+		"scope": []string{config.TRANSFER_DEVICES_SCOPE},
+	})
+	jwtWithoutTransferScope := config.CreateJwtToken(t, jwt.MapClaims{
+		"sub":   testUserID,
+		"scope": []string{"r:deviceinformation:*"},
+	})
+
+	type args struct {
+		ctx     context.Context
+		request *pb.TransferDevicesRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *pb.TransferDevicesResponse
+		wantErr bool
+	}{
+		{
+			name: "invalid accesstoken",
+			args: args{
+				ctx:     context.Background(),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testDevID1}, NewOwner: testUser2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid deviceIds",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.TransferDevicesRequest{NewOwner: testUser2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid new owner",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testDevID1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "transfer to itself",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testDevID1}, NewOwner: testUserID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing scope",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithoutTransferScope),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testDevID1}, NewOwner: testUser2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not owned",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testUser2DevID1}, NewOwner: testUser2},
+			},
+			want: &pb.TransferDevicesResponse{},
+		},
+		{
+			name: "valid",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testDevID1, testDevID2, testUser2DevID1}, NewOwner: testUser2},
+			},
+			want: &pb.TransferDevicesResponse{DeviceIds: []string{testDevID1, testDevID2}},
+		},
+		{
+			name: "already transferred",
+			args: args{
+				ctx:     kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID),
+				request: &pb.TransferDevicesRequest{DeviceIds: []string{testDevID1}, NewOwner: testUser2},
+			},
+			want: &pb.TransferDevicesResponse{},
+		},
+	}
+
+	s, shutdown := newTestService(t)
+	defer shutdown()
+	defer func() {
+		err := s.cleanUp()
+		require.NoError(t, err)
+	}()
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testDevID1, testUserID))
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testDevID2, testUserID))
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testDevID3, testUserID))
+	persistDevice(t, s.service.persistence, newTestDeviceWithIDAndOwner(testUser2DevID1, testUser2))
+	g, err := s.service.CreateGroup(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID), &pb.CreateGroupRequest{
+		Name: "group",
+	})
+	require.NoError(t, err)
+	_, err = s.service.ShareDevices(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUserID), &pb.ShareDevicesRequest{
+		GroupId:   g.GetId(),
+		DeviceIds: []string{testDevID1, testDevID3},
+	})
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.service.TransferDevices(tt.args.ctx, tt.args.request)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			sort.Strings(got.DeviceIds)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	tx := s.service.persistence.NewTransaction(context.Background())
+	defer tx.Close()
+	user2Devices, err := getOwnerDevices(tx, testUser2)
+	require.NoError(t, err)
+	sort.Strings(user2Devices)
+	require.Equal(t, []string{testDevID1, testDevID2, testUser2DevID1}, user2Devices)
+	sharedDevices, err := getGroupDevices(tx, g.GetId())
+	require.NoError(t, err)
+	require.Equal(t, []string{testDevID3}, sharedDevices)
+
+	_, err = s.service.TransferDevices(kitNetGrpc.CtxWithIncomingToken(context.Background(), jwtWithSubTestUser2), &pb.TransferDevicesRequest{
+		DeviceIds: []string{testDevID1},
+		NewOwner:  testUserID,
+	})
+	require.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/plgd-dev/hub/identity-store/service"
 	"github.com/plgd-dev/hub/test/config"
@@ -18,6 +19,9 @@ func MakeConfig(t *testing.T) service.Config {
 	cfg.Clients.Storage.MongoDB.Database = config.IDENTITY_STORE_DB
 
 	cfg.Clients.Eventbus.NATS = config.MakePublisherConfig()
+	cfg.Clients.Eventbus.OutboxRetryInterval = time.Second
+
+	cfg.DeviceTransfer.RequiredScope = config.TRANSFER_DEVICES_SCOPE
 
 	err := cfg.Validate()
 	require.NoError(t, err)
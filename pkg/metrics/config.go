@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"fmt"
+)
+
+// Config configures the prometheus /metrics endpoint.
+type Config struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Addr    string `yaml:"address" json:"address"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Addr == "" {
+		return fmt.Errorf("address('%v')", c.Addr)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path on which the metrics are exposed.
+const Path = "/metrics"
+
+// Server exposes the metrics of the default prometheus registry over HTTP.
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// New starts serving the metrics in the background. When the metrics are disabled
+// a no-op server is returned.
+func New(config Config, logger log.Logger) (*Server, error) {
+	if !config.Enabled {
+		return &Server{}, nil
+	}
+	lis, err := net.Listen("tcp", config.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on metrics address %v: %w", config.Addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.Handler())
+	s := &Server{
+		listener: lis,
+		server:   &http.Server{Handler: mux},
+	}
+	go func() {
+		if err := s.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("cannot serve metrics: %w", err)
+		}
+	}()
+	return s, nil
+}
+
+// Addr returns the address of the metrics listener, nil when the metrics are disabled.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Close stops serving the metrics.
+func (s *Server) Close() {
+	if s.server == nil {
+		return
+	}
+	if err := s.server.Close(); err != nil {
+		log.Errorf("cannot close metrics server: %w", err)
+	}
+}
@@ -0,0 +1,45 @@
+package metrics_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	counter := promauto.NewCounter(prometheus.CounterOpts{
+		Name: "metrics_server_test_total",
+		Help: "Test counter.",
+	})
+	counter.Inc()
+
+	s, err := metrics.New(metrics.Config{
+		Enabled: true,
+		Addr:    "127.0.0.1:0",
+	}, log.Get())
+	require.NoError(t, err)
+	defer s.Close()
+
+	resp, err := http.Get("http://" + s.Addr().String() + metrics.Path)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "metrics_server_test_total 1")
+}
+
+func TestServerDisabled(t *testing.T) {
+	s, err := metrics.New(metrics.Config{}, log.Get())
+	require.NoError(t, err)
+	require.Nil(t, s.Addr())
+	s.Close()
+}
@@ -52,6 +52,19 @@ type Validator interface {
 	ParseWithClaims(token string, claims extJwt.Claims) error
 }
 
+// OwnerResolver resolves the owner from the claims of the validated token, the owner claim of the service
+// might be overridden by the issuer of the token.
+type OwnerResolver interface {
+	Owner(claims jwt.Claims, ownerClaim string) (string, error)
+}
+
+type ownerResolverKey struct{}
+
+// CtxWithOwnerResolver stores the resolver of the owner used by OwnerFromTokenMD to ctx.
+func CtxWithOwnerResolver(ctx context.Context, resolver OwnerResolver) context.Context {
+	return context.WithValue(ctx, ownerResolverKey{}, resolver)
+}
+
 func ValidateJWTWithValidator(validator Validator, claims ClaimsFunc) Interceptor {
 	return func(ctx context.Context, method string) (context.Context, error) {
 		token, err := grpc_auth.AuthFromMD(ctx, "bearer")
@@ -59,9 +72,15 @@ func ValidateJWTWithValidator(validator Validator, claims ClaimsFunc) Intercepto
 			return nil, err
 		}
 		err = validator.ParseWithClaims(token, claims(ctx, method))
+		if jwt.IsMissingScopes(err) {
+			return nil, status.Errorf(codes.PermissionDenied, "invalid token: %v", err)
+		}
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 		}
+		if resolver, ok := validator.(OwnerResolver); ok {
+			ctx = CtxWithOwnerResolver(ctx, resolver)
+		}
 		return ctx, nil
 	}
 }
@@ -97,19 +116,54 @@ func ParseOwnerFromJwtToken(ownerClaim, rawJwtToken string) (string, error) {
 	return owner, nil
 }
 
+// OwnerFromClaims returns the owner from the claims by the resolver stored in ctx by the validation of the token,
+// without the resolver the ownerClaim is used.
+func OwnerFromClaims(ctx context.Context, ownerClaim string, claims jwt.Claims) (string, error) {
+	if resolver, ok := ctx.Value(ownerResolverKey{}).(OwnerResolver); ok {
+		return resolver.Owner(claims, ownerClaim)
+	}
+	owner := claims.Owner(ownerClaim)
+	if owner == "" {
+		return "", fmt.Errorf("claim '%v' was not found", ownerClaim)
+	}
+	return owner, nil
+}
+
+func parseOwnerFromJwtToken(ctx context.Context, ownerClaim, rawJwtToken string) (string, error) {
+	claims, err := jwt.ParseToken(rawJwtToken)
+	if err != nil {
+		return "", err
+	}
+	return OwnerFromClaims(ctx, ownerClaim, claims)
+}
+
 // OwnerFromTokenMD is a helper function for extracting the ownerClaim from the :authorization gRPC metadata of the request.
 func OwnerFromTokenMD(ctx context.Context, ownerClaim string) (string, error) {
 	accessToken, err := TokenFromMD(ctx)
 	if err != nil {
 		return "", err
 	}
-	owner, err := ParseOwnerFromJwtToken(ownerClaim, accessToken)
+	owner, err := parseOwnerFromJwtToken(ctx, ownerClaim, accessToken)
 	if err != nil {
 		return "", ForwardFromError(codes.InvalidArgument, err)
 	}
 	return owner, err
 }
 
+// ServiceAccountIDFromTokenMD is a helper function for extracting ID of the service account from the :authorization gRPC metadata of the request.
+// It returns empty string for tokens of users.
+func ServiceAccountIDFromTokenMD(ctx context.Context) string {
+	token, err := TokenFromMD(ctx)
+	if err != nil {
+		return ""
+	}
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return ""
+	}
+	return claims.ServiceAccountID()
+}
+
 // SubjectFromTokenMD is a helper function for extracting the sub claim from the :authorization gRPC metadata of the request.
 func SubjectFromTokenMD(ctx context.Context) (string, error) {
 	token, err := TokenFromMD(ctx)
@@ -126,3 +180,13 @@ func SubjectFromTokenMD(ctx context.Context) (string, error) {
 	}
 	return subject, nil
 }
+
+// AuditUserIDFromTokenMD is a helper function for extracting the ID of the user who is audited for the changes made by the request
+// from the :authorization gRPC metadata of the request. Changes made by a service account are audited by the ID of the service account,
+// otherwise by the sub claim.
+func AuditUserIDFromTokenMD(ctx context.Context) (string, error) {
+	if serviceAccountID := ServiceAccountIDFromTokenMD(ctx); serviceAccountID != "" {
+		return serviceAccountID, nil
+	}
+	return SubjectFromTokenMD(ctx)
+}
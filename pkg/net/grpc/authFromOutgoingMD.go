@@ -42,7 +42,7 @@ func OwnerFromOutgoingTokenMD(ctx context.Context, ownerClaim string) (string, e
 	if err != nil {
 		return "", err
 	}
-	owner, err := ParseOwnerFromJwtToken(ownerClaim, accessToken)
+	owner, err := parseOwnerFromJwtToken(ctx, ownerClaim, accessToken)
 	if err != nil {
 		return "", ForwardFromError(codes.InvalidArgument, err)
 	}
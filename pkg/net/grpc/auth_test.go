@@ -0,0 +1,116 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	extJwt "github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testValidator accepts tokens of the issuer and resolves their owners by the issuer validator.
+type testValidator struct {
+	*jwt.IssuerValidator
+}
+
+func (v testValidator) ParseWithClaims(token string, claims extJwt.Claims) error {
+	c, err := jwt.ParseToken(token)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.Issuer(c.Issuer()); !ok {
+		return status.Errorf(codes.Unauthenticated, "untrusted issuer")
+	}
+	return nil
+}
+
+func makeTestToken(t *testing.T, claims extJwt.MapClaims) string {
+	token, err := extJwt.NewWithClaims(extJwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestValidateJWTWithValidatorOwner(t *testing.T) {
+	issuers := jwt.NewIssuerValidator()
+	issuers.AddIssuer("https://default", jwt.Issuer{})
+	issuers.AddIssuer("https://other", jwt.Issuer{OwnerClaim: "oid", NamespaceOwner: true})
+	interceptor := grpc.ValidateJWTWithValidator(testValidator{issuers}, func(ctx context.Context, method string) grpc.Claims {
+		return jwt.NewScopeClaims()
+	})
+
+	tests := []struct {
+		name    string
+		claims  extJwt.MapClaims
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			claims: extJwt.MapClaims{jwt.ClaimIssuer: "https://default", jwt.ClaimSubject: "subject", "oid": "owner"},
+			want:   "subject",
+		},
+		{
+			name:   "other",
+			claims: extJwt.MapClaims{jwt.ClaimIssuer: "https://other", jwt.ClaimSubject: "subject", "oid": "owner"},
+			want:   "https://other#owner",
+		},
+		{
+			name:    "unknown",
+			claims:  extJwt.MapClaims{jwt.ClaimIssuer: "https://unknown", jwt.ClaimSubject: "subject"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := interceptor(grpc.CtxWithIncomingToken(context.Background(), makeTestToken(t, tt.claims)), "method")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			owner, err := grpc.OwnerFromTokenMD(ctx, jwt.ClaimSubject)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, owner)
+		})
+	}
+}
+
+func TestAuditUserIDFromTokenMD(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  extJwt.MapClaims
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "user",
+			claims: extJwt.MapClaims{jwt.ClaimSubject: "subject"},
+			want:   "subject",
+		},
+		{
+			name:   "serviceAccount",
+			claims: extJwt.MapClaims{jwt.ClaimSubject: "owner", jwt.ClaimServiceAccountID: "sa0"},
+			want:   "sa0",
+		},
+		{
+			name:    "noSubject",
+			claims:  extJwt.MapClaims{"oid": "owner"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := grpc.AuditUserIDFromTokenMD(grpc.CtxWithIncomingToken(context.Background(), makeTestToken(t, tt.claims)))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, userID)
+		})
+	}
+}
@@ -3,8 +3,10 @@ package client
 import (
 	"fmt"
 
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
@@ -46,6 +48,8 @@ func New(config Config, logger log.Logger, opts ...grpc.DialOption) (*Client, er
 			Timeout:             config.KeepAlive.Timeout,
 			PermitWithoutStream: config.KeepAlive.PermitWithoutStream,
 		}),
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), grpc_prometheus.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(), grpc_prometheus.StreamClientInterceptor),
 	}
 	if len(opts) > 0 {
 		v = append(v, opts...)
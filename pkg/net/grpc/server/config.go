@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/plgd-dev/hub/pkg/security/certManager/server"
@@ -71,8 +72,36 @@ type Config struct {
 	Authorization     AuthorizationConfig     `yaml:"authorization" json:"authorization"`
 }
 
+// MethodScopesConfig requires the scopes in the token for the gRPC methods matched by the method.
+type MethodScopesConfig struct {
+	// Method is a regular expression matching the whole gRPC method name, eg. "/grpcgateway.pb.GrpcGateway/UpdateResource".
+	Method string `yaml:"method" json:"method"`
+	// Scopes are regular expressions, each of them must match a whole scope of the token.
+	Scopes []string `yaml:"scopes" json:"scopes"`
+}
+
+func (c *MethodScopesConfig) Validate() error {
+	if c.Method == "" {
+		return fmt.Errorf("method('%v')", c.Method)
+	}
+	if _, err := regexp.Compile(c.Method); err != nil {
+		return fmt.Errorf("method('%v'): %w", c.Method, err)
+	}
+	if len(c.Scopes) == 0 {
+		return fmt.Errorf("scopes('%v')", c.Scopes)
+	}
+	for i, s := range c.Scopes {
+		if _, err := regexp.Compile(s); err != nil {
+			return fmt.Errorf("scopes[%v]('%v'): %w", i, s, err)
+		}
+	}
+	return nil
+}
+
 type AuthorizationConfig struct {
-	OwnerClaim       string `yaml:"ownerClaim" json:"ownerClaim"`
+	OwnerClaim string `yaml:"ownerClaim" json:"ownerClaim"`
+	// RequiredScopes are evaluated in the order, the first rule matching the method is applied.
+	RequiredScopes   []MethodScopesConfig `yaml:"requiredScopes" json:"requiredScopes"`
 	validator.Config `yaml:",inline" json:",inline"`
 }
 
@@ -80,6 +109,11 @@ func (c *AuthorizationConfig) Validate() error {
 	if c.OwnerClaim == "" {
 		return fmt.Errorf("ownerClaim('%v')", c.OwnerClaim)
 	}
+	for i := range c.RequiredScopes {
+		if err := c.RequiredScopes[i].Validate(); err != nil {
+			return fmt.Errorf("requiredScopes[%v].%w", i, err)
+		}
+	}
 	return c.Config.Validate()
 }
 
@@ -2,21 +2,29 @@ package server
 
 import (
 	context "context"
+	"regexp"
+	"strings"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 )
 
+func init() {
+	grpc_prometheus.EnableHandlingTimeHistogram()
+}
+
 func MakeDefaultOptions(auth kitNetGrpc.AuthInterceptors, logger log.Logger) ([]grpc.ServerOption, error) {
-	streamInterceptors := []grpc.StreamServerInterceptor{}
-	unaryInterceptors := []grpc.UnaryServerInterceptor{}
+	streamInterceptors := []grpc.StreamServerInterceptor{otelgrpc.StreamServerInterceptor(), grpc_prometheus.StreamServerInterceptor}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{otelgrpc.UnaryServerInterceptor(), grpc_prometheus.UnaryServerInterceptor}
 	zapLogger, ok := logger.(*zap.SugaredLogger)
 	if ok && zapLogger.Desugar().Core().Enabled(zapcore.DebugLevel) {
 		streamInterceptors = append(streamInterceptors, grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
@@ -38,11 +46,26 @@ func MakeDefaultOptions(auth kitNetGrpc.AuthInterceptors, logger log.Logger) ([]
 
 }
 
+type methodScopes struct {
+	method *regexp.Regexp
+	scopes []*regexp.Regexp
+}
+
 type cfg struct {
 	disableTokenForwarding bool
 	whiteListedMethods     []string
+	requiredScopes         []methodScopes
+	exchangeToken          ExchangeTokenFunc
+	verifyToken            VerifyTokenFunc
+	serviceAccountScopes   bool
 }
 
+// ExchangeTokenFunc returns the token, which is validated and forwarded instead of the token of the request.
+type ExchangeTokenFunc = func(ctx context.Context, token string) (string, error)
+
+// VerifyTokenFunc rejects the validated token, eg. tokens of revoked service accounts.
+type VerifyTokenFunc = func(ctx context.Context, token string) error
+
 type Option func(*cfg)
 
 func WithDisabledTokenForwarding() Option {
@@ -57,20 +80,123 @@ func WithWhiteListedMethods(method ...string) Option {
 	}
 }
 
+// WithRequiredScopes sets the scopes required for the methods, the configuration must be validated.
+func WithRequiredScopes(requiredScopes []MethodScopesConfig) Option {
+	return func(c *cfg) {
+		for _, r := range requiredScopes {
+			scopes := make([]*regexp.Regexp, 0, len(r.Scopes))
+			for _, s := range r.Scopes {
+				scopes = append(scopes, regexp.MustCompile("^(?:"+s+")$"))
+			}
+			c.requiredScopes = append(c.requiredScopes, methodScopes{
+				method: regexp.MustCompile("^(?:" + r.Method + ")$"),
+				scopes: scopes,
+			})
+		}
+	}
+}
+
+// WithTokenExchange replaces the token of the request before the validation, eg. keys of the service accounts are exchanged for their tokens.
+func WithTokenExchange(exchangeToken ExchangeTokenFunc) Option {
+	return func(c *cfg) {
+		c.exchangeToken = exchangeToken
+	}
+}
+
+// WithTokenVerification verifies the token of the request after the validation.
+func WithTokenVerification(verifyToken VerifyTokenFunc) Option {
+	return func(c *cfg) {
+		c.verifyToken = verifyToken
+	}
+}
+
+// WithServiceAccountScopes requires the read scope from the tokens of the service accounts for the methods reading the data
+// (Get*, Retrieve*, Subscribe*) and the write scope for the other methods, when no required scopes are configured for the method.
+func WithServiceAccountScopes() Option {
+	return func(c *cfg) {
+		c.serviceAccountScopes = true
+	}
+}
+
+func (c *cfg) exchangeTokenMD(ctx context.Context) (context.Context, error) {
+	if c.exchangeToken == nil {
+		return ctx, nil
+	}
+	token, err := kitNetGrpc.TokenFromMD(ctx)
+	if err != nil {
+		// missing token is reported by the validation
+		return ctx, nil
+	}
+	exchangedToken, err := c.exchangeToken(ctx, token)
+	if err != nil {
+		return ctx, err
+	}
+	if exchangedToken == token {
+		return ctx, nil
+	}
+	return kitNetGrpc.CtxWithIncomingToken(ctx, exchangedToken), nil
+}
+
+func (c *cfg) verifyTokenMD(ctx context.Context) error {
+	if c.verifyToken == nil {
+		return nil
+	}
+	token, err := kitNetGrpc.TokenFromMD(ctx)
+	if err != nil {
+		return err
+	}
+	return c.verifyToken(ctx, token)
+}
+
+func isReadMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Get", "Retrieve", "Subscribe"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *cfg) makeScopeClaims(method string) *jwt.ScopeClaims {
+	for _, r := range c.requiredScopes {
+		if r.method.MatchString(method) {
+			return jwt.NewRegexpScopeClaims(r.scopes...)
+		}
+	}
+	claims := jwt.NewScopeClaims()
+	if !c.serviceAccountScopes {
+		return claims
+	}
+	if isReadMethod(method) {
+		return claims.WithServiceAccountScopes(jwt.ServiceAccountReadScope)
+	}
+	return claims.WithServiceAccountScopes(jwt.ServiceAccountWriteScope)
+}
+
 func NewAuth(validator kitNetGrpc.Validator, opts ...Option) kitNetGrpc.AuthInterceptors {
-	interceptor := kitNetGrpc.ValidateJWTWithValidator(validator, func(ctx context.Context, method string) kitNetGrpc.Claims {
-		return jwt.NewScopeClaims()
-	})
 	var cfg cfg
 	for _, o := range opts {
 		o(&cfg)
 	}
+	interceptor := kitNetGrpc.ValidateJWTWithValidator(validator, func(ctx context.Context, method string) kitNetGrpc.Claims {
+		return cfg.makeScopeClaims(method)
+	})
 	return kitNetGrpc.MakeAuthInterceptors(func(ctx context.Context, method string) (context.Context, error) {
-		ctx, err := interceptor(ctx, method)
+		ctx, err := cfg.exchangeTokenMD(ctx)
 		if err != nil {
 			log.Errorf("auth interceptor %v: %w", method, err)
 			return ctx, err
 		}
+		ctx, err = interceptor(ctx, method)
+		if err != nil {
+			log.Errorf("auth interceptor %v: %w", method, err)
+			return ctx, err
+		}
+		if err = cfg.verifyTokenMD(ctx); err != nil {
+			log.Errorf("auth interceptor %v: %w", method, err)
+			return ctx, err
+		}
 
 		if !cfg.disableTokenForwarding {
 			if token, err := kitNetGrpc.TokenFromMD(ctx); err == nil {
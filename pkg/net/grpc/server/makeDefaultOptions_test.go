@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	extJwt "github.com/golang-jwt/jwt/v4"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "secret"
+
+var testRequiredScopes = []MethodScopesConfig{
+	{
+		Method: "/grpcgateway.pb.GrpcGateway/(UpdateResource|DeleteDevices)",
+		Scopes: []string{"w:.*"},
+	},
+	{
+		Method: "/grpcgateway.pb.GrpcGateway/.*",
+		Scopes: []string{"r:.*"},
+	},
+}
+
+type testValidator struct{}
+
+func (testValidator) ParseWithClaims(token string, claims extJwt.Claims) error {
+	_, err := extJwt.ParseWithClaims(token, claims, func(*extJwt.Token) (interface{}, error) {
+		return []byte(testSecret), nil
+	})
+	return err
+}
+
+func makeTestToken(t *testing.T, scope ...string) string {
+	token, err := extJwt.NewWithClaims(extJwt.SigningMethodHS256, extJwt.MapClaims{
+		jwt.ClaimSubject: "owner",
+		jwt.ClaimScope:   scope,
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestMakeScopeClaims(t *testing.T) {
+	var cfg cfg
+	WithRequiredScopes(testRequiredScopes)(&cfg)
+
+	tests := []struct {
+		name    string
+		method  string
+		scope   []string
+		wantErr bool
+	}{
+		{name: "write", method: "/grpcgateway.pb.GrpcGateway/UpdateResource", scope: []string{"w:resources"}},
+		{name: "write with read scope", method: "/grpcgateway.pb.GrpcGateway/DeleteDevices", scope: []string{"r:devices"}, wantErr: true},
+		{name: "read", method: "/grpcgateway.pb.GrpcGateway/GetDevices", scope: []string{"r:devices"}},
+		{name: "read without scope", method: "/grpcgateway.pb.GrpcGateway/GetDevices", wantErr: true},
+		// the method is matched as a whole, so the suffix doesn't match the write rule
+		{name: "anchored method", method: "/grpcgateway.pb.GrpcGateway/UpdateResourceX", scope: []string{"r:resources"}},
+		// the scope is matched as a whole
+		{name: "anchored scope", method: "/grpcgateway.pb.GrpcGateway/GetDevices", scope: []string{"xr:devices"}, wantErr: true},
+		{name: "not configured method", method: "/resourceaggregate.pb.ResourceAggregate/UpdateResource"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := cfg.makeScopeClaims(tt.method)
+			(*claims)[jwt.ClaimScope] = tt.scope
+			err := claims.Valid()
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, jwt.IsMissingScopes(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewAuth(t *testing.T) {
+	auth := NewAuth(testValidator{}, WithRequiredScopes(testRequiredScopes))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode codes.Code
+	}{
+		{name: "read", method: "/grpcgateway.pb.GrpcGateway/GetDevices", token: makeTestToken(t, "r:devices"), wantCode: codes.OK},
+		{name: "write", method: "/grpcgateway.pb.GrpcGateway/UpdateResource", token: makeTestToken(t, "r:devices", "w:resources"), wantCode: codes.OK},
+		{name: "missing scope", method: "/grpcgateway.pb.GrpcGateway/UpdateResource", token: makeTestToken(t, "r:devices"), wantCode: codes.PermissionDenied},
+		{name: "invalid token", method: "/grpcgateway.pb.GrpcGateway/GetDevices", token: "invalid", wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), tt.token)
+			_, err := auth.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func makeTestServiceAccountToken(t *testing.T, serviceAccountID string, scope ...string) string {
+	token, err := extJwt.NewWithClaims(extJwt.SigningMethodHS256, extJwt.MapClaims{
+		jwt.ClaimSubject:          "owner",
+		jwt.ClaimScope:            scope,
+		jwt.ClaimServiceAccountID: serviceAccountID,
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestNewAuthServiceAccount(t *testing.T) {
+	const testKey = "key"
+	readToken := makeTestServiceAccountToken(t, "reader", "r:resources:*")
+	revokedToken := makeTestServiceAccountToken(t, "revoked", "r:resources:*", "w:resources:*")
+	exchangedToken := makeTestServiceAccountToken(t, "writer", "w:resources:*")
+	auth := NewAuth(testValidator{}, WithServiceAccountScopes(),
+		WithTokenExchange(func(ctx context.Context, token string) (string, error) {
+			if token == testKey {
+				return exchangedToken, nil
+			}
+			return token, nil
+		}),
+		WithTokenVerification(func(ctx context.Context, token string) error {
+			if token == revokedToken {
+				return status.Errorf(codes.Unauthenticated, "service account was revoked")
+			}
+			return nil
+		}))
+	var forwardedToken string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		forwardedToken, _ = kitNetGrpc.TokenFromOutgoingMD(ctx)
+		return "ok", nil
+	}
+	tests := []struct {
+		name      string
+		method    string
+		token     string
+		wantCode  codes.Code
+		wantToken string
+	}{
+		{name: "read", method: "/grpcgateway.pb.GrpcGateway/GetResources", token: readToken, wantCode: codes.OK, wantToken: readToken},
+		{name: "subscribe", method: "/grpcgateway.pb.GrpcGateway/SubscribeToEvents", token: readToken, wantCode: codes.OK, wantToken: readToken},
+		{name: "write without write scope", method: "/grpcgateway.pb.GrpcGateway/UpdateResource", token: readToken, wantCode: codes.PermissionDenied},
+		{name: "exchanged key", method: "/grpcgateway.pb.GrpcGateway/UpdateResource", token: testKey, wantCode: codes.OK, wantToken: exchangedToken},
+		{name: "read by exchanged key without read scope", method: "/grpcgateway.pb.GrpcGateway/GetDevices", token: testKey, wantCode: codes.PermissionDenied},
+		{name: "revoked", method: "/grpcgateway.pb.GrpcGateway/GetDevices", token: revokedToken, wantCode: codes.Unauthenticated},
+		// tokens of the users are not restricted by the scopes of the service accounts
+		{name: "user", method: "/grpcgateway.pb.GrpcGateway/UpdateResource", token: makeTestToken(t), wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwardedToken = ""
+			ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), tt.token)
+			_, err := auth.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantToken != "" {
+				require.Equal(t, tt.wantToken, forwardedToken)
+			}
+		})
+	}
+}
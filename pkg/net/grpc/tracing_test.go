@@ -0,0 +1,53 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+func TestServerInterceptorContinuesTrace(t *testing.T) {
+	provider, exporter := opentelemetry.NewInMemory(t.Name())
+	defer provider.Close()
+
+	auth := kitNetGrpc.MakeAuthInterceptors(func(ctx context.Context, method string) (context.Context, error) {
+		return ctx, nil
+	})
+	opts, err := server.MakeDefaultOptions(auth, log.Get())
+	require.NoError(t, err)
+	svr := StubGrpcServer(opts...)
+	defer svr.Close()
+	go func() {
+		_ = svr.Serve()
+	}()
+
+	conn, err := grpc.Dial(svr.Addr(), grpc.WithInsecure(), grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := NewStubServiceClient(conn)
+
+	ctx, span := opentelemetry.Tracer().Start(context.Background(), t.Name())
+	_, err = c.TestCall(ctx, &TestRequest{})
+	require.Error(t, err)
+	span.End()
+
+	var serverSpans int
+	for _, s := range exporter.GetSpans() {
+		require.Equal(t, span.SpanContext().TraceID(), s.SpanContext.TraceID())
+		if s.SpanKind == trace.SpanKindServer {
+			serverSpans++
+			require.Equal(t, "/"+StubService_ServiceDesc.ServiceName+"/TestCall", "/"+s.Name)
+		}
+	}
+	require.Equal(t, 1, serverSpans)
+}
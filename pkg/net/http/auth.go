@@ -82,15 +82,25 @@ func CreateAuthMiddleware(authInterceptor Interceptor, onUnauthorizedAccessFunc
 			default:
 				token := r.Header.Get("Authorization")
 				ctx := ctxWithToken(r.Context(), token)
-				_, err := authInterceptor(ctx, r.Method, r.RequestURI)
+				authCtx, err := authInterceptor(ctx, r.Method, r.RequestURI)
 				if err != nil {
 					onUnauthorizedAccessFunc(ctx, w, r, err)
 					return
 				}
 
-				if rawToken, err := ParseToken(token); err == nil {
-					r = r.WithContext(grpc.CtxWithToken(r.Context(), rawToken))
+				if authCtx == nil {
+					authCtx = ctx
 				}
+				rawToken, err := tokenFromCtx(authCtx)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				if requestToken, _ := ParseToken(token); rawToken != requestToken {
+					// the token was exchanged by the interceptor, the exchanged token is forwarded
+					r.Header.Set("Authorization", bearerKey+" "+rawToken)
+				}
+				r = r.WithContext(grpc.CtxWithToken(r.Context(), rawToken))
 				next.ServeHTTP(w, r)
 			}
 		})
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RequestScopesConfig requires the scopes in the token for the HTTP requests matched by the method and the uri.
+type RequestScopesConfig struct {
+	// Method is the HTTP method of the request, eg. "PUT".
+	Method string `yaml:"method" json:"method"`
+	// URI is a regular expression matched against the whole path of the request, eg. "/api/v1/devices/.*".
+	URI string `yaml:"uri" json:"uri"`
+	// Scopes are regular expressions, each of them must match a whole scope of the token.
+	Scopes []string `yaml:"scopes" json:"scopes"`
+}
+
+func (c *RequestScopesConfig) Validate() error {
+	if c.Method == "" {
+		return fmt.Errorf("method('%v')", c.Method)
+	}
+	if c.URI == "" {
+		return fmt.Errorf("uri('%v')", c.URI)
+	}
+	if _, err := regexp.Compile(c.URI); err != nil {
+		return fmt.Errorf("uri('%v'): %w", c.URI, err)
+	}
+	if len(c.Scopes) == 0 {
+		return fmt.Errorf("scopes('%v')", c.Scopes)
+	}
+	for i, s := range c.Scopes {
+		if _, err := regexp.Compile(s); err != nil {
+			return fmt.Errorf("scopes[%v]('%v'): %w", i, s, err)
+		}
+	}
+	return nil
+}
+
+// WithRequiredScopes returns the auths extended by the rules of the requiredScopes, which are evaluated before the rules
+// of the auths in the order of the requiredScopes. The configuration must be validated.
+func WithRequiredScopes(auths map[string][]AuthArgs, requiredScopes []RequestScopesConfig) map[string][]AuthArgs {
+	rules := make(map[string][]AuthArgs, len(auths))
+	for _, r := range requiredScopes {
+		method := strings.ToUpper(r.Method)
+		scopes := make([]*regexp.Regexp, 0, len(r.Scopes))
+		for _, s := range r.Scopes {
+			scopes = append(scopes, regexp.MustCompile("^(?:"+s+")$"))
+		}
+		rules[method] = append(rules[method], AuthArgs{
+			// the query of the request is not matched
+			URI:    regexp.MustCompile(`^(?:` + r.URI + `)(?:\?.*)?$`),
+			Scopes: scopes,
+		})
+	}
+	for method, args := range auths {
+		rules[method] = append(rules[method], args...)
+	}
+	return rules
+}
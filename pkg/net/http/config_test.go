@@ -0,0 +1,67 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	kitHttp "github.com/plgd-dev/hub/pkg/net/http"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequiredScopes(t *testing.T) {
+	defaultRules := map[string][]kitHttp.AuthArgs{
+		http.MethodGet: {
+			{
+				URI: regexp.MustCompile(`\/api\/.*`),
+			},
+		},
+	}
+	requiredScopes := []kitHttp.RequestScopesConfig{
+		{Method: "put", URI: "/api/v1/devices/.*", Scopes: []string{"w:.*"}},
+		{Method: http.MethodGet, URI: "/api/v1/devices", Scopes: []string{"r:.*"}},
+	}
+	for i := range requiredScopes {
+		require.NoError(t, requiredScopes[i].Validate())
+	}
+	claimsFunc := kitHttp.MakeClaimsFunc(kitHttp.WithRequiredScopes(defaultRules, requiredScopes))
+
+	tests := []struct {
+		name    string
+		method  string
+		uri     string
+		scope   []string
+		wantErr bool
+	}{
+		{name: "write", method: http.MethodPut, uri: "/api/v1/devices/a/b", scope: []string{"w:resources"}},
+		{name: "write without scope", method: http.MethodPut, uri: "/api/v1/devices/a/b", scope: []string{"r:resources"}, wantErr: true},
+		{name: "read", method: http.MethodGet, uri: "/api/v1/devices", scope: []string{"r:devices"}},
+		{name: "read with query", method: http.MethodGet, uri: "/api/v1/devices?status=ONLINE", scope: []string{"r:devices"}},
+		{name: "read without scope", method: http.MethodGet, uri: "/api/v1/devices", scope: []string{"xr:devices"}, wantErr: true},
+		{name: "default rule", method: http.MethodGet, uri: "/api/v1/devices/a"},
+		{name: "unknown method", method: http.MethodDelete, uri: "/api/v1/devices", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := claimsFunc(context.Background(), tt.method, tt.uri)
+			if c, ok := claims.(*jwt.ScopeClaims); ok {
+				(*c)[jwt.ClaimScope] = tt.scope
+			}
+			err := claims.Valid()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRequestScopesConfigValidate(t *testing.T) {
+	require.Error(t, (&kitHttp.RequestScopesConfig{URI: ".*", Scopes: []string{"r:.*"}}).Validate())
+	require.Error(t, (&kitHttp.RequestScopesConfig{Method: http.MethodGet, Scopes: []string{"r:.*"}}).Validate())
+	require.Error(t, (&kitHttp.RequestScopesConfig{Method: http.MethodGet, URI: "("}).Validate())
+	require.Error(t, (&kitHttp.RequestScopesConfig{Method: http.MethodGet, URI: ".*", Scopes: []string{"("}}).Validate())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
@@ -36,6 +37,29 @@ func NewInterceptorWithValidator(validator Validator, auths map[string][]AuthArg
 	}
 }
 
+// ExchangeTokenFunc returns the token, which is validated and forwarded instead of the token of the request.
+type ExchangeTokenFunc = func(ctx context.Context, token string) (string, error)
+
+// NewInterceptorWithTokenExchange replaces the token of the request before the validation, eg. keys of the service accounts
+// are exchanged for their tokens. The exchanged token is forwarded by CreateAuthMiddleware.
+func NewInterceptorWithTokenExchange(interceptor Interceptor, exchangeToken ExchangeTokenFunc) Interceptor {
+	return func(ctx context.Context, method, uri string) (context.Context, error) {
+		token, err := tokenFromCtx(ctx)
+		if err != nil {
+			// missing token is reported by the validation
+			return interceptor(ctx, method, uri)
+		}
+		exchangedToken, err := exchangeToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if exchangedToken != token {
+			ctx = ctxWithToken(ctx, exchangedToken)
+		}
+		return interceptor(ctx, method, uri)
+	}
+}
+
 // NewInterceptor authorizes HTTP request.
 func NewInterceptor(jwksURL string, tls *tls.Config, auths map[string][]AuthArgs, whiteList ...RequestMatcher) Interceptor {
 	validateJWT := validateJWT(jwksURL, tls, MakeClaimsFunc(auths))
@@ -57,13 +81,22 @@ func MakeClaimsFunc(methods map[string][]AuthArgs) ClaimsFunc {
 		}
 		for _, arg := range args {
 			if arg.URI.MatchString(uri) {
-				return jwt.NewRegexpScopeClaims(arg.Scopes...)
+				return jwt.NewRegexpScopeClaims(arg.Scopes...).WithServiceAccountScopes(serviceAccountScope(method))
 			}
 		}
 		return &DeniedClaims{fmt.Errorf("inaccessible uri: %v %v", method, uri)}
 	}
 }
 
+// serviceAccountScope returns the scope required from the tokens of the service accounts, when the request doesn't require any scope.
+func serviceAccountScope(method string) *regexp.Regexp {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return jwt.ServiceAccountReadScope
+	}
+	return jwt.ServiceAccountWriteScope
+}
+
 type DeniedClaims struct {
 	Err error
 }
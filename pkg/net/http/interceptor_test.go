@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	netHttp "net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	extJwt "github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "secret"
+
+type testValidator struct{}
+
+func (testValidator) ParseWithClaims(token string, claims extJwt.Claims) error {
+	_, err := extJwt.ParseWithClaims(token, claims, func(*extJwt.Token) (interface{}, error) {
+		return []byte(testSecret), nil
+	})
+	return err
+}
+
+func makeTestToken(t *testing.T, claims extJwt.MapClaims) string {
+	token, err := extJwt.NewWithClaims(extJwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestNewInterceptorWithTokenExchange(t *testing.T) {
+	const testKey = "key"
+	const invalidKey = "invalidKey"
+	readToken := makeTestToken(t, extJwt.MapClaims{
+		jwt.ClaimSubject:          "owner",
+		jwt.ClaimScope:            []string{"r:resources:*"},
+		jwt.ClaimServiceAccountID: "sa0",
+	})
+	userToken := makeTestToken(t, extJwt.MapClaims{
+		jwt.ClaimSubject: "owner",
+	})
+	auths := map[string][]AuthArgs{
+		netHttp.MethodGet:  {{URI: regexp.MustCompile(`/api/.*`)}},
+		netHttp.MethodPost: {{URI: regexp.MustCompile(`/api/.*`)}},
+	}
+	interceptor := NewInterceptorWithTokenExchange(NewInterceptorWithValidator(testValidator{}, auths), func(ctx context.Context, token string) (string, error) {
+		switch token {
+		case testKey:
+			return readToken, nil
+		case invalidKey:
+			return "", fmt.Errorf("invalid key")
+		}
+		return token, nil
+	})
+	var forwardedToken, forwardedHeader string
+	handler := CreateAuthMiddleware(interceptor, func(ctx context.Context, w netHttp.ResponseWriter, r *netHttp.Request, err error) {
+		w.WriteHeader(netHttp.StatusUnauthorized)
+	})(netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+		forwardedToken, _ = grpc.TokenFromOutgoingMD(r.Context())
+		forwardedHeader = r.Header.Get("Authorization")
+	}))
+
+	tests := []struct {
+		name       string
+		method     string
+		token      string
+		wantStatus int
+		wantToken  string
+	}{
+		{name: "exchanged key", method: netHttp.MethodGet, token: testKey, wantStatus: netHttp.StatusOK, wantToken: readToken},
+		// the key of the service account is not skipped, the exchanged token is validated
+		{name: "invalid key", method: netHttp.MethodGet, token: invalidKey, wantStatus: netHttp.StatusUnauthorized},
+		// tokens of the service accounts require the write scope to modify the data
+		{name: "write by read key", method: netHttp.MethodPost, token: testKey, wantStatus: netHttp.StatusUnauthorized},
+		{name: "write by read token", method: netHttp.MethodPost, token: readToken, wantStatus: netHttp.StatusUnauthorized},
+		{name: "user", method: netHttp.MethodPost, token: userToken, wantStatus: netHttp.StatusOK, wantToken: userToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwardedToken = ""
+			forwardedHeader = ""
+			req := httptest.NewRequest(tt.method, "/api/v1/devices", nil)
+			req.Header.Set("Authorization", "bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != netHttp.StatusOK {
+				return
+			}
+			require.Equal(t, tt.wantToken, forwardedToken)
+			require.Equal(t, bearerKey+" "+tt.wantToken, forwardedHeader)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package opentelemetry
+
+import (
+	"fmt"
+
+	"github.com/plgd-dev/hub/pkg/net/grpc/client"
+)
+
+// Config configures the export of traces to an OpenTelemetry collector via OTLP.
+type Config struct {
+	Enabled bool          `yaml:"enabled" json:"enabled"`
+	GRPC    client.Config `yaml:"grpc" json:"grpc"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if err := c.GRPC.Validate(); err != nil {
+		return fmt.Errorf("grpc.%w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// InstrumentationName identifies the tracer used by the hub services.
+const InstrumentationName = "github.com/plgd-dev/hub"
+
+const shutdownTimeout = time.Second * 10
+
+func init() {
+	// propagate the trace context even when the traces of the service are not exported
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Provider owns the global tracer provider of the service.
+type Provider struct {
+	provider  *sdktrace.TracerProvider
+	closeFunc []func()
+}
+
+func newProvider(serviceName string, opts ...sdktrace.TracerProviderOption) *Provider {
+	opts = append(opts, sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))))
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	return &Provider{provider: provider}
+}
+
+// New creates the tracer provider exporting spans to the OpenTelemetry collector and sets
+// it as the global provider. When the export is disabled, the global no-op provider is kept.
+func New(ctx context.Context, config Config, serviceName string, logger log.Logger) (*Provider, error) {
+	if !config.Enabled {
+		return &Provider{}, nil
+	}
+	certManager, err := client.New(config.GRPC.TLS, logger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create cert manager: %w", err)
+	}
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(config.GRPC.Addr),
+		otlptracegrpc.WithTLSCredentials(credentials.NewTLS(certManager.GetTLSConfig())),
+		otlptracegrpc.WithDialOption(grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                config.GRPC.KeepAlive.Time,
+			Timeout:             config.GRPC.KeepAlive.Timeout,
+			PermitWithoutStream: config.GRPC.KeepAlive.PermitWithoutStream,
+		})),
+	)
+	if err != nil {
+		certManager.Close()
+		return nil, fmt.Errorf("cannot create otlp exporter: %w", err)
+	}
+	p := newProvider(serviceName, sdktrace.WithBatcher(exporter))
+	p.AddCloseFunc(certManager.Close)
+	return p, nil
+}
+
+// NewInMemory creates the tracer provider storing the spans to the returned in-memory exporter
+// and sets it as the global provider. It is intended for tests.
+func NewInMemory(serviceName string) (*Provider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return newProvider(serviceName, sdktrace.WithSyncer(exporter)), exporter
+}
+
+// AddCloseFunc adds a function to be called by the Close method.
+func (p *Provider) AddCloseFunc(f func()) {
+	p.closeFunc = append(p.closeFunc, f)
+}
+
+// Close flushes the pending spans and releases the exporter.
+func (p *Provider) Close() {
+	if p.provider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := p.provider.Shutdown(ctx); err != nil {
+			log.Errorf("cannot shutdown tracer provider: %w", err)
+		}
+	}
+	for _, f := range p.closeFunc {
+		f()
+	}
+}
+
+// Tracer returns the tracer of the hub services from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
@@ -23,6 +23,11 @@ const (
 	ClaimClientID  = "client_id"
 	ClaimEmail     = "email"
 	ClaimName      = "n"
+
+	// ClaimServiceAccountID is set in tokens issued for service accounts by the identity-store.
+	ClaimServiceAccountID = "plgd:serviceaccount:id"
+	// ClaimServiceAccountKeyRotatedAt identifies the key of the service account, which the token was issued for.
+	ClaimServiceAccountKeyRotatedAt = "plgd:serviceaccount:keyrotatedat"
 )
 
 func toNum(v interface{}) (time.Time, error) {
@@ -116,6 +121,19 @@ func (c Claims) Subject() string {
 	return s
 }
 
+// ServiceAccountID returns ID of the service account, it is empty for tokens of users.
+func (c Claims) ServiceAccountID() string {
+	s, _ := strings.ToString(c[ClaimServiceAccountID])
+	return s
+}
+
+// ServiceAccountKeyRotatedAt returns the time of the rotation of the key of the service account in nanoseconds,
+// the tokens issued for the previous keys are rejected.
+func (c Claims) ServiceAccountKeyRotatedAt() string {
+	s, _ := strings.ToString(c[ClaimServiceAccountKeyRotatedAt])
+	return s
+}
+
 func (c Claims) IssuedAt() (time.Time, error) {
 	const expKey = ClaimIssuedAt
 	v, ok := c[expKey]
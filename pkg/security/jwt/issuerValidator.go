@@ -0,0 +1,136 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/pkg/strings"
+)
+
+// OwnerNamespaceSeparator separates the issuer from the owner in namespaced owners.
+const OwnerNamespaceSeparator = "#"
+
+// Issuer configures the validation and the owner of the tokens of a trusted issuer.
+type Issuer struct {
+	Validator *Validator
+	// Audience, when set, must be contained in the aud claim of the token.
+	Audience string
+	// OwnerClaim, when set, overrides the owner claim of the service for tokens of the issuer.
+	OwnerClaim string
+	// NamespaceOwner prefixes the owner by the issuer, so the owners of the issuers don't collide.
+	NamespaceOwner bool
+}
+
+// IssuerValidator validates the token by the validator of the issuer from the iss claim of the token.
+// Tokens of unknown issuers are rejected.
+type IssuerValidator struct {
+	issuers map[string]Issuer
+}
+
+func NewIssuerValidator() *IssuerValidator {
+	return &IssuerValidator{
+		issuers: make(map[string]Issuer),
+	}
+}
+
+// AddIssuer trusts the tokens with the iss claim equal to iss.
+func (v *IssuerValidator) AddIssuer(iss string, issuer Issuer) {
+	v.issuers[iss] = issuer
+}
+
+// Issuer returns the configuration of the trusted issuer.
+func (v *IssuerValidator) Issuer(iss string) (Issuer, bool) {
+	issuer, ok := v.issuers[iss]
+	return issuer, ok
+}
+
+func (v *IssuerValidator) getIssuer(claims Claims) (Issuer, error) {
+	iss, ok := v.issuers[claims.Issuer()]
+	if !ok {
+		return Issuer{}, fmt.Errorf("untrusted issuer('%v')", claims.Issuer())
+	}
+	return iss, nil
+}
+
+func (v *IssuerValidator) getValidator(token string) (*Validator, error) {
+	if token == "" {
+		return nil, fmt.Errorf("missing token")
+	}
+	claims, err := ParseToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse token: %w", err)
+	}
+	iss, err := v.getIssuer(claims)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse token: %w", err)
+	}
+	if iss.Audience != "" && !strings.Contains(claims.Audience(), iss.Audience) {
+		return nil, fmt.Errorf("could not parse token: audience('%v') of issuer('%v') not found in token", iss.Audience, claims.Issuer())
+	}
+	return iss.Validator, nil
+}
+
+func (v *IssuerValidator) Parse(token string) (jwt.MapClaims, error) {
+	validator, err := v.getValidator(token)
+	if err != nil {
+		return nil, err
+	}
+	return validator.Parse(token)
+}
+
+func (v *IssuerValidator) ParseWithContext(ctx context.Context, token string) (jwt.MapClaims, error) {
+	validator, err := v.getValidator(token)
+	if err != nil {
+		return nil, err
+	}
+	return validator.ParseWithContext(ctx, token)
+}
+
+func (v *IssuerValidator) ParseWithClaims(token string, claims jwt.Claims) error {
+	validator, err := v.getValidator(token)
+	if err != nil {
+		return err
+	}
+	return validator.ParseWithClaims(token, claims)
+}
+
+func (v *IssuerValidator) ParseWithContextClaims(ctx context.Context, token string, claims jwt.Claims) error {
+	validator, err := v.getValidator(token)
+	if err != nil {
+		return err
+	}
+	return validator.ParseWithContextClaims(ctx, token, claims)
+}
+
+// Owner returns the owner from the claims of the validated token. The ownerClaim of the service is overridden
+// by the owner claim of the issuer and the owner is prefixed by the issuer when the issuer is namespaced.
+func (v *IssuerValidator) Owner(claims Claims, ownerClaim string) (string, error) {
+	iss, err := v.getIssuer(claims)
+	if err != nil {
+		return "", err
+	}
+	if iss.OwnerClaim != "" {
+		ownerClaim = iss.OwnerClaim
+	}
+	owner := claims.Owner(ownerClaim)
+	if owner == "" {
+		return "", fmt.Errorf("claim '%v' was not found", ownerClaim)
+	}
+	if iss.NamespaceOwner {
+		return claims.Issuer() + OwnerNamespaceSeparator + owner, nil
+	}
+	return owner, nil
+}
+
+// ValidateOwner validates that the owner of the validated token is equal to the userID.
+func (v *IssuerValidator) ValidateOwner(claims Claims, ownerClaim, userID string) error {
+	owner, err := v.Owner(claims, ownerClaim)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return fmt.Errorf("owner identifier from the token '%v' doesn't match userID '%v' from the device", owner, userID)
+	}
+	return nil
+}
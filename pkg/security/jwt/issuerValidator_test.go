@@ -0,0 +1,126 @@
+package jwt_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenIssuer = "http://identity-server:3001"
+
+func TestIssuerValidator(t *testing.T) {
+	server := newTestJwks()
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		issuers map[string]jwt.Issuer
+		wantErr string
+	}{
+		{
+			name: "issuer",
+			issuers: map[string]jwt.Issuer{
+				tokenIssuer: {Validator: jwt.NewValidator(server.URL+uri, &noTLS), Audience: "test.resource"},
+			},
+			wantErr: "token is expired",
+		},
+		{
+			name: "untrusted issuer",
+			issuers: map[string]jwt.Issuer{
+				"https://other": {Validator: jwt.NewValidator(server.URL+uri, &noTLS)},
+			},
+			wantErr: "untrusted issuer",
+		},
+		{
+			name:    "no issuers",
+			wantErr: "untrusted issuer",
+		},
+		{
+			name: "invalid audience",
+			issuers: map[string]jwt.Issuer{
+				tokenIssuer: {Validator: jwt.NewValidator(server.URL+uri, &noTLS), Audience: "other.resource"},
+			},
+			wantErr: "audience('other.resource')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := jwt.NewIssuerValidator()
+			for iss, issuer := range tt.issuers {
+				v.AddIssuer(iss, issuer)
+			}
+			var c jwt.Claims
+			err := v.ParseWithClaims(token, &c)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestIssuerValidatorEmptyToken(t *testing.T) {
+	v := jwt.NewIssuerValidator()
+	_, err := v.Parse("")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing token")
+}
+
+func TestIssuerValidatorOwner(t *testing.T) {
+	v := jwt.NewIssuerValidator()
+	v.AddIssuer("https://default", jwt.Issuer{})
+	v.AddIssuer("https://owner.claim", jwt.Issuer{OwnerClaim: "oid"})
+	v.AddIssuer("https://namespaced", jwt.Issuer{OwnerClaim: "oid", NamespaceOwner: true})
+
+	tests := []struct {
+		name    string
+		claims  jwt.Claims
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			claims: jwt.Claims{jwt.ClaimIssuer: "https://default", jwt.ClaimSubject: "subject", "oid": "owner"},
+			want:   "subject",
+		},
+		{
+			name:   "owner claim of issuer",
+			claims: jwt.Claims{jwt.ClaimIssuer: "https://owner.claim", jwt.ClaimSubject: "subject", "oid": "owner"},
+			want:   "owner",
+		},
+		{
+			name:   "namespaced",
+			claims: jwt.Claims{jwt.ClaimIssuer: "https://namespaced", jwt.ClaimSubject: "subject", "oid": "owner"},
+			want:   "https://namespaced#owner",
+		},
+		{
+			name:    "missing owner claim",
+			claims:  jwt.Claims{jwt.ClaimIssuer: "https://owner.claim", jwt.ClaimSubject: "subject"},
+			wantErr: true,
+		},
+		{
+			name:    "untrusted issuer",
+			claims:  jwt.Claims{jwt.ClaimIssuer: "https://other", jwt.ClaimSubject: "subject"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Owner(tt.claims, jwt.ClaimSubject)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.NoError(t, v.ValidateOwner(tt.claims, jwt.ClaimSubject, tt.want))
+			require.Error(t, v.ValidateOwner(tt.claims, jwt.ClaimSubject, "other"))
+		})
+	}
+
+	// the owner claims are not shared between the validators
+	other := jwt.NewIssuerValidator()
+	other.AddIssuer("https://owner.claim", jwt.Issuer{})
+	got, err := other.Owner(jwt.Claims{jwt.ClaimIssuer: "https://owner.claim", jwt.ClaimSubject: "subject", "oid": "owner"}, jwt.ClaimSubject)
+	require.NoError(t, err)
+	require.Equal(t, "subject", got)
+}
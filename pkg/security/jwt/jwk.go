@@ -23,6 +23,15 @@ func NewKeyCacheWithHttp(url string, client *http.Client) *KeyCache {
 	return &KeyCache{url: url, http: client}
 }
 
+// NewKeyCacheWithKeys creates the cache of the static keys, the keys are not fetched.
+func NewKeyCacheWithKeys(keys ...jwk.Key) *KeyCache {
+	set := jwk.NewSet()
+	for _, k := range keys {
+		set.Add(k)
+	}
+	return &KeyCache{keys: set}
+}
+
 func NewKeyCache(url string, tls *tls.Config) *KeyCache {
 	t := http.DefaultTransport.(*http.Transport).Clone()
 
@@ -39,8 +48,8 @@ func NewKeyCache(url string, tls *tls.Config) *KeyCache {
 }
 
 func (c *KeyCache) GetOrFetchKeyWithContext(ctx context.Context, token *jwt.Token) (interface{}, error) {
-	if k, err := c.GetKey(token); err == nil {
-		return k, nil
+	if k, err := c.GetKey(token); err == nil || c.url == "" {
+		return k, err
 	}
 	if err := c.FetchKeysWithContext(ctx); err != nil {
 		return nil, err
@@ -49,8 +58,8 @@ func (c *KeyCache) GetOrFetchKeyWithContext(ctx context.Context, token *jwt.Toke
 }
 
 func (c *KeyCache) GetOrFetchKey(token *jwt.Token) (interface{}, error) {
-	if k, err := c.GetKey(token); err == nil {
-		return k, nil
+	if k, err := c.GetKey(token); err == nil || c.url == "" {
+		return k, err
 	}
 	if err := c.FetchKeys(); err != nil {
 		return nil, err
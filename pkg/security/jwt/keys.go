@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
+func loadPEMBlock(path string) (*pem.Block, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("cannot decode pem block")
+	}
+	return block, nil
+}
+
+// LoadPrivateKey loads RSA or ECDSA private key from the PEM file.
+func LoadPrivateKey(path string) (interface{}, error) {
+	block, err := loadPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown type")
+}
+
+// LoadPublicKey loads RSA or ECDSA public key from the PEM file. The file contains the public key or the certificate.
+func LoadPublicKey(path string) (interface{}, error) {
+	block, err := loadPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return cert.PublicKey, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown type")
+}
+
+// SigningMethod returns the method used to sign tokens by the private key.
+func SigningMethod(key interface{}) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return jwt.SigningMethodES256, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", key)
+}
+
+// NewJWK creates the JSON web key of the public key. The key ID is derived from the public key,
+// so the signer and the validators compute the same key ID.
+func NewJWK(publicKey interface{}) (jwk.Key, error) {
+	method, err := SigningMethod(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwk.New(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create jwk: %w", err)
+	}
+	data, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal public key: %w", err)
+	}
+	if err = key.Set(jwk.KeyIDKey, uuid.NewSHA1(uuid.NameSpaceX500, data).String()); err != nil {
+		return nil, fmt.Errorf("cannot set %v: %w", jwk.KeyIDKey, err)
+	}
+	if err = key.Set(jwk.AlgorithmKey, method.Alg()); err != nil {
+		return nil, fmt.Errorf("cannot set %v: %w", jwk.AlgorithmKey, err)
+	}
+	return key, nil
+}
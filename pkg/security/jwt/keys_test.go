@@ -0,0 +1,72 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	extJwt "github.com/golang-jwt/jwt/v4"
+	"github.com/plgd-dev/hub/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPEM(t *testing.T, dir, name, blockType string, data []byte) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestValidatorWithStaticKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privateData, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	publicData, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	privateKeyFile := writeTestPEM(t, dir, "key.pem", "EC PRIVATE KEY", privateData)
+	publicKeyFile := writeTestPEM(t, dir, "key.pub", "PUBLIC KEY", publicData)
+
+	privateKey, err := jwt.LoadPrivateKey(privateKeyFile)
+	require.NoError(t, err)
+	publicKey, err := jwt.LoadPublicKey(publicKeyFile)
+	require.NoError(t, err)
+	jwk, err := jwt.NewJWK(publicKey)
+	require.NoError(t, err)
+	method, err := jwt.SigningMethod(privateKey)
+	require.NoError(t, err)
+
+	sign := func(k interface{}) string {
+		token := extJwt.NewWithClaims(method, extJwt.MapClaims{
+			jwt.ClaimSubject:   "owner",
+			jwt.ClaimExpiresAt: time.Now().Add(time.Minute).Unix(),
+		})
+		token.Header["kid"] = jwk.KeyID()
+		s, err := token.SignedString(k)
+		require.NoError(t, err)
+		return s
+	}
+
+	v := jwt.NewValidatorWithKeyCache(jwt.NewKeyCacheWithKeys(jwk))
+	claims, err := v.Parse(sign(privateKey))
+	require.NoError(t, err)
+	require.Equal(t, "owner", jwt.Claims(claims).Subject())
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = v.Parse(sign(otherKey))
+	require.Error(t, err)
+}
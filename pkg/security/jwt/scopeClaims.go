@@ -1,15 +1,41 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/golang-jwt/jwt/v4"
 )
 
 type ScopeClaims Claims
 
 const PlgdRequiredScope = "plgd:required:scope"
 
+// PlgdServiceAccountRequiredScope contains the scopes required from the tokens of the service accounts, when the request
+// doesn't require any scope.
+const PlgdServiceAccountRequiredScope = "plgd:serviceaccount:required:scope"
+
+var (
+	// ServiceAccountReadScope is required from the tokens of the service accounts for the requests reading the data.
+	ServiceAccountReadScope = regexp.MustCompile(`^r:.*$`)
+	// ServiceAccountWriteScope is required from the tokens of the service accounts for the requests modifying the data.
+	ServiceAccountWriteScope = regexp.MustCompile(`^w:.*$`)
+)
+
+// ErrMissingScopes is returned when the token is valid but it doesn't contain the required scopes.
+var ErrMissingScopes = errors.New("missing scopes")
+
+// IsMissingScopes reports whether the token was rejected only because of missing required scopes.
+func IsMissingScopes(err error) bool {
+	var validationErr *jwt.ValidationError
+	if errors.As(err, &validationErr) {
+		return errors.Is(validationErr.Inner, ErrMissingScopes)
+	}
+	return errors.Is(err, ErrMissingScopes)
+}
+
 func NewScopeClaims(scope ...string) *ScopeClaims {
 	requiredScopes := make([]*regexp.Regexp, 0, len(scope))
 	for _, s := range scope {
@@ -24,6 +50,12 @@ func NewRegexpScopeClaims(scope ...*regexp.Regexp) *ScopeClaims {
 	return &v
 }
 
+// WithServiceAccountScopes sets the scopes required from the tokens of the service accounts, when no scope is required.
+func (c *ScopeClaims) WithServiceAccountScopes(scope ...*regexp.Regexp) *ScopeClaims {
+	(*c)[PlgdServiceAccountRequiredScope] = scope
+	return c
+}
+
 func (c *ScopeClaims) Valid() error {
 	v := Claims(*c)
 	if err := v.ValidTimes(time.Now()); err != nil {
@@ -33,10 +65,10 @@ func (c *ScopeClaims) Valid() error {
 	if !ok {
 		return fmt.Errorf("required scope not found")
 	}
-	if rs == nil {
-		return nil
+	requiredScopes, _ := rs.([]*regexp.Regexp)
+	if len(requiredScopes) == 0 && v.ServiceAccountID() != "" {
+		requiredScopes, _ = v[PlgdServiceAccountRequiredScope].([]*regexp.Regexp)
 	}
-	requiredScopes := rs.([]*regexp.Regexp)
 	if len(requiredScopes) == 0 {
 		return nil
 	}
@@ -59,5 +91,5 @@ func (c *ScopeClaims) Valid() error {
 	for scope := range notMatched {
 		missingRequiredScopes = append(missingRequiredScopes, scope)
 	}
-	return fmt.Errorf("missing scopes: %+v", missingRequiredScopes)
+	return fmt.Errorf("%w: %+v", ErrMissingScopes, missingRequiredScopes)
 }
@@ -19,6 +19,7 @@ func TestInvalidScope(t *testing.T) {
 	err := c.Valid()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "missing scopes")
+	require.True(t, IsMissingScopes(err))
 }
 
 func TestExpiredScope(t *testing.T) {
@@ -29,6 +30,29 @@ func TestExpiredScope(t *testing.T) {
 	require.Contains(t, err.Error(), "token is expired")
 }
 
+func TestServiceAccountScope(t *testing.T) {
+	c := testScopeClaims().WithServiceAccountScopes(ServiceAccountReadScope)
+	require.NoError(t, c.Valid())
+
+	// tokens of the service accounts require the scopes of the service accounts, when no scope is required
+	(*c)[ClaimServiceAccountID] = "sa0"
+	err := c.Valid()
+	require.Error(t, err)
+	require.True(t, IsMissingScopes(err))
+	(*c)[ClaimScope] = []string{"r:resources:*"}
+	require.NoError(t, c.Valid())
+
+	// the required scopes take precedence
+	c = NewScopeClaims("w:resources:*").WithServiceAccountScopes(ServiceAccountReadScope)
+	(*c)[ClaimServiceAccountID] = "sa0"
+	(*c)[ClaimScope] = []string{"r:resources:*"}
+	err = c.Valid()
+	require.Error(t, err)
+	require.True(t, IsMissingScopes(err))
+	(*c)[ClaimScope] = []string{"w:resources:*"}
+	require.NoError(t, c.Valid())
+}
+
 func testScopeClaims(scope ...string) *ScopeClaims {
 	c := NewScopeClaims(scope...)
 	(*c)[ClaimClientID] = "testClientID"
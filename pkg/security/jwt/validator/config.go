@@ -6,16 +6,50 @@ import (
 	"github.com/plgd-dev/hub/pkg/net/http/client"
 )
 
+// AuthorityConfig is an additional trusted authority, its tokens are selected by the iss claim. Owners of the authority
+// are prefixed by its issuer (issuer#owner), so they cannot collide with the owners of other authorities.
+type AuthorityConfig struct {
+	Authority string `yaml:"authority" json:"authority"`
+	// Audience, when set, must be contained in the aud claim of the token.
+	Audience string `yaml:"audience" json:"audience"`
+	// OwnerClaim, when set, overrides the owner claim of the service for tokens of the authority.
+	OwnerClaim string `yaml:"ownerClaim" json:"ownerClaim"`
+	// PublicKeyFile, when set, contains the key verifying the tokens of the authority. The authority is used as the issuer
+	// and its openid configuration is not fetched, eg. for service accounts of the identity-store. The tokens are issued by the hub,
+	// so their owners are not prefixed.
+	PublicKeyFile string `yaml:"publicKeyFile" json:"publicKeyFile"`
+}
+
+func (c *AuthorityConfig) Validate() error {
+	if c.Authority == "" {
+		return fmt.Errorf("authority('%v')", c.Authority)
+	}
+	return nil
+}
+
 type Config struct {
-	Authority string        `yaml:"authority" json:"authority"`
-	Audience  string        `yaml:"audience" json:"audience"`
-	HTTP      client.Config `yaml:"http" json:"http"`
+	Authority   string            `yaml:"authority" json:"authority"`
+	Audience    string            `yaml:"audience" json:"audience"`
+	Authorities []AuthorityConfig `yaml:"authorities" json:"authorities"`
+	HTTP        client.Config     `yaml:"http" json:"http"`
 }
 
 func (c *Config) Validate() error {
 	if c.Authority == "" {
 		return fmt.Errorf("authority('%v')", c.Authority)
 	}
+	authorities := map[string]bool{
+		c.Authority: true,
+	}
+	for i := range c.Authorities {
+		if err := c.Authorities[i].Validate(); err != nil {
+			return fmt.Errorf("authorities[%v].%w", i, err)
+		}
+		if authorities[c.Authorities[i].Authority] {
+			return fmt.Errorf("authorities[%v].authority('%v' is duplicit)", i, c.Authorities[i].Authority)
+		}
+		authorities[c.Authorities[i].Authority] = true
+	}
 	if err := c.HTTP.Validate(); err != nil {
 		return fmt.Errorf("http.%w", err)
 	}
@@ -15,7 +15,7 @@ import (
 // Validator Client.
 type Validator struct {
 	http                *client.Client
-	validator           *jwtValidator.Validator
+	validator           *jwtValidator.IssuerValidator
 	openIDConfiguration openid.Config
 
 	// TODO check audience at token
@@ -32,6 +32,35 @@ func (v *Validator) Close() {
 	v.http.Close()
 }
 
+func newValidator(ctx context.Context, httpClient *client.Client, authority string) (*jwtValidator.Validator, openid.Config, error) {
+	openIDCfg, err := openid.GetConfiguration(ctx, httpClient.HTTP(), authority)
+	if err != nil {
+		return nil, openid.Config{}, fmt.Errorf("cannot get openId configuration of %v: %w", authority, err)
+	}
+	return jwtValidator.NewValidatorWithKeyCache(jwtValidator.NewKeyCacheWithHttp(openIDCfg.JWKSURL, httpClient.HTTP())), openIDCfg, nil
+}
+
+func newValidatorWithPublicKey(publicKeyFile string) (*jwtValidator.Validator, error) {
+	publicKey, err := jwtValidator.LoadPublicKey(publicKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load public key %v: %w", publicKeyFile, err)
+	}
+	key, err := jwtValidator.NewJWK(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create jwk from public key %v: %w", publicKeyFile, err)
+	}
+	return jwtValidator.NewValidatorWithKeyCache(jwtValidator.NewKeyCacheWithKeys(key)), nil
+}
+
+func newAuthorityValidator(ctx context.Context, httpClient *client.Client, a AuthorityConfig) (*jwtValidator.Validator, string, error) {
+	if a.PublicKeyFile != "" {
+		v, err := newValidatorWithPublicKey(a.PublicKeyFile)
+		return v, a.Authority, err
+	}
+	v, cfg, err := newValidator(ctx, httpClient, a.Authority)
+	return v, cfg.Issuer, err
+}
+
 func New(ctx context.Context, config Config, logger log.Logger) (*Validator, error) {
 	httpClient, err := client.New(config.HTTP, logger)
 	if err != nil {
@@ -41,17 +70,35 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Validator, err
 	ctx, cancel := context.WithTimeout(ctx, config.HTTP.Timeout)
 	defer cancel()
 
-	openIDCfg, err := openid.GetConfiguration(ctx, httpClient.HTTP(), config.Authority)
+	defaultValidator, openIDCfg, err := newValidator(ctx, httpClient, config.Authority)
 	if err != nil {
 		httpClient.Close()
-		return nil, fmt.Errorf("cannot get openId configuration: %w", err)
+		return nil, err
+	}
+	validator := jwtValidator.NewIssuerValidator()
+	validator.AddIssuer(openIDCfg.Issuer, jwtValidator.Issuer{
+		Validator: defaultValidator,
+	})
+	for _, a := range config.Authorities {
+		v, issuer, err := newAuthorityValidator(ctx, httpClient, a)
+		if err != nil {
+			httpClient.Close()
+			return nil, err
+		}
+		validator.AddIssuer(issuer, jwtValidator.Issuer{
+			Validator:  v,
+			Audience:   a.Audience,
+			OwnerClaim: a.OwnerClaim,
+			// tokens issued by the hub (eg. for service accounts) belong to the owners of the hub
+			NamespaceOwner: a.PublicKeyFile == "",
+		})
 	}
 
 	return &Validator{
 		http:                httpClient,
 		openIDConfiguration: openIDCfg,
 		audience:            config.Audience,
-		validator:           jwtValidator.NewValidatorWithKeyCache(jwtValidator.NewKeyCacheWithHttp(openIDCfg.JWKSURL, httpClient.HTTP())),
+		validator:           validator,
 	}, nil
 }
 
@@ -66,3 +113,8 @@ func (v *Validator) OpenIDConfiguration() openid.Config {
 func (v *Validator) ParseWithClaims(token string, claims jwt.Claims) error {
 	return v.validator.ParseWithClaims(token, claims)
 }
+
+// Owner returns the owner of the validated token, owners of the additional authorities are prefixed by the issuer.
+func (v *Validator) Owner(claims jwtValidator.Claims, ownerClaim string) (string, error) {
+	return v.validator.Owner(claims, ownerClaim)
+}
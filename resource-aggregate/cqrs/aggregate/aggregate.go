@@ -182,6 +182,7 @@ func (a *Aggregate) handleCommandWithAggrModel(ctx context.Context, cmd Command,
 			case eventstore.ConcurrencyException:
 				return nil, true, nil
 			}
+			snapshotsCounter.Inc()
 			newVersion++
 			a.createdSnapshot = previousSnapshotEvent
 		}
@@ -199,11 +200,13 @@ func (a *Aggregate) handleCommandWithAggrModel(ctx context.Context, cmd Command,
 		return events, false, nil
 	}
 
+	snapshotAppended := false
 	if len(newEvents)+amodel.numEvents >= a.numEventsInSnapshot {
 		// append new snapshot because numEvents + len(newEvents) > a.numEventsInSnapshot
 		snapshot, ok := amodel.TakeSnapshot(newVersion + uint64(len(newEvents)))
 		if ok {
 			newEvents = append(newEvents, snapshot)
+			snapshotAppended = true
 		}
 	}
 
@@ -226,11 +229,17 @@ func (a *Aggregate) handleCommandWithAggrModel(ctx context.Context, cmd Command,
 		if status == eventstore.SnapshotRequired {
 			return nil, false, fmt.Errorf("cannot handle status need snapshot during save snapshot[%v]", previousSnapshotEvent)
 		}
+		if status == eventstore.Ok {
+			snapshotsCounter.Inc()
+		}
 		a.createdSnapshot = previousSnapshotEvent
 		return nil, true, nil
 	case eventstore.ConcurrencyException:
 		return nil, true, nil
 	}
+	if snapshotAppended {
+		snapshotsCounter.Inc()
+	}
 	if a.createdSnapshot != nil && a.createdSnapshot.Version()+1 == newEvents[0].Version() {
 		events = append(events, a.createdSnapshot)
 		a.createdSnapshot = nil
@@ -266,6 +275,7 @@ func (a *Aggregate) HandleCommand(ctx context.Context, cmd Command) ([]eventstor
 			return nil, fmt.Errorf("aggregate model cannot handle command: %w", err)
 		}
 		if concurrencyException {
+			occRetriesCounter.Inc()
 			continue
 		}
 		return events, nil
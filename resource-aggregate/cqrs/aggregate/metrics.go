@@ -0,0 +1,21 @@
+package aggregate
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	occRetriesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "plgd",
+		Subsystem: "resource_aggregate",
+		Name:      "occ_retries_total",
+		Help:      "Number of command retries caused by an optimistic concurrency conflict in the eventstore.",
+	})
+	snapshotsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "plgd",
+		Subsystem: "resource_aggregate",
+		Name:      "snapshots_total",
+		Help:      "Number of snapshots stored to the eventstore.",
+	})
+)
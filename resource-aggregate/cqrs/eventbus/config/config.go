@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	kafkaClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
+	kafkaPublisher "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/publisher"
+	kafkaSubscriber "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/subscriber"
+	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
+	natsPublisher "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/publisher"
+	natsSubscriber "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/subscriber"
+)
+
+type Backend string
+
+const (
+	NATSBackend  Backend = "nats"
+	KafkaBackend Backend = "kafka"
+)
+
+func validateBackend(backend Backend, validateNATS, validateKafka func() error) error {
+	switch backend {
+	case "", NATSBackend:
+		if err := validateNATS(); err != nil {
+			return fmt.Errorf("nats.%w", err)
+		}
+	case KafkaBackend:
+		if err := validateKafka(); err != nil {
+			return fmt.Errorf("kafka.%w", err)
+		}
+	default:
+		return fmt.Errorf("backend('%v')", backend)
+	}
+	return nil
+}
+
+type PublisherConfig struct {
+	Backend Backend                     `yaml:"backend" json:"backend" description:"nats or kafka, default nats"`
+	NATS    natsClient.ConfigPublisher  `yaml:"nats" json:"nats"`
+	Kafka   kafkaClient.ConfigPublisher `yaml:"kafka" json:"kafka"`
+}
+
+func (c *PublisherConfig) Validate() error {
+	return validateBackend(c.Backend, c.NATS.Validate, c.Kafka.Validate)
+}
+
+type SubscriberConfig struct {
+	Backend Backend                      `yaml:"backend" json:"backend" description:"nats or kafka, default nats"`
+	NATS    natsClient.Config            `yaml:"nats" json:"nats"`
+	Kafka   kafkaClient.ConfigSubscriber `yaml:"kafka" json:"kafka"`
+}
+
+func (c *SubscriberConfig) Validate() error {
+	return validateBackend(c.Backend, c.NATS.Validate, c.Kafka.Validate)
+}
+
+// Publisher is the publisher created by NewPublisher.
+type Publisher interface {
+	eventbus.Publisher
+	Close()
+}
+
+// NewPublisher creates the publisher of the configured backend. The connection to the backend is closed with the publisher.
+func NewPublisher(config PublisherConfig, logger log.Logger, marshaler func(v interface{}) ([]byte, error)) (Publisher, error) {
+	switch config.Backend {
+	case "", NATSBackend:
+		c, err := natsClient.New(config.NATS.Config, logger)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create nats client: %w", err)
+		}
+		p, err := natsPublisher.New(c.GetConn(), config.NATS.JetStream, natsPublisher.WithMarshaler(marshaler), natsPublisher.WithFlusherTimeout(config.NATS.FlusherTimeout))
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("cannot create nats publisher: %w", err)
+		}
+		p.AddCloseFunc(c.Close)
+		return p, nil
+	case KafkaBackend:
+		c, err := kafkaClient.New(config.Kafka.Config, logger)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create kafka client: %w", err)
+		}
+		p, err := kafkaPublisher.New(c, kafkaPublisher.WithMarshaler(marshaler), kafkaPublisher.WithBatchTimeout(config.Kafka.BatchTimeout))
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("cannot create kafka publisher: %w", err)
+		}
+		p.AddCloseFunc(c.Close)
+		return p, nil
+	}
+	return nil, fmt.Errorf("invalid backend('%v')", config.Backend)
+}
+
+// Subscriber is the subscriber created by NewSubscriber.
+type Subscriber interface {
+	eventbus.Subscriber
+	Close()
+}
+
+// NewSubscriber creates the subscriber of the configured backend. The connection to the backend is closed with the subscriber.
+func NewSubscriber(config SubscriberConfig, logger log.Logger, unmarshaler func(s []byte, v interface{}) error, goroutinePoolGo eventbus.GoroutinePoolGoFunc) (Subscriber, error) {
+	switch config.Backend {
+	case "", NATSBackend:
+		c, err := natsClient.New(config.NATS, logger)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create nats client: %w", err)
+		}
+		s, err := natsSubscriber.New(c.GetConn(), config.NATS.PendingLimits, logger, natsSubscriber.WithUnmarshaler(unmarshaler), natsSubscriber.WithGoPool(goroutinePoolGo))
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("cannot create nats subscriber: %w", err)
+		}
+		s.AddCloseFunc(c.Close)
+		return s, nil
+	case KafkaBackend:
+		c, err := kafkaClient.New(config.Kafka.Config, logger)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create kafka client: %w", err)
+		}
+		s, err := kafkaSubscriber.New(c, config.Kafka.ConsumerGroup, logger, kafkaSubscriber.WithUnmarshaler(unmarshaler), kafkaSubscriber.WithGoPool(goroutinePoolGo))
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("cannot create kafka subscriber: %w", err)
+		}
+		s.AddCloseFunc(c.Close)
+		return s, nil
+	}
+	return nil, fmt.Errorf("invalid backend('%v')", config.Backend)
+}
@@ -0,0 +1,51 @@
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/config"
+	eventbusTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance runs the acceptance test against all backends with the same subjects.
+func TestConformance(t *testing.T) {
+	for _, backend := range []config.Backend{config.NATSBackend, config.KafkaBackend} {
+		t.Run(string(backend), func(t *testing.T) {
+			owner := uuid.Must(uuid.NewRandom()).String()
+			// the topic of the second owner is created after the subscription with the wildcard owner
+			owner2 := uuid.Must(uuid.NewRandom()).String()
+			publishTopics := []string{"plgd.owners." + owner + ".devices.deviceId.metadata." + uuid.Must(uuid.NewRandom()).String(), "plgd.owners." + owner2 + ".devices.deviceId.resources." + uuid.Must(uuid.NewRandom()).String()}
+			subscriberTopics := []string{"plgd.owners." + owner + ".devices.deviceId.metadata.>", "plgd.owners.*.devices.*.resources.>"}
+
+			timeout := time.Second * 30
+
+			logger, err := log.NewLogger(log.Config{})
+			require.NoError(t, err)
+
+			publisher, err := config.NewPublisher(config.PublisherConfig{
+				Backend: backend,
+				NATS:    testCfg.MakePublisherConfig(),
+				Kafka:   testCfg.MakeKafkaPublisherConfig(),
+			}, logger, json.Marshal)
+			require.NoError(t, err)
+			defer publisher.Close()
+
+			subscriber, err := config.NewSubscriber(config.SubscriberConfig{
+				Backend: backend,
+				NATS:    testCfg.MakeSubscriberConfig(),
+				Kafka:   testCfg.MakeKafkaSubscriberConfig(),
+			}, logger, json.Unmarshal, func(f func()) error { go f(); return nil })
+			require.NoError(t, err)
+			defer subscriber.Close()
+
+			eventbusTest.AcceptanceTest(t, context.Background(), timeout, 0, publishTopics, subscriberTopics, publisher, subscriber)
+		})
+	}
+}
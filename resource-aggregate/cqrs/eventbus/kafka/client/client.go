@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+type Client struct {
+	dialer    *kafka.Dialer
+	transport *kafka.Transport
+	brokers   []string
+	topic     TopicConfig
+	closeFunc []func()
+
+	lock          sync.Mutex
+	createdTopics map[string]bool
+}
+
+func New(config Config, logger log.Logger) (*Client, error) {
+	certManager, err := client.New(config.TLS, logger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create cert manager: %w", err)
+	}
+	tlsConfig := certManager.GetTLSConfig()
+	dialer := &kafka.Dialer{
+		Timeout:   config.DialTimeout,
+		DualStack: true,
+		TLS:       tlsConfig,
+	}
+
+	// check that the cluster is reachable
+	conn, err := dialer.Dial("tcp", config.Brokers[0])
+	if err != nil {
+		certManager.Close()
+		return nil, fmt.Errorf("cannot create kafka client connection: %w", err)
+	}
+	if err := conn.Close(); err != nil {
+		logger.Debugf("cannot close kafka client connection: %v", err)
+	}
+
+	c := &Client{
+		dialer: dialer,
+		transport: &kafka.Transport{
+			DialTimeout: config.DialTimeout,
+			TLS:         tlsConfig,
+		},
+		brokers:       config.Brokers,
+		topic:         config.Topic,
+		createdTopics: make(map[string]bool),
+	}
+	c.AddCloseFunc(c.transport.CloseIdleConnections)
+	c.AddCloseFunc(certManager.Close)
+	return c, nil
+}
+
+func (c *Client) GetDialer() *kafka.Dialer {
+	return c.dialer
+}
+
+func (c *Client) GetTransport() *kafka.Transport {
+	return c.transport
+}
+
+func (c *Client) GetBrokers() []string {
+	return c.brokers
+}
+
+func (c *Client) isTopicCreated(topic string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.createdTopics[topic]
+}
+
+// EnsureTopics creates topics which don't exist in the cluster.
+func (c *Client) EnsureTopics(ctx context.Context, topics ...string) error {
+	toCreate := make([]kafka.TopicConfig, 0, len(topics))
+	for _, topic := range topics {
+		if c.isTopicCreated(topic) {
+			continue
+		}
+		toCreate = append(toCreate, kafka.TopicConfig{
+			Topic:             topic,
+			NumPartitions:     c.topic.Partitions,
+			ReplicationFactor: c.topic.ReplicationFactor,
+		})
+	}
+	if len(toCreate) == 0 {
+		return nil
+	}
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.brokers[0])
+	if err != nil {
+		return fmt.Errorf("cannot connect to kafka broker: %w", err)
+	}
+	defer conn.Close()
+	controller, err := conn.Controller()
+	if err != nil {
+		return fmt.Errorf("cannot get kafka controller: %w", err)
+	}
+	controllerConn, err := c.dialer.DialContext(ctx, "tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		return fmt.Errorf("cannot connect to kafka controller: %w", err)
+	}
+	defer controllerConn.Close()
+	// creating of already existing topic has no effect
+	if err := controllerConn.CreateTopics(toCreate...); err != nil {
+		return fmt.Errorf("cannot create topics: %w", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, t := range toCreate {
+		c.createdTopics[t.Topic] = true
+	}
+	return nil
+}
+
+// GetTopics returns the topics of the cluster.
+func (c *Client) GetTopics(ctx context.Context) ([]string, error) {
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to kafka broker: %w", err)
+	}
+	defer conn.Close()
+	partitions, err := conn.ReadPartitions()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read partitions: %w", err)
+	}
+	unique := make(map[string]bool)
+	topics := make([]string, 0, len(partitions))
+	for _, p := range partitions {
+		if unique[p.Topic] {
+			continue
+		}
+		unique[p.Topic] = true
+		topics = append(topics, p.Topic)
+	}
+	return topics, nil
+}
+
+func (c *Client) AddCloseFunc(f func()) {
+	c.closeFunc = append(c.closeFunc, f)
+}
+
+func (c *Client) Close() {
+	for _, f := range c.closeFunc {
+		f()
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+)
+
+type TopicConfig struct {
+	Partitions        int `yaml:"partitions" json:"partitions"`
+	ReplicationFactor int `yaml:"replicationFactor" json:"replicationFactor"`
+}
+
+func (c *TopicConfig) Validate() error {
+	if c.Partitions <= 0 {
+		return fmt.Errorf("partitions('%v')", c.Partitions)
+	}
+	if c.ReplicationFactor <= 0 {
+		return fmt.Errorf("replicationFactor('%v')", c.ReplicationFactor)
+	}
+	return nil
+}
+
+type Config struct {
+	Brokers     []string      `yaml:"brokers" json:"brokers"`
+	DialTimeout time.Duration `yaml:"dialTimeout" json:"dialTimeout"`
+	Topic       TopicConfig   `yaml:"topic" json:"topic"`
+	TLS         client.Config `yaml:"tls" json:"tls"`
+}
+
+type ConfigPublisher struct {
+	BatchTimeout time.Duration `yaml:"batchTimeout" json:"batchTimeout"`
+	Config       `yaml:",inline" json:",inline"`
+}
+
+type ConsumerGroupConfig struct {
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval" json:"heartbeatInterval"`
+	SessionTimeout    time.Duration `yaml:"sessionTimeout" json:"sessionTimeout"`
+	// TopicsRefreshInterval is the interval of resolving topics of the subjects with the wildcard owner.
+	TopicsRefreshInterval time.Duration `yaml:"topicsRefreshInterval" json:"topicsRefreshInterval"`
+}
+
+func (c *ConsumerGroupConfig) Validate() error {
+	if c.HeartbeatInterval <= 0 {
+		return fmt.Errorf("heartbeatInterval('%v')", c.HeartbeatInterval)
+	}
+	if c.SessionTimeout <= c.HeartbeatInterval {
+		return fmt.Errorf("sessionTimeout('%v')", c.SessionTimeout)
+	}
+	if c.TopicsRefreshInterval <= 0 {
+		return fmt.Errorf("topicsRefreshInterval('%v')", c.TopicsRefreshInterval)
+	}
+	return nil
+}
+
+type ConfigSubscriber struct {
+	ConsumerGroup ConsumerGroupConfig `yaml:"consumerGroup" json:"consumerGroup"`
+	Config        `yaml:",inline" json:",inline"`
+}
+
+func (c *Config) Validate() error {
+	if len(c.Brokers) == 0 {
+		return fmt.Errorf("brokers('%v')", c.Brokers)
+	}
+	for i, broker := range c.Brokers {
+		if broker == "" {
+			return fmt.Errorf("brokers[%v]('%v')", i, broker)
+		}
+	}
+	if c.DialTimeout <= 0 {
+		return fmt.Errorf("dialTimeout('%v')", c.DialTimeout)
+	}
+	if err := c.Topic.Validate(); err != nil {
+		return fmt.Errorf("topic.%w", err)
+	}
+	if err := c.TLS.Validate(); err != nil {
+		return fmt.Errorf("tls.%w", err)
+	}
+	return nil
+}
+
+func (c *ConfigPublisher) Validate() error {
+	if c.BatchTimeout <= 0 {
+		return fmt.Errorf("batchTimeout('%v')", c.BatchTimeout)
+	}
+	return c.Config.Validate()
+}
+
+func (c *ConfigSubscriber) Validate() error {
+	if err := c.ConsumerGroup.Validate(); err != nil {
+		return fmt.Errorf("consumerGroup.%w", err)
+	}
+	return c.Config.Validate()
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// HeaderCarrier adapts headers of the kafka message to the TextMapCarrier, so the trace context
+// can be propagated by the message.
+type HeaderCarrier []kafka.Header
+
+// Get returns the value associated with the passed key.
+func (hc *HeaderCarrier) Get(key string) string {
+	for _, h := range *hc {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set stores the key-value pair.
+func (hc *HeaderCarrier) Set(key string, value string) {
+	for i, h := range *hc {
+		if h.Key == key {
+			(*hc)[i].Value = []byte(value)
+			return
+		}
+	}
+	*hc = append(*hc, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys lists the keys stored in this carrier.
+func (hc *HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*hc))
+	for _, h := range *hc {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SubjectHeaderKey is the header of the kafka message which contains the subject of the event.
+const SubjectHeaderKey = "plgd-subject"
+
+const (
+	subjectSeparator = "."
+	// matches exactly one token of the subject
+	singleTokenWildcard = "*"
+	// matches one or more tailing tokens of the subject
+	tailWildcard = ">"
+)
+
+func isTopicChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-'
+}
+
+// escapeTopicToken replaces characters which are not allowed in the kafka topic name by
+// '_' followed by their hex code. The '_' is escaped too, so the mapping is unambiguous.
+func escapeTopicToken(token string) string {
+	var b strings.Builder
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		if isTopicChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "_%02x", c)
+	}
+	return b.String()
+}
+
+func isWildcard(token string) bool {
+	return token == singleTokenWildcard || token == tailWildcard
+}
+
+// ErrTopicWildcard is returned when the topic of the subject is determined by a wildcard,
+// so the subject can be matched by the subjects of several topics.
+var ErrTopicWildcard = errors.New("topic is determined by wildcard")
+
+const (
+	topicPrefix       = "plgd"
+	ownersTopicPrefix = "owners"
+	// topics of the kafka cluster starting by the prefix are internal
+	internalTopicPrefix = "__"
+)
+
+// topicTokensCount returns the count of the leading tokens of the subject which determine the topic.
+func topicTokensCount(subject string, tokens []string) (int, error) {
+	check := func(n int) error {
+		for _, token := range tokens[:n] {
+			if token == "" {
+				return fmt.Errorf("invalid subject('%v'): token('%v') cannot be used to determine topic", subject, token)
+			}
+			if isWildcard(token) {
+				return fmt.Errorf("invalid subject('%v'): token('%v') cannot be used to determine topic: %w", subject, token, ErrTopicWildcard)
+			}
+		}
+		return nil
+	}
+	if err := check(1); err != nil {
+		return 0, err
+	}
+	if tokens[0] != topicPrefix || len(tokens) < 2 {
+		return 1, nil
+	}
+	if len(tokens) == 2 {
+		if tokens[1] == tailWildcard {
+			// matches also subjects of owners
+			return 0, check(2)
+		}
+		return 1, nil
+	}
+	if tokens[1] != ownersTopicPrefix && !isWildcard(tokens[1]) {
+		return 1, nil
+	}
+	if err := check(3); err != nil {
+		return 0, err
+	}
+	return 3, nil
+}
+
+// SubjectToTopic maps the subject to the kafka topic. Subjects of the owner
+// (plgd.owners.{owner}...) are stored in the topic of the owner, other subjects
+// are stored in the topic named by the first token of the subject. The full subject
+// is carried by the SubjectHeaderKey header of the message. ErrTopicWildcard is returned
+// when the subject with wildcards can be matched by subjects of several topics, these topics
+// are resolved by MatchTopic.
+func SubjectToTopic(subject string) (string, error) {
+	tokens := strings.Split(subject, subjectSeparator)
+	n, err := topicTokensCount(subject, tokens)
+	if err != nil {
+		return "", err
+	}
+	topicTokens := make([]string, 0, n)
+	for _, token := range tokens[:n] {
+		topicTokens = append(topicTokens, escapeTopicToken(token))
+	}
+	return strings.Join(topicTokens, subjectSeparator), nil
+}
+
+// MatchTopic reports whether the topic can contain subjects matched by the pattern.
+// It is used to resolve topics of the pattern for which SubjectToTopic returns ErrTopicWildcard.
+func MatchTopic(pattern, topic string) bool {
+	if strings.HasPrefix(topic, internalTopicPrefix) {
+		return false
+	}
+	patternTokens := strings.Split(pattern, subjectSeparator)
+	topicTokens := strings.Split(topic, subjectSeparator)
+	for i, token := range topicTokens {
+		if i >= len(patternTokens) {
+			return false
+		}
+		if patternTokens[i] == tailWildcard {
+			return true
+		}
+		if patternTokens[i] != singleTokenWildcard && escapeTopicToken(patternTokens[i]) != token {
+			return false
+		}
+	}
+	if len(topicTokens) == 1 && topic == topicPrefix && len(patternTokens) > 2 && patternTokens[1] == ownersTopicPrefix {
+		// subjects of owners are stored in the topics of the owners
+		return false
+	}
+	return true
+}
+
+// MatchSubject reports whether the subject matches the pattern, which can contain
+// wildcards '*' and '>' in the same way as NATS subjects.
+func MatchSubject(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, subjectSeparator)
+	subjectTokens := strings.Split(subject, subjectSeparator)
+	for i, token := range patternTokens {
+		if token == tailWildcard {
+			return i == len(patternTokens)-1 && len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) {
+			return false
+		}
+		if token != singleTokenWildcard && token != subjectTokens[i] {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
@@ -0,0 +1,139 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectToTopic(t *testing.T) {
+	tests := []struct {
+		name         string
+		subject      string
+		want         string
+		wantErr      bool
+		wantWildcard bool
+	}{
+		{
+			name:    "owner",
+			subject: "plgd.owners.1.devices.2.resources.3.resourceChanged",
+			want:    "plgd.owners.1",
+		},
+		{
+			name:    "owner with wildcard tail",
+			subject: "plgd.owners.1.devices.2.>",
+			want:    "plgd.owners.1",
+		},
+		{
+			name:    "escaped owner",
+			subject: "plgd.owners.auth0|user_1.registrations.>",
+			want:    "plgd.owners.auth0_7cuser_5f1",
+		},
+		{
+			name:    "other",
+			subject: "test.subscriber.topic0.>",
+			want:    "test",
+		},
+		{
+			name:    "single token",
+			subject: "plgd",
+			want:    "plgd",
+		},
+		{
+			name:    "owners without owner",
+			subject: "plgd.owners",
+			want:    "plgd",
+		},
+		{
+			name:         "wildcard owner",
+			subject:      "plgd.owners.*.devices.>",
+			wantErr:      true,
+			wantWildcard: true,
+		},
+		{
+			name:         "wildcard owners",
+			subject:      "plgd.*.1.devices.>",
+			wantErr:      true,
+			wantWildcard: true,
+		},
+		{
+			name:         "wildcard tail of plgd",
+			subject:      "plgd.>",
+			wantErr:      true,
+			wantWildcard: true,
+		},
+		{
+			name:         "wildcard first token",
+			subject:      ">",
+			wantErr:      true,
+			wantWildcard: true,
+		},
+		{
+			name:    "empty",
+			subject: "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.SubjectToTopic(tt.subject)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tt.wantWildcard, errors.Is(err, client.ErrTopicWildcard))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		subject string
+		want    bool
+	}{
+		{name: "equal", pattern: "a.b.c", subject: "a.b.c", want: true},
+		{name: "different", pattern: "a.b.c", subject: "a.b.d"},
+		{name: "shorter subject", pattern: "a.b.c", subject: "a.b"},
+		{name: "longer subject", pattern: "a.b", subject: "a.b.c"},
+		{name: "single token", pattern: "a.*.c", subject: "a.b.c", want: true},
+		{name: "single token only one", pattern: "a.*", subject: "a.b.c"},
+		{name: "tail", pattern: "a.>", subject: "a.b.c", want: true},
+		{name: "tail needs token", pattern: "a.>", subject: "a"},
+		{name: "single token and tail", pattern: "a.*.>", subject: "a.b.c.d", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, client.MatchSubject(tt.pattern, tt.subject))
+		})
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{name: "wildcard owner", pattern: "plgd.owners.*.devices.>", topic: "plgd.owners.1", want: true},
+		{name: "escaped owner", pattern: "plgd.owners.auth0|user_1.devices.>", topic: "plgd.owners.auth0_7cuser_5f1", want: true},
+		{name: "other owner", pattern: "plgd.owners.1.devices.>", topic: "plgd.owners.2"},
+		{name: "owners not stored in plgd", pattern: "plgd.owners.*.devices.>", topic: "plgd"},
+		{name: "wildcard owners", pattern: "plgd.*.>", topic: "plgd", want: true},
+		{name: "wildcard owners of owner", pattern: "plgd.*.*.devices.>", topic: "plgd.owners.1", want: true},
+		{name: "tail", pattern: ">", topic: "test", want: true},
+		{name: "other topic", pattern: "plgd.owners.*.devices.>", topic: "test"},
+		{name: "internal topic", pattern: ">", topic: "__consumer_offsets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, client.MatchTopic(tt.pattern, tt.topic))
+		})
+	}
+}
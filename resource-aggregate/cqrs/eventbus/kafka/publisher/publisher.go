@@ -0,0 +1,172 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	kafkaClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/pb"
+	kafka "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+//MarshalerFunc marshal struct to bytes.
+type MarshalerFunc = func(v interface{}) ([]byte, error)
+
+// Publisher implements a eventbus.Publisher interface.
+type Publisher struct {
+	dataMarshaler MarshalerFunc
+	client        *kafkaClient.Client
+	writer        *kafka.Writer
+	closeFunc     []func()
+}
+
+func (p *Publisher) AddCloseFunc(f func()) {
+	p.closeFunc = append(p.closeFunc, f)
+}
+
+type options struct {
+	dataMarshaler MarshalerFunc
+	batchTimeout  time.Duration
+}
+
+type Option interface {
+	apply(o *options)
+}
+
+type MarshalerOpt struct {
+	dataMarshaler MarshalerFunc
+}
+
+func (o MarshalerOpt) apply(opts *options) {
+	opts.dataMarshaler = o.dataMarshaler
+}
+
+func WithMarshaler(dataMarshaler MarshalerFunc) MarshalerOpt {
+	return MarshalerOpt{
+		dataMarshaler: dataMarshaler,
+	}
+}
+
+type BatchTimeoutOpt struct {
+	batchTimeout time.Duration
+}
+
+func (o BatchTimeoutOpt) apply(opts *options) {
+	if o.batchTimeout > 0 {
+		opts.batchTimeout = o.batchTimeout
+	}
+}
+
+func WithBatchTimeout(batchTimeout time.Duration) BatchTimeoutOpt {
+	return BatchTimeoutOpt{
+		batchTimeout: batchTimeout,
+	}
+}
+
+// Create publisher with existing kafka client and proto marshaller
+func New(client *kafkaClient.Client, opts ...Option) (*Publisher, error) {
+	cfg := options{
+		dataMarshaler: json.Marshal,
+		batchTimeout:  time.Millisecond * 10,
+	}
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+
+	return &Publisher{
+		dataMarshaler: cfg.dataMarshaler,
+		client:        client,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(client.GetBrokers()...),
+			Balancer:     &kafka.Hash{},
+			BatchTimeout: cfg.batchTimeout,
+			RequiredAcks: kafka.RequireAll,
+			Transport:    client.GetTransport(),
+		},
+	}, nil
+}
+
+// Publish publishes an event to topics.
+func (p *Publisher) Publish(ctx context.Context, topics []string, groupId, aggregateId string, event eventbus.Event) (err error) {
+	ctx, span := opentelemetry.Tracer().Start(ctx, "publish "+event.EventType(), trace.WithSpanKind(trace.SpanKindProducer), trace.WithAttributes(
+		semconv.MessagingSystemKey.String("kafka"),
+		attribute.StringSlice("plgd.eventbus.topics", topics),
+		attribute.String("plgd.eventbus.group_id", groupId),
+		attribute.String("plgd.eventbus.aggregate_id", aggregateId),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	data, err := p.dataMarshaler(event)
+	if err != nil {
+		return errors.New("could not marshal data for event: " + err.Error())
+	}
+
+	e := pb.Event{
+		EventType:   event.EventType(),
+		Data:        data,
+		Version:     event.Version(),
+		GroupId:     groupId,
+		AggregateId: aggregateId,
+	}
+
+	eData, err := proto.Marshal(&e)
+	if err != nil {
+		return errors.New("could not marshal event: " + err.Error())
+	}
+
+	// carry the trace context to the subscribers
+	var headers kafkaClient.HeaderCarrier
+	otel.GetTextMapPropagator().Inject(ctx, &headers)
+
+	msgs := make([]kafka.Message, 0, len(topics))
+	kafkaTopics := make([]string, 0, len(topics))
+	for _, t := range topics {
+		topic, err := kafkaClient.SubjectToTopic(t)
+		if err != nil {
+			return fmt.Errorf("cannot publish events: %w", err)
+		}
+		kafkaTopics = append(kafkaTopics, topic)
+		msgHeaders := make([]kafka.Header, 0, len(headers)+1)
+		msgHeaders = append(msgHeaders, headers...)
+		msgHeaders = append(msgHeaders, kafka.Header{Key: kafkaClient.SubjectHeaderKey, Value: []byte(t)})
+		msgs = append(msgs, kafka.Message{
+			Topic: topic,
+			// events of the aggregate are stored in the same partition, so they are ordered
+			Key:     []byte(aggregateId),
+			Value:   eData,
+			Headers: msgHeaders,
+		})
+	}
+	if err := p.client.EnsureTopics(ctx, kafkaTopics...); err != nil {
+		return fmt.Errorf("cannot publish events: %w", err)
+	}
+
+	if err := p.writer.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("cannot publish events: %w", err)
+	}
+	return nil
+}
+
+func (p *Publisher) Close() {
+	_ = p.writer.Close()
+	for _, f := range p.closeFunc {
+		f()
+	}
+}
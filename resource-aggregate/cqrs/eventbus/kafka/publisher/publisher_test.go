@@ -0,0 +1,48 @@
+package publisher_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/publisher"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/subscriber"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/test"
+	eventbusTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/test"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher(t *testing.T) {
+	topics := []string{"plgd.owners." + uuid.Must(uuid.NewRandom()).String() + ".devices.deviceId.metadata.test", "test.subscriber_topic1" + uuid.Must(uuid.NewRandom()).String()}
+
+	timeout := time.Second * 30
+	waitForSubscription := time.Millisecond * 100
+
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
+
+	kafkaPubClient, publisher, err := test.NewClientAndPublisher(config.MakeKafkaPublisherConfig(), logger, publisher.WithMarshaler(json.Marshal))
+	require.NoError(t, err)
+	require.NotNil(t, publisher)
+	defer func() {
+		publisher.Close()
+		kafkaPubClient.Close()
+	}()
+
+	kafkaSubClient, subscriber, err := test.NewClientAndSubscriber(config.MakeKafkaSubscriberConfig(),
+		logger,
+		subscriber.WithGoPool(func(f func()) error { go f(); return nil }),
+		subscriber.WithUnmarshaler(json.Unmarshal))
+	require.NoError(t, err)
+	require.NotNil(t, subscriber)
+	defer func() {
+		subscriber.Close()
+		kafkaSubClient.Close()
+	}()
+
+	eventbusTest.AcceptanceTest(t, context.Background(), timeout, waitForSubscription, topics, topics, publisher, subscriber)
+}
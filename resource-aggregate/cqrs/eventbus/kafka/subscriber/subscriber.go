@@ -0,0 +1,575 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	kafkaClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/pb"
+	kafka "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+//UnmarshalerFunc unmarshal bytes to pointer of struct.
+type UnmarshalerFunc = func(s []byte, v interface{}) error
+
+// Subscriber implements a eventbus.Subscriber interface.
+type Subscriber struct {
+	dataUnmarshaler UnmarshalerFunc
+	logger          log.Logger
+	client          *kafkaClient.Client
+	goroutinePoolGo eventbus.GoroutinePoolGoFunc
+	consumerGroup   kafkaClient.ConsumerGroupConfig
+	closeFunc       []func()
+}
+
+func (s *Subscriber) AddCloseFunc(f func()) {
+	s.closeFunc = append(s.closeFunc, f)
+}
+
+// Observer handles events from kafka. Each topic of the observer is consumed by the consumer group
+// identified by the subscriptionId and the topic, so the events are balanced among observers with
+// the same subscriptionId. Topics with the wildcard owner consume the topics of all matching owners.
+type Observer struct {
+	lock            sync.Mutex
+	dataUnmarshaler UnmarshalerFunc
+	eventHandler    eventbus.Handler
+	logger          log.Logger
+	client          *kafkaClient.Client
+	consumerGroup   kafkaClient.ConsumerGroupConfig
+	subscriptionId  string
+	subs            map[string]*subscription
+}
+
+type options struct {
+	dataUnmarshaler UnmarshalerFunc
+	goroutinePoolGo eventbus.GoroutinePoolGoFunc
+}
+
+type Option interface {
+	apply(o *options)
+}
+
+type UnmarshalerOpt struct {
+	dataUnmarshaler UnmarshalerFunc
+}
+
+func (o UnmarshalerOpt) apply(opts *options) {
+	opts.dataUnmarshaler = o.dataUnmarshaler
+}
+
+func WithUnmarshaler(dataUnmarshaler UnmarshalerFunc) UnmarshalerOpt {
+	return UnmarshalerOpt{
+		dataUnmarshaler: dataUnmarshaler,
+	}
+}
+
+type GoroutinePoolGoOpt struct {
+	goroutinePoolGo eventbus.GoroutinePoolGoFunc
+}
+
+func (o GoroutinePoolGoOpt) apply(opts *options) {
+	opts.goroutinePoolGo = o.goroutinePoolGo
+}
+
+func WithGoPool(goroutinePoolGo eventbus.GoroutinePoolGoFunc) GoroutinePoolGoOpt {
+	return GoroutinePoolGoOpt{
+		goroutinePoolGo: goroutinePoolGo,
+	}
+}
+
+// Create subscriber with existing kafka client and proto unmarshaller
+func New(client *kafkaClient.Client, consumerGroup kafkaClient.ConsumerGroupConfig, logger log.Logger, opts ...Option) (*Subscriber, error) {
+	cfg := options{
+		dataUnmarshaler: json.Unmarshal,
+		goroutinePoolGo: nil,
+	}
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+
+	if cfg.dataUnmarshaler == nil {
+		return nil, fmt.Errorf("invalid eventUnmarshaler")
+	}
+
+	return &Subscriber{
+		dataUnmarshaler: cfg.dataUnmarshaler,
+		logger:          logger,
+		client:          client,
+		goroutinePoolGo: cfg.goroutinePoolGo,
+		consumerGroup:   consumerGroup,
+	}, nil
+}
+
+// Subscribe creates a observer that listen on events from topics.
+func (s *Subscriber) Subscribe(ctx context.Context, subscriptionId string, topics []string, eh eventbus.Handler) (eventbus.Observer, error) {
+	observer := s.newObservation(subscriptionId, eventbus.NewGoroutinePoolHandler(s.goroutinePoolGo, eh, func(err error) { s.logger.Error(err) }))
+
+	err := observer.SetTopics(ctx, topics)
+	if err != nil {
+		return nil, fmt.Errorf("cannot subscribe: %w", err)
+	}
+
+	return observer, nil
+}
+
+func (s *Subscriber) Close() {
+	for _, f := range s.closeFunc {
+		f()
+	}
+}
+
+func (s *Subscriber) newObservation(subscriptionId string, eh eventbus.Handler) *Observer {
+	return &Observer{
+		client:          s.client,
+		consumerGroup:   s.consumerGroup,
+		dataUnmarshaler: s.dataUnmarshaler,
+		subscriptionId:  subscriptionId,
+		subs:            make(map[string]*subscription),
+		eventHandler:    eh,
+		logger:          s.logger,
+	}
+}
+
+func (o *Observer) cleanUp(topics map[string]bool) (map[string]bool, error) {
+	var errors []error
+	for topic, sub := range o.subs {
+		if _, ok := topics[topic]; !ok {
+			err := sub.close()
+			if err != nil {
+				errors = append(errors, err)
+			}
+			delete(o.subs, topic)
+		}
+	}
+	newSubs := make(map[string]bool)
+	for topic := range topics {
+		if _, ok := o.subs[topic]; !ok {
+			newSubs[topic] = true
+		}
+	}
+
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("cannot unsubscribe from topics: %v", errors)
+	}
+	return newSubs, nil
+}
+
+// SetTopics set new topics to observe. It returns after all consumer groups of the new topics
+// have joined, so events published afterwards are delivered to the observer.
+func (o *Observer) SetTopics(ctx context.Context, topics []string) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	mapTopics := make(map[string]bool)
+	for _, topic := range topics {
+		mapTopics[topic] = true
+	}
+
+	newTopicsForSub, err := o.cleanUp(mapTopics)
+	if err != nil {
+		return fmt.Errorf("cannot set topics: %w", err)
+	}
+
+	cleanUpAfterError := func(err error) error {
+		errors := []error{
+			fmt.Errorf("cannot subscribe to topics: %w", err),
+		}
+		if _, err := o.cleanUp(make(map[string]bool)); err != nil {
+			errors = append(errors, err)
+		}
+		return fmt.Errorf("%+v", errors)
+	}
+
+	for topic := range newTopicsForSub {
+		sub, err := o.subscribe(ctx, topic)
+		if err != nil {
+			return cleanUpAfterError(err)
+		}
+		o.subs[topic] = sub
+	}
+	return nil
+}
+
+// Close cancel observation and leave consumer groups.
+func (o *Observer) Close() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	_, err := o.cleanUp(make(map[string]bool))
+	if err != nil {
+		return fmt.Errorf("cannot close observer: %w", err)
+	}
+	return nil
+}
+
+// resolveTopics returns topics which contain events of the subject. Topics of the subject with
+// the wildcard owner are resolved from the topics of the cluster.
+func (o *Observer) resolveTopics(ctx context.Context, subject string) ([]string, bool, error) {
+	topic, err := kafkaClient.SubjectToTopic(subject)
+	if err == nil {
+		if err := o.client.EnsureTopics(ctx, topic); err != nil {
+			return nil, false, err
+		}
+		return []string{topic}, false, nil
+	}
+	if !errors.Is(err, kafkaClient.ErrTopicWildcard) {
+		return nil, false, err
+	}
+	topics, err := o.client.GetTopics(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot resolve topics of subject %v: %w", subject, err)
+	}
+	matched := make([]string, 0, len(topics))
+	for _, t := range topics {
+		if kafkaClient.MatchTopic(subject, t) {
+			matched = append(matched, t)
+		}
+	}
+	return matched, true, nil
+}
+
+func (o *Observer) subscribe(ctx context.Context, subject string) (*subscription, error) {
+	topics, wildcard, err := o.resolveTopics(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	subCtx, cancel := context.WithCancel(context.Background())
+	s := &subscription{
+		subject:  subject,
+		observer: o,
+		ctx:      subCtx,
+		cancel:   cancel,
+	}
+	if err := s.join(ctx, topics, false); err != nil {
+		cancel()
+		return nil, err
+	}
+	if wildcard {
+		// topics of new owners are created after the subscription
+		s.refreshDone = make(chan struct{})
+		go s.refreshTopics()
+	}
+	return s, nil
+}
+
+type subscription struct {
+	subject     string
+	observer    *Observer
+	ctx         context.Context
+	cancel      context.CancelFunc
+	refreshDone chan struct{}
+
+	lock   sync.Mutex
+	topics map[string]bool
+	group  *kafka.ConsumerGroup
+	done   chan struct{}
+}
+
+// join creates the consumer group of the topics and waits for joining it, so the offsets of the observer are set.
+// Partitions without the committed offset are consumed from the first offset when fromFirstOffset is set.
+func (s *subscription) join(ctx context.Context, topics []string, fromFirstOffset bool) error {
+	s.topics = make(map[string]bool, len(topics))
+	if len(topics) == 0 {
+		return nil
+	}
+	group, err := kafka.NewConsumerGroup(kafka.ConsumerGroupConfig{
+		ID:                s.observer.subscriptionId + "/" + s.subject,
+		Brokers:           s.observer.client.GetBrokers(),
+		Dialer:            s.observer.client.GetDialer(),
+		Topics:            topics,
+		HeartbeatInterval: s.observer.consumerGroup.HeartbeatInterval,
+		SessionTimeout:    s.observer.consumerGroup.SessionTimeout,
+		StartOffset:       kafka.LastOffset,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create consumer group for subject %v: %w", s.subject, err)
+	}
+	gen, err := group.Next(ctx)
+	if err == nil {
+		err = s.start(ctx, gen, fromFirstOffset)
+	}
+	if err != nil {
+		if errClose := group.Close(); errClose != nil {
+			s.observer.logger.Errorf("cannot close consumer group for subject %v: %v", s.subject, errClose)
+		}
+		return fmt.Errorf("cannot join consumer group for subject %v: %w", s.subject, err)
+	}
+	for _, t := range topics {
+		s.topics[t] = true
+	}
+	s.group = group
+	s.done = make(chan struct{})
+	go s.run(group, fromFirstOffset, s.done)
+	return nil
+}
+
+func (s *subscription) closeGroup() error {
+	if s.group == nil {
+		return nil
+	}
+	err := s.group.Close()
+	<-s.done
+	s.group = nil
+	return err
+}
+
+// refreshTopics periodically resolves topics of the subject with the wildcard owner.
+func (s *subscription) refreshTopics() {
+	defer close(s.refreshDone)
+	ticker := time.NewTicker(s.observer.consumerGroup.TopicsRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.refresh(); err != nil && s.ctx.Err() == nil {
+			s.observer.logger.Errorf("cannot refresh topics of subject %v: %v", s.subject, err)
+		}
+	}
+}
+
+// refresh rejoins the consumer group when new topics of the subject appear. The new topics
+// were created after the subscription, so they are consumed from the first offset.
+func (s *subscription) refresh() error {
+	topics, _, err := s.observer.resolveTopics(s.ctx, s.subject)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	changed := false
+	for _, t := range topics {
+		if !s.topics[t] {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := s.closeGroup(); err != nil {
+		s.observer.logger.Debugf("cannot close consumer group for subject %v: %v", s.subject, err)
+	}
+	return s.join(s.ctx, topics, true)
+}
+
+func (s *subscription) readOffset(ctx context.Context, topic string, partition int, first bool) (int64, error) {
+	conn, err := s.observer.client.GetDialer().DialLeader(ctx, "tcp", s.observer.client.GetBrokers()[0], topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if first {
+		return conn.ReadFirstOffset()
+	}
+	return conn.ReadLastOffset()
+}
+
+// start consumes partitions assigned to the generation. Partitions without the committed offset
+// are committed at the last offset (or the first one when fromFirstOffset is set), so the events
+// published afterwards are not skipped when the partition is reassigned.
+func (s *subscription) start(ctx context.Context, gen *kafka.Generation, fromFirstOffset bool) error {
+	commits := make(map[string]map[int]int64)
+	for topic, assignments := range gen.Assignments {
+		for i, a := range assignments {
+			if a.Offset >= 0 {
+				continue
+			}
+			offset, err := s.readOffset(ctx, topic, a.ID, fromFirstOffset)
+			if err != nil {
+				return fmt.Errorf("cannot get offset of topic %v, partition %v: %w", topic, a.ID, err)
+			}
+			assignments[i].Offset = offset
+			if _, ok := commits[topic]; !ok {
+				commits[topic] = make(map[int]int64)
+			}
+			commits[topic][a.ID] = offset
+		}
+	}
+	if err := gen.CommitOffsets(commits); err != nil {
+		return fmt.Errorf("cannot commit offsets: %w", err)
+	}
+	for topic, assignments := range gen.Assignments {
+		for _, a := range assignments {
+			topic := topic
+			a := a
+			gen.Start(func(ctx context.Context) {
+				s.consume(ctx, gen, topic, a.ID, a.Offset)
+			})
+		}
+	}
+	return nil
+}
+
+// run follows generations of the consumer group until the group is closed.
+func (s *subscription) run(group *kafka.ConsumerGroup, fromFirstOffset bool, done chan struct{}) {
+	defer close(done)
+	for {
+		gen, err := group.Next(context.Background())
+		if errors.Is(err, kafka.ErrGroupClosed) {
+			return
+		}
+		if err != nil {
+			s.observer.logger.Errorf("cannot get next generation of consumer group for subject %v: %v", s.subject, err)
+			continue
+		}
+		if err := s.start(context.Background(), gen, fromFirstOffset); err != nil {
+			s.observer.logger.Errorf("cannot start generation of consumer group for subject %v: %v", s.subject, err)
+			// end the generation, so the consumer group rejoins
+			gen.Start(func(context.Context) {})
+		}
+	}
+}
+
+func (s *subscription) consume(ctx context.Context, gen *kafka.Generation, topic string, partition int, offset int64) {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   s.observer.client.GetBrokers(),
+		Dialer:    s.observer.client.GetDialer(),
+		Topic:     topic,
+		Partition: partition,
+		MaxWait:   time.Second,
+	})
+	defer func() {
+		if err := r.Close(); err != nil {
+			s.observer.logger.Debugf("cannot close reader of topic %v, partition %v: %v", topic, partition, err)
+		}
+	}()
+	if err := r.SetOffset(offset); err != nil {
+		s.observer.logger.Errorf("cannot set offset of topic %v, partition %v: %v", topic, partition, err)
+		return
+	}
+	for {
+		msg, err := r.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.observer.logger.Errorf("cannot read message of topic %v, partition %v: %v", topic, partition, err)
+			}
+			return
+		}
+		s.handleMsg(msg)
+		if err := gen.CommitOffsets(map[string]map[int]int64{topic: {partition: msg.Offset + 1}}); err != nil {
+			s.observer.logger.Debugf("cannot commit offset of topic %v, partition %v: %v", topic, partition, err)
+		}
+	}
+}
+
+func (s *subscription) close() error {
+	s.cancel()
+	if s.refreshDone != nil {
+		<-s.refreshDone
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.closeGroup()
+}
+
+func getHeader(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (s *subscription) handleMsg(msg kafka.Message) {
+	subject := getHeader(msg.Headers, kafkaClient.SubjectHeaderKey)
+	if !kafkaClient.MatchSubject(s.subject, subject) {
+		return
+	}
+
+	var e pb.Event
+	err := proto.Unmarshal(msg.Value, &e)
+	if err != nil {
+		s.observer.logger.Errorf("cannot unmarshal event: %v", err)
+		return
+	}
+
+	i := iter{
+		hasNext: true,
+		e:       &e,
+		dataUnmarshaler: func(v interface{}) error {
+			return s.observer.dataUnmarshaler(e.Data, v)
+		},
+	}
+
+	// continue the trace of the publisher
+	headers := kafkaClient.HeaderCarrier(msg.Headers)
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), &headers)
+	ctx, span := opentelemetry.Tracer().Start(ctx, "receive "+e.GetEventType(), trace.WithSpanKind(trace.SpanKindConsumer), trace.WithAttributes(
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKey.String(subject),
+		attribute.String("plgd.eventbus.group_id", e.GetGroupId()),
+		attribute.String("plgd.eventbus.aggregate_id", e.GetAggregateId()),
+	))
+	defer span.End()
+
+	if err := s.observer.eventHandler.Handle(ctx, &i); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		s.observer.logger.Errorf("cannot unmarshal event: %v", err)
+	}
+}
+
+type eventUnmarshaler struct {
+	pb              *pb.Event
+	dataUnmarshaler func(v interface{}) error
+}
+
+func (e *eventUnmarshaler) Version() uint64 {
+	return e.pb.GetVersion()
+}
+func (e *eventUnmarshaler) EventType() string {
+	return e.pb.GetEventType()
+}
+func (e *eventUnmarshaler) AggregateID() string {
+	return e.pb.GetAggregateId()
+}
+func (e *eventUnmarshaler) GroupID() string {
+	return e.pb.GetGroupId()
+}
+func (e *eventUnmarshaler) IsSnapshot() bool {
+	return e.pb.GetIsSnapshot()
+}
+func (e *eventUnmarshaler) Timestamp() time.Time {
+	return pkgTime.Unix(0, e.pb.GetTimestamp())
+}
+func (e *eventUnmarshaler) Unmarshal(v interface{}) error {
+	return e.dataUnmarshaler(v)
+}
+
+type iter struct {
+	e               *pb.Event
+	dataUnmarshaler func(v interface{}) error
+	hasNext         bool
+}
+
+func (i *iter) Next(ctx context.Context) (eventbus.EventUnmarshaler, bool) {
+	if i.hasNext {
+		i.hasNext = false
+		return &eventUnmarshaler{
+			pb:              i.e,
+			dataUnmarshaler: i.dataUnmarshaler,
+		}, true
+	}
+	return nil, false
+}
+
+func (i *iter) Err() error {
+	return nil
+}
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/publisher"
+)
+
+func NewClientAndPublisher(config client.ConfigPublisher, logger log.Logger, opts ...publisher.Option) (*client.Client, *publisher.Publisher, error) {
+	c, err := client.New(config.Config, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts = append([]publisher.Option{publisher.WithBatchTimeout(config.BatchTimeout)}, opts...)
+	p, err := publisher.New(c, opts...)
+	if err != nil {
+		c.Close()
+		return nil, nil, err
+	}
+
+	return c, p, nil
+}
@@ -0,0 +1,22 @@
+package test
+
+import (
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/subscriber"
+)
+
+func NewClientAndSubscriber(config client.ConfigSubscriber, logger log.Logger, opts ...subscriber.Option) (*client.Client, *subscriber.Subscriber, error) {
+	c, err := client.New(config.Config, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := subscriber.New(c, config.ConsumerGroup, logger, opts...)
+	if err != nil {
+		c.Close()
+		return nil, nil, err
+	}
+
+	return c, p, nil
+}
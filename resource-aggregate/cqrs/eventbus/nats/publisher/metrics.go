@@ -0,0 +1,14 @@
+package publisher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var publishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "plgd",
+	Subsystem: "eventbus_nats",
+	Name:      "publish_duration_seconds",
+	Help:      "Time spent publishing and flushing an event to NATS.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"status"})
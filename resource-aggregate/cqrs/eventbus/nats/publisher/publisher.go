@@ -8,8 +8,15 @@ import (
 	"time"
 
 	nats "github.com/nats-io/nats.go"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/pb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -21,7 +28,7 @@ type Publisher struct {
 	dataMarshaler  MarshalerFunc
 	conn           *nats.Conn
 	closeFunc      []func()
-	publish        func(subj string, data []byte) error
+	publish        func(msg *nats.Msg) error
 	flusherTimeout time.Duration
 }
 
@@ -78,14 +85,14 @@ func New(conn *nats.Conn, jetstream bool, opts ...Option) (*Publisher, error) {
 		o.apply(&cfg)
 	}
 
-	publish := conn.Publish
+	publish := conn.PublishMsg
 	if jetstream {
 		js, err := conn.JetStream()
 		if err != nil {
 			return nil, fmt.Errorf("cannot get jetstream context: %w", err)
 		}
-		publish = func(subj string, data []byte) error {
-			_, err := js.Publish(subj, data)
+		publish = func(msg *nats.Msg) error {
+			_, err := js.PublishMsg(msg)
 			return err
 		}
 	}
@@ -99,7 +106,21 @@ func New(conn *nats.Conn, jetstream bool, opts ...Option) (*Publisher, error) {
 }
 
 // Publish publishes an event to topics.
-func (p *Publisher) Publish(ctx context.Context, topics []string, groupId, aggregateId string, event eventbus.Event) error {
+func (p *Publisher) Publish(ctx context.Context, topics []string, groupId, aggregateId string, event eventbus.Event) (err error) {
+	ctx, span := opentelemetry.Tracer().Start(ctx, "publish "+event.EventType(), trace.WithSpanKind(trace.SpanKindProducer), trace.WithAttributes(
+		semconv.MessagingSystemKey.String("nats"),
+		attribute.StringSlice("plgd.eventbus.topics", topics),
+		attribute.String("plgd.eventbus.group_id", groupId),
+		attribute.String("plgd.eventbus.aggregate_id", aggregateId),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	data, err := p.dataMarshaler(event)
 	if err != nil {
 		return errors.New("could not marshal data for event: " + err.Error())
@@ -118,9 +139,18 @@ func (p *Publisher) Publish(ctx context.Context, topics []string, groupId, aggre
 		return errors.New("could not marshal event: " + err.Error())
 	}
 
+	// carry the trace context to the subscribers
+	header := make(nats.Header)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+
+	start := time.Now()
 	var errors []error
 	for _, t := range topics {
-		err := p.PublishData(t, eData)
+		err := p.publish(&nats.Msg{
+			Subject: t,
+			Data:    eData,
+			Header:  header,
+		})
 		if err != nil {
 			errors = append(errors, err)
 		}
@@ -132,14 +162,19 @@ func (p *Publisher) Publish(ctx context.Context, topics []string, groupId, aggre
 	}
 
 	if len(errors) > 0 {
+		publishDuration.WithLabelValues("error").Observe(time.Since(start).Seconds())
 		return fmt.Errorf("cannot publish events: %v", errors)
 	}
+	publishDuration.WithLabelValues("success").Observe(time.Since(start).Seconds())
 
 	return nil
 }
 
 func (p *Publisher) PublishData(subj string, data []byte) error {
-	return p.publish(subj, data)
+	return p.publish(&nats.Msg{
+		Subject: subj,
+		Data:    data,
+	})
 }
 
 func (p *Publisher) Flush(ctx context.Context) error {
@@ -3,22 +3,23 @@ package publisher_test
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	nats "github.com/nats-io/nats.go"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/publisher"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/subscriber"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/test"
+	eventbusTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/test"
 	"github.com/plgd-dev/hub/test/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestPublisher(t *testing.T) {
@@ -55,7 +56,7 @@ func TestPublisher(t *testing.T) {
 		naSubClient.Close()
 	}()
 
-	acceptanceTest(t, context.Background(), timeout, waitForSubscription, topics, publisher, subscriber)
+	eventbusTest.AcceptanceTest(t, context.Background(), timeout, waitForSubscription, topics, topics, publisher, subscriber)
 }
 
 func TestPublisherJetStream(t *testing.T) {
@@ -107,250 +108,78 @@ func TestPublisherJetStream(t *testing.T) {
 		naSubClient.Close()
 	}()
 
-	acceptanceTest(t, context.Background(), timeout, waitForSubscription, topics, publisher, subscriber)
+	eventbusTest.AcceptanceTest(t, context.Background(), timeout, waitForSubscription, topics, topics, publisher, subscriber)
 }
 
-type mockEvent struct {
-	VersionI     uint64
-	EventTypeI   string
-	AggregateIDI string
-	groupID      string
-	isSnapshot   bool
-	timestamp    int64
-	Data         string
+type traceContextHandler struct {
+	spanContext chan trace.SpanContext
 }
 
-func (e mockEvent) Version() uint64 {
-	return e.VersionI
-}
-
-func (e mockEvent) EventType() string {
-	return e.EventTypeI
-}
-
-func (e mockEvent) AggregateID() string {
-	return e.AggregateIDI
-}
-
-func (e mockEvent) GroupID() string {
-	return e.groupID
-}
-
-func (e mockEvent) IsSnapshot() bool {
-	return e.isSnapshot
-}
-
-func (e mockEvent) Timestamp() time.Time {
-	return time.Unix(0, e.timestamp)
-}
-
-type mockEventHandler struct {
-	newEvent chan mockEvent
-}
-
-func newMockEventHandler() *mockEventHandler {
-	return &mockEventHandler{newEvent: make(chan mockEvent, 10)}
-}
-
-func (eh *mockEventHandler) Handle(ctx context.Context, iter eventbus.Iter) error {
+func (h *traceContextHandler) Handle(ctx context.Context, iter eventbus.Iter) error {
 	for {
-		eu, ok := iter.Next(ctx)
+		_, ok := iter.Next(ctx)
 		if !ok {
 			break
 		}
-
-		if eu.EventType() == "" {
-			return errors.New("cannot determine type of event")
-		}
-		var e mockEvent
-		err := eu.Unmarshal(&e)
-		if err != nil {
-			return err
-		}
-		eh.newEvent <- e
+		h.spanContext <- trace.SpanContextFromContext(ctx)
 	}
-
 	return iter.Err()
 }
 
-func (eh *mockEventHandler) waitForEvent(timeout time.Duration) (mockEvent, error) {
-	select {
-	case e := <-eh.newEvent:
-		return e, nil
-	case <-time.After(timeout):
-		return mockEvent{}, fmt.Errorf("timeout")
-	}
-}
-
-func testWaitForAnyEvent(timeout time.Duration, eh1 *mockEventHandler, eh2 *mockEventHandler) (mockEvent, error) {
-	select {
-	case e := <-eh1.newEvent:
-		return e, nil
-	case e := <-eh2.newEvent:
-		return e, nil
-	case <-time.After(timeout):
-		return mockEvent{}, fmt.Errorf("timeout")
-	}
-}
-
-func testNewSubscription(t *testing.T, ctx context.Context, subscriber eventbus.Subscriber, subscriptionId string, topics []string) (*mockEventHandler, eventbus.Observer) {
-	t.Log("Subscribe to testNewSubscription")
-	m := newMockEventHandler()
-	ob, err := subscriber.Subscribe(ctx, subscriptionId, topics, m)
-	assert.NoError(t, err)
-	assert.NotNil(t, ob)
-	if ob == nil {
-		return nil, nil
-	}
-	return m, ob
-}
+func TestPublisherPropagatesTraceContext(t *testing.T) {
+	topic := "test.subscriber_topic0" + uuid.Must(uuid.NewRandom()).String()
+	provider, exporter := opentelemetry.NewInMemory(t.Name())
+	defer provider.Close()
 
-// AcceptanceTest is the acceptance test that all implementations of publisher, subscriber
-// should pass. It should manually be called from a test case in each
-// implementation:
-//
-//   func TestSubscriber(t *testing.T) {
-//       ctx := context.Background() // Or other when testing namespaces.
-//       publisher := NewPublisher()
-//       subscriber := NewSubscriber()
-//       timeout := time.Second*5
-//       topics := []string{"a", "b"}
-//       eventbus.AcceptanceTest(t, ctx, timeout, topics, publisher, subscriber)
-//   }
-//
-func acceptanceTest(t *testing.T, ctx context.Context, timeout time.Duration, waitForSubscription time.Duration, topics []string, publisher eventbus.Publisher, subscriber eventbus.Subscriber) {
-	//savedEvents := []Event{}
-	AggregateID1 := "aggregateID1"
-	AggregateID2 := "aggregateID2"
-	type Path struct {
-		AggregateId string
-		GroupId     string
-	}
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
 
-	aggregateID1Path := Path{
-		AggregateId: AggregateID1,
-		GroupId:     "deviceId",
-	}
-	/*
-		aggregateID2Path := protoEvent.Path{
-			AggregateId: AggregateID2,
-			Path:        []string{"deviceId"},
-		}
-		aggregateIDNotExistPath := protoEvent.Path{
-			AggregateId: "notExist",
-			Path:        []string{"deviceId"},
-		}
-	*/
-	eventsToPublish := []mockEvent{
-		{
-			EventTypeI:   "test0",
-			AggregateIDI: AggregateID1,
-		},
-		{
-			VersionI:     1,
-			EventTypeI:   "test1",
-			AggregateIDI: AggregateID1,
-		},
-		{
-			VersionI:     2,
-			EventTypeI:   "test2",
-			AggregateIDI: AggregateID1,
-		},
-		{
-			VersionI:     3,
-			EventTypeI:   "test3",
-			AggregateIDI: AggregateID1,
-		},
-		{
-			VersionI:     4,
-			EventTypeI:   "test4",
-			AggregateIDI: AggregateID1,
-		},
-		{
-			VersionI:     5,
-			EventTypeI:   "test5",
-			AggregateIDI: AggregateID1,
-		},
-		{
-			VersionI:     6,
-			EventTypeI:   "test6",
-			AggregateIDI: AggregateID2,
+	naPubClient, publisher, err := test.NewClientAndPublisher(client.ConfigPublisher{
+		Config: client.Config{
+			URL:            "nats://localhost:4222",
+			TLS:            config.MakeTLSClientConfig(),
+			FlusherTimeout: time.Second * 30,
 		},
-	}
-
-	assert.Equal(t, 2, len(topics))
-
-	t.Log("Without subscription")
-	err := publisher.Publish(ctx, topics[0:1], aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[0])
-	assert.NoError(t, err)
-	time.Sleep(waitForSubscription)
-
-	// Add handlers and observers.
-	t.Log("Subscribe to first topic")
-	m0, ob0 := testNewSubscription(t, ctx, subscriber, "sub-0", topics[0:1])
-	time.Sleep(waitForSubscription)
-
-	err = publisher.Publish(ctx, topics[0:1], aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[1])
-	assert.NoError(t, err)
-
-	event0, err := m0.waitForEvent(timeout)
-	assert.NoError(t, err)
-	assert.Equal(t, eventsToPublish[1], event0)
-
-	err = ob0.Close()
-	assert.NoError(t, err)
-	t.Log("Subscribe more observers")
-	m1, ob1 := testNewSubscription(t, ctx, subscriber, "sub-1", topics[1:2])
-	defer func() {
-		err = ob1.Close()
-		assert.NoError(t, err)
-	}()
-	m2, ob2 := testNewSubscription(t, ctx, subscriber, "sub-2", topics[1:2])
+	}, logger, publisher.WithMarshaler(json.Marshal))
+	require.NoError(t, err)
 	defer func() {
-		err = ob2.Close()
-		assert.NoError(t, err)
+		publisher.Close()
+		naPubClient.Close()
 	}()
-	m3, ob3 := testNewSubscription(t, ctx, subscriber, "sub-shared", topics[0:1])
+
+	naSubClient, subscriber, err := test.NewClientAndSubscriber(config.MakeSubscriberConfig(),
+		logger,
+		subscriber.WithUnmarshaler(json.Unmarshal))
+	require.NoError(t, err)
 	defer func() {
-		err = ob3.Close()
-		assert.NoError(t, err)
+		subscriber.Close()
+		naSubClient.Close()
 	}()
-	m4, ob4 := testNewSubscription(t, ctx, subscriber, "sub-shared", topics[0:1])
+
+	h := &traceContextHandler{spanContext: make(chan trace.SpanContext, 1)}
+	ob, err := subscriber.Subscribe(context.Background(), "sub-trace", []string{topic}, h)
+	require.NoError(t, err)
 	defer func() {
-		err = ob4.Close()
-		assert.NoError(t, err)
+		err := ob.Close()
+		require.NoError(t, err)
 	}()
 
-	time.Sleep(waitForSubscription)
-
-	err = publisher.Publish(ctx, topics, aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[2])
-	assert.NoError(t, err)
-
-	event1, err := m1.waitForEvent(timeout)
-	assert.NoError(t, err)
-	assert.Equal(t, eventsToPublish[2], event1)
-
-	event2, err := m2.waitForEvent(timeout)
-	assert.NoError(t, err)
-	assert.Equal(t, eventsToPublish[2], event2)
-
-	event3, err := testWaitForAnyEvent(timeout, m3, m4)
-	assert.NoError(t, err)
-	assert.Equal(t, eventsToPublish[2], event3)
-
-	topic := "test.new_topic_" + uuid.Must(uuid.NewRandom()).String()
-	topics = append(topics, topic)
-	err = ob4.SetTopics(ctx, topics)
-	time.Sleep(waitForSubscription)
-	assert.NoError(t, err)
-
-	err = publisher.Publish(ctx, []string{topic}, aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[3])
-	assert.NoError(t, err)
+	ctx, span := opentelemetry.Tracer().Start(context.Background(), t.Name())
+	err = publisher.Publish(ctx, []string{topic}, "deviceId", "aggregateID1", eventbusTest.MockEvent{EventTypeI: "test0"})
+	require.NoError(t, err)
+	span.End()
 
-	event4, err := m4.waitForEvent(timeout)
-	assert.NoError(t, err)
-	assert.Equal(t, eventsToPublish[3], event4)
+	select {
+	case sc := <-h.spanContext:
+		require.Equal(t, span.SpanContext().TraceID(), sc.TraceID())
+	case <-time.After(time.Second * 10):
+		require.FailNow(t, "timeout")
+	}
 
-	err = ob4.SetTopics(ctx, nil)
-	assert.NoError(t, err)
+	// publish and receive spans belong to the trace of the caller
+	spans := exporter.GetSpans()
+	require.NotEmpty(t, spans)
+	for _, s := range spans {
+		require.Equal(t, span.SpanContext().TraceID(), s.SpanContext.TraceID())
+	}
 }
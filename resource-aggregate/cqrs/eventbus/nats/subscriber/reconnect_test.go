@@ -12,6 +12,7 @@ import (
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/publisher"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/subscriber"
 	natsTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/test"
+	eventbusTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/test"
 	"github.com/plgd-dev/hub/test"
 	"github.com/plgd-dev/hub/test/config"
 	"github.com/stretchr/testify/require"
@@ -49,15 +50,24 @@ func TestSubscriberReconnect(t *testing.T) {
 
 	// Add handlers and observers.
 	t.Log("Subscribe to first topic")
-	m0, _ := testNewSubscription(t, ctx, subscriber, "sub-0", topics[0:1])
+	m0 := eventbusTest.NewMockEventHandler()
+	ob0, err := subscriber.Subscribe(ctx, "sub-0", topics[0:1], m0)
+	require.NoError(t, err)
+	defer func() {
+		err := ob0.Close()
+		require.NoError(t, err)
+	}()
 
 	AggregateID1 := "aggregateID1"
-	aggregateID1Path := Path{
+	aggregateID1Path := struct {
+		AggregateId string
+		GroupId     string
+	}{
 		AggregateId: AggregateID1,
 		GroupId:     "deviceId",
 	}
 
-	eventsToPublish := []mockEvent{
+	eventsToPublish := []eventbusTest.MockEvent{
 		{
 			EventTypeI:   "test0",
 			AggregateIDI: AggregateID1,
@@ -72,7 +82,7 @@ func TestSubscriberReconnect(t *testing.T) {
 	err = pub.Publish(ctx, topics[0:1], aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[0])
 	require.NoError(t, err)
 
-	event0, err := m0.waitForEvent(timeout)
+	event0, err := m0.WaitForEvent(timeout)
 	require.NoError(t, err)
 	require.Equal(t, eventsToPublish[0], event0)
 
@@ -99,7 +109,7 @@ func TestSubscriberReconnect(t *testing.T) {
 	}()
 	err = pub1.Publish(ctx, topics[0:1], aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[1])
 	require.NoError(t, err)
-	event0, err = m0.waitForEvent(timeout)
+	event0, err = m0.WaitForEvent(timeout)
 	require.NoError(t, err)
 	require.Equal(t, eventsToPublish[1], event0)
 }
@@ -9,10 +9,17 @@ import (
 
 	nats "github.com/nats-io/nats.go"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	pkgTime "github.com/plgd-dev/hub/pkg/time"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/pb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -298,7 +305,19 @@ func (o *Observer) handleMsg(msg *nats.Msg) {
 		},
 	}
 
-	if err := o.eventHandler.Handle(context.Background(), &i); err != nil {
+	// continue the trace of the publisher
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(msg.Header))
+	ctx, span := opentelemetry.Tracer().Start(ctx, "receive "+e.GetEventType(), trace.WithSpanKind(trace.SpanKindConsumer), trace.WithAttributes(
+		semconv.MessagingSystemKey.String("nats"),
+		semconv.MessagingDestinationKey.String(msg.Subject),
+		attribute.String("plgd.eventbus.group_id", e.GetGroupId()),
+		attribute.String("plgd.eventbus.aggregate_id", e.GetAggregateId()),
+	))
+	defer span.End()
+
+	if err := o.eventHandler.Handle(ctx, &i); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		o.logger.Errorf("cannot unmarshal event: %v", err)
 	}
 }
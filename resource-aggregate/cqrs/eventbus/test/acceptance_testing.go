@@ -0,0 +1,164 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubscription(t *testing.T, ctx context.Context, subscriber eventbus.Subscriber, subscriptionId string, topics []string) (*MockEventHandler, eventbus.Observer) {
+	t.Log("Subscribe to newSubscription")
+	m := NewMockEventHandler()
+	ob, err := subscriber.Subscribe(ctx, subscriptionId, topics, m)
+	require.NoError(t, err)
+	require.NotNil(t, ob)
+	return m, ob
+}
+
+// AcceptanceTest is the acceptance test that all implementations of publisher, subscriber
+// should pass. It should manually be called from a test case in each
+// implementation:
+//
+//   func TestSubscriber(t *testing.T) {
+//       ctx := context.Background() // Or other when testing namespaces.
+//       publisher := NewPublisher()
+//       subscriber := NewSubscriber()
+//       timeout := time.Second*5
+//       waitForSubscription := time.Millisecond*100
+//       publishTopics := []string{"a.b", "a.c"}
+//       subscribeTopics := []string{"a.b", "a.*"}
+//       test.AcceptanceTest(t, ctx, timeout, waitForSubscription, publishTopics, subscribeTopics, publisher, subscriber)
+//   }
+//
+// The first subscribe topic must match the first publish topic and the second subscribe topic
+// must match the second publish topic. Topics are subjects separated by '.', where '*' matches
+// one token and '>' matches one or more tailing tokens.
+func AcceptanceTest(t *testing.T, ctx context.Context, timeout time.Duration, waitForSubscription time.Duration, publishTopics, subscribeTopics []string, publisher eventbus.Publisher, subscriber eventbus.Subscriber) {
+	AggregateID1 := "aggregateID1"
+	AggregateID2 := "aggregateID2"
+	type Path struct {
+		AggregateId string
+		GroupId     string
+	}
+
+	aggregateID1Path := Path{
+		AggregateId: AggregateID1,
+		GroupId:     "deviceId",
+	}
+	eventsToPublish := []MockEvent{
+		{
+			EventTypeI:   "test0",
+			AggregateIDI: AggregateID1,
+		},
+		{
+			VersionI:     1,
+			EventTypeI:   "test1",
+			AggregateIDI: AggregateID1,
+		},
+		{
+			VersionI:     2,
+			EventTypeI:   "test2",
+			AggregateIDI: AggregateID1,
+		},
+		{
+			VersionI:     3,
+			EventTypeI:   "test3",
+			AggregateIDI: AggregateID1,
+		},
+		{
+			VersionI:     4,
+			EventTypeI:   "test4",
+			AggregateIDI: AggregateID1,
+		},
+		{
+			VersionI:     5,
+			EventTypeI:   "test5",
+			AggregateIDI: AggregateID1,
+		},
+		{
+			VersionI:     6,
+			EventTypeI:   "test6",
+			AggregateIDI: AggregateID2,
+		},
+	}
+
+	require.Equal(t, 2, len(publishTopics))
+	require.Equal(t, 2, len(subscribeTopics))
+
+	t.Log("Without subscription")
+	err := publisher.Publish(ctx, publishTopics[0:1], aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[0])
+	require.NoError(t, err)
+	time.Sleep(waitForSubscription)
+
+	// Add handlers and observers.
+	t.Log("Subscribe to first topic")
+	m0, ob0 := newSubscription(t, ctx, subscriber, "sub-0", subscribeTopics[0:1])
+	time.Sleep(waitForSubscription)
+
+	err = publisher.Publish(ctx, publishTopics[0:1], aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[1])
+	require.NoError(t, err)
+
+	event0, err := m0.WaitForEvent(timeout)
+	require.NoError(t, err)
+	require.Equal(t, eventsToPublish[1], event0)
+
+	err = ob0.Close()
+	require.NoError(t, err)
+	t.Log("Subscribe more observers")
+	m1, ob1 := newSubscription(t, ctx, subscriber, "sub-1", subscribeTopics[1:2])
+	defer func() {
+		err = ob1.Close()
+		require.NoError(t, err)
+	}()
+	m2, ob2 := newSubscription(t, ctx, subscriber, "sub-2", subscribeTopics[1:2])
+	defer func() {
+		err = ob2.Close()
+		require.NoError(t, err)
+	}()
+	m3, ob3 := newSubscription(t, ctx, subscriber, "sub-shared", subscribeTopics[0:1])
+	defer func() {
+		err = ob3.Close()
+		require.NoError(t, err)
+	}()
+	m4, ob4 := newSubscription(t, ctx, subscriber, "sub-shared", subscribeTopics[0:1])
+	defer func() {
+		err = ob4.Close()
+		require.NoError(t, err)
+	}()
+	time.Sleep(waitForSubscription)
+
+	err = publisher.Publish(ctx, publishTopics, aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[2])
+	require.NoError(t, err)
+
+	event1, err := m1.WaitForEvent(timeout)
+	require.NoError(t, err)
+	require.Equal(t, eventsToPublish[2], event1)
+
+	event2, err := m2.WaitForEvent(timeout)
+	require.NoError(t, err)
+	require.Equal(t, eventsToPublish[2], event2)
+
+	event3, err := WaitForAnyEvent(timeout, m3, m4)
+	require.NoError(t, err)
+	require.Equal(t, eventsToPublish[2], event3)
+
+	topic := "test.new_topic_" + uuid.Must(uuid.NewRandom()).String()
+	publishTopics = append(publishTopics, topic)
+	err = ob4.SetTopics(ctx, publishTopics)
+	require.NoError(t, err)
+	time.Sleep(waitForSubscription)
+
+	err = publisher.Publish(ctx, []string{topic}, aggregateID1Path.GroupId, aggregateID1Path.AggregateId, eventsToPublish[3])
+	require.NoError(t, err)
+
+	event4, err := m4.WaitForEvent(timeout)
+	require.NoError(t, err)
+	require.Equal(t, eventsToPublish[3], event4)
+
+	err = ob4.SetTopics(ctx, nil)
+	require.NoError(t, err)
+}
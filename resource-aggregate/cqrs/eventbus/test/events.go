@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+)
+
+type MockEvent struct {
+	VersionI     uint64
+	EventTypeI   string
+	AggregateIDI string
+	groupID      string
+	isSnapshot   bool
+	timestamp    int64
+	Data         string
+}
+
+func (e MockEvent) Version() uint64 {
+	return e.VersionI
+}
+
+func (e MockEvent) EventType() string {
+	return e.EventTypeI
+}
+
+func (e MockEvent) AggregateID() string {
+	return e.AggregateIDI
+}
+
+func (e MockEvent) GroupID() string {
+	return e.groupID
+}
+
+func (e MockEvent) IsSnapshot() bool {
+	return e.isSnapshot
+}
+
+func (e MockEvent) Timestamp() time.Time {
+	return time.Unix(0, e.timestamp)
+}
+
+type MockEventHandler struct {
+	newEvent chan MockEvent
+}
+
+func NewMockEventHandler() *MockEventHandler {
+	return &MockEventHandler{newEvent: make(chan MockEvent, 10)}
+}
+
+func (eh *MockEventHandler) Handle(ctx context.Context, iter eventbus.Iter) error {
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			break
+		}
+
+		if eu.EventType() == "" {
+			return errors.New("cannot determine type of event")
+		}
+		var e MockEvent
+		err := eu.Unmarshal(&e)
+		if err != nil {
+			return err
+		}
+		eh.newEvent <- e
+	}
+
+	return iter.Err()
+}
+
+func (eh *MockEventHandler) WaitForEvent(timeout time.Duration) (MockEvent, error) {
+	select {
+	case e := <-eh.newEvent:
+		return e, nil
+	case <-time.After(timeout):
+		return MockEvent{}, fmt.Errorf("timeout")
+	}
+}
+
+func WaitForAnyEvent(timeout time.Duration, eh1 *MockEventHandler, eh2 *MockEventHandler) (MockEvent, error) {
+	select {
+	case e := <-eh1.newEvent:
+		return e, nil
+	case e := <-eh2.newEvent:
+		return e, nil
+	case <-time.After(timeout):
+		return MockEvent{}, fmt.Errorf("timeout")
+	}
+}
@@ -1,18 +1,67 @@
 package config
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/cqldb"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/maintenance"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
 )
 
+type Backend string
+
+const (
+	MongoDBBackend Backend = "mongoDB"
+	CqlDBBackend   Backend = "cqlDB"
+)
+
 type Config struct {
+	Backend Backend        `yaml:"backend" json:"backend" description:"mongoDB or cqlDB, default mongoDB"`
 	MongoDB mongodb.Config `yaml:"mongoDB" json:"mongoDB"`
+	CqlDB   cqldb.Config   `yaml:"cqlDB" json:"cqlDB"`
 }
 
 func (c *Config) Validate() error {
-	if err := c.MongoDB.Validate(); err != nil {
-		return fmt.Errorf("mongoDB.%w", err)
+	switch c.Backend {
+	case "", MongoDBBackend:
+		if err := c.MongoDB.Validate(); err != nil {
+			return fmt.Errorf("mongoDB.%w", err)
+		}
+	case CqlDBBackend:
+		if err := c.CqlDB.Validate(); err != nil {
+			return fmt.Errorf("cqlDB.%w", err)
+		}
+	default:
+		return fmt.Errorf("backend('%v')", c.Backend)
 	}
 	return nil
 }
+
+// EventStore is the eventstore created by New.
+type EventStore interface {
+	eventstore.EventStore
+	maintenance.EventStore
+	Close(ctx context.Context) error
+}
+
+// New creates the eventstore of the configured backend.
+func New(ctx context.Context, config Config, logger log.Logger, marshaler func(v interface{}) ([]byte, error), unmarshaler func(b []byte, v interface{}) error) (EventStore, error) {
+	switch config.Backend {
+	case "", MongoDBBackend:
+		store, err := mongodb.New(ctx, config.MongoDB, logger, mongodb.WithMarshaler(marshaler), mongodb.WithUnmarshaler(unmarshaler))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create mongodb eventstore: %w", err)
+		}
+		return store, nil
+	case CqlDBBackend:
+		store, err := cqldb.New(ctx, config.CqlDB, logger, cqldb.WithMarshaler(marshaler), cqldb.WithUnmarshaler(unmarshaler))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create cqldb eventstore: %w", err)
+		}
+		return store, nil
+	}
+	return nil, fmt.Errorf("invalid backend('%v')", config.Backend)
+}
@@ -0,0 +1,99 @@
+package cqldb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+)
+
+type KeyspaceConfig struct {
+	Name        string            `yaml:"name" json:"name"`
+	Create      bool              `yaml:"create" json:"create" description:"create the keyspace when it doesn't exist"`
+	Replication map[string]string `yaml:"replication" json:"replication" description:"replication of the created keyspace, eg. class: SimpleStrategy, replication_factor: 1"`
+}
+
+func (c *KeyspaceConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name('%v')", c.Name)
+	}
+	if c.Create && c.Replication["class"] == "" {
+		return fmt.Errorf("replication('%v')", c.Replication)
+	}
+	return nil
+}
+
+// Config provides CQL DB (Cassandra, Scylla) configuration options
+type Config struct {
+	Hosts          []string       `yaml:"hosts" json:"hosts"`
+	Port           int            `yaml:"port" json:"port"`
+	NumConnections int            `yaml:"numConnections" json:"numConnections" description:"number of connections per host"`
+	ConnectTimeout time.Duration  `yaml:"connectTimeout" json:"connectTimeout"`
+	Keyspace       KeyspaceConfig `yaml:"keyspace" json:"keyspace"`
+	TLS            client.Config  `yaml:"tls" json:"tls"`
+
+	marshalerFunc   MarshalerFunc   `yaml:"-"`
+	unmarshalerFunc UnmarshalerFunc `yaml:"-"`
+}
+
+func (c *Config) Validate() error {
+	if len(c.Hosts) == 0 {
+		return fmt.Errorf("hosts('%v')", c.Hosts)
+	}
+	for i, host := range c.Hosts {
+		if host == "" {
+			return fmt.Errorf("hosts[%v]('%v')", i, host)
+		}
+	}
+	if c.Port <= 0 {
+		return fmt.Errorf("port('%v')", c.Port)
+	}
+	if c.NumConnections <= 0 {
+		return fmt.Errorf("numConnections('%v')", c.NumConnections)
+	}
+	if c.ConnectTimeout <= 0 {
+		return fmt.Errorf("connectTimeout('%v')", c.ConnectTimeout)
+	}
+	if err := c.Keyspace.Validate(); err != nil {
+		return fmt.Errorf("keyspace.%w", err)
+	}
+	if err := c.TLS.Validate(); err != nil {
+		return fmt.Errorf("tls.%w", err)
+	}
+	return nil
+}
+
+// Option provides the means to use function call chaining
+type Option interface {
+	apply(cfg *Config)
+}
+
+type MarshalerOpt struct {
+	f MarshalerFunc
+}
+
+func (o MarshalerOpt) apply(cfg *Config) {
+	cfg.marshalerFunc = o.f
+}
+
+// WithMarshaler provides the possibility to set an marshaling function for the config
+func WithMarshaler(f MarshalerFunc) MarshalerOpt {
+	return MarshalerOpt{
+		f: f,
+	}
+}
+
+type UnmarshalerOpt struct {
+	f UnmarshalerFunc
+}
+
+func (o UnmarshalerOpt) apply(cfg *Config) {
+	cfg.unmarshalerFunc = o.f
+}
+
+// WithUnmarshaler provides the possibility to set an unmarshaling function for the config
+func WithUnmarshaler(f UnmarshalerFunc) UnmarshalerOpt {
+	return UnmarshalerOpt{
+		f: f,
+	}
+}
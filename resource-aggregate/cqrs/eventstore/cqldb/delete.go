@@ -0,0 +1,57 @@
+package cqldb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/plgd-dev/kit/v2/strings"
+)
+
+func getGroupIDs(queries []eventstore.DeleteQuery) strings.Set {
+	groupIDs := make(strings.Set)
+	for _, q := range queries {
+		if q.GroupID != "" {
+			groupIDs.Add(q.GroupID)
+		}
+	}
+	return groupIDs
+}
+
+func (s *EventStore) deleteGroup(ctx context.Context, groupID string) error {
+	aggregateIDs, err := s.getGroupAggregateIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	for _, aggregateID := range aggregateIDs {
+		err := s.session.Query("DELETE FROM "+s.table(eventsTable)+" WHERE "+aggregateIDKey+" = ?", aggregateID).WithContext(ctx).Exec()
+		if err != nil {
+			return fmt.Errorf("cannot delete events of aggregate %v: %w", aggregateID, err)
+		}
+	}
+	// the group is removed at the end, so the deletion of the group can be repeated on failure
+	err = s.session.Query("DELETE FROM "+s.table(aggregatesTable)+" WHERE "+groupIDKey+" = ?", groupID).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("cannot delete aggregates of group %v: %w", groupID, err)
+	}
+	return nil
+}
+
+// Delete events of aggregates with given group ids
+func (s *EventStore) Delete(ctx context.Context, queries []eventstore.DeleteQuery) error {
+	groupIDs := getGroupIDs(queries)
+	if len(groupIDs) == 0 {
+		return fmt.Errorf("failed to delete documents: invalid query")
+	}
+
+	var errors []error
+	for groupID := range groupIDs {
+		if err := s.deleteGroup(ctx, groupID); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%v", errors)
+	}
+	return nil
+}
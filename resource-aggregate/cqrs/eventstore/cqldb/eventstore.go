@@ -0,0 +1,221 @@
+package cqldb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+)
+
+// Tables
+const eventsTable = "events"
+const aggregatesTable = "aggregates"
+const maintenanceTable = "maintenance"
+
+// Event
+const versionKey = "version"
+const dataKey = "data"
+const eventTypeKey = "eventtype"
+const isSnapshotKey = "issnapshot"
+const timestampKey = "timestamp"
+
+// Aggregate, stored as static columns of the events partition
+const aggregateIDKey = "aggregateid"
+const groupIDKey = "groupid"
+const latestVersionKey = "latestversion"
+const latestSnapshotVersionKey = "latestsnapshotversion"
+const dataSizeKey = "datasize"
+
+// maxEventsDataSize limits the size of the events stored since the latest snapshot, when the limit
+// is reached the snapshot is required. It keeps partitions of the aggregates small and it matches
+// the limit of the mongodb eventstore.
+const maxEventsDataSize = 16 * 1024 * 1024
+
+type LogDebugfFunc = func(fmt string, args ...interface{})
+
+//MarshalerFunc marshal struct to bytes.
+type MarshalerFunc = func(v interface{}) ([]byte, error)
+
+//UnmarshalerFunc unmarshal bytes to pointer of struct.
+type UnmarshalerFunc = func(b []byte, v interface{}) error
+
+// EventStore implements an EventStore for CQL databases (Cassandra, Scylla).
+//
+// Events of the aggregate are stored in one partition ordered by the version. The latest version
+// of the aggregate is stored in the static column of the partition, so the optimistic concurrency
+// control is done by the lightweight transaction.
+type EventStore struct {
+	session         *gocql.Session
+	LogDebugfFunc   LogDebugfFunc
+	keyspace        string
+	dataMarshaler   MarshalerFunc
+	dataUnmarshaler UnmarshalerFunc
+	closeFunc       []func()
+}
+
+func (s *EventStore) AddCloseFunc(f func()) {
+	s.closeFunc = append(s.closeFunc, f)
+}
+
+func New(ctx context.Context, config Config, logger log.Logger, opts ...Option) (*EventStore, error) {
+	config.marshalerFunc = json.Marshal
+	config.unmarshalerFunc = json.Unmarshal
+	for _, o := range opts {
+		o.apply(&config)
+	}
+	certManager, err := client.New(config.TLS, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cert manager: %w", err)
+	}
+
+	cluster := gocql.NewCluster(config.Hosts...)
+	cluster.Port = config.Port
+	cluster.NumConns = config.NumConnections
+	cluster.ConnectTimeout = config.ConnectTimeout
+	cluster.SslOpts = &gocql.SslOptions{
+		Config:                 certManager.GetTLSConfig(),
+		EnableHostVerification: true,
+	}
+	session, err := cluster.CreateSession()
+	if err != nil {
+		certManager.Close()
+		return nil, fmt.Errorf("could not dial database: %w", err)
+	}
+
+	store, err := newEventStoreWithSession(ctx, session, config.Keyspace, config.marshalerFunc, config.unmarshalerFunc, nil)
+	if err != nil {
+		session.Close()
+		certManager.Close()
+		return nil, err
+	}
+	store.AddCloseFunc(certManager.Close)
+	return store, nil
+}
+
+// newEventStoreWithSession creates a new EventStore with a session.
+func newEventStoreWithSession(ctx context.Context, session *gocql.Session, keyspace KeyspaceConfig, eventMarshaler MarshalerFunc, eventUnmarshaler UnmarshalerFunc, LogDebugfFunc LogDebugfFunc) (*EventStore, error) {
+	if session == nil {
+		return nil, errors.New("invalid session")
+	}
+
+	if eventMarshaler == nil {
+		return nil, errors.New("no event marshaler")
+	}
+	if eventUnmarshaler == nil {
+		return nil, errors.New("no event unmarshaler")
+	}
+
+	if keyspace.Name == "" {
+		keyspace.Name = "default"
+	}
+
+	if LogDebugfFunc == nil {
+		LogDebugfFunc = func(fmt string, args ...interface{}) {}
+	}
+
+	s := &EventStore{
+		session:         session,
+		keyspace:        keyspace.Name,
+		dataMarshaler:   eventMarshaler,
+		dataUnmarshaler: eventUnmarshaler,
+		LogDebugfFunc:   LogDebugfFunc,
+	}
+
+	if keyspace.Create {
+		err := s.session.Query(fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %v WITH replication = %v", s.keyspace, replicationToCQL(keyspace.Replication))).WithContext(ctx).Exec()
+		if err != nil {
+			return nil, fmt.Errorf("cannot create keyspace %v: %w", s.keyspace, err)
+		}
+	}
+	if err := s.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func replicationToCQL(replication map[string]string) string {
+	keys := make([]string, 0, len(replication))
+	for key := range replication {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, fmt.Sprintf("'%v': '%v'", key, replication[key]))
+	}
+	return "{" + strings.Join(values, ", ") + "}"
+}
+
+func (s *EventStore) ensureTables(ctx context.Context) error {
+	tables := []string{
+		// the aggregate is one partition, static columns hold the state of the aggregate used by occ
+		"CREATE TABLE IF NOT EXISTS " + s.table(eventsTable) + " (" +
+			aggregateIDKey + " text, " +
+			versionKey + " bigint, " +
+			groupIDKey + " text static, " +
+			latestVersionKey + " bigint static, " +
+			latestSnapshotVersionKey + " bigint static, " +
+			dataSizeKey + " bigint static, " +
+			eventTypeKey + " text, " +
+			dataKey + " blob, " +
+			isSnapshotKey + " boolean, " +
+			timestampKey + " bigint, " +
+			"PRIMARY KEY (" + aggregateIDKey + ", " + versionKey + ")" +
+			") WITH CLUSTERING ORDER BY (" + versionKey + " ASC)",
+		// aggregates of the group
+		"CREATE TABLE IF NOT EXISTS " + s.table(aggregatesTable) + " (" +
+			groupIDKey + " text, " +
+			aggregateIDKey + " text, " +
+			"PRIMARY KEY (" + groupIDKey + ", " + aggregateIDKey + ")" +
+			")",
+		"CREATE TABLE IF NOT EXISTS " + s.table(maintenanceTable) + " (" +
+			aggregateIDKey + " text PRIMARY KEY, " +
+			versionKey + " bigint" +
+			")",
+	}
+	for _, table := range tables {
+		if err := s.session.Query(table).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("cannot ensure tables for eventstore: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *EventStore) table(name string) string {
+	return s.keyspace + "." + name
+}
+
+// Keyspace returns the keyspace of the tables
+func (s *EventStore) Keyspace() string {
+	return s.keyspace
+}
+
+// Clear removes all rows from the tables, but don't drop the keyspace or the tables
+func (s *EventStore) Clear(ctx context.Context) error {
+	var errors []error
+	for _, table := range []string{eventsTable, aggregatesTable, maintenanceTable} {
+		if err := s.session.Query("TRUNCATE " + s.table(table)).WithContext(ctx).Exec(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to clear table %v: %w", table, err))
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%v", errors)
+	}
+	return nil
+}
+
+// Close closes the database session.
+func (s *EventStore) Close(ctx context.Context) error {
+	s.session.Close()
+	for _, f := range s.closeFunc {
+		f()
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/cqldb"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/test"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func NewTestEventStore(ctx context.Context, logger log.Logger) (*cqldb.EventStore, error) {
+	return cqldb.New(
+		ctx,
+		config.MakeEventsStoreCqlDBConfig(),
+		logger,
+		cqldb.WithMarshaler(bson.Marshal),
+		cqldb.WithUnmarshaler(bson.Unmarshal),
+	)
+}
+
+func TestEventStore(t *testing.T) {
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store, err := NewTestEventStore(ctx, logger)
+	assert.NoError(t, err)
+	assert.NotNil(t, store)
+	defer func() {
+		t.Log("clearing db")
+		err := store.Clear(ctx)
+		require.NoError(t, err)
+		_ = store.Close(ctx)
+	}()
+
+	test.ConformanceTest(t, ctx, store, store.Clear)
+}
+
+func TestMaintenance(t *testing.T) {
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store, err := NewTestEventStore(ctx, logger)
+	assert.NoError(t, err)
+	assert.NotNil(t, store)
+	defer func() {
+		t.Log("clearing db")
+		err := store.Clear(ctx)
+		require.NoError(t, err)
+		_ = store.Close(ctx)
+	}()
+
+	err = store.Clear(ctx)
+	require.NoError(t, err)
+	test.MaintenanceTest(t, ctx, store)
+}
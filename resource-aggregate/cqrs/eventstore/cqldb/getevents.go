@@ -0,0 +1,324 @@
+package cqldb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/plgd-dev/kit/v2/strings"
+)
+
+func matchGetEventsQuery(q eventstore.GetEventsQuery, e loadedEvent) bool {
+	if q.GroupID != "" && q.GroupID != e.groupID {
+		return false
+	}
+	if q.AggregateID != "" && q.AggregateID != e.aggregateID {
+		return false
+	}
+	if len(q.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range q.EventTypes {
+		if eventType == e.eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func matchGetEventsPage(page eventstore.GetEventsPage, e loadedEvent) bool {
+	if page.TimestampFrom > 0 && e.timestamp <= page.TimestampFrom {
+		return false
+	}
+	if page.TimestampTo > 0 && e.timestamp >= page.TimestampTo {
+		return false
+	}
+	if page.After == nil {
+		return true
+	}
+	return !lessOrEqualThanCursor(e, *page.After)
+}
+
+func lessOrEqualThanCursor(e loadedEvent, cursor eventstore.GetEventsCursor) bool {
+	if e.timestamp != cursor.Timestamp {
+		return e.timestamp < cursor.Timestamp
+	}
+	if e.aggregateID != cursor.AggregateID {
+		return e.aggregateID < cursor.AggregateID
+	}
+	return uint64(e.version) <= cursor.Version
+}
+
+func sortEvents(events []loadedEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].timestamp != events[j].timestamp {
+			return events[i].timestamp < events[j].timestamp
+		}
+		if events[i].aggregateID != events[j].aggregateID {
+			return events[i].aggregateID < events[j].aggregateID
+		}
+		return events[i].version < events[j].version
+	})
+}
+
+// getEventsAggregateIDs returns the aggregates requested by the queries. The aggregates of all events are
+// read only from the partition keys, so the events are never scanned over the whole table.
+func (s *EventStore) getEventsAggregateIDs(ctx context.Context, queries []eventstore.GetEventsQuery) ([]string, error) {
+	aggregateIDs := make(strings.Set)
+	groupIDs := make(strings.Set)
+	for _, q := range queries {
+		switch {
+		case q.GroupID == "" && q.AggregateID == "":
+			return s.getAllAggregateIDs(ctx)
+		case q.AggregateID != "":
+			aggregateIDs.Add(q.AggregateID)
+		default:
+			groupIDs.Add(q.GroupID)
+		}
+	}
+	for groupID := range groupIDs {
+		groupAggregateIDs, err := s.getGroupAggregateIDs(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+		aggregateIDs.Add(groupAggregateIDs...)
+	}
+	return aggregateIDs.ToSlice(), nil
+}
+
+func (s *EventStore) getAllAggregateIDs(ctx context.Context) ([]string, error) {
+	iter := s.session.Query("SELECT DISTINCT " + aggregateIDKey + " FROM " + s.table(eventsTable)).WithContext(ctx).Iter()
+	var aggregateIDs []string
+	var aggregateID string
+	for iter.Scan(&aggregateID) {
+		aggregateIDs = append(aggregateIDs, aggregateID)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("cannot get aggregates: %w", err)
+	}
+	return aggregateIDs, nil
+}
+
+// timestampBounds are the exclusive bounds of the event timestamps, a bound is ignored when <= 0.
+type timestampBounds struct {
+	from int64
+	to   int64
+}
+
+func newTimestampBounds(page eventstore.GetEventsPage) timestampBounds {
+	b := timestampBounds{
+		from: page.TimestampFrom,
+		to:   page.TimestampTo,
+	}
+	if page.After != nil && page.After.Timestamp-1 > b.from {
+		// events with the same timestamp as the cursor can follow the cursor
+		b.from = page.After.Timestamp - 1
+	}
+	return b
+}
+
+// getEventsQuery selects the events of the aggregate within the timestamp bounds. Filtering is used only within one partition.
+func (s *EventStore) getEventsQuery(aggregateID string, bounds timestampBounds) *gocql.Query {
+	q := "SELECT " + eventColumns + " FROM " + s.table(eventsTable) + " WHERE " + aggregateIDKey + " = ?"
+	values := []interface{}{aggregateID}
+	if bounds.from > 0 {
+		q += " AND " + timestampKey + " > ?"
+		values = append(values, bounds.from)
+	}
+	if bounds.to > 0 {
+		q += " AND " + timestampKey + " < ?"
+		values = append(values, bounds.to)
+	}
+	if len(values) > 1 {
+		q += " ALLOW FILTERING"
+	}
+	return s.session.Query(q, values...)
+}
+
+// boundedEvents holds the first events up to the limit of the page.
+type boundedEvents struct {
+	events []loadedEvent
+	limit  int
+}
+
+func (b *boundedEvents) add(e loadedEvent) {
+	b.events = append(b.events, e)
+	if b.limit > 0 && len(b.events) >= 2*b.limit {
+		b.truncate()
+	}
+}
+
+func (b *boundedEvents) truncate() {
+	sortEvents(b.events)
+	if b.limit > 0 && len(b.events) > b.limit {
+		b.events = b.events[:b.limit]
+	}
+}
+
+// narrow restricts the bounds of next queries when the limit is reached, because only events with a lower or
+// the same timestamp as the last held event can be returned.
+func (b *boundedEvents) narrow(bounds timestampBounds) timestampBounds {
+	if b.limit <= 0 || len(b.events) < b.limit {
+		return bounds
+	}
+	b.truncate()
+	to := b.events[len(b.events)-1].timestamp + 1
+	if bounds.to <= 0 || to < bounds.to {
+		bounds.to = to
+	}
+	return bounds
+}
+
+func (s *EventStore) getEvents(ctx context.Context, aggregateIDs []string, queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage) ([]loadedEvent, error) {
+	events := boundedEvents{
+		limit: int(page.Limit),
+	}
+	bounds := newTimestampBounds(page)
+	for _, aggregateID := range aggregateIDs {
+		iter := s.getEventsQuery(aggregateID, bounds).WithContext(ctx).Iter()
+		for {
+			e, ok := scanEvent(iter)
+			if !ok {
+				break
+			}
+			if !matchGetEventsPage(page, e) {
+				continue
+			}
+			for _, query := range queries {
+				if matchGetEventsQuery(query, e) {
+					events.add(e)
+					break
+				}
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+		bounds = events.narrow(bounds)
+	}
+	events.truncate()
+	return events.events, nil
+}
+
+// getEventsMaxPageSize bounds the number of events held by one page, so GetEvents without a limit doesn't load
+// all matching events at once.
+const getEventsMaxPageSize = 1000
+
+// pagedIterator iterates over events of GetEvents by pages of at most getEventsMaxPageSize events, the next page
+// continues after the last event of the previous page. No page is loaded when the limit of the request is reached.
+type pagedIterator struct {
+	s         *EventStore
+	queries   []eventstore.GetEventsQuery
+	page      eventstore.GetEventsPage
+	remaining int64 // number of events to return, <=0 for all events
+
+	aggregateIDs []string // aggregates requested by the queries, they are resolved before the first page
+	events       []loadedEvent
+	idx          int
+	pageSize     int64                       // limit of the current page, 0 before the first page
+	lastEvent    *eventstore.GetEventsCursor // cursor of the last returned event
+	err          error
+	noMorePages  bool
+}
+
+func newPagedIterator(s *EventStore, queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage) *pagedIterator {
+	return &pagedIterator{
+		s:         s,
+		queries:   queries,
+		page:      page,
+		remaining: page.Limit,
+	}
+}
+
+func (i *pagedIterator) nextPage(ctx context.Context) bool {
+	if i.noMorePages {
+		return false
+	}
+	if i.pageSize == 0 {
+		aggregateIDs, err := i.s.getEventsAggregateIDs(ctx, i.queries)
+		if err != nil {
+			i.err = err
+			i.noMorePages = true
+			return false
+		}
+		i.aggregateIDs = aggregateIDs
+	} else if int64(len(i.events)) < i.pageSize {
+		i.noMorePages = true
+		return false
+	}
+	i.pageSize = getEventsMaxPageSize
+	if i.remaining > 0 && i.remaining < i.pageSize {
+		i.pageSize = i.remaining
+	}
+	page := i.page
+	page.Limit = i.pageSize
+	if i.lastEvent != nil {
+		page.After = i.lastEvent
+	}
+	events, err := i.s.getEvents(ctx, i.aggregateIDs, i.queries, page)
+	if err != nil {
+		i.err = err
+		i.noMorePages = true
+		return false
+	}
+	i.events = events
+	i.idx = 0
+	return len(events) > 0
+}
+
+func (i *pagedIterator) Next(ctx context.Context) (eventstore.EventUnmarshaler, bool) {
+	for {
+		if i.idx >= len(i.events) {
+			if !i.nextPage(ctx) {
+				return nil, false
+			}
+		}
+		e := i.events[i.idx]
+		i.idx++
+		i.lastEvent = &eventstore.GetEventsCursor{
+			Timestamp:   e.timestamp,
+			AggregateID: e.aggregateID,
+			Version:     uint64(e.version),
+		}
+		if i.remaining > 0 {
+			i.remaining--
+			if i.remaining == 0 {
+				i.noMorePages = true
+			}
+		}
+		i.s.LogDebugfFunc("cqldb.pagedIterator.next: GroupId %v: AggregateId %v: Version %v, EvenType %v, Timestamp %v",
+			e.groupID, e.aggregateID, e.version, e.eventType, e.timestamp)
+		return e.toEventUnmarshaler(i.s.dataUnmarshaler), true
+	}
+}
+
+func (i *pagedIterator) Err() error {
+	return i.err
+}
+
+// Get events from the eventstore.
+// CQL databases cannot order rows across partitions, so the events are selected per aggregate with the timestamp
+// bounds of the page, and ordered by the eventstore. The events are loaded by pages of at most getEventsMaxPageSize
+// events and the eventstore holds at most twice the size of the page.
+func (s *EventStore) GetEvents(ctx context.Context, queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage, eventHandler eventstore.Handler) error {
+	s.LogDebugfFunc("cqldb.Evenstore.GetEvents start")
+	t := time.Now()
+	defer func() {
+		s.LogDebugfFunc("cqldb.Evenstore.GetEvents takes %v", time.Since(t))
+	}()
+	if len(queries) == 0 {
+		return fmt.Errorf("not supported")
+	}
+
+	i := newPagedIterator(s, queries, page)
+	if err := eventHandler.Handle(ctx, i); err != nil {
+		return err
+	}
+	if err := i.Err(); err != nil {
+		return fmt.Errorf("cannot get events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package cqldb_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/test"
+	"github.com/stretchr/testify/require"
+)
+
+type orderedEventHandler struct {
+	events []eventstore.GetEventsCursor
+}
+
+func (eh *orderedEventHandler) Handle(ctx context.Context, iter eventstore.Iter) error {
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			return iter.Err()
+		}
+		eh.events = append(eh.events, eventstore.GetEventsCursor{
+			Timestamp:   eu.Timestamp().UnixNano(),
+			AggregateID: eu.AggregateID(),
+			Version:     eu.Version(),
+		})
+	}
+}
+
+func isOrderedEventsCursor(a, b eventstore.GetEventsCursor) bool {
+	if a.Timestamp != b.Timestamp {
+		return a.Timestamp < b.Timestamp
+	}
+	if a.AggregateID != b.AggregateID {
+		return a.AggregateID < b.AggregateID
+	}
+	return a.Version < b.Version
+}
+
+func TestGetEventsByPages(t *testing.T) {
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store, err := NewTestEventStore(ctx, logger)
+	require.NoError(t, err)
+	require.NotNil(t, store)
+	defer func() {
+		err = store.Clear(ctx)
+		require.NoError(t, err)
+		_ = store.Close(ctx)
+	}()
+
+	// events of the aggregates share the timestamps, so the pages are split also within one timestamp
+	const aggregateCount = 50
+	const eventsPerAggregate = 50
+	for a := 0; a < aggregateCount; a++ {
+		events := make([]eventstore.Event, 0, eventsPerAggregate)
+		for v := 0; v < eventsPerAggregate; v++ {
+			events = append(events, test.MockEvent{
+				VersionI:     uint64(v),
+				EventTypeI:   "testType",
+				AggregateIDI: "aggregate" + strconv.Itoa(a),
+				GroupIDI:     "group" + strconv.Itoa(a%5),
+				TimestampI:   int64(1 + v),
+			})
+		}
+		saveStatus, err := store.Save(ctx, events...)
+		require.NoError(t, err)
+		require.Equal(t, eventstore.Ok, saveStatus)
+	}
+	const eventCount = aggregateCount * eventsPerAggregate
+
+	// unbounded request is loaded by many pages
+	var eh orderedEventHandler
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{}, &eh)
+	require.NoError(t, err)
+	require.Len(t, eh.events, eventCount)
+	for i := 1; i < len(eh.events); i++ {
+		require.True(t, isOrderedEventsCursor(eh.events[i-1], eh.events[i]), "events %v and %v are not ordered", eh.events[i-1], eh.events[i])
+	}
+
+	// limits smaller and larger than the page
+	for _, limit := range []int{10, 1500} {
+		var ehLimit orderedEventHandler
+		err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{Limit: int64(limit)}, &ehLimit)
+		require.NoError(t, err)
+		require.Equal(t, eh.events[:limit], ehLimit.events)
+	}
+
+	// the page continues after the cursor
+	var ehAfter orderedEventHandler
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{After: &eh.events[1200]}, &ehAfter)
+	require.NoError(t, err)
+	require.Equal(t, eh.events[1201:], ehAfter.events)
+}
@@ -0,0 +1,252 @@
+package cqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+)
+
+const eventColumns = aggregateIDKey + ", " + groupIDKey + ", " + versionKey + ", " + eventTypeKey + ", " + dataKey + ", " + isSnapshotKey + ", " + timestampKey
+
+type loadedEvent struct {
+	aggregateID string
+	groupID     string
+	version     int64
+	eventType   string
+	data        []byte
+	isSnapshot  bool
+	timestamp   int64
+}
+
+// scanEvent scans the row selected by eventColumns, the row without the event type
+// is the partition without events.
+func scanEvent(iter *gocql.Iter) (loadedEvent, bool) {
+	for {
+		var e loadedEvent
+		if !iter.Scan(&e.aggregateID, &e.groupID, &e.version, &e.eventType, &e.data, &e.isSnapshot, &e.timestamp) {
+			return loadedEvent{}, false
+		}
+		if e.eventType != "" {
+			return e, true
+		}
+	}
+}
+
+func (e loadedEvent) toEventUnmarshaler(dataUnmarshaler UnmarshalerFunc) eventstore.EventUnmarshaler {
+	return eventstore.NewLoadedEvent(
+		uint64(e.version),
+		e.eventType,
+		e.aggregateID,
+		e.groupID,
+		e.isSnapshot,
+		pkgTime.Unix(0, e.timestamp),
+		func(v interface{}) error {
+			return dataUnmarshaler(e.data, v)
+		})
+}
+
+// iterator executes the queries one by one and iterates over the events returned by them.
+type iterator struct {
+	queries         []*gocql.Query
+	iter            *gocql.Iter
+	dataUnmarshaler UnmarshalerFunc
+	logDebugfFunc   LogDebugfFunc
+	err             error
+}
+
+func newIterator(queries []*gocql.Query, dataUnmarshaler UnmarshalerFunc, logDebugfFunc LogDebugfFunc) *iterator {
+	return &iterator{
+		queries:         queries,
+		dataUnmarshaler: dataUnmarshaler,
+		logDebugfFunc:   logDebugfFunc,
+	}
+}
+
+func (i *iterator) Next(ctx context.Context) (eventstore.EventUnmarshaler, bool) {
+	for {
+		if i.iter == nil {
+			if len(i.queries) == 0 {
+				return nil, false
+			}
+			i.iter = i.queries[0].WithContext(ctx).Iter()
+			i.queries = i.queries[1:]
+		}
+		e, ok := scanEvent(i.iter)
+		if ok {
+			i.logDebugfFunc("cqldb.iterator.next: GroupId %v: AggregateId %v: Version %v, EvenType %v, Timestamp %v",
+				e.groupID, e.aggregateID, e.version, e.eventType, e.timestamp)
+			return e.toEventUnmarshaler(i.dataUnmarshaler), true
+		}
+		err := i.iter.Close()
+		i.iter = nil
+		if err != nil {
+			i.err = err
+			return nil, false
+		}
+	}
+}
+
+func (i *iterator) Err() error {
+	return i.err
+}
+
+func (i *iterator) close() {
+	if i.iter != nil {
+		_ = i.iter.Close()
+		i.iter = nil
+	}
+}
+
+func (s *EventStore) loadEventsQuery(ctx context.Context, eh eventstore.Handler, queries []*gocql.Query) error {
+	if len(queries) == 0 {
+		return nil
+	}
+	i := newIterator(queries, s.dataUnmarshaler, s.LogDebugfFunc)
+	defer i.close()
+	return eh.Handle(ctx, i)
+}
+
+func validateVersionQuery(query eventstore.VersionQuery) error {
+	if query.GroupID == "" {
+		return fmt.Errorf("invalid GroupID('%v')", query.GroupID)
+	}
+	if query.AggregateID == "" {
+		return fmt.Errorf("invalid AggregateID('%v')", query.AggregateID)
+	}
+	return nil
+}
+
+func (s *EventStore) loadEvents(ctx context.Context, versionQueries []eventstore.VersionQuery, eh eventstore.Handler, op string) error {
+	var errors []error
+	queries := make([]*gocql.Query, 0, len(versionQueries))
+	for _, q := range versionQueries {
+		if err := validateVersionQuery(q); err != nil {
+			errors = append(errors, fmt.Errorf("cannot load events version for query('%+v'): %w", q, err))
+			continue
+		}
+		queries = append(queries, s.session.Query("SELECT "+eventColumns+" FROM "+s.table(eventsTable)+" WHERE "+aggregateIDKey+" = ? AND "+versionKey+" "+op+" ?", q.AggregateID, int64(q.Version)))
+	}
+	if err := s.loadEventsQuery(ctx, eh, queries); err != nil {
+		errors = append(errors, err)
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%+v", errors)
+	}
+	return nil
+}
+
+// LoadUpToVersion loads aggregates events up to a specific version.
+func (s *EventStore) LoadUpToVersion(ctx context.Context, queries []eventstore.VersionQuery, eh eventstore.Handler) error {
+	return s.loadEvents(ctx, queries, eh, "<")
+}
+
+// LoadFromVersion loads aggregates events from version.
+func (s *EventStore) LoadFromVersion(ctx context.Context, queries []eventstore.VersionQuery, eh eventstore.Handler) error {
+	return s.loadEvents(ctx, queries, eh, ">=")
+}
+
+// getGroupAggregateIDs returns IDs of all aggregates of the group.
+func (s *EventStore) getGroupAggregateIDs(ctx context.Context, groupID string) ([]string, error) {
+	iter := s.session.Query("SELECT "+aggregateIDKey+" FROM "+s.table(aggregatesTable)+" WHERE "+groupIDKey+" = ?", groupID).WithContext(ctx).Iter()
+	var aggregateIDs []string
+	var aggregateID string
+	for iter.Scan(&aggregateID) {
+		aggregateIDs = append(aggregateIDs, aggregateID)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("cannot get aggregates of group %v: %w", groupID, err)
+	}
+	return aggregateIDs, nil
+}
+
+// snapshotQuery creates the query loading the events of the aggregate from the latest snapshot,
+// nil is returned when the aggregate doesn't exist in the group.
+func (s *EventStore) snapshotQuery(ctx context.Context, groupID, aggregateID string) (*gocql.Query, error) {
+	var aggregateGroupID string
+	var latestSnapshotVersion int64
+	err := s.session.Query("SELECT "+groupIDKey+", "+latestSnapshotVersionKey+" FROM "+s.table(eventsTable)+" WHERE "+aggregateIDKey+" = ? LIMIT 1", aggregateID).WithContext(ctx).Scan(&aggregateGroupID, &latestSnapshotVersion)
+	if errors.Is(err, gocql.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get latest snapshot version of aggregate %v: %w", aggregateID, err)
+	}
+	if aggregateGroupID != groupID {
+		return nil, nil
+	}
+	return s.session.Query("SELECT "+eventColumns+" FROM "+s.table(eventsTable)+" WHERE "+aggregateIDKey+" = ? AND "+versionKey+" >= ?", aggregateID, latestSnapshotVersion), nil
+}
+
+func (s *EventStore) loadFromSnapshot(ctx context.Context, groupID string, queries []eventstore.SnapshotQuery, eventHandler eventstore.Handler) error {
+	var aggregateIDs []string
+	if len(queries) == 0 {
+		var err error
+		aggregateIDs, err = s.getGroupAggregateIDs(ctx, groupID)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, q := range queries {
+			aggregateIDs = append(aggregateIDs, q.AggregateID)
+		}
+	}
+	eventQueries := make([]*gocql.Query, 0, len(aggregateIDs))
+	for _, aggregateID := range aggregateIDs {
+		q, err := s.snapshotQuery(ctx, groupID, aggregateID)
+		if err != nil {
+			return err
+		}
+		if q != nil {
+			eventQueries = append(eventQueries, q)
+		}
+	}
+	return s.loadEventsQuery(ctx, eventHandler, eventQueries)
+}
+
+// LoadFromSnapshot loads events from the last snapshot eventstore.
+func (s *EventStore) LoadFromSnapshot(ctx context.Context, queries []eventstore.SnapshotQuery, eventHandler eventstore.Handler) error {
+	s.LogDebugfFunc("cqldb.Evenstore.LoadFromSnapshot start")
+	t := time.Now()
+	defer func() {
+		s.LogDebugfFunc("cqldb.Evenstore.LoadFromSnapshot takes %v", time.Since(t))
+	}()
+	if len(queries) == 0 {
+		return fmt.Errorf("not supported")
+	}
+
+	normalizeQuery := make(map[string][]eventstore.SnapshotQuery)
+	for _, query := range queries {
+		if query.GroupID == "" {
+			continue
+		}
+		if query.AggregateID == "" {
+			normalizeQuery[query.GroupID] = make([]eventstore.SnapshotQuery, 0, 1)
+			continue
+		}
+		v, ok := normalizeQuery[query.GroupID]
+		if !ok {
+			v = make([]eventstore.SnapshotQuery, 0, 4)
+		} else if len(v) == 0 {
+			continue
+		}
+		v = append(v, query)
+		normalizeQuery[query.GroupID] = v
+	}
+
+	var errors []error
+	for groupID, queries := range normalizeQuery {
+		err := s.loadFromSnapshot(ctx, groupID, queries, eventHandler)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%+v", errors)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/maintenance"
+)
+
+// Insert stores (or updates) the information about the latest snapshot version per aggregate into the DB
+func (s *EventStore) Insert(ctx context.Context, task maintenance.Task) error {
+	if task.AggregateID == "" {
+		return errors.New("could not insert record - aggregate ID and/or version cannot be empty")
+	}
+
+	applied, err := s.session.Query("INSERT INTO "+s.table(maintenanceTable)+" ("+aggregateIDKey+", "+versionKey+") VALUES (?, ?) IF NOT EXISTS", task.AggregateID, int64(task.Version)).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		return fmt.Errorf("could not insert record with aggregate ID %v, version %d - %w", task.AggregateID, task.Version, err)
+	}
+	if applied {
+		return nil
+	}
+	applied, err = s.session.Query("UPDATE "+s.table(maintenanceTable)+" SET "+versionKey+" = ? WHERE "+aggregateIDKey+" = ? IF "+versionKey+" < ?", int64(task.Version), task.AggregateID, int64(task.Version)).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		return fmt.Errorf("could not insert record with aggregate ID %v, version %d - %w", task.AggregateID, task.Version, err)
+	}
+	if !applied {
+		return fmt.Errorf("could not insert record with aggregate ID %v, version %d - version is outdated", task.AggregateID, task.Version)
+	}
+	return nil
+}
+
+type aggregateVersionIterator struct {
+	iter *gocql.Iter
+}
+
+func (i *aggregateVersionIterator) Next(ctx context.Context, task *maintenance.Task) bool {
+	var version int64
+	if !i.iter.Scan(&task.AggregateID, &version) {
+		return false
+	}
+	task.Version = uint64(version)
+	return true
+}
+
+func (i *aggregateVersionIterator) Err() error {
+	return nil
+}
+
+// Query retrieves the latest snapshot version per aggregate for the number of aggregates specified by 'limit'
+func (s *EventStore) Query(ctx context.Context, limit int, taskHandler maintenance.TaskHandler) error {
+	iter := s.session.Query("SELECT "+aggregateIDKey+", "+versionKey+" FROM "+s.table(maintenanceTable)+" LIMIT ?", limit).WithContext(ctx).Iter()
+	i := aggregateVersionIterator{
+		iter: iter,
+	}
+	err := taskHandler.Handle(ctx, &i)
+
+	errClose := iter.Close()
+	if err == nil {
+		return errClose
+	}
+	return err
+}
+
+// Remove deletes (the latest snapshot version) database record for a given aggregate ID
+func (s *EventStore) Remove(ctx context.Context, task maintenance.Task) error {
+	applied, err := s.session.Query("DELETE FROM "+s.table(maintenanceTable)+" WHERE "+aggregateIDKey+" = ? IF "+versionKey+" = ?", task.AggregateID, int64(task.Version)).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return fmt.Errorf("could not remove record with aggregate ID %s and/or version %d", task.AggregateID, task.Version)
+	}
+	return nil
+}
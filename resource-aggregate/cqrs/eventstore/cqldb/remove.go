@@ -0,0 +1,29 @@
+package cqldb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+)
+
+// RemoveUpToVersion deletes the aggregated events up to a specific version.
+func (s *EventStore) RemoveUpToVersion(ctx context.Context, versionQueries []eventstore.VersionQuery) error {
+	var errors []error
+	for _, q := range versionQueries {
+		if err := validateVersionQuery(q); err != nil {
+			errors = append(errors, fmt.Errorf("cannot remove events version for query('%+v'): %w", q, err))
+			continue
+		}
+		// static columns of the partition are kept, so the occ of the aggregate still works
+		err := s.session.Query("DELETE FROM "+s.table(eventsTable)+" WHERE "+aggregateIDKey+" = ? AND "+versionKey+" < ?", q.AggregateID, int64(q.Version)).WithContext(ctx).Exec()
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%+v", errors)
+	}
+	return nil
+}
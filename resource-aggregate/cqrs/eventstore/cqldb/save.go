@@ -0,0 +1,153 @@
+package cqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	pkgTime "github.com/plgd-dev/hub/pkg/time"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+)
+
+func getLatestSnapshotVersion(events []eventstore.Event) (uint64, error) {
+	err := fmt.Errorf("not found")
+	var latestSnapshotVersion uint64
+	for _, e := range events {
+		if e.IsSnapshot() {
+			latestSnapshotVersion = e.Version()
+			err = nil
+		}
+	}
+	if err != nil && len(events) > 0 {
+		if events[0].Version() == 0 {
+			latestSnapshotVersion = 0
+			err = nil
+		}
+	}
+	return latestSnapshotVersion, err
+}
+
+// marshalEvents marshals data of the events and returns the total size of the data.
+func marshalEvents(events []eventstore.Event, marshaler MarshalerFunc) ([][]byte, int64, error) {
+	data := make([][]byte, 0, len(events))
+	var size int64
+	for idx, event := range events {
+		raw, err := marshaler(event)
+		if err != nil {
+			return nil, 0, fmt.Errorf("cannot create db event from event[%v]: %w", idx, err)
+		}
+		data = append(data, raw)
+		size += int64(len(raw))
+	}
+	return data, size, nil
+}
+
+// getAggregateState returns the latest version and the size of events since the latest snapshot of the aggregate.
+func (s *EventStore) getAggregateState(ctx context.Context, aggregateID string) (latestVersion int64, dataSize int64, err error) {
+	err = s.session.Query("SELECT "+latestVersionKey+", "+dataSizeKey+" FROM "+s.table(eventsTable)+" WHERE "+aggregateIDKey+" = ? LIMIT 1", aggregateID).WithContext(ctx).Scan(&latestVersion, &dataSize)
+	return latestVersion, dataSize, err
+}
+
+func (s *EventStore) newSaveBatch(ctx context.Context, events []eventstore.Event, data [][]byte) *gocql.Batch {
+	batch := s.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	for idx, event := range events {
+		batch.Query("INSERT INTO "+s.table(eventsTable)+" ("+aggregateIDKey+", "+versionKey+", "+eventTypeKey+", "+dataKey+", "+isSnapshotKey+", "+timestampKey+") VALUES (?, ?, ?, ?, ?, ?)",
+			event.AggregateID(), int64(event.Version()), event.EventType(), data[idx], event.IsSnapshot(), pkgTime.UnixNano(event.Timestamp()))
+	}
+	return batch
+}
+
+func (s *EventStore) saveFirstEvents(ctx context.Context, events []eventstore.Event, data [][]byte, dataSize int64) (eventstore.SaveStatus, error) {
+	// register the aggregate in the group before the events are stored, so the group cannot lose the aggregate
+	err := s.session.Query("INSERT INTO "+s.table(aggregatesTable)+" ("+groupIDKey+", "+aggregateIDKey+") VALUES (?, ?)", events[0].GroupID(), events[0].AggregateID()).WithContext(ctx).Exec()
+	if err != nil {
+		return eventstore.Fail, fmt.Errorf("cannot insert first events('%v'): %w", events, err)
+	}
+	latestSnapshotVersion, err := getLatestSnapshotVersion(events)
+	if err != nil {
+		return eventstore.Fail, fmt.Errorf("cannot get latestSnapshotVersion from events('%v'): %w", events, err)
+	}
+
+	batch := s.newSaveBatch(ctx, events, data)
+	batch.Query("INSERT INTO "+s.table(eventsTable)+" ("+aggregateIDKey+", "+groupIDKey+", "+latestVersionKey+", "+latestSnapshotVersionKey+", "+dataSizeKey+") VALUES (?, ?, ?, ?, ?) IF NOT EXISTS",
+		events[0].AggregateID(), events[0].GroupID(), int64(events[len(events)-1].Version()), int64(latestSnapshotVersion), dataSize)
+	return s.executeSaveBatch(batch, events)
+}
+
+func (s *EventStore) saveEvents(ctx context.Context, events []eventstore.Event, data [][]byte, dataSize int64) (eventstore.SaveStatus, error) {
+	latestVersion, storedDataSize, err := s.getAggregateState(ctx, events[0].AggregateID())
+	if errors.Is(err, gocql.ErrNotFound) {
+		return eventstore.ConcurrencyException, nil
+	}
+	if err != nil {
+		return eventstore.Fail, fmt.Errorf("cannot push events('%v') to db: %w", events, err)
+	}
+	// latestVersion shall be lower by 1 as new event otherwise other event was stored (occ).
+	if latestVersion != int64(events[0].Version())-1 {
+		return eventstore.ConcurrencyException, nil
+	}
+	if !events[0].IsSnapshot() {
+		dataSize += storedDataSize
+		if dataSize > maxEventsDataSize {
+			return eventstore.SnapshotRequired, nil
+		}
+	}
+
+	batch := s.newSaveBatch(ctx, events, data)
+	set := latestVersionKey + " = ?, " + dataSizeKey + " = ?"
+	values := []interface{}{int64(events[len(events)-1].Version()), dataSize}
+	latestSnapshotVersion, err := getLatestSnapshotVersion(events)
+	if err == nil {
+		set += ", " + latestSnapshotVersionKey + " = ?"
+		values = append(values, int64(latestSnapshotVersion))
+	}
+	values = append(values, events[0].AggregateID(), latestVersion)
+	batch.Query("UPDATE "+s.table(eventsTable)+" SET "+set+" WHERE "+aggregateIDKey+" = ? IF "+latestVersionKey+" = ?", values...)
+	return s.executeSaveBatch(batch, events)
+}
+
+func (s *EventStore) executeSaveBatch(batch *gocql.Batch, events []eventstore.Event) (eventstore.SaveStatus, error) {
+	applied, iter, err := s.session.MapExecuteBatchCAS(batch, make(map[string]interface{}))
+	if iter != nil {
+		errClose := iter.Close()
+		if err == nil {
+			err = errClose
+		}
+	}
+	if err != nil {
+		return eventstore.Fail, fmt.Errorf("cannot push events('%v') to db: %w", events, err)
+	}
+	if !applied {
+		return eventstore.ConcurrencyException, nil
+	}
+	return eventstore.Ok, nil
+}
+
+// Save save events to eventstore.
+// AggregateID, GroupID and EventType are required.
+// All events within one Save operation shall have the same AggregateID and GroupID.
+// Versions shall be unique and ascend continually.
+// Only first event can be a snapshot.
+func (s *EventStore) Save(ctx context.Context, events ...eventstore.Event) (eventstore.SaveStatus, error) {
+	s.LogDebugfFunc("cqldb.Evenstore.Save start")
+	t := time.Now()
+	defer func() {
+		s.LogDebugfFunc("cqldb.Evenstore.Save takes %v", time.Since(t))
+	}()
+
+	err := eventstore.ValidateEventsBeforeSave(events...)
+	if err != nil {
+		return eventstore.Fail, err
+	}
+	data, dataSize, err := marshalEvents(events, s.dataMarshaler)
+	if err != nil {
+		return eventstore.Fail, err
+	}
+
+	if events[0].Version() == 0 {
+		return s.saveFirstEvents(ctx, events, data, dataSize)
+	}
+	return s.saveEvents(ctx, events, data, dataSize)
+}
@@ -2,6 +2,7 @@ package eventstore
 
 import (
 	"context"
+	"fmt"
 )
 
 // VersionQuery used to load events from version.
@@ -20,8 +21,35 @@ type SnapshotQuery struct {
 // Get events with given attributes.
 // All filtering options are optional, if none are given then all events are returned,
 type GetEventsQuery struct {
-	GroupID     string //filter by group ID, optional
-	AggregateID string //filter to certain aggregateID, optional
+	GroupID     string   //filter by group ID, optional
+	AggregateID string   //filter to certain aggregateID, optional
+	EventTypes  []string //filter to certain event types, optional
+}
+
+// GetEventsCursor identifies a position of an event in the order of events returned by GetEvents.
+type GetEventsCursor struct {
+	Timestamp   int64  //timestamp of the event in unix nanoseconds
+	AggregateID string //aggregateID of the event
+	Version     uint64 //version of the event
+}
+
+// NewGetEventsCursor creates the cursor pointing to the given event.
+func NewGetEventsCursor(event Event) GetEventsCursor {
+	return GetEventsCursor{
+		Timestamp:   event.Timestamp().UnixNano(),
+		AggregateID: event.AggregateID(),
+		Version:     event.Version(),
+	}
+}
+
+// GetEventsPage bounds events returned by GetEvents.
+// Events are ordered by timestamp, aggregateID and version, so the cursor of the last received event
+// can be used to continue exactly after the event. All options are optional.
+type GetEventsPage struct {
+	TimestampFrom int64            //return events with timestamp > TimestampFrom, ignored when <=0
+	TimestampTo   int64            //return events with timestamp < TimestampTo, ignored when <=0
+	Limit         int64            //maximal number of returned events, ignored when <=0
+	After         *GetEventsCursor //return events following the cursor, ignored when nil
 }
 
 // Delete documents with given group id
@@ -41,9 +69,8 @@ const (
 // EventStore provides interface over eventstore. More aggregates can be grouped by groupID,
 // but aggregateID of aggregates must be unique against whole DB.
 type EventStore interface {
-	// Get events from the eventstore with timestamp larger than given value
-	// If timestamp is <=0 then the argument is ignored.
-	GetEvents(ctx context.Context, queries []GetEventsQuery, timestamp int64, eventHandler Handler) error
+	// Get events from the eventstore ordered by timestamp, aggregateID and version and bounded by the page.
+	GetEvents(ctx context.Context, queries []GetEventsQuery, page GetEventsPage, eventHandler Handler) error
 	// Save save events to eventstore.
 	// AggregateID, GroupID and EventType are required.
 	// All events within one Save operation shall have the same AggregateID and GroupID.
@@ -56,3 +83,55 @@ type EventStore interface {
 	RemoveUpToVersion(ctx context.Context, queries []VersionQuery) error
 	Delete(ctx context.Context, queries []DeleteQuery) error
 }
+
+// ValidateEventsBeforeSave validates events before saving them in the database
+// Prerequisites that must hold:
+//   1) AggregateID, GroupID and EventType are not empty
+//   2) Version for each event is by 1 greater than the version of the previous event
+//   3) Only the first event can be a snapshot
+//   4) All events have the same AggregateId and GroupID
+//   5) Timestamps are non-zero
+//   6) Timestamps are non-decreasing
+func ValidateEventsBeforeSave(events ...Event) error {
+	if len(events) == 0 || events[0] == nil {
+		return fmt.Errorf("invalid events('%v')", events)
+	}
+	aggregateID := events[0].AggregateID()
+	groupID := events[0].GroupID()
+	version := events[0].Version()
+	timestamp := events[0].Timestamp()
+	for idx, event := range events {
+		if event == nil {
+			return fmt.Errorf("invalid events[%v]('%v')", idx, event)
+		}
+		if event.AggregateID() == "" {
+			return fmt.Errorf("invalid events[%v].AggregateID('%v')", idx, event.AggregateID())
+		}
+		if event.GroupID() == "" {
+			return fmt.Errorf("invalid events[%v].GroupID('%v')", idx, event.GroupID())
+		}
+		if event.EventType() == "" {
+			return fmt.Errorf("invalid events[%v].EventType('%v')", idx, event.EventType())
+		}
+		if event.Timestamp().IsZero() {
+			return fmt.Errorf("invalid zero events[%v].Timestamp", idx)
+		}
+		if idx > 0 {
+			if event.Version() != version+uint64(idx) {
+				return fmt.Errorf("invalid continues ascending events[%v].Version(%v))", idx, event.Version())
+			}
+			if event.AggregateID() != aggregateID {
+				return fmt.Errorf("invalid events[%v].AggregateID('%v') != events[0].AggregateID('%v')", idx, event.AggregateID(), aggregateID)
+			}
+			if event.GroupID() != groupID {
+				return fmt.Errorf("invalid events[%v].GroupID('%v') != events[0].GroupID('%v')", idx, event.GroupID(), groupID)
+			}
+			// timestamp values must be non-decreasing
+			if timestamp.After(event.Timestamp()) {
+				return fmt.Errorf("invalid decreasing events[%v].Timestamp(%v))", idx, event.Timestamp())
+			}
+			timestamp = event.Timestamp()
+		}
+	}
+	return nil
+}
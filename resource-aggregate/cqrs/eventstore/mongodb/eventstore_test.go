@@ -45,11 +45,5 @@ func TestEventStore(t *testing.T) {
 		_ = store.Close(ctx)
 	}()
 
-	t.Log("event store with default namespace")
-	test.AcceptanceTest(t, ctx, store)
-
-	t.Log("clearing collections")
-	err = store.ClearCollections(ctx)
-	require.NoError(t, err)
-	test.GetEventsTest(t, ctx, store)
+	test.ConformanceTest(t, ctx, store, store.ClearCollections)
 }
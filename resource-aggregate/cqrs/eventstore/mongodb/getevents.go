@@ -8,105 +8,162 @@ import (
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
 	"github.com/plgd-dev/kit/v2/strings"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-/// Get array of unique aggregateId values
-func getEventsAggregateIdFilter(groupID string, queries []eventstore.GetEventsQuery) bson.A {
-	if len(queries) == 0 {
+// getEventsDocumentQueriesFilter filters documents by groupID and aggregateIDs, nil when all documents are requested
+func getEventsDocumentQueriesFilter(queries []eventstore.GetEventsQuery) bson.A {
+	normalizedFilter := GetNormalizedGetEventsFilter(queries)
+	if normalizedFilter.All {
 		return nil
 	}
 
-	// get unique aggregateIds
-	aggregateIds := make(map[string]struct{})
-	for _, query := range queries {
-		if query.GroupID == groupID && len(query.AggregateID) != 0 {
-			aggregateIds[query.AggregateID] = struct{}{}
+	orQueries := make(bson.A, 0, len(normalizedFilter.DeviceIds))
+	for groupID, filter := range normalizedFilter.DeviceIds {
+		query := bson.M{}
+		if len(groupID) > 0 {
+			query[groupIDKey] = groupID
 		}
+		if !filter.All {
+			aggregateIdFilter := make(bson.A, 0, len(filter.ResourceIds))
+			for aggregateId := range filter.ResourceIds {
+				aggregateIdFilter = append(aggregateIdFilter, aggregateId)
+			}
+			query[aggregateIDKey] = bson.M{
+				"$in": aggregateIdFilter,
+			}
+		}
+		orQueries = append(orQueries, query)
 	}
-
-	if len(aggregateIds) == 0 {
-		return nil
-	}
-
-	// filter to include only given unique aggregateIds
-	aggregateIdFilter := make(bson.A, 0, len(aggregateIds))
-	for aggregateId := range aggregateIds {
-		aggregateIdFilter = append(aggregateIdFilter, aggregateId)
-	}
-	return aggregateIdFilter
+	return orQueries
 }
 
-func getEventsFilter(groupID string, queries []eventstore.GetEventsQuery, timestamp int64) bson.D {
+func getEventsDocumentFilter(queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage) bson.D {
 	filter := bson.D{}
-	if len(groupID) > 0 {
-		// filter documents by groupdID
-		filter = append(filter, bson.E{Key: groupIDKey, Value: groupID})
+	orQueries := getEventsDocumentQueriesFilter(queries)
+	if len(orQueries) > 0 {
+		// filter documents by groupID and aggregateID
+		filter = append(filter, bson.E{Key: "$or", Value: orQueries})
 	}
 
-	if len(queries) > 0 {
-		aggregateIdFilter := getEventsAggregateIdFilter(groupID, queries)
-		if len(aggregateIdFilter) > 0 {
-			// filter documents by aggregateID
-			filter = append(filter, bson.E{
-				Key: aggregateIDKey,
-				Value: bson.M{
-					"$in": aggregateIdFilter,
-				},
-			})
-		}
+	latestTimestampFilter := bson.M{}
+	if page.TimestampFrom > 0 {
+		latestTimestampFilter["$gt"] = page.TimestampFrom
 	}
-
-	if timestamp > 0 {
+	if page.After != nil {
+		latestTimestampFilter["$gte"] = page.After.Timestamp
+	}
+	if len(latestTimestampFilter) > 0 {
 		// filter documents that have the latest timestamp of events larger than given value
-		filter = append(filter, bson.E{
-			Key: latestTimestampKey,
-			Value: bson.M{
-				"$gt": timestamp,
-			},
-		})
+		filter = append(filter, bson.E{Key: latestTimestampKey, Value: latestTimestampFilter})
 	}
 
 	return filter
 }
 
-func getEventsProjection(timestamp int64) bson.M {
-	projection := bson.M{
-		"_id":          0,
-		groupIDKey:     1,
-		aggregateIDKey: 1,
-		eventsKey:      1,
-	}
-
-	if timestamp > 0 {
-		filter := bson.M{
-			"input": "$" + eventsKey,
-			"as":    "event",
-			"cond": bson.M{
-				"$gt": bson.A{"$$event." + timestampKey, timestamp},
-			},
+// getEventsEventQueriesFilter filters unwound events by the queries, nil when any query accepts all events
+func getEventsEventQueriesFilter(queries []eventstore.GetEventsQuery) bson.A {
+	orQueries := make(bson.A, 0, len(queries))
+	for _, q := range queries {
+		query := bson.M{}
+		if len(q.GroupID) > 0 {
+			query[groupIDKey] = q.GroupID
+		}
+		if len(q.AggregateID) > 0 {
+			query[aggregateIDKey] = q.AggregateID
+		}
+		if len(q.EventTypes) > 0 {
+			eventTypes := make(strings.Set)
+			eventTypes.Add(q.EventTypes...)
+			eventTypeFilter := make(bson.A, 0, len(eventTypes))
+			for eventType := range eventTypes {
+				eventTypeFilter = append(eventTypeFilter, eventType)
+			}
+			query[eventsKey+"."+eventTypeKey] = bson.M{
+				"$in": eventTypeFilter,
+			}
 		}
-		projection[eventsKey] = bson.M{
-			"$filter": filter,
+		if len(query) == 0 {
+			return nil
 		}
+		orQueries = append(orQueries, query)
 	}
+	return orQueries
+}
 
-	return projection
+func getEventsCursorFilter(cursor eventstore.GetEventsCursor) bson.A {
+	return bson.A{
+		bson.M{
+			eventsKey + "." + timestampKey: bson.M{"$gt": cursor.Timestamp},
+		},
+		bson.M{
+			eventsKey + "." + timestampKey: cursor.Timestamp,
+			aggregateIDKey:                 bson.M{"$gt": cursor.AggregateID},
+		},
+		bson.M{
+			eventsKey + "." + timestampKey: cursor.Timestamp,
+			aggregateIDKey:                 cursor.AggregateID,
+			eventsKey + "." + versionKey:   bson.M{"$gt": int64(cursor.Version)},
+		},
+	}
 }
 
-func getEventsQueriesToMongoQuery(groupID string, queries []eventstore.GetEventsQuery, timestamp int64) (bson.D, *options.FindOptions) {
-	filter := getEventsFilter(groupID, queries, timestamp)
+func getEventsEventFilter(queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage) bson.M {
+	andQueries := make(bson.A, 0, 3)
+	orQueries := getEventsEventQueriesFilter(queries)
+	if len(orQueries) > 0 {
+		andQueries = append(andQueries, bson.M{"$or": orQueries})
+	}
+
+	timestampFilter := bson.M{}
+	if page.TimestampFrom > 0 {
+		timestampFilter["$gt"] = page.TimestampFrom
+	}
+	if page.TimestampTo > 0 {
+		timestampFilter["$lt"] = page.TimestampTo
+	}
+	if len(timestampFilter) > 0 {
+		andQueries = append(andQueries, bson.M{eventsKey + "." + timestampKey: timestampFilter})
+	}
 
-	opts := options.Find()
-	opts.SetAllowDiskUse(true)
-	opts.SetProjection(getEventsProjection(timestamp))
+	if page.After != nil {
+		andQueries = append(andQueries, bson.M{"$or": getEventsCursorFilter(*page.After)})
+	}
 
-	return filter, opts
+	if len(andQueries) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": andQueries}
 }
 
-func (s *EventStore) getEvents(ctx context.Context, groupID string, queries []eventstore.GetEventsQuery, timestamp int64, eventHandler eventstore.Handler) error {
-	filter, opts := getEventsQueriesToMongoQuery(groupID, queries, timestamp)
-	return s.loadEventsQuery(ctx, eventHandler, nil, filter, opts)
+func getEventsPipeline(queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage) mongo.Pipeline {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: getEventsDocumentFilter(queries, page)}},
+		{{Key: "$project", Value: bson.M{
+			"_id":          0,
+			groupIDKey:     1,
+			aggregateIDKey: 1,
+			eventsKey:      1,
+		}}},
+		{{Key: "$unwind", Value: "$" + eventsKey}},
+		{{Key: "$match", Value: getEventsEventFilter(queries, page)}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: eventsKey + "." + timestampKey, Value: 1},
+			{Key: aggregateIDKey, Value: 1},
+			{Key: eventsKey + "." + versionKey, Value: 1},
+		}}},
+	}
+	if page.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: page.Limit}})
+	}
+	// wrap the unwound event to the array, so each document is processed by the iterator as a document with one event
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.M{
+		groupIDKey:     1,
+		aggregateIDKey: 1,
+		eventsKey:      bson.A{"$" + eventsKey},
+	}}})
+	return pipeline
 }
 
 type ResourceIdFilter struct {
@@ -154,8 +211,124 @@ func GetNormalizedGetEventsFilter(queries []eventstore.GetEventsQuery) DeviceIdF
 	return filter
 }
 
-// Get events from the eventstore.
-func (s *EventStore) GetEvents(ctx context.Context, queries []eventstore.GetEventsQuery, timestamp int64, eventHandler eventstore.Handler) error {
+// getEventsMaxPageSize bounds the number of events sorted by one aggregation. The server keeps only the top
+// events of the page during the sort, so GetEvents without a limit doesn't sort all matching events at once.
+const getEventsMaxPageSize = 1000
+
+// pagedIterator iterates over events of GetEvents by pages of at most getEventsMaxPageSize events, the next page
+// continues after the last event of the previous page.
+type pagedIterator struct {
+	s         *EventStore
+	col       *mongo.Collection
+	queries   []eventstore.GetEventsQuery
+	page      eventstore.GetEventsPage
+	remaining int64 // number of events to return, <=0 for all events
+
+	cursor      *mongo.Cursor
+	iter        *iterator
+	pageSize    int64                       // limit of the current page
+	pageEvents  int64                       // number of events returned from the current page
+	lastEvent   *eventstore.GetEventsCursor // cursor of the last returned event
+	err         error
+	noMorePages bool
+}
+
+func newPagedIterator(s *EventStore, col *mongo.Collection, queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage) *pagedIterator {
+	return &pagedIterator{
+		s:         s,
+		col:       col,
+		queries:   queries,
+		page:      page,
+		remaining: page.Limit,
+	}
+}
+
+func (i *pagedIterator) nextPage(ctx context.Context) bool {
+	if i.noMorePages {
+		return false
+	}
+	if i.cursor != nil {
+		i.err = i.iter.Err()
+		errClose := i.cursor.Close(ctx)
+		if i.err == nil {
+			i.err = errClose
+		}
+		i.cursor = nil
+		if i.err != nil || i.pageEvents < i.pageSize {
+			i.noMorePages = true
+			return false
+		}
+	}
+	i.pageSize = getEventsMaxPageSize
+	if i.remaining > 0 && i.remaining < i.pageSize {
+		i.pageSize = i.remaining
+	}
+	page := i.page
+	page.Limit = i.pageSize
+	if i.lastEvent != nil {
+		page.After = i.lastEvent
+	}
+	cursor, err := i.col.Aggregate(ctx, getEventsPipeline(i.queries, page), options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		i.err = err
+		i.noMorePages = true
+		return false
+	}
+	i.cursor = cursor
+	i.iter = NewIterator(cursor, nil, i.s.dataUnmarshaler, i.s.LogDebugfFunc)
+	i.pageEvents = 0
+	return true
+}
+
+func (i *pagedIterator) Next(ctx context.Context) (eventstore.EventUnmarshaler, bool) {
+	for {
+		if i.cursor == nil && !i.nextPage(ctx) {
+			return nil, false
+		}
+		ev, ok := i.iter.Next(ctx)
+		if !ok {
+			if !i.nextPage(ctx) {
+				return nil, false
+			}
+			continue
+		}
+		i.pageEvents++
+		i.lastEvent = &eventstore.GetEventsCursor{
+			Timestamp:   ev.Timestamp().UnixNano(),
+			AggregateID: ev.AggregateID(),
+			Version:     ev.Version(),
+		}
+		if i.remaining > 0 {
+			i.remaining--
+			if i.remaining == 0 {
+				i.noMorePages = true
+			}
+		}
+		return ev, true
+	}
+}
+
+func (i *pagedIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	if i.cursor != nil {
+		return i.iter.Err()
+	}
+	return nil
+}
+
+func (i *pagedIterator) Close(ctx context.Context) error {
+	if i.cursor == nil {
+		return nil
+	}
+	err := i.cursor.Close(ctx)
+	i.cursor = nil
+	return err
+}
+
+// Get events from the eventstore. The events are loaded by pages of at most getEventsMaxPageSize events.
+func (s *EventStore) GetEvents(ctx context.Context, queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage, eventHandler eventstore.Handler) error {
 	s.LogDebugfFunc("mongodb.Evenstore.GetEvents start")
 	t := time.Now()
 	defer func() {
@@ -165,23 +338,15 @@ func (s *EventStore) GetEvents(ctx context.Context, queries []eventstore.GetEven
 		return fmt.Errorf("not supported")
 	}
 
-	eventFilter := GetNormalizedGetEventsFilter(queries)
-	if eventFilter.All {
-		s.LogDebugfFunc("Query all events")
-		return s.getEvents(ctx, "", nil, timestamp, eventHandler)
+	col := s.client.Database(s.DBName()).Collection(getEventCollectionName())
+	i := newPagedIterator(s, col, queries, page)
+	err := eventHandler.Handle(ctx, i)
+	errClose := i.Close(ctx)
+	if err == nil {
+		err = i.Err()
 	}
-
-	var errors []error
-	for groupID, filter := range eventFilter.DeviceIds {
-		s.LogDebugfFunc("GroupID: %v, all: %v #resourceIds: %v", groupID, filter.All, len(filter.ResourceIds))
-		err := s.getEvents(ctx, groupID, queries, timestamp, eventHandler)
-		if err != nil {
-			errors = append(errors, err)
-		}
-	}
-	if len(errors) > 0 {
-		return fmt.Errorf("%+v", errors)
+	if err == nil {
+		return errClose
 	}
-
-	return nil
+	return err
 }
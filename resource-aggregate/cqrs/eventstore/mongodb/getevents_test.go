@@ -72,7 +72,7 @@ func addEventsForGetEventsToDB(t *testing.T, ctx context.Context, store *mongodb
 }
 
 func getEventsByTimestamp(t *testing.T, ctx context.Context, store *mongodb.EventStore, queries []eventstore.GetEventsQuery, timestamp int64) {
-	err := store.GetEvents(ctx, queries, timestamp, &dummyEventHandler{})
+	err := store.GetEvents(ctx, queries, eventstore.GetEventsPage{TimestampFrom: timestamp}, &dummyEventHandler{})
 	require.NoError(t, err)
 }
 
@@ -180,6 +180,68 @@ func TestGetResourcesEventsByTimestamp(t *testing.T) {
 	})
 }
 
+type orderedEventHandler struct {
+	events []eventstore.GetEventsCursor
+}
+
+func (eh *orderedEventHandler) Handle(ctx context.Context, iter eventstore.Iter) error {
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			return iter.Err()
+		}
+		eh.events = append(eh.events, eventstore.GetEventsCursor{
+			Timestamp:   eu.Timestamp().UnixNano(),
+			AggregateID: eu.AggregateID(),
+			Version:     eu.Version(),
+		})
+	}
+}
+
+func isOrderedEventsCursor(a, b eventstore.GetEventsCursor) bool {
+	if a.Timestamp != b.Timestamp {
+		return a.Timestamp < b.Timestamp
+	}
+	if a.AggregateID != b.AggregateID {
+		return a.AggregateID < b.AggregateID
+	}
+	return a.Version < b.Version
+}
+
+func TestGetEventsByPages(t *testing.T) {
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store, err := NewTestEventStore(ctx, logger)
+	require.NoError(t, err)
+	require.NotNil(t, store)
+	defer func() {
+		err = store.Clear(ctx)
+		require.NoError(t, err)
+		err := store.Close(ctx)
+		require.NoError(t, err)
+	}()
+
+	eventCount := addEventsForGetEventsToDB(t, ctx, store)
+
+	// unbounded request is loaded by many pages
+	var eh orderedEventHandler
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{}, &eh)
+	require.NoError(t, err)
+	require.Len(t, eh.events, eventCount)
+	for i := 1; i < len(eh.events); i++ {
+		require.True(t, isOrderedEventsCursor(eh.events[i-1], eh.events[i]), "events %v and %v are not ordered", eh.events[i-1], eh.events[i])
+	}
+
+	// limit larger than the page
+	const limit = 2500
+	var ehLimit orderedEventHandler
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{Limit: limit}, &ehLimit)
+	require.NoError(t, err)
+	require.Equal(t, eh.events[:limit], ehLimit.events)
+}
+
 func Test_getNormalizedGetEventsFilter(t *testing.T) {
 	const groupID1 = "groupID1"
 	const aggregateID1 = "aggregateID1"
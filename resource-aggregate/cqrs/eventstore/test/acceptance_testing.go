@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -9,22 +10,19 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// AcceptanceTest is the acceptance test that all implementations of EventStore
-// should pass. It should manually be called from a test case in each
-// implementation:
-//
-//   func TestEventStore(t *testing.T) {
-//       ctx := context.Background() // Or other when testing namespaces.
-//       store := NewEventStore()
-//       eventstore.AcceptanceTest(t, ctx, store)
-//   }
-//
+// getEventType alternates event types by version, so filtering by event type can be tested
+func getEventType(version uint64) string {
+	if version%2 == 0 {
+		return "test0"
+	}
+	return "test1"
+}
 
 func getEvents(fromVersion uint64, num uint64, firstEventSnapshot bool, groupID string, aggregateID string, timestamp int64) []eventstore.Event {
 	e := []eventstore.Event{
 		MockEvent{
 			VersionI:     fromVersion,
-			EventTypeI:   "test0",
+			EventTypeI:   getEventType(fromVersion),
 			AggregateIDI: aggregateID,
 			GroupIDI:     groupID,
 			IsSnapshotI:  firstEventSnapshot,
@@ -34,7 +32,7 @@ func getEvents(fromVersion uint64, num uint64, firstEventSnapshot bool, groupID
 	for i := uint64(1); i < num; i++ {
 		e = append(e, MockEvent{
 			VersionI:     fromVersion + i,
-			EventTypeI:   "test0",
+			EventTypeI:   getEventType(fromVersion + i),
 			AggregateIDI: aggregateID,
 			GroupIDI:     groupID,
 			TimestampI:   timestamp + int64(i),
@@ -59,11 +57,13 @@ const aggregateID1 = "aggregateID1"
 const aggregateID2 = "aggregateID2"
 const aggregateID3 = "aggregateID3"
 const aggregateID4 = "aggregateID4"
+const aggregateID5 = "aggregateID5"
 
 const groupID1 = "deviceId1"
 const groupID2 = "deviceId2"
 const groupID3 = "deviceId3"
 
+// GetEventsTest tests the filtering, ordering and paging of GetEvents, it is a part of the ConformanceTest.
 func GetEventsTest(t *testing.T, ctx context.Context, store eventstore.EventStore) {
 	t.Log("testing GetEvents")
 
@@ -99,13 +99,13 @@ func GetEventsTest(t *testing.T, ctx context.Context, store eventstore.EventStor
 
 	t.Log("get all events")
 	saveEh := NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, 0, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{}, saveEh)
 	require.NoError(t, err)
 	require.True(t, saveEh.Equals(allEvents))
 
 	t.Logf("get groupid %v and %v events", groupID1, groupID2)
 	saveEh = NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID1}, {GroupID: groupID2}}, 0, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID1}, {GroupID: groupID2}}, eventstore.GetEventsPage{}, saveEh)
 	require.NoError(t, err)
 	events := groupID1Events
 	events = append(events, groupID2Events...)
@@ -113,13 +113,13 @@ func GetEventsTest(t *testing.T, ctx context.Context, store eventstore.EventStor
 
 	t.Logf("get aggregateid %v events", aggregateID2)
 	saveEh = NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{AggregateID: aggregateID2}}, 0, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{AggregateID: aggregateID2}}, eventstore.GetEventsPage{}, saveEh)
 	require.NoError(t, err)
 	require.True(t, saveEh.Equals(groupID2AggID2Events))
 
 	t.Logf("get groupid %v and aggregateid %v events", groupID1, aggregateID4)
 	saveEh = NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID1}, {GroupID: groupID3, AggregateID: aggregateID4}}, 0, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID1}, {GroupID: groupID3, AggregateID: aggregateID4}}, eventstore.GetEventsPage{}, saveEh)
 	require.NoError(t, err)
 	events = groupID1Events
 	events = append(events, groupID3Events...)
@@ -128,14 +128,14 @@ func GetEventsTest(t *testing.T, ctx context.Context, store eventstore.EventStor
 	timestamp := timestamp4 - 1
 	t.Logf("get events with timestamp > %v", timestamp)
 	saveEh = NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, timestamp, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{TimestampFrom: timestamp}, saveEh)
 	require.NoError(t, err)
 	require.True(t, saveEh.Equals(groupID3Events))
 
 	timestamp = timestamp3 + 2
 	t.Logf("get groupid (%v, %v) events with timestamp > %v", groupID2, groupID3, timestamp)
 	saveEh = NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID2}, {GroupID: groupID3}}, timestamp, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID2}, {GroupID: groupID3}}, eventstore.GetEventsPage{TimestampFrom: timestamp}, saveEh)
 	require.NoError(t, err)
 	events = filterEvents(allEvents, func(e eventstore.Event) bool {
 		return e.Timestamp().UnixNano() > timestamp
@@ -145,11 +145,114 @@ func GetEventsTest(t *testing.T, ctx context.Context, store eventstore.EventStor
 	timestamp = timestamp2 - 1
 	t.Logf("get aggregateid (%v, %v) events with timestamp > %v", aggregateID3, aggregateID4, timestamp)
 	saveEh = NewMockEventHandler()
-	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{AggregateID: aggregateID3}, {AggregateID: aggregateID4}}, timestamp, saveEh)
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{AggregateID: aggregateID3}, {AggregateID: aggregateID4}}, eventstore.GetEventsPage{TimestampFrom: timestamp}, saveEh)
 	require.NoError(t, err)
 	events = groupID2AggID3Events
 	events = append(events, groupID3Events...)
 	require.True(t, saveEh.Equals(events))
+
+	eventType := getEventType(1)
+	t.Logf("get events with event type %v", eventType)
+	saveEh = NewMockEventHandler()
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{EventTypes: []string{eventType}}}, eventstore.GetEventsPage{}, saveEh)
+	require.NoError(t, err)
+	events = filterEvents(allEvents, func(e eventstore.Event) bool {
+		return e.EventType() == eventType
+	})
+	require.True(t, saveEh.Equals(events))
+
+	timestamp = timestamp3 + 2
+	t.Logf("get groupid (%v, %v) events with event type %v and timestamp > %v", groupID2, groupID3, eventType, timestamp)
+	saveEh = NewMockEventHandler()
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID2, EventTypes: []string{eventType}}, {GroupID: groupID3, EventTypes: []string{eventType}}}, eventstore.GetEventsPage{TimestampFrom: timestamp}, saveEh)
+	require.NoError(t, err)
+	events = filterEvents(groupID2Events, func(e eventstore.Event) bool {
+		return e.EventType() == eventType && e.Timestamp().UnixNano() > timestamp
+	})
+	events = append(events, filterEvents(groupID3Events, func(e eventstore.Event) bool {
+		return e.EventType() == eventType && e.Timestamp().UnixNano() > timestamp
+	})...)
+	require.True(t, saveEh.Equals(events))
+
+	t.Logf("get aggregateid %v events with event types (%v, %v)", aggregateID1, getEventType(0), getEventType(1))
+	saveEh = NewMockEventHandler()
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID1, AggregateID: aggregateID1, EventTypes: []string{getEventType(0), getEventType(1)}}}, eventstore.GetEventsPage{}, saveEh)
+	require.NoError(t, err)
+	require.True(t, saveEh.Equals(groupID1Events))
+
+	getEventsPageTest(t, ctx, store, allEvents, timestamp4)
+}
+
+// sortEvents sorts events in the order of GetEvents: by timestamp, aggregateID and version
+func sortEvents(events []eventstore.Event) []eventstore.Event {
+	sorted := make([]eventstore.Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Timestamp().UnixNano() != sorted[j].Timestamp().UnixNano() {
+			return sorted[i].Timestamp().UnixNano() < sorted[j].Timestamp().UnixNano()
+		}
+		if sorted[i].AggregateID() != sorted[j].AggregateID() {
+			return sorted[i].AggregateID() < sorted[j].AggregateID()
+		}
+		return sorted[i].Version() < sorted[j].Version()
+	})
+	return sorted
+}
+
+func getEventsPageTest(t *testing.T, ctx context.Context, store eventstore.EventStore, allEvents []eventstore.Event, timestamp int64) {
+	t.Logf("insert events of %v with the same timestamps as %v", aggregateID5, aggregateID4)
+	groupID3AggID5Events := getEvents(0, 5, false, groupID3, aggregateID5, timestamp)
+	saveStatus, err := store.Save(ctx, groupID3AggID5Events...)
+	require.NoError(t, err)
+	require.Equal(t, eventstore.Ok, saveStatus)
+	allEvents = sortEvents(append(allEvents, groupID3AggID5Events...))
+
+	t.Log("get all events in order")
+	saveEh := NewMockEventHandler()
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{}, saveEh)
+	require.NoError(t, err)
+	require.Equal(t, allEvents, saveEh.Events())
+
+	timestampFrom := allEvents[2].Timestamp().UnixNano()
+	timestampTo := timestamp + 2
+	t.Logf("get events with %v < timestamp < %v", timestampFrom, timestampTo)
+	saveEh = NewMockEventHandler()
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{TimestampFrom: timestampFrom, TimestampTo: timestampTo}, saveEh)
+	require.NoError(t, err)
+	events := filterEvents(allEvents, func(e eventstore.Event) bool {
+		return e.Timestamp().UnixNano() > timestampFrom && e.Timestamp().UnixNano() < timestampTo
+	})
+	require.Equal(t, events, saveEh.Events())
+
+	const limit = 3
+	t.Logf("get all events by pages of %v events", limit)
+	var pages []eventstore.Event
+	var after *eventstore.GetEventsCursor
+	for {
+		saveEh = NewMockEventHandler()
+		err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{Limit: limit, After: after}, saveEh)
+		require.NoError(t, err)
+		page := saveEh.Events()
+		require.LessOrEqual(t, len(page), limit)
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page...)
+		cursor := eventstore.NewGetEventsCursor(page[len(page)-1])
+		after = &cursor
+	}
+	require.Equal(t, allEvents, pages)
+
+	t.Logf("get groupid %v events after the cursor of the event with a shared timestamp", groupID3)
+	cursor := eventstore.NewGetEventsCursor(groupID3AggID5Events[0])
+	saveEh = NewMockEventHandler()
+	err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{GroupID: groupID3}}, eventstore.GetEventsPage{After: &cursor}, saveEh)
+	require.NoError(t, err)
+	events = filterEvents(allEvents, func(e eventstore.Event) bool {
+		return e.GroupID() == groupID3 && (e.Timestamp().UnixNano() > cursor.Timestamp ||
+			(e.Timestamp().UnixNano() == cursor.Timestamp && e.AggregateID() > cursor.AggregateID))
+	})
+	require.Equal(t, events, saveEh.Events())
 }
 
 func emptySaveFailTest(t *testing.T, ctx context.Context, store eventstore.EventStore) {
@@ -171,6 +274,8 @@ func invalidTimpestampFailTest(t *testing.T, ctx context.Context, store eventsto
 	require.Equal(t, eventstore.Fail, saveStatus)
 }
 
+// AcceptanceTest is the acceptance test that all implementations of EventStore
+// should pass, it is a part of the ConformanceTest.
 func AcceptanceTest(t *testing.T, ctx context.Context, store eventstore.EventStore) {
 	type Path struct {
 		GroupID     string
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/stretchr/testify/require"
+)
+
+// ClearFunc removes all events from the eventstore.
+type ClearFunc = func(ctx context.Context) error
+
+// ConformanceTest is the set of tests that all implementations of EventStore
+// must pass. The clear function is called before each test, so the tests
+// start with an empty eventstore. It should manually be called from a test
+// case in each implementation:
+//
+//   func TestEventStore(t *testing.T) {
+//       ctx := context.Background()
+//       store := NewEventStore()
+//       test.ConformanceTest(t, ctx, store, store.Clear)
+//   }
+//
+func ConformanceTest(t *testing.T, ctx context.Context, store eventstore.EventStore, clear ClearFunc) {
+	t.Run("Acceptance", func(t *testing.T) {
+		require.NoError(t, clear(ctx))
+		AcceptanceTest(t, ctx, store)
+	})
+	t.Run("GetEvents", func(t *testing.T) {
+		require.NoError(t, clear(ctx))
+		GetEventsTest(t, ctx, store)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, clear(ctx))
+		DeleteTest(t, ctx, store, clear)
+	})
+}
@@ -1,19 +1,15 @@
-package mongodb_test
+package test
 
 import (
 	"context"
 	"strconv"
 	"testing"
 
-	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
-	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
-	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/test"
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func addEventsForDeleteToDB(t *testing.T, ctx context.Context, store *mongodb.EventStore) int {
+func addEventsForDeleteToDB(t *testing.T, ctx context.Context, store eventstore.EventStore) int {
 	const eventCount = 2000
 	const deviceCount = 10
 	const resourceCount = 100
@@ -27,7 +23,7 @@ func addEventsForDeleteToDB(t *testing.T, ctx context.Context, store *mongodb.Ev
 			resourceTimestamp[i] = int64((eventCount / resourceCount) * i)
 		}
 
-		resourceEvents[resourceIndex] = append(resourceEvents[resourceIndex], test.MockEvent{
+		resourceEvents[resourceIndex] = append(resourceEvents[resourceIndex], MockEvent{
 			VersionI:     resourceVersion[resourceIndex],
 			EventTypeI:   "testType",
 			IsSnapshotI:  false,
@@ -49,21 +45,9 @@ func addEventsForDeleteToDB(t *testing.T, ctx context.Context, store *mongodb.Ev
 	return eventCount
 }
 
-func TestEventStore_Delete(t *testing.T) {
-	logger, err := log.NewLogger(log.Config{})
-	require.NoError(t, err)
-
-	ctx := context.Background()
-	store, err := NewTestEventStore(ctx, logger)
-	assert.NoError(t, err)
-	assert.NotNil(t, store)
-	defer func() {
-		t.Log("clearing db")
-		err := store.Clear(ctx)
-		require.NoError(t, err)
-		_ = store.Close(ctx)
-	}()
-
+// DeleteTest tests the deletion of events by group ids. The clear function is used to remove all
+// events from the store after each test case.
+func DeleteTest(t *testing.T, ctx context.Context, store eventstore.EventStore, clear ClearFunc) {
 	type args struct {
 		query []eventstore.DeleteQuery
 	}
@@ -129,7 +113,7 @@ func TestEventStore_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			addEventsForDeleteToDB(t, ctx, store)
 			defer func() {
-				err = store.ClearCollections(ctx)
+				err := clear(ctx)
 				require.NoError(t, err)
 			}()
 
@@ -141,8 +125,8 @@ func TestEventStore_Delete(t *testing.T) {
 			}
 
 			// get all events after deletion
-			handler := test.NewMockEventHandler()
-			err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, 0, handler)
+			handler := NewMockEventHandler()
+			err = store.GetEvents(ctx, []eventstore.GetEventsQuery{{}}, eventstore.GetEventsPage{}, handler)
 			require.NoError(t, err)
 			// no documents with deleted group id should remain
 			for _, q := range tt.args.query {
@@ -453,8 +453,9 @@ func (e MockEvent) Timestamp() time.Time {
 }
 
 type MockEventHandler struct {
-	lock   sync.Mutex
-	events map[string]map[string][]eventstore.Event
+	lock    sync.Mutex
+	events  map[string]map[string][]eventstore.Event
+	ordered []eventstore.Event
 }
 
 func NewMockEventHandler() *MockEventHandler {
@@ -472,6 +473,14 @@ func (eh *MockEventHandler) SetElement(groupId, aggregateId string, e MockEvent)
 		eh.events[groupId] = device
 	}
 	device[aggregateId] = append(device[aggregateId], e)
+	eh.ordered = append(eh.ordered, e)
+}
+
+// Events returns events in the order in which they were handled.
+func (eh *MockEventHandler) Events() []eventstore.Event {
+	eh.lock.Lock()
+	defer eh.lock.Unlock()
+	return eh.ordered
 }
 
 func (eh *MockEventHandler) Contains(event eventstore.Event) bool {
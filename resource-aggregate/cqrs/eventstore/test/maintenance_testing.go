@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/maintenance"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRecordHandler struct {
+	lock  sync.Mutex
+	tasks map[string]maintenance.Task
+}
+
+func newMockRecordHandler() *mockRecordHandler {
+	return &mockRecordHandler{tasks: make(map[string]maintenance.Task)}
+}
+
+func (eh *mockRecordHandler) SetElement(aggregateID string, task maintenance.Task) {
+	var aggregate maintenance.Task
+	var ok bool
+
+	eh.lock.Lock()
+	defer eh.lock.Unlock()
+	if aggregate, ok = eh.tasks[aggregateID]; !ok {
+		eh.tasks[aggregateID] = maintenance.Task{AggregateID: task.AggregateID, Version: task.Version}
+	}
+	aggregate.AggregateID = task.AggregateID
+	aggregate.Version = task.Version
+}
+
+func (eh *mockRecordHandler) Handle(ctx context.Context, iter maintenance.Iter) error {
+	var task maintenance.Task
+
+	for iter.Next(ctx, &task) {
+		eh.SetElement(task.AggregateID, task)
+	}
+	return nil
+}
+
+// MaintenanceTest tests the maintenance records of the store. The store must not contain
+// any maintenance record.
+func MaintenanceTest(t *testing.T, ctx context.Context, store maintenance.EventStore) {
+	aggregateID1 := "aggregateID1"
+	tasksToSave := []maintenance.Task{
+		{
+			AggregateID: aggregateID1,
+		},
+		{
+			AggregateID: aggregateID1,
+			Version:     1,
+		},
+		{
+			AggregateID: aggregateID1,
+			Version:     2,
+		},
+		{
+			AggregateID: aggregateID1,
+			Version:     3,
+		},
+		{
+			AggregateID: aggregateID1,
+			Version:     4,
+		},
+	}
+
+	t.Log("insert maintenance record without body")
+	err := store.Insert(ctx, maintenance.Task{})
+	require.Error(t, err)
+
+	t.Log("insert maintenance record")
+	err = store.Insert(ctx, tasksToSave[1])
+	require.NoError(t, err)
+
+	t.Log("insert maintenance record with higher version")
+	err = store.Insert(ctx, tasksToSave[4])
+	require.NoError(t, err)
+
+	t.Log("query maintenance records")
+	eh1 := newMockRecordHandler()
+	err = store.Query(ctx, 777, eh1)
+	require.NoError(t, err)
+	require.Equal(t, tasksToSave[4], eh1.tasks[aggregateID1])
+
+	t.Log("insert maintenance record with lower version")
+	err = store.Insert(ctx, tasksToSave[3])
+	require.Error(t, err)
+
+	t.Log("query maintenance records")
+	eh2 := newMockRecordHandler()
+	err = store.Query(ctx, 777, eh2)
+	require.NoError(t, err)
+	require.Equal(t, tasksToSave[4], eh2.tasks[aggregateID1])
+
+	t.Log("remove maintenance record - incorrect version")
+	err = store.Remove(ctx, tasksToSave[3])
+	require.Error(t, err)
+
+	t.Log("remove maintenance record")
+	err = store.Remove(ctx, tasksToSave[4])
+	require.NoError(t, err)
+
+	t.Log("query maintenance records - empty collection")
+	eh3 := newMockRecordHandler()
+	err = store.Query(ctx, 777, eh3)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(eh3.tasks))
+}
@@ -221,7 +221,7 @@ func (e *DeviceMetadataSnapshotTaken) CancelPendingMetadataUpdates(ctx context.C
 }
 
 func (e *DeviceMetadataSnapshotTaken) HandleCommand(ctx context.Context, cmd aggregate.Command, newVersion uint64) ([]eventstore.Event, error) {
-	userID, err := grpc.SubjectFromTokenMD(ctx)
+	userID, err := grpc.AuditUserIDFromTokenMD(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +49,7 @@ func (e *ResourceChanged) CopyData(event *ResourceChanged) {
 	e.AuditContext = event.GetAuditContext()
 	e.EventMetadata = event.GetEventMetadata()
 	e.Status = event.GetStatus()
+	e.Etag = event.GetEtag()
 }
 
 func (e *ResourceChanged) CheckInitialized() bool {
@@ -70,6 +71,10 @@ func (rc *ResourceChanged) Equal(changed *ResourceChanged) bool {
 		return false
 	}
 
+	if !bytes.Equal(rc.GetEtag(), changed.GetEtag()) {
+		return false
+	}
+
 	if rc.GetAuditContext().GetUserId() != changed.GetAuditContext().GetUserId() {
 		return false
 	}
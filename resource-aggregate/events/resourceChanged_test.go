@@ -31,6 +31,7 @@ var testEventResourceChanged events.ResourceChanged = events.ResourceChanged{
 		Sequence:     1,
 	},
 	Status: commands.Status_ACCEPTED,
+	Etag:   []byte{'e', 't', 'a', 'g'},
 }
 
 func TestResourceChanged_CopyData(t *testing.T) {
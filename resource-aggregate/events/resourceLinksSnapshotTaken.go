@@ -162,7 +162,7 @@ func (e *ResourceLinksSnapshotTaken) Handle(ctx context.Context, iter eventstore
 }
 
 func (e *ResourceLinksSnapshotTaken) HandleCommand(ctx context.Context, cmd aggregate.Command, newVersion uint64) ([]eventstore.Event, error) {
-	userID, err := grpc.SubjectFromTokenMD(ctx)
+	userID, err := grpc.AuditUserIDFromTokenMD(ctx)
 	if err != nil {
 		return nil, err
 	}
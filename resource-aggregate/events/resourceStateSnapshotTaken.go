@@ -609,7 +609,7 @@ func (e *ResourceStateSnapshotTaken) CancelPendingCommands(ctx context.Context,
 }
 
 func (e *ResourceStateSnapshotTaken) HandleCommand(ctx context.Context, cmd aggregate.Command, newVersion uint64) ([]eventstore.Event, error) {
-	userID, err := grpc.SubjectFromTokenMD(ctx)
+	userID, err := grpc.AuditUserIDFromTokenMD(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -633,6 +633,7 @@ func (e *ResourceStateSnapshotTaken) HandleCommand(ctx context.Context, cmd aggr
 			EventMetadata: em,
 			Content:       req.GetContent(),
 			Status:        req.GetStatus(),
+			Etag:          req.GetEtag(),
 		}
 		var ok bool
 		var err error
@@ -134,6 +134,13 @@ func TestEqual(t *testing.T) {
 		Status:       commands.Status_ERROR,
 	}
 
+	resWithChangedETag := events.ResourceChanged{
+		Content:      res.Content,
+		AuditContext: res.AuditContext,
+		Status:       res.Status,
+		Etag:         []byte{'e', 't', 'a', 'g'},
+	}
+
 	type args struct {
 		current *events.ResourceChanged
 		changed *events.ResourceChanged
@@ -167,6 +174,14 @@ func TestEqual(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Changed etag",
+			args: args{
+				current: &res,
+				changed: &resWithChangedETag,
+			},
+			want: false,
+		},
 		{
 			name: "Identical",
 			args: args{
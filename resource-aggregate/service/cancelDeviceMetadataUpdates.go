@@ -38,7 +38,7 @@ func (a *aggregate) CancelPendingMetadataUpdates(ctx context.Context, request *c
 }
 
 func (r RequestHandler) CancelPendingMetadataUpdates(ctx context.Context, request *commands.CancelPendingMetadataUpdatesRequest) (*commands.CancelPendingMetadataUpdatesResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetDeviceId())
+	owner, userID, err := r.validateAccessToDevice(ctx, request.GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -68,7 +68,7 @@ func (r RequestHandler) CancelPendingMetadataUpdates(ctx context.Context, reques
 	}
 
 	return &commands.CancelPendingMetadataUpdatesResponse{
-		AuditContext:   commands.NewAuditContext(owner, ""),
+		AuditContext:   commands.NewAuditContext(userID, ""),
 		CorrelationIds: correlationIDs,
 	}, nil
 }
@@ -232,7 +232,7 @@ func TestRequestHandler_CancelPendingMetadataUpdates(t *testing.T) {
 	})), testMakeUpdateDeviceMetadataRequest(deviceID, correlationID2, nil, commands.ShadowSynchronization_DISABLED, 0))
 	require.NoError(t, err)
 
-	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
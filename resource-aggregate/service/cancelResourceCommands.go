@@ -41,7 +41,7 @@ func (a *aggregate) CancelResourceCommand(ctx context.Context, request *commands
 }
 
 func (r RequestHandler) CancelPendingCommands(ctx context.Context, request *commands.CancelPendingCommandsRequest) (*commands.CancelPendingCommandsResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -77,7 +77,7 @@ func (r RequestHandler) CancelPendingCommands(ctx context.Context, request *comm
 	}
 
 	return &commands.CancelPendingCommandsResponse{
-		AuditContext:   commands.NewAuditContext(owner, ""),
+		AuditContext:   commands.NewAuditContext(userID, ""),
 		CorrelationIds: correlationIDs,
 	}, nil
 }
@@ -6,9 +6,11 @@ import (
 
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	grpcServer "github.com/plgd-dev/hub/pkg/net/grpc/server"
-	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
+	eventbusConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/config"
 	eventstoreConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/config"
 )
 
@@ -30,13 +32,17 @@ func (c *Config) Validate() error {
 }
 
 type APIsConfig struct {
-	GRPC GRPCConfig `yaml:"grpc" json:"grpc"`
+	GRPC    GRPCConfig     `yaml:"grpc" json:"grpc"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.GRPC.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
@@ -53,12 +59,18 @@ func (c *GRPCConfig) Validate() error {
 }
 
 type EventBusConfig struct {
-	NATS natsClient.ConfigPublisher `yaml:"nats" json:"nats"`
+	eventbusConfig.PublisherConfig `yaml:",inline" json:",inline"`
 }
 
 func (c *EventBusConfig) Validate() error {
-	if err := c.NATS.Validate(); err != nil {
-		return fmt.Errorf("nats.%w", err)
+	if err := c.PublisherConfig.Validate(); err != nil {
+		return err
+	}
+	if c.Backend == eventbusConfig.KafkaBackend {
+		// events of the identity-store for the owner cache are always delivered by nats
+		if err := c.NATS.Validate(); err != nil {
+			return fmt.Errorf("nats.%w", err)
+		}
 	}
 	return nil
 }
@@ -82,19 +94,26 @@ func (c *EventStoreConfig) Validate() error {
 
 type IdentityStoreConfig struct {
 	Connection client.Config `yaml:"grpc" json:"grpc"`
+	// ServiceAccountVerificationInterval is the interval of the verification of the tokens of the service accounts by the identity-store,
+	// revoked service accounts and rotated keys are rejected at most after the interval. Zero value verifies each request.
+	ServiceAccountVerificationInterval time.Duration `yaml:"serviceAccountVerificationInterval" json:"serviceAccountVerificationInterval"`
 }
 
 func (c *IdentityStoreConfig) Validate() error {
 	if err := c.Connection.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if c.ServiceAccountVerificationInterval < 0 {
+		return fmt.Errorf("serviceAccountVerificationInterval('%v')", c.ServiceAccountVerificationInterval)
+	}
 	return nil
 }
 
 type ClientsConfig struct {
-	Eventbus      EventBusConfig      `yaml:"eventBus" json:"eventBus"`
-	Eventstore    EventStoreConfig    `yaml:"eventStore" json:"eventStore"`
-	IdentityStore IdentityStoreConfig `yaml:"identityStore" json:"identityStore"`
+	Eventbus               EventBusConfig       `yaml:"eventBus" json:"eventBus"`
+	Eventstore             EventStoreConfig     `yaml:"eventStore" json:"eventStore"`
+	IdentityStore          IdentityStoreConfig  `yaml:"identityStore" json:"identityStore"`
+	OpenTelemetryCollector opentelemetry.Config `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 func (c *ClientsConfig) Validate() error {
@@ -107,6 +126,9 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.IdentityStore.Validate(); err != nil {
 		return fmt.Errorf("identityStore.%w", err)
 	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
@@ -41,7 +41,7 @@ func (a *aggregate) ConfirmDeviceMetadataUpdate(ctx context.Context, request *co
 }
 
 func (r RequestHandler) ConfirmDeviceMetadataUpdate(ctx context.Context, request *commands.ConfirmDeviceMetadataUpdateRequest) (*commands.ConfirmDeviceMetadataUpdateResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -62,6 +62,6 @@ func (r RequestHandler) ConfirmDeviceMetadataUpdate(ctx context.Context, request
 		log.Errorf("cannot publish device('%v') metadata events: %w", request.GetDeviceId(), err)
 	}
 	return &commands.ConfirmDeviceMetadataUpdateResponse{
-		AuditContext: commands.NewAuditContext(owner, ""),
+		AuditContext: commands.NewAuditContext(userID, ""),
 	}, nil
 }
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
@@ -15,24 +17,28 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
-type getOwnerDevicesFunc = func(ctx context.Context, owner string, deviceIDs []string) ([]string, error)
+// getDevicesOwnersFunc returns owners of the selected devices, which are accessible by the user with at least the role.
+// An empty list of devices selects all accessible devices.
+type getDevicesOwnersFunc = func(ctx context.Context, userID string, deviceIDs []string, role pbIS.Role) (map[string]string, error)
 
 //RequestHandler for handling incoming request
 type RequestHandler struct {
 	UnimplementedResourceAggregateServer
-	config              Config
-	eventstore          EventStore
-	publisher           eventbus.Publisher
-	getOwnerDevicesFunc getOwnerDevicesFunc
+	config               Config
+	eventstore           EventStore
+	publisher            eventbus.Publisher
+	getDevicesOwnersFunc getDevicesOwnersFunc
+	transferDevicesFunc  transferDevicesFunc
 }
 
 //NewRequestHandler factory for new RequestHandler
-func NewRequestHandler(config Config, eventstore EventStore, publisher eventbus.Publisher, getOwnerDevicesFunc getOwnerDevicesFunc) *RequestHandler {
+func NewRequestHandler(config Config, eventstore EventStore, publisher eventbus.Publisher, getDevicesOwnersFunc getDevicesOwnersFunc, transferDevicesFunc transferDevicesFunc) *RequestHandler {
 	return &RequestHandler{
-		config:              config,
-		eventstore:          eventstore,
-		publisher:           publisher,
-		getOwnerDevicesFunc: getOwnerDevicesFunc,
+		config:               config,
+		eventstore:           eventstore,
+		publisher:            publisher,
+		getDevicesOwnersFunc: getDevicesOwnersFunc,
+		transferDevicesFunc:  transferDevicesFunc,
 	}
 }
 
@@ -51,49 +57,81 @@ func PublishEvents(publisher eventbus.Publisher, owner, deviceId, resourceId str
 	return nil
 }
 
-// Check if device with given ID belongs to given owner
-func (r RequestHandler) isUserDevice(ctx context.Context, owner string, deviceID string) (bool, error) {
-	deviceIds, err := r.getOwnerDevicesFunc(ctx, owner, []string{deviceID})
+// Return owner of the device, when the device is accessible by the user with at least the role.
+func (r RequestHandler) getDeviceOwner(ctx context.Context, userID string, deviceID string, role pbIS.Role) (string, bool, error) {
+	owners, err := r.getDevicesOwnersFunc(ctx, userID, []string{deviceID}, role)
 	if err != nil {
-		return false, err
+		return "", false, err
 	}
-	return len(deviceIds) == 1, nil
+	owner, ok := owners[deviceID]
+	return owner, ok, nil
 }
 
-func (r RequestHandler) validateAccessToDevice(ctx context.Context, deviceID string) (string, error) {
-	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, r.config.APIs.GRPC.Authorization.OwnerClaim)
+// Validate that the user is allowed to send commands to the device.
+//
+// Function returns the owner of the device, which is used to publish events, and the user. Commands of the service account
+// are audited by the ID of the service account. Devices shared with the user by a group require at least the operator role.
+func (r RequestHandler) validateAccessToDevice(ctx context.Context, deviceID string) (string, string, error) {
+	return r.validateAccess(ctx, deviceID, false)
+}
+
+// Validate that the command is sent on behalf of the device.
+//
+// Commands sent by the device (publishing of resource links, notifications of resource changes, confirmations of commands and
+// updates of the connection status) are accepted only from the owner of the device, devices shared by a group are rejected
+// regardless of the role.
+func (r RequestHandler) validateDeviceAccessToDevice(ctx context.Context, deviceID string) (string, string, error) {
+	return r.validateAccess(ctx, deviceID, true)
+}
+
+func (r RequestHandler) validateAccess(ctx context.Context, deviceID string, ownerOnly bool) (string, string, error) {
+	userID, err := kitNetGrpc.OwnerFromTokenMD(ctx, r.config.APIs.GRPC.Authorization.OwnerClaim)
 	if err != nil {
-		return "", kitNetGrpc.ForwardErrorf(codes.InvalidArgument, "invalid owner: %v", err)
+		return "", "", kitNetGrpc.ForwardErrorf(codes.InvalidArgument, "invalid owner: %v", err)
+	}
+	role := pbIS.Role_OPERATOR
+	if ownerOnly {
+		role = pbIS.Role_OWNER
 	}
-	ok, err := r.isUserDevice(ctx, owner, deviceID)
+	owner, ok, err := r.getDeviceOwner(ctx, userID, deviceID, role)
 	if err != nil {
-		return "", kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate: %v", err)
+		return "", "", kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate: %v", err)
+	}
+	if !ok || (ownerOnly && owner != userID) {
+		return "", "", kitNetGrpc.ForwardErrorf(codes.PermissionDenied, "access denied")
 	}
-	if !ok {
-		return "", kitNetGrpc.ForwardErrorf(codes.PermissionDenied, "access denied")
+	if serviceAccountID := kitNetGrpc.ServiceAccountIDFromTokenMD(ctx); serviceAccountID != "" {
+		return owner, serviceAccountID, nil
 	}
-	return owner, nil
+	return owner, userID, nil
 }
 
 // Return owner and list of owned devices from the input slices.
 //
 // Function iterates over input slice of device IDs and returns owner name, and the intersection
-// of the input device IDs with owned devices.
+// of the input device IDs with owned devices. Devices shared with the owner are not included.
 func (r RequestHandler) getOwnedDevices(ctx context.Context, deviceIDs []string) (string, []string, error) {
 	owner, err := kitNetGrpc.OwnerFromTokenMD(ctx, r.config.APIs.GRPC.Authorization.OwnerClaim)
 	if err != nil {
 		return "", nil, kitNetGrpc.ForwardErrorf(codes.InvalidArgument, "invalid owner: %v", err)
 	}
 
-	ownedDevices, err := r.getOwnerDevicesFunc(ctx, owner, deviceIDs)
+	owners, err := r.getDevicesOwnersFunc(ctx, owner, deviceIDs, pbIS.Role_OWNER)
 	if err != nil {
 		return "", nil, kitNetGrpc.ForwardErrorf(codes.InvalidArgument, "cannot validate: %v", err)
 	}
+	ownedDevices := make([]string, 0, len(owners))
+	for deviceID, deviceOwner := range owners {
+		if deviceOwner == owner {
+			ownedDevices = append(ownedDevices, deviceID)
+		}
+	}
+	sort.Strings(ownedDevices)
 	return owner, ownedDevices, nil
 }
 
 func (r RequestHandler) PublishResourceLinks(ctx context.Context, request *commands.PublishResourceLinksRequest) (*commands.PublishResourceLinksResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -113,7 +151,7 @@ func (r RequestHandler) PublishResourceLinks(ctx context.Context, request *comma
 	if err != nil {
 		log.Errorf("cannot publish resource links published events: %v", err)
 	}
-	auditContext := commands.NewAuditContext(owner, "")
+	auditContext := commands.NewAuditContext(userID, "")
 	return newPublishResourceLinksResponse(events, aggregate.DeviceID(), auditContext), nil
 }
 
@@ -134,7 +172,7 @@ func newPublishResourceLinksResponse(events []eventstore.Event, deviceID string,
 }
 
 func (r RequestHandler) UnpublishResourceLinks(ctx context.Context, request *commands.UnpublishResourceLinksRequest) (*commands.UnpublishResourceLinksResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -154,7 +192,7 @@ func (r RequestHandler) UnpublishResourceLinks(ctx context.Context, request *com
 	if err != nil {
 		log.Errorf("cannot publish resource links unpublished events: %v", err)
 	}
-	auditContext := commands.NewAuditContext(owner, "")
+	auditContext := commands.NewAuditContext(userID, "")
 	return newUnpublishResourceLinksResponse(events, aggregate.DeviceID(), auditContext), nil
 }
 
@@ -175,7 +213,7 @@ func newUnpublishResourceLinksResponse(events []eventstore.Event, deviceID strin
 }
 
 func (r RequestHandler) NotifyResourceChanged(ctx context.Context, request *commands.NotifyResourceChangedRequest) (*commands.NotifyResourceChangedResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -193,14 +231,14 @@ func (r RequestHandler) NotifyResourceChanged(ctx context.Context, request *comm
 	if err != nil {
 		log.Errorf("cannot publish resource content changed notification events: %v", err)
 	}
-	auditContext := commands.NewAuditContext(owner, "")
+	auditContext := commands.NewAuditContext(userID, "")
 	return &commands.NotifyResourceChangedResponse{
 		AuditContext: auditContext,
 	}, nil
 }
 
 func (r RequestHandler) UpdateResource(ctx context.Context, request *commands.UpdateResourceRequest) (*commands.UpdateResourceResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -229,7 +267,7 @@ func (r RequestHandler) UpdateResource(ctx context.Context, request *commands.Up
 		}
 	}
 
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.UpdateResourceResponse{
 		AuditContext: auditContext,
 		ValidUntil:   validUntil,
@@ -237,7 +275,7 @@ func (r RequestHandler) UpdateResource(ctx context.Context, request *commands.Up
 }
 
 func (r RequestHandler) ConfirmResourceUpdate(ctx context.Context, request *commands.ConfirmResourceUpdateRequest) (*commands.ConfirmResourceUpdateResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -255,14 +293,14 @@ func (r RequestHandler) ConfirmResourceUpdate(ctx context.Context, request *comm
 	if err != nil {
 		log.Errorf("cannot publish resource content update confirmation events: %v", err)
 	}
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.ConfirmResourceUpdateResponse{
 		AuditContext: auditContext,
 	}, nil
 }
 
 func (r RequestHandler) RetrieveResource(ctx context.Context, request *commands.RetrieveResourceRequest) (*commands.RetrieveResourceResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -291,7 +329,7 @@ func (r RequestHandler) RetrieveResource(ctx context.Context, request *commands.
 		}
 	}
 
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.RetrieveResourceResponse{
 		AuditContext: auditContext,
 		ValidUntil:   validUntil,
@@ -299,7 +337,7 @@ func (r RequestHandler) RetrieveResource(ctx context.Context, request *commands.
 }
 
 func (r RequestHandler) ConfirmResourceRetrieve(ctx context.Context, request *commands.ConfirmResourceRetrieveRequest) (*commands.ConfirmResourceRetrieveResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -318,14 +356,14 @@ func (r RequestHandler) ConfirmResourceRetrieve(ctx context.Context, request *co
 		log.Errorf("cannot publish resource content retrieve confirmation events: %v", err)
 	}
 
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.ConfirmResourceRetrieveResponse{
 		AuditContext: auditContext,
 	}, nil
 }
 
 func (r RequestHandler) DeleteResource(ctx context.Context, request *commands.DeleteResourceRequest) (*commands.DeleteResourceResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -354,7 +392,7 @@ func (r RequestHandler) DeleteResource(ctx context.Context, request *commands.De
 		}
 	}
 
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.DeleteResourceResponse{
 		AuditContext: auditContext,
 		ValidUntil:   validUntil,
@@ -362,7 +400,7 @@ func (r RequestHandler) DeleteResource(ctx context.Context, request *commands.De
 }
 
 func (r RequestHandler) ConfirmResourceDelete(ctx context.Context, request *commands.ConfirmResourceDeleteRequest) (*commands.ConfirmResourceDeleteResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -382,14 +420,14 @@ func (r RequestHandler) ConfirmResourceDelete(ctx context.Context, request *comm
 		log.Errorf("cannot publish resource delete confirmation events: %v", err)
 	}
 
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.ConfirmResourceDeleteResponse{
 		AuditContext: auditContext,
 	}, nil
 }
 
 func (r RequestHandler) CreateResource(ctx context.Context, request *commands.CreateResourceRequest) (*commands.CreateResourceResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -418,7 +456,7 @@ func (r RequestHandler) CreateResource(ctx context.Context, request *commands.Cr
 		}
 	}
 
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.CreateResourceResponse{
 		AuditContext: auditContext,
 		ValidUntil:   validUntil,
@@ -426,7 +464,7 @@ func (r RequestHandler) CreateResource(ctx context.Context, request *commands.Cr
 }
 
 func (r RequestHandler) ConfirmResourceCreate(ctx context.Context, request *commands.ConfirmResourceCreateRequest) (*commands.ConfirmResourceCreateResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
+	owner, userID, err := r.validateDeviceAccessToDevice(ctx, request.GetResourceId().GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -445,7 +483,7 @@ func (r RequestHandler) ConfirmResourceCreate(ctx context.Context, request *comm
 	if err != nil {
 		log.Errorf("cannot publish resource create confirmation events: %v", err)
 	}
-	auditContext := commands.NewAuditContext(owner, request.GetCorrelationId())
+	auditContext := commands.NewAuditContext(userID, request.GetCorrelationId())
 	return &commands.ConfirmResourceCreateResponse{
 		AuditContext: auditContext,
 	}, nil
@@ -7,20 +7,25 @@ import (
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/plgd-dev/device/schema/interfaces"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	pkgJwt "github.com/plgd-dev/hub/pkg/security/jwt"
 	"github.com/plgd-dev/hub/pkg/strings"
 	pkgTime "github.com/plgd-dev/hub/pkg/time"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/publisher"
 	natsTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/test"
+	cqrsEventStore "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
 	mongodb "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+	raEvents "github.com/plgd-dev/hub/resource-aggregate/events"
 	"github.com/plgd-dev/hub/resource-aggregate/service"
 	raTest "github.com/plgd-dev/hub/resource-aggregate/test"
 	"github.com/plgd-dev/hub/test/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -118,7 +123,7 @@ func TestRequestHandler_PublishResource(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
@@ -228,7 +233,7 @@ func TestRequestHandler_UnpublishResource(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 
 	pubReq := testMakePublishResourceRequest(deviceID, []string{href})
 	_, err = requestHandler.PublishResourceLinks(ctx, pubReq)
@@ -308,7 +313,7 @@ func TestRequestHandler_NotifyResourceChanged(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
 			response, err := requestHandler.NotifyResourceChanged(ctx, tt.args.request)
@@ -398,7 +403,7 @@ func TestRequestHandler_UpdateResourceContent(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
 			if tt.args.request.GetResourceId().GetDeviceId() != "" && tt.args.request.GetResourceId().GetHref() != "" {
@@ -486,7 +491,7 @@ func TestRequestHandler_ConfirmResourceUpdate(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	_, err = requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, resID, 0))
 	require.NoError(t, err)
 	_, err = requestHandler.UpdateResource(ctx, testMakeUpdateResourceRequest(deviceID, resID, "", correlationID, time.Hour))
@@ -571,7 +576,7 @@ func TestRequestHandler_RetrieveResource(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
 			if tt.args.request.GetResourceId().GetDeviceId() != "" && tt.args.request.GetResourceId().GetHref() != "" {
@@ -659,7 +664,7 @@ func TestRequestHandler_ConfirmResourceRetrieve(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	_, err = requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, resID, 0))
 	require.NoError(t, err)
 	_, err = requestHandler.RetrieveResource(ctx, testMakeRetrieveResourceRequest(deviceID, resID, correlationID, time.Hour))
@@ -747,7 +752,7 @@ func TestRequestHandler_DeleteResource(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
 			if tt.args.request.GetResourceId().GetDeviceId() != "" && tt.args.request.GetResourceId().GetHref() != "" {
@@ -835,7 +840,7 @@ func TestRequestHandler_ConfirmResourceDelete(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	_, err = requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, resID, 0))
 	require.NoError(t, err)
 	_, err = requestHandler.DeleteResource(ctx, testMakeDeleteResourceRequest(deviceID, resID, correlationID, time.Hour))
@@ -923,7 +928,7 @@ func TestRequestHandler_CreateResource(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
 			if tt.args.request.GetResourceId().GetDeviceId() != "" && tt.args.request.GetResourceId().GetHref() != "" {
@@ -1011,7 +1016,7 @@ func TestRequestHandler_ConfirmResourceCreate(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 	_, err = requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, resID, 0))
 	require.NoError(t, err)
 	_, err = requestHandler.CreateResource(ctx, testMakeCreateResourceRequest(deviceID, resID, correlationID, time.Hour))
@@ -1034,14 +1039,320 @@ func TestRequestHandler_ConfirmResourceCreate(t *testing.T) {
 	}
 }
 
-func mockGetOwnerDevices(ctx context.Context, owner string, deviceIDs []string) ([]string, error) {
+func mockGetOwnerDevices(ctx context.Context, owner string, deviceIDs []string, role pbIS.Role) (map[string]string, error) {
 	ownedDevices, code, err := testListDevicesOfUserFunc(ctx, "0", owner)
 	if err != nil {
 		return nil, status.Errorf(code, "%v", err)
 	}
 	getAllDevices := len(deviceIDs) == 0
-	if getAllDevices {
-		return ownedDevices, nil
+	if !getAllDevices {
+		ownedDevices = strings.Intersection(ownedDevices, deviceIDs)
 	}
-	return strings.Intersection(ownedDevices, deviceIDs), nil
+	owners := make(map[string]string, len(ownedDevices))
+	for _, deviceID := range ownedDevices {
+		owners[deviceID] = owner
+	}
+	return owners, nil
+}
+
+// mockGetDevicesOwnersWithRoles shares the devices of testDeviceOwner with the users by their roles.
+func mockGetDevicesOwnersWithRoles(roles map[string]pbIS.Role) func(ctx context.Context, userID string, deviceIDs []string, role pbIS.Role) (map[string]string, error) {
+	return func(ctx context.Context, userID string, deviceIDs []string, role pbIS.Role) (map[string]string, error) {
+		owners := make(map[string]string, len(deviceIDs))
+		userRole, ok := roles[userID]
+		if userID != testDeviceOwner && (!ok || userRole < role) {
+			return owners, nil
+		}
+		for _, deviceID := range deviceIDs {
+			owners[deviceID] = testDeviceOwner
+		}
+		return owners, nil
+	}
+}
+
+const testDeviceOwner = "owner"
+
+func TestRequestHandlerRoles(t *testing.T) {
+	const deviceID = "dev0"
+	const href = "/res0"
+	ctxWithUser := func(userID string) context.Context {
+		return kitNetGrpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+			"sub": userID,
+		}))
+	}
+	owner := ctxWithUser(testDeviceOwner)
+	operator := ctxWithUser("operator")
+	viewer := ctxWithUser("viewer")
+	groupOwner := ctxWithUser("groupOwner")
+
+	cfg := raTest.MakeConfig(t)
+	logger, err := log.NewLogger(cfg.Log)
+	require.NoError(t, err)
+	ctx := context.Background()
+	eventstore, err := mongodb.New(ctx, cfg.Clients.Eventstore.Connection.MongoDB, logger, mongodb.WithUnmarshaler(utils.Unmarshal), mongodb.WithMarshaler(utils.Marshal))
+	require.NoError(t, err)
+	defer func() {
+		err := eventstore.Close(ctx)
+		assert.NoError(t, err)
+	}()
+	err = eventstore.Clear(ctx)
+	require.NoError(t, err)
+	naClient, publisher, err := natsTest.NewClientAndPublisher(cfg.Clients.Eventbus.NATS, logger, publisher.WithMarshaler(utils.Marshal))
+	require.NoError(t, err)
+	defer func() {
+		publisher.Close()
+		naClient.Close()
+	}()
+
+	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetDevicesOwnersWithRoles(map[string]pbIS.Role{
+		"operator":   pbIS.Role_OPERATOR,
+		"viewer":     pbIS.Role_VIEWER,
+		"groupOwner": pbIS.Role_OWNER,
+	}), mockTransferDevices)
+
+	online := commands.ConnectionStatus_ONLINE
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		send     func(ctx context.Context) error
+		wantCode codes.Code
+	}{
+		{
+			name: "owner publishes resource links",
+			ctx:  owner,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.PublishResourceLinks(ctx, testMakePublishResourceRequest(deviceID, []string{href}))
+				return err
+			},
+		},
+		{
+			name: "operator cannot publish resource links",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.PublishResourceLinks(ctx, testMakePublishResourceRequest(deviceID, []string{href}))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "group owner cannot unpublish resource links",
+			ctx:  groupOwner,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UnpublishResourceLinks(ctx, testMakeUnpublishResourceRequest(deviceID, []string{href}))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "owner notifies resource changed",
+			ctx:  owner,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, href, 1))
+				return err
+			},
+		},
+		{
+			name: "operator cannot notify resource changed",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, href, 2))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "viewer cannot update resource",
+			ctx:  viewer,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UpdateResource(ctx, testMakeUpdateResourceRequest(deviceID, href, "", "viewer", time.Hour))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "operator updates resource",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UpdateResource(ctx, testMakeUpdateResourceRequest(deviceID, href, "", "operator", time.Hour))
+				return err
+			},
+		},
+		{
+			name: "operator cannot confirm resource update",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.ConfirmResourceUpdate(ctx, testMakeConfirmResourceUpdateRequest(deviceID, href, "operator"))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "owner confirms resource update",
+			ctx:  owner,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.ConfirmResourceUpdate(ctx, testMakeConfirmResourceUpdateRequest(deviceID, href, "operator"))
+				return err
+			},
+		},
+		{
+			name: "operator cannot update connection status",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UpdateDeviceMetadata(ctx, testMakeUpdateDeviceMetadataRequest(deviceID, "", &online, commands.ShadowSynchronization_UNSET, time.Hour))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "owner updates connection status",
+			ctx:  owner,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UpdateDeviceMetadata(ctx, testMakeUpdateDeviceMetadataRequest(deviceID, "", &online, commands.ShadowSynchronization_UNSET, time.Hour))
+				return err
+			},
+		},
+		{
+			name: "viewer cannot update shadow synchronization",
+			ctx:  viewer,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UpdateDeviceMetadata(ctx, testMakeUpdateDeviceMetadataRequest(deviceID, "viewer", nil, commands.ShadowSynchronization_DISABLED, time.Hour))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "operator updates shadow synchronization",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.UpdateDeviceMetadata(ctx, testMakeUpdateDeviceMetadataRequest(deviceID, "operator", nil, commands.ShadowSynchronization_DISABLED, time.Hour))
+				return err
+			},
+		},
+		{
+			name: "operator cannot confirm device metadata update",
+			ctx:  operator,
+			send: func(ctx context.Context) error {
+				_, err := requestHandler.ConfirmDeviceMetadataUpdate(ctx, testMakeConfirmDeviceMetadataUpdateRequest(deviceID, commands.ShadowSynchronization_DISABLED))
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.send(tt.ctx)
+			if tt.wantCode == codes.OK {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, status.Convert(err).Code())
+		})
+	}
+}
+
+func mockTransferDevices(ctx context.Context, deviceIDs []string, newOwner string) ([]string, error) {
+	return deviceIDs, nil
+}
+
+// auditContextHandler collects the users of the stored pending commands by their correlation IDs.
+type auditContextHandler struct {
+	users map[string]string
+}
+
+func (h *auditContextHandler) Handle(ctx context.Context, iter cqrsEventStore.Iter) error {
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			return iter.Err()
+		}
+		var ev interface {
+			GetAuditContext() *commands.AuditContext
+		}
+		switch eu.EventType() {
+		case (&raEvents.ResourceUpdatePending{}).EventType():
+			ev = &raEvents.ResourceUpdatePending{}
+		case (&raEvents.ResourceRetrievePending{}).EventType():
+			ev = &raEvents.ResourceRetrievePending{}
+		case (&raEvents.ResourceCreatePending{}).EventType():
+			ev = &raEvents.ResourceCreatePending{}
+		case (&raEvents.ResourceDeletePending{}).EventType():
+			ev = &raEvents.ResourceDeletePending{}
+		default:
+			continue
+		}
+		if err := eu.Unmarshal(ev); err != nil {
+			return err
+		}
+		h.users[ev.GetAuditContext().GetCorrelationId()] = ev.GetAuditContext().GetUserId()
+	}
+}
+
+func TestRequestHandlerServiceAccountAudit(t *testing.T) {
+	const deviceID = "dev0"
+	const href = "/res0"
+	const user0 = "user0"
+	const serviceAccountID = "sa0"
+	user := kitNetGrpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": user0,
+	}))
+	serviceAccount := kitNetGrpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+		"sub":                        user0,
+		pkgJwt.ClaimServiceAccountID: serviceAccountID,
+	}))
+
+	cfg := raTest.MakeConfig(t)
+	logger, err := log.NewLogger(cfg.Log)
+	require.NoError(t, err)
+	ctx := context.Background()
+	eventstore, err := mongodb.New(ctx, cfg.Clients.Eventstore.Connection.MongoDB, logger, mongodb.WithUnmarshaler(utils.Unmarshal), mongodb.WithMarshaler(utils.Marshal))
+	require.NoError(t, err)
+	defer func() {
+		err := eventstore.Close(ctx)
+		assert.NoError(t, err)
+	}()
+	err = eventstore.Clear(ctx)
+	require.NoError(t, err)
+	naClient, publisher, err := natsTest.NewClientAndPublisher(cfg.Clients.Eventbus.NATS, logger, publisher.WithMarshaler(utils.Marshal))
+	require.NoError(t, err)
+	defer func() {
+		publisher.Close()
+		naClient.Close()
+	}()
+
+	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
+	_, err = requestHandler.NotifyResourceChanged(user, testMakeNotifyResourceChangedRequest(deviceID, href, 0))
+	require.NoError(t, err)
+
+	// commands of the service account are audited by the ID of the service account, the commands of the owner by the owner
+	updated, err := requestHandler.UpdateResource(serviceAccount, testMakeUpdateResourceRequest(deviceID, href, "", "update", time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, serviceAccountID, updated.GetAuditContext().GetUserId())
+	retrieved, err := requestHandler.RetrieveResource(serviceAccount, testMakeRetrieveResourceRequest(deviceID, href, "retrieve", time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, serviceAccountID, retrieved.GetAuditContext().GetUserId())
+	created, err := requestHandler.CreateResource(serviceAccount, testMakeCreateResourceRequest(deviceID, href, "create", time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, serviceAccountID, created.GetAuditContext().GetUserId())
+	deleted, err := requestHandler.DeleteResource(serviceAccount, testMakeDeleteResourceRequest(deviceID, href, "delete", time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, serviceAccountID, deleted.GetAuditContext().GetUserId())
+	updatedByUser, err := requestHandler.UpdateResource(user, testMakeUpdateResourceRequest(deviceID, href, "", "updateByUser", time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, user0, updatedByUser.GetAuditContext().GetUserId())
+
+	// the stored events are audited by the same users as the responses
+	h := &auditContextHandler{users: make(map[string]string)}
+	err = eventstore.LoadFromVersion(ctx, []cqrsEventStore.VersionQuery{{
+		GroupID:     deviceID,
+		AggregateID: commands.NewResourceID(deviceID, href).ToUUID(),
+	}}, h)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"update":       serviceAccountID,
+		"retrieve":     serviceAccountID,
+		"create":       serviceAccountID,
+		"delete":       serviceAccountID,
+		"updateByUser": user0,
+	}, h.users)
 }
@@ -11,15 +11,17 @@ import (
 	clientIS "github.com/plgd-dev/hub/identity-store/client"
 	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	cqrsEventBus "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	eventbusConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/config"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
-	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/publisher"
 	cqrsEventStore "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	eventstoreConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/config"
 	cqrsMaintenance "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/maintenance"
-	mongodb "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
 )
 
@@ -36,47 +38,40 @@ type Service struct {
 }
 
 func New(ctx context.Context, config Config, logger log.Logger) (*Service, error) {
-	eventstore, err := mongodb.New(ctx, config.Clients.Eventstore.Connection.MongoDB, logger, mongodb.WithUnmarshaler(utils.Unmarshal), mongodb.WithMarshaler(utils.Marshal))
+	eventstore, err := eventstoreConfig.New(ctx, config.Clients.Eventstore.Connection, logger, utils.Marshal, utils.Unmarshal)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create mongodb eventstore %w", err)
+		return nil, fmt.Errorf("cannot create eventstore %w", err)
 	}
 	closeEventStore := func() {
 		err := eventstore.Close(ctx)
 		if err != nil {
-			logger.Errorf("error occurs during closing of connection to mongodb: %w", err)
+			logger.Errorf("error occurs during closing of connection to eventstore: %w", err)
 		}
 	}
-	naClient, err := natsClient.New(config.Clients.Eventbus.NATS.Config, logger)
+	publisher, err := eventbusConfig.NewPublisher(config.Clients.Eventbus.PublisherConfig, logger, utils.Marshal)
 	if err != nil {
 		closeEventStore()
-		return nil, fmt.Errorf("cannot create nats client %w", err)
+		return nil, fmt.Errorf("cannot create eventbus publisher %w", err)
 	}
-	publisher, err := publisher.New(naClient.GetConn(), config.Clients.Eventbus.NATS.JetStream, publisher.WithMarshaler(utils.Marshal), publisher.WithFlusherTimeout(config.Clients.Eventbus.NATS.Config.FlusherTimeout))
-	if err != nil {
-		naClient.Close()
-		closeEventStore()
-		return nil, fmt.Errorf("cannot create nats publisher %w", err)
-	}
-	naClient.AddCloseFunc(publisher.Close)
 
 	service, err := NewService(ctx, config, logger, eventstore, publisher)
 	if err != nil {
-		naClient.Close()
+		publisher.Close()
 		closeEventStore()
-		return nil, fmt.Errorf("cannot create nats publisher %w", err)
+		return nil, fmt.Errorf("cannot create service %w", err)
 	}
 	service.AddCloseFunc(closeEventStore)
-	service.AddCloseFunc(naClient.Close)
+	service.AddCloseFunc(publisher.Close)
 
 	return service, nil
 }
 
-func newGrpcServer(ctx context.Context, config GRPCConfig, logger log.Logger) (*server.Server, error) {
+func newGrpcServer(ctx context.Context, config GRPCConfig, verifyToken server.VerifyTokenFunc, logger log.Logger) (*server.Server, error) {
 	validator, err := validator.New(ctx, config.Authorization.Config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create validator: %w", err)
 	}
-	authInterceptor := server.NewAuth(validator)
+	authInterceptor := server.NewAuth(validator, server.WithRequiredScopes(config.Authorization.RequiredScopes), server.WithTokenVerification(verifyToken))
 	opts, err := server.MakeDefaultOptions(authInterceptor, logger)
 	if err != nil {
 		validator.Close()
@@ -108,17 +103,33 @@ func newIdentityStoreClient(config IdentityStoreConfig, logger log.Logger) (pbIS
 
 // New creates new Server with provided store and publisher.
 func NewService(ctx context.Context, config Config, logger log.Logger, eventStore EventStore, publisher cqrsEventBus.Publisher) (*Service, error) {
-	grpcServer, err := newGrpcServer(ctx, config.APIs.GRPC, logger)
+	isClient, closeIsClient, err := newIdentityStoreClient(config.Clients.IdentityStore, logger)
 	if err != nil {
+		return nil, fmt.Errorf("cannot create identity-store client: %w", err)
+	}
+	// the tokens of the service accounts forwarded by the gateways are verified again, so revoked service accounts and rotated keys
+	// are rejected also for the clients of the resource-aggregate
+	serviceAccountTokens := clientIS.NewServiceAccountTokens(isClient, config.Clients.IdentityStore.ServiceAccountVerificationInterval)
+	grpcServer, err := newGrpcServer(ctx, config.APIs.GRPC, serviceAccountTokens.Verify, logger)
+	if err != nil {
+		closeIsClient()
 		return nil, err
 	}
+	grpcServer.AddCloseFunc(closeIsClient)
+
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		grpcServer.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	grpcServer.AddCloseFunc(metricsServer.Close)
 
-	isClient, closeIsClient, err := newIdentityStoreClient(config.Clients.IdentityStore, logger)
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "resource-aggregate", logger)
 	if err != nil {
 		grpcServer.Close()
-		return nil, fmt.Errorf("cannot create identity-store client: %w", err)
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
 	}
-	grpcServer.AddCloseFunc(closeIsClient)
+	grpcServer.AddCloseFunc(otelProvider.Close)
 
 	nats, err := natsClient.New(config.Clients.Eventbus.NATS.Config, logger)
 	if err != nil {
@@ -132,12 +143,17 @@ func NewService(ctx context.Context, config Config, logger log.Logger, eventStor
 	})
 	grpcServer.AddCloseFunc(ownerCache.Close)
 
-	requestHandler := NewRequestHandler(config, eventStore, publisher, func(ctx context.Context, owner string, deviceIDs []string) ([]string, error) {
-		getAllDevices := len(deviceIDs) == 0
-		if !getAllDevices {
-			return ownerCache.GetSelectedDevices(ctx, deviceIDs)
+	requestHandler := NewRequestHandler(config, eventStore, publisher, func(ctx context.Context, userID string, deviceIDs []string, role pbIS.Role) (map[string]string, error) {
+		return ownerCache.GetDevicesOwners(ctx, deviceIDs, role)
+	}, func(ctx context.Context, deviceIDs []string, newOwner string) ([]string, error) {
+		resp, err := isClient.TransferDevices(ctx, &pbIS.TransferDevicesRequest{
+			DeviceIds: deviceIDs,
+			NewOwner:  newOwner,
+		})
+		if err != nil {
+			return nil, err
 		}
-		return ownerCache.GetDevices(ctx)
+		return resp.GetDeviceIds(), nil
 	})
 	RegisterResourceAggregateServer(grpcServer.Server, requestHandler)
 
@@ -35,6 +35,7 @@ type ResourceAggregateClient interface {
 	CancelPendingMetadataUpdates(ctx context.Context, in *commands.CancelPendingMetadataUpdatesRequest, opts ...grpc.CallOption) (*commands.CancelPendingMetadataUpdatesResponse, error)
 	CancelPendingCommands(ctx context.Context, in *commands.CancelPendingCommandsRequest, opts ...grpc.CallOption) (*commands.CancelPendingCommandsResponse, error)
 	DeleteDevices(ctx context.Context, in *commands.DeleteDevicesRequest, opts ...grpc.CallOption) (*commands.DeleteDevicesResponse, error)
+	TransferDevices(ctx context.Context, in *commands.TransferDevicesRequest, opts ...grpc.CallOption) (*commands.TransferDevicesResponse, error)
 }
 
 type resourceAggregateClient struct {
@@ -189,6 +190,15 @@ func (c *resourceAggregateClient) DeleteDevices(ctx context.Context, in *command
 	return out, nil
 }
 
+func (c *resourceAggregateClient) TransferDevices(ctx context.Context, in *commands.TransferDevicesRequest, opts ...grpc.CallOption) (*commands.TransferDevicesResponse, error) {
+	out := new(commands.TransferDevicesResponse)
+	err := c.cc.Invoke(ctx, "/resourceaggregate.pb.ResourceAggregate/TransferDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResourceAggregateServer is the server API for ResourceAggregate service.
 // All implementations must embed UnimplementedResourceAggregateServer
 // for forward compatibility
@@ -209,6 +219,7 @@ type ResourceAggregateServer interface {
 	CancelPendingMetadataUpdates(context.Context, *commands.CancelPendingMetadataUpdatesRequest) (*commands.CancelPendingMetadataUpdatesResponse, error)
 	CancelPendingCommands(context.Context, *commands.CancelPendingCommandsRequest) (*commands.CancelPendingCommandsResponse, error)
 	DeleteDevices(context.Context, *commands.DeleteDevicesRequest) (*commands.DeleteDevicesResponse, error)
+	TransferDevices(context.Context, *commands.TransferDevicesRequest) (*commands.TransferDevicesResponse, error)
 	mustEmbedUnimplementedResourceAggregateServer()
 }
 
@@ -264,6 +275,9 @@ func (UnimplementedResourceAggregateServer) CancelPendingCommands(context.Contex
 func (UnimplementedResourceAggregateServer) DeleteDevices(context.Context, *commands.DeleteDevicesRequest) (*commands.DeleteDevicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevices not implemented")
 }
+func (UnimplementedResourceAggregateServer) TransferDevices(context.Context, *commands.TransferDevicesRequest) (*commands.TransferDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferDevices not implemented")
+}
 func (UnimplementedResourceAggregateServer) mustEmbedUnimplementedResourceAggregateServer() {}
 
 // UnsafeResourceAggregateServer may be embedded to opt out of forward compatibility for this service.
@@ -565,6 +579,24 @@ func _ResourceAggregate_DeleteDevices_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceAggregate_TransferDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(commands.TransferDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceAggregateServer).TransferDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resourceaggregate.pb.ResourceAggregate/TransferDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceAggregateServer).TransferDevices(ctx, req.(*commands.TransferDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ResourceAggregate_ServiceDesc is the grpc.ServiceDesc for ResourceAggregate service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -636,6 +668,10 @@ var ResourceAggregate_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDevices",
 			Handler:    _ResourceAggregate_DeleteDevices_Handler,
 		},
+		{
+			MethodName: "TransferDevices",
+			Handler:    _ResourceAggregate_TransferDevices_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/plgd-dev/hub/resource-aggregate/pb/service.proto",
@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/plgd-dev/device/schema/platform"
 	pbIS "github.com/plgd-dev/hub/identity-store/pb"
@@ -14,7 +15,10 @@ import (
 	"github.com/plgd-dev/hub/resource-aggregate/test"
 	testCfg "github.com/plgd-dev/hub/test/config"
 	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	hubTestService "github.com/plgd-dev/hub/test/service"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestPublishUnpublish(t *testing.T) {
@@ -68,3 +72,161 @@ func TestPublishUnpublish(t *testing.T) {
 	_, err = raClient.UnpublishResourceLinks(ctx, unpubReq)
 	require.NoError(t, err)
 }
+
+func TestSharedDeviceRoles(t *testing.T) {
+	config := test.MakeConfig(t)
+	config.APIs.GRPC.Addr = "localhost:9888"
+
+	oauthShutdown := oauthTest.SetUp(t)
+	defer oauthShutdown()
+
+	idShutdown := idService.SetUp(t)
+	defer idShutdown()
+
+	raShutdown := test.New(t, config)
+	defer raShutdown()
+
+	ctx := kitNetGrpc.CtxWithToken(context.Background(), oauthTest.GetDefaultServiceToken(t))
+	viewerCtx := kitNetGrpc.CtxWithToken(context.Background(), oauthTest.GetDefaultServiceTokenForOwner(t, "viewer"))
+	operatorCtx := kitNetGrpc.CtxWithToken(context.Background(), oauthTest.GetDefaultServiceTokenForOwner(t, "operator"))
+
+	idConn, err := client.New(testCfg.MakeGrpcClientConfig(config.Clients.IdentityStore.Connection.Addr), log.Get())
+	require.NoError(t, err)
+	defer func() {
+		_ = idConn.Close()
+	}()
+	idClient := pbIS.NewIdentityStoreClient(idConn.GRPC())
+
+	raConn, err := client.New(testCfg.MakeGrpcClientConfig(config.APIs.GRPC.Addr), log.Get())
+	require.NoError(t, err)
+	defer func() {
+		_ = raConn.Close()
+	}()
+	raClient := service.NewResourceAggregateClient(raConn.GRPC())
+
+	deviceId := "dev0"
+	href := platform.ResourceURI
+	_, err = idClient.AddDevice(ctx, &pbIS.AddDeviceRequest{
+		DeviceId: deviceId,
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err = idClient.DeleteDevices(ctx, &pbIS.DeleteDevicesRequest{
+			DeviceIds: []string{deviceId},
+		})
+		require.NoError(t, err)
+	}()
+	group, err := idClient.CreateGroup(ctx, &pbIS.CreateGroupRequest{
+		Name: "group",
+		Members: []*pbIS.GroupMember{
+			{UserId: "viewer", Role: pbIS.Role_VIEWER},
+			{UserId: "operator", Role: pbIS.Role_OPERATOR},
+		},
+	})
+	require.NoError(t, err)
+	_, err = idClient.ShareDevices(ctx, &pbIS.ShareDevicesRequest{
+		GroupId:   group.GetId(),
+		DeviceIds: []string{deviceId},
+	})
+	require.NoError(t, err)
+
+	// only the owner sends the commands of the device
+	_, err = raClient.PublishResourceLinks(ctx, testMakePublishResourceRequest(deviceId, []string{href}))
+	require.NoError(t, err)
+	_, err = raClient.PublishResourceLinks(operatorCtx, testMakePublishResourceRequest(deviceId, []string{href}))
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+	_, err = raClient.NotifyResourceChanged(operatorCtx, testMakeNotifyResourceChangedRequest(deviceId, href, 1))
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+
+	// the operator sends commands to the device, the viewer doesn't
+	_, err = raClient.UpdateResource(viewerCtx, testMakeUpdateResourceRequest(deviceId, href, "", "viewer", time.Hour))
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+	_, err = raClient.UpdateResource(operatorCtx, testMakeUpdateResourceRequest(deviceId, href, "", "operator", time.Hour))
+	require.NoError(t, err)
+	_, err = raClient.ConfirmResourceUpdate(operatorCtx, testMakeConfirmResourceUpdateRequest(deviceId, href, "operator"))
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+	_, err = raClient.ConfirmResourceUpdate(ctx, testMakeConfirmResourceUpdateRequest(deviceId, href, "operator"))
+	require.NoError(t, err)
+}
+
+func TestServiceAccountTokenVerification(t *testing.T) {
+	config := test.MakeConfig(t)
+	config.APIs.GRPC.Addr = "localhost:9888"
+	config.APIs.GRPC.Authorization.Authorities = append(config.APIs.GRPC.Authorization.Authorities, hubTestService.ServiceAccountsAuthority())
+	config.Clients.IdentityStore.ServiceAccountVerificationInterval = 0
+
+	oauthShutdown := oauthTest.SetUp(t)
+	defer oauthShutdown()
+
+	idCfg := idService.MakeConfig(t)
+	idCfg.APIs.GRPC.Authorization.Authorities = append(idCfg.APIs.GRPC.Authorization.Authorities, hubTestService.ServiceAccountsAuthority())
+	idCfg.ServiceAccounts.Issuer = hubTestService.ServiceAccountsIssuer
+	idCfg.ServiceAccounts.KeyFile = testCfg.KEY_FILE
+	idCfg.ServiceAccounts.TokenExpiration = time.Hour
+	idShutdown := idService.New(t, idCfg)
+	defer idShutdown()
+
+	raShutdown := test.New(t, config)
+	defer raShutdown()
+
+	ctx := kitNetGrpc.CtxWithToken(context.Background(), oauthTest.GetDefaultServiceToken(t))
+
+	idConn, err := client.New(testCfg.MakeGrpcClientConfig(config.Clients.IdentityStore.Connection.Addr), log.Get())
+	require.NoError(t, err)
+	defer func() {
+		_ = idConn.Close()
+	}()
+	idClient := pbIS.NewIdentityStoreClient(idConn.GRPC())
+
+	raConn, err := client.New(testCfg.MakeGrpcClientConfig(config.APIs.GRPC.Addr), log.Get())
+	require.NoError(t, err)
+	defer func() {
+		_ = raConn.Close()
+	}()
+	raClient := service.NewResourceAggregateClient(raConn.GRPC())
+
+	deviceId := "dev0"
+	_, err = idClient.AddDevice(ctx, &pbIS.AddDeviceRequest{
+		DeviceId: deviceId,
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err = idClient.DeleteDevices(ctx, &pbIS.DeleteDevicesRequest{
+			DeviceIds: []string{deviceId},
+		})
+		require.NoError(t, err)
+	}()
+
+	created, err := idClient.CreateServiceAccount(ctx, &pbIS.CreateServiceAccountRequest{
+		Name:   "writer",
+		Scopes: []string{"w:deviceinformation:*"},
+	})
+	require.NoError(t, err)
+	getToken := func(key string) string {
+		token, err := idClient.GetServiceAccountToken(context.Background(), &pbIS.GetServiceAccountTokenRequest{Key: key})
+		require.NoError(t, err)
+		return token.GetAccessToken()
+	}
+	publish := func(token string) codes.Code {
+		_, err := raClient.PublishResourceLinks(kitNetGrpc.CtxWithToken(context.Background(), token), testMakePublishResourceRequest(deviceId, []string{platform.ResourceURI}))
+		return status.Code(err)
+	}
+	token := getToken(created.GetKey())
+	require.Equal(t, codes.OK, publish(token))
+
+	// the token issued for the previous key is rejected after the rotation
+	rotated, err := idClient.RotateServiceAccountKey(ctx, &pbIS.RotateServiceAccountKeyRequest{
+		ServiceAccountId: created.GetServiceAccount().GetId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, codes.Unauthenticated, publish(token))
+	rotatedToken := getToken(rotated.GetKey())
+	require.Equal(t, codes.OK, publish(rotatedToken))
+
+	// the token of the revoked service account is rejected
+	_, err = idClient.RevokeServiceAccounts(ctx, &pbIS.RevokeServiceAccountsRequest{
+		ServiceAccountIds: []string{created.GetServiceAccount().GetId()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, codes.Unauthenticated, publish(rotatedToken))
+}
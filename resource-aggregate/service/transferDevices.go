@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	cqrsAggregate "github.com/plgd-dev/hub/resource-aggregate/cqrs/aggregate"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/plgd-dev/kit/v2/strings"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transferDevicesFunc reassigns the devices owned by the user to the new owner and returns the transferred devices.
+type transferDevicesFunc = func(ctx context.Context, deviceIDs []string, newOwner string) ([]string, error)
+
+// snapshotModel projects events of an aggregate and tracks the version of the last projected event.
+type snapshotModel struct {
+	cqrsAggregate.AggregateModel
+	version uint64
+}
+
+type snapshotModelIterator struct {
+	iter  eventstore.Iter
+	model *snapshotModel
+}
+
+func (i *snapshotModelIterator) Next(ctx context.Context) (eventstore.EventUnmarshaler, bool) {
+	ev, ok := i.iter.Next(ctx)
+	if ok {
+		i.model.version = ev.Version()
+	}
+	return ev, ok
+}
+
+func (i *snapshotModelIterator) Err() error {
+	return i.iter.Err()
+}
+
+func (m *snapshotModel) Handle(ctx context.Context, iter eventstore.Iter) error {
+	return m.AggregateModel.Handle(ctx, &snapshotModelIterator{iter: iter, model: m})
+}
+
+func newSnapshotModel(ctx context.Context, groupID, aggregateID string) (eventstore.Model, error) {
+	factoryModel := ResourceStateFactoryModel
+	switch aggregateID {
+	case commands.MakeLinksResourceUUID(groupID):
+		factoryModel = ResourceLinksFactoryModel
+	case commands.MakeStatusResourceUUID(groupID):
+		factoryModel = DeviceMetadataFactoryModel
+	}
+	model, err := factoryModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotModel{AggregateModel: model}, nil
+}
+
+// republishDevice publishes snapshots of all aggregates of the device under the subjects of the owner.
+func (r RequestHandler) republishDevice(ctx context.Context, owner, deviceID string) error {
+	projection := eventstore.NewProjection(r.eventstore, newSnapshotModel, nil)
+	queries := []eventstore.SnapshotQuery{{GroupID: deviceID}}
+	if err := projection.Project(ctx, queries); err != nil {
+		return fmt.Errorf("cannot load aggregates: %w", err)
+	}
+	for _, m := range projection.Models(queries) {
+		model := m.(*snapshotModel)
+		snapshot, ok := model.TakeSnapshot(model.version)
+		if !ok {
+			continue
+		}
+		if err := PublishEvents(r.publisher, owner, deviceID, snapshot.AggregateID(), []eventbus.Event{snapshot}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transfer devices owned by the user to the new owner.
+//
+// The ownership is reassigned by the identity-store, afterwards the state of the transferred devices is
+// published under the subjects of the new owner, so the subscribers of the new owner can
+// reconstruct it without waiting for new events from the devices.
+//
+// Function returns the list of transferred devices, devices that are not owned by the user are skipped.
+func (r RequestHandler) TransferDevices(ctx context.Context, request *commands.TransferDevicesRequest) (*commands.TransferDevicesResponse, error) {
+	deviceIDs := make(strings.Set)
+	deviceIDs.Add(request.GetDeviceIds()...)
+	delete(deviceIDs, "")
+	if len(deviceIDs) == 0 {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot transfer devices: invalid DeviceIds"))
+	}
+	if request.GetNewOwner() == "" {
+		return nil, log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot transfer devices: invalid NewOwner"))
+	}
+
+	owner, ownedDevices, err := r.getOwnedDevices(ctx, deviceIDs.ToSlice())
+	if err != nil {
+		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
+	}
+	if len(ownedDevices) == 0 {
+		return &commands.TransferDevicesResponse{
+			AuditContext: commands.NewAuditContext(owner, ""),
+		}, nil
+	}
+
+	transferredDevices, err := r.transferDevicesFunc(ctx, ownedDevices, request.GetNewOwner())
+	if err != nil {
+		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot transfer devices: %v", err))
+	}
+	for _, deviceID := range transferredDevices {
+		if err := r.republishDevice(ctx, request.GetNewOwner(), deviceID); err != nil {
+			log.Errorf("cannot republish device('%v') to owner('%v'): %v", deviceID, request.GetNewOwner(), err)
+		}
+	}
+
+	return &commands.TransferDevicesResponse{
+		DeviceIds:    transferredDevices,
+		AuditContext: commands.NewAuditContext(owner, ""),
+	}, nil
+}
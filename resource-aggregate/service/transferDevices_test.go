@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nats-io/nats.go"
+	isEvents "github.com/plgd-dev/hub/identity-store/events"
+	"github.com/plgd-dev/hub/pkg/log"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/publisher"
+	natsTest "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/test"
+	mongodb "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+	raEvents "github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-aggregate/service"
+	raTest "github.com/plgd-dev/hub/resource-aggregate/test"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestHandler_TransferDevices(t *testing.T) {
+	const deviceID = "dev0"
+	const user0 = "user0"
+	const user1 = "user1"
+	const resID = "/oic/p"
+
+	ctx := kitNetGrpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+		"sub": user0,
+	}))
+	cfg := raTest.MakeConfig(t)
+	logger, err := log.NewLogger(cfg.Log)
+	require.NoError(t, err)
+	eventstore, err := mongodb.New(ctx, cfg.Clients.Eventstore.Connection.MongoDB, logger, mongodb.WithUnmarshaler(utils.Unmarshal), mongodb.WithMarshaler(utils.Marshal))
+	require.NoError(t, err)
+	defer func() {
+		err := eventstore.Clear(ctx)
+		require.NoError(t, err)
+		_ = eventstore.Close(ctx)
+	}()
+	naClient, publisher, err := natsTest.NewClientAndPublisher(cfg.Clients.Eventbus.NATS, logger, publisher.WithMarshaler(utils.Marshal))
+	require.NoError(t, err)
+	defer func() {
+		publisher.Close()
+		naClient.Close()
+	}()
+
+	requestHandler := service.NewRequestHandler(cfg, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
+	_, err = requestHandler.PublishResourceLinks(ctx, testMakePublishResourceRequest(deviceID, []string{resID}))
+	require.NoError(t, err)
+	_, err = requestHandler.NotifyResourceChanged(ctx, testMakeNotifyResourceChangedRequest(deviceID, resID, 0))
+	require.NoError(t, err)
+
+	republished := make(chan *nats.Msg, 10)
+	sub, err := naClient.GetConn().ChanSubscribe(isEvents.PlgdOwners+"."+user1+".>", republished)
+	require.NoError(t, err)
+	defer func() {
+		_ = sub.Unsubscribe()
+	}()
+
+	type args struct {
+		req   *commands.TransferDevicesRequest
+		owner string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      *commands.TransferDevicesResponse
+		wantError bool
+	}{
+		{
+			name: "unauthorized user",
+			args: args{
+				req: &commands.TransferDevicesRequest{
+					DeviceIds: []string{deviceID},
+					NewOwner:  user1,
+				},
+				owner: testUnauthorizedUser,
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid deviceIds",
+			args: args{
+				req: &commands.TransferDevicesRequest{
+					NewOwner: user1,
+				},
+				owner: user0,
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid new owner",
+			args: args{
+				req: &commands.TransferDevicesRequest{
+					DeviceIds: []string{deviceID},
+				},
+				owner: user0,
+			},
+			wantError: true,
+		},
+		{
+			name: "non-owned device",
+			args: args{
+				req: &commands.TransferDevicesRequest{
+					DeviceIds: []string{"testDev0"},
+					NewOwner:  user1,
+				},
+				owner: user0,
+			},
+			want: &commands.TransferDevicesResponse{
+				AuditContext: &commands.AuditContext{
+					UserId: user0,
+				},
+			},
+		},
+		{
+			name: "owned and not-owned devices",
+			args: args{
+				req: &commands.TransferDevicesRequest{
+					DeviceIds: []string{"testDev0", deviceID},
+					NewOwner:  user1,
+				},
+				owner: user0,
+			},
+			want: &commands.TransferDevicesResponse{
+				DeviceIds: []string{deviceID},
+				AuditContext: &commands.AuditContext{
+					UserId: user0,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferDevicesCtx := kitNetGrpc.CtxWithIncomingToken(context.Background(), config.CreateJwtToken(t, jwt.MapClaims{
+				"sub": tt.args.owner,
+			}))
+			response, err := requestHandler.TransferDevices(transferDevicesCtx, tt.args.req)
+			if tt.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.DeviceIds, response.DeviceIds)
+			require.Equal(t, tt.want.AuditContext, response.AuditContext)
+		})
+	}
+
+	// snapshots of the resource links and of the resource are published under the new owner
+	want := map[string]bool{
+		raEvents.NewResourceLinksSnapshotTaken().EventType(): true,
+		raEvents.NewResourceStateSnapshotTaken().EventType(): true,
+	}
+	got := make(map[string]bool)
+	for len(got) < len(want) {
+		select {
+		case msg := <-republished:
+			subject := strings.Split(msg.Subject, ".")
+			got[subject[len(subject)-1]] = true
+		case <-time.After(time.Second * 5):
+			require.FailNow(t, "republished events were not received")
+		}
+	}
+	require.Equal(t, want, got)
+}
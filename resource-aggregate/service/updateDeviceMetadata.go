@@ -56,7 +56,12 @@ func checkTimeToLiveForDefault(defaultTimeToLive time.Duration, reqTimeToLive in
 }
 
 func (r RequestHandler) UpdateDeviceMetadata(ctx context.Context, request *commands.UpdateDeviceMetadataRequest) (*commands.UpdateDeviceMetadataResponse, error) {
-	owner, err := r.validateAccessToDevice(ctx, request.GetDeviceId())
+	validate := r.validateAccessToDevice
+	if request.GetStatus() != nil {
+		// the connection status is updated on behalf of the device
+		validate = r.validateDeviceAccessToDevice
+	}
+	owner, userID, err := validate(ctx, request.GetDeviceId())
 	if err != nil {
 		return nil, log.LogAndReturnError(kitNetGrpc.ForwardErrorf(codes.Internal, "cannot validate user access: %v", err))
 	}
@@ -87,7 +92,7 @@ func (r RequestHandler) UpdateDeviceMetadata(ctx context.Context, request *comma
 	}
 
 	return &commands.UpdateDeviceMetadataResponse{
-		AuditContext: commands.NewAuditContext(owner, ""),
+		AuditContext: commands.NewAuditContext(userID, ""),
 		ValidUntil:   validUntil,
 	}, nil
 }
@@ -230,7 +230,7 @@ func TestRequestHandler_UpdateDeviceMetadata(t *testing.T) {
 		naClient.Close()
 	}()
 
-	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices)
+	requestHandler := service.NewRequestHandler(config, eventstore, publisher, mockGetOwnerDevices, mockTransferDevices)
 
 	for _, tt := range test {
 		tfunc := func(t *testing.T) {
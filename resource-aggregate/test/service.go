@@ -19,6 +19,7 @@ func MakeConfig(t *testing.T) service.Config {
 	cfg.APIs.GRPC.Config = config.MakeGrpcServerConfig(config.RESOURCE_AGGREGATE_HOST)
 
 	cfg.Clients.IdentityStore.Connection = config.MakeGrpcClientConfig(config.IDENTITY_STORE_HOST)
+	cfg.Clients.IdentityStore.ServiceAccountVerificationInterval = time.Second * 10
 
 	cfg.Clients.Eventbus.NATS = config.MakePublisherConfig()
 
@@ -7,8 +7,11 @@ import (
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/pkg/config"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
+	"github.com/plgd-dev/hub/pkg/mongodb"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	pkgTime "github.com/plgd-dev/hub/pkg/time"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 	eventstoreConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/config"
@@ -36,13 +39,17 @@ func (c *Config) Validate() error {
 
 // Config represent application configuration
 type APIsConfig struct {
-	GRPC GRPCConfig `yaml:"grpc" json:"grpc"`
+	GRPC    GRPCConfig     `yaml:"grpc" json:"grpc"`
+	Metrics metrics.Config `yaml:"metrics" json:"metrics"`
 }
 
 func (c *APIsConfig) Validate() error {
 	if err := c.GRPC.Validate(); err != nil {
 		return fmt.Errorf("grpc.%w", err)
 	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics.%w", err)
+	}
 	return nil
 }
 
@@ -59,9 +66,11 @@ func (c *GRPCConfig) Validate() error {
 }
 
 type ClientsConfig struct {
-	Eventbus      EventBusConfig      `yaml:"eventBus" json:"eventBus"`
-	Eventstore    EventStoreConfig    `yaml:"eventStore" json:"eventStore"`
-	IdentityStore IdentityStoreConfig `yaml:"identityStore" json:"identityStore"`
+	Eventbus               EventBusConfig       `yaml:"eventBus" json:"eventBus"`
+	Eventstore             EventStoreConfig     `yaml:"eventStore" json:"eventStore"`
+	IdentityStore          IdentityStoreConfig  `yaml:"identityStore" json:"identityStore"`
+	ReadModel              ReadModelConfig      `yaml:"readModel" json:"readModel"`
+	OpenTelemetryCollector opentelemetry.Config `yaml:"openTelemetryCollector" json:"openTelemetryCollector"`
 }
 
 func (c *ClientsConfig) Validate() error {
@@ -74,6 +83,12 @@ func (c *ClientsConfig) Validate() error {
 	if err := c.Eventstore.Validate(); err != nil {
 		return fmt.Errorf("eventstore.%w", err)
 	}
+	if err := c.ReadModel.Validate(); err != nil {
+		return fmt.Errorf("readModel.%w", err)
+	}
+	if err := c.OpenTelemetryCollector.Validate(); err != nil {
+		return fmt.Errorf("openTelemetryCollector.%w", err)
+	}
 	return nil
 }
 
@@ -104,6 +119,29 @@ func (c *EventStoreConfig) Validate() error {
 	return c.Connection.Validate()
 }
 
+// ReadModelConfig configures the persistent read model of devices. When it is disabled, the devices are loaded
+// from the eventstore to the in-memory projection.
+type ReadModelConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// events of the eventbus are delivered at most once, so the synchronized devices with events missing in the read model
+	// are reloaded from the eventstore periodically
+	ReconciliationInterval time.Duration  `yaml:"reconciliationInterval" json:"reconciliationInterval"`
+	MongoDB                mongodb.Config `yaml:"mongoDB" json:"mongoDB"`
+}
+
+func (c *ReadModelConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ReconciliationInterval <= 0 {
+		return fmt.Errorf("reconciliationInterval('%v')", c.ReconciliationInterval)
+	}
+	if err := c.MongoDB.Validate(); err != nil {
+		return fmt.Errorf("mongoDB.%w", err)
+	}
+	return nil
+}
+
 type IdentityStoreConfig struct {
 	Connection client.Config `yaml:"grpc" json:"grpc"`
 }
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+)
+
+type contentFilterOperator string
+
+const (
+	contentFilterEq contentFilterOperator = "=="
+	contentFilterNe contentFilterOperator = "!="
+	contentFilterLe contentFilterOperator = "<="
+	contentFilterGe contentFilterOperator = ">="
+	contentFilterLt contentFilterOperator = "<"
+	contentFilterGt contentFilterOperator = ">"
+)
+
+// operators with a common prefix must be ordered from the longest one
+var contentFilterOperators = []contentFilterOperator{contentFilterEq, contentFilterNe, contentFilterLe, contentFilterGe, contentFilterLt, contentFilterGt}
+
+// contentFilterPathElem is the key of the map or the index of the array when key is empty.
+type contentFilterPathElem struct {
+	key   string
+	index int
+}
+
+type contentFilterCondition struct {
+	path     []contentFilterPathElem
+	operator contentFilterOperator
+	value    interface{}
+}
+
+// ContentFilter matches resources with the decoded content satisfying all conditions.
+type ContentFilter struct {
+	conditions []contentFilterCondition
+}
+
+// splitContentFilter splits the expression by && which are not part of a string value.
+func splitContentFilter(expr string) []string {
+	var parts []string
+	inString := false
+	escaped := false
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && c == '&' && i+1 < len(expr) && expr[i+1] == '&':
+			parts = append(parts, expr[start:i])
+			start = i + 2
+			i++
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+func parseContentFilterPath(path string) ([]contentFilterPathElem, error) {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+	elems := make([]contentFilterPathElem, 0, 4)
+	for len(path) > 0 {
+		if path[0] == '[' {
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']'")
+			}
+			index, err := strconv.Atoi(path[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index '%v'", path[1:end])
+			}
+			elems = append(elems, contentFilterPathElem{index: index})
+			path = strings.TrimPrefix(path[end+1:], ".")
+			continue
+		}
+		end := strings.IndexAny(path, ".[")
+		if end < 0 {
+			end = len(path)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("empty property name")
+		}
+		elems = append(elems, contentFilterPathElem{key: path[:end]})
+		path = path[end:]
+		if strings.HasPrefix(path, ".") {
+			path = path[1:]
+			if len(path) == 0 {
+				return nil, fmt.Errorf("empty property name")
+			}
+		}
+	}
+	return elems, nil
+}
+
+func parseContentFilterCondition(cond string) (contentFilterCondition, error) {
+	cond = strings.TrimSpace(cond)
+	end := strings.IndexAny(cond, "=!<> \t")
+	if end <= 0 {
+		return contentFilterCondition{}, fmt.Errorf("invalid condition '%v': missing property path", cond)
+	}
+	path, err := parseContentFilterPath(cond[:end])
+	if err != nil {
+		return contentFilterCondition{}, fmt.Errorf("invalid property path '%v': %w", cond[:end], err)
+	}
+	rest := strings.TrimSpace(cond[end:])
+	var operator contentFilterOperator
+	for _, op := range contentFilterOperators {
+		if strings.HasPrefix(rest, string(op)) {
+			operator = op
+			break
+		}
+	}
+	if operator == "" {
+		return contentFilterCondition{}, fmt.Errorf("invalid condition '%v': missing operator", cond)
+	}
+	rawValue := strings.TrimSpace(rest[len(operator):])
+	var value interface{}
+	if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+		return contentFilterCondition{}, fmt.Errorf("invalid value '%v': %w", rawValue, err)
+	}
+	switch value.(type) {
+	case float64, string:
+	case bool, nil:
+		if operator != contentFilterEq && operator != contentFilterNe {
+			return contentFilterCondition{}, fmt.Errorf("invalid condition '%v': operator %v is not supported for value %v", cond, operator, rawValue)
+		}
+	default:
+		return contentFilterCondition{}, fmt.Errorf("invalid value '%v': only numbers, strings, booleans and null are supported", rawValue)
+	}
+	return contentFilterCondition{
+		path:     path,
+		operator: operator,
+		value:    value,
+	}, nil
+}
+
+// ParseContentFilter parses the content filter expression. An empty expression returns nil filter which matches all resources.
+func ParseContentFilter(expr string) (*ContentFilter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	parts := splitContentFilter(expr)
+	conditions := make([]contentFilterCondition, 0, len(parts))
+	for _, p := range parts {
+		cond, err := parseContentFilterCondition(p)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse content filter: %w", err)
+		}
+		conditions = append(conditions, cond)
+	}
+	return &ContentFilter{
+		conditions: conditions,
+	}, nil
+}
+
+func lookupContentValue(v interface{}, path []contentFilterPathElem) (interface{}, bool) {
+	for _, e := range path {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			if e.key == "" {
+				return nil, false
+			}
+			var ok bool
+			if v, ok = val[e.key]; !ok {
+				return nil, false
+			}
+		case map[interface{}]interface{}:
+			if e.key == "" {
+				return nil, false
+			}
+			var ok bool
+			if v, ok = val[e.key]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			if e.key != "" || e.index >= len(val) {
+				return nil, false
+			}
+			v = val[e.index]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	case int:
+		return float64(val), true
+	case json.Number:
+		f, err := val.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// compareContentValues returns the result of comparison and false when the values are not comparable.
+func compareContentValues(a, b interface{}) (int, bool) {
+	switch bv := b.(type) {
+	case float64:
+		av, ok := toFloat64(a)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av < bv:
+			return -1, true
+		case av > bv:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		av, ok := a.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	case bool:
+		av, ok := a.(bool)
+		if !ok || av != bv {
+			return 1, ok
+		}
+		return 0, true
+	case nil:
+		if a != nil {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// match returns false when the property is missing in the content, even for the operator !=.
+func (c contentFilterCondition) match(content interface{}) bool {
+	v, ok := lookupContentValue(content, c.path)
+	if !ok {
+		return false
+	}
+	cmp, ok := compareContentValues(v, c.value)
+	if !ok {
+		return c.operator == contentFilterNe
+	}
+	switch c.operator {
+	case contentFilterEq:
+		return cmp == 0
+	case contentFilterNe:
+		return cmp != 0
+	case contentFilterLt:
+		return cmp < 0
+	case contentFilterLe:
+		return cmp <= 0
+	case contentFilterGt:
+		return cmp > 0
+	case contentFilterGe:
+		return cmp >= 0
+	}
+	return false
+}
+
+// Match returns true when the decoded content satisfies all conditions. Content which cannot be decoded doesn't match.
+func (f *ContentFilter) Match(content *commands.Content) bool {
+	if f == nil {
+		return true
+	}
+	var v interface{}
+	if err := decodeContent(content, &v); err != nil {
+		return false
+	}
+	for _, c := range f.conditions {
+		if !c.match(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-directory/service"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentFilterMatch(t *testing.T) {
+	data, err := cbor.Encode(map[string]interface{}{
+		"temperature": 35.5,
+		"units":       "C",
+		"on":          true,
+		"range":       []interface{}{0, 100},
+		"nested":      map[string]interface{}{"value": 7},
+	})
+	require.NoError(t, err)
+	cborContent := &commands.Content{
+		ContentType: message.AppOcfCbor.String(),
+		Data:        data,
+	}
+	jsonContent := &commands.Content{
+		ContentType: message.AppJSON.String(),
+		Data:        []byte(`{"temperature":25,"units":"F"}`),
+	}
+
+	tests := []struct {
+		name    string
+		filter  string
+		content *commands.Content
+		want    bool
+		wantErr bool
+	}{
+		{name: "empty", filter: "", content: cborContent, want: true},
+		{name: "gt", filter: "temperature > 30", content: cborContent, want: true},
+		{name: "gt - json", filter: "temperature > 30", content: jsonContent, want: false},
+		{name: "le", filter: "$.temperature<=35.5", content: cborContent, want: true},
+		{name: "and", filter: `temperature >= 30 && units == "C"`, content: cborContent, want: true},
+		{name: "and - not matching", filter: `temperature >= 30 && units != "C"`, content: cborContent, want: false},
+		{name: "string with &&", filter: `units == "C && F"`, content: cborContent, want: false},
+		{name: "bool", filter: "on == true", content: cborContent, want: true},
+		{name: "array index", filter: "range[1] == 100", content: cborContent, want: true},
+		{name: "nested", filter: "$.nested.value < 10", content: cborContent, want: true},
+		{name: "missing property", filter: "humidity > 10", content: cborContent, want: false},
+		{name: "missing property - ne", filter: "humidity != 10", content: cborContent, want: false},
+		{name: "missing nested property - ne", filter: `nested.unit != "C"`, content: cborContent, want: false},
+		{name: "type mismatch", filter: `units > 10`, content: cborContent, want: false},
+		{name: "empty content", filter: "temperature > 30", content: nil, want: false},
+		{name: "missing operator", filter: "temperature 30", wantErr: true},
+		{name: "invalid value", filter: "units == C", wantErr: true},
+		{name: "invalid operator for bool", filter: "on > true", wantErr: true},
+		{name: "invalid path", filter: "range[a] == 1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := service.ParseContentFilter(tt.filter)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, f.Match(tt.content))
+		})
+	}
+}
@@ -40,12 +40,12 @@ func hasMatchingStatus(isOnline bool, statusFilter []pb.GetDevicesRequest_Status
 }
 
 type DeviceDirectory struct {
-	projection    *Projection
+	projection    ReadModel
 	userDeviceIds strings.Set
 }
 
 // NewDeviceDirectory creates new device directory.
-func NewDeviceDirectory(projection *Projection, deviceIds []string) *DeviceDirectory {
+func NewDeviceDirectory(projection ReadModel, deviceIds []string) *DeviceDirectory {
 	mapDeviceIds := make(strings.Set)
 	mapDeviceIds.Add(deviceIds...)
 
@@ -18,7 +18,7 @@ func (r *RequestHandler) GetDevices(req *pb.GetDevicesRequest, srv pb.GrpcGatewa
 		return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot get devices contents: %v", err))
 	}
 
-	rd := NewDeviceDirectory(r.resourceProjection, deviceIDs)
+	rd := NewDeviceDirectory(r.readModel, deviceIDs)
 	err = rd.GetDevices(req, srv)
 	if err != nil {
 		return log.LogAndReturnError(err)
@@ -9,7 +9,9 @@ import (
 	"github.com/plgd-dev/device/schema/device"
 	"github.com/plgd-dev/device/schema/interfaces"
 	"github.com/plgd-dev/device/test/resource/types"
+	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
@@ -20,7 +22,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 func TestRequestHandler_GetDevices(t *testing.T) {
@@ -96,3 +100,80 @@ func TestRequestHandler_GetDevices(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestHandler_GetDevicesSharedWithViewer(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	tearDown := service.SetUp(ctx, t)
+	defer tearDown()
+	ownerCtx := kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+	viewerCtx := kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceTokenForOwner(t, "viewer"))
+	strangerCtx := kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceTokenForOwner(t, "stranger"))
+
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := pb.NewGrpcGatewayClient(conn)
+
+	idConn, err := grpc.Dial(testCfg.IDENTITY_STORE_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = idConn.Close()
+	}()
+	idClient := pbIS.NewIdentityStoreClient(idConn)
+
+	_, shutdownDevSim := test.OnboardDevSim(ownerCtx, t, c, deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	group, err := idClient.CreateGroup(ownerCtx, &pbIS.CreateGroupRequest{
+		Name:    "viewers",
+		Members: []*pbIS.GroupMember{{UserId: "viewer", Role: pbIS.Role_VIEWER}},
+	})
+	require.NoError(t, err)
+	_, err = idClient.ShareDevices(ownerCtx, &pbIS.ShareDevicesRequest{
+		GroupId:   group.GetId(),
+		DeviceIds: []string{deviceID},
+	})
+	require.NoError(t, err)
+
+	getDeviceIDs := func(ctx context.Context) []string {
+		client, err := c.GetDevices(ctx, &pb.GetDevicesRequest{})
+		require.NoError(t, err)
+		var deviceIDs []string
+		for {
+			dev, err := client.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			deviceIDs = append(deviceIDs, dev.GetId())
+		}
+		return deviceIDs
+	}
+
+	// the viewer reads the shared device
+	require.Equal(t, []string{deviceID}, getDeviceIDs(viewerCtx))
+	require.Empty(t, getDeviceIDs(strangerCtx))
+
+	// but the viewer cannot send commands to it
+	_, err = c.UpdateResource(viewerCtx, &pb.UpdateResourceRequest{
+		ResourceId: commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")),
+		Content: &pb.Content{
+			ContentType: message.AppOcfCbor.String(),
+			Data: test.EncodeToCbor(t, map[string]interface{}{
+				"power": 1,
+			}),
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Convert(err).Code())
+}
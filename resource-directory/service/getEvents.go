@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/pkg/log"
@@ -248,6 +249,40 @@ var eventTypeToEventHandler = map[string]resourceEventHandler{
 	(&events.DeviceMetadataSnapshotTaken{}).EventType(): handleDeviceMetadataSnapshotTaken,
 }
 
+var eventFilterToEventType = map[pb.GetEventsRequest_Event]string{
+	pb.GetEventsRequest_RESOURCE_LINKS_PUBLISHED:       (&events.ResourceLinksPublished{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_LINKS_UNPUBLISHED:     (&events.ResourceLinksUnpublished{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_LINKS_SNAPSHOT_TAKEN:  (&events.ResourceLinksSnapshotTaken{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_CHANGED:               (&events.ResourceChanged{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_UPDATE_PENDING:        (&events.ResourceUpdatePending{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_UPDATED:               (&events.ResourceUpdated{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_RETRIEVE_PENDING:      (&events.ResourceRetrievePending{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_RETRIEVED:             (&events.ResourceRetrieved{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_DELETE_PENDING:        (&events.ResourceDeletePending{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_DELETED:               (&events.ResourceDeleted{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_CREATE_PENDING:        (&events.ResourceCreatePending{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_CREATED:               (&events.ResourceCreated{}).EventType(),
+	pb.GetEventsRequest_RESOURCE_STATE_SNAPSHOT_TAKEN:  (&events.ResourceStateSnapshotTaken{}).EventType(),
+	pb.GetEventsRequest_DEVICE_METADATA_UPDATE_PENDING: (&events.DeviceMetadataUpdatePending{}).EventType(),
+	pb.GetEventsRequest_DEVICE_METADATA_UPDATED:        (&events.DeviceMetadataUpdated{}).EventType(),
+	pb.GetEventsRequest_DEVICE_METADATA_SNAPSHOT_TAKEN: (&events.DeviceMetadataSnapshotTaken{}).EventType(),
+}
+
+func getEventTypes(eventFilter []pb.GetEventsRequest_Event) ([]string, error) {
+	if len(eventFilter) == 0 {
+		return nil, nil
+	}
+	eventTypes := make(strings.Set)
+	for _, e := range eventFilter {
+		eventType, ok := eventFilterToEventType[e]
+		if !ok {
+			return nil, fmt.Errorf("invalid eventFilter value %v", e)
+		}
+		eventTypes.Add(eventType)
+	}
+	return eventTypes.ToSlice(), nil
+}
+
 func handleEvent(eu eventstore.EventUnmarshaler) *pb.GetEventsResponse {
 	log.Debugf("handleEvent deviceID=%v eventype%v version=%v", eu.GroupID(), eu.EventType(), eu.Version())
 	handler, ok := eventTypeToEventHandler[eu.EventType()]
@@ -273,6 +308,11 @@ func (p *resourceEvent) Handle(ctx context.Context, iter eventstore.Iter) error
 		if resp == nil {
 			continue
 		}
+		token, err := pb.MakeContinuationToken(eu)
+		if err != nil {
+			return fmt.Errorf("cannot create continuation token: %w", err)
+		}
+		resp.ContinuationToken = token
 		if err := p.srv.Send(resp); err != nil {
 			return err
 		}
@@ -343,6 +383,15 @@ func (r *RequestHandler) GetEvents(req *pb.GetEventsRequest, srv pb.GrpcGateway_
 		mapUserDeviceIds[userDeviceId] = struct{}{}
 	}
 
+	eventTypes, err := getEventTypes(req.GetEventFilter())
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot get events: %v", err))
+	}
+	after, err := pb.ParseContinuationToken(req.GetContinuationToken())
+	if err != nil {
+		return log.LogAndReturnError(status.Errorf(codes.InvalidArgument, "cannot get events: %v", err))
+	}
+
 	var queries []eventstore.GetEventsQuery
 	if len(req.DeviceIdFilter) == 0 && len(req.ResourceIdFilter) == 0 {
 		queries = getUserDeviceQueries(mapUserDeviceIds)
@@ -350,8 +399,17 @@ func (r *RequestHandler) GetEvents(req *pb.GetEventsRequest, srv pb.GrpcGateway_
 		queries = getDeviceQueries(req.DeviceIdFilter, mapUserDeviceIds)
 		queries = append(queries, getResourceQueries(req.ResourceIdFilter, mapUserDeviceIds)...)
 	}
+	for i := range queries {
+		queries[i].EventTypes = eventTypes
+	}
 
-	err = r.eventStore.GetEvents(srv.Context(), queries, req.TimestampFilter, &resourceEvent{srv: srv})
+	page := eventstore.GetEventsPage{
+		TimestampFrom: req.GetTimestampFilter(),
+		TimestampTo:   req.GetTimestampToFilter(),
+		Limit:         int64(req.GetLimit()),
+		After:         after,
+	}
+	err = r.eventStore.GetEvents(srv.Context(), queries, page, &resourceEvent{srv: srv})
 	if err != nil {
 		return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot get events: %v", err))
 	}
@@ -16,7 +16,9 @@ import (
 	"github.com/plgd-dev/hub/test/service"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 func containsDevMetadataUpdated(t *testing.T, values []*pb.GetEventsResponse, deviceID string, status commands.ConnectionStatus_Status) {
@@ -87,4 +89,77 @@ func TestRequestHandler_GetEvents(t *testing.T) {
 		require.NotNil(t, evt)
 		require.Equal(t, evt.GetResourceId().GetHref(), res.Href)
 	}
+
+	client, err = c.GetEvents(ctx, &pb.GetEventsRequest{
+		DeviceIdFilter: []string{deviceID},
+		EventFilter:    []pb.GetEventsRequest_Event{pb.GetEventsRequest_DEVICE_METADATA_UPDATED, pb.GetEventsRequest_RESOURCE_LINKS_PUBLISHED},
+	})
+	require.NoError(t, err)
+	values = make([]*pb.GetEventsResponse, 0, 8)
+	for {
+		value, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		values = append(values, value)
+	}
+	containsDevMetadataUpdated(t, values, deviceID, commands.ConnectionStatus_ONLINE)
+	containsResourceLinksPublished(t, values, deviceID, resources)
+	for _, v := range values {
+		if v.GetDeviceMetadataUpdated() == nil && v.GetResourceLinksPublished() == nil {
+			require.Failf(t, "unexpected event", "%v", v)
+		}
+	}
+
+	client, err = c.GetEvents(ctx, &pb.GetEventsRequest{
+		DeviceIdFilter: []string{deviceID},
+	})
+	require.NoError(t, err)
+	deviceValues := make([]*pb.GetEventsResponse, 0, 8)
+	for {
+		value, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		deviceValues = append(deviceValues, value)
+	}
+	require.NotEmpty(t, deviceValues)
+
+	var continuationToken string
+	pages := make([]*pb.GetEventsResponse, 0, len(deviceValues))
+	for {
+		client, err = c.GetEvents(ctx, &pb.GetEventsRequest{
+			DeviceIdFilter:    []string{deviceID},
+			Limit:             2,
+			ContinuationToken: continuationToken,
+		})
+		require.NoError(t, err)
+		page := make([]*pb.GetEventsResponse, 0, 2)
+		for {
+			value, err := client.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			page = append(page, value)
+		}
+		require.LessOrEqual(t, len(page), 2)
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page...)
+		continuationToken = page[len(page)-1].GetContinuationToken()
+		require.NotEmpty(t, continuationToken)
+	}
+	test.CheckProtobufs(t, deviceValues, pages, test.RequireToCheckFunc(require.Equal))
+
+	client, err = c.GetEvents(ctx, &pb.GetEventsRequest{
+		ContinuationToken: "invalid",
+	})
+	require.NoError(t, err)
+	_, err = client.Recv()
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
 }
@@ -4,14 +4,16 @@ import (
 	"context"
 
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	pbIS "github.com/plgd-dev/hub/identity-store/pb"
 	"github.com/plgd-dev/hub/pkg/log"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// getOwnerDevices returns devices readable by the owner, it includes devices shared with the owner by groups.
 func (r *RequestHandler) getOwnerDevices(ctx context.Context, owner string) ([]string, error) {
-	deviceIDs, err := r.ownerCache.GetDevices(ctx)
+	deviceIDs, err := r.ownerCache.GetAccessibleDevices(ctx, pbIS.Role_VIEWER)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +30,7 @@ func (r *RequestHandler) GetPendingCommands(req *pb.GetPendingCommandsRequest, s
 		return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot retrieve pending commands: %v", err))
 	}
 
-	rs := NewResourceShadow(r.resourceProjection, deviceIDs)
+	rs := NewResourceShadow(r.readModel, deviceIDs)
 	err = rs.GetPendingCommands(req, srv)
 	if err != nil {
 		return log.LogAndReturnError(err)
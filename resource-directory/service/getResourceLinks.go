@@ -18,7 +18,7 @@ func (r *RequestHandler) GetResourceLinks(req *pb.GetResourceLinksRequest, srv p
 		return log.LogAndReturnError(status.Errorf(status.Convert(err).Code(), "cannot get resource links: %v", err))
 	}
 
-	rd := NewResourceDirectory(r.resourceProjection, deviceIDs)
+	rd := NewResourceDirectory(r.readModel, deviceIDs)
 	err = rd.GetResourceLinks(req, srv)
 	if err != nil {
 		return log.LogAndReturnError(err)
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 
 	"github.com/google/uuid"
+	nats "github.com/nats-io/nats.go"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	clientIS "github.com/plgd-dev/hub/identity-store/client"
 	pbIS "github.com/plgd-dev/hub/identity-store/pb"
@@ -13,12 +14,14 @@ import (
 	"github.com/plgd-dev/hub/pkg/log"
 	"github.com/plgd-dev/hub/pkg/net/grpc/client"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	cmClient "github.com/plgd-dev/hub/pkg/security/certManager/client"
 	"github.com/plgd-dev/hub/resource-aggregate/commands"
 	naClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/subscriber"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
-	mongodb "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
+	eventstoreConfig "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/config"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+	rdMongo "github.com/plgd-dev/hub/resource-directory/store/mongodb"
 	"google.golang.org/grpc"
 )
 
@@ -26,7 +29,7 @@ import (
 type RequestHandler struct {
 	pb.UnimplementedGrpcGatewayServer
 
-	resourceProjection  *Projection
+	readModel           ReadModel
 	eventStore          eventstore.EventStore
 	publicConfiguration PublicConfiguration
 	ownerCache          *clientIS.OwnerCache
@@ -70,6 +73,41 @@ func newIdentityStoreClient(config IdentityStoreConfig, logger log.Logger) (pbIS
 	return isClient, closeIsClient.ToFunction(), nil
 }
 
+func newPersistentReadModel(ctx context.Context, config ReadModelConfig, eventstore eventstore.EventStore, resourceSubscriber *subscriber.Subscriber, conn *nats.Conn, logger log.Logger) (*PersistentReadModel, func(), error) {
+	var fl fn.FuncList
+	certManager, err := cmClient.New(config.MongoDB.TLS, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create cert manager: %w", err)
+	}
+	fl.AddFunc(certManager.Close)
+
+	store, err := rdMongo.NewStore(ctx, config.MongoDB, certManager.GetTLSConfig())
+	if err != nil {
+		fl.Execute()
+		return nil, nil, fmt.Errorf("cannot create mongodb read model store: %w", err)
+	}
+	fl.AddFunc(func() {
+		if err := store.Close(ctx); err != nil {
+			logger.Errorf("error occurs during close connection to read model store: %w", err)
+		}
+	})
+
+	updater, err := NewReadModelUpdater(ctx, store, eventstore, resourceSubscriber, conn, config.ReconciliationInterval, logger)
+	if err != nil {
+		fl.Execute()
+		return nil, nil, fmt.Errorf("cannot create read model updater: %w", err)
+	}
+	// events published during the reconnection are lost
+	reconnectID := resourceSubscriber.AddReconnectFunc(updater.TriggerReconciliation)
+	fl.AddFunc(func() {
+		resourceSubscriber.RemoveReconnectFunc(reconnectID)
+		if err := updater.Close(); err != nil {
+			logger.Errorf("error occurs during close read model updater: %w", err)
+		}
+	})
+	return NewPersistentReadModel(store, updater), fl.ToFunction(), nil
+}
+
 func newRequestHandlerFromConfig(ctx context.Context, config Config, publicConfiguration PublicConfiguration, logger log.Logger, goroutinePoolGo func(func()) error) (*RequestHandler, error) {
 	var closeFunc fn.FuncList
 	if publicConfiguration.CAPool != "" {
@@ -86,14 +124,14 @@ func newRequestHandlerFromConfig(ctx context.Context, config Config, publicConfi
 	}
 	closeFunc.AddFunc(closeIsClient)
 
-	eventstore, err := mongodb.New(ctx, config.Clients.Eventstore.Connection.MongoDB, logger, mongodb.WithUnmarshaler(utils.Unmarshal), mongodb.WithMarshaler(utils.Marshal))
+	eventstore, err := eventstoreConfig.New(ctx, config.Clients.Eventstore.Connection, logger, utils.Marshal, utils.Unmarshal)
 	if err != nil {
 		closeFunc.Execute()
-		return nil, fmt.Errorf("cannot create resource mongodb eventstore %w", err)
+		return nil, fmt.Errorf("cannot create resource eventstore %w", err)
 	}
 	closeFunc.AddFunc(func() {
 		if err := eventstore.Close(ctx); err != nil {
-			logger.Errorf("error occurs during close connection to mongodb: %w", err)
+			logger.Errorf("error occurs during close connection to eventstore: %w", err)
 		}
 	})
 
@@ -116,16 +154,29 @@ func newRequestHandlerFromConfig(ctx context.Context, config Config, publicConfi
 	}
 	closeFunc.AddFunc(resourceSubscriber.Close)
 
-	mf := NewEventStoreModelFactory()
-	projUUID, err := uuid.NewRandom()
-	if err != nil {
-		closeFunc.Execute()
-		return nil, fmt.Errorf("cannot create uuid for projection %w", err)
-	}
-	resourceProjection, err := NewProjection(ctx, projUUID.String(), eventstore, resourceSubscriber, mf, config.Clients.Eventstore.ProjectionCacheExpiration)
-	if err != nil {
-		closeFunc.Execute()
-		return nil, fmt.Errorf("cannot create projection over resource aggregate events: %w", err)
+	var readModel ReadModel
+	if config.Clients.ReadModel.Enabled {
+		// the in-memory projection isn't created, the read model is maintained by the events of all devices
+		persistentReadModel, closeReadModel, err := newPersistentReadModel(ctx, config.Clients.ReadModel, eventstore, resourceSubscriber, natsClient.GetConn(), logger)
+		if err != nil {
+			closeFunc.Execute()
+			return nil, fmt.Errorf("cannot create read model: %w", err)
+		}
+		closeFunc.AddFunc(closeReadModel)
+		readModel = persistentReadModel
+	} else {
+		mf := NewEventStoreModelFactory()
+		projUUID, err := uuid.NewRandom()
+		if err != nil {
+			closeFunc.Execute()
+			return nil, fmt.Errorf("cannot create uuid for projection %w", err)
+		}
+		resourceProjection, err := NewProjection(ctx, projUUID.String(), eventstore, resourceSubscriber, mf, config.Clients.Eventstore.ProjectionCacheExpiration)
+		if err != nil {
+			closeFunc.Execute()
+			return nil, fmt.Errorf("cannot create projection over resource aggregate events: %w", err)
+		}
+		readModel = resourceProjection
 	}
 
 	ownerCache := clientIS.NewOwnerCache(config.APIs.GRPC.Authorization.OwnerClaim,
@@ -136,7 +187,7 @@ func newRequestHandlerFromConfig(ctx context.Context, config Config, publicConfi
 		})
 
 	h := NewRequestHandler(
-		resourceProjection,
+		readModel,
 		eventstore,
 		publicConfiguration,
 		ownerCache,
@@ -147,14 +198,14 @@ func newRequestHandlerFromConfig(ctx context.Context, config Config, publicConfi
 
 // NewRequestHandler factory for new RequestHandler.
 func NewRequestHandler(
-	resourceProjection *Projection,
+	readModel ReadModel,
 	eventstore eventstore.EventStore,
 	publicConfiguration PublicConfiguration,
 	ownerCache *clientIS.OwnerCache,
 	closeFunc fn.FuncList,
 ) *RequestHandler {
 	return &RequestHandler{
-		resourceProjection:  resourceProjection,
+		readModel:           readModel,
 		eventStore:          eventstore,
 		publicConfiguration: publicConfiguration,
 		ownerCache:          ownerCache,
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var projectionCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "plgd",
+	Subsystem: "resource_directory",
+	Name:      "projection_cache_devices",
+	Help:      "Number of devices held in the projection cache.",
+})
@@ -50,6 +50,7 @@ func NewProjection(ctx context.Context, name string, store eventstore.EventStore
 		if err := projection.Unregister(deviceID); err != nil {
 			log.Errorf("failed to unregister device %v in projection cache during eviction: %w", deviceID, err)
 		}
+		projectionCacheSize.Set(float64(cache.ItemCount()))
 	})
 	return &Projection{Projection: projection, cache: cache}, nil
 }
@@ -61,6 +62,7 @@ func (p *Projection) getModels(ctx context.Context, resourceID *commands.Resourc
 	}
 	if created {
 		p.cache.Set(resourceID.GetDeviceId(), created, cache.DefaultExpiration)
+		projectionCacheSize.Set(float64(p.cache.ItemCount()))
 	} else {
 		if err := p.cache.Replace(resourceID.GetDeviceId(), created, cache.DefaultExpiration); err != nil {
 			log.Debugf("failed to replace item %v in projection cache: %v", resourceID, err)
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"github.com/plgd-dev/kit/v2/strings"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReadModel provides the state of devices for GetDevices, GetResourceLinks, GetResources and GetDevicesMetadata.
+type ReadModel interface {
+	GetResourceLinks(ctx context.Context, deviceIDFilter, typeFilter strings.Set) (map[string]*events.ResourceLinksSnapshotTaken, error)
+	GetDevicesMetadata(ctx context.Context, deviceIDFilter strings.Set) (map[string]*events.DeviceMetadataSnapshotTaken, error)
+	GetResourcesWithLinks(ctx context.Context, resourceIDFilter []*commands.ResourceId, typeFilter strings.Set) (map[string]map[string]*Resource, error)
+}
+
+var _ ReadModel = (*Projection)(nil)
+
+// PersistentReadModel queries the state of devices from the store maintained by the ReadModelUpdater. Devices which are not in the store
+// yet are loaded from the eventstore on the first access.
+type PersistentReadModel struct {
+	store   store.Store
+	updater *ReadModelUpdater
+}
+
+var _ ReadModel = (*PersistentReadModel)(nil)
+
+// number of devices loaded from the eventstore in parallel by a request
+const readModelMaxParallelSyncs = 16
+
+func NewPersistentReadModel(store store.Store, updater *ReadModelUpdater) *PersistentReadModel {
+	return &PersistentReadModel{
+		store:   store,
+		updater: updater,
+	}
+}
+
+// syncDevices loads the devices which are not in the store yet from the eventstore in parallel.
+func (rm *PersistentReadModel) syncDevices(ctx context.Context, deviceIDs []string) error {
+	synced, err := rm.store.SyncedDevices(ctx, deviceIDs)
+	if err != nil {
+		return err
+	}
+	if len(synced) == len(deviceIDs) {
+		return nil
+	}
+	syncedDevices := strings.MakeSet(synced...)
+	limit := make(chan struct{}, readModelMaxParallelSyncs)
+	g, ctx := errgroup.WithContext(ctx)
+	for _, deviceID := range deviceIDs {
+		if syncedDevices.HasOneOf(deviceID) {
+			continue
+		}
+		deviceID := deviceID
+		g.Go(func() error {
+			select {
+			case limit <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			defer func() {
+				<-limit
+			}()
+			return rm.updater.SyncDevice(ctx, deviceID)
+		})
+	}
+	return g.Wait()
+}
+
+func (rm *PersistentReadModel) GetResourceLinks(ctx context.Context, deviceIDFilter, typeFilter strings.Set) (map[string]*events.ResourceLinksSnapshotTaken, error) {
+	deviceIDs := deviceIDFilter.ToSlice()
+	if err := rm.syncDevices(ctx, deviceIDs); err != nil {
+		return nil, fmt.Errorf("cannot get resource links: %w", err)
+	}
+	links, err := rm.store.GetResourceLinks(ctx, deviceIDs, typeFilter.ToSlice())
+	if err != nil {
+		return nil, err
+	}
+	devicesResourceLinks := make(map[string]*events.ResourceLinksSnapshotTaken, len(links))
+	for _, resourceLinks := range links {
+		devicesResourceLinks[resourceLinks.GetDeviceId()] = resourceLinks
+		for href, resource := range resourceLinks.GetResources() {
+			if !hasMatchingType(resource.ResourceTypes, typeFilter) {
+				delete(resourceLinks.Resources, href)
+			}
+		}
+	}
+	return devicesResourceLinks, nil
+}
+
+func (rm *PersistentReadModel) GetDevicesMetadata(ctx context.Context, deviceIDFilter strings.Set) (map[string]*events.DeviceMetadataSnapshotTaken, error) {
+	deviceIDs := deviceIDFilter.ToSlice()
+	if err := rm.syncDevices(ctx, deviceIDs); err != nil {
+		return nil, fmt.Errorf("cannot get devices metadata: %w", err)
+	}
+	metadata, err := rm.store.GetDevicesMetadata(ctx, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+	devicesMetadata := make(map[string]*events.DeviceMetadataSnapshotTaken, len(metadata))
+	for _, deviceMetadata := range metadata {
+		devicesMetadata[deviceMetadata.GetDeviceId()] = deviceMetadata
+	}
+	return devicesMetadata, nil
+}
+
+func (rm *PersistentReadModel) GetResourcesWithLinks(ctx context.Context, resourceIDFilter []*commands.ResourceId, typeFilter strings.Set) (map[string]map[string]*Resource, error) {
+	query := store.ResourcesQuery{
+		TypeFilter: typeFilter.ToSlice(),
+	}
+	for deviceID, hrefs := range getResourceIDMapFilter(resourceIDFilter) {
+		if hrefs == nil {
+			query.DeviceIDs = append(query.DeviceIDs, deviceID)
+			continue
+		}
+		for href := range hrefs {
+			query.ResourceIDs = append(query.ResourceIDs, commands.NewResourceID(deviceID, href))
+		}
+	}
+	deviceIDs := make(strings.Set)
+	deviceIDs.Add(query.DeviceIDs...)
+	for _, resourceID := range query.ResourceIDs {
+		deviceIDs.Add(resourceID.GetDeviceId())
+	}
+	if err := rm.syncDevices(ctx, deviceIDs.ToSlice()); err != nil {
+		return nil, fmt.Errorf("cannot get resources: %w", err)
+	}
+
+	storedResources, err := rm.store.GetResources(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[string]map[string]*Resource)
+	for _, r := range storedResources {
+		deviceResources, ok := resources[r.Link.GetDeviceId()]
+		if !ok {
+			deviceResources = make(map[string]*Resource)
+			resources[r.Link.GetDeviceId()] = deviceResources
+		}
+		deviceResources[r.Link.GetHref()] = &Resource{
+			Resource: r.Link,
+			state:    r.State,
+		}
+	}
+	return resources, nil
+}
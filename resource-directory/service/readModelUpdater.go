@@ -0,0 +1,568 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	isEvents "github.com/plgd-dev/hub/identity-store/events"
+	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/utils"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"golang.org/x/sync/singleflight"
+)
+
+// instances of the resource-directory share the subscription, so each event is applied by one of them
+const readModelSubscriptionID = "resource-directory-read-model"
+
+// number of attempts to apply the event when the aggregate is modified concurrently
+const readModelMaxSaveAttempts = 8
+
+// timeout of removing unregistered devices from the read model
+const readModelDeleteTimeout = time.Second * 10
+
+// timeout of loading the device from the eventstore, it is shared by the concurrent requests
+const readModelSyncTimeout = time.Minute
+
+// events stored during the previous reconciliation are checked again, because their timestamps precede their storing
+const readModelReconciliationOverlap = time.Minute
+
+// readModelAggregate is the snapshot of the aggregate stored in the read model.
+type readModelAggregate interface {
+	eventstore.Handler
+	// load replaces the snapshot by the stored one and returns its checkpoint
+	load(ctx context.Context) (store.Checkpoint, error)
+	save(ctx context.Context, expected store.Checkpoint, version uint64) (bool, error)
+	reset()
+}
+
+type resourceLinksAggregate struct {
+	*events.ResourceLinksSnapshotTaken
+	store    store.Store
+	deviceID string
+}
+
+func (a *resourceLinksAggregate) load(ctx context.Context) (store.Checkpoint, error) {
+	s, checkpoint, err := a.store.LoadResourceLinks(ctx, a.deviceID)
+	if err != nil {
+		return store.Checkpoint{}, err
+	}
+	a.ResourceLinksSnapshotTaken = s
+	return checkpoint, nil
+}
+
+func (a *resourceLinksAggregate) save(ctx context.Context, expected store.Checkpoint, version uint64) (bool, error) {
+	return a.store.SaveResourceLinks(ctx, a.deviceID, a.ResourceLinksSnapshotTaken, expected, version)
+}
+
+func (a *resourceLinksAggregate) reset() {
+	a.ResourceLinksSnapshotTaken = events.NewResourceLinksSnapshotTaken()
+}
+
+type deviceMetadataAggregate struct {
+	*events.DeviceMetadataSnapshotTaken
+	store    store.Store
+	deviceID string
+}
+
+func (a *deviceMetadataAggregate) load(ctx context.Context) (store.Checkpoint, error) {
+	s, checkpoint, err := a.store.LoadDeviceMetadata(ctx, a.deviceID)
+	if err != nil {
+		return store.Checkpoint{}, err
+	}
+	a.DeviceMetadataSnapshotTaken = s
+	return checkpoint, nil
+}
+
+func (a *deviceMetadataAggregate) save(ctx context.Context, expected store.Checkpoint, version uint64) (bool, error) {
+	return a.store.SaveDeviceMetadata(ctx, a.deviceID, a.DeviceMetadataSnapshotTaken, expected, version)
+}
+
+func (a *deviceMetadataAggregate) reset() {
+	a.DeviceMetadataSnapshotTaken = events.NewDeviceMetadataSnapshotTaken()
+}
+
+type resourceStateAggregate struct {
+	*events.ResourceStateSnapshotTaken
+	store        store.Store
+	deviceID     string
+	resourceUUID string
+}
+
+func (a *resourceStateAggregate) load(ctx context.Context) (store.Checkpoint, error) {
+	s, checkpoint, err := a.store.LoadResourceState(ctx, a.resourceUUID)
+	if err != nil {
+		return store.Checkpoint{}, err
+	}
+	a.ResourceStateSnapshotTaken = s
+	return checkpoint, nil
+}
+
+func (a *resourceStateAggregate) save(ctx context.Context, expected store.Checkpoint, version uint64) (bool, error) {
+	return a.store.SaveResourceState(ctx, a.deviceID, a.resourceUUID, a.ResourceStateSnapshotTaken, expected, version)
+}
+
+func (a *resourceStateAggregate) reset() {
+	a.ResourceStateSnapshotTaken = events.NewResourceStateSnapshotTaken()
+}
+
+func newReadModelAggregate(s store.Store, deviceID, aggregateID string) readModelAggregate {
+	switch aggregateID {
+	case commands.MakeLinksResourceUUID(deviceID):
+		return &resourceLinksAggregate{
+			ResourceLinksSnapshotTaken: events.NewResourceLinksSnapshotTaken(),
+			store:                      s,
+			deviceID:                   deviceID,
+		}
+	case commands.MakeStatusResourceUUID(deviceID):
+		return &deviceMetadataAggregate{
+			DeviceMetadataSnapshotTaken: events.NewDeviceMetadataSnapshotTaken(),
+			store:                       s,
+			deviceID:                    deviceID,
+		}
+	}
+	return &resourceStateAggregate{
+		ResourceStateSnapshotTaken: events.NewResourceStateSnapshotTaken(),
+		store:                      s,
+		deviceID:                   deviceID,
+		resourceUUID:               aggregateID,
+	}
+}
+
+type eventsIter struct {
+	events []eventstore.EventUnmarshaler
+	idx    int
+}
+
+func newEventsIter(events ...eventstore.EventUnmarshaler) *eventsIter {
+	return &eventsIter{
+		events: events,
+	}
+}
+
+func (i *eventsIter) Next(ctx context.Context) (eventstore.EventUnmarshaler, bool) {
+	if i.idx >= len(i.events) {
+		return nil, false
+	}
+	e := i.events[i.idx]
+	i.idx++
+	return e, true
+}
+
+func (i *eventsIter) Err() error {
+	return nil
+}
+
+// versionHandler forwards events to the handler and remembers the version of the last one.
+type versionHandler struct {
+	handler eventstore.Handler
+	version uint64
+	loaded  bool
+}
+
+func (h *versionHandler) Handle(ctx context.Context, iter eventstore.Iter) error {
+	var events []eventstore.EventUnmarshaler
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			break
+		}
+		events = append(events, eu)
+		h.version = eu.Version()
+		h.loaded = true
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return h.handler.Handle(ctx, newEventsIter(events...))
+}
+
+// ReadModelUpdater maintains the persistent read model by events from the eventbus. The version of the last applied event
+// is stored with each aggregate, so duplicated events are skipped and missed events are loaded from the eventstore.
+// The eventbus delivers events at most once, so a device whose event cannot be applied is loaded from the eventstore
+// on the next access and the synchronized devices with events missing in the read model are reconciled with the eventstore
+// periodically.
+type ReadModelUpdater struct {
+	store      store.Store
+	eventstore eventstore.EventStore
+	logger     log.Logger
+	observer   eventbus.Observer
+	// deviceUnregistered removes devices deleted from the hub
+	deviceUnregistered *nats.Subscription
+	syncGroup          singleflight.Group
+	reconcile          chan struct{}
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+}
+
+func NewReadModelUpdater(ctx context.Context, store store.Store, eventstore eventstore.EventStore, subscriber eventbus.Subscriber, conn *nats.Conn, reconciliationInterval time.Duration, logger log.Logger) (*ReadModelUpdater, error) {
+	u := &ReadModelUpdater{
+		store:      store,
+		eventstore: eventstore,
+		logger:     logger,
+		reconcile:  make(chan struct{}, 1),
+	}
+	deviceUnregistered, err := conn.QueueSubscribe(isEvents.ToSubject(isEvents.PlgdOwnersOwnerRegistrationsEvent, isEvents.WithOwner("*"), isEvents.WithEventType(isEvents.DevicesUnregisteredEvent)), readModelSubscriptionID, u.handleDevicesUnregistered)
+	if err != nil {
+		return nil, fmt.Errorf("cannot subscribe to devices unregistered events: %w", err)
+	}
+	u.deviceUnregistered = deviceUnregistered
+	topics := []string{isEvents.ToSubject(utils.PlgdOwnersOwnerDevices, isEvents.WithOwner("*")) + ".>"}
+	observer, err := subscriber.Subscribe(ctx, readModelSubscriptionID, topics, u)
+	if err != nil {
+		_ = deviceUnregistered.Unsubscribe()
+		return nil, fmt.Errorf("cannot subscribe to events: %w", err)
+	}
+	u.observer = observer
+
+	reconcileCtx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+		u.runReconciliation(reconcileCtx, reconciliationInterval)
+	}()
+	return u, nil
+}
+
+func (u *ReadModelUpdater) handleDevicesUnregistered(msg *nats.Msg) {
+	var e isEvents.Event
+	if err := utils.Unmarshal(msg.Data, &e); err != nil {
+		u.logger.Errorf("cannot unmarshal devices unregistered event: %w", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), readModelDeleteTimeout)
+	defer cancel()
+	if err := u.store.DeleteDevices(ctx, e.GetDevicesUnregistered().GetDeviceIds()); err != nil {
+		u.logger.Errorf("cannot remove unregistered devices from read model: %w", err)
+	}
+}
+
+// TriggerReconciliation reconciles the synchronized devices with the eventstore, eg. when the connection to the eventbus was
+// restored.
+func (u *ReadModelUpdater) TriggerReconciliation() {
+	select {
+	case u.reconcile <- struct{}{}:
+	default:
+	}
+}
+
+func (u *ReadModelUpdater) runReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-u.reconcile:
+		}
+		u.reconcileDevices(ctx)
+	}
+}
+
+func (u *ReadModelUpdater) reconcileDevices(ctx context.Context) {
+	start := time.Now()
+	timestamp, err := u.store.GetReconciliationTimestamp(ctx)
+	if err != nil {
+		u.logger.Errorf("cannot reconcile read model: %w", err)
+		return
+	}
+	deviceIDs, err := u.store.GetSyncedDevices(ctx)
+	if err != nil {
+		u.logger.Errorf("cannot reconcile read model: %w", err)
+		return
+	}
+	if timestamp > 0 {
+		// instances share the timestamp, so the devices are loaded only when events were missed by all of them
+		deviceIDs, err = u.getLaggingDevices(ctx, deviceIDs, timestamp-readModelReconciliationOverlap.Nanoseconds())
+		if err != nil {
+			u.logger.Errorf("cannot reconcile read model: %w", err)
+			return
+		}
+	}
+	var failed bool
+	for _, deviceID := range deviceIDs {
+		if err := u.SyncDevice(ctx, deviceID); err != nil {
+			u.logger.Errorf("cannot reconcile read model: %w", err)
+			failed = true
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	if failed {
+		// the devices are checked again by the next reconciliation
+		return
+	}
+	if err := u.store.SetReconciliationTimestamp(ctx, start.UnixNano()); err != nil {
+		u.logger.Errorf("cannot reconcile read model: %w", err)
+	}
+}
+
+type aggregateKey struct {
+	groupID     string
+	aggregateID string
+}
+
+// latestVersionsHandler collects the latest versions of the aggregates.
+type latestVersionsHandler struct {
+	versions map[aggregateKey]uint64
+}
+
+func (h *latestVersionsHandler) Handle(ctx context.Context, iter eventstore.Iter) error {
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			break
+		}
+		key := aggregateKey{groupID: eu.GroupID(), aggregateID: eu.AggregateID()}
+		if v, ok := h.versions[key]; !ok || eu.Version() > v {
+			h.versions[key] = eu.Version()
+		}
+	}
+	return iter.Err()
+}
+
+// getLaggingDevices returns the devices with events stored after the timestamp, which weren't applied to the read model.
+func (u *ReadModelUpdater) getLaggingDevices(ctx context.Context, deviceIDs []string, timestamp int64) ([]string, error) {
+	if len(deviceIDs) == 0 {
+		return nil, nil
+	}
+	queries := make([]eventstore.GetEventsQuery, 0, len(deviceIDs))
+	for _, deviceID := range deviceIDs {
+		queries = append(queries, eventstore.GetEventsQuery{GroupID: deviceID})
+	}
+	h := latestVersionsHandler{
+		versions: make(map[aggregateKey]uint64),
+	}
+	if err := u.eventstore.GetEvents(ctx, queries, eventstore.GetEventsPage{TimestampFrom: timestamp}, &h); err != nil {
+		return nil, fmt.Errorf("cannot get events: %w", err)
+	}
+	lagging := make(map[string]struct{})
+	for key, version := range h.versions {
+		if _, ok := lagging[key.groupID]; ok {
+			continue
+		}
+		checkpoint, err := newReadModelAggregate(u.store, key.groupID, key.aggregateID).load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !checkpoint.Exists || checkpoint.Version < version {
+			lagging[key.groupID] = struct{}{}
+		}
+	}
+	laggingDeviceIDs := make([]string, 0, len(lagging))
+	for deviceID := range lagging {
+		laggingDeviceIDs = append(laggingDeviceIDs, deviceID)
+	}
+	return laggingDeviceIDs, nil
+}
+
+// loadEvents applies events of the aggregate stored in the eventstore after the checkpoint.
+func (u *ReadModelUpdater) loadEvents(ctx context.Context, a readModelAggregate, checkpoint store.Checkpoint, groupID, aggregateID string) (*versionHandler, error) {
+	h := versionHandler{
+		handler: a,
+	}
+	if checkpoint.Exists {
+		err := u.eventstore.LoadFromVersion(ctx, []eventstore.VersionQuery{{GroupID: groupID, AggregateID: aggregateID, Version: checkpoint.Version + 1}}, &h)
+		return &h, err
+	}
+	a.reset()
+	err := u.eventstore.LoadFromSnapshot(ctx, []eventstore.SnapshotQuery{{GroupID: groupID, AggregateID: aggregateID}}, &h)
+	return &h, err
+}
+
+// applyEvent updates the snapshot loaded with the checkpoint by the event and returns the version of the snapshot.
+// It returns false when the event was already applied.
+func (u *ReadModelUpdater) applyEvent(ctx context.Context, a readModelAggregate, checkpoint store.Checkpoint, eu eventstore.EventUnmarshaler) (uint64, bool, error) {
+	switch {
+	case eu.IsSnapshot():
+		if checkpoint.Exists && eu.Version() <= checkpoint.Version {
+			return 0, false, nil
+		}
+		a.reset()
+	case eu.Version() == 0:
+		// the first event of the aggregate, e.g. the device was registered again
+		a.reset()
+	case checkpoint.Exists && eu.Version() <= checkpoint.Version:
+		return 0, false, nil
+	case !checkpoint.Exists || eu.Version() > checkpoint.Version+1:
+		// events were missed
+		h, err := u.loadEvents(ctx, a, checkpoint, eu.GroupID(), eu.AggregateID())
+		if err != nil {
+			return 0, false, fmt.Errorf("cannot load events of aggregate %v: %w", eu.AggregateID(), err)
+		}
+		if h.loaded && h.version >= eu.Version() {
+			return h.version, true, nil
+		}
+	}
+	if err := a.Handle(ctx, newEventsIter(eu)); err != nil {
+		return 0, false, fmt.Errorf("cannot apply event %v of aggregate %v: %w", eu.Version(), eu.AggregateID(), err)
+	}
+	return eu.Version(), true, nil
+}
+
+// isFirstResourceLinksEvent returns true for the first event of the resource links, the aggregates of the device are
+// created from the beginning when the device is registered again.
+func isFirstResourceLinksEvent(eu eventstore.EventUnmarshaler) bool {
+	return !eu.IsSnapshot() && eu.Version() == 0 && eu.AggregateID() == commands.MakeLinksResourceUUID(eu.GroupID())
+}
+
+func (u *ReadModelUpdater) handleEvent(ctx context.Context, eu eventstore.EventUnmarshaler) error {
+	if isFirstResourceLinksEvent(eu) {
+		// states of resources from the previous registration are removed and the device is loaded from the eventstore
+		// on the next access, because events of the new registration could be applied to them concurrently
+		if err := u.store.DeleteResources(ctx, eu.GroupID()); err != nil {
+			return err
+		}
+		if err := u.store.UnsetDeviceSynced(ctx, eu.GroupID()); err != nil {
+			return err
+		}
+	}
+	a := newReadModelAggregate(u.store, eu.GroupID(), eu.AggregateID())
+	for i := 0; i < readModelMaxSaveAttempts; i++ {
+		checkpoint, err := a.load(ctx)
+		if err != nil {
+			return err
+		}
+		version, ok, err := u.applyEvent(ctx, a, checkpoint, eu)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		saved, err := a.save(ctx, checkpoint, version)
+		if err != nil {
+			return err
+		}
+		if saved {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot apply event %v of aggregate %v: aggregate was modified concurrently", eu.Version(), eu.AggregateID())
+}
+
+// Handle applies events from the eventbus to the read model.
+func (u *ReadModelUpdater) Handle(ctx context.Context, iter eventbus.Iter) error {
+	var errors []error
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			break
+		}
+		if err := u.handleEvent(ctx, eu); err != nil {
+			errors = append(errors, err)
+			// the event is not delivered again, so the device is loaded from the eventstore on the next access
+			if errUnset := u.store.UnsetDeviceSynced(ctx, eu.GroupID()); errUnset != nil {
+				errors = append(errors, errUnset)
+			}
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("cannot update read model: %v", errors)
+	}
+	return nil
+}
+
+// deviceSyncHandler builds snapshots of all aggregates of the device.
+type deviceSyncHandler struct {
+	store      store.Store
+	deviceID   string
+	aggregates map[string]*versionHandler
+}
+
+func (h *deviceSyncHandler) Handle(ctx context.Context, iter eventstore.Iter) error {
+	for {
+		eu, ok := iter.Next(ctx)
+		if !ok {
+			break
+		}
+		a, ok := h.aggregates[eu.AggregateID()]
+		if !ok {
+			a = &versionHandler{
+				handler: newReadModelAggregate(h.store, h.deviceID, eu.AggregateID()),
+			}
+			h.aggregates[eu.AggregateID()] = a
+		}
+		if err := a.Handle(ctx, newEventsIter(eu)); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// SyncDevice loads all aggregates of the device from the eventstore to the read model. Concurrent calls for the same device
+// are executed only once, so the loading isn't canceled by the context of the caller.
+func (u *ReadModelUpdater) SyncDevice(ctx context.Context, deviceID string) error {
+	ch := u.syncGroup.DoChan(deviceID, func() (interface{}, error) {
+		syncCtx, cancel := context.WithTimeout(context.Background(), readModelSyncTimeout)
+		defer cancel()
+		return nil, u.syncDevice(syncCtx, deviceID)
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return fmt.Errorf("cannot sync device %v: %w", deviceID, ctx.Err())
+	}
+}
+
+func (u *ReadModelUpdater) syncDevice(ctx context.Context, deviceID string) error {
+	h := deviceSyncHandler{
+		store:      u.store,
+		deviceID:   deviceID,
+		aggregates: make(map[string]*versionHandler),
+	}
+	if err := u.eventstore.LoadFromSnapshot(ctx, []eventstore.SnapshotQuery{{GroupID: deviceID}}, &h); err != nil {
+		return fmt.Errorf("cannot load device %v from eventstore: %w", deviceID, err)
+	}
+	if len(h.aggregates) == 0 {
+		// the device was deleted or it hasn't been connected yet
+		if err := u.store.DeleteDevices(ctx, []string{deviceID}); err != nil {
+			return fmt.Errorf("cannot sync device %v: %w", deviceID, err)
+		}
+		return nil
+	}
+	for aggregateID, a := range h.aggregates {
+		stored := newReadModelAggregate(u.store, deviceID, aggregateID)
+		checkpoint, err := stored.load(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot sync device %v: %w", deviceID, err)
+		}
+		if checkpoint.Exists && checkpoint.Version >= a.version {
+			continue
+		}
+		// when the aggregate was modified concurrently, it contains newer events
+		if _, err := a.handler.(readModelAggregate).save(ctx, checkpoint, a.version); err != nil {
+			return fmt.Errorf("cannot sync device %v: %w", deviceID, err)
+		}
+	}
+	if err := u.store.SetDeviceSynced(ctx, deviceID); err != nil {
+		return fmt.Errorf("cannot sync device %v: %w", deviceID, err)
+	}
+	return nil
+}
+
+func (u *ReadModelUpdater) Close() error {
+	u.cancel()
+	u.wg.Wait()
+	var errors []error
+	if err := u.deviceUnregistered.Unsubscribe(); err != nil {
+		errors = append(errors, err)
+	}
+	if err := u.observer.Close(); err != nil {
+		errors = append(errors, err)
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%v", errors)
+	}
+	return nil
+}
@@ -0,0 +1,485 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore"
+	mockEvents "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/test"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type testStoredSnapshot struct {
+	data       proto.Message
+	checkpoint store.Checkpoint
+}
+
+// testReadModelStore is the in-memory read model store, only snapshots and synced devices are supported.
+type testReadModelStore struct {
+	store.Store
+	lock             sync.Mutex
+	snapshots        map[string]testStoredSnapshot
+	synced           map[string]bool
+	deletedResources []string
+	saves            int
+	reconciliation   int64
+	// beforeSave is called before the snapshot is stored, eg. to store the snapshot concurrently
+	beforeSave func(aggregateID string)
+}
+
+func newTestReadModelStore() *testReadModelStore {
+	return &testReadModelStore{
+		snapshots: make(map[string]testStoredSnapshot),
+		synced:    make(map[string]bool),
+	}
+}
+
+func (s *testReadModelStore) load(aggregateID string, v proto.Message) store.Checkpoint {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stored, ok := s.snapshots[aggregateID]
+	if !ok {
+		return store.Checkpoint{}
+	}
+	proto.Merge(v, stored.data)
+	return stored.checkpoint
+}
+
+func (s *testReadModelStore) set(aggregateID string, v proto.Message, version uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.snapshots[aggregateID] = testStoredSnapshot{
+		data:       proto.Clone(v),
+		checkpoint: store.Checkpoint{Exists: true, Version: version},
+	}
+}
+
+func (s *testReadModelStore) save(aggregateID string, v proto.Message, expected store.Checkpoint, version uint64) bool {
+	s.lock.Lock()
+	beforeSave := s.beforeSave
+	s.lock.Unlock()
+	if beforeSave != nil {
+		beforeSave(aggregateID)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.snapshots[aggregateID].checkpoint != expected {
+		return false
+	}
+	s.saves++
+	s.snapshots[aggregateID] = testStoredSnapshot{
+		data:       proto.Clone(v),
+		checkpoint: store.Checkpoint{Exists: true, Version: version},
+	}
+	return true
+}
+
+func (s *testReadModelStore) SetDeviceSynced(ctx context.Context, deviceID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.synced[deviceID] = true
+	return nil
+}
+
+func (s *testReadModelStore) UnsetDeviceSynced(ctx context.Context, deviceID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.synced, deviceID)
+	return nil
+}
+
+func (s *testReadModelStore) GetSyncedDevices(ctx context.Context) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	deviceIDs := make([]string, 0, len(s.synced))
+	for deviceID := range s.synced {
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+	return deviceIDs, nil
+}
+
+func (s *testReadModelStore) GetReconciliationTimestamp(ctx context.Context) (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.reconciliation, nil
+}
+
+func (s *testReadModelStore) SetReconciliationTimestamp(ctx context.Context, timestamp int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if timestamp > s.reconciliation {
+		s.reconciliation = timestamp
+	}
+	return nil
+}
+
+func (s *testReadModelStore) DeleteResources(ctx context.Context, deviceID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.deletedResources = append(s.deletedResources, deviceID)
+	return nil
+}
+
+func (s *testReadModelStore) LoadResourceLinks(ctx context.Context, deviceID string) (*events.ResourceLinksSnapshotTaken, store.Checkpoint, error) {
+	links := events.NewResourceLinksSnapshotTaken()
+	return links, s.load(commands.MakeLinksResourceUUID(deviceID), links), nil
+}
+
+func (s *testReadModelStore) SaveResourceLinks(ctx context.Context, deviceID string, links *events.ResourceLinksSnapshotTaken, expected store.Checkpoint, version uint64) (bool, error) {
+	return s.save(commands.MakeLinksResourceUUID(deviceID), links, expected, version), nil
+}
+
+func (s *testReadModelStore) LoadDeviceMetadata(ctx context.Context, deviceID string) (*events.DeviceMetadataSnapshotTaken, store.Checkpoint, error) {
+	metadata := events.NewDeviceMetadataSnapshotTaken()
+	return metadata, s.load(commands.MakeStatusResourceUUID(deviceID), metadata), nil
+}
+
+func (s *testReadModelStore) SaveDeviceMetadata(ctx context.Context, deviceID string, metadata *events.DeviceMetadataSnapshotTaken, expected store.Checkpoint, version uint64) (bool, error) {
+	return s.save(commands.MakeStatusResourceUUID(deviceID), metadata, expected, version), nil
+}
+
+func (s *testReadModelStore) LoadResourceState(ctx context.Context, resourceUUID string) (*events.ResourceStateSnapshotTaken, store.Checkpoint, error) {
+	state := events.NewResourceStateSnapshotTaken()
+	return state, s.load(resourceUUID, state), nil
+}
+
+func (s *testReadModelStore) SaveResourceState(ctx context.Context, deviceID, resourceUUID string, state *events.ResourceStateSnapshotTaken, expected store.Checkpoint, version uint64) (bool, error) {
+	return s.save(resourceUUID, state, expected, version), nil
+}
+
+// testReadModelEventstore loads events of aggregates from the version and records the queries.
+type testReadModelEventstore struct {
+	eventstore.EventStore
+	lock    sync.Mutex
+	events  map[string][]eventstore.EventUnmarshaler
+	queries []eventstore.VersionQuery
+	pages   []eventstore.GetEventsPage
+	err     error
+	// wait blocks the loading of events until it is closed or the context is canceled
+	wait chan struct{}
+}
+
+func newTestReadModelEventstore(evs ...eventstore.EventUnmarshaler) *testReadModelEventstore {
+	s := &testReadModelEventstore{
+		events: make(map[string][]eventstore.EventUnmarshaler),
+	}
+	for _, e := range evs {
+		s.events[e.AggregateID()] = append(s.events[e.AggregateID()], e)
+	}
+	return s
+}
+
+func (s *testReadModelEventstore) LoadFromVersion(ctx context.Context, queries []eventstore.VersionQuery, eventHandler eventstore.Handler) error {
+	s.lock.Lock()
+	s.queries = append(s.queries, queries...)
+	err := s.err
+	wait := s.wait
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if wait != nil {
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	var evs []eventstore.EventUnmarshaler
+	for _, q := range queries {
+		for _, e := range s.events[q.AggregateID] {
+			if e.Version() >= q.Version {
+				evs = append(evs, e)
+			}
+		}
+	}
+	return eventHandler.Handle(ctx, newEventsIter(evs...))
+}
+
+func (s *testReadModelEventstore) LoadFromSnapshot(ctx context.Context, queries []eventstore.SnapshotQuery, eventHandler eventstore.Handler) error {
+	versionQueries := make([]eventstore.VersionQuery, 0, len(queries))
+	for _, q := range queries {
+		for aggregateID, evs := range s.events {
+			if (q.AggregateID != "" && q.AggregateID != aggregateID) || (q.GroupID != "" && q.GroupID != evs[0].GroupID()) {
+				continue
+			}
+			var version uint64
+			for _, e := range evs {
+				if e.IsSnapshot() {
+					version = e.Version()
+				}
+			}
+			versionQueries = append(versionQueries, eventstore.VersionQuery{GroupID: q.GroupID, AggregateID: aggregateID, Version: version})
+		}
+	}
+	return s.LoadFromVersion(ctx, versionQueries, eventHandler)
+}
+
+// GetEvents returns all events of the groups, the page is only recorded.
+func (s *testReadModelEventstore) GetEvents(ctx context.Context, queries []eventstore.GetEventsQuery, page eventstore.GetEventsPage, eventHandler eventstore.Handler) error {
+	s.lock.Lock()
+	s.pages = append(s.pages, page)
+	var evs []eventstore.EventUnmarshaler
+	for _, q := range queries {
+		for _, aggregateEvents := range s.events {
+			if aggregateEvents[0].GroupID() == q.GroupID {
+				evs = append(evs, aggregateEvents...)
+			}
+		}
+	}
+	s.lock.Unlock()
+	return eventHandler.Handle(ctx, newEventsIter(evs...))
+}
+
+func (s *testReadModelEventstore) add(e eventstore.EventUnmarshaler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.events[e.AggregateID()] = append(s.events[e.AggregateID()], e)
+}
+
+func (s *testReadModelEventstore) loadedVersions() []uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	versions := make([]uint64, 0, len(s.queries))
+	for _, q := range s.queries {
+		versions = append(versions, q.Version)
+	}
+	return versions
+}
+
+const testReadModelDeviceID = "device"
+
+func makeTestLink(href string) *commands.Resource {
+	return &commands.Resource{
+		DeviceId: testReadModelDeviceID,
+		Href:     href,
+	}
+}
+
+func makeTestLinksPublished(version uint64, hrefs ...string) eventstore.EventUnmarshaler {
+	links := make([]*commands.Resource, 0, len(hrefs))
+	for _, href := range hrefs {
+		links = append(links, makeTestLink(href))
+	}
+	return mockEvents.MakeResourceLinksPublishedEvent(links, testReadModelDeviceID, events.MakeEventMeta("", 0, version))
+}
+
+func makeTestStoredLinks(hrefs ...string) *events.ResourceLinksSnapshotTaken {
+	links := events.NewResourceLinksSnapshotTaken()
+	links.DeviceId = testReadModelDeviceID
+	for _, href := range hrefs {
+		links.GetResources()[href] = makeTestLink(href)
+	}
+	return links
+}
+
+func checkStoredLinks(t *testing.T, s *testReadModelStore, version uint64, hrefs ...string) {
+	links, checkpoint, err := s.LoadResourceLinks(context.Background(), testReadModelDeviceID)
+	require.NoError(t, err)
+	require.Equal(t, store.Checkpoint{Exists: true, Version: version}, checkpoint)
+	stored := make([]string, 0, len(links.GetResources()))
+	for href := range links.GetResources() {
+		stored = append(stored, href)
+	}
+	sort.Strings(stored)
+	sort.Strings(hrefs)
+	require.Equal(t, hrefs, stored)
+}
+
+func handleTestEvents(u *ReadModelUpdater, evs ...eventstore.EventUnmarshaler) error {
+	return u.Handle(context.Background(), newEventsIter(evs...))
+}
+
+func TestReadModelUpdaterLoadsMissedEvents(t *testing.T) {
+	s := newTestReadModelStore()
+	s.set(commands.MakeLinksResourceUUID(testReadModelDeviceID), makeTestStoredLinks("/a"), 0)
+	es := newTestReadModelEventstore(
+		makeTestLinksPublished(0, "/a"),
+		makeTestLinksPublished(1, "/b"),
+		makeTestLinksPublished(2, "/c"),
+	)
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	// the event 1 was missed
+	require.NoError(t, handleTestEvents(u, makeTestLinksPublished(2, "/c")))
+	require.Equal(t, []uint64{1}, es.loadedVersions())
+	checkStoredLinks(t, s, 2, "/a", "/b", "/c")
+
+	// the aggregate without the checkpoint is loaded from the snapshot
+	s = newTestReadModelStore()
+	es = newTestReadModelEventstore(
+		mockEvents.MakeResourceLinksSnapshotTaken(map[string]*commands.Resource{"/a": makeTestLink("/a")}, testReadModelDeviceID, events.MakeEventMeta("", 0, 3)),
+		makeTestLinksPublished(4, "/b"),
+	)
+	u = &ReadModelUpdater{store: s, eventstore: es}
+	require.NoError(t, handleTestEvents(u, makeTestLinksPublished(4, "/b")))
+	require.Equal(t, []uint64{3}, es.loadedVersions())
+	checkStoredLinks(t, s, 4, "/a", "/b")
+}
+
+func TestReadModelUpdaterSkipsDuplicatedEvents(t *testing.T) {
+	s := newTestReadModelStore()
+	s.set(commands.MakeLinksResourceUUID(testReadModelDeviceID), makeTestStoredLinks("/a", "/b"), 1)
+	es := newTestReadModelEventstore()
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	require.NoError(t, handleTestEvents(u, makeTestLinksPublished(1, "/c")))
+	require.NoError(t, handleTestEvents(u, mockEvents.MakeResourceLinksSnapshotTaken(map[string]*commands.Resource{"/d": makeTestLink("/d")}, testReadModelDeviceID, events.MakeEventMeta("", 0, 1))))
+	require.Empty(t, es.loadedVersions())
+	require.Equal(t, 0, s.saves)
+	checkStoredLinks(t, s, 1, "/a", "/b")
+
+	require.NoError(t, handleTestEvents(u, makeTestLinksPublished(2, "/c")))
+	require.Empty(t, es.loadedVersions())
+	checkStoredLinks(t, s, 2, "/a", "/b", "/c")
+}
+
+func TestReadModelUpdaterResetsOnFirstEvent(t *testing.T) {
+	s := newTestReadModelStore()
+	s.set(commands.MakeLinksResourceUUID(testReadModelDeviceID), makeTestStoredLinks("/a", "/b"), 5)
+	s.synced[testReadModelDeviceID] = true
+	es := newTestReadModelEventstore()
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	// the device was registered again
+	require.NoError(t, handleTestEvents(u, makeTestLinksPublished(0, "/c")))
+	require.Empty(t, es.loadedVersions())
+	checkStoredLinks(t, s, 0, "/c")
+	require.Equal(t, []string{testReadModelDeviceID}, s.deletedResources)
+	require.False(t, s.synced[testReadModelDeviceID])
+
+	// the first event of the resource state replaces the state of the previous registration
+	resourceID := commands.NewResourceID(testReadModelDeviceID, "/c")
+	s.set(resourceID.ToUUID(), events.NewResourceStateSnapshotTaken(), 7)
+	require.NoError(t, handleTestEvents(u, mockEvents.MakeResourceChangedEvent(resourceID, &commands.Content{Data: []byte("0")}, events.MakeEventMeta("", 0, 0), mockEvents.MakeAuditContext("userId", ""))))
+	state, checkpoint, err := s.LoadResourceState(context.Background(), resourceID.ToUUID())
+	require.NoError(t, err)
+	require.Equal(t, store.Checkpoint{Exists: true, Version: 0}, checkpoint)
+	require.Equal(t, []byte("0"), state.GetLatestResourceChange().GetContent().GetData())
+}
+
+func TestReadModelUpdaterConcurrentSave(t *testing.T) {
+	s := newTestReadModelStore()
+	s.set(commands.MakeLinksResourceUUID(testReadModelDeviceID), makeTestStoredLinks("/0"), 0)
+	const numEvents = 16
+	evs := make([]eventstore.EventUnmarshaler, 0, numEvents)
+	hrefs := make([]string, 0, numEvents)
+	for i := 0; i < numEvents; i++ {
+		href := fmt.Sprintf("/%v", i)
+		evs = append(evs, makeTestLinksPublished(uint64(i), href))
+		hrefs = append(hrefs, href)
+	}
+	es := newTestReadModelEventstore(evs...)
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	// another instance stores the event 1 before the event 2 is saved
+	s.beforeSave = func(aggregateID string) {
+		s.lock.Lock()
+		s.beforeSave = nil
+		s.lock.Unlock()
+		s.set(aggregateID, makeTestStoredLinks("/0", "/1"), 1)
+	}
+	require.NoError(t, handleTestEvents(u, evs[2]))
+	checkStoredLinks(t, s, 2, "/0", "/1", "/2")
+
+	// events are applied by more instances in any order
+	var wg sync.WaitGroup
+	errs := make(chan error, numEvents)
+	for i := numEvents - 1; i > 2; i-- {
+		wg.Add(1)
+		go func(e eventstore.EventUnmarshaler) {
+			defer wg.Done()
+			errs <- handleTestEvents(u, e)
+		}(evs[i])
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	checkStoredLinks(t, s, numEvents-1, hrefs...)
+}
+
+func TestReadModelUpdaterUnsetsSyncedOnError(t *testing.T) {
+	s := newTestReadModelStore()
+	s.set(commands.MakeLinksResourceUUID(testReadModelDeviceID), makeTestStoredLinks("/a"), 0)
+	s.synced[testReadModelDeviceID] = true
+	es := newTestReadModelEventstore()
+	es.err = errors.New("eventstore is not available")
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	require.Error(t, handleTestEvents(u, makeTestLinksPublished(2, "/c")))
+	require.False(t, s.synced[testReadModelDeviceID])
+	checkStoredLinks(t, s, 0, "/a")
+}
+
+func TestReadModelUpdaterReconcilesLaggingDevices(t *testing.T) {
+	s := newTestReadModelStore()
+	s.set(commands.MakeLinksResourceUUID(testReadModelDeviceID), makeTestStoredLinks("/a", "/b"), 1)
+	s.synced[testReadModelDeviceID] = true
+	s.reconciliation = 100
+	es := newTestReadModelEventstore(
+		makeTestLinksPublished(0, "/a"),
+		makeTestLinksPublished(1, "/b"),
+	)
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	// the device is up to date, so it isn't loaded
+	u.reconcileDevices(context.Background())
+	require.Empty(t, es.loadedVersions())
+	require.Equal(t, 0, s.saves)
+	require.Equal(t, []eventstore.GetEventsPage{{TimestampFrom: 100 - readModelReconciliationOverlap.Nanoseconds()}}, es.pages)
+	reconciliation := s.reconciliation
+	require.Greater(t, reconciliation, int64(100))
+
+	// the event was missed
+	es.add(makeTestLinksPublished(2, "/c"))
+	u.reconcileDevices(context.Background())
+	require.Equal(t, []uint64{0}, es.loadedVersions())
+	checkStoredLinks(t, s, 2, "/a", "/b", "/c")
+	require.Equal(t, reconciliation-readModelReconciliationOverlap.Nanoseconds(), es.pages[1].TimestampFrom)
+	require.True(t, s.synced[testReadModelDeviceID])
+
+	// all synced devices are loaded, when the read model hasn't been reconciled yet
+	s.reconciliation = 0
+	u.reconcileDevices(context.Background())
+	require.Equal(t, []uint64{0, 0}, es.loadedVersions())
+	require.Len(t, es.pages, 2)
+	require.Greater(t, s.reconciliation, int64(0))
+}
+
+func TestReadModelUpdaterSyncDeviceDetached(t *testing.T) {
+	s := newTestReadModelStore()
+	es := newTestReadModelEventstore(makeTestLinksPublished(0, "/a"))
+	es.wait = make(chan struct{})
+	u := &ReadModelUpdater{store: s, eventstore: es}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- u.SyncDevice(ctx, testReadModelDeviceID)
+	}()
+	require.Eventually(t, func() bool {
+		return len(es.loadedVersions()) > 0
+	}, time.Second, time.Millisecond)
+
+	// the shared loading isn't canceled with the context of the caller
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+	close(es.wait)
+	require.Eventually(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.synced[testReadModelDeviceID]
+	}, time.Second, time.Millisecond)
+	checkStoredLinks(t, s, 0, "/a")
+}
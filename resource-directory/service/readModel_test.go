@@ -0,0 +1,207 @@
+package service_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/schema/interfaces"
+	"github.com/plgd-dev/device/test/resource/types"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/hub/grpc-gateway/pb"
+	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	rdTest "github.com/plgd-dev/hub/resource-directory/test"
+	"github.com/plgd-dev/hub/test"
+	testCfg "github.com/plgd-dev/hub/test/config"
+	oauthService "github.com/plgd-dev/hub/test/oauth-server/service"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+	pbTest "github.com/plgd-dev/hub/test/pb"
+	"github.com/plgd-dev/hub/test/service"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func getLightResources(ctx context.Context, t *testing.T, c pb.GrpcGatewayClient, deviceID string) []*pb.Resource {
+	client, err := c.GetResources(ctx, &pb.GetResourcesRequest{
+		ResourceIdFilter: []string{
+			commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")).ToString(),
+		},
+	})
+	require.NoError(t, err)
+	values := make([]*pb.Resource, 0, 1)
+	for {
+		value, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		values = append(values, value)
+	}
+	return values
+}
+
+func makeLightResource(t *testing.T, deviceID string, power uint64) *pb.Resource {
+	return &pb.Resource{
+		Types: []string{types.CORE_LIGHT},
+		Data: &events.ResourceChanged{
+			ResourceId: &commands.ResourceId{
+				DeviceId: deviceID,
+				Href:     test.TestResourceLightInstanceHref("1"),
+			},
+			Content: &commands.Content{
+				ContentType: message.AppOcfCbor.String(),
+				Data: test.EncodeToCbor(t, map[string]interface{}{
+					"state": false,
+					"power": power,
+					"name":  "Light",
+					"if":    []interface{}{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE},
+					"rt":    []interface{}{types.CORE_LIGHT},
+				}),
+			},
+			Status: commands.Status_OK,
+		},
+	}
+}
+
+func TestRequestHandlerWithReadModel(t *testing.T) {
+	deviceID := test.MustFindDeviceByName(test.TestDeviceName)
+	ctx, cancel := context.WithTimeout(context.Background(), testCfg.TEST_TIMEOUT)
+	defer cancel()
+
+	rdCfg := rdTest.MakeConfig(t)
+	rdCfg.Clients.ReadModel = rdTest.MakeReadModelConfig()
+	tearDown := service.SetUp(ctx, t, service.WithRDConfig(rdCfg))
+	defer tearDown()
+	ctx = kitNetGrpc.CtxWithToken(ctx, oauthTest.GetDefaultServiceToken(t))
+
+	conn, err := grpc.Dial(testCfg.GRPC_HOST, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: test.GetRootCertificatePool(t),
+	})))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	c := pb.NewGrpcGatewayClient(conn)
+
+	_, shutdownDevSim := test.OnboardDevSim(ctx, t, c, deviceID, testCfg.GW_HOST, test.GetAllBackendResourceLinks())
+	defer shutdownDevSim()
+
+	t.Run("GetDevices", func(t *testing.T) {
+		client, err := c.GetDevices(ctx, &pb.GetDevicesRequest{})
+		require.NoError(t, err)
+		devices := make([]*pb.Device, 0, 1)
+		for {
+			dev, err := client.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			devices = append(devices, dev)
+		}
+		require.Len(t, devices, 1)
+		require.Equal(t, deviceID, devices[0].GetId())
+		require.Equal(t, test.TestDeviceName, devices[0].GetName())
+		require.Equal(t, commands.ConnectionStatus_ONLINE, devices[0].GetMetadata().GetStatus().GetValue())
+	})
+
+	t.Run("GetResourceLinks", func(t *testing.T) {
+		client, err := c.GetResourceLinks(ctx, &pb.GetResourceLinksRequest{})
+		require.NoError(t, err)
+		links := make([]*events.ResourceLinksPublished, 0, 1)
+		for {
+			link, err := client.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			links = append(links, pbTest.CleanUpResourceLinksPublished(link))
+		}
+		want := []*events.ResourceLinksPublished{
+			{
+				DeviceId:     deviceID,
+				Resources:    test.ResourceLinksToResources(deviceID, test.GetAllBackendResourceLinks()),
+				AuditContext: commands.NewAuditContext(oauthService.DeviceUserID, ""),
+			},
+		}
+		test.CheckProtobufs(t, want, links, test.RequireToCheckFunc(require.Equal))
+	})
+
+	t.Run("GetDevicesMetadata", func(t *testing.T) {
+		client, err := c.GetDevicesMetadata(ctx, &pb.GetDevicesMetadataRequest{
+			DeviceIdFilter: []string{deviceID},
+		})
+		require.NoError(t, err)
+		var values []*events.DeviceMetadataUpdated
+		for {
+			value, err := client.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			values = append(values, value)
+		}
+		pbTest.CmpDeviceMetadataUpdatedSlice(t, []*events.DeviceMetadataUpdated{
+			{
+				DeviceId: deviceID,
+				Status: &commands.ConnectionStatus{
+					Value: commands.ConnectionStatus_ONLINE,
+				},
+				AuditContext: commands.NewAuditContext(oauthService.DeviceUserID, ""),
+			},
+		}, values)
+	})
+
+	t.Run("GetResources", func(t *testing.T) {
+		cmpResourceValues(t, []*pb.Resource{makeLightResource(t, deviceID, 0)}, getLightResources(ctx, t, c, deviceID))
+	})
+
+	t.Run("GetResourcesAfterUpdate", func(t *testing.T) {
+		_, err := c.UpdateResource(ctx, &pb.UpdateResourceRequest{
+			ResourceId: commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")),
+			Content: &pb.Content{
+				ContentType: message.AppOcfCbor.String(),
+				Data: test.EncodeToCbor(t, map[string]interface{}{
+					"power": 1,
+				}),
+			},
+		})
+		require.NoError(t, err)
+		defer func() {
+			_, err := c.UpdateResource(ctx, &pb.UpdateResourceRequest{
+				ResourceId: commands.NewResourceID(deviceID, test.TestResourceLightInstanceHref("1")),
+				Content: &pb.Content{
+					ContentType: message.AppOcfCbor.String(),
+					Data: test.EncodeToCbor(t, map[string]interface{}{
+						"power": 0,
+					}),
+				},
+			})
+			require.NoError(t, err)
+		}()
+
+		// the read model is updated by the events of the eventbus
+		want := makeLightResource(t, deviceID, 1)
+		for {
+			values := getLightResources(ctx, t, c, deviceID)
+			require.Len(t, values, 1)
+			var light map[string]interface{}
+			err := cbor.Decode(values[0].GetData().GetContent().GetData(), &light)
+			require.NoError(t, err)
+			if light["power"] == uint64(1) {
+				cmpResourceValues(t, []*pb.Resource{want}, values)
+				return
+			}
+			select {
+			case <-time.After(time.Millisecond * 100):
+			case <-ctx.Done():
+				require.NoError(t, ctx.Err(), "read model hasn't been updated")
+			}
+		}
+	})
+}
@@ -7,7 +7,9 @@ import (
 
 type Resource struct {
 	projection *resourceProjection
-	Resource   *commands.Resource
+	// state is set by the persistent read model, which doesn't use the projection
+	state    *events.ResourceStateSnapshotTaken
+	Resource *commands.Resource
 }
 
 func (r *Resource) GetResourceChanged() *events.ResourceChanged {
@@ -15,21 +17,49 @@ func (r *Resource) GetResourceChanged() *events.ResourceChanged {
 		return nil
 	}
 	if r.projection == nil {
-		return nil
+		return r.state.GetLatestResourceChange()
 	}
 	return r.projection.content
 }
 
 func (r *Resource) GetContent() *commands.Content {
-	if r.projection == nil {
+	if r.projection == nil && r.state.GetLatestResourceChange() == nil {
 		return nil
 	}
 	return r.GetResourceChanged().GetContent()
 }
 
 func (r *Resource) GetStatus() commands.Status {
-	if r.projection == nil {
+	if r.projection == nil && r.state.GetLatestResourceChange() == nil {
 		return commands.Status_UNAVAILABLE
 	}
 	return r.GetResourceChanged().GetStatus()
 }
+
+func (r *Resource) getResourceCreatePendings() []*events.ResourceCreatePending {
+	if r.projection == nil {
+		return r.state.GetResourceCreatePendings()
+	}
+	return r.projection.resourceCreatePendings
+}
+
+func (r *Resource) getResourceRetrievePendings() []*events.ResourceRetrievePending {
+	if r.projection == nil {
+		return r.state.GetResourceRetrievePendings()
+	}
+	return r.projection.resourceRetrievePendings
+}
+
+func (r *Resource) getResourceUpdatePendings() []*events.ResourceUpdatePending {
+	if r.projection == nil {
+		return r.state.GetResourceUpdatePendings()
+	}
+	return r.projection.resourceUpdatePendings
+}
+
+func (r *Resource) getResourceDeletePendings() []*events.ResourceDeletePending {
+	if r.projection == nil {
+		return r.state.GetResourceDeletePendings()
+	}
+	return r.projection.resourceDeletePendings
+}
@@ -10,11 +10,11 @@ import (
 )
 
 type ResourceDirectory struct {
-	projection    *Projection
+	projection    ReadModel
 	userDeviceIds strings.Set
 }
 
-func NewResourceDirectory(projection *Projection, deviceIds []string) *ResourceDirectory {
+func NewResourceDirectory(projection ReadModel, deviceIds []string) *ResourceDirectory {
 	mapDeviceIds := make(strings.Set)
 	mapDeviceIds.Add(deviceIds...)
 
@@ -23,12 +23,30 @@ func toResourceValue(resource *Resource) *pb.Resource {
 	}
 }
 
+// toResourceValueWithoutContent returns the resource without the content, the stored event is shared, so it is copied.
+func toResourceValueWithoutContent(resource *Resource) *pb.Resource {
+	changed := resource.GetResourceChanged()
+	if changed == nil {
+		return toResourceValue(resource)
+	}
+	return &pb.Resource{
+		Data: &events.ResourceChanged{
+			ResourceId:    changed.GetResourceId(),
+			Status:        changed.GetStatus(),
+			AuditContext:  changed.GetAuditContext(),
+			EventMetadata: changed.GetEventMetadata(),
+			Etag:          changed.GetEtag(),
+		},
+		Types: resource.Resource.GetResourceTypes(),
+	}
+}
+
 type ResourceShadow struct {
-	projection    *Projection
+	projection    ReadModel
 	userDeviceIds strings.Set
 }
 
-func NewResourceShadow(projection *Projection, deviceIds []string) *ResourceShadow {
+func NewResourceShadow(projection ReadModel, deviceIds []string) *ResourceShadow {
 	mapDeviceIds := make(strings.Set)
 	mapDeviceIds.Add(deviceIds...)
 
@@ -73,6 +91,10 @@ func (rd *ResourceShadow) filterResources(ctx context.Context, resourceIDsFilter
 }
 
 func (rd *ResourceShadow) GetResources(req *pb.GetResourcesRequest, srv pb.GrpcGateway_GetResourcesServer) error {
+	contentFilter, err := ParseContentFilter(req.GetContentFilter())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid content filter: %v", err)
+	}
 	resources, err := rd.filterResources(srv.Context(), req.GetResourceIdFilter(), req.GetDeviceIdFilter(), req.GetTypeFilter())
 	if err != nil {
 		return err
@@ -84,7 +106,16 @@ func (rd *ResourceShadow) GetResources(req *pb.GetResourcesRequest, srv pb.GrpcG
 
 	for _, deviceResources := range resources {
 		for _, resource := range deviceResources {
-			val := toResourceValue(resource)
+			// the content isn't indexed, so it is decoded and evaluated for each resource selected by the other filters
+			if !contentFilter.Match(resource.GetContent()) {
+				continue
+			}
+			var val *pb.Resource
+			if req.GetExcludeContent() {
+				val = toResourceValueWithoutContent(resource)
+			} else {
+				val = toResourceValue(resource)
+			}
 			err = srv.Send(val)
 			if err != nil {
 				return status.Errorf(codes.Canceled, "cannot send resource value %v: %v", val, err)
@@ -95,12 +126,9 @@ func (rd *ResourceShadow) GetResources(req *pb.GetResourcesRequest, srv pb.GrpcG
 }
 
 func toPendingCommands(resource *Resource, commandFilter subscription.FilterBitmask, now time.Time) []*pb.PendingCommand {
-	if resource.projection == nil {
-		return nil
-	}
 	pendingCmds := make([]*pb.PendingCommand, 0, 32)
 	if subscription.IsFilteredBit(commandFilter, subscription.FilterBitmaskResourceCreatePending) {
-		for _, pendingCmd := range resource.projection.resourceCreatePendings {
+		for _, pendingCmd := range resource.getResourceCreatePendings() {
 			if pendingCmd.IsExpired(now) {
 				continue
 			}
@@ -112,7 +140,7 @@ func toPendingCommands(resource *Resource, commandFilter subscription.FilterBitm
 		}
 	}
 	if subscription.IsFilteredBit(commandFilter, subscription.FilterBitmaskResourceRetrievePending) {
-		for _, pendingCmd := range resource.projection.resourceRetrievePendings {
+		for _, pendingCmd := range resource.getResourceRetrievePendings() {
 			if pendingCmd.IsExpired(now) {
 				continue
 			}
@@ -124,7 +152,7 @@ func toPendingCommands(resource *Resource, commandFilter subscription.FilterBitm
 		}
 	}
 	if subscription.IsFilteredBit(commandFilter, subscription.FilterBitmaskResourceUpdatePending) {
-		for _, pendingCmd := range resource.projection.resourceUpdatePendings {
+		for _, pendingCmd := range resource.getResourceUpdatePendings() {
 			if pendingCmd.IsExpired(now) {
 				continue
 			}
@@ -136,7 +164,7 @@ func toPendingCommands(resource *Resource, commandFilter subscription.FilterBitm
 		}
 	}
 	if subscription.IsFilteredBit(commandFilter, subscription.FilterBitmaskResourceDeletePending) {
-		for _, pendingCmd := range resource.projection.resourceDeletePendings {
+		for _, pendingCmd := range resource.getResourceDeletePendings() {
 			if pendingCmd.IsExpired(now) {
 				continue
 			}
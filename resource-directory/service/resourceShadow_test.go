@@ -160,6 +160,28 @@ func TestResourceShadow_GetResources(t *testing.T) {
 			},
 		},
 
+		{
+			name: "filter by device Id and type without content",
+			args: args{
+				req: &pb.GetResourcesRequest{
+					DeviceIdFilter: []string{Resource1.DeviceId},
+					TypeFilter:     []string{Resource1.ResourceTypes[0]},
+					ExcludeContent: true,
+				},
+			},
+			want: map[string]*pb.Resource{
+				Resource1.Href: {
+					Data: &events.ResourceChanged{
+						ResourceId: &commands.ResourceId{
+							DeviceId: Resource1.DeviceId,
+							Href:     Resource1.Href,
+						},
+					},
+					Types: Resource1.ResourceTypes,
+				},
+			},
+		},
+
 		{
 			name: "list all resources of user",
 			args: args{
@@ -7,8 +7,10 @@ import (
 	"github.com/panjf2000/ants/v2"
 	"github.com/plgd-dev/hub/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/pkg/log"
+	"github.com/plgd-dev/hub/pkg/metrics"
 	kitNetGrpc "github.com/plgd-dev/hub/pkg/net/grpc"
 	"github.com/plgd-dev/hub/pkg/net/grpc/server"
+	"github.com/plgd-dev/hub/pkg/opentelemetry"
 	"github.com/plgd-dev/hub/pkg/security/jwt"
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 )
@@ -23,7 +25,7 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 		return nil, fmt.Errorf("cannot create validator: %w", err)
 	}
 	method := "/" + pb.GrpcGateway_ServiceDesc.ServiceName + "/GetHubConfiguration"
-	interceptor := server.NewAuth(validator, server.WithWhiteListedMethods(method))
+	interceptor := server.NewAuth(validator, server.WithWhiteListedMethods(method), server.WithRequiredScopes(config.APIs.GRPC.Authorization.RequiredScopes))
 	opts, err := server.MakeDefaultOptions(interceptor, logger)
 	if err != nil {
 		validator.Close()
@@ -37,6 +39,20 @@ func New(ctx context.Context, config Config, logger log.Logger) (*Service, error
 	}
 	server.AddCloseFunc(validator.Close)
 
+	metricsServer, err := metrics.New(config.APIs.Metrics, logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create metrics server: %w", err)
+	}
+	server.AddCloseFunc(metricsServer.Close)
+
+	otelProvider, err := opentelemetry.New(ctx, config.Clients.OpenTelemetryCollector, "resource-directory", logger)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("cannot create open telemetry provider: %w", err)
+	}
+	server.AddCloseFunc(otelProvider.Close)
+
 	pool, err := ants.NewPool(config.Clients.Eventbus.GoPoolSize)
 	if err != nil {
 		server.Close()
@@ -0,0 +1,289 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+const devicesCName = "devices"
+const linksKey = "links"
+const linksTypesKey = "linkstypes"
+const metadataKey = "metadata"
+const syncedKey = "synced"
+
+var devicesLinksTypesQueryIndex = bson.D{
+	{Key: linksTypesKey, Value: 1},
+}
+
+type dbDevice struct {
+	ID         string      `bson:"_id"`
+	Links      *dbSnapshot `bson:"links,omitempty"`
+	LinksTypes []string    `bson:"linkstypes,omitempty"`
+	Metadata   *dbSnapshot `bson:"metadata,omitempty"`
+	Synced     bool        `bson:"synced,omitempty"`
+}
+
+func (s *Store) findSyncedDevices(ctx context.Context, q bson.M) ([]string, error) {
+	col := s.Collection(devicesCName)
+	iter, err := col.Find(ctx, q, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("cannot load synced devices: %w", err)
+	}
+	var devices []dbDevice
+	if err := iter.All(ctx, &devices); err != nil {
+		return nil, fmt.Errorf("cannot load synced devices: %w", err)
+	}
+	synced := make([]string, 0, len(devices))
+	for _, d := range devices {
+		synced = append(synced, d.ID)
+	}
+	return synced, nil
+}
+
+func (s *Store) SyncedDevices(ctx context.Context, deviceIDs []string) ([]string, error) {
+	if len(deviceIDs) == 0 {
+		return nil, nil
+	}
+	return s.findSyncedDevices(ctx, bson.M{
+		"_id":     bson.M{"$in": deviceIDs},
+		syncedKey: true,
+	})
+}
+
+func (s *Store) SetDeviceSynced(ctx context.Context, deviceID string) error {
+	if deviceID == "" {
+		return fmt.Errorf("cannot set device synced: invalid deviceID")
+	}
+	col := s.Collection(devicesCName)
+	if _, err := col.UpdateOne(ctx, bson.M{"_id": deviceID}, bson.M{"$set": bson.M{syncedKey: true}}, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("cannot set device %v synced: %w", deviceID, err)
+	}
+	return nil
+}
+
+func (s *Store) UnsetDeviceSynced(ctx context.Context, deviceID string) error {
+	if deviceID == "" {
+		return fmt.Errorf("cannot unset device synced: invalid deviceID")
+	}
+	col := s.Collection(devicesCName)
+	if _, err := col.UpdateOne(ctx, bson.M{"_id": deviceID}, bson.M{"$unset": bson.M{syncedKey: ""}}); err != nil {
+		return fmt.Errorf("cannot unset device %v synced: %w", deviceID, err)
+	}
+	return nil
+}
+
+func (s *Store) GetSyncedDevices(ctx context.Context) ([]string, error) {
+	return s.findSyncedDevices(ctx, bson.M{syncedKey: true})
+}
+
+// DeleteDevices removes the documents of the devices and of their resources.
+func (s *Store) DeleteDevices(ctx context.Context, deviceIDs []string) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	_, err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if _, err := s.Collection(resourcesCName).DeleteMany(sc, bson.M{deviceIDKey: bson.M{"$in": deviceIDs}}); err != nil {
+			return err
+		}
+		_, err := s.Collection(devicesCName).DeleteMany(sc, bson.M{"_id": bson.M{"$in": deviceIDs}})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete devices %v: %w", deviceIDs, err)
+	}
+	return nil
+}
+
+func (s *Store) loadDevice(ctx context.Context, deviceID, key string) (*dbDevice, error) {
+	col := s.Collection(devicesCName)
+	res := col.FindOne(ctx, bson.M{"_id": deviceID}, options.FindOne().SetProjection(bson.M{key: 1}))
+	if res.Err() == mongo.ErrNoDocuments {
+		return &dbDevice{ID: deviceID}, nil
+	}
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+	var d dbDevice
+	if err := res.Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *Store) LoadResourceLinks(ctx context.Context, deviceID string) (*events.ResourceLinksSnapshotTaken, store.Checkpoint, error) {
+	d, err := s.loadDevice(ctx, deviceID, linksKey)
+	if err != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load resource links of device %v: %w", deviceID, err)
+	}
+	links := events.NewResourceLinksSnapshotTaken()
+	checkpoint, err := d.Links.unmarshal(links)
+	if err != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load resource links of device %v: %w", deviceID, err)
+	}
+	return links, checkpoint, nil
+}
+
+func getLinksTypes(links *events.ResourceLinksSnapshotTaken) []string {
+	types := make([]string, 0, len(links.GetResources()))
+	set := make(map[string]struct{}, len(links.GetResources()))
+	for _, link := range links.GetResources() {
+		for _, t := range link.GetResourceTypes() {
+			if _, ok := set[t]; ok {
+				continue
+			}
+			set[t] = struct{}{}
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// syncResourcesLinks updates the links of the resources documents to match the resource links of the device.
+func (s *Store) syncResourcesLinks(ctx context.Context, deviceID string, links *events.ResourceLinksSnapshotTaken) error {
+	models := make([]mongo.WriteModel, 0, len(links.GetResources())+1)
+	hrefs := make([]string, 0, len(links.GetResources()))
+	for href, link := range links.GetResources() {
+		data, err := proto.Marshal(link)
+		if err != nil {
+			return err
+		}
+		hrefs = append(hrefs, href)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": commands.NewResourceID(deviceID, href).ToUUID()}).
+			SetUpdate(bson.M{"$set": bson.M{
+				deviceIDKey: deviceID,
+				hrefKey:     href,
+				typesKey:    link.GetResourceTypes(),
+				linkKey:     data,
+			}}).
+			SetUpsert(true))
+	}
+	models = append(models, mongo.NewUpdateManyModel().
+		SetFilter(bson.M{
+			deviceIDKey: deviceID,
+			hrefKey:     bson.M{"$nin": hrefs},
+			linkKey:     bson.M{"$exists": true},
+		}).
+		SetUpdate(bson.M{"$unset": bson.M{
+			typesKey: "",
+			linkKey:  "",
+		}}))
+	_, err := s.Collection(resourcesCName).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// SaveResourceLinks stores the resource links of the device and updates the links of the resources documents in one transaction.
+func (s *Store) SaveResourceLinks(ctx context.Context, deviceID string, links *events.ResourceLinksSnapshotTaken, expected store.Checkpoint, version uint64) (bool, error) {
+	if deviceID == "" {
+		return false, fmt.Errorf("cannot save resource links: invalid deviceID")
+	}
+	saved, err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		saved, err := saveSnapshot(sc, s.Collection(devicesCName), deviceID, linksKey, links, expected, version, bson.M{
+			linksTypesKey: getLinksTypes(links),
+		})
+		if err != nil {
+			return err
+		}
+		if !saved {
+			return errNotSaved
+		}
+		return s.syncResourcesLinks(sc, deviceID, links)
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot save resource links of device %v: %w", deviceID, err)
+	}
+	return saved, nil
+}
+
+func (s *Store) LoadDeviceMetadata(ctx context.Context, deviceID string) (*events.DeviceMetadataSnapshotTaken, store.Checkpoint, error) {
+	d, err := s.loadDevice(ctx, deviceID, metadataKey)
+	if err != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load metadata of device %v: %w", deviceID, err)
+	}
+	metadata := events.NewDeviceMetadataSnapshotTaken()
+	checkpoint, err := d.Metadata.unmarshal(metadata)
+	if err != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load metadata of device %v: %w", deviceID, err)
+	}
+	return metadata, checkpoint, nil
+}
+
+func (s *Store) SaveDeviceMetadata(ctx context.Context, deviceID string, metadata *events.DeviceMetadataSnapshotTaken, expected store.Checkpoint, version uint64) (bool, error) {
+	if deviceID == "" {
+		return false, fmt.Errorf("cannot save device metadata: invalid deviceID")
+	}
+	saved, err := saveSnapshot(ctx, s.Collection(devicesCName), deviceID, metadataKey, metadata, expected, version, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot save metadata of device %v: %w", deviceID, err)
+	}
+	return saved, nil
+}
+
+func (s *Store) loadDevices(ctx context.Context, q bson.M, key string) ([]dbDevice, error) {
+	col := s.Collection(devicesCName)
+	iter, err := col.Find(ctx, q, options.Find().SetProjection(bson.M{key: 1}))
+	if err != nil {
+		return nil, err
+	}
+	var devices []dbDevice
+	if err := iter.All(ctx, &devices); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (s *Store) GetResourceLinks(ctx context.Context, deviceIDs []string, typeFilter []string) ([]*events.ResourceLinksSnapshotTaken, error) {
+	if len(deviceIDs) == 0 {
+		return nil, nil
+	}
+	q := bson.M{
+		"_id":    bson.M{"$in": deviceIDs},
+		linksKey: bson.M{"$exists": true},
+	}
+	if len(typeFilter) > 0 {
+		q[linksTypesKey] = bson.M{"$in": typeFilter}
+	}
+	devices, err := s.loadDevices(ctx, q, linksKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get resource links: %w", err)
+	}
+	result := make([]*events.ResourceLinksSnapshotTaken, 0, len(devices))
+	for _, d := range devices {
+		links := events.NewResourceLinksSnapshotTaken()
+		if _, err := d.Links.unmarshal(links); err != nil {
+			return nil, fmt.Errorf("cannot get resource links of device %v: %w", d.ID, err)
+		}
+		result = append(result, links)
+	}
+	return result, nil
+}
+
+func (s *Store) GetDevicesMetadata(ctx context.Context, deviceIDs []string) ([]*events.DeviceMetadataSnapshotTaken, error) {
+	if len(deviceIDs) == 0 {
+		return nil, nil
+	}
+	devices, err := s.loadDevices(ctx, bson.M{
+		"_id":       bson.M{"$in": deviceIDs},
+		metadataKey: bson.M{"$exists": true},
+	}, metadataKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get devices metadata: %w", err)
+	}
+	result := make([]*events.DeviceMetadataSnapshotTaken, 0, len(devices))
+	for _, d := range devices {
+		metadata := events.NewDeviceMetadataSnapshotTaken()
+		if _, err := d.Metadata.unmarshal(metadata); err != nil {
+			return nil, fmt.Errorf("cannot get metadata of device %v: %w", d.ID, err)
+		}
+		result = append(result, metadata)
+	}
+	return result, nil
+}
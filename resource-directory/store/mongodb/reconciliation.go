@@ -0,0 +1,43 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const reconciliationCName = "reconciliation"
+const reconciliationID = "reconciliation"
+const timestampKey = "timestamp"
+
+type dbReconciliation struct {
+	ID        string `bson:"_id"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+func (s *Store) GetReconciliationTimestamp(ctx context.Context) (int64, error) {
+	res := s.Collection(reconciliationCName).FindOne(ctx, bson.M{"_id": reconciliationID})
+	if res.Err() == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if res.Err() != nil {
+		return 0, fmt.Errorf("cannot load reconciliation timestamp: %w", res.Err())
+	}
+	var r dbReconciliation
+	if err := res.Decode(&r); err != nil {
+		return 0, fmt.Errorf("cannot load reconciliation timestamp: %w", err)
+	}
+	return r.Timestamp, nil
+}
+
+func (s *Store) SetReconciliationTimestamp(ctx context.Context, timestamp int64) error {
+	col := s.Collection(reconciliationCName)
+	_, err := col.UpdateOne(ctx, bson.M{"_id": reconciliationID}, bson.M{"$max": bson.M{timestampKey: timestamp}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("cannot store reconciliation timestamp: %w", err)
+	}
+	return nil
+}
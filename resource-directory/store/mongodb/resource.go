@@ -0,0 +1,142 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+const resourcesCName = "resources"
+const deviceIDKey = "deviceid"
+const hrefKey = "href"
+const typesKey = "types"
+const linkKey = "link"
+const stateKey = "state"
+
+var resourcesDeviceIDTypesQueryIndex = bson.D{
+	{Key: deviceIDKey, Value: 1},
+	{Key: typesKey, Value: 1},
+}
+
+type dbResource struct {
+	ID       string      `bson:"_id"`
+	DeviceID string      `bson:"deviceid"`
+	Href     string      `bson:"href"`
+	Types    []string    `bson:"types,omitempty"`
+	Link     []byte      `bson:"link,omitempty"`
+	State    *dbSnapshot `bson:"state,omitempty"`
+}
+
+func (s *Store) LoadResourceState(ctx context.Context, resourceUUID string) (*events.ResourceStateSnapshotTaken, store.Checkpoint, error) {
+	col := s.Collection(resourcesCName)
+	res := col.FindOne(ctx, bson.M{"_id": resourceUUID}, options.FindOne().SetProjection(bson.M{stateKey: 1}))
+	state := events.NewResourceStateSnapshotTaken()
+	if res.Err() == mongo.ErrNoDocuments {
+		return state, store.Checkpoint{}, nil
+	}
+	if res.Err() != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load state of resource %v: %w", resourceUUID, res.Err())
+	}
+	var r dbResource
+	if err := res.Decode(&r); err != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load state of resource %v: %w", resourceUUID, err)
+	}
+	checkpoint, err := r.State.unmarshal(state)
+	if err != nil {
+		return nil, store.Checkpoint{}, fmt.Errorf("cannot load state of resource %v: %w", resourceUUID, err)
+	}
+	return state, checkpoint, nil
+}
+
+func (s *Store) SaveResourceState(ctx context.Context, deviceID, resourceUUID string, state *events.ResourceStateSnapshotTaken, expected store.Checkpoint, version uint64) (bool, error) {
+	if deviceID == "" {
+		return false, fmt.Errorf("cannot save resource state: invalid deviceID")
+	}
+	if resourceUUID == "" {
+		return false, fmt.Errorf("cannot save resource state: invalid resourceUUID")
+	}
+	saved, err := saveSnapshot(ctx, s.Collection(resourcesCName), resourceUUID, stateKey, state, expected, version, bson.M{
+		deviceIDKey: deviceID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot save state of resource %v: %w", resourceUUID, err)
+	}
+	return saved, nil
+}
+
+func (s *Store) DeleteResources(ctx context.Context, deviceID string) error {
+	if deviceID == "" {
+		return fmt.Errorf("cannot delete resources: invalid deviceID")
+	}
+	if _, err := s.Collection(resourcesCName).DeleteMany(ctx, bson.M{deviceIDKey: deviceID}); err != nil {
+		return fmt.Errorf("cannot delete resources of device %v: %w", deviceID, err)
+	}
+	return nil
+}
+
+func toResourcesQuery(query store.ResourcesQuery) bson.M {
+	or := make(bson.A, 0, 2)
+	if len(query.DeviceIDs) > 0 {
+		or = append(or, bson.M{deviceIDKey: bson.M{"$in": query.DeviceIDs}})
+	}
+	if len(query.ResourceIDs) > 0 {
+		resourceUUIDs := make([]string, 0, len(query.ResourceIDs))
+		for _, resourceID := range query.ResourceIDs {
+			resourceUUIDs = append(resourceUUIDs, resourceID.ToUUID())
+		}
+		or = append(or, bson.M{"_id": bson.M{"$in": resourceUUIDs}})
+	}
+	if len(or) == 0 {
+		return nil
+	}
+	q := bson.M{
+		"$or":   or,
+		linkKey: bson.M{"$exists": true},
+	}
+	if len(query.TypeFilter) > 0 {
+		q[typesKey] = bson.M{"$in": query.TypeFilter}
+	}
+	return q
+}
+
+func (s *Store) GetResources(ctx context.Context, query store.ResourcesQuery) ([]store.Resource, error) {
+	q := toResourcesQuery(query)
+	if q == nil {
+		return nil, nil
+	}
+	col := s.Collection(resourcesCName)
+	iter, err := col.Find(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get resources: %w", err)
+	}
+	var resources []dbResource
+	if err := iter.All(ctx, &resources); err != nil {
+		return nil, fmt.Errorf("cannot get resources: %w", err)
+	}
+	result := make([]store.Resource, 0, len(resources))
+	for _, r := range resources {
+		var link commands.Resource
+		if err := proto.Unmarshal(r.Link, &link); err != nil {
+			return nil, fmt.Errorf("cannot get link of resource %v: %w", r.ID, err)
+		}
+		var state *events.ResourceStateSnapshotTaken
+		if r.State != nil {
+			state = events.NewResourceStateSnapshotTaken()
+			if _, err := r.State.unmarshal(state); err != nil {
+				return nil, fmt.Errorf("cannot get state of resource %v: %w", r.ID, err)
+			}
+		}
+		result = append(result, store.Resource{
+			Link:  &link,
+			State: state,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+const versionKey = "version"
+
+type Store struct {
+	*pkgMongo.Store
+}
+
+var _ store.Store = (*Store)(nil)
+
+func NewStore(ctx context.Context, cfg pkgMongo.Config, tls *tls.Config) (*Store, error) {
+	s, err := pkgMongo.NewStoreWithCollection(ctx, cfg, tls, devicesCName, devicesLinksTypesQueryIndex)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.EnsureIndex(ctx, resourcesCName, resourcesDeviceIDTypesQueryIndex); err != nil {
+		if errClose := s.Close(ctx); errClose != nil {
+			err = fmt.Errorf("%w, %v", err, errClose)
+		}
+		return nil, err
+	}
+	s.SetOnClear(func(c context.Context) error {
+		if err := s.DropCollection(c, resourcesCName); err != nil {
+			return err
+		}
+		if err := s.DropCollection(c, reconciliationCName); err != nil {
+			return err
+		}
+		return s.DropCollection(c, devicesCName)
+	})
+	return &Store{s}, nil
+}
+
+// dbSnapshot is the serialized snapshot of the aggregate with the version of the last applied event.
+type dbSnapshot struct {
+	Version uint64 `bson:"version"`
+	Data    []byte `bson:"data"`
+}
+
+func (s *dbSnapshot) unmarshal(v proto.Message) (store.Checkpoint, error) {
+	if s == nil {
+		return store.Checkpoint{}, nil
+	}
+	if err := proto.Unmarshal(s.Data, v); err != nil {
+		return store.Checkpoint{}, err
+	}
+	return store.Checkpoint{
+		Exists:  true,
+		Version: s.Version,
+	}, nil
+}
+
+// saveSnapshot stores the snapshot of the aggregate under the key of the document with optimistic concurrency control by the version.
+func saveSnapshot(ctx context.Context, col *mongo.Collection, id, key string, v proto.Message, expected store.Checkpoint, version uint64, set bson.M) (bool, error) {
+	data, err := proto.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.M{"_id": id}
+	if expected.Exists {
+		filter[key+"."+versionKey] = expected.Version
+	} else {
+		filter[key] = bson.M{"$exists": false}
+	}
+	if set == nil {
+		set = bson.M{}
+	}
+	set[key] = dbSnapshot{
+		Version: version,
+		Data:    data,
+	}
+	res, err := col.UpdateOne(ctx, filter, bson.M{"$set": set}, options.Update().SetUpsert(!expected.Exists))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			// the document was created concurrently
+			return false, nil
+		}
+		return false, err
+	}
+	return res.MatchedCount > 0 || res.UpsertedCount > 0, nil
+}
+
+// errNotSaved aborts the transaction when the document was modified concurrently.
+var errNotSaved = errors.New("document was modified concurrently")
+
+// withTransaction executes the function in the transaction. The function returns errNotSaved to abort the transaction
+// without the error.
+func (s *Store) withTransaction(ctx context.Context, f func(sc mongo.SessionContext) error) (bool, error) {
+	session, err := s.Client().StartSession()
+	if err != nil {
+		return false, fmt.Errorf("cannot start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, f(sc)
+	})
+	if errors.Is(err, errNotSaved) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,218 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/hub/pkg/log"
+	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
+	"github.com/plgd-dev/hub/pkg/security/certManager/client"
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+	"github.com/plgd-dev/hub/resource-directory/store"
+	"github.com/plgd-dev/hub/resource-directory/store/mongodb"
+	"github.com/plgd-dev/hub/test/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*mongodb.Store, func()) {
+	cfg := pkgMongo.Config{
+		URI:      config.MONGODB_URI,
+		Database: "resourceDirectory",
+		TLS:      config.MakeTLSClientConfig(),
+	}
+	logger, err := log.NewLogger(log.Config{})
+	require.NoError(t, err)
+
+	certManager, err := client.New(cfg.TLS, logger)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	s, err := mongodb.NewStore(ctx, cfg, certManager.GetTLSConfig())
+	require.NoError(t, err)
+
+	return s, func() {
+		err := s.Clear(ctx)
+		require.NoError(t, err)
+		_ = s.Close(ctx)
+		certManager.Close()
+	}
+}
+
+func makeResourceLinks(deviceID string, links ...*commands.Resource) *events.ResourceLinksSnapshotTaken {
+	s := events.NewResourceLinksSnapshotTaken()
+	s.DeviceId = deviceID
+	for _, l := range links {
+		s.GetResources()[l.GetHref()] = l
+	}
+	return s
+}
+
+func TestStoreSaveResourceLinks(t *testing.T) {
+	s, cleanUp := newTestStore(t)
+	defer cleanUp()
+	ctx := context.Background()
+
+	const deviceID = "device"
+	light := &commands.Resource{DeviceId: deviceID, Href: "/light/1", ResourceTypes: []string{"oic.r.light"}}
+	sw := &commands.Resource{DeviceId: deviceID, Href: "/switch/1", ResourceTypes: []string{"oic.r.switch.binary"}}
+
+	_, checkpoint, err := s.LoadResourceLinks(ctx, deviceID)
+	require.NoError(t, err)
+	require.False(t, checkpoint.Exists)
+
+	saved, err := s.SaveResourceLinks(ctx, deviceID, makeResourceLinks(deviceID, light, sw), checkpoint, 1)
+	require.NoError(t, err)
+	require.True(t, saved)
+
+	// stale checkpoint
+	saved, err = s.SaveResourceLinks(ctx, deviceID, makeResourceLinks(deviceID, light), checkpoint, 2)
+	require.NoError(t, err)
+	require.False(t, saved)
+
+	links, checkpoint, err := s.LoadResourceLinks(ctx, deviceID)
+	require.NoError(t, err)
+	require.Equal(t, store.Checkpoint{Exists: true, Version: 1}, checkpoint)
+	require.Len(t, links.GetResources(), 2)
+
+	saved, err = s.SaveResourceLinks(ctx, deviceID, makeResourceLinks(deviceID, light), checkpoint, 2)
+	require.NoError(t, err)
+	require.True(t, saved)
+
+	got, err := s.GetResourceLinks(ctx, []string{deviceID}, []string{"oic.r.switch.binary"})
+	require.NoError(t, err)
+	require.Empty(t, got)
+	got, err = s.GetResourceLinks(ctx, []string{deviceID}, []string{"oic.r.light"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].GetResources(), 1)
+
+	resources, err := s.GetResources(ctx, store.ResourcesQuery{DeviceIDs: []string{deviceID}})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Equal(t, light.GetHref(), resources[0].Link.GetHref())
+	require.Nil(t, resources[0].State)
+}
+
+func TestStoreSaveResourceState(t *testing.T) {
+	s, cleanUp := newTestStore(t)
+	defer cleanUp()
+	ctx := context.Background()
+
+	const deviceID = "device"
+	light := &commands.Resource{DeviceId: deviceID, Href: "/light/1", ResourceTypes: []string{"oic.r.light"}}
+	saved, err := s.SaveResourceLinks(ctx, deviceID, makeResourceLinks(deviceID, light), store.Checkpoint{}, 0)
+	require.NoError(t, err)
+	require.True(t, saved)
+
+	resourceID := commands.NewResourceID(deviceID, light.GetHref())
+	state := events.NewResourceStateSnapshotTaken()
+	state.ResourceId = resourceID
+	state.LatestResourceChange = &events.ResourceChanged{
+		ResourceId: resourceID,
+		Status:     commands.Status_OK,
+	}
+	saved, err = s.SaveResourceState(ctx, deviceID, resourceID.ToUUID(), state, store.Checkpoint{}, 3)
+	require.NoError(t, err)
+	require.True(t, saved)
+
+	resources, err := s.GetResources(ctx, store.ResourcesQuery{ResourceIDs: []*commands.ResourceId{resourceID}})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Equal(t, commands.Status_OK, resources[0].State.GetLatestResourceChange().GetStatus())
+
+	// resources without link are not returned
+	saved, err = s.SaveResourceLinks(ctx, deviceID, makeResourceLinks(deviceID), store.Checkpoint{Exists: true}, 1)
+	require.NoError(t, err)
+	require.True(t, saved)
+	resources, err = s.GetResources(ctx, store.ResourcesQuery{DeviceIDs: []string{deviceID}})
+	require.NoError(t, err)
+	require.Empty(t, resources)
+}
+
+func TestStoreSyncedDevices(t *testing.T) {
+	s, cleanUp := newTestStore(t)
+	defer cleanUp()
+	ctx := context.Background()
+
+	err := s.SetDeviceSynced(ctx, "a")
+	require.NoError(t, err)
+	err = s.SetDeviceSynced(ctx, "c")
+	require.NoError(t, err)
+	synced, err := s.SyncedDevices(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, synced)
+
+	err = s.UnsetDeviceSynced(ctx, "a")
+	require.NoError(t, err)
+	synced, err = s.SyncedDevices(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	require.Empty(t, synced)
+	synced, err = s.GetSyncedDevices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, synced)
+}
+
+func TestStoreDeleteDevices(t *testing.T) {
+	s, cleanUp := newTestStore(t)
+	defer cleanUp()
+	ctx := context.Background()
+
+	saveDevice := func(deviceID string) {
+		light := &commands.Resource{DeviceId: deviceID, Href: "/light/1", ResourceTypes: []string{"oic.r.light"}}
+		saved, err := s.SaveResourceLinks(ctx, deviceID, makeResourceLinks(deviceID, light), store.Checkpoint{}, 0)
+		require.NoError(t, err)
+		require.True(t, saved)
+		resourceID := commands.NewResourceID(deviceID, light.GetHref())
+		saved, err = s.SaveResourceState(ctx, deviceID, resourceID.ToUUID(), events.NewResourceStateSnapshotTaken(), store.Checkpoint{}, 0)
+		require.NoError(t, err)
+		require.True(t, saved)
+		err = s.SetDeviceSynced(ctx, deviceID)
+		require.NoError(t, err)
+	}
+	saveDevice("a")
+	saveDevice("b")
+
+	// the device was registered again
+	err := s.DeleteResources(ctx, "a")
+	require.NoError(t, err)
+	_, checkpoint, err := s.LoadResourceState(ctx, commands.NewResourceID("a", "/light/1").ToUUID())
+	require.NoError(t, err)
+	require.False(t, checkpoint.Exists)
+	resources, err := s.GetResources(ctx, store.ResourcesQuery{DeviceIDs: []string{"a"}})
+	require.NoError(t, err)
+	require.Empty(t, resources)
+
+	err = s.DeleteDevices(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	for _, deviceID := range []string{"a", "b"} {
+		_, checkpoint, err := s.LoadResourceLinks(ctx, deviceID)
+		require.NoError(t, err)
+		require.False(t, checkpoint.Exists)
+		_, checkpoint, err = s.LoadResourceState(ctx, commands.NewResourceID(deviceID, "/light/1").ToUUID())
+		require.NoError(t, err)
+		require.False(t, checkpoint.Exists)
+	}
+	synced, err := s.GetSyncedDevices(ctx)
+	require.NoError(t, err)
+	require.Empty(t, synced)
+}
+
+func TestStoreReconciliationTimestamp(t *testing.T) {
+	s, cleanUp := newTestStore(t)
+	defer cleanUp()
+	ctx := context.Background()
+
+	timestamp, err := s.GetReconciliationTimestamp(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), timestamp)
+
+	err = s.SetReconciliationTimestamp(ctx, 10)
+	require.NoError(t, err)
+	// the older timestamp of another instance doesn't replace the stored one
+	err = s.SetReconciliationTimestamp(ctx, 5)
+	require.NoError(t, err)
+	timestamp, err = s.GetReconciliationTimestamp(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), timestamp)
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+
+	"github.com/plgd-dev/hub/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/resource-aggregate/events"
+)
+
+// Checkpoint is the version of the last event of the aggregate applied to the read model.
+type Checkpoint struct {
+	Exists  bool // false when no event of the aggregate was applied yet
+	Version uint64
+}
+
+// Resource is the published resource with its latest state.
+type Resource struct {
+	Link  *commands.Resource
+	State *events.ResourceStateSnapshotTaken // nil when no event of the resource was applied yet
+}
+
+type ResourcesQuery struct {
+	DeviceIDs   []string               // all resources of the devices
+	ResourceIDs []*commands.ResourceId // specific resources
+	TypeFilter  []string               // an empty filter matches all resource types
+}
+
+// Store is the persistent read model of devices, it contains the resource links, the metadata and the latest state of resources.
+type Store interface {
+	// SyncedDevices returns devices which were loaded from the eventstore.
+	SyncedDevices(ctx context.Context, deviceIDs []string) ([]string, error)
+	SetDeviceSynced(ctx context.Context, deviceID string) error
+	// UnsetDeviceSynced marks the device to be loaded from the eventstore again, eg. when an event of the device cannot be applied.
+	UnsetDeviceSynced(ctx context.Context, deviceID string) error
+	// GetSyncedDevices returns all devices which were loaded from the eventstore.
+	GetSyncedDevices(ctx context.Context) ([]string, error)
+	// DeleteDevices removes the devices with the states of their resources.
+	DeleteDevices(ctx context.Context, deviceIDs []string) error
+	// DeleteResources removes the links and the states of all resources of the device.
+	DeleteResources(ctx context.Context, deviceID string) error
+	// GetReconciliationTimestamp returns the timestamp up to which the events of the eventstore were reconciled with
+	// the synchronized devices, 0 when the reconciliation hasn't been completed yet.
+	GetReconciliationTimestamp(ctx context.Context) (int64, error)
+	// SetReconciliationTimestamp stores the timestamp, when it is greater than the stored one.
+	SetReconciliationTimestamp(ctx context.Context, timestamp int64) error
+
+	LoadResourceLinks(ctx context.Context, deviceID string) (*events.ResourceLinksSnapshotTaken, Checkpoint, error)
+	// SaveResourceLinks stores the snapshot when the checkpoint of the stored snapshot is equal to the expected one. It returns false
+	// if the snapshot was modified concurrently. The links of the resources are updated atomically with the snapshot.
+	SaveResourceLinks(ctx context.Context, deviceID string, s *events.ResourceLinksSnapshotTaken, expected Checkpoint, version uint64) (bool, error)
+	LoadDeviceMetadata(ctx context.Context, deviceID string) (*events.DeviceMetadataSnapshotTaken, Checkpoint, error)
+	SaveDeviceMetadata(ctx context.Context, deviceID string, s *events.DeviceMetadataSnapshotTaken, expected Checkpoint, version uint64) (bool, error)
+	LoadResourceState(ctx context.Context, resourceUUID string) (*events.ResourceStateSnapshotTaken, Checkpoint, error)
+	SaveResourceState(ctx context.Context, deviceID, resourceUUID string, s *events.ResourceStateSnapshotTaken, expected Checkpoint, version uint64) (bool, error)
+
+	// GetResourceLinks returns resource links of devices with at least one resource matching the type filter.
+	GetResourceLinks(ctx context.Context, deviceIDs []string, typeFilter []string) ([]*events.ResourceLinksSnapshotTaken, error)
+	GetDevicesMetadata(ctx context.Context, deviceIDs []string) ([]*events.DeviceMetadataSnapshotTaken, error)
+	GetResources(ctx context.Context, query ResourcesQuery) ([]Resource, error)
+
+	Close(ctx context.Context) error
+}
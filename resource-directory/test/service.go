@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/plgd-dev/hub/pkg/log"
+	pkgMongo "github.com/plgd-dev/hub/pkg/mongodb"
 	"github.com/plgd-dev/hub/resource-directory/service"
 	"github.com/plgd-dev/hub/test/config"
 
@@ -38,6 +39,19 @@ func MakeConfig(t *testing.T) service.Config {
 	return cfg
 }
 
+// MakeReadModelConfig returns the config of the persistent read model stored in the mongoDB.
+func MakeReadModelConfig() service.ReadModelConfig {
+	return service.ReadModelConfig{
+		Enabled:                true,
+		ReconciliationInterval: time.Minute,
+		MongoDB: pkgMongo.Config{
+			URI:      config.MONGODB_URI,
+			Database: "resourceDirectory",
+			TLS:      config.MakeTLSClientConfig(),
+		},
+	}
+}
+
 func SetUp(t *testing.T) (TearDown func()) {
 	return New(t, MakeConfig(t))
 }
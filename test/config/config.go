@@ -17,30 +17,35 @@ import (
 	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
 	"github.com/plgd-dev/hub/pkg/security/oauth2"
 	"github.com/plgd-dev/hub/pkg/security/oauth2/oauth"
+	kafkaClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/kafka/client"
 	natsClient "github.com/plgd-dev/hub/resource-aggregate/cqrs/eventbus/nats/client"
+	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/cqldb"
 	"github.com/plgd-dev/hub/resource-aggregate/cqrs/eventstore/mongodb"
 	"github.com/plgd-dev/hub/test/oauth-server/uri"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	IDENTITY_STORE_HOST        = "localhost:20000"
-	IDENTITY_STORE_DB          = "ownersDevices"
-	GW_HOST                    = "localhost:20002"
-	RESOURCE_AGGREGATE_HOST    = "localhost:20003"
-	RESOURCE_DIRECTORY_HOST    = "localhost:20004"
-	CERTIFICATE_AUTHORITY_HOST = "localhost:20011"
-	GRPC_HOST                  = "localhost:20005"
-	C2C_CONNECTOR_HOST         = "localhost:20006"
-	C2C_CONNECTOR_DB           = "cloud2cloudConnector"
-	C2C_GW_HOST                = "localhost:20007"
-	C2C_GW_DB                  = "cloud2cloudGateway"
-	OAUTH_SERVER_HOST          = "localhost:20009"
-	TEST_TIMEOUT               = time.Second * 30
-	OAUTH_MANAGER_CLIENT_ID    = "test"
-	OAUTH_MANAGER_AUDIENCE     = "localhost"
-	HTTP_GW_HOST               = "localhost:20010"
-	DEVICE_PROVIDER            = "plgd"
+	IDENTITY_STORE_HOST             = "localhost:20000"
+	IDENTITY_STORE_DB               = "ownersDevices"
+	GW_HOST                         = "localhost:20002"
+	RESOURCE_AGGREGATE_HOST         = "localhost:20003"
+	RESOURCE_DIRECTORY_HOST         = "localhost:20004"
+	CERTIFICATE_AUTHORITY_HOST      = "localhost:20011"
+	CERTIFICATE_AUTHORITY_HTTP_HOST = "localhost:20012"
+	CERTIFICATE_AUTHORITY_DB        = "certificateAuthority"
+	GRPC_HOST                       = "localhost:20005"
+	C2C_CONNECTOR_HOST              = "localhost:20006"
+	C2C_CONNECTOR_DB                = "cloud2cloudConnector"
+	C2C_GW_HOST                     = "localhost:20007"
+	C2C_GW_DB                       = "cloud2cloudGateway"
+	OAUTH_SERVER_HOST               = "localhost:20009"
+	TEST_TIMEOUT                    = time.Second * 30
+	OAUTH_MANAGER_CLIENT_ID         = "test"
+	OAUTH_MANAGER_AUDIENCE          = "localhost"
+	HTTP_GW_HOST                    = "localhost:20010"
+	DEVICE_PROVIDER                 = "plgd"
+	TRANSFER_DEVICES_SCOPE          = "admin:devices"
 )
 
 var CA_POOL = os.Getenv("LISTEN_FILE_CA_POOL")
@@ -48,6 +53,8 @@ var KEY_FILE = os.Getenv("LISTEN_FILE_CERT_DIR_PATH") + "/" + os.Getenv("LISTEN_
 var CERT_FILE = os.Getenv("LISTEN_FILE_CERT_DIR_PATH") + "/" + os.Getenv("LISTEN_FILE_CERT_NAME")
 var MONGODB_URI = "mongodb://localhost:27017"
 var NATS_URL = "nats://localhost:4222"
+var KAFKA_BROKER = "localhost:9092"
+var CQL_HOST = "localhost"
 var OWNER_CLAIM = "sub"
 
 var OAUTH_MANAGER_ENDPOINT_AUTHURL = "https://" + OAUTH_SERVER_HOST + uri.Authorize
@@ -138,6 +145,36 @@ func MakeSubscriberConfig() natsClient.Config {
 	}
 }
 
+func makeKafkaConfig() kafkaClient.Config {
+	return kafkaClient.Config{
+		Brokers:     []string{KAFKA_BROKER},
+		DialTimeout: time.Second * 10,
+		Topic: kafkaClient.TopicConfig{
+			Partitions:        4,
+			ReplicationFactor: 1,
+		},
+		TLS: MakeTLSClientConfig(),
+	}
+}
+
+func MakeKafkaPublisherConfig() kafkaClient.ConfigPublisher {
+	return kafkaClient.ConfigPublisher{
+		BatchTimeout: time.Millisecond * 10,
+		Config:       makeKafkaConfig(),
+	}
+}
+
+func MakeKafkaSubscriberConfig() kafkaClient.ConfigSubscriber {
+	return kafkaClient.ConfigSubscriber{
+		ConsumerGroup: kafkaClient.ConsumerGroupConfig{
+			HeartbeatInterval:     time.Second,
+			SessionTimeout:        time.Second * 10,
+			TopicsRefreshInterval: time.Second,
+		},
+		Config: makeKafkaConfig(),
+	}
+}
+
 func MakeEventsStoreMongoDBConfig() mongodb.Config {
 	return mongodb.Config{
 		Embedded: pkgMongo.Config{
@@ -151,6 +188,24 @@ func MakeEventsStoreMongoDBConfig() mongodb.Config {
 	}
 }
 
+func MakeEventsStoreCqlDBConfig() cqldb.Config {
+	return cqldb.Config{
+		Hosts:          []string{CQL_HOST},
+		Port:           9042,
+		NumConnections: 2,
+		ConnectTimeout: time.Second * 10,
+		Keyspace: cqldb.KeyspaceConfig{
+			Name:   "plgdhub",
+			Create: true,
+			Replication: map[string]string{
+				"class":              "SimpleStrategy",
+				"replication_factor": "1",
+			},
+		},
+		TLS: MakeTLSClientConfig(),
+	}
+}
+
 func MakeAuthorizationConfig() validator.Config {
 	return validator.Config{
 		Authority: "https://" + OAUTH_SERVER_HOST,
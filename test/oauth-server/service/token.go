@@ -28,10 +28,13 @@ const (
 	TokenDeviceID    = "https://plgd.dev/deviceId"
 )
 
-func makeAccessToken(clientID, host, deviceID string, issuedAt, expires time.Time) (jwt.Token, error) {
+func makeAccessToken(clientID, host, deviceID, owner string, issuedAt, expires time.Time) (jwt.Token, error) {
 	token := jwt.New()
 
-	if err := token.Set(jwt.SubjectKey, DeviceUserID); err != nil {
+	if owner == "" {
+		owner = DeviceUserID
+	}
+	if err := token.Set(jwt.SubjectKey, owner); err != nil {
 		return nil, fmt.Errorf("failed to set %v: %w", jwt.SubjectKey, err)
 	}
 	if err := token.Set(jwt.AudienceKey, host+"/"); err != nil {
@@ -45,7 +48,7 @@ func makeAccessToken(clientID, host, deviceID string, issuedAt, expires time.Tim
 			return nil, fmt.Errorf("failed to set %v: %w", jwt.ExpirationKey, err)
 		}
 	}
-	if err := token.Set(TokenScopeKey, []string{"openid", "r:deviceinformation:*", "r:resources:*", "w:resources:*", "w:subscriptions:*"}); err != nil {
+	if err := token.Set(TokenScopeKey, []string{"openid", "r:deviceinformation:*", "r:resources:*", "w:resources:*", "w:subscriptions:*", "admin:devices"}); err != nil {
 		return nil, fmt.Errorf("failed to set %v: %w", TokenScopeKey, err)
 	}
 	if err := token.Set(uri.ClientIDKey, clientID); err != nil {
@@ -81,13 +84,13 @@ func makeJWTPayload(key interface{}, jwkKey jwk.Key, data []byte) ([]byte, error
 	return payload, nil
 }
 
-func generateAccessToken(clientID string, lifeTime time.Duration, host, deviceID string, key interface{}, jwkKey jwk.Key) (string, time.Time, error) {
+func generateAccessToken(clientID string, lifeTime time.Duration, host, deviceID, owner string, key interface{}, jwkKey jwk.Key) (string, time.Time, error) {
 	now := time.Now()
 	var expires time.Time
 	if lifeTime > 0 {
 		expires = now.Add(lifeTime)
 	}
-	token, err := makeAccessToken(clientID, host, deviceID, now, expires)
+	token, err := makeAccessToken(clientID, host, deviceID, owner, now, expires)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to make token: %w", err)
 	}
@@ -191,6 +194,7 @@ type tokenRequest struct {
 	Password     string `json:"password"`
 	Audience     string `json:"audience"`
 	RefreshToken string `json:"refresh_token"`
+	Owner        string `json:"owner"` // subject of the access token, DeviceUserID is used when it is empty
 
 	host      string
 	tokenType AccessTokenType
@@ -200,6 +204,7 @@ type tokenRequest struct {
 func (requestHandler *RequestHandler) getToken(w http.ResponseWriter, r *http.Request) {
 	clientID := r.URL.Query().Get(uri.ClientIDKey)
 	audience := r.URL.Query().Get(uri.AudienceKey)
+	owner := r.URL.Query().Get(uri.OwnerKey)
 	var ok bool
 	if clientID == "" {
 		clientID, _, ok = r.BasicAuth()
@@ -212,6 +217,7 @@ func (requestHandler *RequestHandler) getToken(w http.ResponseWriter, r *http.Re
 		ClientID:  clientID,
 		GrantType: string(AllowedGrantType_CLIENT_CREDENTIALS),
 		Audience:  audience,
+		Owner:     owner,
 
 		host:      r.Host,
 		tokenType: AccessTokenType_JWT,
@@ -242,6 +248,7 @@ func (requestHandler *RequestHandler) postToken(w http.ResponseWriter, r *http.R
 		tokenReq.Password = r.PostFormValue(uri.PasswordKey)
 		tokenReq.Audience = r.PostFormValue(uri.AudienceKey)
 		tokenReq.RefreshToken = r.PostFormValue(uri.RefreshTokenKey)
+		tokenReq.Owner = r.PostFormValue(uri.OwnerKey)
 	} else {
 		err := json.ReadFrom(r.Body, &tokenReq)
 		if err != nil {
@@ -291,7 +298,7 @@ func (requestHandler *RequestHandler) getAuthorizedSession(tokenReq tokenRequest
 
 func (requestHandler *RequestHandler) getAccessToken(tokenReq tokenRequest, clientCfg *Client, deviceID string) (string, time.Time, error) {
 	if tokenReq.tokenType == AccessTokenType_JWT {
-		return generateAccessToken(clientCfg.ID, clientCfg.AccessTokenLifetime, tokenReq.host, deviceID, requestHandler.accessTokenKey,
+		return generateAccessToken(clientCfg.ID, clientCfg.AccessTokenLifetime, tokenReq.host, deviceID, tokenReq.Owner, requestHandler.accessTokenKey,
 			requestHandler.accessTokenJwkKey)
 	}
 	accessToken := clientCfg.ID
@@ -153,11 +153,19 @@ func HTTPDo(t *testing.T, req *http.Request, followRedirect bool) *http.Response
 }
 
 func GetServiceToken(t *testing.T, authServerHost, clientId string) string {
+	return GetServiceTokenForOwner(t, authServerHost, clientId, "")
+}
+
+// GetServiceTokenForOwner returns the access token with the owner as the subject, service.DeviceUserID is used for the empty owner.
+func GetServiceTokenForOwner(t *testing.T, authServerHost, clientId, owner string) string {
 	reqBody := map[string]string{
 		"grant_type":    string(service.AllowedGrantType_CLIENT_CREDENTIALS),
 		uri.ClientIDKey: clientId,
 		"audience":      "localhost",
 	}
+	if owner != "" {
+		reqBody[uri.OwnerKey] = owner
+	}
 	d, err := json.Encode(reqBody)
 	require.NoError(t, err)
 
@@ -179,6 +187,11 @@ func GetDefaultServiceToken(t *testing.T) string {
 	return GetServiceToken(t, config.OAUTH_SERVER_HOST, ClientTest)
 }
 
+// GetDefaultServiceTokenForOwner returns the access token of another user than the default one.
+func GetDefaultServiceTokenForOwner(t *testing.T, owner string) string {
+	return GetServiceTokenForOwner(t, config.OAUTH_SERVER_HOST, ClientTest, owner)
+}
+
 func GetDeviceAuthorizationCode(t *testing.T, authServerHost, clientId, deviceID string) string {
 	u, err := url.Parse(uri.Authorize)
 	require.NoError(t, err)
@@ -14,6 +14,7 @@ const (
 	AudienceKey     = "audience"
 	RefreshTokenKey = "refresh_token"
 	DeviceId        = "deviceId"
+	OwnerKey        = "owner"
 	ResponseMode    = "response_mode"
 
 	Token               = "/oauth/token"
@@ -147,6 +147,7 @@ func CleanUpEvent(t *testing.T, ev *pb.Event) {
 	handler, ok := cleanupHandlerFn[GetEventType(ev)]
 	require.True(t, ok)
 
+	ev.ResumeCursor = ""
 	handler(ev)
 }
 
@@ -199,6 +200,17 @@ func CmpEvents(t *testing.T, expected, got []*pb.Event) {
 	require.Len(t, got, len(expected))
 
 	// normalize
+	expectedByID := make(map[string]*pb.Event, len(expected))
+	for _, ev := range expected {
+		if _, ok := expectedByID[GetEventID(ev)]; !ok {
+			expectedByID[GetEventID(ev)] = ev
+		}
+	}
+	for _, ev := range got {
+		if ev.GetResourceChanged() != nil {
+			CleanUpResourceChangedEtag(expectedByID[GetEventID(ev)].GetResourceChanged(), ev.GetResourceChanged())
+		}
+	}
 	for i := range expected {
 		expected[i].SubscriptionId = ""
 		got[i].SubscriptionId = ""
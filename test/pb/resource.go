@@ -100,6 +100,14 @@ func CleanUpResourceChanged(e *events.ResourceChanged) *events.ResourceChanged {
 	return e
 }
 
+// CleanUpResourceChangedEtag removes the ETag of the received event when the expected event doesn't contain it.
+// ETag is generated by the device, so it is compared only when the test expects it.
+func CleanUpResourceChangedEtag(expected, got *events.ResourceChanged) {
+	if got != nil && len(expected.GetEtag()) == 0 {
+		got.Etag = nil
+	}
+}
+
 func CmpResourceChanged(t *testing.T, expected, got *events.ResourceChanged) {
 	require.NotEmpty(t, expected)
 	require.NotEmpty(t, got)
@@ -134,6 +142,7 @@ func CmpResourceChanged(t *testing.T, expected, got *events.ResourceChanged) {
 		expected.GetContent().Data = nil
 	}
 
+	CleanUpResourceChangedEtag(expected, got)
 	CleanUpResourceChanged(expected)
 	CleanUpResourceChanged(got)
 
@@ -309,6 +318,7 @@ func CmpResourceValues(t *testing.T, expected, got []*pb.Resource) {
 		dataGot := getData(t, g)
 		g.Data.Content.Data = nil
 		require.Equal(t, dataExpected, dataGot)
+		CleanUpResourceChangedEtag(e.GetData(), g.GetData())
 		CleanUpResourceChanged(e.GetData())
 		CleanUpResourceChanged(g.GetData())
 		test.CheckProtobufs(t, e, g, test.RequireToCheckFunc(require.Equal))
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grpcgwTest "github.com/plgd-dev/hub/grpc-gateway/test"
+	idService "github.com/plgd-dev/hub/identity-store/test"
+	"github.com/plgd-dev/hub/pkg/security/jwt/validator"
+	rdTest "github.com/plgd-dev/hub/resource-directory/test"
+	"github.com/plgd-dev/hub/test/config"
+	oauthTest "github.com/plgd-dev/hub/test/oauth-server/test"
+)
+
+// ServiceAccountsIssuer issues the tokens of the service accounts in the tests.
+const ServiceAccountsIssuer = "https://serviceaccounts.plgd.dev"
+
+// ServiceAccountsAuthority trusts the tokens of the service accounts signed by the key of the test certificate.
+func ServiceAccountsAuthority() validator.AuthorityConfig {
+	return validator.AuthorityConfig{
+		Authority:     ServiceAccountsIssuer,
+		PublicKeyFile: config.CERT_FILE,
+	}
+}
+
+// SetUpServiceAccounts starts the oauth server, the identity-store issuing the tokens of the service accounts, the resource-directory
+// and the grpc-gateway, which verifies the tokens of the service accounts for each request.
+func SetUpServiceAccounts(ctx context.Context, t *testing.T) (TearDown func()) {
+	ClearDB(ctx, t)
+	oauthShutdown := oauthTest.SetUp(t)
+	idCfg := idService.MakeConfig(t)
+	idCfg.APIs.GRPC.Authorization.Authorities = append(idCfg.APIs.GRPC.Authorization.Authorities, ServiceAccountsAuthority())
+	idCfg.ServiceAccounts.Issuer = ServiceAccountsIssuer
+	idCfg.ServiceAccounts.KeyFile = config.KEY_FILE
+	idCfg.ServiceAccounts.TokenExpiration = time.Hour
+	idShutdown := idService.New(t, idCfg)
+	rdCfg := rdTest.MakeConfig(t)
+	rdCfg.APIs.GRPC.Authorization.Authorities = append(rdCfg.APIs.GRPC.Authorization.Authorities, ServiceAccountsAuthority())
+	rdShutdown := rdTest.New(t, rdCfg)
+	grpcgwCfg := grpcgwTest.MakeConfig(t)
+	grpcgwCfg.APIs.GRPC.Authorization.Authorities = append(grpcgwCfg.APIs.GRPC.Authorization.Authorities, ServiceAccountsAuthority())
+	grpcgwCfg.Clients.IdentityStore.ServiceAccountVerificationInterval = 0
+	grpcShutdown := grpcgwTest.New(t, grpcgwCfg)
+
+	return func() {
+		grpcShutdown()
+		rdShutdown()
+		idShutdown()
+		oauthShutdown()
+	}
+}